@@ -2,13 +2,16 @@
 package main
 
 import (
+	"context"
 	"fantom-api-graphql/cmd/apiserver/build"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/graphql/resolvers"
+	"fantom-api-graphql/internal/grpcapi"
 	"fantom-api-graphql/internal/handlers"
 	"fantom-api-graphql/internal/logger"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/svc"
+	"fantom-api-graphql/internal/tracing"
 	"flag"
 	"log"
 	"net/http"
@@ -24,6 +27,9 @@ type apiServer struct {
 	log          logger.Logger
 	api          resolvers.ApiResolver
 	srv          *http.Server
+	grpc         *grpcapi.Server
+	clients      *handlers.ApiClients
+	drained      chan bool
 	isVersionReq bool
 }
 
@@ -43,16 +49,28 @@ func (app *apiServer) init() {
 	// configure logger based on the configuration
 	app.log = logger.New(app.cfg)
 
-	// make sure to pass logger and config to internals
+	// setup distributed tracing, if enabled
+	tracing.Setup(app.cfg, app.log)
+
+	// make sure to pass logger and config to internals;
+	// each internal logs as a separate module, so the levels can be configured separately
 	repository.SetConfig(app.cfg)
-	repository.SetLogger(app.log)
+	repository.SetLogger(app.log.ModuleLogger(logger.ModuleRepository))
 	resolvers.SetConfig(app.cfg)
-	resolvers.SetLogger(app.log)
+	resolvers.SetLogger(app.log.ModuleLogger(logger.ModuleApi))
 	svc.SetConfig(app.cfg)
-	svc.SetLogger(app.log)
+	svc.SetLogger(app.log.ModuleLogger(logger.ModuleScanner))
+
+	// the API key limits are shared by all the API end-points
+	app.clients = handlers.NewApiClients()
 
 	// make the HTTP server
 	app.makeHttpServer()
+
+	// make the gRPC server, if enabled
+	if app.cfg.Grpc.Enabled {
+		app.grpc = grpcapi.New(app.cfg, app.log, app.clients)
+	}
 }
 
 // run executes the API server function.
@@ -63,19 +81,42 @@ func (app *apiServer) run() {
 		return
 	}
 
+	// the database schema is migrated on the repository start; exit if this is the only thing requested
+	if app.cfg.RepoCommand.MigrateOnly {
+		if repo := repository.R(); repo != nil {
+			app.log.Notice("database migrated")
+			repo.Close()
+		}
+		return
+	}
+
 	// make sure to capture terminate signals
 	app.observeSignals()
 
+	// preload the cache before the requests start to come
+	repository.R().WarmUp(&app.cfg.Cache.WarmUp)
+
 	// run services
 	svc.Manager().Run()
 
+	// start responding to gRPC calls, if enabled
+	if app.grpc != nil {
+		go func() {
+			if err := app.grpc.Run(); err != nil {
+				app.log.Errorf("gRPC server failed; %s", err.Error())
+			}
+		}()
+	}
+
 	// start responding to requests
 	app.log.Infof("welcome to Fantom GraphQL API server")
 	app.log.Infof("listening for requests on %s", app.cfg.Server.BindAddress)
 
-	// listen the interface
+	// listen the interface; wait for the in-flight requests to finish if closed
 	err := app.srv.ListenAndServe()
-	if err != nil {
+	if err == http.ErrServerClosed {
+		<-app.drained
+	} else if err != nil {
 		app.log.Errorf(err.Error())
 	}
 
@@ -106,10 +147,11 @@ func (app *apiServer) makeHttpServer() {
 func (app *apiServer) setupHandlers(mux *http.ServeMux) {
 	// create root resolver
 	app.api = resolvers.New()
+	log := app.log.ModuleLogger(logger.ModuleHttp)
 
 	// setup GraphQL API handler
 	h := http.TimeoutHandler(
-		handlers.Api(app.cfg, app.log, app.api),
+		handlers.Api(app.cfg, log, app.api, app.clients),
 		time.Second*time.Duration(app.cfg.Server.ResolverTimeout),
 		"Service timeout.",
 	)
@@ -117,10 +159,21 @@ func (app *apiServer) setupHandlers(mux *http.ServeMux) {
 	mux.Handle("/graphql", h)
 
 	// setup gas price estimator REST API resolver
-	mux.Handle("/json/gas", handlers.GasPrice(app.log))
+	mux.Handle("/json/gas", handlers.GasPrice(log))
+
+	// setup REST API facade of the common chain data
+	mux.Handle(handlers.RestBlockPath, handlers.RestBlock(log))
+	mux.Handle(handlers.RestTransactionPath, handlers.RestTransaction(log))
+	mux.Handle(handlers.RestAccountPath, handlers.RestAccount(log))
+
+	// setup export of the account history for accounting tools
+	mux.Handle(handlers.ExportAccountPath, handlers.Export(app.cfg, log, app.clients))
 
 	// handle GraphiQL interface
-	mux.Handle("/graphi", handlers.GraphiHandler(app.cfg.Server.DomainAddress, app.log))
+	mux.Handle("/graphi", handlers.GraphiHandler(app.cfg.Server.DomainAddress, log))
+
+	// expose the operational metrics for Prometheus
+	mux.Handle("/metrics", handlers.Metrics())
 }
 
 // observeSignals setups terminate signals observation.
@@ -131,6 +184,7 @@ func (app *apiServer) observeSignals() {
 	// make the signal consumer
 	ts := make(chan os.Signal, 1)
 	signal.Notify(ts, syscall.SIGINT, syscall.SIGTERM)
+	app.drained = make(chan bool)
 
 	// start monitoring
 	go func() {
@@ -139,15 +193,64 @@ func (app *apiServer) observeSignals() {
 
 		// terminate HTTP responder
 		app.log.Notice("closing HTTP server")
+		app.shutdown()
+		close(app.drained)
+	}()
+
+	// reload the configuration on hang up signal
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	go func() {
+		for range hup {
+			app.reload()
+		}
+	}()
+}
+
+// shutdown stops the HTTP server from accepting new requests and waits
+// for the in-flight requests to finish within the configured time limit.
+func (app *apiServer) shutdown() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*time.Duration(app.cfg.Server.ShutdownTimeout))
+	defer cancel()
+
+	if err := app.srv.Shutdown(ctx); err != nil {
+		app.log.Errorf("in-flight requests not finished; %s", err.Error())
 		if err := app.srv.Close(); err != nil {
 			app.log.Errorf("could not terminate HTTP listener")
 			os.Exit(0)
 		}
-	}()
+	}
+	app.log.Notice("HTTP server closed")
+}
+
+// reload applies the tunable options of the re-loaded configuration without restarting
+// the server, so the warmed cache and the scanner position are kept.
+func (app *apiServer) reload() {
+	cfg, err := config.Reload()
+	if err != nil {
+		app.log.Errorf("can not reload configuration; %s", err.Error())
+		return
+	}
+
+	// the repository compares the new options with the current ones
+	if repo := repository.R(); repo != nil {
+		repo.Reconfigure(cfg)
+	}
+	app.cfg.Update(cfg)
+	logger.SetLevels(app.cfg)
+
+	app.log.Notice("configuration reloaded")
 }
 
 // terminate modules of the API server.
 func (app *apiServer) terminate() {
+	// stop the gRPC server
+	if app.grpc != nil {
+		app.log.Notice("closing gRPC server")
+		app.grpc.Close()
+	}
+
 	// close resolvers
 	app.log.Notice("closing resolver")
 	app.api.Close()
@@ -163,4 +266,7 @@ func (app *apiServer) terminate() {
 	if repo := repository.R(); repo != nil {
 		repo.Close()
 	}
+
+	// flush pending trace spans
+	tracing.Close(app.log)
 }
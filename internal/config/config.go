@@ -3,6 +3,7 @@ package config
 
 import (
 	"crypto/ecdsa"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -31,6 +32,21 @@ type Config struct {
 	// Cache configuration
 	Cache Cache `mapstructure:"cache"`
 
+	// Tracing configuration
+	Tracing Tracing `mapstructure:"tracing"`
+
+	// Watch represents the address watch webhooks configuration
+	Watch Watch `mapstructure:"watch"`
+
+	// Stream represents the processed data streaming configuration
+	Stream Stream `mapstructure:"stream"`
+
+	// NftMetadata represents the NFT metadata fetcher configuration
+	NftMetadata NftMetadata `mapstructure:"nft_metadata"`
+
+	// Grpc represents the gRPC API server configuration
+	Grpc Grpc `mapstructure:"grpc"`
+
 	// Cache configuration
 	Compiler Compiler `mapstructure:"compiler"`
 
@@ -57,26 +73,84 @@ type Config struct {
 
 	// ReScanBlocks represents the number of blocks to be re-scanned.
 	RepoCommand RepoCmd `mapstructure:"cmd"`
+
+	// tunables keeps the current set of the options changed on the fly, see Tunable.
+	tunables atomic.Value
 }
 
 // RepoCmd represents a repository command configuration.
 type RepoCmd struct {
 	BlockScanReScan uint64
+	ScanWorkers     int
 	RestoreStake    string
+	ReindexFrom     uint64
+	ReindexTo       uint64
+	MigrateOnly     bool
 }
 
 // Server represents the GraphQL server configuration
 type Server struct {
-	BindAddress     string   `mapstructure:"bind"`
-	DomainAddress   string   `mapstructure:"domain"`
-	Origin          string   `mapstructure:"origin"`
-	Peers           []string `mapstructure:"peers"`
-	CorsOrigin      []string `mapstructure:"cors_origins"`
-	ReadTimeout     int64    `mapstructure:"read_timeout"`
-	WriteTimeout    int64    `mapstructure:"write_timeout"`
-	IdleTimeout     int64    `mapstructure:"idle_timeout"`
-	HeaderTimeout   int64    `mapstructure:"header_timeout"`
-	ResolverTimeout int64    `mapstructure:"resolver_timeout"`
+	BindAddress     string           `mapstructure:"bind"`
+	DomainAddress   string           `mapstructure:"domain"`
+	Origin          string           `mapstructure:"origin"`
+	Peers           []string         `mapstructure:"peers"`
+	CorsOrigin      []string         `mapstructure:"cors_origins"`
+	CorsMethods     []string         `mapstructure:"cors_methods"`
+	CorsMaxAge      int              `mapstructure:"cors_max_age"`
+	StrictMode      bool             `mapstructure:"strict_mode"`
+	ReadTimeout     int64            `mapstructure:"read_timeout"`
+	WriteTimeout    int64            `mapstructure:"write_timeout"`
+	IdleTimeout     int64            `mapstructure:"idle_timeout"`
+	HeaderTimeout   int64            `mapstructure:"header_timeout"`
+	ResolverTimeout int64            `mapstructure:"resolver_timeout"`
+	ShutdownTimeout int64            `mapstructure:"shutdown_timeout"`
+	MaxQueryDepth   int              `mapstructure:"max_query_depth"`
+	MaxQueryCost    int              `mapstructure:"max_query_cost"`
+	ExportMaxRows   int              `mapstructure:"export_max_rows"`
+	ApiKeys         ApiKeys          `mapstructure:"api_keys"`
+	Queries         PersistedQueries `mapstructure:"persisted_queries"`
+	Audit           AuditLog         `mapstructure:"audit"`
+	Compression     Compression      `mapstructure:"compression"`
+	Auth            Auth             `mapstructure:"auth"`
+}
+
+// ApiKeys represents the API access keys configuration.
+type ApiKeys struct {
+	Required      bool   `mapstructure:"required"`
+	AnonymousRate int    `mapstructure:"anonymous_rate"`
+	AdminToken    string `mapstructure:"admin_token"`
+}
+
+// PersistedQueries represents the persisted GraphQL queries configuration.
+type PersistedQueries struct {
+	Enabled   bool   `mapstructure:"enabled"`
+	AllowList string `mapstructure:"allow_list"`
+	Strict    bool   `mapstructure:"strict"`
+}
+
+// AuditLog represents the executed GraphQL operations audit log configuration.
+type AuditLog struct {
+	Enabled bool  `mapstructure:"enabled"`
+	Size    int64 `mapstructure:"size"`
+}
+
+// Auth represents the JWT bearer authentication configuration.
+type Auth struct {
+	Enabled    bool     `mapstructure:"enabled"`
+	Issuer     string   `mapstructure:"issuer"`
+	Audience   string   `mapstructure:"audience"`
+	Secret     string   `mapstructure:"secret"`
+	KeyFiles   []string `mapstructure:"key_files"`
+	RolesClaim string   `mapstructure:"roles_claim"`
+	WriteRole  string   `mapstructure:"write_role"`
+	AdminRole  string   `mapstructure:"admin_role"`
+}
+
+// Compression represents the API responses compression configuration.
+type Compression struct {
+	Enabled      bool     `mapstructure:"enabled"`
+	MinSize      int      `mapstructure:"min_size"`
+	ContentTypes []string `mapstructure:"content_types"`
 }
 
 // ServerSignature represents the signature used by this server
@@ -90,34 +164,130 @@ type ServerSignature struct {
 type Log struct {
 	Level  string `mapstructure:"level"`
 	Format string `mapstructure:"format"`
+
+	// Modules maps module names to their logging levels;
+	// modules not listed use the global level.
+	Modules map[string]string `mapstructure:"modules"`
 }
 
 // Lachesis represents the Lachesis node access configuration
 type Lachesis struct {
-	Url string `mapstructure:"url"`
+	Url         string   `mapstructure:"url"`
+	Backup      []string `mapstructure:"backup"`
+	LoadBalance bool     `mapstructure:"balance"`
+
+	// Multicall represents the address of a Multicall2 compatible contract
+	// used to batch contract reads; empty address disables the batching.
+	Multicall common.Address `mapstructure:"multicall"`
 }
 
 // Database represents the database access configuration.
 type Database struct {
+	Type   string `mapstructure:"type"`
 	Url    string `mapstructure:"url"`
 	DbName string `mapstructure:"db"`
+
+	// BatchSize represents the max number of scanner writes sent to the database
+	// in a single bulk write; zero disables the batching.
+	BatchSize int `mapstructure:"batch_size"`
+
+	// FlushInterval represents the max time a batched write waits for the bulk write.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+
+	// ReadPreference represents the read preference mode of the heavy list queries,
+	// e.g. "secondaryPreferred"; the scanner writes always go to the primary.
+	ReadPreference string `mapstructure:"read_preference"`
+
+	// ReadConcern represents the read concern level of the heavy list queries,
+	// e.g. "local" or "majority"; empty value keeps the server default.
+	ReadConcern string `mapstructure:"read_concern"`
 }
 
 // Cache represents the cache sub-system configuration.
 type Cache struct {
+	Type     string        `mapstructure:"type"`
 	Eviction time.Duration `mapstructure:"eviction"`
 	MaxSize  int           `mapstructure:"size"`
+	RedisUrl string        `mapstructure:"redis"`
+	WarmUp   CacheWarmUp   `mapstructure:"warmup"`
+
+	// NotFoundTTL represents the time the not found blocks, transactions
+	// and accounts are remembered; zero disables the negative caching.
+	NotFoundTTL time.Duration `mapstructure:"not_found_ttl"`
+
+	// Entities maps cached entities, i.e. blocks, transactions, accounts
+	// and tokens, to their own cache policy; entities not listed share
+	// the default cache.
+	Entities map[string]CacheEntity `mapstructure:"entities"`
+}
+
+// CacheEntity represents the cache policy of a cached entity;
+// options not set are inherited from the default cache policy.
+type CacheEntity struct {
+	Eviction time.Duration `mapstructure:"eviction"`
+	MaxSize  int           `mapstructure:"size"`
+}
+
+// CacheWarmUp represents the cache warm-up configuration, the amounts
+// of entities preloaded into the cache before the API server starts
+// to serve requests; zero disables the warm-up of the entity.
+type CacheWarmUp struct {
+	Blocks   int   `mapstructure:"blocks"`
+	Accounts int32 `mapstructure:"accounts"`
+	Tokens   int32 `mapstructure:"tokens"`
+	Stakers  bool  `mapstructure:"stakers"`
+}
+
+// Tracing represents the distributed tracing configuration.
+type Tracing struct {
+	Enabled  bool    `mapstructure:"enabled"`
+	Endpoint string  `mapstructure:"endpoint"`
+	Sample   float64 `mapstructure:"sample"`
+}
+
+// Watch represents the address watch webhooks configuration.
+type Watch struct {
+	Enabled bool          `mapstructure:"enabled"`
+	Workers int           `mapstructure:"workers"`
+	Retries int           `mapstructure:"retries"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Stream represents the configuration of processed data streaming to a message broker.
+type Stream struct {
+	Enabled bool     `mapstructure:"enabled"`
+	Type    string   `mapstructure:"type"`
+	Brokers []string `mapstructure:"brokers"`
+	Prefix  string   `mapstructure:"prefix"`
+}
+
+// NftMetadata represents the configuration of the NFT metadata fetcher.
+type NftMetadata struct {
+	Enabled      bool          `mapstructure:"enabled"`
+	Workers      int           `mapstructure:"workers"`
+	Gateway      string        `mapstructure:"ipfs_gateway"`
+	MaxSize      int64         `mapstructure:"max_size"`
+	Timeout      time.Duration `mapstructure:"timeout"`
+	RefreshAfter time.Duration `mapstructure:"refresh_after"`
+}
+
+// Grpc represents the gRPC API server configuration.
+type Grpc struct {
+	Enabled     bool   `mapstructure:"enabled"`
+	BindAddress string `mapstructure:"bind"`
 }
 
 // Compiler represents the contract compilers configuration.
 type Compiler struct {
-	CompilerTempPath       string `mapstructure:"temp"`
-	DefaultSolCompilerPath string `mapstructure:"sol"`
+	CompilerTempPath       string            `mapstructure:"temp"`
+	DefaultSolCompilerPath string            `mapstructure:"sol"`
+	SolCompilers           map[string]string `mapstructure:"sol_versions"`
 }
 
 // Repository represents the repository configuration.
 type Repository struct {
-	MonitorStakers bool `mapstructure:"stakers"`
+	MonitorStakers     bool `mapstructure:"stakers"`
+	TraceInternalCalls bool `mapstructure:"trace"`
 }
 
 // Staking represents the PoS Staking module configuration.
@@ -126,6 +296,12 @@ type Staking struct {
 	StiContract         common.Address `mapstructure:"sti"`
 	TokenizerContract   common.Address `mapstructure:"tokenizer"`
 	TokenizedStakeToken common.Address `mapstructure:"token"`
+
+	// FeeBurnShare represents the share of transaction fees burned by the network.
+	FeeBurnShare float64 `mapstructure:"fee_burn_share"`
+
+	// FeeBurnFrom represents the first block the transaction fees are burned in.
+	FeeBurnFrom uint64 `mapstructure:"fee_burn_from"`
 }
 
 // DeFi represents the DeFi and financial contracts configuration.
@@ -134,6 +310,13 @@ type DeFi struct {
 	Uniswap      DeFiUniswap `mapstructure:"uniswap"`
 	FLend        DeFiFLend   `mapstructure:"flend"`
 	PriceSymbols []string    `mapstructure:"symbols"`
+
+	// PriceOracles maps target price symbols to Chainlink style
+	// aggregator contracts providing FTM price in the symbol units.
+	PriceOracles map[string]common.Address `mapstructure:"oracles"`
+
+	// PriceMaxAge represents the age of a price after which the price is considered stale.
+	PriceMaxAge time.Duration `mapstructure:"price_max_age"`
 }
 
 // DeFiFMint represents the fMint DeFi module configuration.
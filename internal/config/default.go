@@ -29,6 +29,24 @@ const (
 	defIdleTimeout     = 1
 	defHeaderTimeout   = 1
 	defResolverTimeout = 30
+	defShutdownTimeout = 30
+
+	// default limits of the query complexity; zero disables the limit
+	defMaxQueryDepth = 15
+	defMaxQueryCost  = 50000
+
+	// defExportMaxRows represents the default max number of rows of a single account history export;
+	// the export must fit into the server write timeout
+	defExportMaxRows = 10000
+
+	// defAuditSize represents the default max size of the operations audit log in bytes
+	defAuditSize = 64 << 20
+
+	// defCompressionMinSize represents the default min size of a response to be compressed in bytes
+	defCompressionMinSize = 1024
+
+	// defCorsMaxAge represents the default time the CORS preflight response is cached by clients in seconds
+	defCorsMaxAge = 300
 
 	// defServerDomain holds default API server domain address
 	defServerDomain = "localhost:16761"
@@ -50,12 +68,81 @@ const (
 	// defMongoDatabase holds the default name of the API persistent database
 	defMongoDatabase = "fantom"
 
+	// DbTypeMongo represents the MongoDB persistent storage type
+	DbTypeMongo = "mongo"
+
+	// defDbBatchSize represents the default max number of scanner writes in a single bulk write
+	defDbBatchSize = 500
+
+	// defDbFlushInterval represents the default max time a batched write waits for the bulk write
+	defDbFlushInterval = time.Second
+
+	// defDbReadPreference represents the default read preference mode of the list queries
+	defDbReadPreference = "primary"
+
 	// defCacheEvictionTime holds default time for in-memory eviction periods
 	defCacheEvictionTime = 15 * time.Minute
 
 	// defCacheMax size represents the default max size of the cache in MB
 	defCacheMaxSize = 4096
 
+	// defCacheType represents the default type of the cache, the local in-memory cache
+	defCacheType = "memory"
+
+	// defCacheRedisUrl holds default Redis connection string used by the shared cache
+	defCacheRedisUrl = "redis://localhost:6379/0"
+
+	// defCacheNotFoundTTL represents the default time the not found lookups are remembered
+	defCacheNotFoundTTL = 10 * time.Second
+
+	// defCacheWarmBlocks represents the default number of the latest blocks preloaded into the cache
+	defCacheWarmBlocks = 50
+
+	// defCacheWarmAccounts represents the default number of the top accounts preloaded into the cache
+	defCacheWarmAccounts = 100
+
+	// defCacheWarmTokens represents the default number of the most active tokens preloaded into the cache
+	defCacheWarmTokens = 100
+
+	// defTracingEndpoint holds default Jaeger collector endpoint receiving the trace spans
+	defTracingEndpoint = "http://localhost:14268/api/traces"
+
+	// defTracingSample represents the default ratio of API requests traced
+	defTracingSample = 1.0
+
+	// defWatchWorkers represents the default number of parallel webhook deliveries
+	defWatchWorkers = 4
+
+	// defWatchRetries represents the default number of webhook delivery attempts
+	defWatchRetries = 5
+
+	// defWatchTimeout represents the default time limit of a single webhook delivery attempt
+	defWatchTimeout = 10 * time.Second
+
+	// defNftMetadataWorkers represents the default number of parallel NFT metadata downloads
+	defNftMetadataWorkers = 4
+
+	// defNftMetadataGateway represents the default IPFS HTTP gateway used to resolve ipfs:// URIs
+	defNftMetadataGateway = "https://ipfs.io/ipfs/"
+
+	// defNftMetadataMaxSize represents the default max size of an NFT metadata document in bytes
+	defNftMetadataMaxSize = 256 * 1024
+
+	// defNftMetadataTimeout represents the default time limit of a single NFT metadata download
+	defNftMetadataTimeout = 10 * time.Second
+
+	// defNftMetadataRefreshAfter represents the default age after which NFT metadata are downloaded again
+	defNftMetadataRefreshAfter = 24 * time.Hour
+
+	// defStreamType represents the default type of the streaming message broker
+	defStreamType = "kafka"
+
+	// defStreamPrefix represents the default prefix of the streamed topics
+	defStreamPrefix = "fantom."
+
+	// defGrpcBind holds default address the gRPC API server listens on
+	defGrpcBind = "0.0.0.0:16762"
+
 	// defSolCompilerPath represents the default SOL compiler path
 	defSolCompilerPath = "/usr/bin/solc"
 
@@ -65,6 +152,9 @@ const (
 	// defSfcContract is the default address of the SFC contract
 	defSfcContract = "0xFC00FACE00000000000000000000000000000000"
 
+	// defFeeBurnShare represents the default share of transaction fees burned by the network
+	defFeeBurnShare = 0.2
+
 	// defStiContract holds deployment address of the Staker Info smart contract.
 	defStiContract = "0x92ffad75b8a942d149621a39502cdd8ad1dd57b4"
 
@@ -77,19 +167,31 @@ const (
 	// defDefiFMintAddressProvider represents the address of the fMintAddressProvider
 	defDefiUniswapRouter = EmptyAddress
 
+	// defDefiPriceMaxAge represents the default age of a price to be considered stale
+	defDefiPriceMaxAge = 10 * time.Minute
+
 	// defTokenLogoFilePath represents the default path to the tokens map file
 	defTokenLogoFilePath = "tokens.json"
 
 	// defBlockScanRescanDepth represents the amount of blocks re-scanned on server start
 	defBlockScanRescanDepth = 200
+
+	// defBlockScanWorkers represents the default number of parallel workers of each block processing stage
+	defBlockScanWorkers = 4
 )
 
 // default list of API peers
 var defApiPeers = []string{"https://localhost:16761/api"}
 
+// defStreamBrokers holds the default list of streaming message brokers.
+var defStreamBrokers = []string{"localhost:9092"}
+
 // defCorsAllowOrigins holds CORS default allowed origins.
 var defCorsAllowOrigins = []string{"*"}
 
+// defCorsAllowMethods holds CORS default allowed methods.
+var defCorsAllowMethods = []string{"HEAD", "GET", "POST"}
+
 // default list of API peers
 var defVotingSources = make([]string, 0)
 
@@ -109,8 +211,14 @@ func applyDefaults(cfg *viper.Viper) {
 	cfg.SetDefault(keyLoggingLevel, defLoggingLevel)
 	cfg.SetDefault(keyLoggingFormat, defLoggingFormat)
 	cfg.SetDefault(keyLachesisUrl, defLachesisUrl)
+	cfg.SetDefault(keyLachesisMulticall, EmptyAddress)
 	cfg.SetDefault(keyMongoUrl, defMongoUrl)
 	cfg.SetDefault(keyMongoDatabase, defMongoDatabase)
+	cfg.SetDefault(keyDbType, DbTypeMongo)
+	cfg.SetDefault(keyDbBatchSize, defDbBatchSize)
+	cfg.SetDefault(keyDbFlush, defDbFlushInterval)
+	cfg.SetDefault(keyDbReadPref, defDbReadPreference)
+	cfg.SetDefault(keyDbReadConcern, "")
 	cfg.SetDefault(keySolCompilerPath, defSolCompilerPath)
 	cfg.SetDefault(keyApiPeers, defApiPeers)
 	cfg.SetDefault(keyApiStateOrigin, defApiStateOrigin)
@@ -120,6 +228,42 @@ func applyDefaults(cfg *viper.Viper) {
 	// in-memory cache
 	cfg.SetDefault(keyCacheEvictionTime, defCacheEvictionTime)
 	cfg.SetDefault(keyCacheMaxSize, defCacheMaxSize)
+	cfg.SetDefault(keyCacheType, defCacheType)
+	cfg.SetDefault(keyCacheWarmBlocks, defCacheWarmBlocks)
+	cfg.SetDefault(keyCacheWarmAccounts, defCacheWarmAccounts)
+	cfg.SetDefault(keyCacheWarmTokens, defCacheWarmTokens)
+	cfg.SetDefault(keyCacheWarmStakers, true)
+	cfg.SetDefault(keyCacheNotFoundTTL, defCacheNotFoundTTL)
+	cfg.SetDefault(keyCacheRedisUrl, defCacheRedisUrl)
+
+	// distributed tracing
+	cfg.SetDefault(keyTracingEnabled, false)
+	cfg.SetDefault(keyTracingEndpoint, defTracingEndpoint)
+	cfg.SetDefault(keyTracingSample, defTracingSample)
+
+	// address watch webhooks
+	cfg.SetDefault(keyWatchEnabled, false)
+	cfg.SetDefault(keyWatchWorkers, defWatchWorkers)
+	cfg.SetDefault(keyWatchRetries, defWatchRetries)
+	cfg.SetDefault(keyWatchTimeout, defWatchTimeout)
+
+	// NFT metadata fetcher
+	cfg.SetDefault(keyNftMetadataEnabled, false)
+	cfg.SetDefault(keyNftMetadataWorkers, defNftMetadataWorkers)
+	cfg.SetDefault(keyNftMetadataGateway, defNftMetadataGateway)
+	cfg.SetDefault(keyNftMetadataMaxSize, defNftMetadataMaxSize)
+	cfg.SetDefault(keyNftMetadataTimeout, defNftMetadataTimeout)
+	cfg.SetDefault(keyNftMetadataRefreshAfter, defNftMetadataRefreshAfter)
+
+	// processed data streaming
+	cfg.SetDefault(keyStreamEnabled, false)
+	cfg.SetDefault(keyStreamType, defStreamType)
+	cfg.SetDefault(keyStreamBrokers, defStreamBrokers)
+	cfg.SetDefault(keyStreamPrefix, defStreamPrefix)
+
+	// gRPC API server
+	cfg.SetDefault(keyGrpcEnabled, false)
+	cfg.SetDefault(keyGrpcBind, defGrpcBind)
 
 	// server timeouts
 	cfg.SetDefault(keyTimeoutRead, defReadTimeout)
@@ -127,21 +271,55 @@ func applyDefaults(cfg *viper.Viper) {
 	cfg.SetDefault(keyTimeoutHeader, defHeaderTimeout)
 	cfg.SetDefault(keyTimeoutIdle, defIdleTimeout)
 	cfg.SetDefault(keyTimeoutResolver, defResolverTimeout)
+	cfg.SetDefault(keyTimeoutShutdown, defShutdownTimeout)
+
+	// query complexity limits
+	cfg.SetDefault(keyMaxQueryDepth, defMaxQueryDepth)
+	cfg.SetDefault(keyMaxQueryCost, defMaxQueryCost)
+	cfg.SetDefault(keyExportMaxRows, defExportMaxRows)
+
+	// API keys are optional, anonymous access is not limited by default
+	cfg.SetDefault(keyApiKeysRequired, false)
+	cfg.SetDefault(keyApiKeysAnonymousRate, 0)
+
+	// automatic persisted queries are supported, any query can be executed
+	cfg.SetDefault(keyPersistedQueriesEnabled, true)
+	cfg.SetDefault(keyPersistedQueriesStrict, false)
+
+	// operations audit log is off by default
+	cfg.SetDefault(keyAuditEnabled, false)
+	cfg.SetDefault(keyAuditSize, defAuditSize)
+
+	// JSON responses are compressed
+	cfg.SetDefault(keyCompressionEnabled, true)
+	cfg.SetDefault(keyCompressionMinSize, defCompressionMinSize)
+	cfg.SetDefault(keyCompressionTypes, []string{"application/json"})
+
+	// JWT authentication is off, mutations are open as they used to be
+	cfg.SetDefault(keyAuthEnabled, false)
+	cfg.SetDefault(keyAuthRolesClaim, "roles")
+	cfg.SetDefault(keyAuthWriteRole, "writer")
+	cfg.SetDefault(keyAuthAdminRole, "admin")
 
 	// no voting sources by default
 	cfg.SetDefault(keyVotingSources, defVotingSources)
 
 	// cors
 	cfg.SetDefault(keyCorsAllowOrigins, defCorsAllowOrigins)
+	cfg.SetDefault(keyCorsAllowMethods, defCorsAllowMethods)
+	cfg.SetDefault(keyCorsMaxAge, defCorsMaxAge)
+	cfg.SetDefault(keyStrictMode, false)
 
 	// staking configuration defaults
 	cfg.SetDefault(keyStakingSfcContract, defSfcContract)
 	cfg.SetDefault(keyStakingStiContract, defStiContract)
 	cfg.SetDefault(keyStakingTokenizerContract, EmptyAddress)
 	cfg.SetDefault(keyStakingERC20Token, EmptyAddress)
+	cfg.SetDefault(keyStakingFeeBurnShare, defFeeBurnShare)
 
 	// DeFi configuration
 	cfg.SetDefault(keyDefiFMintAddressProvider, defDefiFMintAddressProvider)
 	cfg.SetDefault(keyDefiUniswapCore, defDefiUniswapCore)
 	cfg.SetDefault(keyDefiUniswapRouter, defDefiUniswapRouter)
+	cfg.SetDefault(keyDefiPriceMaxAge, defDefiPriceMaxAge)
 }
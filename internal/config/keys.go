@@ -12,6 +12,10 @@ const (
 	keyConfigCmdBlockScanEnd    = "cmd.blk_to"
 	keyConfigCmdBlockScanReScan = "cmd.rescan"
 	keyConfigCmdRestoreStake    = "cmd.fix_stake"
+	keyConfigCmdScanWorkers     = "cmd.workers"
+	keyConfigCmdReindexFrom     = "reindex"
+	keyConfigCmdReindexTo       = "reindex_to"
+	keyConfigCmdMigrateOnly     = "migrate-only"
 
 	// server related keys
 	keyBindAddress      = "server.bind"
@@ -19,6 +23,9 @@ const (
 	keyApiPeers         = "server.peers"
 	keyApiStateOrigin   = "server.origin"
 	keyCorsAllowOrigins = "server.cors_origins"
+	keyCorsAllowMethods = "server.cors_methods"
+	keyCorsMaxAge       = "server.cors_max_age"
+	keyStrictMode       = "server.strict_mode"
 
 	// server time out related keys
 	keyTimeoutRead     = "server.read_timeout"
@@ -26,6 +33,35 @@ const (
 	keyTimeoutIdle     = "server.idle_timeout"
 	keyTimeoutHeader   = "server.header_timeout"
 	keyTimeoutResolver = "server.resolver_timeout"
+	keyTimeoutShutdown = "server.shutdown_timeout"
+
+	// query complexity limits
+	keyMaxQueryDepth = "server.max_query_depth"
+	keyMaxQueryCost  = "server.max_query_cost"
+	keyExportMaxRows = "server.export_max_rows"
+
+	// API access keys options
+	keyApiKeysRequired      = "server.api_keys.required"
+	keyApiKeysAnonymousRate = "server.api_keys.anonymous_rate"
+
+	// persisted queries options
+	keyPersistedQueriesEnabled = "server.persisted_queries.enabled"
+	keyPersistedQueriesStrict  = "server.persisted_queries.strict"
+
+	// operations audit log options
+	keyAuditEnabled = "server.audit.enabled"
+	keyAuditSize    = "server.audit.size"
+
+	// responses compression options
+	keyCompressionEnabled = "server.compression.enabled"
+	keyCompressionMinSize = "server.compression.min_size"
+	keyCompressionTypes   = "server.compression.content_types"
+
+	// JWT bearer authentication options
+	keyAuthEnabled    = "server.auth.enabled"
+	keyAuthRolesClaim = "server.auth.roles_claim"
+	keyAuthWriteRole  = "server.auth.write_role"
+	keyAuthAdminRole  = "server.auth.admin_role"
 
 	// API server signature related keys
 	keySignatureAddress    = "me.address"
@@ -36,15 +72,57 @@ const (
 	keyLoggingFormat = "log.format"
 
 	// node connection related options
-	keyLachesisUrl = "lachesis.url"
+	keyLachesisUrl       = "node.url"
+	keyLachesisMulticall = "node.multicall"
 
 	// off-chain database related options
 	keyMongoUrl      = "db.url"
 	keyMongoDatabase = "db.db"
+	keyDbType        = "db.type"
+	keyDbBatchSize   = "db.batch_size"
+	keyDbFlush       = "db.flush_interval"
+	keyDbReadPref    = "db.read_preference"
+	keyDbReadConcern = "db.read_concern"
 
 	// cache related options
 	keyCacheEvictionTime = "cache.eviction"
 	keyCacheMaxSize      = "cache.size"
+	keyCacheType         = "cache.type"
+	keyCacheRedisUrl     = "cache.redis"
+	keyCacheWarmBlocks   = "cache.warmup.blocks"
+	keyCacheWarmAccounts = "cache.warmup.accounts"
+	keyCacheWarmTokens   = "cache.warmup.tokens"
+	keyCacheWarmStakers  = "cache.warmup.stakers"
+	keyCacheNotFoundTTL  = "cache.not_found_ttl"
+
+	// distributed tracing options
+	keyTracingEnabled  = "tracing.enabled"
+	keyTracingEndpoint = "tracing.endpoint"
+	keyTracingSample   = "tracing.sample"
+
+	// address watch webhooks options
+	keyWatchEnabled = "watch.enabled"
+	keyWatchWorkers = "watch.workers"
+	keyWatchRetries = "watch.retries"
+	keyWatchTimeout = "watch.timeout"
+
+	// NFT metadata fetcher options
+	keyNftMetadataEnabled      = "nft_metadata.enabled"
+	keyNftMetadataWorkers      = "nft_metadata.workers"
+	keyNftMetadataGateway      = "nft_metadata.ipfs_gateway"
+	keyNftMetadataMaxSize      = "nft_metadata.max_size"
+	keyNftMetadataTimeout      = "nft_metadata.timeout"
+	keyNftMetadataRefreshAfter = "nft_metadata.refresh_after"
+
+	// processed data streaming options
+	keyStreamEnabled = "stream.enabled"
+	keyStreamType    = "stream.type"
+	keyStreamBrokers = "stream.brokers"
+	keyStreamPrefix  = "stream.prefix"
+
+	// gRPC API server options
+	keyGrpcEnabled = "grpc.enabled"
+	keyGrpcBind    = "grpc.bind"
 
 	// contract validation related
 	keySolCompilerPath = "compiler.sol"
@@ -59,9 +137,11 @@ const (
 	keyStakingStiContract       = "staking.sti"
 	keyStakingTokenizerContract = "staking.tokenizer"
 	keyStakingERC20Token        = "staking.token"
+	keyStakingFeeBurnShare      = "staking.fee_burn_share"
 
 	// defi related configs
 	keyDefiFMintAddressProvider = "defi.fmint.address_provider"
 	keyDefiUniswapCore          = "defi.uniswap.core"
 	keyDefiUniswapRouter        = "defi.uniswap.router"
+	keyDefiPriceMaxAge          = "defi.price_max_age"
 )
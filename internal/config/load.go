@@ -16,6 +16,12 @@ import (
 )
 
 // Load provides a loaded configuration for Fantom API server.
+// The options are merged from the following sources, each one taking precedence
+// over the sources listed after it:
+//   - command line flags
+//   - environment variables (FAPI_ prefix)
+//   - configuration file
+//   - default values
 func Load() (*Config, error) {
 	// Get the config reader
 	var config Config
@@ -37,14 +43,22 @@ func Load() (*Config, error) {
 	// try to load the logo map file
 	loadErc20LogMap(&config)
 
+	// keep the reader so the configuration can be reloaded
+	source = cfg
+
 	// return the final config
 	return &config, nil
 }
 
 // attachCliFlags connects CLI flags to certain configuration options.
 func attachCliFlags(cfg *Config) {
-	flag.Uint64Var(&cfg.RepoCommand.BlockScanReScan, keyConfigCmdBlockScanReScan, defBlockScanRescanDepth, "How many blocks are re-scanned on the server start.")
+	flag.Uint64Var(&cfg.RepoCommand.BlockScanReScan, keyConfigCmdBlockScanReScan, defBlockScanRescanDepth, "How many of the last processed blocks are verified on the server start.")
+	flag.IntVar(&cfg.RepoCommand.ScanWorkers, keyConfigCmdScanWorkers, defBlockScanWorkers, "How many parallel workers are used by each stage of the block processing.")
 	flag.StringVar(&cfg.RepoCommand.RestoreStake, keyConfigCmdRestoreStake, "", "Owner of the stake to be restored.")
+	flag.Uint64Var(&cfg.RepoCommand.ReindexFrom, keyConfigCmdReindexFrom, 0, "Replay the chain from the given block to rebuild the indexed data.")
+	flag.Uint64Var(&cfg.RepoCommand.ReindexTo, keyConfigCmdReindexTo, 0, "The last block of the chain replay; the current head if not set.")
+	flag.BoolVar(&cfg.RepoCommand.MigrateOnly, keyConfigCmdMigrateOnly, false, "Migrate the database schema to the current version and exit.")
+	attachOverrideFlags()
 }
 
 // readConfigFile reads the config file and provides instance
@@ -72,6 +86,8 @@ func readConfigFile() (*viper.Viper, error) {
 		log.Print("configuration file not found, using default values")
 	}
 
+	// command line flags override everything else
+	applyOverrideFlags(cfg)
 	return cfg, nil
 }
 
@@ -209,6 +225,9 @@ func reader() *viper.Viper {
 	// what is the expected name of the config file
 	cfg.SetConfigName(configFileName)
 
+	// options can be set by environment variables
+	bindEnv(cfg)
+
 	// where to look for common files
 	cfg.AddConfigPath(defaultConfigDir())
 	cfg.AddConfigPath(".")
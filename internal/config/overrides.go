@@ -0,0 +1,76 @@
+package config
+
+import (
+	"flag"
+	"github.com/spf13/viper"
+	"log"
+	"strings"
+)
+
+// envPrefix is the prefix of environment variables overriding configuration options.
+// Any option can be set by the variable derived from its key, i.e. FAPI_SERVER_BIND
+// sets the "server.bind" option.
+const envPrefix = "FAPI"
+
+// override represents a configuration option with a well known
+// environment variable name and command line flag to set it.
+type override struct {
+	key   string
+	env   string
+	flag  string
+	usage string
+}
+
+// overrides lists the options configurable by short environment
+// variable names and command line flags, mostly for container deployment.
+var overrides = []override{
+	{key: keyLachesisUrl, env: "FAPI_RPC_URL", flag: "rpc", usage: "Address of the Opera node RPC interface."},
+	{key: keyMongoUrl, env: "FAPI_MONGO_URI", flag: "mongo", usage: "Connection string of the Mongo database."},
+	{key: keyMongoDatabase, env: "FAPI_MONGO_DB", flag: "db", usage: "Name of the Mongo database."},
+	{key: keyBindAddress, env: "FAPI_BIND", flag: "bind", usage: "Address the API server listens on."},
+	{key: keyDomainAddress, env: "FAPI_DOMAIN", flag: "domain", usage: "Public domain of the API server."},
+	{key: keyLoggingLevel, env: "FAPI_LOG_LEVEL", flag: "log", usage: "Logging level of the API server."},
+	{key: keyCacheRedisUrl, env: "FAPI_REDIS_URL", flag: "redis", usage: "Address of the Redis cache server."},
+}
+
+// attachOverrideFlags attaches CLI flags of the overridable configuration options.
+func attachOverrideFlags() {
+	for _, o := range overrides {
+		flag.String(o.flag, "", o.usage)
+	}
+}
+
+// bindEnv connects the configuration reader with the environment variables.
+func bindEnv(cfg *viper.Viper) {
+	cfg.SetEnvPrefix(envPrefix)
+	cfg.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	cfg.AutomaticEnv()
+
+	// the short names take precedence over the derived ones
+	for _, o := range overrides {
+		if err := cfg.BindEnv(o.key, o.env, envName(o.key)); err != nil {
+			log.Printf("can not bind %s to %s; %s", o.env, o.key, err.Error())
+		}
+	}
+}
+
+// envName provides the name of the environment variable derived from the given option key.
+func envName(key string) string {
+	return envPrefix + "_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// applyOverrideFlags applies the override flags set on the command line;
+// the flags take precedence over all the other configuration sources.
+func applyOverrideFlags(cfg *viper.Viper) {
+	keys := make(map[string]string, len(overrides))
+	for _, o := range overrides {
+		keys[o.flag] = o.key
+	}
+
+	// visit only the flags actually set
+	flag.Visit(func(f *flag.Flag) {
+		if key, ok := keys[f.Name]; ok {
+			cfg.Set(key, f.Value.String())
+		}
+	})
+}
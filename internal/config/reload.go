@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/viper"
+	"log"
+	"time"
+)
+
+// Tunables represents the configuration options which can be changed on the fly.
+// A new set of the options replaces the previous one on the configuration update, so
+// a set of the options provided by the configuration is never changed and can be read concurrently.
+type Tunables struct {
+	AnonymousRate int
+	CacheEviction time.Duration
+	PriceMaxAge   time.Duration
+	TokenLogo     map[common.Address]string
+	Lachesis      Lachesis
+}
+
+// source keeps the reader of the loaded configuration, so the configuration can be reloaded.
+var source *viper.Viper
+
+// Reload re-reads the configuration file the server configuration was loaded from
+// and provides the updated configuration. Environment variables and command line flags
+// are applied with the same precedence as on the initial loading.
+func Reload() (*Config, error) {
+	if source == nil {
+		return nil, fmt.Errorf("configuration not loaded")
+	}
+
+	log.Printf("reloading app configuration")
+	if err := source.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			log.Printf("can not read the server configuration")
+			return nil, err
+		}
+	}
+
+	var config Config
+	if err := source.Unmarshal(&config, setupConfigUnmarshaler); err != nil {
+		log.Printf("can not extract API server configuration; %s", err.Error())
+		return nil, err
+	}
+
+	loadErc20LogMap(&config)
+	return &config, nil
+}
+
+// Update applies the tunable options of the given configuration to the configuration.
+// The tunable options can be changed on the fly without restarting the server,
+// e.g. the anonymous rate limit, the Redis cache eviction and blockchain nodes;
+// changes of the other options are applied on the next server start.
+// The tunable options are read by the Tunable call, the fields of the configuration keep
+// the values loaded on the server start. Logging levels are updated in place, they are
+// read only by the logger on the configuration update.
+func (cfg *Config) Update(n *Config) {
+	cfg.Log.Level = n.Log.Level
+	cfg.Log.Modules = n.Log.Modules
+	cfg.tunables.Store(n.Tunable())
+}
+
+// Tunable provides the current set of the tunable options.
+// The in-memory cache applies a changed eviction time only on the next server start,
+// the Redis cache applies it immediately.
+func (cfg *Config) Tunable() *Tunables {
+	if t, ok := cfg.tunables.Load().(*Tunables); ok {
+		return t
+	}
+
+	return &Tunables{
+		AnonymousRate: cfg.Server.ApiKeys.AnonymousRate,
+		CacheEviction: cfg.Cache.Eviction,
+		PriceMaxAge:   cfg.DeFi.PriceMaxAge,
+		TokenLogo:     cfg.TokenLogo,
+		Lachesis:      cfg.Lachesis,
+	}
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -27,9 +28,9 @@ func NewAccount(acc *types.Account) *Account {
 }
 
 // Account resolves blockchain account by address.
-func (rs *rootResolver) Account(args struct{ Address common.Address }) (*Account, error) {
+func (rs *rootResolver) Account(ctx context.Context, args struct{ Address common.Address }) (*Account, error) {
 	// simply pull the block by hash
-	acc, err := repository.R().Account(&args.Address)
+	acc, err := repository.R().Account(ctx, &args.Address)
 	if err != nil {
 		log.Errorf("could not get the specified account")
 		return nil, err
@@ -38,15 +39,36 @@ func (rs *rootResolver) Account(args struct{ Address common.Address }) (*Account
 }
 
 // AccountsActive resolves total number of active accounts on the blockchain.
-func (rs *rootResolver) AccountsActive() (hexutil.Uint64, error) {
-	return repository.R().AccountsActive()
+func (rs *rootResolver) AccountsActive(ctx context.Context) (hexutil.Uint64, error) {
+	return repository.R().AccountsActive(ctx)
+}
+
+// TransactionCount resolves the number of indexed transactions the account is involved in.
+// The counter is maintained by the scanner, so no count query is needed.
+func (acc *Account) TransactionCount() hexutil.Uint64 {
+	return acc.TrxCounter
+}
+
+// AccountAppearance represents resolvable block and time of an account activity.
+type AccountAppearance struct {
+	Block     hexutil.Uint64
+	Timestamp hexutil.Uint64
+}
+
+// FirstAppearance resolves the block and the time of the first indexed transaction of the account.
+func (acc *Account) FirstAppearance(ctx context.Context) (*AccountAppearance, error) {
+	blk, ts, err := repository.R().AccountFirstAppearance(ctx, &acc.Account)
+	if err != nil || blk == 0 {
+		return nil, err
+	}
+	return &AccountAppearance{Block: hexutil.Uint64(blk), Timestamp: hexutil.Uint64(ts)}, nil
 }
 
 // Balance resolves total balance of the account.
-func (acc *Account) Balance() (hexutil.Big, error) {
+func (acc *Account) Balance(ctx context.Context) (hexutil.Big, error) {
 	// get the balance
 	val, err, _ := acc.cg.Do("balance", func() (interface{}, error) {
-		return repository.R().AccountBalance(&acc.Address)
+		return repository.R().AccountBalance(ctx, &acc.Address)
 	})
 
 	// can not get the balance?
@@ -57,15 +79,15 @@ func (acc *Account) Balance() (hexutil.Big, error) {
 }
 
 // TotalValue resolves account total value including delegated amount and pending rewards.
-func (acc *Account) TotalValue() (hexutil.Big, error) {
+func (acc *Account) TotalValue(ctx context.Context) (hexutil.Big, error) {
 	// get the balance
-	balance, err := acc.Balance()
+	balance, err := acc.Balance(ctx)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
 
 	// try to pull the delegations details
-	delegated, pendingOut, rewards, err := acc.delegationsTotal()
+	delegated, pendingOut, rewards, err := acc.delegationsTotal(ctx)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -76,9 +98,9 @@ func (acc *Account) TotalValue() (hexutil.Big, error) {
 }
 
 // TxCount resolves the number of transaction sent by the account, also known as nonce.
-func (acc *Account) TxCount() (hexutil.Uint64, error) {
+func (acc *Account) TxCount(ctx context.Context) (hexutil.Uint64, error) {
 	// get the sender by address
-	bal, err := repository.R().AccountNonce(&acc.Address)
+	bal, err := repository.R().AccountNonce(ctx, &acc.Address)
 	if err != nil {
 		return hexutil.Uint64(0), err
 	}
@@ -87,17 +109,18 @@ func (acc *Account) TxCount() (hexutil.Uint64, error) {
 }
 
 // TxList resolves list of transaction associated with the account.
-func (acc *Account) TxList(args struct {
+func (acc *Account) TxList(ctx context.Context, args struct {
 	Recipient *common.Address
 	Cursor    *Cursor
 	Count     int32
+	Filter    *TransactionFilterInput
 }) (*TransactionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
 	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
 
 	// get the transaction hash list from repository
-	bl, err := repository.R().AccountTransactions(&acc.Address, args.Recipient, (*string)(args.Cursor), args.Count)
+	bl, err := repository.R().AccountTransactions(ctx, &acc.Address, args.Recipient, (*string)(args.Cursor), args.Count, args.Filter.filter())
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +129,7 @@ func (acc *Account) TxList(args struct {
 }
 
 // Erc20TxList resolves list of ERC20 transactions associated with the account.
-func (acc *Account) Erc20TxList(args struct {
+func (acc *Account) Erc20TxList(ctx context.Context, args struct {
 	Cursor *Cursor
 	Count  int32
 	Token  *common.Address
@@ -117,7 +140,7 @@ func (acc *Account) Erc20TxList(args struct {
 	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
 
 	// get the transaction hash list from repository
-	tl, err := repository.R().TokenTransactions(
+	tl, err := repository.R().TokenTransactions(ctx,
 		types.AccountTypeERC20Token,
 		args.Token,
 		nil,
@@ -134,7 +157,7 @@ func (acc *Account) Erc20TxList(args struct {
 }
 
 // Erc721TxList resolves list of ERC721 transactions associated with the account.
-func (acc *Account) Erc721TxList(args struct {
+func (acc *Account) Erc721TxList(ctx context.Context, args struct {
 	Cursor  *Cursor
 	Count   int32
 	Token   *common.Address
@@ -146,7 +169,7 @@ func (acc *Account) Erc721TxList(args struct {
 	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
 
 	// get the transaction hash list from repository
-	tl, err := repository.R().TokenTransactions(
+	tl, err := repository.R().TokenTransactions(ctx,
 		types.AccountTypeERC721Contract,
 		args.Token,
 		(*big.Int)(args.TokenId),
@@ -163,7 +186,7 @@ func (acc *Account) Erc721TxList(args struct {
 }
 
 // Erc1155TxList resolves list of ERC1155 transactions associated with the account.
-func (acc *Account) Erc1155TxList(args struct {
+func (acc *Account) Erc1155TxList(ctx context.Context, args struct {
 	Cursor  *Cursor
 	Count   int32
 	Token   *common.Address
@@ -175,7 +198,7 @@ func (acc *Account) Erc1155TxList(args struct {
 	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
 
 	// get the transaction hash list from repository
-	tl, err := repository.R().TokenTransactions(
+	tl, err := repository.R().TokenTransactions(ctx,
 		types.AccountTypeERC1155Contract,
 		args.Token,
 		(*big.Int)(args.TokenId),
@@ -192,9 +215,9 @@ func (acc *Account) Erc1155TxList(args struct {
 }
 
 // Staker resolves the account staker detail, if the account is a staker.
-func (acc *Account) Staker() (*Staker, error) {
+func (acc *Account) Staker(ctx context.Context) (*Staker, error) {
 	// get the staker
-	st, err := repository.R().ValidatorByAddress(&acc.Address)
+	st, err := repository.R().ValidatorByAddress(ctx, &acc.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -207,7 +230,7 @@ func (acc *Account) Staker() (*Staker, error) {
 }
 
 // Delegations resolves a list of account delegations, if the account is a delegator.
-func (acc *Account) Delegations(args *struct {
+func (acc *Account) Delegations(ctx context.Context, args *struct {
 	Cursor *Cursor
 	Count  int32
 }) (*DelegationList, error) {
@@ -216,7 +239,7 @@ func (acc *Account) Delegations(args *struct {
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// pull the list
-	dl, err := repository.R().DelegationsByAddress(&acc.Address, (*string)(args.Cursor), args.Count)
+	dl, err := repository.R().DelegationsByAddress(ctx, &acc.Address, (*string)(args.Cursor), args.Count)
 	if err != nil {
 		return nil, err
 	}
@@ -227,14 +250,14 @@ func (acc *Account) Delegations(args *struct {
 
 // Contract resolves the account smart contract detail,
 // if the account is a smart contract address.
-func (acc *Account) Contract() (*Contract, error) {
+func (acc *Account) Contract(ctx context.Context) (*Contract, error) {
 	// is this actually a contract account?
 	if acc.ContractTx == nil {
 		return nil, nil
 	}
 
 	// get new contract
-	con, err := repository.R().Contract(&acc.Address)
+	con, err := repository.R().Contract(ctx, &acc.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -243,9 +266,9 @@ func (acc *Account) Contract() (*Contract, error) {
 
 // delegationsTotal calculates total sum of delegations of the given account including
 // pending rewards for those delegations.
-func (acc *Account) delegationsTotal() (amount *big.Int, inWithdraw *big.Int, rewards *big.Int, err error) {
+func (acc *Account) delegationsTotal(ctx context.Context) (amount *big.Int, inWithdraw *big.Int, rewards *big.Int, err error) {
 	// pull all the delegations of the account
-	list, err := repository.R().DelegationsByAddressAll(&acc.Address)
+	list, err := repository.R().DelegationsByAddressAll(ctx, &acc.Address)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -261,7 +284,7 @@ func (acc *Account) delegationsTotal() (amount *big.Int, inWithdraw *big.Int, re
 		}
 
 		// get pending rewards for this delegation (can be stashed)
-		rw, err := repository.R().PendingRewards(&acc.Address, dlg.ToStakerId)
+		rw, err := repository.R().PendingRewards(ctx, &acc.Address, dlg.ToStakerId)
 		if err != nil {
 			return nil, nil, nil, err
 		}
@@ -272,7 +295,7 @@ func (acc *Account) delegationsTotal() (amount *big.Int, inWithdraw *big.Int, re
 		}
 
 		// get pending withdrawals
-		wd, err := repository.R().WithdrawRequestsPendingTotal(&acc.Address, dlg.ToStakerId)
+		wd, err := repository.R().WithdrawRequestsPendingTotal(ctx, &acc.Address, dlg.ToStakerId)
 		if err != nil {
 			return nil, nil, nil, err
 		}
@@ -0,0 +1,105 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"strconv"
+)
+
+// AccountBalanceList represents resolvable list of accounts sorted by their balance.
+type AccountBalanceList struct {
+	types.AccountBalanceList
+}
+
+// AccountBalanceListEdge represents a single edge of the account balance list.
+type AccountBalanceListEdge struct {
+	bal *types.AccountBalance
+}
+
+// TopAccounts resolves a list of accounts sorted by their balance
+// from the highest to the lowest.
+func (rs *rootResolver) TopAccounts(ctx context.Context, args struct {
+	Cursor *Cursor
+	Count  int32
+}) (*AccountBalanceList, error) {
+	// limit query size; the list is always loaded from the top
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	if args.Count < 0 {
+		args.Count = -args.Count
+	}
+
+	// the list is expensive to load, use the response cache
+	var list types.AccountBalanceList
+	err := rs.cachedResponse("topAccounts", args, &list, func() error {
+		bl, err := repository.R().TopAccounts(ctx, (*string)(args.Cursor), args.Count)
+		if err == nil {
+			list = *bl
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &AccountBalanceList{list}, nil
+}
+
+// TotalCount resolves the total number of accounts in the list.
+func (abl *AccountBalanceList) TotalCount() hexutil.Big {
+	val := new(big.Int).SetUint64(abl.Total)
+	return (hexutil.Big)(*val)
+}
+
+// PageInfo resolves the current page information for the account balance list.
+func (abl *AccountBalanceList) PageInfo() (*ListPageInfo, error) {
+	// do we have any items?
+	if len(abl.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, !abl.IsStart)
+	}
+
+	// get the first and last elements
+	first := accountBalanceCursor(abl.Collection[0])
+	last := accountBalanceCursor(abl.Collection[len(abl.Collection)-1])
+	return NewListPageInfo(&first, &last, !abl.IsEnd, !abl.IsStart)
+}
+
+// Edges resolves list of edges of the account balance list.
+func (abl *AccountBalanceList) Edges() []*AccountBalanceListEdge {
+	edges := make([]*AccountBalanceListEdge, len(abl.Collection))
+	for i, bal := range abl.Collection {
+		edges[i] = &AccountBalanceListEdge{bal: bal}
+	}
+	return edges
+}
+
+// Cursor resolves the cursor of the edge.
+func (edge *AccountBalanceListEdge) Cursor() Cursor {
+	return accountBalanceCursor(edge.bal)
+}
+
+// Rank resolves the position of the account in the list.
+func (edge *AccountBalanceListEdge) Rank() hexutil.Uint64 {
+	return hexutil.Uint64(edge.bal.Rank)
+}
+
+// Balance resolves the known balance of the account.
+func (edge *AccountBalanceListEdge) Balance() hexutil.Big {
+	return edge.bal.Balance
+}
+
+// Account resolves the account of the edge.
+func (edge *AccountBalanceListEdge) Account(ctx context.Context) (*Account, error) {
+	acc, err := loadAccount(ctx, &edge.bal.Address)
+	if err != nil {
+		return nil, err
+	}
+	return NewAccount(acc), nil
+}
+
+// accountBalanceCursor creates the cursor of the given account balance.
+func accountBalanceCursor(bal *types.AccountBalance) Cursor {
+	return Cursor(strconv.FormatUint(bal.Rank, 10))
+}
@@ -0,0 +1,64 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// accountGasStatsMaxWindow represents the max number of days the account gas stats are calculated on.
+const accountGasStatsMaxWindow = 365
+
+// AccountGasStats represents a resolvable summary of the gas spent by an account.
+type AccountGasStats struct {
+	types.AccountGasStats
+	Window int32
+}
+
+// GasStats resolves the summary of the gas used and the fees paid by the transactions
+// sent from the account over the given number of the most recent days.
+func (acc *Account) GasStats(ctx context.Context, args struct{ Window int32 }) (*AccountGasStats, error) {
+	if args.Window <= 0 || args.Window > accountGasStatsMaxWindow {
+		args.Window = accountGasStatsMaxWindow
+	}
+
+	st, err := repository.R().AccountGasStats(ctx, &acc.Address, args.Window)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountGasStats{AccountGasStats: *st, Window: args.Window}, nil
+}
+
+// Transactions resolves the number of transactions sent from the account.
+func (ags *AccountGasStats) Transactions() hexutil.Uint64 {
+	return hexutil.Uint64(ags.AccountGasStats.Transactions)
+}
+
+// GasUsed resolves the total amount of gas used by the transactions.
+func (ags *AccountGasStats) GasUsed() hexutil.Uint64 {
+	return hexutil.Uint64(ags.AccountGasStats.GasUsed)
+}
+
+// AvgGasUsed resolves the average amount of gas used by a transaction.
+func (ags *AccountGasStats) AvgGasUsed() hexutil.Uint64 {
+	if ags.AccountGasStats.Transactions == 0 {
+		return 0
+	}
+	return hexutil.Uint64(ags.AccountGasStats.GasUsed / ags.AccountGasStats.Transactions)
+}
+
+// FeesPaid resolves the total amount of fees paid by the transactions in WEI.
+func (ags *AccountGasStats) FeesPaid() hexutil.Big {
+	// the fee is aggregated from the gas price reduced by the gas correction
+	val := new(big.Int).Mul(new(big.Int).SetInt64(ags.AccountGasStats.Fee), types.TransactionGasCorrection)
+	return hexutil.Big(*val)
+}
+
+// AvgGasPrice resolves the average gas price of the transactions in WEI.
+func (ags *AccountGasStats) AvgGasPrice() hexutil.Big {
+	val := new(big.Int).Mul(new(big.Int).SetInt64(int64(ags.AccountGasStats.AvgGasPrice)), types.TransactionGasCorrection)
+	return hexutil.Big(*val)
+}
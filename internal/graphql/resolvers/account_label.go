@@ -0,0 +1,104 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"strings"
+)
+
+// accountLabelMaxNameLength represents the max length of an account label name.
+const accountLabelMaxNameLength = 64
+
+// AccountLabel represents resolvable name and category of a known address.
+type AccountLabel struct {
+	types.AccountLabel
+}
+
+// NewAccountLabel builds new resolvable account label.
+func NewAccountLabel(al *types.AccountLabel) *AccountLabel {
+	return &AccountLabel{AccountLabel: *al}
+}
+
+// Label resolves the label of the account, if the address is known.
+func (acc *Account) Label(ctx context.Context) (*AccountLabel, error) {
+	al, err := repository.R().AccountLabel(ctx, &acc.Address)
+	if err != nil {
+		log.Errorf("can not get label of %s; %s", acc.Address.String(), err.Error())
+		return nil, err
+	}
+	if al == nil {
+		return nil, nil
+	}
+	return NewAccountLabel(al), nil
+}
+
+// AccountLabels resolves the list of known account labels, optionally of the given category only.
+func (rs *rootResolver) AccountLabels(ctx context.Context, args struct{ Category *string }) ([]*AccountLabel, error) {
+	var cat *string
+	if args.Category != nil {
+		c := strings.ToLower(*args.Category)
+		cat = &c
+	}
+
+	list, err := repository.R().AccountLabels(ctx, cat)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*AccountLabel, len(list))
+	for i, al := range list {
+		res[i] = NewAccountLabel(al)
+	}
+	return res, nil
+}
+
+// SetAccountLabel resolves assignment of a label to the given address.
+func (rs *rootResolver) SetAccountLabel(ctx context.Context, args *struct {
+	Address  common.Address
+	Name     string
+	Category string
+}) (*AccountLabel, error) {
+	if !isAdmin(ctx) {
+		return nil, errAdminOnly
+	}
+
+	name := strings.TrimSpace(args.Name)
+	if len(name) == 0 || len(name) > accountLabelMaxNameLength {
+		return nil, fmt.Errorf("label name must be 1 to %d characters long", accountLabelMaxNameLength)
+	}
+
+	al, err := repository.R().SetAccountLabel(ctx, &args.Address, name, strings.ToLower(args.Category))
+	if err != nil {
+		log.Errorf("can not label %s; %s", args.Address.String(), err.Error())
+		return nil, err
+	}
+	return NewAccountLabel(al), nil
+}
+
+// RemoveAccountLabel resolves removal of the label of the given address.
+func (rs *rootResolver) RemoveAccountLabel(ctx context.Context, args *struct{ Address common.Address }) (bool, error) {
+	if !isAdmin(ctx) {
+		return false, errAdminOnly
+	}
+	return repository.R().RemoveAccountLabel(ctx, &args.Address)
+}
+
+// Address resolves the labeled address.
+func (al *AccountLabel) Address() common.Address {
+	return common.HexToAddress(al.AccountLabel.Address)
+}
+
+// Category resolves the category of the labeled address.
+func (al *AccountLabel) Category() string {
+	return strings.ToUpper(al.AccountLabel.Category)
+}
+
+// Updated resolves the UNIX timestamp of the last label update.
+func (al *AccountLabel) Updated() hexutil.Uint64 {
+	return hexutil.Uint64(al.AccountLabel.Updated.Unix())
+}
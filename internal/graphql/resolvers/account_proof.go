@@ -0,0 +1,58 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// accountProofMaxKeys represents the max number of storage slots proved in one request.
+const accountProofMaxKeys = 32
+
+// AccountProof represents a resolvable Merkle proof of an account state.
+type AccountProof struct {
+	types.AccountStateProof
+}
+
+// StorageProof represents a resolvable Merkle proof of an account storage slot.
+type StorageProof struct {
+	types.StorageProof
+}
+
+// Proof resolves the Merkle proof of the account state and of the given storage slots
+// of the account at the given block, or at the latest block.
+func (acc *Account) Proof(ctx context.Context, args struct {
+	StorageKeys *[]common.Hash
+	Block       *hexutil.Uint64
+}) (*AccountProof, error) {
+	keys := make([]common.Hash, 0)
+	if args.StorageKeys != nil {
+		keys = *args.StorageKeys
+	}
+	if len(keys) > accountProofMaxKeys {
+		return nil, types.Errorf(types.ErrCodeInvalidInput, "at most %d storage keys can be proved at once", accountProofMaxKeys)
+	}
+
+	proof, err := repository.R().AccountProof(ctx, &acc.Address, keys, args.Block)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountProof{*proof}, nil
+}
+
+// StorageProof resolves the list of the proofs of the requested storage slots.
+func (ap *AccountProof) StorageProof() []*StorageProof {
+	list := make([]*StorageProof, len(ap.AccountStateProof.StorageProof))
+	for i, sp := range ap.AccountStateProof.StorageProof {
+		list[i] = &StorageProof{sp}
+	}
+	return list
+}
+
+// Key resolves the key of the proved storage slot.
+func (sp *StorageProof) Key() common.Hash {
+	return common.HexToHash(sp.StorageProof.Key)
+}
@@ -0,0 +1,88 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/svc"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Admin represents resolvable namespace of the API server administration.
+// The same resolver serves both the query and the mutation namespace.
+type Admin struct{}
+
+// ScannerStatus represents resolvable state of the blockchain data scanner.
+type ScannerStatus struct {
+	types.ScannerStatus
+}
+
+// Admin resolves the administration namespace; it's available to the API server administrators only.
+func (rs *rootResolver) Admin(ctx context.Context) (*Admin, error) {
+	if !isAdmin(ctx) {
+		return nil, errAdminOnly
+	}
+	return &Admin{}, nil
+}
+
+// ScannerStatus resolves the current state of the blockchain data scanner.
+func (adm *Admin) ScannerStatus() *ScannerStatus {
+	return &ScannerStatus{ScannerStatus: *svc.Manager().ScannerStatus()}
+}
+
+// ReindexFrom resolves the start of the chain replay from the given block to the current head.
+func (adm *Admin) ReindexFrom(args *struct{ Block hexutil.Uint64 }) (bool, error) {
+	if args.Block == 0 {
+		return false, fmt.Errorf("reindex must start at a positive block")
+	}
+
+	if err := svc.Manager().Reindex(uint64(args.Block)); err != nil {
+		log.Errorf("can not start chain reindex at #%d; %s", uint64(args.Block), err.Error())
+		return false, err
+	}
+
+	log.Noticef("chain reindex from #%d requested", uint64(args.Block))
+	return true, nil
+}
+
+// PurgeCache resolves removal of the cached entries of the given key prefix.
+func (adm *Admin) PurgeCache(args *struct{ Prefix string }) (hexutil.Uint64, error) {
+	count, err := repository.R().PurgeCache(args.Prefix)
+	return hexutil.Uint64(count), err
+}
+
+// SetLogLevel resolves the logging level change of the given module.
+func (adm *Admin) SetLogLevel(args *struct {
+	Module string
+	Level  string
+}) (bool, error) {
+	if err := logger.SetModuleLevel(args.Module, args.Level); err != nil {
+		return false, err
+	}
+
+	log.Noticef("logging level of module '%s' set to %s", args.Module, args.Level)
+	return true, nil
+}
+
+// LastProcessedBlock resolves the number of the last block fully processed by the scanner.
+func (ss *ScannerStatus) LastProcessedBlock() hexutil.Uint64 {
+	return hexutil.Uint64(ss.ScannerStatus.LastProcessedBlock)
+}
+
+// ReindexFrom resolves the first block of the recent chain reindex.
+func (ss *ScannerStatus) ReindexFrom() hexutil.Uint64 {
+	return hexutil.Uint64(ss.ScannerStatus.ReindexFrom)
+}
+
+// ReindexTo resolves the last block of the recent chain reindex.
+func (ss *ScannerStatus) ReindexTo() hexutil.Uint64 {
+	return hexutil.Uint64(ss.ScannerStatus.ReindexTo)
+}
+
+// ReindexNext resolves the next block to be replayed by the recent chain reindex.
+func (ss *ScannerStatus) ReindexNext() hexutil.Uint64 {
+	return hexutil.Uint64(ss.ScannerStatus.ReindexNext)
+}
@@ -0,0 +1,118 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// adminKey represents the context key of the API server administrator mark.
+type adminKey struct{}
+
+// apiKeyKey represents the context key of the API key hash of the client.
+type apiKeyKey struct{}
+
+// errAdminOnly represents an error of an administrator call made by a regular client.
+var errAdminOnly = fmt.Errorf("administrator access required")
+
+// ApiKey represents resolvable API access key.
+type ApiKey struct {
+	ak  *types.ApiKey
+	key *string
+}
+
+// WithAdmin provides a context marked as a request of the API server administrator.
+func WithAdmin(ctx context.Context) context.Context {
+	return context.WithValue(ctx, adminKey{}, true)
+}
+
+// isAdmin checks if the context belongs to a request of the API server administrator.
+func isAdmin(ctx context.Context) bool {
+	is, _ := ctx.Value(adminKey{}).(bool)
+	return is
+}
+
+// WithApiKey provides a context marked as a request of the client with the given API key hash.
+func WithApiKey(ctx context.Context, hash string) context.Context {
+	return context.WithValue(ctx, apiKeyKey{}, hash)
+}
+
+// apiKeyOf provides the API key hash of the client of the request, if any.
+func apiKeyOf(ctx context.Context) (string, bool) {
+	hash, ok := ctx.Value(apiKeyKey{}).(string)
+	return hash, ok
+}
+
+// RequestClient provides the API key hash of the client of the request, if any,
+// and the mark of the API server administrator request.
+func RequestClient(ctx context.Context) (string, bool) {
+	hash, _ := apiKeyOf(ctx)
+	return hash, isAdmin(ctx)
+}
+
+// CreateApiKey resolves a new API key issued with the given usage limits.
+func (rs *rootResolver) CreateApiKey(ctx context.Context, args *struct {
+	Name       string
+	RateLimit  int32
+	DailyQuota *hexutil.Uint64
+}) (*ApiKey, error) {
+	if !isAdmin(ctx) {
+		return nil, errAdminOnly
+	}
+	if args.RateLimit <= 0 {
+		return nil, fmt.Errorf("rate limit must be positive")
+	}
+
+	var quota int64
+	if args.DailyQuota != nil {
+		quota = int64(*args.DailyQuota)
+	}
+
+	key, ak, err := repository.R().CreateApiKey(ctx, args.Name, args.RateLimit, quota)
+	if err != nil {
+		log.Errorf("can not create API key; %s", err.Error())
+		return nil, err
+	}
+	return &ApiKey{ak: ak, key: &key}, nil
+}
+
+// RevokeApiKey resolves revocation of the given API key.
+func (rs *rootResolver) RevokeApiKey(ctx context.Context, args *struct{ Key string }) (bool, error) {
+	if !isAdmin(ctx) {
+		return false, errAdminOnly
+	}
+	return repository.R().RevokeApiKey(ctx, args.Key)
+}
+
+// Key resolves the API key; it's available only on the key creation.
+func (k *ApiKey) Key() *string {
+	return k.key
+}
+
+// Name resolves the name of the client the key was issued to.
+func (k *ApiKey) Name() string {
+	return k.ak.Name
+}
+
+// RateLimit resolves the max number of requests per minute.
+func (k *ApiKey) RateLimit() int32 {
+	return k.ak.RateLimit
+}
+
+// DailyQuota resolves the max number of requests per day.
+func (k *ApiKey) DailyQuota() hexutil.Uint64 {
+	return hexutil.Uint64(k.ak.DailyQuota)
+}
+
+// Created resolves the UNIX timestamp of the key creation.
+func (k *ApiKey) Created() hexutil.Uint64 {
+	return hexutil.Uint64(k.ak.Created.Unix())
+}
+
+// Revoked resolves the revocation state of the key.
+func (k *ApiKey) Revoked() bool {
+	return !k.ak.IsActive()
+}
@@ -0,0 +1,71 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// apiKeyUsageMaxDays represents the max number of days of the API key usage provided at once.
+const apiKeyUsageMaxDays = 90
+
+// errApiKeyRequired represents an error of an API key usage request made by an anonymous client.
+var errApiKeyRequired = fmt.Errorf("API key required to see its usage")
+
+// ApiKeyUsage represents resolvable daily usage of an API key.
+type ApiKeyUsage struct {
+	types.ApiKeyUsage
+}
+
+// ApiKeyUsage resolves the daily usage of the API key of the client over the given number of days.
+// The administrator can see the usage of any key by its hash.
+func (rs *rootResolver) ApiKeyUsage(ctx context.Context, args *struct {
+	Client *string
+	Days   int32
+}) ([]*ApiKeyUsage, error) {
+	if args.Days < 1 || args.Days > apiKeyUsageMaxDays {
+		return nil, types.Errorf(types.ErrCodeInvalidInput, "days must be between 1 and %d", apiKeyUsageMaxDays)
+	}
+
+	hash, ok := apiKeyOf(ctx)
+	switch {
+	case isAdmin(ctx):
+		if args.Client == nil {
+			return nil, types.NewError(types.ErrCodeInvalidInput, "client API key hash required")
+		}
+		hash = *args.Client
+	case !ok:
+		return nil, errApiKeyRequired
+	case args.Client != nil && *args.Client != hash:
+		return nil, errAdminOnly
+	}
+
+	list, err := repository.R().ApiKeyUsage(ctx, hash, int(args.Days))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*ApiKeyUsage, len(list))
+	for i, u := range list {
+		res[i] = &ApiKeyUsage{*u}
+	}
+	return res, nil
+}
+
+// Day resolves the day of the usage in format YYYY-MM-DD.
+func (u *ApiKeyUsage) Day() string {
+	return u.ApiKeyUsage.Day.Format("2006-01-02")
+}
+
+// Queries resolves the number of queries executed on the day.
+func (u *ApiKeyUsage) Queries() hexutil.Uint64 {
+	return hexutil.Uint64(u.ApiKeyUsage.Queries)
+}
+
+// Cost resolves the cumulative estimated cost of the queries executed on the day.
+func (u *ApiKeyUsage) Cost() hexutil.Uint64 {
+	return hexutil.Uint64(u.ApiKeyUsage.Cost)
+}
@@ -0,0 +1,60 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// AuditRecord represents resolvable record of an executed operation.
+type AuditRecord struct {
+	types.AuditRecord
+}
+
+// AuditLog resolves the most recent records of the executed operations audit log.
+func (rs *rootResolver) AuditLog(ctx context.Context, args *struct {
+	Client    *string
+	Operation *string
+	Count     int32
+}) ([]*AuditRecord, error) {
+	if !isAdmin(ctx) {
+		return nil, errAdminOnly
+	}
+
+	// limit query size
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	if args.Count < 0 {
+		args.Count = -args.Count
+	}
+
+	al, err := repository.R().AuditLog(ctx, args.Client, args.Operation, args.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*AuditRecord, len(al))
+	for i, rec := range al {
+		list[i] = &AuditRecord{AuditRecord: *rec}
+	}
+	return list, nil
+}
+
+// Duration resolves the duration of the operation execution in milliseconds.
+func (ar *AuditRecord) Duration() int32 {
+	return int32(ar.AuditRecord.Duration)
+}
+
+// Errors resolves the errors of the operation execution.
+func (ar *AuditRecord) Errors() []string {
+	if ar.AuditRecord.Errors == nil {
+		return []string{}
+	}
+	return ar.AuditRecord.Errors
+}
+
+// Executed resolves the UNIX timestamp of the operation execution.
+func (ar *AuditRecord) Executed() hexutil.Uint64 {
+	return hexutil.Uint64(ar.AuditRecord.Executed.Unix())
+}
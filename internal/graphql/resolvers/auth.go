@@ -0,0 +1,35 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+)
+
+// rolesKey represents the context key of the roles of a client authenticated by a bearer token.
+type rolesKey struct{}
+
+// WithRoles provides a context marked as a request of a client authenticated with the given roles.
+func WithRoles(ctx context.Context, roles []string) context.Context {
+	return context.WithValue(ctx, rolesKey{}, roles)
+}
+
+// requireRole checks if the client of the request may execute an operation guarded by the given role.
+// The operations are open to everybody if the bearer authentication is not enabled;
+// the administrator passes any role check.
+func requireRole(ctx context.Context, role string) error {
+	if !cfg.Server.Auth.Enabled || isAdmin(ctx) {
+		return nil
+	}
+
+	roles, ok := ctx.Value(rolesKey{}).([]string)
+	if !ok {
+		return types.NewError(types.ErrCodeUnauthorized, "authentication required")
+	}
+	for _, r := range roles {
+		if r == role {
+			return nil
+		}
+	}
+	return types.Errorf(types.ErrCodeForbidden, "role %s required", role)
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -22,25 +23,25 @@ func NewBlock(blk *types.Block) *Block {
 }
 
 // Block resolves blockchain block by number or by hash. If neither is provided, the most recent block is given.
-func (rs *rootResolver) Block(args *struct {
+func (rs *rootResolver) Block(ctx context.Context, args *struct {
 	Number *hexutil.Uint64
 	Hash   *common.Hash
 }) (*Block, error) {
 	// do we have the number, or hash is not given?
 	if args.Number != nil || args.Hash == nil {
-		b, err := repository.R().BlockByNumber(args.Number)
+		b, err := repository.R().BlockByNumber(ctx, args.Number)
 		return NewBlock(b), err
 	}
 
 	// simply pull the block by hash
-	b, err := repository.R().BlockByHash(args.Hash)
+	b, err := repository.R().BlockByHash(ctx, args.Hash)
 	return NewBlock(b), err
 }
 
 // Parent resolves parent block information to the given block.
-func (blk *Block) Parent() (*Block, error) {
+func (blk *Block) Parent(ctx context.Context) (*Block, error) {
 	// get the parent block by hash
-	parent, err := repository.R().BlockByHash(&blk.ParentHash)
+	parent, err := repository.R().BlockByHash(ctx, &blk.ParentHash)
 	return NewBlock(parent), err
 }
 
@@ -55,21 +56,21 @@ func (blk *Block) TxHashList() []common.Hash {
 }
 
 // TxList resolves list of transaction details of the transactions bundled in the block.
-func (blk *Block) TxList() ([]*Transaction, error) {
-	// make the container
-	txs := make([]*Transaction, len(blk.Txs))
+func (blk *Block) TxList(ctx context.Context) ([]*Transaction, error) {
+	// load all the transactions in a single batch
+	list, err := repository.R().TransactionsByHash(ctx, blk.Txs)
+	if err != nil {
+		return nil, err
+	}
 
-	// loop the hashes and extract transactions
-	for i, hash := range blk.Txs {
-		trx, err := repository.R().Transaction(hash)
-		if err != nil {
-			return nil, err
+	// make resolvable transactions
+	txs := make([]*Transaction, len(list))
+	for i, trx := range list {
+		if trx == nil {
+			return nil, repository.ErrTransactionNotFound
 		}
-
-		// make a resolvable transaction
 		txs[i] = NewTransaction(trx)
 	}
-
 	return txs, nil
 }
 
@@ -2,11 +2,20 @@
 package resolvers
 
 import (
+	"context"
+	"encoding/base64"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"strconv"
+	"strings"
 )
 
+// blockCursorPrefix represents the prefix of the encoded block list cursor.
+const blockCursorPrefix = "blk:"
+
 // BlockList represents resolvable list of blockchain block edges structure.
 type BlockList struct {
 	list       *types.BlockList
@@ -28,22 +37,39 @@ func NewBlockList(blocks *types.BlockList, totalCount *hexutil.Big) *BlockList {
 }
 
 // Blocks resolves list of blockchain blocks encapsulated in a listable structure.
-func (rs *rootResolver) Blocks(args *struct {
+// If a time range is given, only blocks of the range are listed.
+func (rs *rootResolver) Blocks(ctx context.Context, args *struct {
 	Cursor *Cursor
 	Count  int32
+	From   *hexutil.Uint64
+	To     *hexutil.Uint64
 }) (*BlockList, error) {
-	// do we have a cursor? try to decode it into an actual block number
-	var num *uint64
-	if args.Cursor != nil {
-		val, err := hexutil.DecodeUint64(string(*args.Cursor))
-		if err != nil {
-			log.Errorf("invalid block cursor [%s]; %s", args.Cursor, err.Error())
+	// time range filter requested? use the range list
+	if args.From != nil || args.To != nil {
+		from, to := hexutil.Uint64(0), hexutil.Uint64(^uint64(0)>>1)
+		if args.From != nil {
+			from = *args.From
 		}
-		num = &val
+		if args.To != nil {
+			to = *args.To
+		}
+		return rs.BlocksByTimeRange(ctx, &struct {
+			From   hexutil.Uint64
+			To     hexutil.Uint64
+			Cursor *Cursor
+			Count  int32
+		}{From: from, To: to, Cursor: args.Cursor, Count: args.Count})
+	}
+
+	// do we have a cursor? try to decode it into an actual block number
+	num, err := decodeBlockCursor(args.Cursor)
+	if err != nil {
+		log.Errorf("invalid block cursor [%s]; %s", *args.Cursor, err.Error())
+		return nil, err
 	}
 
 	// get the first block so we know the total
-	bh, err := repository.R().BlockHeight()
+	bh, err := repository.R().BlockHeight(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +79,7 @@ func (rs *rootResolver) Blocks(args *struct {
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// get the block list from repository
-	bl, err := repository.R().Blocks(num, args.Count)
+	bl, err := repository.R().Blocks(ctx, num, args.Count)
 	if err != nil {
 		log.Errorf("can not get blocks list; %s", err.Error())
 		return nil, err
@@ -62,6 +88,70 @@ func (rs *rootResolver) Blocks(args *struct {
 	return NewBlockList(bl, bh), nil
 }
 
+// BlocksByTimeRange resolves list of blockchain blocks created in the given UNIX time range,
+// both ends of the range included.
+func (rs *rootResolver) BlocksByTimeRange(ctx context.Context, args *struct {
+	From   hexutil.Uint64
+	To     hexutil.Uint64
+	Cursor *Cursor
+	Count  int32
+}) (*BlockList, error) {
+	if args.To < args.From {
+		return nil, fmt.Errorf("invalid time range <%d, %d>", uint64(args.From), uint64(args.To))
+	}
+
+	// decode the cursor, if any
+	num, err := decodeBlockCursor(args.Cursor)
+	if err != nil {
+		log.Errorf("invalid block cursor [%s]; %s", *args.Cursor, err.Error())
+		return nil, err
+	}
+
+	// limit query size; the count can be either positive or negative
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	bl, total, err := repository.R().BlocksByTimeRange(ctx, uint64(args.From), uint64(args.To), num, args.Count)
+	if err != nil {
+		log.Errorf("can not get blocks list of time range <%d, %d>; %s", uint64(args.From), uint64(args.To), err.Error())
+		return nil, err
+	}
+
+	return NewBlockList(bl, (*hexutil.Big)(new(big.Int).SetUint64(total))), nil
+}
+
+// blockCursor provides an opaque list cursor of the given block number.
+func blockCursor(num hexutil.Uint64) Cursor {
+	return Cursor(base64.RawURLEncoding.EncodeToString([]byte(blockCursorPrefix + strconv.FormatUint(uint64(num), 10))))
+}
+
+// decodeBlockCursor decodes the block number from the given block list cursor.
+// Legacy cursors containing hex encoded block numbers are accepted as well.
+func decodeBlockCursor(c *Cursor) (*uint64, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	// legacy hex block number
+	if strings.HasPrefix(string(*c), "0x") {
+		val, err := hexutil.DecodeUint64(string(*c))
+		if err != nil {
+			return nil, types.Errorf(types.ErrCodeInvalidCursor, "invalid cursor value; %s", err.Error())
+		}
+		return &val, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(string(*c))
+	if err != nil || !strings.HasPrefix(string(raw), blockCursorPrefix) {
+		return nil, types.NewError(types.ErrCodeInvalidCursor, "unknown cursor format")
+	}
+
+	val, err := strconv.ParseUint(strings.TrimPrefix(string(raw), blockCursorPrefix), 10, 64)
+	if err != nil {
+		return nil, types.Errorf(types.ErrCodeInvalidCursor, "invalid cursor value; %s", err.Error())
+	}
+	return &val, nil
+}
+
 // PageInfo resolves the current page information for the blocks list.
 func (bl *BlockList) PageInfo() (*ListPageInfo, error) {
 	// do we have any items?
@@ -70,8 +160,8 @@ func (bl *BlockList) PageInfo() (*ListPageInfo, error) {
 	}
 
 	// get the first and last elements
-	first := Cursor(bl.list.Collection[0].Number.String())
-	last := Cursor(bl.list.Collection[len(bl.list.Collection)-1].Number.String())
+	first := blockCursor(bl.list.Collection[0].Number)
+	last := blockCursor(bl.list.Collection[len(bl.list.Collection)-1].Number)
 	return NewListPageInfo(&first, &last, !bl.list.IsEnd, !bl.list.IsStart)
 }
 
@@ -88,7 +178,7 @@ func (bl *BlockList) Edges() []*BlockListEdge {
 		// make the element
 		edge := BlockListEdge{
 			Block:  NewBlock(b),
-			Cursor: Cursor(b.Number.String()),
+			Cursor: blockCursor(b.Number),
 		}
 
 		// add it to the list
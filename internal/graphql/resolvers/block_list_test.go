@@ -0,0 +1,63 @@
+package resolvers
+
+import (
+	"encoding/base64"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+func TestBlockCursor(t *testing.T) {
+	for _, n := range []uint64{0, 1, 123456, ^uint64(0)} {
+		g := gomega.NewGomegaWithT(t)
+
+		c := blockCursor(hexutil.Uint64(n))
+		num, err := decodeBlockCursor(&c)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(*num).To(gomega.Equal(n))
+	}
+}
+
+func TestDecodeBlockCursor(t *testing.T) {
+	cursor := func(s string) *Cursor {
+		c := Cursor(s)
+		return &c
+	}
+	encoded := func(s string) *Cursor {
+		return cursor(base64.RawURLEncoding.EncodeToString([]byte(s)))
+	}
+	num := func(n uint64) *uint64 {
+		return &n
+	}
+
+	tests := []struct {
+		name   string
+		cursor *Cursor
+		want   *uint64
+		err    bool
+	}{
+		{name: "no cursor"},
+		{name: "opaque cursor", cursor: encoded("blk:1024"), want: num(1024)},
+		{name: "legacy hex cursor", cursor: cursor("0x400"), want: num(1024)},
+		{name: "invalid hex cursor", cursor: cursor("0xzz"), err: true},
+		{name: "not encoded", cursor: cursor("1024"), err: true},
+		{name: "foreign prefix", cursor: encoded("trx:1024"), err: true},
+		{name: "invalid number", cursor: encoded("blk:-1"), err: true},
+		{name: "empty number", cursor: encoded("blk:"), err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			num, err := decodeBlockCursor(tt.cursor)
+			if tt.err {
+				g.Expect(err).NotTo(gomega.BeNil())
+				return
+			}
+
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(num).To(gomega.Equal(tt.want))
+		})
+	}
+}
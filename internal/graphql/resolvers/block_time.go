@@ -0,0 +1,81 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+)
+
+// blockTimeStatsMaxWindow represents the max number of blocks the block time stats are calculated on.
+const blockTimeStatsMaxWindow = 10000
+
+// DailyBlockTime represents a resolvable daily aggregation of the block time.
+type DailyBlockTime struct {
+	types.DailyBlockTime
+}
+
+// BlockTimeStats resolves statistics of the block time and the time to finality
+// over the given number of the most recent blocks.
+func (rs *rootResolver) BlockTimeStats(ctx context.Context, args struct{ Window int32 }) (*types.BlockTimeStats, error) {
+	if args.Window < 2 || args.Window > blockTimeStatsMaxWindow {
+		return nil, fmt.Errorf("window must be between 2 and %d blocks", blockTimeStatsMaxWindow)
+	}
+	return repository.R().BlockTimeStats(ctx, args.Window)
+}
+
+// BlockTimeDaily resolves list of daily aggregations of the block time and the time to finality.
+func (rs *rootResolver) BlockTimeDaily(ctx context.Context, args struct {
+	From *string
+	To   *string
+}) ([]*DailyBlockTime, error) {
+	from, to, err := trxVolumeRange(args)
+	if err != nil {
+		return nil, err
+	}
+
+	dl, err := repository.R().BlockTimeDaily(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*DailyBlockTime, len(dl))
+	for i, v := range dl {
+		list[i] = &DailyBlockTime{*v}
+	}
+	return list, nil
+}
+
+// Blocks resolves the number of blocks created on the day.
+func (dbt *DailyBlockTime) Blocks() int32 {
+	return int32(dbt.DailyBlockTime.Blocks)
+}
+
+// AvgBlockTime resolves the average time between blocks in seconds.
+func (dbt *DailyBlockTime) AvgBlockTime() float64 {
+	return dbt.DailyBlockTime.AvgBlockTime / 1000
+}
+
+// MaxBlockTime resolves the longest time between blocks in seconds.
+func (dbt *DailyBlockTime) MaxBlockTime() float64 {
+	return float64(dbt.DailyBlockTime.MaxBlockTime) / 1000
+}
+
+// AvgTtf resolves the average observed time to finality in seconds.
+func (dbt *DailyBlockTime) AvgTtf() *float64 {
+	if dbt.DailyBlockTime.AvgTtf == nil {
+		return nil
+	}
+	val := *dbt.DailyBlockTime.AvgTtf / 1000
+	return &val
+}
+
+// MaxTtf resolves the longest observed time to finality in seconds.
+func (dbt *DailyBlockTime) MaxTtf() *float64 {
+	if dbt.DailyBlockTime.MaxTtf == nil {
+		return nil
+	}
+	val := float64(*dbt.DailyBlockTime.MaxTtf) / 1000
+	return &val
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"crypto/sha256"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
@@ -68,6 +69,10 @@ type ContractValidationInput struct {
 	// during the contract compilation.
 	OptimizeRuns int32 `json:"optimizeRuns"`
 
+	// CompilerVersion represents an optional version of the Solidity
+	// compiler used to compile the contract, i.e. "0.5.17".
+	CompilerVersion *string `json:"compilerVersion,omitempty"`
+
 	// SourceCode represents the Solidity source code to be validated.
 	SourceCode string `json:"sourceCode"`
 }
@@ -78,11 +83,26 @@ func NewContract(con *types.Contract) *Contract {
 }
 
 // DeployedBy resolves the deployment transaction of the contract.
-func (con *Contract) DeployedBy() (*Transaction, error) {
-	tr, err := repository.R().Transaction(&con.TransactionHash)
+func (con *Contract) DeployedBy(ctx context.Context) (*Transaction, error) {
+	tr, err := repository.R().Transaction(ctx, &con.TransactionHash)
 	return NewTransaction(tr), err
 }
 
+// Deployer resolves the address of the account which deployed the contract.
+// Contracts recorded before the deployer has been tracked
+// get the address from the deployment transaction.
+func (con *Contract) Deployer(ctx context.Context) (common.Address, error) {
+	if con.Contract.Deployer != nil {
+		return *con.Contract.Deployer, nil
+	}
+
+	tr, err := repository.R().Transaction(ctx, &con.TransactionHash)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return tr.From, nil
+}
+
 // sanitizeStringOption sanitizes and validates optional string value from the
 // smart contract validation check.
 func sanitizeStringOption(o *string, length int) (bool, *string) {
@@ -131,6 +151,11 @@ func isValidationValid(in *ContractValidationInput) error {
 		return fmt.Errorf("invalid version information provided")
 	}
 
+	// validate the compiler version syntax
+	if in.CompilerVersion != nil && !scVersionSyntaxRegexp.MatchString(*in.CompilerVersion) {
+		return fmt.Errorf("invalid compiler version provided")
+	}
+
 	// validate the version syntax
 	if in.OptimizeRuns < 0 {
 		return fmt.Errorf("invalid number of optimization runs provided")
@@ -154,6 +179,12 @@ func updateContractFromInput(con *ContractValidationInput, sc *types.Contract) {
 	sc.IsOptimized = con.Optimized
 	sc.OptimizeRuns = con.OptimizeRuns
 
+	// pass the requested compiler version; the validation replaces it
+	// with the real compiler information on success
+	if con.CompilerVersion != nil {
+		sc.Compiler = *con.CompilerVersion
+	}
+
 	// pass the intended name
 	if con.Name != nil {
 		sc.Name = *con.Name
@@ -178,7 +209,11 @@ func updateContractFromInput(con *ContractValidationInput, sc *types.Contract) {
 // ValidateContract resolves smart contract source code vs. deployed byte code and marks
 // the contract as validated if the match is found. Peer API points are ringed on success
 // to notify them about the change.
-func (rs *rootResolver) ValidateContract(args *struct{ Contract ContractValidationInput }) (*Contract, error) {
+func (rs *rootResolver) ValidateContract(ctx context.Context, args *struct{ Contract ContractValidationInput }) (*Contract, error) {
+	if err := requireRole(ctx, cfg.Server.Auth.WriteRole); err != nil {
+		return nil, err
+	}
+
 	// validate the input
 	if err := isValidationValid(&args.Contract); err != nil {
 		log.Errorf("can not validate contract, validation request is not valid; %s", err.Error())
@@ -186,7 +221,7 @@ func (rs *rootResolver) ValidateContract(args *struct{ Contract ContractValidati
 	}
 
 	// get a contract to be validated if any
-	sc, err := repository.R().Contract(&args.Contract.Address)
+	sc, err := repository.R().Contract(ctx, &args.Contract.Address)
 	if err != nil {
 		log.Errorf("contract [%s] not found", args.Contract.Address.String())
 		return nil, err
@@ -204,7 +239,7 @@ func (rs *rootResolver) ValidateContract(args *struct{ Contract ContractValidati
 	updateContractFromInput(&args.Contract, sc)
 
 	// do the validation
-	if err := repository.R().ValidateContract(sc); err != nil {
+	if err := repository.R().ValidateContract(ctx, sc); err != nil {
 		log.Errorf("contract validation failed; %s", err.Error())
 		return nil, err
 	}
@@ -0,0 +1,30 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ContractCall resolves a read-only call of a contract with the raw call data.
+func (rs *rootResolver) ContractCall(ctx context.Context, args *struct {
+	To    common.Address
+	Data  hexutil.Bytes
+	Block *hexutil.Uint64
+}) (hexutil.Bytes, error) {
+	return repository.R().ContractCall(ctx, &args.To, args.Data, args.Block)
+}
+
+// ContractCallTyped resolves a read-only call of the named function of a contract
+// using the stored contract ABI to encode the arguments and decode the output.
+func (rs *rootResolver) ContractCallTyped(ctx context.Context, args *struct {
+	To       common.Address
+	Function string
+	Args     *string
+	Block    *hexutil.Uint64
+}) ([]types.DecodedArgument, error) {
+	return repository.R().ContractCallAbi(ctx, &args.To, args.Function, args.Args, args.Block)
+}
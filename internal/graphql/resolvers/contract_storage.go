@@ -0,0 +1,59 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// ContractStorageVariable represents a resolvable state variable of a smart contract.
+type ContractStorageVariable struct {
+	types.ContractStorageValue
+}
+
+// ByteCode resolves the runtime byte code deployed on the contract address.
+func (con *Contract) ByteCode(ctx context.Context) (hexutil.Bytes, error) {
+	return repository.R().ContractByteCode(ctx, &con.Address)
+}
+
+// StorageAt resolves the value of the given storage slot of the contract
+// at the given block, or at the latest block.
+func (con *Contract) StorageAt(ctx context.Context, args struct {
+	Slot  common.Hash
+	Block *hexutil.Uint64
+}) (common.Hash, error) {
+	return repository.R().ContractStorageAt(ctx, &con.Address, args.Slot, args.Block)
+}
+
+// StorageLayout resolves the state variables of the contract decoded from the contract
+// storage at the given block, or at the latest block. Nil is returned if the storage
+// layout of the contract is not known.
+func (con *Contract) StorageLayout(ctx context.Context, args struct{ Block *hexutil.Uint64 }) (*[]*ContractStorageVariable, error) {
+	if len(con.Contract.StorageLayout) == 0 {
+		return nil, nil
+	}
+
+	vars, err := repository.R().ContractStorage(ctx, &con.Contract, args.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*ContractStorageVariable, len(vars))
+	for i, v := range vars {
+		list[i] = &ContractStorageVariable{v}
+	}
+	return &list, nil
+}
+
+// Slot resolves the storage slot the state variable starts at.
+func (csv *ContractStorageVariable) Slot() common.Hash {
+	idx, ok := new(big.Int).SetString(csv.ContractStorageVariable.Slot, 10)
+	if !ok {
+		return common.Hash{}
+	}
+	return common.BigToHash(idx)
+}
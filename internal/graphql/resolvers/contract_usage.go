@@ -0,0 +1,103 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// topContractsByGasMaxCount represents the max number of contracts in the gas used ranking.
+const topContractsByGasMaxCount = 100
+
+// ContractDailyUsage represents a resolvable daily usage aggregation of a smart contract.
+type ContractDailyUsage struct {
+	types.ContractDailyUsage
+}
+
+// ContractGasRank represents a resolvable smart contract ranked by the gas used.
+type ContractGasRank struct {
+	types.ContractGasRank
+}
+
+// UsageStats resolves the list of daily usage aggregations of the contract.
+func (con *Contract) UsageStats(ctx context.Context, args struct {
+	From *string
+	To   *string
+}) ([]*ContractDailyUsage, error) {
+	from, to, err := trxVolumeRange(args)
+	if err != nil {
+		return nil, err
+	}
+
+	dl, err := repository.R().ContractUsage(ctx, &con.Address, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*ContractDailyUsage, len(dl))
+	for i, v := range dl {
+		list[i] = &ContractDailyUsage{*v}
+	}
+	return list, nil
+}
+
+// Calls resolves the number of calls of the contract made on the day.
+func (cdu *ContractDailyUsage) Calls() hexutil.Uint64 {
+	return hexutil.Uint64(cdu.ContractDailyUsage.Calls)
+}
+
+// Callers resolves the number of unique accounts calling the contract on the day.
+func (cdu *ContractDailyUsage) Callers() hexutil.Uint64 {
+	return hexutil.Uint64(cdu.ContractDailyUsage.Callers)
+}
+
+// GasUsed resolves the amount of gas consumed by the calls of the contract on the day.
+func (cdu *ContractDailyUsage) GasUsed() hexutil.Uint64 {
+	return hexutil.Uint64(cdu.ContractDailyUsage.Gas)
+}
+
+// TopContractsByGas resolves the list of the contracts with the highest gas used.
+func (rs *rootResolver) TopContractsByGas(ctx context.Context, args struct{ Count int32 }) ([]*ContractGasRank, error) {
+	if args.Count <= 0 || args.Count > topContractsByGasMaxCount {
+		args.Count = topContractsByGasMaxCount
+	}
+
+	rl, err := repository.R().TopContractsByGas(ctx, args.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*ContractGasRank, len(rl))
+	for i, v := range rl {
+		list[i] = &ContractGasRank{*v}
+	}
+	return list, nil
+}
+
+// Address resolves the address of the ranked contract.
+func (cgr *ContractGasRank) Address() common.Address {
+	return common.HexToAddress(cgr.ContractGasRank.Contract)
+}
+
+// Contract resolves the details of the ranked contract.
+func (cgr *ContractGasRank) Contract(ctx context.Context) (*Contract, error) {
+	adr := cgr.Address()
+	con, err := repository.R().Contract(ctx, &adr)
+	if err != nil {
+		return nil, err
+	}
+	return NewContract(con), nil
+}
+
+// Calls resolves the number of calls of the contract.
+func (cgr *ContractGasRank) Calls() hexutil.Uint64 {
+	return hexutil.Uint64(cgr.ContractGasRank.Calls)
+}
+
+// GasUsed resolves the amount of gas consumed by the calls of the contract.
+func (cgr *ContractGasRank) GasUsed() hexutil.Uint64 {
+	return hexutil.Uint64(cgr.ContractGasRank.Gas)
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/repository"
 	"github.com/ethereum/go-ethereum/common"
@@ -19,9 +20,9 @@ func (rs *rootResolver) State() (CurrentState, error) {
 }
 
 // SealedEpoch resolves the most recent sealed epoch details.
-func (cst CurrentState) SealedEpoch() (Epoch, error) {
+func (cst CurrentState) SealedEpoch(ctx context.Context) (Epoch, error) {
 	// get the sealed epoch
-	e, err := repository.R().CurrentSealedEpoch()
+	e, err := repository.R().CurrentSealedEpoch(ctx)
 	if err != nil {
 		return Epoch{}, err
 	}
@@ -29,20 +30,20 @@ func (cst CurrentState) SealedEpoch() (Epoch, error) {
 }
 
 // Validators resolves the number of validators active in the network.
-func (cst CurrentState) Validators() (hexutil.Uint64, error) {
-	val, err := repository.R().ValidatorsCount()
+func (cst CurrentState) Validators(ctx context.Context) (hexutil.Uint64, error) {
+	val, err := repository.R().ValidatorsCount(ctx)
 	return hexutil.Uint64(val), err
 }
 
 // Accounts resolves the number of accounts participating on chain transactions.
-func (cst CurrentState) Accounts() (hexutil.Uint64, error) {
-	return repository.R().AccountsActive()
+func (cst CurrentState) Accounts(ctx context.Context) (hexutil.Uint64, error) {
+	return repository.R().AccountsActive(ctx)
 }
 
 // Blocks resolves the total number of blocks in the chain.
-func (cst CurrentState) Blocks() (hexutil.Big, error) {
+func (cst CurrentState) Blocks(ctx context.Context) (hexutil.Big, error) {
 	// get the block height of the chain
-	h, err := repository.R().BlockHeight()
+	h, err := repository.R().BlockHeight(ctx)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -60,11 +61,11 @@ func (cst CurrentState) SfcContractAddress() common.Address {
 }
 
 // SfcLockingEnabled indicates if the stake locking has been enabled in SFC contract.
-func (cst CurrentState) SfcLockingEnabled() (bool, error) {
-	return repository.R().LockingAllowed()
+func (cst CurrentState) SfcLockingEnabled(ctx context.Context) (bool, error) {
+	return repository.R().LockingAllowed(ctx)
 }
 
 // SfcVersion resolves the current version of the SFC contract on the connected node.
-func (cst CurrentState) SfcVersion() (hexutil.Uint64, error) {
-	return repository.R().SfcVersion()
+func (cst CurrentState) SfcVersion(ctx context.Context) (hexutil.Uint64, error) {
+	return repository.R().SfcVersion(ctx)
 }
@@ -2,10 +2,15 @@
 package resolvers
 
 import (
-	"errors"
+	"fantom-api-graphql/internal/types"
+	"regexp"
 	"strconv"
 )
 
+// reCursor represents the format of a list cursor; all the lists encode their positions
+// into short strings of hex, or decimal numbers, or URL safe base64 encoded values.
+var reCursor = regexp.MustCompile(`^[\w\-.:=+/]{1,128}$`)
+
 // Cursor represents a string key of an element position in a sequential list of edges.
 type Cursor string
 
@@ -19,11 +24,14 @@ func (c *Cursor) UnmarshalGraphQL(input interface{}) error {
 	var err error
 	switch input := input.(type) {
 	case string:
+		if !reCursor.MatchString(input) {
+			return types.Errorf(types.ErrCodeInvalidCursor, "invalid cursor %q; unexpected format", input)
+		}
 		*c = Cursor(input)
 	case int32:
 		*c = Cursor(strconv.Itoa(int(input)))
 	default:
-		err = errors.New("wrong cursor type")
+		err = types.NewError(types.ErrCodeInvalidCursor, "wrong cursor type")
 	}
 	return err
 }
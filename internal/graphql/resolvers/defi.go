@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -22,9 +23,9 @@ func NewDefiToken(tk *types.DefiToken) *DefiToken {
 }
 
 // DefiTokens resolves list of DeFi tokens available for the DeFi functions.
-func (rs *rootResolver) DefiTokens() ([]*DefiToken, error) {
+func (rs *rootResolver) DefiTokens(ctx context.Context) ([]*DefiToken, error) {
 	// pass the call to repository
-	tkList, err := repository.R().DefiTokens()
+	tkList, err := repository.R().DefiTokens(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -38,31 +39,31 @@ func (rs *rootResolver) DefiTokens() ([]*DefiToken, error) {
 }
 
 // DefiNativeToken resolves the native FTM wrapper token.
-func (rs *rootResolver) DefiNativeToken() *ERC20Token {
+func (rs *rootResolver) DefiNativeToken(ctx context.Context) *ERC20Token {
 	// get the token address
-	adr, err := repository.R().NativeTokenAddress()
+	adr, err := repository.R().NativeTokenAddress(ctx)
 	if err != nil {
 		return nil
 	}
-	return NewErc20Token(adr)
+	return NewErc20Token(ctx, adr)
 }
 
 // Price resolves the value of the token in ref. denomination
 // using on-chain price oracle.
-func (dt *DefiToken) Price() (hexutil.Big, error) {
-	return repository.R().DefiTokenPrice(&dt.Address)
+func (dt *DefiToken) Price(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().DefiTokenPrice(ctx, &dt.Address)
 }
 
 // AvailableBalance resolves the total amount of ERC20 tokens
 // available to the specified token holder.
-func (dt *DefiToken) AvailableBalance(args *struct{ Owner common.Address }) (hexutil.Big, error) {
-	return repository.R().Erc20BalanceOf(&dt.Address, &args.Owner)
+func (dt *DefiToken) AvailableBalance(ctx context.Context, args *struct{ Owner common.Address }) (hexutil.Big, error) {
+	return repository.R().Erc20BalanceOf(ctx, &dt.Address, &args.Owner)
 }
 
 // Allowance resolves the total amount of ERC20 tokens unlocked
 // by the token holder for DeFi operations.
-func (dt *DefiToken) Allowance(args *struct{ Owner common.Address }) (hexutil.Big, error) {
-	return repository.R().Erc20Allowance(&dt.Address, &args.Owner, nil)
+func (dt *DefiToken) Allowance(ctx context.Context, args *struct{ Owner common.Address }) (hexutil.Big, error) {
+	return repository.R().Erc20Allowance(ctx, &dt.Address, &args.Owner, nil)
 }
 
 // CanWrapFTM signals if the token can be used to wrap native FTM
@@ -72,16 +73,16 @@ func (dt *DefiToken) CanWrapFTM() bool {
 }
 
 // TotalSupply represents the total amount of tokens on supply.
-func (dt *DefiToken) TotalSupply() (hexutil.Big, error) {
-	return repository.R().Erc20TotalSupply(&dt.Address)
+func (dt *DefiToken) TotalSupply(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().Erc20TotalSupply(ctx, &dt.Address)
 }
 
 // TotalDeposit represents the total amount of tokens deposited to fMint as collateral.
-func (dt *DefiToken) TotalDeposit() (hexutil.Big, error) {
-	return repository.R().FMintTokenTotalBalance(&dt.Address, types.DefiTokenTypeCollateral)
+func (dt *DefiToken) TotalDeposit(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().FMintTokenTotalBalance(ctx, &dt.Address, types.DefiTokenTypeCollateral)
 }
 
 // TotalDebt represents the total amount of tokens borrowed/minted on fMint.
-func (dt *DefiToken) TotalDebt() (hexutil.Big, error) {
-	return repository.R().FMintTokenTotalBalance(&dt.Address, types.DefiTokenTypeDebt)
+func (dt *DefiToken) TotalDebt(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().FMintTokenTotalBalance(ctx, &dt.Address, types.DefiTokenTypeDebt)
 }
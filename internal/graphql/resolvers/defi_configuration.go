@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -20,9 +21,9 @@ func NewDefiConfiguration(cf *types.DefiSettings) *DefiConfiguration {
 }
 
 // DefiConfiguration resolves the current DeFi contract settings.
-func (rs *rootResolver) DefiConfiguration() (*DefiConfiguration, error) {
+func (rs *rootResolver) DefiConfiguration(ctx context.Context) (*DefiConfiguration, error) {
 	// pass the call to repository
-	st, err := repository.R().DefiConfiguration()
+	st, err := repository.R().DefiConfiguration(ctx)
 	if err != nil {
 		return nil, err
 	}
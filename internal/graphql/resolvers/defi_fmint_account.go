@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -35,9 +36,9 @@ func NewFMintTokenBalance(owner common.Address, token common.Address, tp types.D
 }
 
 // FMintAccount resolves details of a DeFi account by its address.
-func (rs *rootResolver) FMintAccount(args *struct{ Owner common.Address }) (*FMintAccount, error) {
+func (rs *rootResolver) FMintAccount(ctx context.Context, args *struct{ Owner common.Address }) (*FMintAccount, error) {
 	// get the delegator detail from backend
-	ac, err := repository.R().FMintAccount(args.Owner)
+	ac, err := repository.R().FMintAccount(ctx, args.Owner)
 	if err != nil {
 		return nil, err
 	}
@@ -45,6 +46,15 @@ func (rs *rootResolver) FMintAccount(args *struct{ Owner common.Address }) (*FMi
 	return NewFMintAccount(ac), nil
 }
 
+// FMintAccount resolves the DeFi/fMint protocol account of the account.
+func (acc *Account) FMintAccount(ctx context.Context) (*FMintAccount, error) {
+	ac, err := repository.R().FMintAccount(ctx, acc.Address)
+	if err != nil {
+		return nil, err
+	}
+	return NewFMintAccount(ac), nil
+}
+
 // Collateral resolves the list of collateral token balance containers.
 func (fac *FMintAccount) Collateral() []*FMintTokenBalance {
 	// prep container and loop all the collateral addresses
@@ -69,39 +79,39 @@ func (fac *FMintAccount) Debt() []*FMintTokenBalance {
 
 // RewardsEarned resolves the total amount of rewards
 // accumulated on the account for the excessive collateral deposits.
-func (fac *FMintAccount) RewardsEarned() (hexutil.Big, error) {
-	return repository.R().FMintRewardsEarned(&fac.Address)
+func (fac *FMintAccount) RewardsEarned(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().FMintRewardsEarned(ctx, &fac.Address)
 }
 
 // RewardsStashed resolves the total amount of rewards
 // accumulated on the account in the stash.
-func (fac *FMintAccount) RewardsStashed() (hexutil.Big, error) {
-	return repository.R().FMintRewardsStashed(&fac.Address)
+func (fac *FMintAccount) RewardsStashed(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().FMintRewardsStashed(ctx, &fac.Address)
 }
 
 // CanClaimRewards resolves the fMint account flag for being allowed
 // to claim earned rewards.
-func (fac *FMintAccount) CanClaimRewards() (bool, error) {
-	return repository.R().FMintCanClaimRewards(&fac.Address)
+func (fac *FMintAccount) CanClaimRewards(ctx context.Context) (bool, error) {
+	return repository.R().FMintCanClaimRewards(ctx, &fac.Address)
 }
 
 // CanReceiveRewards resolves the fMint account flag for being eligible
 // to receive earned rewards. If the collateral to debt ration drop below
 // certain value, earned rewards are burned.
-func (fac *FMintAccount) CanReceiveRewards() (bool, error) {
-	return repository.R().FMintCanReceiveRewards(&fac.Address)
+func (fac *FMintAccount) CanReceiveRewards(ctx context.Context) (bool, error) {
+	return repository.R().FMintCanReceiveRewards(ctx, &fac.Address)
 }
 
 // CanPushNewRewards resolves the flag about the new rewards unlocked
 // and ready for push.
-func (fac *FMintAccount) CanPushNewRewards() (bool, error) {
-	return repository.R().FMintCanPushRewards()
+func (fac *FMintAccount) CanPushNewRewards(ctx context.Context) (bool, error) {
+	return repository.R().FMintCanPushRewards(ctx)
 }
 
 // Token resolves the token information from the related token address.
-func (mb *FMintTokenBalance) Token() (*DefiToken, error) {
+func (mb *FMintTokenBalance) Token(ctx context.Context) (*DefiToken, error) {
 	// get the token backend
-	tk, err := repository.R().DefiToken(&mb.TokenAddress)
+	tk, err := repository.R().DefiToken(ctx, &mb.TokenAddress)
 	if err != nil {
 		return nil, err
 	}
@@ -111,11 +121,20 @@ func (mb *FMintTokenBalance) Token() (*DefiToken, error) {
 }
 
 // Balance resolves the balance of the token for the related token address.
-func (mb *FMintTokenBalance) Balance() (hexutil.Big, error) {
-	return repository.R().FMintTokenBalance(&mb.OwnerAddress, &mb.TokenAddress, mb.Type)
+func (mb *FMintTokenBalance) Balance(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().FMintTokenBalance(ctx, &mb.OwnerAddress, &mb.TokenAddress, mb.Type)
 }
 
 // Value resolves the value of the token for the related token address in fUSD.
-func (mb *FMintTokenBalance) Value() (hexutil.Big, error) {
-	return repository.R().FMintTokenValue(&mb.OwnerAddress, &mb.TokenAddress, mb.Type)
+func (mb *FMintTokenBalance) Value(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().FMintTokenValue(ctx, &mb.OwnerAddress, &mb.TokenAddress, mb.Type)
+}
+
+// LiquidationPrice resolves the price of the collateral token on which the account
+// collateral to debt ratio drops below the lowest allowed ratio.
+func (mb *FMintTokenBalance) LiquidationPrice(ctx context.Context) (*hexutil.Big, error) {
+	if mb.Type != types.DefiTokenTypeCollateral {
+		return nil, nil
+	}
+	return repository.R().FMintLiquidationPrice(ctx, &mb.OwnerAddress, &mb.TokenAddress)
 }
@@ -0,0 +1,108 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"time"
+)
+
+// DelegationLock represents resolvable lock of a delegation stake.
+type DelegationLock struct {
+	types.DelegationLock
+}
+
+// DelegationLockEvent represents resolvable delegation lockup or unlock event.
+type DelegationLockEvent struct {
+	types.DelegationLockEvent
+}
+
+// Lock resolves the current lock of the delegation stake.
+func (del Delegation) Lock(ctx context.Context) (*DelegationLock, error) {
+	lock, err := del.DelegationLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if lock == nil {
+		return &DelegationLock{}, nil
+	}
+	return &DelegationLock{DelegationLock: *lock}, nil
+}
+
+// LockHistory resolves the most recent lockup and unlock events of the delegation.
+func (del Delegation) LockHistory(ctx context.Context, args struct{ Count int32 }) ([]*DelegationLockEvent, error) {
+	// limit query size
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	if args.Count < 0 {
+		args.Count = -args.Count
+	}
+
+	hist, err := repository.R().DelegationLockHistory(ctx, &del.Address, del.Delegation.ToStakerId, args.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*DelegationLockEvent, len(hist))
+	for i, dle := range hist {
+		list[i] = &DelegationLockEvent{DelegationLockEvent: *dle}
+	}
+	return list, nil
+}
+
+// IsLocked signals if the lock is active right now.
+func (dl *DelegationLock) IsLocked() bool {
+	return 0 > zeroInt.Cmp(dl.DelegationLock.LockedAmount.ToInt()) &&
+		uint64(dl.DelegationLock.LockedUntil) > uint64(time.Now().UTC().Unix()-delegationLockSafetyWallSec)
+}
+
+// activeDuration provides the duration of the lock, if the lock is active.
+func (dl *DelegationLock) activeDuration() hexutil.Uint64 {
+	if !dl.IsLocked() {
+		return 0
+	}
+	return dl.DelegationLock.Duration
+}
+
+// RewardRatio resolves the share of full rewards paid to the stake
+// under the current lock.
+func (dl *DelegationLock) RewardRatio(ctx context.Context) (float64, error) {
+	ratio, err := repository.R().DelegationLockRewardRatio(ctx, dl.activeDuration())
+	if err != nil {
+		return 0, err
+	}
+	return sfcRatioToFloat(ratio), nil
+}
+
+// AprBonus resolves the multiplier applied to the base APR
+// of unlocked stake by the current lock.
+func (dl *DelegationLock) AprBonus(ctx context.Context) (float64, error) {
+	base, err := repository.R().DelegationLockRewardRatio(ctx, 0)
+	if err != nil {
+		return 0, err
+	}
+	if base.Sign() == 0 {
+		return 1, nil
+	}
+
+	ratio, err := repository.R().DelegationLockRewardRatio(ctx, dl.activeDuration())
+	if err != nil {
+		return 0, err
+	}
+
+	val, _ := new(big.Float).Quo(new(big.Float).SetInt(ratio), new(big.Float).SetInt(base)).Float64()
+	return val, nil
+}
+
+// sfcRatioToFloat converts an SFC ratio with 18 decimals to a float value.
+func sfcRatioToFloat(ratio *big.Int) float64 {
+	val, _ := new(big.Float).Quo(new(big.Float).SetInt(ratio), new(big.Float).SetInt(weiToFtmDecimals)).Float64()
+	return val
+}
+
+// LogIndex resolves the index of the event log in the transaction.
+func (dle *DelegationLockEvent) LogIndex() int32 {
+	return int32(dle.DelegationLockEvent.LogIndex)
+}
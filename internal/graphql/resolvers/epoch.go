@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -13,8 +14,8 @@ type Epoch struct {
 }
 
 // Epoch resolves information about epoch of the given id.
-func (rs *rootResolver) Epoch(args *struct{ Id *hexutil.Uint64 }) (Epoch, error) {
-	epo, err := repository.R().Epoch(args.Id)
+func (rs *rootResolver) Epoch(ctx context.Context, args *struct{ Id *hexutil.Uint64 }) (Epoch, error) {
+	epo, err := repository.R().Epoch(ctx, args.Id)
 	if err != nil {
 		return Epoch{}, err
 	}
@@ -22,7 +23,7 @@ func (rs *rootResolver) Epoch(args *struct{ Id *hexutil.Uint64 }) (Epoch, error)
 }
 
 // Duration resolves the time length of the given epoch
-func (ep Epoch) Duration() hexutil.Uint64 {
+func (ep Epoch) Duration(ctx context.Context) hexutil.Uint64 {
 	// no length for the first epochs
 	if uint64(ep.Id) < 2 {
 		return 0
@@ -30,7 +31,7 @@ func (ep Epoch) Duration() hexutil.Uint64 {
 
 	// get the previous epoch so we can compare end times
 	pid := uint64(ep.Id) - 1
-	prev, err := repository.R().Epoch((*hexutil.Uint64)(&pid))
+	prev, err := repository.R().Epoch(ctx, (*hexutil.Uint64)(&pid))
 	if err != nil {
 		return 0
 	}
@@ -41,3 +42,9 @@ func (ep Epoch) Duration() hexutil.Uint64 {
 	}
 	return ep.EndTime - prev.EndTime
 }
+
+// ValidatorRewards resolves the list of validators of the epoch
+// with their received stake and rewards.
+func (ep Epoch) ValidatorRewards(ctx context.Context) ([]types.EpochValidator, error) {
+	return repository.R().EpochValidators(ctx, ep.Id)
+}
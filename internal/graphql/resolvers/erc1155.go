@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -15,7 +16,7 @@ type ERC1155Contract struct {
 
 // NewErc1155Contract creates a new instance of resolvable ERC1155 contract.
 func NewErc1155Contract(adr *common.Address) *ERC1155Contract {
-	return &ERC1155Contract{ types.Erc1155Contract{Address: *adr} }
+	return &ERC1155Contract{types.Erc1155Contract{Address: *adr}}
 }
 
 // Erc1155Contract resolves an instance of ERC1155 contract if available.
@@ -24,9 +25,9 @@ func (rs *rootResolver) Erc1155Contract(args *struct{ Address common.Address })
 }
 
 // Uri provides URI of Metadata JSON Schema of the token.
-func (token *ERC1155Contract) Uri(args *struct{ TokenId hexutil.Big }) (*string, error) {
+func (token *ERC1155Contract) Uri(ctx context.Context, args *struct{ TokenId hexutil.Big }) (*string, error) {
 	tokenId := big.Int(args.TokenId)
-	uri, err := repository.R().Erc1155Uri(&token.Address, &tokenId)
+	uri, err := repository.R().Erc1155Uri(ctx, &token.Address, &tokenId)
 	if err != nil { // optional - ignore err, return null
 		return nil, nil
 	} else {
@@ -35,9 +36,12 @@ func (token *ERC1155Contract) Uri(args *struct{ TokenId hexutil.Big }) (*string,
 }
 
 // BalanceOf resolves the available balance of the given token for a user.
-func (token *ERC1155Contract) BalanceOf(args *struct{ Owner common.Address; TokenId hexutil.Big }) (hexutil.Big, error) {
+func (token *ERC1155Contract) BalanceOf(ctx context.Context, args *struct {
+	Owner   common.Address
+	TokenId hexutil.Big
+}) (hexutil.Big, error) {
 	tokenId := big.Int(args.TokenId)
-	balance,err := repository.R().Erc1155BalanceOf(&token.Address, &args.Owner, &tokenId)
+	balance, err := repository.R().Erc1155BalanceOf(ctx, &token.Address, &args.Owner, &tokenId)
 	if err != nil || balance == nil {
 		return hexutil.Big{}, err
 	} else {
@@ -46,14 +50,17 @@ func (token *ERC1155Contract) BalanceOf(args *struct{ Owner common.Address; Toke
 }
 
 // BalanceOfBatch resolves the available balances of the given tokens and owners.
-func (token *ERC1155Contract) BalanceOfBatch(args *struct{ Owners []common.Address; TokenIds []hexutil.Big }) ([]hexutil.Big, error) {
+func (token *ERC1155Contract) BalanceOfBatch(ctx context.Context, args *struct {
+	Owners   []common.Address
+	TokenIds []hexutil.Big
+}) ([]hexutil.Big, error) {
 	tokenIds := make([]*big.Int, len(args.TokenIds))
 	for i, tokenId := range args.TokenIds {
 		value := big.Int(tokenId)
 		tokenIds[i] = &value
 	}
 
-	balances,err := repository.R().Erc1155BalanceOfBatch(&token.Address, &args.Owners, tokenIds)
+	balances, err := repository.R().Erc1155BalanceOfBatch(ctx, &token.Address, &args.Owners, tokenIds)
 	if err != nil || balances == nil {
 		return nil, err
 	} else {
@@ -66,8 +73,11 @@ func (token *ERC1155Contract) BalanceOfBatch(args *struct{ Owners []common.Addre
 }
 
 // IsApprovedForAll provides information about operator approved to manipulate with tokens of given owner.
-func (token *ERC1155Contract) IsApprovedForAll(args *struct{ Owner common.Address; Operator common.Address }) (*bool, error) {
-	isApproved, err := repository.R().Erc1155IsApprovedForAll(&token.Address, &args.Owner, &args.Operator)
+func (token *ERC1155Contract) IsApprovedForAll(ctx context.Context, args *struct {
+	Owner    common.Address
+	Operator common.Address
+}) (*bool, error) {
+	isApproved, err := repository.R().Erc1155IsApprovedForAll(ctx, &token.Address, &args.Owner, &args.Operator)
 	if err != nil { // ignore err, return null
 		return nil, nil
 	} else {
@@ -0,0 +1,49 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ERC1155Balance represents a resolvable balance of an ERC1155 token type held by an account.
+type ERC1155Balance struct {
+	// Contract represents the address of the ERC1155 contract.
+	Contract common.Address
+
+	// TokenId represents the identifier of the token type.
+	TokenId hexutil.Big
+
+	// Balance represents the current amount of the token type held by the account.
+	Balance hexutil.Big
+}
+
+// Token resolves the detail of the ERC1155 contract managing the token.
+func (bal *ERC1155Balance) Token() *ERC1155Contract {
+	return NewErc1155Contract(&bal.Contract)
+}
+
+// Erc1155Balances resolves the list of ERC1155 token types held by the account
+// along with the current balance. Token types with zero balance are skipped.
+func (acc *Account) Erc1155Balances(ctx context.Context, args struct{ Count int32 }) ([]*ERC1155Balance, error) {
+	// limit query size
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	// get the list of token types the account holds
+	bl, err := repository.R().Erc1155Balances(ctx, &acc.Address, args.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*ERC1155Balance, len(bl))
+	for i, bal := range bl {
+		list[i] = &ERC1155Balance{
+			Contract: bal.Contract,
+			TokenId:  bal.TokenId,
+			Balance:  bal.Balance,
+		}
+	}
+	return list, nil
+}
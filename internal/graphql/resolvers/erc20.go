@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -16,9 +17,9 @@ type ERC20Token struct {
 // NewErc20Token creates a new instance of resolvable ERC20 token, it also validates
 // the token existence by loading the total supply of the token
 // before making a resolvable instance.
-func NewErc20Token(adr *common.Address) *ERC20Token {
+func NewErc20Token(ctx context.Context, adr *common.Address) *ERC20Token {
 	// get the total supply of the token and validate the token existence
-	erc20, err := repository.R().Erc20Token(adr)
+	erc20, err := repository.R().Erc20Token(ctx, adr)
 	if err != nil {
 		return nil
 	}
@@ -27,17 +28,17 @@ func NewErc20Token(adr *common.Address) *ERC20Token {
 }
 
 // Erc20Token resolves an instance of ERC20 token if available.
-func (rs *rootResolver) Erc20Token(args *struct{ Token common.Address }) *ERC20Token {
-	return NewErc20Token(&args.Token)
+func (rs *rootResolver) Erc20Token(ctx context.Context, args *struct{ Token common.Address }) *ERC20Token {
+	return NewErc20Token(ctx, &args.Token)
 }
 
 // FMintTokenAllowance resolves the amount of ERC20 tokens unlocked
 // by the token owner for DeFi operations.
-func (rs *rootResolver) FMintTokenAllowance(args *struct {
+func (rs *rootResolver) FMintTokenAllowance(ctx context.Context, args *struct {
 	Owner common.Address
 	Token common.Address
 }) hexutil.Big {
-	a, err := repository.R().Erc20Allowance(&args.Token, &args.Owner, nil)
+	a, err := repository.R().Erc20Allowance(ctx, &args.Token, &args.Owner, nil)
 	if err != nil {
 		log.Errorf("allowance of %s for %s not known; %s", args.Token.String(), args.Owner.String(), err.Error())
 		return hexutil.Big{}
@@ -46,8 +47,8 @@ func (rs *rootResolver) FMintTokenAllowance(args *struct {
 }
 
 // ErcTotalSupply resolves the current total supply of the specified token.
-func (rs *rootResolver) ErcTotalSupply(args *struct{ Token common.Address }) hexutil.Big {
-	s, err := repository.R().Erc20TotalSupply(&args.Token)
+func (rs *rootResolver) ErcTotalSupply(ctx context.Context, args *struct{ Token common.Address }) hexutil.Big {
+	s, err := repository.R().Erc20TotalSupply(ctx, &args.Token)
 	if err != nil {
 		log.Errorf("total supply of %s not known; %s", args.Token.String(), err.Error())
 		return hexutil.Big{}
@@ -57,11 +58,11 @@ func (rs *rootResolver) ErcTotalSupply(args *struct{ Token common.Address }) hex
 
 // ErcTokenBalance resolves the current available balance of the specified token
 // for the specified owner.
-func (rs *rootResolver) ErcTokenBalance(args *struct {
+func (rs *rootResolver) ErcTokenBalance(ctx context.Context, args *struct {
 	Owner common.Address
 	Token common.Address
 }) hexutil.Big {
-	b, err := repository.R().Erc20BalanceOf(&args.Token, &args.Owner)
+	b, err := repository.R().Erc20BalanceOf(ctx, &args.Token, &args.Owner)
 	if err != nil {
 		log.Errorf("balance of %s for %s not known; %s", args.Token.String(), args.Owner.String(), err.Error())
 		return hexutil.Big{}
@@ -71,12 +72,12 @@ func (rs *rootResolver) ErcTokenBalance(args *struct {
 
 // ErcTokenAllowance resolves the current amount of ERC20 tokens unlocked
 // by the token owner for the spender to be manipulated with.
-func (rs *rootResolver) ErcTokenAllowance(args *struct {
+func (rs *rootResolver) ErcTokenAllowance(ctx context.Context, args *struct {
 	Token   common.Address
 	Owner   common.Address
 	Spender common.Address
 }) hexutil.Big {
-	a, err := repository.R().Erc20Allowance(&args.Token, &args.Owner, &args.Spender)
+	a, err := repository.R().Erc20Allowance(ctx, &args.Token, &args.Owner, &args.Spender)
 	if err != nil {
 		log.Errorf("allowance of %s for %s -> %s not known; %s", args.Token.String(), args.Owner.String(), args.Spender.String(), err.Error())
 		return hexutil.Big{}
@@ -85,8 +86,8 @@ func (rs *rootResolver) ErcTokenAllowance(args *struct {
 }
 
 // TotalSupply resolves the total supply of the given ERC20 token.
-func (token *ERC20Token) TotalSupply() hexutil.Big {
-	s, err := repository.R().Erc20TotalSupply(&token.Address)
+func (token *ERC20Token) TotalSupply(ctx context.Context) hexutil.Big {
+	s, err := repository.R().Erc20TotalSupply(ctx, &token.Address)
 	if err != nil {
 		log.Errorf("total supply of %s not known; %s", token.Address.String(), err.Error())
 		return hexutil.Big{}
@@ -95,8 +96,8 @@ func (token *ERC20Token) TotalSupply() hexutil.Big {
 }
 
 // BalanceOf resolves the available balance of the given ERC20 token to a user.
-func (token *ERC20Token) BalanceOf(args *struct{ Owner common.Address }) hexutil.Big {
-	b, err := repository.R().Erc20BalanceOf(&token.Address, &args.Owner)
+func (token *ERC20Token) BalanceOf(ctx context.Context, args *struct{ Owner common.Address }) hexutil.Big {
+	b, err := repository.R().Erc20BalanceOf(ctx, &token.Address, &args.Owner)
 	if err != nil {
 		log.Errorf("balance of %s for %s not known; %s", token.Address.String(), args.Owner.String(), err.Error())
 		return hexutil.Big{}
@@ -105,11 +106,11 @@ func (token *ERC20Token) BalanceOf(args *struct{ Owner common.Address }) hexutil
 }
 
 // Allowance resolves the unlocked allowance of the given ERC20 token from the owner to spender.
-func (token *ERC20Token) Allowance(args *struct {
+func (token *ERC20Token) Allowance(ctx context.Context, args *struct {
 	Owner   common.Address
 	Spender common.Address
 }) hexutil.Big {
-	a, err := repository.R().Erc20Allowance(&token.Address, &args.Owner, &args.Spender)
+	a, err := repository.R().Erc20Allowance(ctx, &token.Address, &args.Owner, &args.Spender)
 	if err != nil {
 		log.Errorf("allowance of %s for %s -> %s not known; %s", token.Address.String(), args.Owner.String(), args.Spender.String(), err.Error())
 		return hexutil.Big{}
@@ -118,13 +119,13 @@ func (token *ERC20Token) Allowance(args *struct {
 }
 
 // LogoURL resolves an URL of the token logo.
-func (token *ERC20Token) LogoURL() string {
-	return repository.R().Erc20LogoURL(&token.Address)
+func (token *ERC20Token) LogoURL(ctx context.Context) string {
+	return repository.R().Erc20LogoURL(ctx, &token.Address)
 }
 
 // TotalDeposit represents the total amount of tokens deposited to fMint as collateral.
-func (token *ERC20Token) TotalDeposit() hexutil.Big {
-	d, err := repository.R().FMintTokenTotalBalance(&token.Address, types.DefiTokenTypeCollateral)
+func (token *ERC20Token) TotalDeposit(ctx context.Context) hexutil.Big {
+	d, err := repository.R().FMintTokenTotalBalance(ctx, &token.Address, types.DefiTokenTypeCollateral)
 	if err != nil {
 		log.Errorf("unknown deposit of %s; %s", token.Address.String(), err.Error())
 		return hexutil.Big{}
@@ -133,11 +134,30 @@ func (token *ERC20Token) TotalDeposit() hexutil.Big {
 }
 
 // TotalDebt represents the total amount of tokens borrowed/minted on fMint.
-func (token *ERC20Token) TotalDebt() hexutil.Big {
-	d, err := repository.R().FMintTokenTotalBalance(&token.Address, types.DefiTokenTypeDebt)
+func (token *ERC20Token) TotalDebt(ctx context.Context) hexutil.Big {
+	d, err := repository.R().FMintTokenTotalBalance(ctx, &token.Address, types.DefiTokenTypeDebt)
 	if err != nil {
 		log.Errorf("unknown debt of %s; %s", token.Address.String(), err.Error())
 		return hexutil.Big{}
 	}
 	return d
 }
+
+// Holders resolves a list of the token holders sorted by their balance
+// from the highest to the lowest.
+func (token *ERC20Token) Holders(ctx context.Context, args struct {
+	Cursor *Cursor
+	Count  int32
+}) (*AccountBalanceList, error) {
+	// limit query size; the list is always loaded from the top
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	if args.Count < 0 {
+		args.Count = -args.Count
+	}
+
+	list, err := repository.R().Erc20Holders(ctx, &token.Address, (*string)(args.Cursor), args.Count)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountBalanceList{*list}, nil
+}
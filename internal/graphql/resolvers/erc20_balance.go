@@ -0,0 +1,61 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ERC20Balance represents a resolvable balance of an ERC20 token held by an account.
+type ERC20Balance struct {
+	// Token represents the token the balance belongs to.
+	Token *ERC20Token
+
+	// Balance represents the current available balance of the token.
+	Balance hexutil.Big
+}
+
+// Erc20Balances resolves the list of ERC20 tokens held by the account
+// along with the current balance. Tokens with zero balance are skipped.
+func (acc *Account) Erc20Balances(ctx context.Context, args struct{ Count int32 }) ([]*ERC20Balance, error) {
+	// limit query size
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	// get the list of tokens the account interacted with
+	al, err := repository.R().Erc20Assets(ctx, acc.Address, args.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	// get the current balances in a single batch
+	bl, err := repository.R().Erc20BalancesOf(ctx, &acc.Address, al)
+	if err != nil {
+		return nil, err
+	}
+
+	// collect tokens with non-zero balance
+	list := make([]*ERC20Balance, 0, len(al))
+	for i := range al {
+		// skip unknown and empty balances
+		if bl[i] == nil {
+			log.Errorf("balance of %s for %s not known", al[i].String(), acc.Address.String())
+			continue
+		}
+		if bl[i].ToInt().Sign() == 0 {
+			continue
+		}
+
+		// get the token detail; skip unknown tokens
+		token := NewErc20Token(ctx, &al[i])
+		if token == nil {
+			continue
+		}
+
+		list = append(list, &ERC20Balance{Token: token, Balance: *bl[i]})
+		if int32(len(list)) >= args.Count {
+			break
+		}
+	}
+	return list, nil
+}
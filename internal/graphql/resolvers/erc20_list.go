@@ -2,18 +2,19 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 // Erc20TokenList resolves an instance of ERC20 token list if available.
-func (rs *rootResolver) Erc20TokenList(args struct{ Count int32 }) ([]*ERC20Token, error) {
+func (rs *rootResolver) Erc20TokenList(ctx context.Context, args struct{ Count int32 }) ([]*ERC20Token, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// get the list of addresses of active tokens
-	al, err := repository.R().Erc20TokensList(args.Count)
+	al, err := repository.R().Erc20TokensList(ctx, args.Count)
 	if err != nil {
 		return nil, err
 	}
@@ -21,14 +22,14 @@ func (rs *rootResolver) Erc20TokenList(args struct{ Count int32 }) ([]*ERC20Toke
 	// make the container and create resolvables
 	list := make([]*ERC20Token, len(al))
 	for i, adr := range al {
-		list[i] = NewErc20Token(&adr)
+		list[i] = NewErc20Token(ctx, &adr)
 	}
 
 	return list, nil
 }
 
 // Erc20Assets resolves a list of instances of ERC20 tokens for the given owner.
-func (rs *rootResolver) Erc20Assets(args struct {
+func (rs *rootResolver) Erc20Assets(ctx context.Context, args struct {
 	Owner common.Address
 	Count int32
 }) ([]*ERC20Token, error) {
@@ -37,7 +38,7 @@ func (rs *rootResolver) Erc20Assets(args struct {
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// get the list of addresses of active tokens for the owner
-	al, err := repository.R().Erc20Assets(args.Owner, args.Count)
+	al, err := repository.R().Erc20Assets(ctx, args.Owner, args.Count)
 	if err != nil {
 		return nil, err
 	}
@@ -45,16 +46,16 @@ func (rs *rootResolver) Erc20Assets(args struct {
 	// make the container and build the list (limit to recognized assets)
 	list := make([]*ERC20Token, len(al))
 	for i, token := range al {
-		list[i] = NewErc20Token(&token)
+		list[i] = NewErc20Token(ctx, &token)
 	}
 
 	return list, nil
 }
 
 // ownsErc20Asset checks if the given owner has any tokens of the given ERC20.
-func (rs *rootResolver) ownsErc20Asset(token *common.Address, owner *common.Address) bool {
+func (rs *rootResolver) ownsErc20Asset(ctx context.Context, token *common.Address, owner *common.Address) bool {
 	// get the balance for the owner
-	val, err := repository.R().Erc20BalanceOf(token, owner)
+	val, err := repository.R().Erc20BalanceOf(ctx, token, owner)
 	if err != nil {
 		log.Errorf("token %s balance can not be loaded for %s; %s", token.String(), owner.String(), err.Error())
 		return false
@@ -0,0 +1,37 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Price resolves the current price of the token in native FTM tokens
+// derived from the DEX pair reserves; nil if the token is not traded against FTM.
+func (token *ERC20Token) Price(ctx context.Context) (*float64, error) {
+	price, err := repository.R().Erc20Price(ctx, &token.Erc20Token)
+	if err != nil {
+		if types.ErrorCode(err) == types.ErrCodeNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &price, nil
+}
+
+// PriceChange24h resolves the relative change of the token price in native FTM tokens
+// over the last 24 hours in percents; nil if not known.
+func (token *ERC20Token) PriceChange24h(ctx context.Context) (*float64, error) {
+	change, err := repository.R().Erc20PriceChange24h(ctx, &token.Erc20Token)
+	if err != nil && types.ErrorCode(err) == types.ErrCodeNotFound {
+		return nil, nil
+	}
+	return change, err
+}
+
+// Volume24h resolves the amount of the token traded on the DEX pairs over the last 24 hours.
+func (token *ERC20Token) Volume24h(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().Erc20Volume24h(ctx, &token.Address)
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -15,9 +16,9 @@ type ERC721Contract struct {
 }
 
 // NewErc721Contract creates a new instance of resolvable ERC721 token.
-func NewErc721Contract(adr *common.Address) *ERC721Contract {
+func NewErc721Contract(ctx context.Context, adr *common.Address) *ERC721Contract {
 	// get the total supply of the token and validate the token existence
-	token, err := repository.R().Erc721Contract(adr)
+	token, err := repository.R().Erc721Contract(ctx, adr)
 	if err != nil {
 		return nil
 	}
@@ -26,13 +27,13 @@ func NewErc721Contract(adr *common.Address) *ERC721Contract {
 }
 
 // Erc721Contract resolves an instance of ERC721 token if available.
-func (rs *rootResolver) Erc721Contract(args *struct{ Token common.Address }) *ERC721Contract {
-	return NewErc721Contract(&args.Token)
+func (rs *rootResolver) Erc721Contract(ctx context.Context, args *struct{ Token common.Address }) *ERC721Contract {
+	return NewErc721Contract(ctx, &args.Token)
 }
 
 // TotalSupply resolves the total supply of the given ERC20 token.
-func (token *ERC721Contract) TotalSupply() (*hexutil.Big, error) {
-	totalSupply, err := repository.R().Erc721TotalSupply(&token.Address)
+func (token *ERC721Contract) TotalSupply(ctx context.Context) (*hexutil.Big, error) {
+	totalSupply, err := repository.R().Erc721TotalSupply(ctx, &token.Address)
 	if err != nil { // ignore err, return null
 		return nil, nil
 	} else {
@@ -41,14 +42,14 @@ func (token *ERC721Contract) TotalSupply() (*hexutil.Big, error) {
 }
 
 // BalanceOf resolves the available balance of the given ERC721 token to a user.
-func (token *ERC721Contract) BalanceOf(args *struct{ Owner common.Address }) (hexutil.Big, error) {
-	return repository.R().Erc721BalanceOf(&token.Address, &args.Owner)
+func (token *ERC721Contract) BalanceOf(ctx context.Context, args *struct{ Owner common.Address }) (hexutil.Big, error) {
+	return repository.R().Erc721BalanceOf(ctx, &token.Address, &args.Owner)
 }
 
 // TokenURI provides URI of Metadata JSON Schema of the ERC721 token.
-func (token *ERC721Contract) TokenURI(args *struct{ TokenId hexutil.Big }) (*string, error) {
+func (token *ERC721Contract) TokenURI(ctx context.Context, args *struct{ TokenId hexutil.Big }) (*string, error) {
 	tokenId := big.Int(args.TokenId)
-	uri, err := repository.R().Erc721TokenURI(&token.Address, &tokenId)
+	uri, err := repository.R().Erc721TokenURI(ctx, &token.Address, &tokenId)
 	if err != nil { // ignore err, return null
 		return nil, nil
 	} else {
@@ -57,9 +58,9 @@ func (token *ERC721Contract) TokenURI(args *struct{ TokenId hexutil.Big }) (*str
 }
 
 // OwnerOf provides information about NFT token ownership.
-func (token *ERC721Contract) OwnerOf(args *struct{ TokenId hexutil.Big }) (*common.Address, error) {
+func (token *ERC721Contract) OwnerOf(ctx context.Context, args *struct{ TokenId hexutil.Big }) (*common.Address, error) {
 	tokenId := big.Int(args.TokenId)
-	owner, err := repository.R().Erc721OwnerOf(&token.Address, &tokenId)
+	owner, err := repository.R().Erc721OwnerOf(ctx, &token.Address, &tokenId)
 	if err != nil { // ignore err, return null
 		return nil, nil
 	} else {
@@ -68,9 +69,9 @@ func (token *ERC721Contract) OwnerOf(args *struct{ TokenId hexutil.Big }) (*comm
 }
 
 // GetApproved provides information about operator approved to manipulate with the NFT token.
-func (token *ERC721Contract) GetApproved(args *struct{ TokenId hexutil.Big }) (*common.Address, error) {
+func (token *ERC721Contract) GetApproved(ctx context.Context, args *struct{ TokenId hexutil.Big }) (*common.Address, error) {
 	tokenId := big.Int(args.TokenId)
-	operator, err := repository.R().Erc721GetApproved(&token.Address, &tokenId)
+	operator, err := repository.R().Erc721GetApproved(ctx, &token.Address, &tokenId)
 	if err != nil { // ignore err, return null
 		return nil, nil
 	} else {
@@ -79,8 +80,11 @@ func (token *ERC721Contract) GetApproved(args *struct{ TokenId hexutil.Big }) (*
 }
 
 // IsApprovedForAll provides information about operator approved to manipulate with NFT tokens of given owner.
-func (token *ERC721Contract) IsApprovedForAll(args *struct{ Owner common.Address; Operator common.Address }) (*bool, error) {
-	isApproved, err := repository.R().Erc721IsApprovedForAll(&token.Address, &args.Owner, &args.Operator)
+func (token *ERC721Contract) IsApprovedForAll(ctx context.Context, args *struct {
+	Owner    common.Address
+	Operator common.Address
+}) (*bool, error) {
+	isApproved, err := repository.R().Erc721IsApprovedForAll(ctx, &token.Address, &args.Owner, &args.Operator)
 	if err != nil { // ignore err, return null
 		return nil, nil
 	} else {
@@ -2,17 +2,18 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 )
 
 // Erc721ContractList resolves an instance of ERC721 token list if available.
-func (rs *rootResolver) Erc721ContractList(args struct{ Count int32 }) ([]*ERC721Contract, error) {
+func (rs *rootResolver) Erc721ContractList(ctx context.Context, args struct{ Count int32 }) ([]*ERC721Contract, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// get the list of addresses of active tokens
-	al, err := repository.R().Erc721ContractsList(args.Count)
+	al, err := repository.R().Erc721ContractsList(ctx, args.Count)
 	if err != nil {
 		return nil, err
 	}
@@ -20,8 +21,8 @@ func (rs *rootResolver) Erc721ContractList(args struct{ Count int32 }) ([]*ERC72
 	// make the container and create resolvable
 	list := make([]*ERC721Contract, len(al))
 	for i, adr := range al {
-		list[i] = NewErc721Contract(&adr)
+		list[i] = NewErc721Contract(ctx, &adr)
 	}
 
 	return list, nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,70 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/svc"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ERC721Metadata represents resolvable metadata of an NFT token.
+type ERC721Metadata struct {
+	types.NftMetadata
+}
+
+// ERC721Attribute represents a resolvable single trait of an NFT token.
+type ERC721Attribute struct {
+	types.NftAttribute
+}
+
+// Metadata resolves the metadata of the NFT token downloaded from the token URI;
+// nil if the metadata are not available.
+func (tok *ERC721Token) Metadata(ctx context.Context) (*ERC721Metadata, error) {
+	md, err := svc.Manager().NftMetadata(ctx, &tok.Contract, tok.TokenId.ToInt())
+	if err != nil {
+		log.Debugf("metadata of NFT %s of %s not available; %s", tok.TokenId.String(), tok.Contract.String(), err.Error())
+		return nil, nil
+	}
+	if md == nil || md.Error != "" {
+		return nil, nil
+	}
+	return &ERC721Metadata{*md}, nil
+}
+
+// Image resolves the URL of the NFT image, if any.
+func (md *ERC721Metadata) Image() *string {
+	return optionalString(md.NftMetadata.Image)
+}
+
+// ExternalUrl resolves the URL of the NFT page on the issuer site, if any.
+func (md *ERC721Metadata) ExternalUrl() *string {
+	return optionalString(md.NftMetadata.ExternalUrl)
+}
+
+// Attributes resolves the list of the NFT traits.
+func (md *ERC721Metadata) Attributes() []*ERC721Attribute {
+	list := make([]*ERC721Attribute, len(md.NftMetadata.Attributes))
+	for i, a := range md.NftMetadata.Attributes {
+		list[i] = &ERC721Attribute{a}
+	}
+	return list
+}
+
+// Fetched resolves the time the metadata were downloaded.
+func (md *ERC721Metadata) Fetched() hexutil.Uint64 {
+	return hexutil.Uint64(md.NftMetadata.Fetched.Unix())
+}
+
+// DisplayType resolves the hint of the trait value presentation, if any.
+func (a *ERC721Attribute) DisplayType() *string {
+	return optionalString(a.NftAttribute.DisplayType)
+}
+
+// optionalString provides a pointer to the given string, or nil if the string is empty.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
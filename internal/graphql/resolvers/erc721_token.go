@@ -0,0 +1,75 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// accMaxNftPerRequest represents the max number of NFT tokens an account can list in one request.
+const accMaxNftPerRequest = 100
+
+// ERC721Token represents a resolvable single NFT token of an ERC721 contract.
+type ERC721Token struct {
+	types.Erc721Token
+}
+
+// NewErc721Token creates a new instance of resolvable ERC721 NFT token.
+func NewErc721Token(tok *types.Erc721Token) *ERC721Token {
+	return &ERC721Token{*tok}
+}
+
+// Erc721Token resolves an NFT token identified by the ERC721 contract address and the token id.
+func (rs *rootResolver) Erc721Token(ctx context.Context, args *struct {
+	Token   common.Address
+	TokenId hexutil.Big
+}) (*ERC721Token, error) {
+	// the owner is pulled from the contract directly to validate the token existence
+	owner, err := repository.R().Erc721OwnerOf(ctx, &args.Token, args.TokenId.ToInt())
+	if err != nil {
+		log.Debugf("NFT %s of %s not found; %s", args.TokenId.String(), args.Token.String(), err.Error())
+		return nil, nil
+	}
+
+	return NewErc721Token(&types.Erc721Token{
+		Contract: args.Token,
+		TokenId:  args.TokenId,
+		Owner:    owner,
+	}), nil
+}
+
+// Token resolves the detail of the ERC721 contract managing the NFT token.
+func (tok *ERC721Token) Token(ctx context.Context) *ERC721Contract {
+	return NewErc721Contract(ctx, &tok.Contract)
+}
+
+// TokenURI resolves the URI of Metadata JSON Schema of the NFT token.
+func (tok *ERC721Token) TokenURI(ctx context.Context) *string {
+	uri, err := repository.R().Erc721TokenURI(ctx, &tok.Contract, tok.TokenId.ToInt())
+	if err != nil { // ignore err, return null
+		return nil
+	}
+	return &uri
+}
+
+// NftList resolves the list of ERC721 NFT tokens currently owned by the account.
+func (acc *Account) NftList(ctx context.Context, args struct{ Count int32 }) ([]*ERC721Token, error) {
+	// limit query size
+	args.Count = listLimitCount(args.Count, accMaxNftPerRequest)
+
+	// get the list of tokens
+	list, err := repository.R().Erc721TokensOfOwner(ctx, &acc.Address, args.Count)
+	if err != nil {
+		return nil, err
+	}
+
+	// make the resolvable list
+	res := make([]*ERC721Token, len(list))
+	for i, tok := range list {
+		res[i] = NewErc721Token(tok)
+	}
+	return res, nil
+}
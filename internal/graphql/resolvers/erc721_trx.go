@@ -1,7 +1,7 @@
 package resolvers
 
 import (
-	"fantom-api-graphql/internal/repository"
+	"context"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -22,9 +22,9 @@ func (trx *ERC721Transaction) TrxHash() common.Hash {
 }
 
 // Transaction resolves an instance of the transaction executing the ERC721 call.
-func (trx *ERC721Transaction) Transaction() (*Transaction, error) {
+func (trx *ERC721Transaction) Transaction(ctx context.Context) (*Transaction, error) {
 	// get the transaction from repo
-	tx, err := repository.R().Transaction(&trx.TokenTransaction.Transaction)
+	tx, err := loadTransaction(ctx, &trx.TokenTransaction.Transaction)
 	if err != nil {
 		return nil, err
 	}
@@ -32,8 +32,8 @@ func (trx *ERC721Transaction) Transaction() (*Transaction, error) {
 }
 
 // Token resolves instance of the ERC721 token involved.
-func (trx *ERC721Transaction) Token() *ERC721Contract {
-	return NewErc721Contract(&trx.TokenAddress)
+func (trx *ERC721Transaction) Token(ctx context.Context) *ERC721Contract {
+	return NewErc721Contract(ctx, &trx.TokenAddress)
 }
 
 // TrxType resolves the type of the ERC721 transaction.
@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -9,7 +10,7 @@ import (
 )
 
 // Erc20Transactions resolves list of ERC20 transactions.
-func (rs *rootResolver) Erc20Transactions(args struct {
+func (rs *rootResolver) Erc20Transactions(ctx context.Context, args struct {
 	Cursor  *Cursor
 	Count   int32
 	Token   *common.Address
@@ -21,7 +22,7 @@ func (rs *rootResolver) Erc20Transactions(args struct {
 	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
 
 	// get the transaction hash list from repository
-	tl, err := repository.R().TokenTransactions(
+	tl, err := repository.R().TokenTransactions(ctx,
 		types.AccountTypeERC20Token,
 		args.Token,
 		nil,
@@ -38,7 +39,7 @@ func (rs *rootResolver) Erc20Transactions(args struct {
 }
 
 // Erc721Transactions resolves list of ERC721 transactions.
-func (rs *rootResolver) Erc721Transactions(args struct {
+func (rs *rootResolver) Erc721Transactions(ctx context.Context, args struct {
 	Cursor  *Cursor
 	Count   int32
 	Token   *common.Address
@@ -51,7 +52,7 @@ func (rs *rootResolver) Erc721Transactions(args struct {
 	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
 
 	// get the transaction hash list from repository
-	tl, err := repository.R().TokenTransactions(
+	tl, err := repository.R().TokenTransactions(ctx,
 		types.AccountTypeERC721Contract,
 		args.Token,
 		(*big.Int)(args.TokenId),
@@ -68,7 +69,7 @@ func (rs *rootResolver) Erc721Transactions(args struct {
 }
 
 // Erc1155Transactions resolves list of ERC1155 transactions.
-func (rs *rootResolver) Erc1155Transactions(args struct {
+func (rs *rootResolver) Erc1155Transactions(ctx context.Context, args struct {
 	Cursor  *Cursor
 	Count   int32
 	Token   *common.Address
@@ -81,7 +82,7 @@ func (rs *rootResolver) Erc1155Transactions(args struct {
 	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
 
 	// get the transaction hash list from repository
-	tl, err := repository.R().TokenTransactions(
+	tl, err := repository.R().TokenTransactions(ctx,
 		types.AccountTypeERC1155Contract,
 		args.Token,
 		(*big.Int)(args.TokenId),
@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"fmt"
@@ -22,6 +23,10 @@ type EstimatedRewards struct {
 	Staked      hexutil.Uint64
 	TotalStaked hexutil.Big
 	LastEpoch   Epoch
+
+	// rewardRatio is the share of the full rewards paid to the stake
+	// with 18 decimals; the full rewards are paid if not set.
+	rewardRatio *big.Int
 }
 
 // weiToFtmDecimals represents decimal conversion between WEI and FTM units.
@@ -37,19 +42,19 @@ func NewEstimatedRewards(ep *types.Epoch, amount *hexutil.Uint64, total *hexutil
 }
 
 // estimateRewardsByAddress instantiates the estimated rewards for specified address if possible.
-func (rs *rootResolver) estimateRewardsByAddress(addr *common.Address, ep *types.Epoch, total *hexutil.Big) (EstimatedRewards, error) {
+func (rs *rootResolver) estimateRewardsByAddress(ctx context.Context, addr *common.Address, ep *types.Epoch, total *hexutil.Big) (EstimatedRewards, error) {
 	// try to get the address involved
-	acc, err := repository.R().Account(addr)
+	acc, err := repository.R().Account(ctx, addr)
 	if err != nil {
 		log.Error("invalid address or address not found")
-		return EstimatedRewards{}, fmt.Errorf("address not found")
+		return EstimatedRewards{}, types.NewError(types.ErrCodeNotFound, "address not found")
 	}
 
 	// inform to debug
 	log.Debugf("calculating rewards estimation for address [%s]", acc.Address.String())
 
 	// get the address balance
-	balance, err := repository.R().AccountBalance(&acc.Address)
+	balance, err := repository.R().AccountBalance(ctx, &acc.Address)
 	if err != nil {
 		log.Errorf("can not get balance for address [%s]", acc.Address.String())
 		return EstimatedRewards{}, fmt.Errorf("address balance not found")
@@ -61,9 +66,11 @@ func (rs *rootResolver) estimateRewardsByAddress(addr *common.Address, ep *types
 }
 
 // EstimateRewards resolves reward estimation for the given address or amount staked.
-func (rs *rootResolver) EstimateRewards(args *struct {
-	Address *common.Address
-	Amount  *hexutil.Uint64
+func (rs *rootResolver) EstimateRewards(ctx context.Context, args *struct {
+	Address      *common.Address
+	Amount       *hexutil.Uint64
+	StakerId     *hexutil.Big
+	LockDuration *hexutil.Uint64
 }) (EstimatedRewards, error) {
 	// at least one of the parameters must be present
 	if args == nil || (args.Address == nil && args.Amount == nil) {
@@ -74,24 +81,84 @@ func (rs *rootResolver) EstimateRewards(args *struct {
 	// get the latest sealed epoch
 	// the data could be delayed behind the real-time sealed epoch due to caching,
 	// but we don't need that precise reflection here
-	ep, err := repository.R().CurrentSealedEpoch()
+	ep, err := repository.R().CurrentSealedEpoch(ctx)
 	if err != nil {
 		log.Errorf("can not get the current sealed epoch information; %s", err.Error())
 		return EstimatedRewards{}, fmt.Errorf("current sealed epoch not found")
 	}
 
 	// get the current total staked amount
-	total, err := repository.R().TotalStaked()
+	total, err := repository.R().TotalStaked(ctx)
 	if err != nil {
 		log.Errorf("can not get the current total staked amount; %s", err.Error())
 		return EstimatedRewards{}, fmt.Errorf("current total staked amount not found")
 	}
 
 	// if address is specified, pull the estimation from it
+	var erw EstimatedRewards
 	if args.Address != nil {
-		return rs.estimateRewardsByAddress(args.Address, ep, total)
+		erw, err = rs.estimateRewardsByAddress(ctx, args.Address, ep, total)
+		if err != nil {
+			return EstimatedRewards{}, err
+		}
+	} else {
+		erw = NewEstimatedRewards(ep, args.Amount, total)
+	}
+
+	// apply the validator commission and the lockup to the estimation
+	erw.rewardRatio, err = estimateRewardsRatio(ctx, args.StakerId, args.LockDuration)
+	if err != nil {
+		return EstimatedRewards{}, err
 	}
-	return NewEstimatedRewards(ep, args.Amount, total), nil
+	return erw, nil
+}
+
+// estimateRewardsRatio calculates the share of full rewards paid to a delegation
+// to the given validator locked for the given duration; the SFC deducts the validator
+// commission first and scales the rest by the lockup duration.
+func estimateRewardsRatio(ctx context.Context, stakerID *hexutil.Big, lockDuration *hexutil.Uint64) (*big.Int, error) {
+	if stakerID == nil && lockDuration == nil {
+		return nil, nil
+	}
+	unit := repository.R().SfcDecimalUnit()
+	ratio := new(big.Int).Set(unit)
+
+	// the validator takes the commission from the delegation rewards
+	if stakerID != nil {
+		val, err := repository.R().Validator(ctx, stakerID)
+		if err != nil || val == nil {
+			return nil, types.Errorf(types.ErrCodeNotFound, "validator #%s not found", stakerID.ToInt().String())
+		}
+
+		com, err := repository.R().SfcValidatorCommission(ctx)
+		if err != nil {
+			log.Errorf("can not get the validator commission; %s", err.Error())
+			return nil, fmt.Errorf("validator commission not available")
+		}
+		ratio.Sub(ratio, com)
+	}
+
+	// the delegation receives the full reward share only if locked for the longest period
+	if lockDuration != nil {
+		sc, err := repository.R().SfcConfiguration(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		dur := new(big.Int).SetUint64(uint64(*lockDuration))
+		if dur.Sign() > 0 && (dur.Cmp(sc.MinLockupDuration.ToInt()) < 0 || dur.Cmp(sc.MaxLockupDuration.ToInt()) > 0) {
+			return nil, fmt.Errorf("lock duration must be between %s and %s seconds",
+				sc.MinLockupDuration.ToInt().String(), sc.MaxLockupDuration.ToInt().String())
+		}
+
+		lr, err := repository.R().DelegationLockRewardRatio(ctx, *lockDuration)
+		if err != nil {
+			log.Errorf("can not get the lockup reward ratio; %s", err.Error())
+			return nil, fmt.Errorf("lockup reward ratio not available")
+		}
+		ratio.Div(ratio.Mul(ratio, lr), unit)
+	}
+	return ratio, nil
 }
 
 // canCalculateRewards checks if the reward can actually be calculated
@@ -116,6 +183,11 @@ func (erw EstimatedRewards) getRewards(period uint64) hexutil.Big {
 	staked := new(big.Int).Mul(base, new(big.Int).SetUint64(uint64(erw.Staked)))
 	val := new(big.Int).Div(staked, erw.TotalStaked.ToInt())
 
+	// apply the share of rewards paid to the stake, if any
+	if erw.rewardRatio != nil {
+		val.Div(val.Mul(val, erw.rewardRatio), repository.R().SfcDecimalUnit())
+	}
+
 	// return the value
 	return hexutil.Big(*val)
 }
@@ -157,3 +229,27 @@ func (erw EstimatedRewards) CurrentRewardRateYearly() int32 {
 	// get the value
 	return int32(val.Int64())
 }
+
+// RewardRatio resolves the share of the full rewards paid to the stake
+// after the validator commission and the lockup duration are applied.
+func (erw EstimatedRewards) RewardRatio() float64 {
+	if erw.rewardRatio == nil {
+		return 1
+	}
+	val, _ := new(big.Float).Quo(new(big.Float).SetInt(erw.rewardRatio), new(big.Float).SetInt(repository.R().SfcDecimalUnit())).Float64()
+	return val
+}
+
+// Apr resolves the estimated annual percentage rate of the stake
+// including the validator commission and the lockup duration, if provided.
+func (erw EstimatedRewards) Apr() float64 {
+	// make sure we can calculate the yearly rate safely
+	if erw.LastEpoch.BaseRewardPerSecond.ToInt().Sign() <= 0 || erw.TotalStaked.ToInt().Sign() <= 0 {
+		return 0
+	}
+
+	// (perSecond * year * 100) / totalStakedAmount
+	base := new(big.Int).Mul(erw.LastEpoch.BaseRewardPerSecond.ToInt(), new(big.Int).SetUint64(erwSecondsInYear))
+	val, _ := new(big.Float).Quo(new(big.Float).SetInt(base), new(big.Float).SetInt(erw.TotalStaked.ToInt())).Float64()
+	return 100 * val * erw.RewardRatio()
+}
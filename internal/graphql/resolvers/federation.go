@@ -0,0 +1,187 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	gqlSchema "fantom-api-graphql/internal/graphql/schema"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"strconv"
+)
+
+// federationTypeField represents the field of an entity representation carrying the entity type.
+const federationTypeField = "__typename"
+
+// Any represents an entity representation sent by a federation gateway.
+type Any map[string]interface{}
+
+// FederationService represents the federated service definition.
+type FederationService struct{}
+
+// Entity represents an entity resolved for a federation gateway, one of the union members.
+type Entity struct {
+	acc   *Account
+	block *Block
+	trx   *Transaction
+	token *ERC20Token
+}
+
+// ImplementsGraphQLType notifies the GraphQL that this type resolves _Any scalar.
+func (Any) ImplementsGraphQLType(name string) bool {
+	return name == "_Any"
+}
+
+// UnmarshalGraphQL unmarshal incoming entity representation into a local variable.
+func (a *Any) UnmarshalGraphQL(input interface{}) error {
+	rep, ok := input.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("entity representation expected")
+	}
+	*a = rep
+	return nil
+}
+
+// Service resolves the federated service definition.
+func (rs *rootResolver) Service() FederationService {
+	return FederationService{}
+}
+
+// Sdl resolves the schema annotated with the federation entity keys.
+func (FederationService) Sdl() *string {
+	sdl := gqlSchema.Subgraph()
+	return &sdl
+}
+
+// Entities resolves the entities referenced by other federated services by their key fields.
+// Entities not found are resolved as null, unknown entity types fail the request.
+func (rs *rootResolver) Entities(ctx context.Context, args struct{ Representations []Any }) ([]*Entity, error) {
+	list := make([]*Entity, len(args.Representations))
+	for i, rep := range args.Representations {
+		ent, err := rs.entity(ctx, rep)
+		if err != nil {
+			return nil, err
+		}
+		list[i] = ent
+	}
+	return list, nil
+}
+
+// entity resolves a single entity by its representation.
+func (rs *rootResolver) entity(ctx context.Context, rep Any) (*Entity, error) {
+	switch rep[federationTypeField] {
+	case "Account":
+		adr, err := rep.address("address")
+		if err != nil {
+			return nil, err
+		}
+		acc, err := loadAccount(ctx, adr)
+		if err != nil {
+			return nil, nil
+		}
+		return &Entity{acc: NewAccount(acc)}, nil
+
+	case "Block":
+		num, err := rep.number("number")
+		if err != nil {
+			return nil, err
+		}
+		blk, err := loadBlock(ctx, num)
+		if err != nil {
+			return nil, nil
+		}
+		return &Entity{block: NewBlock(blk)}, nil
+
+	case "Transaction":
+		hash, err := rep.hash("hash")
+		if err != nil {
+			return nil, err
+		}
+		trx, err := loadTransaction(ctx, hash)
+		if err != nil {
+			return nil, nil
+		}
+		return &Entity{trx: NewTransaction(trx)}, nil
+
+	case "ERC20Token":
+		adr, err := rep.address("address")
+		if err != nil {
+			return nil, err
+		}
+		if tok := NewErc20Token(ctx, adr); tok != nil {
+			return &Entity{token: tok}, nil
+		}
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unknown entity type %v", rep[federationTypeField])
+}
+
+// address decodes the address key field of the representation.
+func (a Any) address(field string) (*common.Address, error) {
+	s, ok := a[field].(string)
+	if !ok {
+		return nil, types.Errorf(types.ErrCodeInvalidInput, "invalid entity key %s; string expected", field)
+	}
+	adr, err := types.ParseAddress(s)
+	if err != nil {
+		return nil, types.Errorf(types.ErrCodeInvalidInput, "invalid entity key %s; %s", field, err.Error())
+	}
+	return &adr, nil
+}
+
+// hash decodes the hash key field of the representation.
+func (a Any) hash(field string) (*common.Hash, error) {
+	s, ok := a[field].(string)
+	if !ok {
+		return nil, types.Errorf(types.ErrCodeInvalidInput, "invalid entity key %s; string expected", field)
+	}
+	hash, err := types.ParseHash(s)
+	if err != nil {
+		return nil, types.Errorf(types.ErrCodeInvalidInput, "invalid entity key %s; %s", field, err.Error())
+	}
+	return &hash, nil
+}
+
+// number decodes the numeric key field of the representation;
+// the number can be sent as a hex or decimal string, or as a JSON number.
+func (a Any) number(field string) (*hexutil.Uint64, error) {
+	var num uint64
+	var err error
+
+	switch val := a[field].(type) {
+	case string:
+		num, err = strconv.ParseUint(val, 0, 64)
+	case int32:
+		num = uint64(val)
+	case float64:
+		num = uint64(val)
+	default:
+		err = fmt.Errorf("number expected")
+	}
+
+	if err != nil {
+		return nil, types.Errorf(types.ErrCodeInvalidInput, "invalid entity key %s; %s", field, err.Error())
+	}
+	return (*hexutil.Uint64)(&num), nil
+}
+
+// ToAccount resolves the account entity.
+func (ent *Entity) ToAccount() (*Account, bool) {
+	return ent.acc, ent.acc != nil
+}
+
+// ToBlock resolves the block entity.
+func (ent *Entity) ToBlock() (*Block, bool) {
+	return ent.block, ent.block != nil
+}
+
+// ToTransaction resolves the transaction entity.
+func (ent *Entity) ToTransaction() (*Transaction, bool) {
+	return ent.trx, ent.trx != nil
+}
+
+// ToERC20Token resolves the ERC20 token entity.
+func (ent *Entity) ToERC20Token() (*ERC20Token, bool) {
+	return ent.token, ent.token != nil
+}
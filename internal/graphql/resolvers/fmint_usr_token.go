@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -59,9 +60,9 @@ func fMintTypeToPurpose(t int32) string {
 
 // FMintUserTokens resolves list of fMint users and associated tokens
 // used for specified purpose.
-func (rs *rootResolver) FMintUserTokens(args struct{ Purpose string }) ([]*FMintUserToken, error) {
+func (rs *rootResolver) FMintUserTokens(ctx context.Context, args struct{ Purpose string }) ([]*FMintUserToken, error) {
 	// get the aggregated list of addresses and their tokens
-	list, err := repository.R().FMintUsers(fMintPurposeToType(args.Purpose))
+	list, err := repository.R().FMintUsers(ctx, fMintPurposeToType(args.Purpose))
 	if err != nil {
 		return nil, err
 	}
@@ -81,9 +82,9 @@ func (rs *rootResolver) FMintUserTokens(args struct{ Purpose string }) ([]*FMint
 }
 
 // Account resolves account of the fMint user.
-func (fut *FMintUserToken) Account() (*FMintAccount, error) {
+func (fut *FMintUserToken) Account(ctx context.Context) (*FMintAccount, error) {
 	// get the delegator detail from backend
-	ac, err := repository.R().FMintAccount(fut.UserAddress)
+	ac, err := repository.R().FMintAccount(ctx, fut.UserAddress)
 	if err != nil {
 		return nil, err
 	}
@@ -92,6 +93,6 @@ func (fut *FMintUserToken) Account() (*FMintAccount, error) {
 }
 
 // Token resolves the detail of the associated ERC20 token.
-func (fut *FMintUserToken) Token() *ERC20Token {
-	return NewErc20Token(&fut.TokenAddress)
+func (fut *FMintUserToken) Token(ctx context.Context) *ERC20Token {
+	return NewErc20Token(ctx, &fut.TokenAddress)
 }
@@ -0,0 +1,124 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"strconv"
+)
+
+// FtmBurnList represents resolvable list of FTM burns sorted from the most recent block.
+type FtmBurnList struct {
+	types.FtmBurnList
+}
+
+// FtmBurnListEdge represents a single edge of the FTM burn list.
+type FtmBurnListEdge struct {
+	Burn *FtmBurn
+}
+
+// FtmBurn represents a resolvable FTM burn record of a block.
+type FtmBurn struct {
+	types.FtmBurn
+}
+
+// FtmBurnedTotal resolves the total amount of native FTM tokens burned.
+func (rs *rootResolver) FtmBurnedTotal(ctx context.Context) (hexutil.Big, error) {
+	// the total is aggregated over the whole collection, use the response cache
+	var total hexutil.Big
+	err := rs.cachedResponse("ftmBurnedTotal", nil, &total, func() error {
+		val, err := repository.R().FtmBurnedTotal(ctx)
+		if err == nil {
+			total = hexutil.Big(*val)
+		}
+		return err
+	})
+	return total, err
+}
+
+// FtmBurnList resolves a list of FTM burns of blocks from the most recent one.
+func (rs *rootResolver) FtmBurnList(ctx context.Context, args struct {
+	Cursor *Cursor
+	Count  int32
+}) (*FtmBurnList, error) {
+	// limit query size; the list is always loaded from the top
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	if args.Count < 0 {
+		args.Count = -args.Count
+	}
+
+	bl, err := repository.R().FtmBurnList(ctx, (*string)(args.Cursor), args.Count)
+	if err != nil {
+		return nil, err
+	}
+	return &FtmBurnList{*bl}, nil
+}
+
+// TotalCount resolves the total number of burns in the list.
+func (fbl *FtmBurnList) TotalCount() hexutil.Big {
+	val := new(big.Int).SetUint64(fbl.Total)
+	return (hexutil.Big)(*val)
+}
+
+// PageInfo resolves the current page information for the FTM burn list.
+func (fbl *FtmBurnList) PageInfo() (*ListPageInfo, error) {
+	// do we have any items?
+	if len(fbl.Collection) == 0 {
+		return NewListPageInfo(nil, nil, false, !fbl.IsStart)
+	}
+
+	// get the first and last elements
+	first := ftmBurnCursor(fbl.Collection[0])
+	last := ftmBurnCursor(fbl.Collection[len(fbl.Collection)-1])
+	return NewListPageInfo(&first, &last, !fbl.IsEnd, !fbl.IsStart)
+}
+
+// Edges resolves list of edges of the FTM burn list.
+func (fbl *FtmBurnList) Edges() []*FtmBurnListEdge {
+	edges := make([]*FtmBurnListEdge, len(fbl.Collection))
+	for i, burn := range fbl.Collection {
+		edges[i] = &FtmBurnListEdge{Burn: &FtmBurn{*burn}}
+	}
+	return edges
+}
+
+// Cursor resolves the cursor of the edge.
+func (edge *FtmBurnListEdge) Cursor() Cursor {
+	return ftmBurnCursor(&edge.Burn.FtmBurn)
+}
+
+// Block resolves the block the fees were burned in.
+func (burn *FtmBurn) Block(ctx context.Context) (*Block, error) {
+	blk, err := loadBlock(ctx, &burn.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return NewBlock(blk), nil
+}
+
+// Timestamp resolves the time stamp of the block the fees were burned in.
+func (burn *FtmBurn) Timestamp() hexutil.Uint64 {
+	return burn.BlockTimeStamp
+}
+
+// TxCount resolves the number of transactions paying the burned fees.
+func (burn *FtmBurn) TxCount() int32 {
+	return int32(burn.FtmBurn.TxCount)
+}
+
+// FtmBurned resolves the amount of native FTM tokens burned by the block.
+func (blk *Block) FtmBurned(ctx context.Context) (hexutil.Big, error) {
+	val, err := repository.R().FtmBurnOfBlock(ctx, uint64(blk.Number))
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*val), nil
+}
+
+// ftmBurnCursor creates the cursor of the given FTM burn record.
+func ftmBurnCursor(burn *types.FtmBurn) Cursor {
+	return Cursor(strconv.FormatUint(uint64(burn.BlockNumber), 10))
+}
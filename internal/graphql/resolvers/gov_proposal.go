@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -35,32 +36,32 @@ func NewGovernanceProposal(gp *types.GovernanceProposal) *GovernanceProposal {
 
 // OptionState resolves a state of a given Proposal option identified
 // by it's id (index position) in the Proposal options list.
-func (gp *GovernanceProposal) OptionState(args *struct{ OptionId hexutil.Big }) (*types.GovernanceOptionState, error) {
-	return repository.R().GovernanceOptionState(&gp.GovernanceId, &gp.Id, &args.OptionId)
+func (gp *GovernanceProposal) OptionState(ctx context.Context, args *struct{ OptionId hexutil.Big }) (*types.GovernanceOptionState, error) {
+	return repository.R().GovernanceOptionState(ctx, &gp.GovernanceId, &gp.Id, &args.OptionId)
 }
 
 // OptionStates resolves a list of states of Proposal options.
-func (gp *GovernanceProposal) OptionStates() ([]*types.GovernanceOptionState, error) {
+func (gp *GovernanceProposal) OptionStates(ctx context.Context) ([]*types.GovernanceOptionState, error) {
 	// make sure to call this only once in parallel processing
 	ops, err, _ := gp.cg.Do("opt_states", func() (interface{}, error) {
-		return repository.R().GovernanceOptionStates(&gp.GovernanceId, &gp.Id, len(gp.Options))
+		return repository.R().GovernanceOptionStates(ctx, &gp.GovernanceId, &gp.Id, len(gp.Options))
 	})
 	return ops.([]*types.GovernanceOptionState), err
 }
 
 // Vote resolves the vote for the given <from> address linked
 // with the <delegatedTo> delegation recipient.
-func (gp *GovernanceProposal) Vote(args *struct {
+func (gp *GovernanceProposal) Vote(ctx context.Context, args *struct {
 	From        common.Address
 	DelegatedTo *common.Address
 }) (*types.GovernanceVote, error) {
-	return repository.R().GovernanceVote(&gp.GovernanceId, &gp.Id, &args.From, args.DelegatedTo)
+	return repository.R().GovernanceVote(ctx, &gp.GovernanceId, &gp.Id, &args.From, args.DelegatedTo)
 }
 
 // Governance resolves the parent Governance instance.
-func (gp *GovernanceProposal) Governance() (*GovernanceContract, error) {
+func (gp *GovernanceProposal) Governance(ctx context.Context) (*GovernanceContract, error) {
 	// get the governance contract by address
-	gc, err := repository.R().GovernanceContractBy(&gp.GovernanceId)
+	gc, err := repository.R().GovernanceContractBy(ctx, &gp.GovernanceId)
 	if err != nil {
 		return nil, err
 	}
@@ -73,10 +74,10 @@ func (gp *GovernanceProposal) Governance() (*GovernanceContract, error) {
 }
 
 // State resolves the state of the Governance Proposal.
-func (gp *GovernanceProposal) State() (*GovernanceProposalState, error) {
+func (gp *GovernanceProposal) State(ctx context.Context) (*GovernanceProposalState, error) {
 	// make sure to call this only once in parallel processing
 	gps, err, _ := gp.cg.Do("state", func() (interface{}, error) {
-		return repository.R().GovernanceProposalState(&gp.GovernanceId, &gp.Id)
+		return repository.R().GovernanceProposalState(ctx, &gp.GovernanceId, &gp.Id)
 	})
 	if err != nil {
 		return nil, err
@@ -86,10 +87,10 @@ func (gp *GovernanceProposal) State() (*GovernanceProposalState, error) {
 
 // TotalWeight resolves the total available voting power which can influence
 // the proposal outcome.
-func (gp *GovernanceProposal) TotalWeight() (hexutil.Big, error) {
+func (gp *GovernanceProposal) TotalWeight(ctx context.Context) (hexutil.Big, error) {
 	// make sure to call it only once if in parallel processing
 	wt, err, _ := gp.cg.Do("weight", func() (interface{}, error) {
-		return repository.R().GovernanceTotalWeight(&gp.GovernanceId)
+		return repository.R().GovernanceTotalWeight(ctx, &gp.GovernanceId)
 	})
 	if err != nil {
 		return hexutil.Big{}, err
@@ -99,15 +100,15 @@ func (gp *GovernanceProposal) TotalWeight() (hexutil.Big, error) {
 
 // VotedWeightRatio represents what percentage of the total voting power already
 // placed a vote either directly, or though a delegation.
-func (gp *GovernanceProposal) VotedWeightRatio() int32 {
+func (gp *GovernanceProposal) VotedWeightRatio(ctx context.Context) int32 {
 	// get the total weight
-	total, err := gp.TotalWeight()
+	total, err := gp.TotalWeight(ctx)
 	if err != nil || 0 == total.ToInt().Cmp(zeroInt) {
 		return 0
 	}
 
 	// get the current proposal state
-	state, err := gp.State()
+	state, err := gp.State(ctx)
 	if err != nil || 0 == state.Votes.ToInt().Cmp(zeroInt) {
 		return 0
 	}
@@ -117,14 +118,14 @@ func (gp *GovernanceProposal) VotedWeightRatio() int32 {
 }
 
 // WinnerId resolves id of the winner of the proposal.
-func (gps *GovernanceProposalState) WinnerId() (*hexutil.Big, error) {
+func (gps *GovernanceProposalState) WinnerId(ctx context.Context) (*hexutil.Big, error) {
 	// non-resolved proposal means no winner
 	if !gps.IsResolved {
 		return nil, nil
 	}
 
 	// get options states
-	states, err := gps.gp.OptionStates()
+	states, err := gps.gp.OptionStates(ctx)
 	if err != nil {
 		return nil, err
 	}
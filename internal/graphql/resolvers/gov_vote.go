@@ -0,0 +1,22 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+)
+
+// accMaxGovVotesPerRequest represents the max number of governance votes
+// loaded for an account in a single request.
+const accMaxGovVotesPerRequest = 100
+
+// GovVotes resolves the list of the most recent governance votes placed by the account.
+func (acc *Account) GovVotes(ctx context.Context, args struct{ Count int32 }) ([]*types.GovernanceVote, error) {
+	// limit query size
+	args.Count = listLimitCount(args.Count, accMaxGovVotesPerRequest)
+	if args.Count < 0 {
+		args.Count = -args.Count
+	}
+	return repository.R().GovernanceVotesOf(ctx, &acc.Address, args.Count)
+}
@@ -20,16 +20,16 @@ type ApiResolver interface {
 	Version() string
 
 	// Epochs resolves a list of epochs for the given cursor and count.
-	Epochs(args struct {
+	Epochs(ctx context.Context, args struct {
 		Cursor *Cursor
 		Count  int32
 	}) (*EpochList, error)
 
 	// Account resolves blockchain account by address.
-	Account(struct{ Address common.Address }) (*Account, error)
+	Account(context.Context, struct{ Address common.Address }) (*Account, error)
 
 	// Contracts resolves list of blockchain smart contracts encapsulated in a listable structure.
-	Contracts(*struct {
+	Contracts(context.Context, *struct {
 		ValidatedOnly bool
 		Cursor        *Cursor
 		Count         int32
@@ -38,100 +38,138 @@ type ApiResolver interface {
 	// ValidateContract resolves smart contract source code vs. deployed byte code and marks
 	// the contract as validated if the match is found. Peer API points are ringed on success
 	// to notify them about the change.
-	ValidateContract(*struct{ Contract ContractValidationInput }) (*Contract, error)
+	ValidateContract(context.Context, *struct{ Contract ContractValidationInput }) (*Contract, error)
 
 	// Block resolves blockchain block by number or by hash. If neither is provided, the most recent block is given.
-	Block(*struct {
+	Block(context.Context, *struct {
 		Number *hexutil.Uint64
 		Hash   *common.Hash
 	}) (*Block, error)
 
 	// Blocks resolves list of blockchain blocks encapsulated in a listable structure.
-	Blocks(*struct {
+	Blocks(context.Context, *struct {
+		Cursor *Cursor
+		Count  int32
+		From   *hexutil.Uint64
+		To     *hexutil.Uint64
+	}) (*BlockList, error)
+
+	// BlocksByTimeRange resolves list of blockchain blocks created in the given time range.
+	BlocksByTimeRange(context.Context, *struct {
+		From   hexutil.Uint64
+		To     hexutil.Uint64
 		Cursor *Cursor
 		Count  int32
 	}) (*BlockList, error)
 
 	// Transaction resolves blockchain transaction by hash.
-	Transaction(*struct{ Hash common.Hash }) (*Transaction, error)
+	Transaction(context.Context, *struct{ Hash common.Hash }) (*Transaction, error)
 
 	// Transactions resolves list of blockchain transactions encapsulated in a listable structure.
-	Transactions(*struct {
+	Transactions(context.Context, *struct {
 		Cursor *Cursor
 		Count  int32
+		Filter *TransactionFilterInput
 	}) (*TransactionList, error)
 
+	// TransactionCount resolves the exact number of indexed transactions.
+	TransactionCount(context.Context) (hexutil.Uint64, error)
+
 	// OnBlock resolves subscription to new blocks' event broadcast.
 	OnBlock(ctx context.Context) <-chan *Block
 
-	// OnTransaction resolves subscription to new transactions' event broadcast.
-	OnTransaction(ctx context.Context) <-chan *Transaction
+	// OnTransaction resolves subscription to new transactions' event broadcast,
+	// optionally filtered by the sender and/or recipient address.
+	OnTransaction(ctx context.Context, args struct {
+		Sender    *common.Address
+		Recipient *common.Address
+	}) <-chan *Transaction
+
+	// OnTransactionStatus resolves subscription to status changes of the given transaction.
+	OnTransactionStatus(ctx context.Context, args struct{ Hash common.Hash }) <-chan *TransactionStatus
 
 	// CurrentEpoch resolves id of the current epoch.
-	CurrentEpoch() (hexutil.Uint64, error)
+	CurrentEpoch(context.Context) (hexutil.Uint64, error)
 
 	// Epoch resolves information about epoch of the given id.
-	Epoch(*struct{ Id *hexutil.Uint64 }) (Epoch, error)
+	Epoch(context.Context, *struct{ Id *hexutil.Uint64 }) (Epoch, error)
 
 	// LastStakerId resolves the last staker id in Opera blockchain.
-	LastStakerId() (hexutil.Uint64, error)
+	LastStakerId(context.Context) (hexutil.Uint64, error)
 
 	// StakersNum resolves the number of stakers in Opera blockchain.
-	StakersNum() (hexutil.Uint64, error)
+	StakersNum(context.Context) (hexutil.Uint64, error)
 
 	// Staker resolves a staker information from SFC smart contract.
-	Staker(struct {
+	Staker(context.Context, struct {
 		Id      *hexutil.Big
 		Address *common.Address
 	}) (*Staker, error)
 
 	// Stakers resolves a list of staker information from SFC smart contract.
-	Stakers() ([]*Staker, error)
+	Stakers(context.Context) ([]*Staker, error)
+
+	// ValidatorLeaderboard resolves the list of validators sorted by their performance
+	// over the given number of the most recent sealed epochs.
+	ValidatorLeaderboard(ctx context.Context, args struct {
+		Epochs int32
+		Count  int32
+	}) ([]*ValidatorPerformance, error)
 
 	// Delegation resolves details of a delegator by its address.
-	Delegation(*struct {
+	Delegation(context.Context, *struct {
 		Address common.Address
 		Staker  hexutil.Big
 	}) (*Delegation, error)
 
 	// DelegationsOf a list of delegations information of a staker.
-	DelegationsOf(*struct {
+	DelegationsOf(context.Context, *struct {
 		Staker hexutil.Big
 		Cursor *Cursor
 		Count  int32
 	}) (*DelegationList, error)
 
 	// DelegationsByAddress a list of own delegations by the account address.
-	DelegationsByAddress(*struct {
+	DelegationsByAddress(context.Context, *struct {
 		Address common.Address
 		Cursor  *Cursor
 		Count   int32
 	}) (*DelegationList, error)
 
 	// Price resolves price details of the Opera blockchain token for the given target symbols.
-	Price(*struct{ To string }) (types.Price, error)
+	Price(context.Context, *struct{ To string }) (types.Price, error)
 
 	// GasPrice resolves the current amount of WEI for single Gas.
-	GasPrice() (hexutil.Uint64, error)
+	GasPrice(context.Context) (hexutil.Uint64, error)
 
 	// EstimateGas resolves the estimated amount of Gas required to perform
 	// transaction described by the input params.
-	EstimateGas(struct {
+	EstimateGas(context.Context, struct {
 		From  *common.Address
 		To    *common.Address
 		Value *hexutil.Big
 		Data  *string
 	}) (*hexutil.Uint64, error)
 
+	// VerifySignature resolves if the given message has been signed by the given address.
+	VerifySignature(context.Context, *struct {
+		Address   common.Address
+		Message   string
+		Signature hexutil.Bytes
+		Scheme    string
+	}) (bool, error)
+
 	// EstimateRewards resolves reward estimation for the given address or amount staked.
-	EstimateRewards(*struct {
-		Address *common.Address
-		Amount  *hexutil.Uint64
+	EstimateRewards(context.Context, *struct {
+		Address      *common.Address
+		Amount       *hexutil.Uint64
+		StakerId     *hexutil.Big
+		LockDuration *hexutil.Uint64
 	}) (EstimatedRewards, error)
 
 	// SfcRewardsCollectedAmount resolves the amount of collected rewards
 	// based on provided filtering criteria.
-	SfcRewardsCollectedAmount(struct {
+	SfcRewardsCollectedAmount(context.Context, struct {
 		Delegator *common.Address
 		Staker    *hexutil.Big
 		Since     *hexutil.Uint64
@@ -139,73 +177,76 @@ type ApiResolver interface {
 	}) (hexutil.Big, error)
 
 	// SendTransaction sends raw signed and RLP encoded transaction to the blockchain.
-	SendTransaction(*struct{ Tx hexutil.Bytes }) (*Transaction, error)
+	SendTransaction(context.Context, *struct{ Tx hexutil.Bytes }) (*Transaction, error)
 
 	// DefiConfiguration resolves the current DeFi contract settings.
-	DefiConfiguration() (*DefiConfiguration, error)
+	DefiConfiguration(context.Context) (*DefiConfiguration, error)
 
 	// DefiTokens resolves list of DeFi tokens available for the DeFi functions.
-	DefiTokens() ([]*DefiToken, error)
+	DefiTokens(context.Context) ([]*DefiToken, error)
 
 	// DefiUniswapPairs resolves a list of all pairs managed by the Uniswap core.
-	DefiUniswapPairs() []*UniswapPair
+	DefiUniswapPairs(context.Context) []*UniswapPair
+
+	// DefiUniswapPair resolves the Uniswap pair of the given address.
+	DefiUniswapPair(ctx context.Context, args *struct{ PairAddress common.Address }) (*UniswapPair, error)
 
 	// DefiUniswapAmountsOut resolves a list of output amounts for the given
 	// input amount and a list of tokens to be used to make the swap operation.
-	DefiUniswapAmountsOut(*struct {
+	DefiUniswapAmountsOut(context.Context, *struct {
 		AmountIn hexutil.Big
 		Tokens   []common.Address
 	}) ([]hexutil.Big, error)
 
 	// DefiUniswapAmountsIn resolves a list of input amounts for the given
 	// output amount and a list of tokens to be used to make the swap operation.
-	DefiUniswapAmountsIn(*struct {
+	DefiUniswapAmountsIn(context.Context, *struct {
 		AmountOut hexutil.Big
 		Tokens    []common.Address
 	}) ([]hexutil.Big, error)
 
 	// DefiUniswapQuoteLiquidity resolves a list of optimal amounts of tokens
 	// to be added to both sides of a pair on addLiquidity call.
-	DefiUniswapQuoteLiquidity(*struct {
+	DefiUniswapQuoteLiquidity(context.Context, *struct {
 		Tokens    []common.Address
 		AmountsIn []hexutil.Big
 	}) ([]hexutil.Big, error)
 
 	// FMintAccount resolves details of a specified DeFi account.
-	FMintAccount(*struct{ Owner common.Address }) (*FMintAccount, error)
+	FMintAccount(context.Context, *struct{ Owner common.Address }) (*FMintAccount, error)
 
 	// FMintTokenAllowance resolves the amount of ERC20 tokens unlocked
 	// by the token owner for DeFi/fMint protocol operations.
-	FMintTokenAllowance(args *struct {
+	FMintTokenAllowance(ctx context.Context, args *struct {
 		Owner common.Address
 		Token common.Address
 	}) hexutil.Big
 
 	// Erc20Token resolves an instance of ERC20 token if available.
-	Erc20Token(*struct{ Token common.Address }) *ERC20Token
+	Erc20Token(context.Context, *struct{ Token common.Address }) *ERC20Token
 
 	// Erc20TokenList resolves a list of instances of ERC20 tokens.
-	Erc20TokenList(struct{ Count int32 }) ([]*ERC20Token, error)
+	Erc20TokenList(context.Context, struct{ Count int32 }) ([]*ERC20Token, error)
 
 	// Erc20Assets resolves a list of instances of ERC20 tokens for the given owner.
-	Erc20Assets(struct {
+	Erc20Assets(context.Context, struct {
 		Owner common.Address
 		Count int32
 	}) ([]*ERC20Token, error)
 
 	// ErcTokenBalance resolves the current available balance of the specified token
 	// for the specified owner.
-	ErcTokenBalance(args *struct {
+	ErcTokenBalance(ctx context.Context, args *struct {
 		Owner common.Address
 		Token common.Address
 	}) hexutil.Big
 
 	// ErcTotalSupply resolves the current total supply of the specified token.
-	ErcTotalSupply(args *struct{ Token common.Address }) hexutil.Big
+	ErcTotalSupply(ctx context.Context, args *struct{ Token common.Address }) hexutil.Big
 
 	// ErcTokenAllowance resolves the current amount of ERC20 tokens unlocked
 	// by the token owner for the spender to be manipulated with.
-	ErcTokenAllowance(args *struct {
+	ErcTokenAllowance(ctx context.Context, args *struct {
 		Token   common.Address
 		Owner   common.Address
 		Spender common.Address
@@ -215,10 +256,10 @@ type ApiResolver interface {
 	GovContracts() ([]*GovernanceContract, error)
 
 	// GovContract provides a specific Governance contract information by its address.
-	GovContract(struct{ Address common.Address }) (*GovernanceContract, error)
+	GovContract(context.Context, struct{ Address common.Address }) (*GovernanceContract, error)
 
 	// GovProposals represents list of joined proposals across all the Governance contracts.
-	GovProposals(struct {
+	GovProposals(context.Context, struct {
 		Cursor     *Cursor
 		Count      int32
 		ActiveOnly bool
@@ -226,23 +267,123 @@ type ApiResolver interface {
 
 	// TrxVolume resolves list of daily aggregations
 	// of the network transaction flow.
-	TrxVolume(args struct {
+	TrxVolume(ctx context.Context, args struct {
 		From *string
 		To   *string
 	}) ([]*DailyTrxVolume, error)
 
+	// TrxDailyStats resolves list of daily aggregated statistics
+	// of the network transaction flow.
+	TrxDailyStats(ctx context.Context, args struct {
+		From *string
+		To   *string
+	}) ([]*DailyTrxVolume, error)
+
+	// BlockTimeStats resolves statistics of the block time and the time to finality
+	// over the given number of the most recent blocks.
+	BlockTimeStats(ctx context.Context, args struct{ Window int32 }) (*types.BlockTimeStats, error)
+
+	// BlockTimeDaily resolves list of daily aggregations of the block time and the time to finality.
+	BlockTimeDaily(ctx context.Context, args struct {
+		From *string
+		To   *string
+	}) ([]*DailyBlockTime, error)
+
+	// TopContractsByGas resolves the list of the contracts with the highest gas used.
+	TopContractsByGas(ctx context.Context, args struct{ Count int32 }) ([]*ContractGasRank, error)
+
+	// FtmBurnedTotal resolves the total amount of native FTM tokens burned.
+	FtmBurnedTotal(context.Context) (hexutil.Big, error)
+
+	// FtmBurnList resolves a list of FTM burns of blocks from the most recent one.
+	FtmBurnList(ctx context.Context, args struct {
+		Cursor *Cursor
+		Count  int32
+	}) (*FtmBurnList, error)
+
 	// TrxSpeed resolves the recent speed of the network in transactions processed per second.
-	TrxSpeed(args struct {
+	TrxSpeed(ctx context.Context, args struct {
 		Range int32
 	}) (float64, error)
 
 	// TrxGasSpeed resolves the gas consumption speed
 	// of the network in transactions processed per second.
-	TrxGasSpeed(args struct {
+	TrxGasSpeed(ctx context.Context, args struct {
 		Range int32
 		To    *string
 	}) (float64, error)
 
+	// CreateApiKey resolves a new API key issued with the given usage limits.
+	CreateApiKey(context.Context, *struct {
+		Name       string
+		RateLimit  int32
+		DailyQuota *hexutil.Uint64
+	}) (*ApiKey, error)
+
+	// RevokeApiKey resolves revocation of the given API key.
+	RevokeApiKey(context.Context, *struct{ Key string }) (bool, error)
+
+	// AuditLog resolves the most recent records of the executed operations audit log.
+	AuditLog(context.Context, *struct {
+		Client    *string
+		Operation *string
+		Count     int32
+	}) ([]*AuditRecord, error)
+
+	// ScannerState resolves the current state of the blockchain data scanner.
+	ScannerState() *ScannerStatus
+
+	// Admin resolves the administration namespace of the API server.
+	Admin(context.Context) (*Admin, error)
+
+	// AccountLabels resolves the list of known account labels, optionally of the given category only.
+	AccountLabels(ctx context.Context, args struct{ Category *string }) ([]*AccountLabel, error)
+
+	// SetAccountLabel resolves assignment of a label to the given address.
+	SetAccountLabel(context.Context, *struct {
+		Address  common.Address
+		Name     string
+		Category string
+	}) (*AccountLabel, error)
+
+	// RemoveAccountLabel resolves removal of the label of the given address.
+	RemoveAccountLabel(context.Context, *struct{ Address common.Address }) (bool, error)
+
+	// Watches resolves the list of address watches of the client.
+	Watches(context.Context) ([]*Watch, error)
+
+	// CreateWatch resolves registration of a new webhook notified about events of the given address.
+	CreateWatch(context.Context, *struct {
+		Address common.Address
+		Url     string
+		Events  []string
+	}) (*Watch, error)
+
+	// RemoveWatch resolves removal of the watch of the given id.
+	RemoveWatch(context.Context, *struct{ Id string }) (bool, error)
+
+	// CreateDelegation resolves an unsigned SFC call delegating the given amount to the validator.
+	CreateDelegation(context.Context, *struct {
+		From        common.Address
+		ValidatorId hexutil.Big
+		Amount      hexutil.Big
+	}) (*types.UnsignedTransaction, error)
+
+	// ClaimRewards resolves an unsigned SFC call claiming pending rewards of the delegation.
+	ClaimRewards(context.Context, *struct {
+		From        common.Address
+		ValidatorId hexutil.Big
+		Restake     bool
+	}) (*types.UnsignedTransaction, error)
+
+	// Undelegate resolves an unsigned SFC call un-delegating the given amount from the validator.
+	Undelegate(context.Context, *struct {
+		From        common.Address
+		ValidatorId hexutil.Big
+		Amount      hexutil.Big
+		RequestId   *hexutil.Big
+	}) (*types.UnsignedTransaction, error)
+
 	// Close terminates resolver broadcast management.
 	Close()
 }
@@ -0,0 +1,66 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+)
+
+// InternalTransaction represents a resolvable call executed inside a transaction.
+type InternalTransaction struct {
+	types.InternalTransaction
+}
+
+// NewInternalTransaction creates a new instance of resolvable internal transaction.
+func NewInternalTransaction(itx *types.InternalTransaction) *InternalTransaction {
+	return &InternalTransaction{InternalTransaction: *itx}
+}
+
+// newInternalTransactionList converts a list of internal transactions into a resolvable list.
+func newInternalTransactionList(list []*types.InternalTransaction) []*InternalTransaction {
+	res := make([]*InternalTransaction, len(list))
+	for i, itx := range list {
+		res[i] = NewInternalTransaction(itx)
+	}
+	return res
+}
+
+// Transaction resolves the parent transaction of the internal call.
+func (itx *InternalTransaction) Transaction(ctx context.Context) (*Transaction, error) {
+	trx, err := loadTransaction(ctx, &itx.TrxHash)
+	if err != nil {
+		return nil, err
+	}
+	return NewTransaction(trx), nil
+}
+
+// InternalTransactions resolves the list of calls executed inside the transaction.
+func (trx *Transaction) InternalTransactions(ctx context.Context) ([]*InternalTransaction, error) {
+	// simple transfers and contract deployments without calls have no internal transactions
+	if trx.To == nil || len(trx.InputData) == 0 {
+		return []*InternalTransaction{}, nil
+	}
+
+	list, err := repository.R().InternalTransactions(ctx, &trx.Hash)
+	if err != nil {
+		return nil, err
+	}
+	return newInternalTransactionList(list), nil
+}
+
+// InternalTxList resolves the list of the most recent known internal transactions
+// the account is involved with.
+func (acc *Account) InternalTxList(ctx context.Context, args struct{ Count int32 }) ([]*InternalTransaction, error) {
+	// limit query size
+	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
+	if args.Count < 0 {
+		args.Count = -args.Count
+	}
+
+	list, err := repository.R().InternalTransactionsOfAccount(ctx, &acc.Address, args.Count)
+	if err != nil {
+		return nil, err
+	}
+	return newInternalTransactionList(list), nil
+}
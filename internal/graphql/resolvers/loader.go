@@ -0,0 +1,258 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"sync"
+	"time"
+)
+
+// loaderBatchWait represents the time a loader waits for more keys
+// to be requested before the batch is loaded.
+const loaderBatchWait = 2 * time.Millisecond
+
+// loaderBatchMaxSize represents the max number of keys loaded in a single batch.
+const loaderBatchMaxSize = 100
+
+// loadersKey represents the context key of the request data loaders.
+type loadersKey struct{}
+
+// loaders represents the set of data loaders of a single API request.
+type loaders struct {
+	account *loader
+	block   *loader
+	trx     *loader
+}
+
+// loader collects keys requested by resolvers running in parallel
+// and loads them in a single batch. Loaded values are kept for the whole request,
+// so the same key is never loaded twice.
+type loader struct {
+	mu       sync.Mutex
+	ctx      context.Context
+	fetch    func(context.Context, []interface{}) ([]interface{}, error)
+	notFound error
+	calls    map[interface{}]*loaderCall
+	batch    *loaderBatch
+}
+
+// loaderBatch represents a batch of keys waiting to be loaded.
+type loaderBatch struct {
+	keys  []interface{}
+	calls []*loaderCall
+	sent  bool
+}
+
+// loaderCall represents a single key load shared by all its callers.
+type loaderCall struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// WithLoaders provides a context with a new set of data loaders attached.
+// Resolvers use the loaders to batch data requests of a single API request.
+// The batches are loaded within the given request context.
+func WithLoaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{
+		account: newLoader(ctx, fetchAccounts, types.NewError(types.ErrCodeNotFound, "account not found")),
+		block:   newLoader(ctx, fetchBlocks, repository.ErrBlockNotFound),
+		trx:     newLoader(ctx, fetchTransactions, repository.ErrTransactionNotFound),
+	})
+}
+
+// loadersOf provides the data loaders attached to the given context, if any.
+func loadersOf(ctx context.Context) *loaders {
+	if ctx == nil {
+		return nil
+	}
+	ld, _ := ctx.Value(loadersKey{}).(*loaders)
+	return ld
+}
+
+// newLoader creates a new loader using the given batch fetch function.
+// The not found error is returned for keys the fetch function did not provide.
+func newLoader(ctx context.Context, fetch func(context.Context, []interface{}) ([]interface{}, error), notFound error) *loader {
+	return &loader{
+		ctx:      ctx,
+		fetch:    fetch,
+		notFound: notFound,
+		calls:    make(map[interface{}]*loaderCall),
+	}
+}
+
+// load provides the value of the given key loaded in a batch with other keys.
+func (ld *loader) load(key interface{}) (interface{}, error) {
+	ld.mu.Lock()
+
+	// the key is already known
+	if call, ok := ld.calls[key]; ok {
+		ld.mu.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+
+	// add the key to the current batch; start a new batch if needed
+	call := &loaderCall{done: make(chan struct{})}
+	ld.calls[key] = call
+
+	if ld.batch == nil {
+		ld.batch = &loaderBatch{}
+		go ld.dispatchAfter(ld.batch, loaderBatchWait)
+	}
+	ld.batch.keys = append(ld.batch.keys, key)
+	ld.batch.calls = append(ld.batch.calls, call)
+
+	// the batch is full, send it right away
+	if len(ld.batch.keys) >= loaderBatchMaxSize {
+		go ld.dispatchAfter(ld.batch, 0)
+		ld.batch = nil
+	}
+
+	ld.mu.Unlock()
+	<-call.done
+	return call.value, call.err
+}
+
+// dispatchAfter loads the given batch after the given delay,
+// unless the batch has already been sent.
+func (ld *loader) dispatchAfter(b *loaderBatch, delay time.Duration) {
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+
+	ld.mu.Lock()
+	if b.sent {
+		ld.mu.Unlock()
+		return
+	}
+	b.sent = true
+	if ld.batch == b {
+		ld.batch = nil
+	}
+	ld.mu.Unlock()
+
+	// load the values and release the callers
+	values, err := ld.fetch(ld.ctx, b.keys)
+	for i, call := range b.calls {
+		if err != nil {
+			call.err = err
+		} else if i >= len(values) || values[i] == nil {
+			call.err = ld.notFound
+		} else {
+			call.value = values[i]
+		}
+		close(call.done)
+	}
+}
+
+// fetchAccounts loads a batch of accounts by their addresses.
+func fetchAccounts(ctx context.Context, keys []interface{}) ([]interface{}, error) {
+	adr := make([]*common.Address, len(keys))
+	for i, k := range keys {
+		a := k.(common.Address)
+		adr[i] = &a
+	}
+
+	list, err := repository.R().Accounts(ctx, adr)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]interface{}, len(list))
+	for i, acc := range list {
+		if acc != nil {
+			res[i] = acc
+		}
+	}
+	return res, nil
+}
+
+// fetchBlocks loads a batch of blocks by their numbers.
+func fetchBlocks(ctx context.Context, keys []interface{}) ([]interface{}, error) {
+	nums := make([]uint64, len(keys))
+	for i, k := range keys {
+		nums[i] = k.(uint64)
+	}
+
+	list, err := repository.R().BlocksByNumbers(ctx, nums)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]interface{}, len(list))
+	for i, blk := range list {
+		if blk != nil {
+			res[i] = blk
+		}
+	}
+	return res, nil
+}
+
+// fetchTransactions loads a batch of transactions by their hashes.
+func fetchTransactions(ctx context.Context, keys []interface{}) ([]interface{}, error) {
+	hashes := make([]*common.Hash, len(keys))
+	for i, k := range keys {
+		h := k.(common.Hash)
+		hashes[i] = &h
+	}
+
+	list, err := repository.R().TransactionsByHash(ctx, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]interface{}, len(list))
+	for i, trx := range list {
+		if trx != nil {
+			res[i] = trx
+		}
+	}
+	return res, nil
+}
+
+// loadAccount provides the account of the given address using the request loader, if available.
+func loadAccount(ctx context.Context, adr *common.Address) (*types.Account, error) {
+	ld := loadersOf(ctx)
+	if ld == nil {
+		return repository.R().Account(ctx, adr)
+	}
+
+	acc, err := ld.account.load(*adr)
+	if err != nil {
+		return nil, err
+	}
+	return acc.(*types.Account), nil
+}
+
+// loadBlock provides the block of the given number using the request loader, if available.
+func loadBlock(ctx context.Context, num *hexutil.Uint64) (*types.Block, error) {
+	ld := loadersOf(ctx)
+	if ld == nil {
+		return repository.R().BlockByNumber(ctx, num)
+	}
+
+	blk, err := ld.block.load(uint64(*num))
+	if err != nil {
+		return nil, err
+	}
+	return blk.(*types.Block), nil
+}
+
+// loadTransaction provides the transaction of the given hash using the request loader, if available.
+func loadTransaction(ctx context.Context, hash *common.Hash) (*types.Transaction, error) {
+	ld := loadersOf(ctx)
+	if ld == nil {
+		return repository.R().Transaction(ctx, hash)
+	}
+
+	trx, err := ld.trx.load(*hash)
+	if err != nil {
+		return nil, err
+	}
+	return trx.(*types.Transaction), nil
+}
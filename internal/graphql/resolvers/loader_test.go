@@ -0,0 +1,138 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"github.com/onsi/gomega"
+	"sync"
+	"testing"
+)
+
+// testFetch represents a batch fetch function recording the requested batches.
+type testFetch struct {
+	mu      sync.Mutex
+	batches [][]interface{}
+	err     error
+}
+
+// fetch provides the keys doubled; odd keys are not found.
+func (tf *testFetch) fetch(_ context.Context, keys []interface{}) ([]interface{}, error) {
+	tf.mu.Lock()
+	tf.batches = append(tf.batches, keys)
+	tf.mu.Unlock()
+
+	if tf.err != nil {
+		return nil, tf.err
+	}
+
+	res := make([]interface{}, len(keys))
+	for i, k := range keys {
+		if k.(int)%2 == 0 {
+			res[i] = 2 * k.(int)
+		}
+	}
+	return res, nil
+}
+
+// short provides the first key only, regardless of the number of keys requested.
+func (tf *testFetch) short(ctx context.Context, keys []interface{}) ([]interface{}, error) {
+	res, err := tf.fetch(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+	return res[:1], nil
+}
+
+func TestLoaderBatches(t *testing.T) {
+	notFound := fmt.Errorf("not found")
+
+	tests := []struct {
+		name    string
+		keys    []int
+		err     error
+		short   bool
+		batches int
+	}{
+		{name: "single key", keys: []int{2}, batches: 1},
+		{name: "parallel keys in one batch", keys: []int{0, 2, 4, 6, 8, 10}, batches: 1},
+		{name: "repeated keys loaded once", keys: []int{2, 2, 2, 4, 4}, batches: 1},
+		{name: "not found keys", keys: []int{1, 2, 3}, batches: 1},
+		{name: "full batches split", keys: seq(2*loaderBatchMaxSize + 10), batches: 3},
+		{name: "fetch failure", keys: []int{2, 4}, err: fmt.Errorf("database down"), batches: 1},
+		{name: "fetch with missing values", keys: []int{0, 2, 4}, short: true, batches: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			tf := &testFetch{err: tt.err}
+			fetch := tf.fetch
+			if tt.short {
+				fetch = tf.short
+			}
+			ld := newLoader(context.Background(), fetch, notFound)
+
+			// load all the keys in parallel, as the GraphQL resolvers do
+			values := make([]interface{}, len(tt.keys))
+			errs := make([]error, len(tt.keys))
+			start := make(chan struct{})
+			var wg sync.WaitGroup
+			for i, k := range tt.keys {
+				wg.Add(1)
+				go func(i int, k int) {
+					defer wg.Done()
+					<-start
+					values[i], errs[i] = ld.load(k)
+				}(i, k)
+			}
+			close(start)
+			wg.Wait()
+
+			g.Expect(tf.batches).To(gomega.HaveLen(tt.batches))
+			seen := map[interface{}]bool{}
+			for _, b := range tf.batches {
+				g.Expect(len(b)).To(gomega.BeNumerically("<=", loaderBatchMaxSize))
+				for _, k := range b {
+					g.Expect(seen[k]).To(gomega.BeFalse(), "key %v loaded twice", k)
+					seen[k] = true
+				}
+			}
+
+			for i, k := range tt.keys {
+				switch {
+				case tt.err != nil:
+					g.Expect(errs[i]).To(gomega.Equal(tt.err))
+				case k%2 == 1 || (tt.short && values[i] == nil):
+					g.Expect(errs[i]).To(gomega.Equal(notFound))
+				default:
+					g.Expect(errs[i]).To(gomega.BeNil())
+					g.Expect(values[i]).To(gomega.Equal(2 * k))
+				}
+			}
+		})
+	}
+}
+
+func TestLoaderCachesValues(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tf := &testFetch{}
+	ld := newLoader(context.Background(), tf.fetch, fmt.Errorf("not found"))
+
+	for i := 0; i < 3; i++ {
+		v, err := ld.load(4)
+		g.Expect(err).To(gomega.BeNil())
+		g.Expect(v).To(gomega.Equal(8))
+	}
+	g.Expect(tf.batches).To(gomega.HaveLen(1))
+}
+
+// seq provides the list of even numbers of the given length.
+func seq(n int) []int {
+	list := make([]int, n)
+	for i := range list {
+		list[i] = 2 * i
+	}
+	return list
+}
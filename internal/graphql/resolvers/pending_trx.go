@@ -0,0 +1,45 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+)
+
+// PendingTransactions resolves a list of transactions pending in the node transaction pool
+// sorted by their gas price from the highest to the lowest.
+func (rs *rootResolver) PendingTransactions(ctx context.Context, args struct{ Count int32 }) ([]*Transaction, error) {
+	// limit query size; the list is always loaded from the top
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	if args.Count < 0 {
+		args.Count = -args.Count
+	}
+
+	list, err := repository.R().PendingTransactions(ctx, args.Count)
+	if err != nil {
+		log.Errorf("can not get pending transactions; %s", err.Error())
+		return nil, err
+	}
+	return pendingTransactionsList(list), nil
+}
+
+// PendingTxList resolves a list of transactions of the account pending
+// in the node transaction pool sorted by their nonce.
+func (acc *Account) PendingTxList(ctx context.Context) ([]*Transaction, error) {
+	list, err := repository.R().PendingTransactionsOf(ctx, &acc.Address)
+	if err != nil {
+		log.Errorf("can not get pending transactions of %s; %s", acc.Address.String(), err.Error())
+		return nil, err
+	}
+	return pendingTransactionsList(list), nil
+}
+
+// pendingTransactionsList converts the list of pending transactions to resolvable transactions.
+func pendingTransactionsList(list []*types.Transaction) []*Transaction {
+	res := make([]*Transaction, len(list))
+	for i, trx := range list {
+		res[i] = NewTransaction(trx)
+	}
+	return res
+}
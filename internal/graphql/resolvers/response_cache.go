@@ -0,0 +1,60 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fantom-api-graphql/internal/repository"
+	"fmt"
+	"sync/atomic"
+)
+
+// cachedResponse fills the target with the response of the resolver of the given name and arguments.
+// The response is taken from the response cache if available, otherwise the loader is executed
+// and its response is cached. Cached responses are bound to the current head block,
+// so a new block invalidates them.
+func (rs *rootResolver) cachedResponse(name string, args interface{}, target interface{}, load func() error) error {
+	key, err := responseKey(name, args, atomic.LoadUint64(&rs.head))
+	if err != nil {
+		return load()
+	}
+
+	// try the cache first
+	if data := repository.R().ResolverResponse(key); data != nil {
+		err := json.Unmarshal(data, target)
+		if err == nil {
+			return nil
+		}
+		log.Errorf("can not decode cached %s response; %s", name, err.Error())
+	}
+
+	// load the response once for all the parallel requests
+	data, err, _ := rs.cg.Do("rsp_"+key, func() (interface{}, error) {
+		if err := load(); err != nil {
+			return nil, err
+		}
+
+		data, err := json.Marshal(target)
+		if err != nil {
+			return nil, err
+		}
+		repository.R().StoreResolverResponse(key, data)
+		return data, nil
+	})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data.([]byte), target)
+}
+
+// responseKey provides the response cache key of the resolver of the given name and arguments at the given block.
+func responseKey(name string, args interface{}, head uint64) (string, error) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf("%s_%s_%d", name, hex.EncodeToString(sum[:8]), head), nil
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"golang.org/x/sync/singleflight"
 	"sync"
+	"sync/atomic"
 )
 
 const (
@@ -41,6 +42,14 @@ type rootResolver struct {
 	unsubscribeOnTrx chan string
 	trxSubscribers   map[string]*subscriptOnTrx
 	onTrxEvents      chan *types.Transaction
+
+	// transaction status subscriptions management
+	subscribeOnTrxStatus   chan *subscriptOnTrxStatus
+	unsubscribeOnTrxStatus chan string
+	trxStatusSubscribers   map[string]*subscriptOnTrxStatus
+
+	// head is the number of the most recent block observed; it binds the cached responses
+	head uint64
 }
 
 // log represents the logger to be used by the repository.
@@ -87,6 +96,11 @@ func New() ApiResolver {
 		unsubscribeOnTrx: make(chan string, subscriptionQueueCapacity),
 		trxSubscribers:   make(map[string]*subscriptOnTrx, subscriptionInitialCapacity),
 		onTrxEvents:      make(chan *types.Transaction, onBlockChannelCapacity),
+
+		// transaction status subscription basics
+		subscribeOnTrxStatus:   make(chan *subscriptOnTrxStatus, subscriptionQueueCapacity),
+		unsubscribeOnTrxStatus: make(chan string, subscriptionQueueCapacity),
+		trxStatusSubscribers:   make(map[string]*subscriptOnTrxStatus, subscriptionInitialCapacity),
 	}
 
 	// pass subscription data source channels to the service manager
@@ -136,17 +150,25 @@ func (rs *rootResolver) run() {
 		case id := <-rs.unsubscribeOnTrx:
 			delete(rs.trxSubscribers, id)
 
+		case id := <-rs.unsubscribeOnTrxStatus:
+			delete(rs.trxStatusSubscribers, id)
+
 		case sub := <-rs.subscribeOnBlock:
 			rs.addBlockSubscriber(sub)
 
 		case sub := <-rs.subscribeOnTrx:
 			rs.addTrxSubscriber(sub)
 
+		case sub := <-rs.subscribeOnTrxStatus:
+			rs.addTrxStatusSubscriber(sub)
+
 		case evt := <-rs.onBlockEvents:
+			atomic.StoreUint64(&rs.head, uint64(evt.Number))
 			rs.dispatchOnBlock(evt)
 
 		case evt := <-rs.onTrxEvents:
 			rs.dispatchOnTransaction(evt)
+			rs.dispatchOnTransactionStatus(evt)
 		}
 	}
 }
@@ -0,0 +1,28 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"fantom-api-graphql/internal/svc"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ScannerState resolves the current state of the blockchain data scanner,
+// so the indexing lag can be monitored.
+func (rs *rootResolver) ScannerState() *ScannerStatus {
+	return &ScannerStatus{ScannerStatus: *svc.Manager().ScannerStatus()}
+}
+
+// ChainHead resolves the number of the most recent block observed on the chain.
+func (ss *ScannerStatus) ChainHead() hexutil.Uint64 {
+	return hexutil.Uint64(ss.ScannerStatus.ChainHead)
+}
+
+// BlocksBehind resolves the number of blocks the processing is behind the chain head.
+func (ss *ScannerStatus) BlocksBehind() hexutil.Uint64 {
+	return hexutil.Uint64(ss.ScannerStatus.BlocksBehind())
+}
+
+// CatchUpTime resolves the estimated time in seconds the scanner needs to catch up with the chain head.
+func (ss *ScannerStatus) CatchUpTime() hexutil.Uint64 {
+	return hexutil.Uint64(ss.ScannerStatus.CatchUpTime().Seconds())
+}
@@ -0,0 +1,131 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// reSearchBlockNumber represents a phrase expected to be a block number.
+	reSearchBlockNumber = regexp.MustCompile(`^(\d{1,19}|0x[0-9a-fA-F]{1,16})$`)
+
+	// reSearchHash represents a phrase expected to be a transaction, or a block hash.
+	reSearchHash = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+
+	// reSearchAddress represents a phrase expected to be an account address.
+	reSearchAddress = regexp.MustCompile(`^0x[0-9a-fA-F]{40}$`)
+
+	// reSearchSymbol represents a phrase expected to be a token symbol.
+	reSearchSymbol = regexp.MustCompile(`^[\w.\-]{1,16}$`)
+)
+
+// SearchResult represents a resolvable search result, one of the union members.
+type SearchResult struct {
+	block *Block
+	trx   *Transaction
+	acc   *Account
+	token *ERC20Token
+}
+
+// Search resolves the given phrase as a block number, a transaction hash, a block hash,
+// an account address, or an ERC20 token symbol and returns the matching entities.
+func (rs *rootResolver) Search(ctx context.Context, args struct{ Phrase string }) ([]*SearchResult, error) {
+	phrase := strings.TrimSpace(args.Phrase)
+	switch {
+	case reSearchBlockNumber.MatchString(phrase):
+		return rs.searchBlockNumber(ctx, phrase), nil
+	case reSearchHash.MatchString(phrase):
+		return rs.searchHash(ctx, common.HexToHash(phrase)), nil
+	case reSearchAddress.MatchString(phrase):
+		adr, err := types.ParseAddress(phrase)
+		if err != nil {
+			return nil, err
+		}
+		return rs.searchAddress(ctx, adr), nil
+	case reSearchSymbol.MatchString(phrase):
+		return rs.searchTokenSymbol(ctx, phrase)
+	}
+	return []*SearchResult{}, nil
+}
+
+// searchBlockNumber finds a block by the given decimal, or hexadecimal number.
+func (rs *rootResolver) searchBlockNumber(ctx context.Context, phrase string) []*SearchResult {
+	num, err := strconv.ParseUint(phrase, 0, 64)
+	if err != nil {
+		return []*SearchResult{}
+	}
+
+	// the block number is always given to avoid loading the latest block
+	bn := hexutil.Uint64(num)
+	blk, err := repository.R().BlockByNumber(ctx, &bn)
+	if err != nil || blk == nil {
+		return []*SearchResult{}
+	}
+	return []*SearchResult{{block: NewBlock(blk)}}
+}
+
+// searchHash finds a transaction, or a block by the given hash.
+func (rs *rootResolver) searchHash(ctx context.Context, hash common.Hash) []*SearchResult {
+	if trx, err := repository.R().Transaction(ctx, &hash); err == nil && trx != nil {
+		return []*SearchResult{{trx: NewTransaction(trx)}}
+	}
+	if blk, err := repository.R().BlockByHash(ctx, &hash); err == nil && blk != nil {
+		return []*SearchResult{{block: NewBlock(blk)}}
+	}
+	return []*SearchResult{}
+}
+
+// searchAddress finds an account, and an ERC20 token, by the given address.
+func (rs *rootResolver) searchAddress(ctx context.Context, adr common.Address) []*SearchResult {
+	list := make([]*SearchResult, 0)
+	if acc, err := repository.R().Account(ctx, &adr); err == nil {
+		list = append(list, &SearchResult{acc: NewAccount(acc)})
+	}
+	if tok := NewErc20Token(ctx, &adr); tok != nil {
+		list = append(list, &SearchResult{token: tok})
+	}
+	return list
+}
+
+// searchTokenSymbol finds known ERC20 tokens by the given symbol.
+func (rs *rootResolver) searchTokenSymbol(ctx context.Context, sym string) ([]*SearchResult, error) {
+	al, err := repository.R().Erc20TokensBySymbol(ctx, sym, int32(listMaxEdgesPerRequest))
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*SearchResult, 0, len(al))
+	for i := range al {
+		if tok := NewErc20Token(ctx, &al[i]); tok != nil {
+			list = append(list, &SearchResult{token: tok})
+		}
+	}
+	return list, nil
+}
+
+// ToBlock resolves the block search result.
+func (sr *SearchResult) ToBlock() (*Block, bool) {
+	return sr.block, sr.block != nil
+}
+
+// ToTransaction resolves the transaction search result.
+func (sr *SearchResult) ToTransaction() (*Transaction, bool) {
+	return sr.trx, sr.trx != nil
+}
+
+// ToAccount resolves the account search result.
+func (sr *SearchResult) ToAccount() (*Account, bool) {
+	return sr.acc, sr.acc != nil
+}
+
+// ToERC20Token resolves the ERC20 token search result.
+func (sr *SearchResult) ToERC20Token() (*ERC20Token, bool) {
+	return sr.token, sr.token != nil
+}
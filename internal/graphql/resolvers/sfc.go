@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -9,13 +10,13 @@ import (
 )
 
 // CurrentEpoch resolves the id of the current epoch of the Opera blockchain.
-func (rs *rootResolver) CurrentEpoch() (hexutil.Uint64, error) {
-	return repository.R().CurrentEpoch()
+func (rs *rootResolver) CurrentEpoch(ctx context.Context) (hexutil.Uint64, error) {
+	return repository.R().CurrentEpoch(ctx)
 }
 
 // LastStakerId resolves the last staker id in Opera blockchain.
-func (rs *rootResolver) LastStakerId() (hexutil.Uint64, error) {
-	val, err := repository.R().LastValidatorId()
+func (rs *rootResolver) LastStakerId(ctx context.Context) (hexutil.Uint64, error) {
+	val, err := repository.R().LastValidatorId(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -23,8 +24,8 @@ func (rs *rootResolver) LastStakerId() (hexutil.Uint64, error) {
 }
 
 // StakersNum resolves the number of stakers in Opera blockchain.
-func (rs *rootResolver) StakersNum() (hexutil.Uint64, error) {
-	val, err := repository.R().ValidatorsCount()
+func (rs *rootResolver) StakersNum(ctx context.Context) (hexutil.Uint64, error) {
+	val, err := repository.R().ValidatorsCount(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -32,29 +33,39 @@ func (rs *rootResolver) StakersNum() (hexutil.Uint64, error) {
 }
 
 // Staker resolves a validator information from SFC smart contract.
-func (rs *rootResolver) Staker(args struct {
+func (rs *rootResolver) Staker(ctx context.Context, args struct {
 	Id      *hexutil.Big
 	Address *common.Address
 }) (*Staker, error) {
 	// by ID or by address?
 	if args.Id != nil {
-		st, err := repository.R().Validator(args.Id)
+		st, err := repository.R().Validator(ctx, args.Id)
 		if err != nil {
 			return nil, err
 		}
 		return NewStaker(st), err
 	}
 
-	st, err := repository.R().ValidatorByAddress(args.Address)
+	st, err := repository.R().ValidatorByAddress(ctx, args.Address)
 	if err != nil {
 		return nil, err
 	}
 	return NewStaker(st), err
 }
 
+// StakerByAddress resolves a staker information from SFC smart contract
+// by the address of the staker.
+func (rs *rootResolver) StakerByAddress(ctx context.Context, args struct{ Address common.Address }) (*Staker, error) {
+	st, err := repository.R().ValidatorByAddress(ctx, &args.Address)
+	if err != nil {
+		return nil, err
+	}
+	return NewStaker(st), nil
+}
+
 // SfcRewardsCollectedAmount resolves the amount of collected rewards
 // based on provided filtering criteria.
-func (rs *rootResolver) SfcRewardsCollectedAmount(args struct {
+func (rs *rootResolver) SfcRewardsCollectedAmount(ctx context.Context, args struct {
 	Delegator *common.Address
 	Staker    *hexutil.Big
 	Since     *hexutil.Uint64
@@ -75,7 +86,7 @@ func (rs *rootResolver) SfcRewardsCollectedAmount(args struct {
 	}
 
 	// get the filtered amount
-	val, err := repository.R().RewardsClaimed(args.Delegator, (*big.Int)(args.Staker), since, until)
+	val, err := repository.R().RewardsClaimed(ctx, args.Delegator, (*big.Int)(args.Staker), since, until)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
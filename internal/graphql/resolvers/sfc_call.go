@@ -0,0 +1,44 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"time"
+)
+
+// CreateDelegation resolves an unsigned SFC call delegating the given amount to the validator.
+func (rs *rootResolver) CreateDelegation(ctx context.Context, args *struct {
+	From        common.Address
+	ValidatorId hexutil.Big
+	Amount      hexutil.Big
+}) (*types.UnsignedTransaction, error) {
+	return repository.R().SfcDelegateCall(ctx, &args.From, &args.ValidatorId, &args.Amount)
+}
+
+// ClaimRewards resolves an unsigned SFC call claiming pending rewards of the delegation.
+func (rs *rootResolver) ClaimRewards(ctx context.Context, args *struct {
+	From        common.Address
+	ValidatorId hexutil.Big
+	Restake     bool
+}) (*types.UnsignedTransaction, error) {
+	return repository.R().SfcClaimRewardsCall(ctx, &args.From, &args.ValidatorId, args.Restake)
+}
+
+// Undelegate resolves an unsigned SFC call un-delegating the given amount from the validator.
+// If the withdraw request ID is not given, the current UNIX time is used.
+func (rs *rootResolver) Undelegate(ctx context.Context, args *struct {
+	From        common.Address
+	ValidatorId hexutil.Big
+	Amount      hexutil.Big
+	RequestId   *hexutil.Big
+}) (*types.UnsignedTransaction, error) {
+	if args.RequestId == nil {
+		args.RequestId = (*hexutil.Big)(big.NewInt(time.Now().Unix()))
+	}
+	return repository.R().SfcUndelegateCall(ctx, &args.From, &args.ValidatorId, args.RequestId, &args.Amount)
+}
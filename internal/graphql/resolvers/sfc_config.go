@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -18,10 +19,10 @@ func (rs *rootResolver) SfcConfig() SfcConfig {
 }
 
 // getConfig load the configuration from repository.
-func (sc SfcConfig) getConfig() (*types.SfcConfig, error) {
+func (sc SfcConfig) getConfig(ctx context.Context) (*types.SfcConfig, error) {
 	// get the SFC configuration only once
 	cfg, err, _ := sc.cg.Do("cfg", func() (interface{}, error) {
-		return repository.R().SfcConfiguration()
+		return repository.R().SfcConfiguration(ctx)
 	})
 
 	// loader failed
@@ -32,8 +33,8 @@ func (sc SfcConfig) getConfig() (*types.SfcConfig, error) {
 }
 
 // MinValidatorStake resolves the minimal validator stake in WEI unit.
-func (sc SfcConfig) MinValidatorStake() (hexutil.Big, error) {
-	c, err := sc.getConfig()
+func (sc SfcConfig) MinValidatorStake(ctx context.Context) (hexutil.Big, error) {
+	c, err := sc.getConfig(ctx)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -42,8 +43,8 @@ func (sc SfcConfig) MinValidatorStake() (hexutil.Big, error) {
 
 // MaxDelegatedRatio resolves the ratio between self stake
 // and all received stake in 18 digits number multiplier.
-func (sc SfcConfig) MaxDelegatedRatio() (hexutil.Big, error) {
-	c, err := sc.getConfig()
+func (sc SfcConfig) MaxDelegatedRatio(ctx context.Context) (hexutil.Big, error) {
+	c, err := sc.getConfig(ctx)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -51,8 +52,8 @@ func (sc SfcConfig) MaxDelegatedRatio() (hexutil.Big, error) {
 }
 
 // MinLockupDuration resolves the lowest lockup duration allowed.
-func (sc SfcConfig) MinLockupDuration() (hexutil.Big, error) {
-	c, err := sc.getConfig()
+func (sc SfcConfig) MinLockupDuration(ctx context.Context) (hexutil.Big, error) {
+	c, err := sc.getConfig(ctx)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -60,8 +61,8 @@ func (sc SfcConfig) MinLockupDuration() (hexutil.Big, error) {
 }
 
 // MaxLockupDuration resolves the highest lockup duration allowed.
-func (sc SfcConfig) MaxLockupDuration() (hexutil.Big, error) {
-	c, err := sc.getConfig()
+func (sc SfcConfig) MaxLockupDuration(ctx context.Context) (hexutil.Big, error) {
+	c, err := sc.getConfig(ctx)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -70,8 +71,8 @@ func (sc SfcConfig) MaxLockupDuration() (hexutil.Big, error) {
 
 // WithdrawalPeriodEpochs resolves the minimal number of epochs allowed
 // between un-delegate and withdraw requests.
-func (sc SfcConfig) WithdrawalPeriodEpochs() (hexutil.Big, error) {
-	c, err := sc.getConfig()
+func (sc SfcConfig) WithdrawalPeriodEpochs(ctx context.Context) (hexutil.Big, error) {
+	c, err := sc.getConfig(ctx)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -80,8 +81,8 @@ func (sc SfcConfig) WithdrawalPeriodEpochs() (hexutil.Big, error) {
 
 // WithdrawalPeriodTime resolves the minimal number of seconds allowed
 // between un-delegate and withdraw requests.
-func (sc SfcConfig) WithdrawalPeriodTime() (hexutil.Big, error) {
-	c, err := sc.getConfig()
+func (sc SfcConfig) WithdrawalPeriodTime(ctx context.Context) (hexutil.Big, error) {
+	c, err := sc.getConfig(ctx)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
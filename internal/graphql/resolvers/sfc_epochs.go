@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -22,7 +23,7 @@ func NewEpochList(el *types.EpochList) *EpochList {
 }
 
 // Epochs resolves a list of epochs for the given cursor and count.
-func (rs *rootResolver) Epochs(args struct {
+func (rs *rootResolver) Epochs(ctx context.Context, args struct {
 	Cursor *Cursor
 	Count  int32
 }) (*EpochList, error) {
@@ -31,7 +32,7 @@ func (rs *rootResolver) Epochs(args struct {
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// get the transaction hash list from repository
-	epl, err := repository.R().Epochs((*string)(args.Cursor), args.Count)
+	epl, err := repository.R().Epochs(ctx, (*string)(args.Cursor), args.Count)
 	if err != nil {
 		log.Errorf("can not get epoch list; %s", err.Error())
 		return nil, err
@@ -40,8 +41,8 @@ func (rs *rootResolver) Epochs(args struct {
 }
 
 // TotalCount resolves the total number of epochs in the list.
-func (el *EpochList) TotalCount() (hexutil.Uint64, error) {
-	return repository.R().CurrentEpoch()
+func (el *EpochList) TotalCount(ctx context.Context) (hexutil.Uint64, error) {
+	return repository.R().CurrentEpoch(ctx)
 }
 
 // PageInfo resolves the current page information for the epoch list.
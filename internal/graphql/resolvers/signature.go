@@ -0,0 +1,32 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// signatureSchemePersonal represents the EIP-191 personal_sign signature scheme.
+	signatureSchemePersonal = "PERSONAL_SIGN"
+
+	// signatureSchemeTypedData represents the EIP-712 typed data signature scheme.
+	signatureSchemeTypedData = "EIP712"
+)
+
+// VerifySignature resolves if the given message has been signed by the given address.
+func (rs *rootResolver) VerifySignature(ctx context.Context, args *struct {
+	Address   common.Address
+	Message   string
+	Signature hexutil.Bytes
+	Scheme    string
+}) (bool, error) {
+	switch args.Scheme {
+	case signatureSchemePersonal, signatureSchemeTypedData:
+		return repository.R().VerifySignature(ctx, &args.Address, args.Message, args.Signature, args.Scheme == signatureSchemeTypedData)
+	}
+	return false, types.Errorf(types.ErrCodeInvalidInput, "unknown signature scheme %s", args.Scheme)
+}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -39,7 +40,7 @@ func NewStaker(st *types.Validator) *Staker {
 }
 
 // Delegations resolves list of delegations associated with the staker.
-func (st Staker) Delegations(args struct {
+func (st Staker) Delegations(ctx context.Context, args struct {
 	Cursor *Cursor
 	Count  int32
 }) (*DelegationList, error) {
@@ -48,7 +49,7 @@ func (st Staker) Delegations(args struct {
 	args.Count = listLimitCount(args.Count, accMaxTransactionsPerRequest)
 
 	// get delegations
-	dl, err := repository.R().DelegationsOfValidator(&st.Id, (*string)(args.Cursor), args.Count)
+	dl, err := repository.R().DelegationsOfValidator(ctx, &st.Id, (*string)(args.Cursor), args.Count)
 	if err != nil {
 		return nil, err
 	}
@@ -57,15 +58,15 @@ func (st Staker) Delegations(args struct {
 }
 
 // StakerInfo resolves extended staker information if available.
-func (st Staker) StakerInfo() *types.StakerInfo {
-	return repository.R().RetrieveStakerInfo(&st.Id)
+func (st Staker) StakerInfo(ctx context.Context) *types.StakerInfo {
+	return repository.R().RetrieveStakerInfo(ctx, &st.Id)
 }
 
 // DelegationLock returns information about validator lock.
-func (st Staker) DelegationLock() (*types.DelegationLock, error) {
+func (st Staker) DelegationLock(ctx context.Context) (*types.DelegationLock, error) {
 	// load the delegations lock only once
 	dl, err, _ := st.cg.Do(stakerCallGroupLock, func() (interface{}, error) {
-		return repository.R().DelegationLock(&st.StakerAddress, &st.Id)
+		return repository.R().DelegationLock(ctx, &st.StakerAddress, &st.Id)
 	})
 	if err != nil {
 		return nil, err
@@ -74,8 +75,8 @@ func (st Staker) DelegationLock() (*types.DelegationLock, error) {
 }
 
 // IsStakeLocked signals if the stake is locked right now.
-func (st Staker) IsStakeLocked() (bool, error) {
-	lock, err := st.DelegationLock()
+func (st Staker) IsStakeLocked(ctx context.Context) (bool, error) {
+	lock, err := st.DelegationLock(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -83,9 +84,9 @@ func (st Staker) IsStakeLocked() (bool, error) {
 }
 
 // LockedUntil resolves the end time of delegation.
-func (st Staker) LockedUntil() (hexutil.Uint64, error) {
+func (st Staker) LockedUntil(ctx context.Context) (hexutil.Uint64, error) {
 	// get the lock detail
-	lock, err := st.DelegationLock()
+	lock, err := st.DelegationLock(ctx)
 	if err != nil {
 		return hexutil.Uint64(0), err
 	}
@@ -97,8 +98,8 @@ func (st Staker) LockedUntil() (hexutil.Uint64, error) {
 }
 
 // LockedFromEpoch resolves the epoch om which the lock has been created.
-func (st Staker) LockedFromEpoch() (hexutil.Uint64, error) {
-	lock, err := st.DelegationLock()
+func (st Staker) LockedFromEpoch(ctx context.Context) (hexutil.Uint64, error) {
+	lock, err := st.DelegationLock(ctx)
 	if err != nil {
 		return hexutil.Uint64(0), err
 	}
@@ -111,9 +112,9 @@ func (st Staker) LockedFromEpoch() (hexutil.Uint64, error) {
 
 // WithdrawRequests resolves partial withdraw requests of the staker.
 // We load withdraw requests of the stake only, not the stake delegators.
-func (st Staker) WithdrawRequests() ([]WithdrawRequest, error) {
+func (st Staker) WithdrawRequests(ctx context.Context) ([]WithdrawRequest, error) {
 	// pull the requests list from remote server
-	wwl, err := repository.R().WithdrawRequests(&st.StakerAddress, nil, nil, 50)
+	wwl, err := repository.R().WithdrawRequests(ctx, &st.StakerAddress, nil, nil, 50)
 	if err != nil {
 		return nil, err
 	}
@@ -129,10 +130,10 @@ func (st Staker) WithdrawRequests() ([]WithdrawRequest, error) {
 }
 
 // Stake resolves the amount of self staked tokens.
-func (st Staker) Stake() (hexutil.Big, error) {
+func (st Staker) Stake(ctx context.Context) (hexutil.Big, error) {
 	// load the delegations lock only once
 	dl, err, _ := st.cg.Do(stakerCallGroupStake, func() (interface{}, error) {
-		return repository.R().DelegationAmountStaked(&st.StakerAddress, &st.Id)
+		return repository.R().DelegationAmountStaked(ctx, &st.StakerAddress, &st.Id)
 	})
 	if err != nil {
 		return hexutil.Big{}, err
@@ -142,9 +143,9 @@ func (st Staker) Stake() (hexutil.Big, error) {
 
 // DelegatedMe resolves the amount of tokens delegated to the validator
 // without the self staked amount.
-func (st Staker) DelegatedMe() (hexutil.Big, error) {
+func (st Staker) DelegatedMe(ctx context.Context) (hexutil.Big, error) {
 	// get the amount of self staked tokens
-	sf, err := st.Stake()
+	sf, err := st.Stake(ctx)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -164,17 +165,17 @@ func (st Staker) DelegatedMe() (hexutil.Big, error) {
 
 // TotalDelegatedLimit resolves the total max amount of tokens delegated
 // to the validator including the self stake.
-func (st Staker) TotalDelegatedLimit() (hexutil.Big, error) {
+func (st Staker) TotalDelegatedLimit(ctx context.Context) (hexutil.Big, error) {
 	// calculate the delegation limit
 	lim, err, _ := st.cg.Do(stakerCallGroupMaxDelegation, func() (interface{}, error) {
 		// pull the amount of self staked tokens
-		self, err := st.Stake()
+		self, err := st.Stake(ctx)
 		if err != nil {
 			return hexutil.Big{}, err
 		}
 
 		// pull the staking ratio
-		ratio, err := repository.R().SfcMaxDelegatedRatio()
+		ratio, err := repository.R().SfcMaxDelegatedRatio(ctx)
 		if err != nil {
 			return hexutil.Big{}, err
 		}
@@ -191,9 +192,9 @@ func (st Staker) TotalDelegatedLimit() (hexutil.Big, error) {
 
 // DelegatedLimit resolves the amount of tokens available to be delegated
 // to the validator before their max delegation limit is reached
-func (st Staker) DelegatedLimit() (hexutil.Big, error) {
+func (st Staker) DelegatedLimit(ctx context.Context) (hexutil.Big, error) {
 	// get the total limit
-	lim, err := st.TotalDelegatedLimit()
+	lim, err := st.TotalDelegatedLimit(ctx)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -226,8 +227,8 @@ func (st Staker) IsOffline() bool {
 }
 
 // Downtime resolves the amount of time a validator is offline.
-func (st Staker) Downtime() (hexutil.Uint64, error) {
-	tm, _, err := st.downtime()
+func (st Staker) Downtime(ctx context.Context) (hexutil.Uint64, error) {
+	tm, _, err := st.downtime(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -235,8 +236,8 @@ func (st Staker) Downtime() (hexutil.Uint64, error) {
 }
 
 // MissedBlocks resolves the amount of blocks a validator missed recently.
-func (st Staker) MissedBlocks() (hexutil.Uint64, error) {
-	_, blk, err := st.downtime()
+func (st Staker) MissedBlocks(ctx context.Context) (hexutil.Uint64, error) {
+	_, blk, err := st.downtime(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -244,7 +245,7 @@ func (st Staker) MissedBlocks() (hexutil.Uint64, error) {
 }
 
 // downtime pulls information about the validator down time and missed blocks from aBFT API.
-func (st Staker) downtime() (uint64, uint64, error) {
+func (st Staker) downtime(ctx context.Context) (uint64, uint64, error) {
 	// how the call group responds
 	type dt struct {
 		Time   uint64
@@ -253,7 +254,7 @@ func (st Staker) downtime() (uint64, uint64, error) {
 
 	// pull the values
 	val, err, _ := st.cg.Do(stakerCallGroupDowntime, func() (interface{}, error) {
-		dtm, blocks, err := repository.R().ValidatorDowntime(&st.Id)
+		dtm, blocks, err := repository.R().ValidatorDowntime(ctx, &st.Id)
 		if err != nil {
 			return dt{}, err
 		}
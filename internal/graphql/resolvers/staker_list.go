@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -9,13 +10,13 @@ import (
 )
 
 // Stakers resolves a list of staker information from SFC smart contract.
-func (rs *rootResolver) Stakers() ([]*Staker, error) {
-	return loadStakersFiltered(func(v *types.Validator) bool { return v != nil })
+func (rs *rootResolver) Stakers(ctx context.Context) ([]*Staker, error) {
+	return rs.stakersFiltered(ctx, func(v *types.Validator) bool { return v != nil })
 }
 
 // StakersWithFlag resolves a list of stakers for the given type of flag.
-func (rs *rootResolver) StakersWithFlag(args struct{ Flag string }) ([]*Staker, error) {
-	return loadStakersFiltered(func(v *types.Validator) bool {
+func (rs *rootResolver) StakersWithFlag(ctx context.Context, args struct{ Flag string }) ([]*Staker, error) {
+	return rs.stakersFiltered(ctx, func(v *types.Validator) bool {
 		if v == nil {
 			return false
 		}
@@ -36,21 +37,47 @@ func (rs *rootResolver) StakersWithFlag(args struct{ Flag string }) ([]*Staker,
 	})
 }
 
-// loadStakersFiltered loads list of validators check each one if it can be added to the output list
-// using a provided callback check.
-func loadStakersFiltered(check func(*types.Validator) bool) ([]*Staker, error) {
+// stakersFiltered loads list of validators check each one if it can be added to the output list
+// using a provided callback check. The list of validators is taken from the response cache, if available.
+func (rs *rootResolver) stakersFiltered(ctx context.Context, check func(*types.Validator) bool) ([]*Staker, error) {
+	var vl []*types.Validator
+	if err := rs.cachedResponse("stakers", nil, &vl, func() (err error) {
+		vl, err = loadValidators(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	// make the list
+	list := make([]*Staker, 0)
+	for _, st := range vl {
+		if check(st) {
+			list = append(list, NewStaker(st))
+		}
+	}
+
+	// inform
+	log.Debugf("found %d stakers", len(list))
+
+	// sort the list by total amount delegated and return the result
+	sort.Sort(StakesByTotalStaked(list))
+	return list, nil
+}
+
+// loadValidators loads the list of all the valid validators.
+func loadValidators(ctx context.Context) ([]*types.Validator, error) {
 	// get the number
-	num, err := repository.R().LastValidatorId()
+	num, err := repository.R().LastValidatorId(ctx)
 	if err != nil {
 		log.Errorf("can not get the highest staker id; %s", err.Error())
 		return nil, err
 	}
 
 	// make the list
-	list := make([]*Staker, 0)
+	list := make([]*types.Validator, 0)
 	for i := uint64(1); i <= num; i++ {
 		// extract the staker info
-		st, err := repository.R().Validator((*hexutil.Big)(new(big.Int).SetUint64(i)))
+		st, err := repository.R().Validator(ctx, (*hexutil.Big)(new(big.Int).SetUint64(i)))
 		if err != nil {
 			log.Criticalf("can not extract staker #%d information; %s", i, err.Error())
 			continue
@@ -61,18 +88,8 @@ func loadStakersFiltered(check func(*types.Validator) bool) ([]*Staker, error) {
 			log.Debugf("staker #%d has invalid ID", i)
 			continue
 		}
-
-		// check if it can be added
-		if check(st) {
-			list = append(list, NewStaker(st))
-		}
+		list = append(list, st)
 	}
-
-	// inform
-	log.Debugf("found %d stakers", len(list))
-
-	// sort the list by total amount delegated and return the result
-	sort.Sort(StakesByTotalStaked(list))
 	return list, nil
 }
 
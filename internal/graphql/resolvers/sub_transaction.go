@@ -2,8 +2,10 @@
 package resolvers
 
 import (
+	"bytes"
 	"context"
 	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
 	"time"
 )
 
@@ -12,24 +14,46 @@ const onTrxChannelCapacity = 500
 
 // subscriptOnTrx represents reference to a subscriber to onTransaction events broadcast.
 type subscriptOnTrx struct {
-	stop   <-chan struct{}
-	events chan<- *Transaction
+	stop      <-chan struct{}
+	events    chan<- *Transaction
+	sender    *common.Address
+	recipient *common.Address
 }
 
-// OnBlock resolves subscription to new blocks event broadcast.
-func (rs *rootResolver) OnTransaction(ctx context.Context) <-chan *Transaction {
+// OnTransaction resolves subscription to new transactions event broadcast.
+// The stream can be optionally limited to transactions of the given sender and/or recipient.
+func (rs *rootResolver) OnTransaction(ctx context.Context, args struct {
+	Sender    *common.Address
+	Recipient *common.Address
+}) <-chan *Transaction {
 	// make the stream
 	c := make(chan *Transaction, onTrxChannelCapacity)
 
 	// subscribe to event dispatch
 	rs.subscribeOnTrx <- &subscriptOnTrx{
-		stop:   ctx.Done(),
-		events: c,
+		stop:      ctx.Done(),
+		events:    c,
+		sender:    args.Sender,
+		recipient: args.Recipient,
 	}
 
 	return c
 }
 
+// accepts checks if the given transaction matches the subscriber filter, if any.
+func (sub *subscriptOnTrx) accepts(trx *types.Transaction) bool {
+	// sender filter does not match
+	if sub.sender != nil && !bytes.Equal(sub.sender.Bytes(), trx.From.Bytes()) {
+		return false
+	}
+
+	// recipient filter does not match; contract creation has no recipient
+	if sub.recipient != nil && (trx.To == nil || !bytes.Equal(sub.recipient.Bytes(), trx.To.Bytes())) {
+		return false
+	}
+	return true
+}
+
 // addTrxSubscriber adds a new subscription to onTransaction events.
 func (rs *rootResolver) addTrxSubscriber(sub *subscriptOnTrx) {
 	id, err := uuid()
@@ -45,12 +69,14 @@ func (rs *rootResolver) addTrxSubscriber(sub *subscriptOnTrx) {
 
 // dispatchOnTransaction dispatches onTransaction event to registered subscribers.
 func (rs *rootResolver) dispatchOnTransaction(trx *types.Transaction) {
-	// prep the block
+	// prep the transaction
 	transaction := NewTransaction(trx)
 
 	// broadcast the event in separate go routines so we don't block here
 	for id, sub := range rs.trxSubscribers {
-		go rs.notifyOnTransaction(transaction, sub, id)
+		if sub.accepts(trx) {
+			go rs.notifyOnTransaction(transaction, sub, id)
+		}
 	}
 }
 
@@ -0,0 +1,179 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+const (
+	// trxStatusPending represents the status of a transaction waiting in the transaction pool.
+	trxStatusPending = "PENDING"
+
+	// trxStatusMined represents the status of a transaction included in a block.
+	trxStatusMined = "MINED"
+
+	// trxStatusDropped represents the status of a transaction removed from the pool without being mined.
+	trxStatusDropped = "DROPPED"
+
+	// trxStatusCheckInterval represents the interval of the transaction status verification.
+	trxStatusCheckInterval = 5 * time.Second
+
+	// trxStatusDropGrace represents the time a transaction never seen by the node
+	// is waited for before being considered dropped.
+	trxStatusDropGrace = time.Minute
+)
+
+// TransactionStatus represents a status change of a transaction.
+type TransactionStatus struct {
+	Hash   common.Hash
+	Status string
+	trx    *types.Transaction
+}
+
+// subscriptOnTrxStatus represents reference to a subscriber to onTransactionStatus events.
+type subscriptOnTrxStatus struct {
+	id     string
+	hash   common.Hash
+	ctx    context.Context
+	stop   <-chan struct{}
+	events chan<- *TransactionStatus
+	mined  chan *types.Transaction
+	done   chan struct{}
+}
+
+// Transaction resolves the transaction details, if available.
+func (ts *TransactionStatus) Transaction() *Transaction {
+	if ts.trx == nil {
+		return nil
+	}
+	return NewTransaction(ts.trx)
+}
+
+// OnTransactionStatus resolves subscription to status changes of the given transaction.
+// The stream is closed when the transaction is mined, or dropped.
+func (rs *rootResolver) OnTransactionStatus(ctx context.Context, args struct{ Hash common.Hash }) <-chan *TransactionStatus {
+	// make the stream
+	c := make(chan *TransactionStatus, 1)
+
+	id, err := uuid()
+	if err != nil {
+		log.Criticalf("can not generate UUID for new onTransactionStatus subscriber; %s", err.Error())
+		close(c)
+		return c
+	}
+
+	// subscribe to event dispatch and watch the transaction
+	sub := &subscriptOnTrxStatus{
+		id:     id,
+		hash:   args.Hash,
+		ctx:    ctx,
+		stop:   ctx.Done(),
+		events: c,
+		mined:  make(chan *types.Transaction, 1),
+		done:   make(chan struct{}),
+	}
+	rs.subscribeOnTrxStatus <- sub
+
+	go rs.watchTrxStatus(sub, c)
+	return c
+}
+
+// watchTrxStatus watches the status of the subscribed transaction until it's mined, or dropped.
+func (rs *rootResolver) watchTrxStatus(sub *subscriptOnTrxStatus, c chan *TransactionStatus) {
+	defer func() {
+		close(sub.done)
+		rs.unsubscribeOnTrxStatus <- sub.id
+		close(c)
+	}()
+
+	// check the current status first
+	since := time.Now()
+	seen := false
+	if sub.checkStatus(since, &seen) {
+		return
+	}
+
+	ticker := time.NewTicker(trxStatusCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-sub.stop:
+			return
+		case trx := <-sub.mined:
+			sub.notify(&TransactionStatus{Hash: sub.hash, Status: trxStatusMined, trx: trx})
+			return
+		case <-ticker.C:
+			if sub.checkStatus(since, &seen) {
+				return
+			}
+		}
+	}
+}
+
+// checkStatus verifies the status of the transaction on the node
+// and notifies the subscriber about changes. It returns TRUE if the final status has been reached.
+func (sub *subscriptOnTrxStatus) checkStatus(since time.Time, seen *bool) bool {
+	trx, err := repository.R().Transaction(sub.ctx, &sub.hash)
+	if err != nil {
+		// transaction not known; was it dropped?
+		if err == repository.ErrTransactionNotFound {
+			if *seen || time.Since(since) > trxStatusDropGrace {
+				sub.notify(&TransactionStatus{Hash: sub.hash, Status: trxStatusDropped})
+				return true
+			}
+			return false
+		}
+
+		log.Errorf("can not check status of transaction %s; %s", sub.hash.String(), err.Error())
+		return false
+	}
+
+	// still pending?
+	if trx.BlockHash == nil {
+		if !*seen {
+			*seen = true
+			sub.notify(&TransactionStatus{Hash: sub.hash, Status: trxStatusPending, trx: trx})
+		}
+		return false
+	}
+
+	sub.notify(&TransactionStatus{Hash: sub.hash, Status: trxStatusMined, trx: trx})
+	return true
+}
+
+// notify sends the transaction status to the subscriber.
+func (sub *subscriptOnTrxStatus) notify(ts *TransactionStatus) {
+	select {
+	case <-sub.stop:
+	case sub.events <- ts:
+	case <-time.After(time.Second):
+	}
+}
+
+// addTrxStatusSubscriber adds a new subscription to onTransactionStatus events.
+// The watcher may have finished already, the subscriber is not added in that case.
+func (rs *rootResolver) addTrxStatusSubscriber(sub *subscriptOnTrxStatus) {
+	select {
+	case <-sub.done:
+		return
+	default:
+	}
+	rs.trxStatusSubscribers[sub.id] = sub
+}
+
+// dispatchOnTransactionStatus notifies subscribers of the mined transaction status.
+func (rs *rootResolver) dispatchOnTransactionStatus(trx *types.Transaction) {
+	for _, sub := range rs.trxStatusSubscribers {
+		if sub.hash == trx.Hash {
+			select {
+			case sub.mined <- trx:
+			default:
+			}
+		}
+	}
+}
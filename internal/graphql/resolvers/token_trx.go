@@ -1,6 +1,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -33,12 +34,12 @@ func (ttx *TokenTransaction) Type() string {
 }
 
 // TokenName resolves the name of the ERC token contract, if available.
-func (ttx *TokenTransaction) TokenName() (name string, err error) {
+func (ttx *TokenTransaction) TokenName(ctx context.Context) (name string, err error) {
 	switch ttx.TokenTransaction.TokenType {
 	case types.AccountTypeERC20Token:
-		name, err = repository.R().Erc20Name(&ttx.TokenTransaction.TokenAddress)
+		name, err = repository.R().Erc20Name(ctx, &ttx.TokenTransaction.TokenAddress)
 	case types.AccountTypeERC721Contract:
-		name, err = repository.R().Erc721Name(&ttx.TokenTransaction.TokenAddress)
+		name, err = repository.R().Erc721Name(ctx, &ttx.TokenTransaction.TokenAddress)
 	default:
 		name, err = "", nil
 	}
@@ -46,12 +47,12 @@ func (ttx *TokenTransaction) TokenName() (name string, err error) {
 }
 
 // TokenSymbol resolves the symbol of the ERC token contract, if available.
-func (ttx *TokenTransaction) TokenSymbol() (sym string, err error) {
+func (ttx *TokenTransaction) TokenSymbol(ctx context.Context) (sym string, err error) {
 	switch ttx.TokenTransaction.TokenType {
 	case types.AccountTypeERC20Token:
-		sym, err = repository.R().Erc20Symbol(&ttx.TokenTransaction.TokenAddress)
+		sym, err = repository.R().Erc20Symbol(ctx, &ttx.TokenTransaction.TokenAddress)
 	case types.AccountTypeERC721Contract:
-		sym, err = repository.R().Erc721Symbol(&ttx.TokenTransaction.TokenAddress)
+		sym, err = repository.R().Erc721Symbol(ctx, &ttx.TokenTransaction.TokenAddress)
 	default:
 		sym, err = "", nil
 	}
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -24,9 +25,9 @@ func NewTransaction(trx *types.Transaction) *Transaction {
 }
 
 // Transaction resolves blockchain transaction by transaction hash.
-func (rs *rootResolver) Transaction(args *struct{ Hash common.Hash }) (*Transaction, error) {
+func (rs *rootResolver) Transaction(ctx context.Context, args *struct{ Hash common.Hash }) (*Transaction, error) {
 	// get the transaction from repository
-	trx, err := repository.R().Transaction(&args.Hash)
+	trx, err := repository.R().Transaction(ctx, &args.Hash)
 	if err != nil {
 		log.Warningf("can not get transaction %s", args.Hash)
 		return nil, err
@@ -36,9 +37,13 @@ func (rs *rootResolver) Transaction(args *struct{ Hash common.Hash }) (*Transact
 }
 
 // SendTransaction sends raw signed and RLP encoded transaction to the blockchain.
-func (rs *rootResolver) SendTransaction(args *struct{ Tx hexutil.Bytes }) (*Transaction, error) {
+func (rs *rootResolver) SendTransaction(ctx context.Context, args *struct{ Tx hexutil.Bytes }) (*Transaction, error) {
+	if err := requireRole(ctx, cfg.Server.Auth.WriteRole); err != nil {
+		return nil, err
+	}
+
 	// get the transaction from repository
-	trx, err := repository.R().SendTransaction(args.Tx)
+	trx, err := repository.R().SendTransaction(ctx, args.Tx)
 	if err != nil {
 		log.Warningf("can not send transaction; %s", err.Error())
 		return nil, err
@@ -48,9 +53,9 @@ func (rs *rootResolver) SendTransaction(args *struct{ Tx hexutil.Bytes }) (*Tran
 }
 
 // Sender resolves sender's account of the transaction.
-func (trx *Transaction) Sender() (*Account, error) {
+func (trx *Transaction) Sender(ctx context.Context) (*Account, error) {
 	// get the sender by address
-	acc, err := repository.R().Account(&trx.From)
+	acc, err := loadAccount(ctx, &trx.From)
 	if err != nil {
 		return nil, err
 	}
@@ -59,14 +64,14 @@ func (trx *Transaction) Sender() (*Account, error) {
 }
 
 // Recipient resolves recipient's account of the transaction.
-func (trx *Transaction) Recipient() (*Account, error) {
+func (trx *Transaction) Recipient(ctx context.Context) (*Account, error) {
 	// no recipient available
 	if trx.To == nil {
 		return nil, nil
 	}
 
 	// get the recipient by address
-	acc, err := repository.R().Account(trx.To)
+	acc, err := loadAccount(ctx, trx.To)
 	if err != nil {
 		return nil, err
 	}
@@ -75,14 +80,14 @@ func (trx *Transaction) Recipient() (*Account, error) {
 }
 
 // Block resolves block the transaction is bundled in, nil if it's pending and not added to a block yet.
-func (trx *Transaction) Block() (*Block, error) {
+func (trx *Transaction) Block(ctx context.Context) (*Block, error) {
 	// no recipient available
 	if trx.BlockNumber == nil {
 		return nil, nil
 	}
 
 	// get the sender by address
-	blk, err := repository.R().BlockByNumber(trx.BlockNumber)
+	blk, err := loadBlock(ctx, trx.BlockNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -91,11 +96,11 @@ func (trx *Transaction) Block() (*Block, error) {
 }
 
 // tokenTransactions loads list of all token transaction related to this transaction call.
-func (trx *Transaction) tokenTransactions() ([]*types.TokenTransaction, error) {
+func (trx *Transaction) tokenTransactions(ctx context.Context) ([]*types.TokenTransaction, error) {
 	// call for it only once
 	val, err, _ := trx.cg.Do("erc", func() (interface{}, error) {
 		log.Noticef("Loading ERC list for %s", trx.Hash.String())
-		return repository.R().TokenTransactionsByCall(&trx.Hash)
+		return repository.R().TokenTransactionsByCall(ctx, &trx.Hash)
 	})
 	if err != nil {
 		return nil, err
@@ -105,9 +110,9 @@ func (trx *Transaction) tokenTransactions() ([]*types.TokenTransaction, error) {
 
 // TokenTransactions resolves list of all generic token transactions involved
 // with the base transaction call.
-func (trx *Transaction) TokenTransactions() ([]*TokenTransaction, error) {
+func (trx *Transaction) TokenTransactions(ctx context.Context) ([]*TokenTransaction, error) {
 	// get all the transaction
-	tl, err := trx.tokenTransactions()
+	tl, err := trx.tokenTransactions(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -122,9 +127,9 @@ func (trx *Transaction) TokenTransactions() ([]*TokenTransaction, error) {
 
 // Erc20Transactions resolves list of ERC-20 transactions executed in the scope
 // of this general transaction function call.
-func (trx *Transaction) Erc20Transactions() ([]*ERC20Transaction, error) {
+func (trx *Transaction) Erc20Transactions(ctx context.Context) ([]*ERC20Transaction, error) {
 	// get all the transaction
-	tl, err := trx.tokenTransactions()
+	tl, err := trx.tokenTransactions(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -140,9 +145,9 @@ func (trx *Transaction) Erc20Transactions() ([]*ERC20Transaction, error) {
 
 // Erc721Transactions resolves list of ERC-721 transactions executed in the scope
 // of this general transaction function call.
-func (trx *Transaction) Erc721Transactions() ([]*ERC721Transaction, error) {
+func (trx *Transaction) Erc721Transactions(ctx context.Context) ([]*ERC721Transaction, error) {
 	// get all the transaction
-	tl, err := trx.tokenTransactions()
+	tl, err := trx.tokenTransactions(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -158,9 +163,9 @@ func (trx *Transaction) Erc721Transactions() ([]*ERC721Transaction, error) {
 
 // Erc1155Transactions resolves list of ERC-155 transactions executed in the scope
 // of this general transaction function call.
-func (trx *Transaction) Erc1155Transactions() ([]*ERC1155Transaction, error) {
+func (trx *Transaction) Erc1155Transactions(ctx context.Context) ([]*ERC1155Transaction, error) {
 	// get all the transaction
-	tl, err := trx.tokenTransactions()
+	tl, err := trx.tokenTransactions(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -173,3 +178,29 @@ func (trx *Transaction) Erc1155Transactions() ([]*ERC1155Transaction, error) {
 	}
 	return list, nil
 }
+
+// InputDataDecoded resolves the input data of the transaction decoded
+// using the ABI of the recipient contract, if available.
+func (trx *Transaction) InputDataDecoded(ctx context.Context) (*types.DecodedCall, error) {
+	dc, err := repository.R().TransactionInputDecoded(ctx, &trx.Transaction)
+	if err != nil {
+		log.Debugf("can not decode input of %s; %s", trx.Hash.String(), err.Error())
+		return nil, nil
+	}
+	return dc, nil
+}
+
+// ErrorMessage resolves the reason of failure of a failed transaction.
+// Nil is provided for pending and successful transactions.
+func (trx *Transaction) ErrorMessage(ctx context.Context) *string {
+	if trx.Status == nil || *trx.Status != types.TransactionStatusFailed {
+		return nil
+	}
+
+	reason, err := repository.R().TransactionRevertReason(ctx, &trx.Transaction)
+	if err != nil {
+		log.Debugf("can not get revert reason of %s; %s", trx.Hash.String(), err.Error())
+		return nil
+	}
+	return &reason
+}
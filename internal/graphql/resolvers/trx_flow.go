@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"fmt"
@@ -19,7 +20,7 @@ type DailyTrxVolume struct {
 }
 
 // TrxVolume resolves list of daily aggregations of the network transaction flow.
-func (rs *rootResolver) TrxVolume(args struct {
+func (rs *rootResolver) TrxVolume(ctx context.Context, args struct {
 	From *string
 	To   *string
 }) ([]*DailyTrxVolume, error) {
@@ -29,8 +30,12 @@ func (rs *rootResolver) TrxVolume(args struct {
 		return nil, err
 	}
 
-	// load data
-	dv, err := repository.R().TrxFlowVolume(from, to)
+	// load data; the aggregation is expensive, use the response cache
+	var dv []*types.DailyTrxVolume
+	err = rs.cachedResponse("trxVolume", args, &dv, func() (err error) {
+		dv, err = repository.R().TrxFlowVolume(ctx, from, to)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -43,9 +48,17 @@ func (rs *rootResolver) TrxVolume(args struct {
 	return list, nil
 }
 
+// TrxDailyStats resolves list of daily aggregated statistics of the network transaction flow.
+func (rs *rootResolver) TrxDailyStats(ctx context.Context, args struct {
+	From *string
+	To   *string
+}) ([]*DailyTrxVolume, error) {
+	return rs.TrxVolume(ctx, args)
+}
+
 // TrxGasSpeed resolves the gas consumption speed speed
 // of the network in transactions processed per second.
-func (rs *rootResolver) TrxGasSpeed(args struct {
+func (rs *rootResolver) TrxGasSpeed(ctx context.Context, args struct {
 	Range int32
 	To    *string
 }) (val float64, err error) {
@@ -68,18 +81,24 @@ func (rs *rootResolver) TrxGasSpeed(args struct {
 
 	// log what we do
 	log.Noticef("calculating gas speed from %s to %s", from.String(), to.String())
-	return repository.R().TrxGasSpeed(&from, &to)
+	return repository.R().TrxGasSpeed(ctx, &from, &to)
 }
 
 // TrxSpeed resolves the recent speed of the network in transactions processed per second.
-func (rs *rootResolver) TrxSpeed(args struct {
+func (rs *rootResolver) TrxSpeed(ctx context.Context, args struct {
 	Range int32
 }) (float64, error) {
 	// make sure to obey the minimal range
 	if args.Range < 60 {
 		args.Range = 60
 	}
-	return repository.R().TrxFlowSpeed(args.Range)
+	return repository.R().TrxFlowSpeed(ctx, args.Range)
+}
+
+// NetworkLoad resolves the recent load of the network
+// calculated over a sliding window of the most recent blocks.
+func (rs *rootResolver) NetworkLoad(ctx context.Context) (*types.NetworkLoad, error) {
+	return repository.R().NetworkLoad(ctx)
 }
 
 // trxVolumeRange generates the time range for trx volume resolver.
@@ -132,3 +151,15 @@ func (dtv *DailyTrxVolume) Gas() hexutil.Big {
 	val := new(big.Int).SetInt64(dtv.DailyTrxVolume.Gas)
 	return hexutil.Big(*val)
 }
+
+// Fee resolves the amount of fees paid by transactions on the network in WEI.
+func (dtv *DailyTrxVolume) Fee() hexutil.Big {
+	// the fee is aggregated from the gas price reduced by the gas correction
+	val := new(big.Int).Mul(new(big.Int).SetInt64(dtv.DailyTrxVolume.Fee), types.TransactionGasCorrection)
+	return hexutil.Big(*val)
+}
+
+// ActiveAccounts resolves the number of unique accounts active on the network.
+func (dtv *DailyTrxVolume) ActiveAccounts() int32 {
+	return int32(dtv.DailyTrxVolume.ActiveAccounts)
+}
@@ -2,8 +2,10 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
 )
@@ -26,17 +28,43 @@ func NewTransactionList(txs *types.TransactionList) *TransactionList {
 	}
 }
 
+// TransactionFilterInput represents an input structure of transaction list conditions.
+type TransactionFilterInput struct {
+	// Direction represents the direction of transactions relative to the listed account.
+	Direction *string
+
+	// MinValue represents the lowest value of listed transactions.
+	MinValue *hexutil.Big
+
+	// MaxValue represents the highest value of listed transactions.
+	MaxValue *hexutil.Big
+
+	// Status represents the receipt status of listed transactions.
+	Status *string
+
+	// Counterpart represents the address on the other side of listed transactions.
+	Counterpart *common.Address
+}
+
+// TransactionCount resolves the exact number of indexed transactions.
+// The value is maintained as a counter by the scanner, so no count query is needed.
+func (rs *rootResolver) TransactionCount(ctx context.Context) (hexutil.Uint64, error) {
+	val, err := repository.R().TransactionsTotal(ctx)
+	return hexutil.Uint64(val), err
+}
+
 // Transactions resolves list of blockchain transactions encapsulated in a listable structure.
-func (rs *rootResolver) Transactions(args *struct {
+func (rs *rootResolver) Transactions(ctx context.Context, args *struct {
 	Cursor *Cursor
 	Count  int32
+	Filter *TransactionFilterInput
 }) (*TransactionList, error) {
 	// limit query size; the count can be either positive or negative
 	// this controls the loading direction
 	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
 
 	// get the transaction hash list from repository
-	txs, err := repository.R().Transactions((*string)(args.Cursor), args.Count)
+	txs, err := repository.R().Transactions(ctx, (*string)(args.Cursor), args.Count, args.Filter.filter())
 	if err != nil {
 		log.Errorf("can not get transactions list; %s", err.Error())
 		return nil, err
@@ -44,6 +72,38 @@ func (rs *rootResolver) Transactions(args *struct {
 	return NewTransactionList(txs), nil
 }
 
+// filter converts the input into the repository transaction filter.
+// Nil is returned for an empty input.
+func (in *TransactionFilterInput) filter() *types.TransactionFilter {
+	if in == nil {
+		return nil
+	}
+
+	f := types.TransactionFilter{Counterpart: in.Counterpart}
+	if in.Direction != nil {
+		switch *in.Direction {
+		case "IN":
+			f.Direction = types.TransactionDirectionIn
+		case "OUT":
+			f.Direction = types.TransactionDirectionOut
+		}
+	}
+	if in.MinValue != nil {
+		f.MinValue = in.MinValue.ToInt()
+	}
+	if in.MaxValue != nil {
+		f.MaxValue = in.MaxValue.ToInt()
+	}
+	if in.Status != nil {
+		st := uint64(types.TransactionStatusSuccess)
+		if *in.Status == "FAILED" {
+			st = types.TransactionStatusFailed
+		}
+		f.Status = &st
+	}
+	return &f
+}
+
 // TotalCount resolves the total number of transactions in the list.
 func (tl *TransactionList) TotalCount() hexutil.Big {
 	val := (*hexutil.Big)(big.NewInt(int64(tl.Total)))
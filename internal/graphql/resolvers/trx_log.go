@@ -0,0 +1,60 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// TransactionLog represents a resolvable event log record emitted by a transaction.
+type TransactionLog struct {
+	lg retypes.Log
+}
+
+// Logs resolves the list of event log records emitted by the transaction.
+func (trx *Transaction) Logs(ctx context.Context) ([]*TransactionLog, error) {
+	logs, err := repository.R().TransactionLogs(ctx, &trx.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*TransactionLog, len(logs))
+	for i := range logs {
+		list[i] = &TransactionLog{lg: logs[i]}
+	}
+	return list, nil
+}
+
+// Address resolves the address of the contract emitting the event.
+func (tl *TransactionLog) Address() common.Address {
+	return tl.lg.Address
+}
+
+// Topics resolves the list of topics of the event log record.
+func (tl *TransactionLog) Topics() []common.Hash {
+	return tl.lg.Topics
+}
+
+// Data resolves the not indexed data of the event log record.
+func (tl *TransactionLog) Data() hexutil.Bytes {
+	return tl.lg.Data
+}
+
+// Index resolves the index of the event log record in the block.
+func (tl *TransactionLog) Index() hexutil.Uint64 {
+	return hexutil.Uint64(tl.lg.Index)
+}
+
+// Decoded resolves the event decoded using the ABI of the emitting contract, if available.
+func (tl *TransactionLog) Decoded(ctx context.Context) (*types.DecodedEvent, error) {
+	de, err := repository.R().DecodeLog(ctx, &tl.lg)
+	if err != nil {
+		log.Debugf("can not decode log #%d of %s; %s", tl.lg.Index, tl.lg.TxHash.String(), err.Error())
+		return nil, nil
+	}
+	return de, nil
+}
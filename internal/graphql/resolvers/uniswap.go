@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"fmt"
@@ -46,11 +47,11 @@ func NewUniswapPair(adr *common.Address) *UniswapPair {
 }
 
 // defiUniswapPairs load list of Uniswap pairs once in concurrent threads.
-func (rs *rootResolver) defiUniswapPairs() []*UniswapPair {
+func (rs *rootResolver) defiUniswapPairs(ctx context.Context) []*UniswapPair {
 	// make sure to do this only once
 	list, err, _ := rs.cg.Do("uniswap-pairs", func() (interface{}, error) {
 		// get the list of pair addresses
-		pairs, err := repository.R().UniswapKnownPairs()
+		pairs, err := repository.R().UniswapKnownPairs(ctx)
 		if err != nil || pairs == nil {
 			return make([]*UniswapPair, 0), nil
 		}
@@ -69,31 +70,46 @@ func (rs *rootResolver) defiUniswapPairs() []*UniswapPair {
 }
 
 // DefiUniswapPairs resolves list of
-func (rs *rootResolver) DefiUniswapPairs() []*UniswapPair {
-	return rs.defiUniswapPairs()
+func (rs *rootResolver) DefiUniswapPairs(ctx context.Context) []*UniswapPair {
+	return rs.defiUniswapPairs(ctx)
+}
+
+// DefiUniswapPair resolves the Uniswap pair of the given address, if the pair is managed by the Uniswap Core.
+func (rs *rootResolver) DefiUniswapPair(ctx context.Context, args *struct{ PairAddress common.Address }) (*UniswapPair, error) {
+	pairs, err := repository.R().UniswapPairs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, adr := range pairs {
+		if adr == args.PairAddress {
+			return NewUniswapPair(&adr), nil
+		}
+	}
+	return nil, nil
 }
 
 // DefiUniswapAmountsOut resolves a list of output amounts for the given
 // input amount and a list of tokens to be used to make the swap operation.
-func (rs *rootResolver) DefiUniswapAmountsOut(args *struct {
+func (rs *rootResolver) DefiUniswapAmountsOut(ctx context.Context, args *struct {
 	AmountIn hexutil.Big
 	Tokens   []common.Address
 }) ([]hexutil.Big, error) {
-	return repository.R().UniswapAmountsOut(args.AmountIn, args.Tokens)
+	return repository.R().UniswapAmountsOut(ctx, args.AmountIn, args.Tokens)
 }
 
 // DefiUniswapAmountsIn resolves a list of input amounts for the given
 // output amount and a list of tokens to be used to make the swap operation.
-func (rs *rootResolver) DefiUniswapAmountsIn(args *struct {
+func (rs *rootResolver) DefiUniswapAmountsIn(ctx context.Context, args *struct {
 	AmountOut hexutil.Big
 	Tokens    []common.Address
 }) ([]hexutil.Big, error) {
-	return repository.R().UniswapAmountsIn(args.AmountOut, args.Tokens)
+	return repository.R().UniswapAmountsIn(ctx, args.AmountOut, args.Tokens)
 }
 
 // DefiUniswapQuoteLiquidity resolves a list of optimal amounts of tokens
 // to be added to both sides of a pair on addLiquidity call.
-func (rs *rootResolver) DefiUniswapQuoteLiquidity(args *struct {
+func (rs *rootResolver) DefiUniswapQuoteLiquidity(ctx context.Context, args *struct {
 	Tokens    []common.Address
 	AmountsIn []hexutil.Big
 }) ([]hexutil.Big, error) {
@@ -108,25 +124,25 @@ func (rs *rootResolver) DefiUniswapQuoteLiquidity(args *struct {
 	}
 
 	// get the pair address for the given set of tokens
-	pair, err := repository.R().UniswapPair(&args.Tokens[0], &args.Tokens[1])
+	pair, err := repository.R().UniswapPair(ctx, &args.Tokens[0], &args.Tokens[1])
 	if err != nil {
 		return nil, err
 	}
 
 	// get normalized tokens order
-	tokens, err := repository.R().UniswapTokens(pair)
+	tokens, err := repository.R().UniswapTokens(ctx, pair)
 	if err != nil {
 		return nil, err
 	}
 
 	// make sure to call the amounts correctly
 	if tokens[0] == args.Tokens[0] {
-		return rs.uniswapOptimalLiquidity(pair, &args.AmountsIn[0], &args.AmountsIn[1])
+		return rs.uniswapOptimalLiquidity(ctx, pair, &args.AmountsIn[0], &args.AmountsIn[1])
 	}
 
 	// tokens came in in reversed order
 	if tokens[0] == args.Tokens[1] {
-		val, err := rs.uniswapOptimalLiquidity(pair, &args.AmountsIn[1], &args.AmountsIn[0])
+		val, err := rs.uniswapOptimalLiquidity(ctx, pair, &args.AmountsIn[1], &args.AmountsIn[0])
 		if err != nil {
 			return nil, err
 		}
@@ -140,13 +156,13 @@ func (rs *rootResolver) DefiUniswapQuoteLiquidity(args *struct {
 }
 
 // uniswapQuoteLiquidity calculates the optimal liquidity advance on addLiquidity call.
-func (rs *rootResolver) uniswapOptimalLiquidity(
+func (rs *rootResolver) uniswapOptimalLiquidity(ctx context.Context,
 	pair *common.Address,
 	amountAIn *hexutil.Big,
 	amountBIn *hexutil.Big,
 ) ([]hexutil.Big, error) {
 	// get amount of reserves
-	reserves, err := repository.R().UniswapReserves(pair)
+	reserves, err := repository.R().UniswapReserves(ctx, pair)
 	if err != nil {
 		return nil, err
 	}
@@ -157,7 +173,7 @@ func (rs *rootResolver) uniswapOptimalLiquidity(
 	}
 
 	// get side B optimal
-	optimalB, err := repository.R().UniswapQuoteInput(*amountAIn, reserves[0], reserves[1])
+	optimalB, err := repository.R().UniswapQuoteInput(ctx, *amountAIn, reserves[0], reserves[1])
 	if err != nil {
 		return nil, err
 	}
@@ -168,7 +184,7 @@ func (rs *rootResolver) uniswapOptimalLiquidity(
 	}
 
 	// optimal B si higher than the input offered; calculate optimal A from the reversed reserves
-	optimalA, err := repository.R().UniswapQuoteInput(*amountBIn, reserves[1], reserves[0])
+	optimalA, err := repository.R().UniswapQuoteInput(ctx, *amountBIn, reserves[1], reserves[0])
 	if err != nil {
 		return nil, err
 	}
@@ -182,9 +198,9 @@ func (rs *rootResolver) uniswapOptimalLiquidity(
 }
 
 // Tokens resolves a list of tokens of the given Uniswap pair.
-func (up *UniswapPair) Tokens() ([]*ERC20Token, error) {
+func (up *UniswapPair) Tokens(ctx context.Context) ([]*ERC20Token, error) {
 	// load addresses
-	tokens, err := repository.R().UniswapTokens(&up.PairAddress)
+	tokens, err := repository.R().UniswapTokens(ctx, &up.PairAddress)
 	if err != nil {
 		return nil, err
 	}
@@ -198,29 +214,29 @@ func (up *UniswapPair) Tokens() ([]*ERC20Token, error) {
 	// make the list container
 	list := make([]*ERC20Token, len(tokens))
 	for i, adr := range tokens {
-		erc := NewErc20Token(&adr)
+		erc := NewErc20Token(ctx, &adr)
 		list[i] = erc
 	}
 	return list, nil
 }
 
 // DefiUniswapVolumes returns all swap pairs and their information for swap volumes
-func (rs *rootResolver) DefiUniswapVolumes() []*UniswapPairVolume {
+func (rs *rootResolver) DefiUniswapVolumes(ctx context.Context) []*UniswapPairVolume {
 	// get all the pairs
-	pairs := rs.defiUniswapPairs()
+	pairs := rs.defiUniswapPairs(ctx)
 
 	// create empty list as a result object
 	list := make([]*UniswapPairVolume, len(pairs))
 	for i, pair := range pairs {
 		// get thr pair tokens
-		tl, err := repository.R().UniswapTokens(&pair.PairAddress)
+		tl, err := repository.R().UniswapTokens(ctx, &pair.PairAddress)
 		if err != nil {
 			return list
 		}
 
 		// get token price for denomination
 		isDenominated := true
-		tokenAPrice, err := repository.R().DefiTokenPrice(&tl[0])
+		tokenAPrice, err := repository.R().DefiTokenPrice(ctx, &tl[0])
 		if err != nil {
 			tokenAPrice = hexutil.Big{}
 			isDenominated = false
@@ -237,9 +253,9 @@ func (rs *rootResolver) DefiUniswapVolumes() []*UniswapPairVolume {
 	return list
 }
 
-func (upv *UniswapPairVolume) getVolumeTillNow(fromTime int64) (hexutil.Big, error) {
+func (upv *UniswapPairVolume) getVolumeTillNow(ctx context.Context, fromTime int64) (hexutil.Big, error) {
 	toTime := time.Now().UTC().Unix()
-	swapVolume, err := repository.R().UniswapVolume(&upv.PairAddress, fromTime, toTime)
+	swapVolume, err := repository.R().UniswapVolume(ctx, &upv.PairAddress, fromTime, toTime)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -247,27 +263,27 @@ func (upv *UniswapPairVolume) getVolumeTillNow(fromTime int64) (hexutil.Big, err
 }
 
 // DailyVolume returns swap volume for last 24 hours
-func (upv *UniswapPairVolume) DailyVolume() (hexutil.Big, error) {
+func (upv *UniswapPairVolume) DailyVolume(ctx context.Context) (hexutil.Big, error) {
 	fromTime := time.Now().UTC().AddDate(0, 0, -1).Unix()
-	return upv.getVolumeTillNow(fromTime)
+	return upv.getVolumeTillNow(ctx, fromTime)
 }
 
 // WeeklyVolume returns swap volume for last 7 days
-func (upv *UniswapPairVolume) WeeklyVolume() (hexutil.Big, error) {
+func (upv *UniswapPairVolume) WeeklyVolume(ctx context.Context) (hexutil.Big, error) {
 	fromTime := time.Now().UTC().AddDate(0, 0, -7).Unix()
-	return upv.getVolumeTillNow(fromTime)
+	return upv.getVolumeTillNow(ctx, fromTime)
 }
 
 // MonthlyVolume returns swap volume for last month
-func (upv *UniswapPairVolume) MonthlyVolume() (hexutil.Big, error) {
+func (upv *UniswapPairVolume) MonthlyVolume(ctx context.Context) (hexutil.Big, error) {
 	fromTime := time.Now().UTC().AddDate(0, -1, 0).Unix()
-	return upv.getVolumeTillNow(fromTime)
+	return upv.getVolumeTillNow(ctx, fromTime)
 }
 
 // YearlyVolume returns swap volume for last year
-func (upv *UniswapPairVolume) YearlyVolume() (hexutil.Big, error) {
+func (upv *UniswapPairVolume) YearlyVolume(ctx context.Context) (hexutil.Big, error) {
 	fromTime := time.Now().UTC().AddDate(-1, 0, 0).Unix()
-	return upv.getVolumeTillNow(fromTime)
+	return upv.getVolumeTillNow(ctx, fromTime)
 }
 
 // IsInFUSD indicates if TokenA from the pair has a price value to be able
@@ -278,7 +294,7 @@ func (upv *UniswapPairVolume) IsInFUSD() (bool, error) {
 
 // DefiTimeVolumes resolves swap volumes for given pair
 // If dates are not given, then it returns last month values
-func (rs *rootResolver) DefiTimeVolumes(args *struct {
+func (rs *rootResolver) DefiTimeVolumes(ctx context.Context, args *struct {
 	Address    common.Address
 	Resolution *string
 	FromDate   *int32
@@ -300,7 +316,7 @@ func (rs *rootResolver) DefiTimeVolumes(args *struct {
 	}
 
 	// get volumes from DB repository
-	swapVolumes, err := repository.R().UniswapTimeVolumes(&args.Address, resolution, fDate, tDate)
+	swapVolumes, err := repository.R().UniswapTimeVolumes(ctx, &args.Address, resolution, fDate, tDate)
 	if err != nil {
 		log.Errorf("Can not get swap volumes from DB repository: %s", err.Error())
 		return make([]*DefiTimeVolume, 0)
@@ -320,7 +336,7 @@ func (rs *rootResolver) DefiTimeVolumes(args *struct {
 
 // DefiTimePrices resolves swap prices for given pair
 // If dates are not given, then it returns last month values
-func (rs *rootResolver) DefiTimePrices(args *struct {
+func (rs *rootResolver) DefiTimePrices(ctx context.Context, args *struct {
 	Address    common.Address
 	Resolution *string
 	FromDate   *int32
@@ -349,7 +365,7 @@ func (rs *rootResolver) DefiTimePrices(args *struct {
 	}
 
 	// get prices from DB repository
-	swapPrices, err := repository.R().UniswapTimePrices(&args.Address, resolution, fDate, tDate, dir)
+	swapPrices, err := repository.R().UniswapTimePrices(ctx, &args.Address, resolution, fDate, tDate, dir)
 	if err != nil {
 		log.Errorf("Can not get uniswap prices from DB repository: %s", err.Error())
 		return make([]types.DefiTimePrice, 0)
@@ -358,35 +374,62 @@ func (rs *rootResolver) DefiTimePrices(args *struct {
 }
 
 // Reserves resolves a list of token reserves of the given Uniswap pair.
-func (up *UniswapPair) Reserves() ([]hexutil.Big, error) {
-	return repository.R().UniswapReserves(&up.PairAddress)
+func (up *UniswapPair) Reserves(ctx context.Context) ([]hexutil.Big, error) {
+	return repository.R().UniswapReserves(ctx, &up.PairAddress)
 }
 
 // ReservesTimeStamp resolves reserves of the given Uniswap pair.
-func (up *UniswapPair) ReservesTimeStamp() (hexutil.Uint64, error) {
-	return repository.R().UniswapReservesTimeStamp(&up.PairAddress)
+func (up *UniswapPair) ReservesTimeStamp(ctx context.Context) (hexutil.Uint64, error) {
+	return repository.R().UniswapReservesTimeStamp(ctx, &up.PairAddress)
 }
 
 // CumulativePrices resolves a list of token cumulative prices
 // of the given Uniswap pair.
-func (up *UniswapPair) CumulativePrices() ([]hexutil.Big, error) {
-	return repository.R().UniswapCumulativePrices(&up.PairAddress)
+func (up *UniswapPair) CumulativePrices(ctx context.Context) ([]hexutil.Big, error) {
+	return repository.R().UniswapCumulativePrices(ctx, &up.PairAddress)
 }
 
 // TotalSupply resolves the total amount of pair tokens, e.g. the share pool
 // of the given Uniswap pair.
-func (up *UniswapPair) TotalSupply() (hexutil.Big, error) {
-	return repository.R().Erc20TotalSupply(&up.PairAddress)
+func (up *UniswapPair) TotalSupply(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().Erc20TotalSupply(ctx, &up.PairAddress)
 }
 
 // ShareOf resolves the total amount of a share of the given user on the given Uniswap pair.
-func (up *UniswapPair) ShareOf(args *struct{ User common.Address }) (hexutil.Big, error) {
-	return repository.R().Erc20BalanceOf(&up.PairAddress, &args.User)
+func (up *UniswapPair) ShareOf(ctx context.Context, args *struct{ User common.Address }) (hexutil.Big, error) {
+	return repository.R().Erc20BalanceOf(ctx, &up.PairAddress, &args.User)
+}
+
+// Swaps resolves list of swaps executed on the pair.
+func (up *UniswapPair) Swaps(ctx context.Context, args *struct {
+	Cursor *Cursor
+	Count  int32
+}) (*UniswapActionList, error) {
+	// limit query size; the count can be either positive or negative
+	// this controls the loading direction
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+
+	al, err := repository.R().UniswapActions(ctx, &up.PairAddress, (*string)(args.Cursor), args.Count, types.SwapNormal)
+	if err != nil {
+		log.Errorf("can not get swaps of uniswap pair %s; %s", up.PairAddress.String(), err.Error())
+		return nil, err
+	}
+	return NewUniswapActionList(al), nil
+}
+
+// DailyVolume resolves the swap volume of the pair for last 24 hours.
+func (up *UniswapPair) DailyVolume(ctx context.Context) (hexutil.Big, error) {
+	now := time.Now().UTC()
+	sv, err := repository.R().UniswapVolume(ctx, &up.PairAddress, now.AddDate(0, 0, -1).Unix(), now.Unix())
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*sv.Volume), nil
 }
 
 // LastKValue resolves the last value of the pool control coefficient.
-func (up *UniswapPair) LastKValue() (hexutil.Big, error) {
-	return repository.R().UniswapLastKValue(&up.PairAddress)
+func (up *UniswapPair) LastKValue(ctx context.Context) (hexutil.Big, error) {
+	return repository.R().UniswapLastKValue(ctx, &up.PairAddress)
 }
 
 func checkDate(td *int32) int64 {
@@ -398,7 +441,7 @@ func checkDate(td *int32) int64 {
 
 // DefiTimeReserves resolves uniswap reserves for given pair
 // If dates are not given, then it returns last month values
-func (rs *rootResolver) DefiTimeReserves(args *struct {
+func (rs *rootResolver) DefiTimeReserves(ctx context.Context, args *struct {
 	Address    common.Address
 	Resolution *string
 	FromDate   *int32
@@ -420,7 +463,7 @@ func (rs *rootResolver) DefiTimeReserves(args *struct {
 	}
 
 	// get reserves from DB repository
-	timeReserves, err := repository.R().UniswapTimeReserves(&args.Address, resolution, fDate, tDate)
+	timeReserves, err := repository.R().UniswapTimeReserves(ctx, &args.Address, resolution, fDate, tDate)
 	if err != nil {
 		log.Errorf("Can not get uniswap reserves from DB repository: %s", err.Error())
 		return make([]DefiTimeReserve, 0)
@@ -2,6 +2,7 @@
 package resolvers
 
 import (
+	"context"
 	"crypto/rand"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
@@ -16,18 +17,27 @@ import (
 var reExpectedPriceSymbol = regexp.MustCompile(`^[\w]{2,4}$`)
 
 // Price resolves price details of the Opera blockchain token for the given target symbols.
-func (rs *rootResolver) Price(args *struct{ To string }) (types.Price, error) {
+func (rs *rootResolver) Price(ctx context.Context, args *struct{ To string }) (types.Price, error) {
 	// is the requested denomination even reasonable
 	if !reExpectedPriceSymbol.Match([]byte(args.To)) {
 		return types.Price{}, fmt.Errorf("invalid denomination received")
 	}
-	return repository.R().Price(args.To)
+	return repository.R().Price(ctx, args.To)
+}
+
+// FtmPrice resolves the price of the Opera blockchain token in the given target symbol.
+func (rs *rootResolver) FtmPrice(ctx context.Context, args *struct{ To string }) (float64, error) {
+	pri, err := rs.Price(ctx, args)
+	if err != nil {
+		return 0, err
+	}
+	return pri.Price, nil
 }
 
 // GasPrice resolves the current amount of WEI for single Gas.
-func (rs *rootResolver) GasPrice() (hexutil.Uint64, error) {
+func (rs *rootResolver) GasPrice(ctx context.Context) (hexutil.Uint64, error) {
 	// get the actual value
-	price, err := repository.R().GasPrice()
+	price, err := repository.R().GasPrice(ctx)
 	if err != nil {
 		return hexutil.Uint64(0), err
 	}
@@ -43,15 +53,28 @@ func (rs *rootResolver) GasPrice() (hexutil.Uint64, error) {
 	return hexutil.Uint64(price.ToInt().Uint64()), nil
 }
 
+// GasPriceSuggestion resolves the gas price suggestions calculated
+// from gas prices of recent transactions.
+func (rs *rootResolver) GasPriceSuggestion(ctx context.Context) (*types.GasPriceSuggestion, error) {
+	return repository.R().GasPriceSuggestion(ctx)
+}
+
 // EstimateGas resolves the estimated amount of Gas required to perform
 // transaction described by the input params.
-func (rs *rootResolver) EstimateGas(args struct {
+func (rs *rootResolver) EstimateGas(ctx context.Context, args struct {
 	From  *common.Address
 	To    *common.Address
 	Value *hexutil.Big
 	Data  *string
 }) (*hexutil.Uint64, error) {
-	return repository.R().GasEstimate(&args)
+	// make sure the call data are valid before we bother the node
+	if args.Data != nil {
+		if _, err := hexutil.Decode(*args.Data); err != nil {
+			log.Debugf("invalid gas estimation call data; %s", err.Error())
+			return nil, fmt.Errorf("invalid call data; %s", err.Error())
+		}
+	}
+	return repository.R().GasEstimate(ctx, &args)
 }
 
 // uuid generates new random subscription UUID
@@ -0,0 +1,168 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"sort"
+)
+
+const (
+	// validatorPerformanceEpochs represents the default number of epochs
+	// the validator performance is evaluated over.
+	validatorPerformanceEpochs = 100
+
+	// validatorPerformanceMaxEpochs represents the max number of epochs
+	// the validator performance can be evaluated over.
+	validatorPerformanceMaxEpochs = 10000
+)
+
+// ValidatorPerformance represents resolvable performance of a validator
+// over a range of recent sealed epochs.
+type ValidatorPerformance struct {
+	types.ValidatorPerformance
+}
+
+// NewValidatorPerformance creates a new resolvable validator performance.
+func NewValidatorPerformance(vp *types.ValidatorPerformance) *ValidatorPerformance {
+	return &ValidatorPerformance{ValidatorPerformance: *vp}
+}
+
+// validatorPerformanceEpochsLimit limits the number of epochs of the performance range.
+func validatorPerformanceEpochsLimit(epochs int32) int32 {
+	if epochs <= 0 {
+		return validatorPerformanceEpochs
+	}
+	if epochs > validatorPerformanceMaxEpochs {
+		return validatorPerformanceMaxEpochs
+	}
+	return epochs
+}
+
+// ValidatorLeaderboard resolves the list of validators sorted by their performance
+// over the given number of the most recent sealed epochs.
+func (rs *rootResolver) ValidatorLeaderboard(ctx context.Context, args struct {
+	Epochs int32
+	Count  int32
+}) ([]*ValidatorPerformance, error) {
+	args.Epochs = validatorPerformanceEpochsLimit(args.Epochs)
+	args.Count = listLimitCount(args.Count, listMaxEdgesPerRequest)
+	if args.Count < 0 {
+		args.Count = -args.Count
+	}
+
+	var vpl []*types.ValidatorPerformance
+	if err := rs.cachedResponse("validatorLeaderboard", args.Epochs, &vpl, func() (err error) {
+		vpl, err = repository.R().ValidatorPerformanceList(ctx, args.Epochs)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	list := make([]*ValidatorPerformance, len(vpl))
+	for i, vp := range vpl {
+		list[i] = NewValidatorPerformance(vp)
+	}
+
+	// sort by the performance score, the uptime decides on equal score
+	sort.SliceStable(list, func(i, j int) bool {
+		si, sj := list[i].PerformanceScore(), list[j].PerformanceScore()
+		if si == sj {
+			return list[i].Uptime() > list[j].Uptime()
+		}
+		return si > sj
+	})
+
+	if len(list) > int(args.Count) {
+		list = list[:args.Count]
+	}
+	return list, nil
+}
+
+// Performance resolves the performance of the validator
+// over the given number of the most recent sealed epochs.
+func (st Staker) Performance(ctx context.Context, args struct{ Epochs int32 }) (*ValidatorPerformance, error) {
+	vp, err := repository.R().ValidatorPerformance(ctx, &st.Id, validatorPerformanceEpochsLimit(args.Epochs))
+	if err != nil {
+		return nil, err
+	}
+	return NewValidatorPerformance(vp), nil
+}
+
+// PerformanceScore resolves the performance score of the validator
+// over the default number of the most recent sealed epochs.
+func (st Staker) PerformanceScore(ctx context.Context) (float64, error) {
+	vp, err := st.Performance(ctx, struct{ Epochs int32 }{Epochs: validatorPerformanceEpochs})
+	if err != nil {
+		return 0, err
+	}
+	return vp.PerformanceScore(), nil
+}
+
+// Staker resolves the validator of the performance record.
+func (vp *ValidatorPerformance) Staker(ctx context.Context) (*Staker, error) {
+	st, err := repository.R().Validator(ctx, &vp.ValidatorId)
+	if err != nil {
+		return nil, err
+	}
+	return NewStaker(st), nil
+}
+
+// Epochs resolves the number of epochs the validator participated in.
+func (vp *ValidatorPerformance) Epochs() int32 {
+	return int32(vp.ValidatorPerformance.Epochs)
+}
+
+// Duration resolves the total duration of the epochs in seconds.
+func (vp *ValidatorPerformance) Duration() hexutil.Uint64 {
+	return hexutil.Uint64(vp.ValidatorPerformance.Duration)
+}
+
+// Uptime resolves the total time the validator was online in seconds.
+func (vp *ValidatorPerformance) Uptime() hexutil.Uint64 {
+	return hexutil.Uint64(vp.ValidatorPerformance.Uptime)
+}
+
+// Downtime resolves the total time the validator was offline in seconds.
+func (vp *ValidatorPerformance) Downtime() hexutil.Uint64 {
+	if vp.ValidatorPerformance.Uptime >= vp.ValidatorPerformance.Duration {
+		return 0
+	}
+	return hexutil.Uint64(vp.ValidatorPerformance.Duration - vp.ValidatorPerformance.Uptime)
+}
+
+// MissedBlocks resolves the highest number of blocks missed in a row.
+func (vp *ValidatorPerformance) MissedBlocks() hexutil.Uint64 {
+	return hexutil.Uint64(vp.ValidatorPerformance.MissedBlocks)
+}
+
+// OriginatedFee resolves the amount of fees originated by the validator.
+func (vp *ValidatorPerformance) OriginatedFee() hexutil.Big {
+	return (hexutil.Big)(*vp.ValidatorPerformance.OriginatedFee)
+}
+
+// FeeShare resolves the share of the network fees originated by the validator.
+func (vp *ValidatorPerformance) FeeShare() float64 {
+	if vp.EpochFee == nil || vp.EpochFee.Sign() == 0 {
+		return 0
+	}
+	val, _ := new(big.Float).Quo(new(big.Float).SetInt(vp.ValidatorPerformance.OriginatedFee), new(big.Float).SetInt(vp.EpochFee)).Float64()
+	return val
+}
+
+// PerformanceScore resolves the performance score of the validator
+// in the range of 0 to 100 based on the validator uptime.
+func (vp *ValidatorPerformance) PerformanceScore() float64 {
+	if vp.ValidatorPerformance.Duration == 0 {
+		return 0
+	}
+
+	score := 100 * float64(vp.ValidatorPerformance.Uptime) / float64(vp.ValidatorPerformance.Duration)
+	if score > 100 {
+		return 100
+	}
+	return score
+}
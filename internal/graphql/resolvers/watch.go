@@ -0,0 +1,185 @@
+// Package resolvers implements GraphQL resolvers to incoming API requests.
+package resolvers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/svc"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"net/url"
+	"strings"
+)
+
+const (
+	// watchMaxPerOwner represents the max number of watches a single client can register.
+	watchMaxPerOwner = 100
+
+	// watchAdminOwner represents the owner of watches registered by the API server administrator.
+	watchAdminOwner = "admin"
+)
+
+// errWatchOwnerUnknown represents an error of a watch management call made by an anonymous client.
+var errWatchOwnerUnknown = fmt.Errorf("API key required to manage watches")
+
+// watchEvents maps the API watch event types to the stored event types.
+var watchEvents = map[string]string{
+	"INCOMING_TRANSACTION": types.WatchEventIncomingTrx,
+	"OUTGOING_TRANSACTION": types.WatchEventOutgoingTrx,
+	"TOKEN_TRANSFER":       types.WatchEventTokenTransfer,
+	"DELEGATION":           types.WatchEventDelegation,
+}
+
+// Watch represents resolvable address watch with a webhook.
+type Watch struct {
+	w      *types.Watch
+	secret bool
+}
+
+// watchOwner provides the owner of watches managed by the client of the request.
+// Nil is returned for the administrator, who can manage all the watches.
+func watchOwner(ctx context.Context) (*string, error) {
+	if isAdmin(ctx) {
+		return nil, nil
+	}
+	if hash, ok := apiKeyOf(ctx); ok {
+		return &hash, nil
+	}
+	return nil, errWatchOwnerUnknown
+}
+
+// Watches resolves the list of address watches of the client.
+func (rs *rootResolver) Watches(ctx context.Context) ([]*Watch, error) {
+	owner, err := watchOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := repository.R().Watches(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*Watch, len(list))
+	for i, w := range list {
+		res[i] = &Watch{w: w}
+	}
+	return res, nil
+}
+
+// CreateWatch resolves registration of a new webhook notified about events of the given address.
+func (rs *rootResolver) CreateWatch(ctx context.Context, args *struct {
+	Address common.Address
+	Url     string
+	Events  []string
+}) (*Watch, error) {
+	owner, err := watchOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := isWatchUrlValid(ctx, args.Url); err != nil {
+		return nil, err
+	}
+	if len(args.Events) == 0 {
+		return nil, fmt.Errorf("at least one event type required")
+	}
+
+	// limit the number of watches of a client
+	id := watchAdminOwner
+	if owner != nil {
+		id = *owner
+		list, err := repository.R().Watches(ctx, owner)
+		if err != nil {
+			return nil, err
+		}
+		if len(list) >= watchMaxPerOwner {
+			return nil, fmt.Errorf("max number of %d watches reached", watchMaxPerOwner)
+		}
+	}
+
+	events := make([]string, 0, len(args.Events))
+	for _, e := range args.Events {
+		events = append(events, watchEvents[e])
+	}
+
+	w, err := repository.R().CreateWatch(ctx, id, &args.Address, args.Url, events)
+	if err != nil {
+		log.Errorf("can not create watch of %s; %s", args.Address.String(), err.Error())
+		return nil, err
+	}
+	return &Watch{w: w, secret: true}, nil
+}
+
+// RemoveWatch resolves removal of the watch of the given id.
+func (rs *rootResolver) RemoveWatch(ctx context.Context, args *struct{ Id string }) (bool, error) {
+	owner, err := watchOwner(ctx)
+	if err != nil {
+		return false, err
+	}
+	return repository.R().RemoveWatch(ctx, args.Id, owner)
+}
+
+// isWatchUrlValid checks if the webhook URL can be used to deliver watch events.
+// Webhooks on the server private network are not allowed.
+func isWatchUrlValid(ctx context.Context, u string) error {
+	wu, err := url.Parse(u)
+	if err != nil || !wu.IsAbs() || wu.Host == "" {
+		return fmt.Errorf("invalid webhook URL")
+	}
+	if wu.Scheme != "https" && wu.Scheme != "http" {
+		return fmt.Errorf("webhook URL must use http, or https")
+	}
+	if err := svc.IsPublicUrl(ctx, u); err != nil {
+		return fmt.Errorf("webhook URL not allowed; %s", err.Error())
+	}
+	return nil
+}
+
+// Id resolves the identifier of the watch.
+func (w *Watch) Id() string {
+	return w.w.Id
+}
+
+// Address resolves the watched address.
+func (w *Watch) Address() common.Address {
+	return common.HexToAddress(w.w.Address)
+}
+
+// Url resolves the webhook URL.
+func (w *Watch) Url() string {
+	return w.w.Url
+}
+
+// Events resolves the list of event types the webhook is notified about.
+func (w *Watch) Events() []string {
+	list := make([]string, len(w.w.Events))
+	for i, e := range w.w.Events {
+		list[i] = eventName(e)
+	}
+	return list
+}
+
+// Secret resolves the key of the webhook payload signature; it's available only on the watch creation.
+func (w *Watch) Secret() *string {
+	if !w.secret {
+		return nil
+	}
+	return &w.w.Secret
+}
+
+// Created resolves the UNIX timestamp of the watch registration.
+func (w *Watch) Created() hexutil.Uint64 {
+	return hexutil.Uint64(w.w.Created.Unix())
+}
+
+// eventName provides the API name of the given stored watch event type.
+func eventName(e string) string {
+	for api, v := range watchEvents {
+		if v == e {
+			return api
+		}
+	}
+	return strings.ToUpper(e)
+}
@@ -2,9 +2,11 @@
 package resolvers
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
 )
 
 // WithdrawRequest represents resolvable partial withdraw request
@@ -49,9 +51,9 @@ func (wr WithdrawRequest) Amount() hexutil.Big {
 }
 
 // Account resolves the account detail of the partial withdraw request.
-func (wr WithdrawRequest) Account() (*Account, error) {
+func (wr WithdrawRequest) Account(ctx context.Context) (*Account, error) {
 	// get the account detail by address
-	acc, err := repository.R().Account(&wr.Address)
+	acc, err := loadAccount(ctx, &wr.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -61,9 +63,9 @@ func (wr WithdrawRequest) Account() (*Account, error) {
 }
 
 // Staker resolves the withdraw request staker detail, if available.
-func (wr WithdrawRequest) Staker() (*Staker, error) {
+func (wr WithdrawRequest) Staker(ctx context.Context) (*Staker, error) {
 	// get staker detail by the staker id
-	st, err := repository.R().Validator(wr.WithdrawRequest.StakerID)
+	st, err := repository.R().Validator(ctx, wr.WithdrawRequest.StakerID)
 	if err != nil {
 		return nil, err
 	}
@@ -71,3 +73,41 @@ func (wr WithdrawRequest) Staker() (*Staker, error) {
 	// return the staker information
 	return NewStaker(st), nil
 }
+
+// ClaimableTime resolves the time stamp after which the requested amount
+// can be withdrawn, as enforced by the SFC contract withdrawal period.
+func (wr WithdrawRequest) ClaimableTime(ctx context.Context) (hexutil.Uint64, error) {
+	sc, err := repository.R().SfcConfiguration(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return wr.CreatedTime + hexutil.Uint64(sc.WithdrawalPeriodTime.ToInt().Uint64()), nil
+}
+
+// ClaimableIn resolves the number of seconds remaining until the requested amount
+// can be withdrawn; zero if the amount is claimable already.
+func (wr WithdrawRequest) ClaimableIn(ctx context.Context) (hexutil.Uint64, error) {
+	ct, err := wr.ClaimableTime(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := hexutil.Uint64(time.Now().UTC().Unix())
+	if now >= ct {
+		return 0, nil
+	}
+	return ct - now, nil
+}
+
+// IsClaimable signals if the pending request can be withdrawn right now.
+func (wr WithdrawRequest) IsClaimable(ctx context.Context) (bool, error) {
+	if wr.WithdrawTime != nil {
+		return false, nil
+	}
+
+	left, err := wr.ClaimableIn(ctx)
+	if err != nil {
+		return false, err
+	}
+	return left == 0, nil
+}
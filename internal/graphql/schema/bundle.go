@@ -46,6 +46,14 @@ type DailyTrxVolume {
     # gas represents the total amount of gas consumed by transactions
     # on the network on the day.
     gas: BigInt!
+
+    # fee represents the total amount of fees in WEI paid
+    # by transactions on the network on the day.
+    fee: BigInt!
+
+    # activeAccounts represents the number of unique accounts
+    # sending, or receiving transactions on the day.
+    activeAccounts: Int!
 }
 
 # DefiToken represents a token available for DeFi operations.
@@ -260,6 +268,40 @@ type TransactionListEdge {
 }
 
 
+# TransactionDirection represents the direction of a transaction relative to an account.
+enum TransactionDirection {
+    IN
+    OUT
+}
+
+# TransactionResultStatus represents the receipt status of a mined transaction.
+enum TransactionResultStatus {
+    SUCCESS
+    FAILED
+}
+
+# TransactionFilter represents a set of conditions of a transaction list.
+# Conditions not provided are not applied.
+input TransactionFilter {
+    # direction of transactions relative to the account;
+    # both directions are listed if not provided.
+    direction: TransactionDirection
+
+    # minValue is the lowest value of listed transactions in WEI.
+    # The value is compared with GWei precision.
+    minValue: BigInt
+
+    # maxValue is the highest value of listed transactions in WEI.
+    # The value is compared with GWei precision.
+    maxValue: BigInt
+
+    # status of listed transactions.
+    status: TransactionResultStatus
+
+    # counterpart is the address on the other side of listed transactions.
+    counterpart: Address
+}
+
 # BlockList is a list of block edges provided by sequential access request.
 type BlockList {
     # Edges contains provided edges of the sequential list.
@@ -356,6 +398,11 @@ type Transaction {
     # is a contract address.
     inputData: Bytes!
 
+    # inputDataDecoded represents the input data decoded using the ABI
+    # of the recipient contract. Null if the ABI is not known,
+    # or the input data can not be decoded.
+    inputDataDecoded: DecodedCall
+
     # BlockHash is the hash of the block this transaction was assigned to.
     # Null if the transaction is pending.
     blockHash: Bytes32
@@ -374,6 +421,20 @@ type Transaction {
     # field will be null.
     status: Long
 
+    # errorMessage is the reason of failure of a failed transaction,
+    # e.g. the revert reason provided by the contract. Null for pending
+    # and successful transactions.
+    errorMessage: String
+
+    # logs represents the list of event log records emitted by the transaction.
+    logs: [TransactionLog!]!
+
+    # internalTransactions represents the list of calls executed inside
+    # the transaction by contracts, including internal FTM transfers.
+    # The calls are available only if the internal calls tracing is enabled
+    # on the API server.
+    internalTransactions: [InternalTransaction!]!
+
     # tokenTransactions represents a list of generic token transactions executed in the scope
     # of the transaction call; token type and transaction type is provided.
     tokenTransactions: [TokenTransaction!]!
@@ -420,6 +481,10 @@ type Block {
 
     # txList is a list of transactions assigned to the block.
     txList: [Transaction!]!
+
+    # ftmBurned represents the amount of native FTM tokens burned
+    # from the transaction fees of the block in WEI units.
+    ftmBurned: BigInt!
 }
 
 # ERC721Contract represents a generic ERC721 non-fungible tokens (NFT) contract.
@@ -520,6 +585,24 @@ type ERC20Token {
 
     # totalDebt represents total amount of borrowed/minted tokens on fMint.
     totalDebt: BigInt!
+
+    # holders represents a list of accounts holding the token sorted
+    # by their balance from the highest to the lowest. The list is browsed
+    # forward only, the cursor is the rank of the holder the list continues after.
+    holders(cursor: Cursor, count: Int = 25): AccountBalanceList!
+
+    # price represents the current price of a whole token in native FTM tokens
+    # derived from the reserves of the token pair with the wrapped FTM on the DEX.
+    # Null if the token is not traded against the wrapped FTM.
+    price: Float
+
+    # priceChange24h represents the relative change of the token price
+    # in native FTM tokens over the last 24 hours in percents, if known.
+    priceChange24h: Float
+
+    # volume24h represents the amount of the token traded on the known DEX pairs
+    # over the last 24 hours.
+    volume24h: BigInt!
 }
 
 # DelegationList is a list of delegations edges provided by sequential access request.
@@ -561,6 +644,9 @@ type Delegation {
     # Time stamp of the delegation creation.
     createdTime: Long!
 
+    # Id of the epoch the delegation has been created in.
+    createdEpoch: Long!
+
     # Amount delegated in WEI. The value includes all the pending un-delegations.
     amount: BigInt!
 
@@ -570,8 +656,9 @@ type Delegation {
     # Amount locked in pending un-delegations in WEI.
     amountInWithdraw: BigInt!
 
-    # Total amount of rewards claimed.
-    claimedReward: BigInt!
+    # Total amount of rewards claimed. The optional since and until
+    # time stamps limit the sum to claims made within the time range.
+    claimedReward(since: Long, until: Long): BigInt!
 
     # Pending rewards for the delegation in WEI.
     pendingRewards: PendingRewards!
@@ -614,6 +701,13 @@ type Delegation {
     # to the stake amount on premature unlock
     unlockPenalty(amount: BigInt!): BigInt!
 
+    # lock represents the current lock of the delegation stake.
+    lock: DelegationLock!
+
+    # lockHistory represents the most recent lockup and unlock events
+    # of the delegation, sorted from the most recent.
+    lockHistory(count: Int = 25): [DelegationLockEvent!]!
+
     # outstandingSFTM represents the amount of sFTM tokens representing
     # the tokenized stake minted and un-repaid on this delegation.
     outstandingSFTM: BigInt!
@@ -676,6 +770,32 @@ type Epoch {
 
     # Total supply amount.
     totalSupply: BigInt!
+
+    # List of validators active in the epoch
+    # with their received stake and rewards.
+    validatorRewards: [EpochValidator!]!
+}
+
+# EpochValidator represents the state of a validator
+# and its rewards at the end of an epoch.
+type EpochValidator {
+    # Identifier of the validator.
+    validatorId: BigInt!
+
+    # Total stake received by the validator in the epoch.
+    receivedStake: BigInt!
+
+    # Reward per token of stake granted in the epoch.
+    rewardPerToken: BigInt!
+
+    # Reward per token of stake accumulated up to the epoch.
+    accumulatedRewardPerToken: BigInt!
+
+    # Fee of transactions originated by the validator accumulated up to the epoch.
+    originatedTxsFee: BigInt!
+
+    # Uptime of the validator accumulated up to the epoch.
+    uptime: BigInt!
 }
 
 # ERC721TransactionList is a list of ERC721 transaction edges provided by sequential access request.
@@ -707,6 +827,9 @@ type Contract {
     "transactionHash represents the smart contract deployment transaction hash."
     transactionHash: Bytes32!
 
+    "Deployer represents the address of the account which deployed the contract."
+    deployer: Address!
+
     "Smart contract name. Empty if not available."
     name: String!
 
@@ -739,6 +862,55 @@ type Contract {
 
     "Timestamp is the unix timestamp at which this smart contract was deployed."
     timestamp: Long!
+
+    """
+    UsageStats provides a list of daily aggregations of the calls of the contract.
+    Boundaries are defined the same way as for the trxVolume query.
+    """
+    usageStats(from:String, to:String): [ContractDailyUsage!]!
+
+    "ByteCode represents the runtime byte code deployed on the contract address."
+    byteCode: Bytes!
+
+    """
+    StorageAt provides the raw value of the given storage slot of the contract
+    at the given block number, or at the latest block if the block is not specified.
+    """
+    storageAt(slot: Bytes32!, block: Long): Bytes32!
+
+    """
+    StorageLayout provides the state variables of the contract with values decoded
+    from the contract storage at the given block number, or at the latest block.
+    The layout is known for contracts validated by a compiler providing it,
+    null is returned otherwise. Only value types placed inside a single storage slot
+    are decoded; mappings, dynamic arrays, strings and structs have no value.
+    """
+    storageLayout(block: Long): [ContractStorageVariable!]
+}
+
+# ContractStorageVariable represents a state variable of a smart contract
+# as placed in the contract storage.
+type ContractStorageVariable {
+    "Label is the name of the state variable."
+    label: String!
+
+    "Type is the Solidity type of the variable, i.e. uint256."
+    type: String!
+
+    "Slot is the storage slot the variable starts at."
+    slot: Bytes32!
+
+    "Offset is the byte offset of the variable inside the storage slot."
+    offset: Int!
+
+    "Size is the number of bytes used by the variable."
+    size: Int!
+
+    "Encoding is the storage encoding of the variable; inplace, mapping, dynamic_array, or bytes."
+    encoding: String!
+
+    "Value is the decoded value of the variable, null if the value can not be decoded."
+    value: String
 }
 
 # ContractValidationInput represents a set of data sent from client
@@ -771,6 +943,13 @@ input ContractValidationInput {
     """
     optimizeRuns: Int = 200
 
+    """
+    CompilerVersion specifies the version of the Solidity compiler
+    the contract was compiled with, i.e. "0.5.17". The default compiler
+    is used if not specified, or if the version is not available.
+    """
+    compilerVersion: String
+
     "Smart contract source code."
     sourceCode: String!
 }
@@ -900,9 +1079,10 @@ type UniswapAction {
     sender: Address!
 
     # type represents action type:
-    # 0 - swap
-    # 1 - mint
-    # 2 - burn
+    # 0 - mint (and swaps indexed before the swap type was introduced)
+    # 1 - burn
+    # 2 - sync
+    # 3 - swap
     type: Int!
 
     # blockNr is number of the block for this action
@@ -990,6 +1170,14 @@ type Staker {
     # Number of seconds the staker is offline.
     downtime: Long!
 
+    # performanceScore represents the performance score of the staker
+    # in the range of 0 to 100 based on the uptime in the recent 100 epochs.
+    performanceScore: Float!
+
+    # performance provides the performance of the staker
+    # over the given number of the most recent sealed epochs.
+    performance(epochs: Int = 100): ValidatorPerformance!
+
     # List of delegations of this staker. Cursor is used to obtain specific slice
     # of the staker delegations. The most recent delegations
     # are provided if cursor is omitted.
@@ -1056,6 +1244,11 @@ type FMintAccount {
     # in ref. denomination (fUSD).
     debtValue: BigInt!
 
+    # collateralRatio4 represents the current ratio between the collateral
+    # and the debt value in 4 digits, e.g. value 30000 = 3.0x.
+    # The ratio is not available if the account does not have any debt.
+    collateralRatio4: BigInt
+
     # rewardsEarned represents accumulated rewards
     # earned on the DeFi / fMint account for the excessive
     # collateral value. Please note that the rewards could still
@@ -1102,6 +1295,12 @@ type FMintTokenBalance {
     # value of the current balance of the token on the account
     # in ref. denomination (fUSD).
     value: BigInt!
+
+    # liquidationPrice represents the price of a collateral token
+    # on which the collateral to debt ratio of the account drops below
+    # the lowest allowed ratio, considering prices of other tokens stay the same.
+    # The price is not available for debt tokens and for accounts without debt.
+    liquidationPrice: BigInt
 }
 
 # DefiSettings represents the set of current settings and limits
@@ -1193,6 +1392,15 @@ type EstimatedRewards {
     # of tokens yearly.
     currentRewardRateYearly: Int!
 
+    # rewardRatio represents the share of the full rewards paid to the stake
+    # after the validator commission and the lockup duration are applied.
+    # The value is 1.0 if neither the staker, nor the lock duration is provided.
+    rewardRatio: Float!
+
+    # apr represents the estimated annual percentage rate of the stake
+    # with the validator commission and the lockup duration applied.
+    apr: Float!
+
     # Total amount of staked FTM tokens used for the calculation in WEI units.
     # The estimation uses total staked amount, not the effective amount provided
     # by the last epoch. The effective amount does not include current
@@ -1235,6 +1443,17 @@ type WithdrawRequest {
     # WithdrawTime represents the time stamp of the request finalization.
     # If the request is pending, the withdrawTime will be NULL.
     withdrawTime: Long
+
+    # claimableTime represents the time stamp after which the requested
+    # amount can be withdrawn, as enforced by the SFC withdrawal period.
+    claimableTime: Long!
+
+    # claimableIn represents the number of seconds remaining
+    # until the requested amount can be withdrawn; zero if already claimable.
+    claimableIn: Long!
+
+    # isClaimable indicates if the pending request can be withdrawn right now.
+    isClaimable: Boolean!
 }
 
 # UniswapPair represents the information about single
@@ -1279,6 +1498,14 @@ type UniswapPair {
     # To get the share percentage, divide this value by the total supply
     # of the pair.
     shareOf(user: Address!): BigInt!
+
+    # swaps represents the list of swap trades executed on the pair.
+    # If <count> is positive, return edges after the cursor,
+    # if negative, return edges before the cursor.
+    swaps(cursor:Cursor, count:Int = 25): UniswapActionList!
+
+    # dailyVolume represents the swap volume of the pair for last 24 hours.
+    dailyVolume: BigInt!
 }
 
 
@@ -1558,6 +1785,9 @@ type Price {
 
     "Timestamp of the last update of this price value."
     lastUpdate: Long!
+
+    "Signals the price has not been updated for longer than the configured max price age."
+    isStale: Boolean!
 }
 
 # Erc1155TransactionType represents a type of transaction.
@@ -1717,6 +1947,9 @@ type Account {
     # Address is the address of the account.
     address: Address!
 
+    # label is the name and category of a known address, if available.
+    label: AccountLabel
+
     # Balance is the current balance of the Account in WEI.
     balance: BigInt!
 
@@ -1728,8 +1961,47 @@ type Account {
     # txCount represents number of transaction sent from the account (Nonce).
     txCount: Long!
 
+    # transactionCount represents the number of indexed transactions the account
+    # is involved in, either as the sender, or as the recipient.
+    transactionCount: Long!
+
+    # firstAppearance represents the block and the time of the first indexed
+    # transaction of the account, if the account has any.
+    firstAppearance: AccountAppearance
+
+    # lastActivity represents the unix time stamp of the most recent indexed
+    # transaction of the account; zero if the account has no indexed activity.
+    lastActivity: Long!
+
+    # gasStats represents a summary of the gas used and the fees paid by the transactions
+    # sent from the account over the given number of the most recent days; at most 365 days.
+    gasStats(window: Int = 30): AccountGasStats!
+
+    # proof represents the Merkle proof of the account state and of the given storage slots
+    # of the account at the given block, or at the latest block if no block is given.
+    # At most 32 storage slots can be proved at once.
+    proof(storageKeys: [Bytes32!], block: Long): AccountProof!
+
     # txList represents list of transactions of the account in form of TransactionList.
-    txList(recipient: Address, cursor:Cursor, count:Int!): TransactionList!
+    # The optional filter limits the list to matching transactions.
+    txList(recipient: Address, cursor:Cursor, count:Int!, filter:TransactionFilter): TransactionList!
+
+    # pendingTxList represents the list of transactions sent by the account
+    # still pending in the node transaction pool sorted by their nonce.
+    pendingTxList: [Transaction!]!
+
+    # internalTxList represents the list of the most recent known internal
+    # transactions the account is involved with, either as the caller, or the callee.
+    internalTxList(count: Int = 25): [InternalTransaction!]!
+
+    # govVotes represents the list of the most recent votes
+    # the account placed on governance proposals.
+    govVotes(count: Int = 25): [GovernanceVote!]!
+
+    # erc20Balances represents the list of ERC20 tokens held by the account
+    # along with the current available balance of each of them.
+    # Only tokens with non-zero balance are listed.
+    erc20Balances(count: Int = 50): [ERC20Balance!]!
 
     # erc20TxList represents list of ERC20 transactions of the account.
     erc20TxList(cursor:Cursor, count:Int = 25, token: Address, txType: String): ERC20TransactionList!
@@ -1737,6 +2009,14 @@ type Account {
     # erc721TxList represents list of ERC721 transactions of the account.
     erc721TxList(cursor:Cursor, count:Int = 25, token: Address, tokenId: BigInt, txType: String): ERC721TransactionList!
 
+    # nftList represents the list of ERC721 NFT tokens currently owned by the account.
+    nftList(count: Int = 25): [ERC721Token!]!
+
+    # erc1155Balances represents the list of ERC1155 token types held by the account
+    # along with the current balance of each of them.
+    # Only token types with non-zero balance are listed.
+    erc1155Balances(count: Int = 25): [ERC1155Balance!]!
+
     # erc1155TxList represents list of ERC1155 transactions of the account.
     erc1155TxList(cursor:Cursor, count:Int = 25, token: Address, tokenId: BigInt, txType: String): ERC1155TransactionList!
 
@@ -1748,6 +2028,80 @@ type Account {
 
     # Details about smart contract, if the account is a smart contract.
     contract: Contract
+
+    # fMintAccount represents the DeFi/fMint protocol account of the account
+    # with collateral and debt balances.
+    fMintAccount: FMintAccount!
+}
+
+# AccountProof represents the Merkle proof of an account state
+# and of the selected storage slots of the account.
+type AccountProof {
+    # address represents the address of the account.
+    address: Address!
+
+    # accountProof represents the list of the state trie nodes
+    # from the state root to the account.
+    accountProof: [Bytes!]!
+
+    # balance represents the balance of the account.
+    balance: BigInt!
+
+    # codeHash represents the hash of the code of the account.
+    codeHash: Bytes32!
+
+    # nonce represents the nonce of the account.
+    nonce: Long!
+
+    # storageHash represents the root of the storage trie of the account.
+    storageHash: Bytes32!
+
+    # storageProof represents the list of the proofs of the requested storage slots.
+    storageProof: [StorageProof!]!
+}
+
+# StorageProof represents the Merkle proof of a single storage slot of an account.
+type StorageProof {
+    # key represents the key of the storage slot.
+    key: Bytes32!
+
+    # value represents the value of the storage slot.
+    value: BigInt!
+
+    # proof represents the list of the storage trie nodes
+    # from the storage root to the slot.
+    proof: [Bytes!]!
+}
+
+# AccountGasStats represents a summary of the gas used and the fees paid
+# by the transactions sent from an account.
+type AccountGasStats {
+    # window represents the number of days the stats are calculated on.
+    window: Int!
+
+    # transactions represents the number of transactions sent from the account.
+    transactions: Long!
+
+    # gasUsed represents the total amount of gas used by the transactions.
+    gasUsed: Long!
+
+    # avgGasUsed represents the average amount of gas used by a transaction.
+    avgGasUsed: Long!
+
+    # feesPaid represents the total amount of fees paid by the transactions in WEI units.
+    feesPaid: BigInt!
+
+    # avgGasPrice represents the average gas price of the transactions in WEI units.
+    avgGasPrice: BigInt!
+}
+
+# AccountAppearance represents the block and the time of an account activity.
+type AccountAppearance {
+    # block is the number of the block.
+    block: Long!
+
+    # timestamp is the unix time stamp of the block.
+    timestamp: Long!
 }
 
 # GovernanceContract represents basic information
@@ -1982,6 +2336,12 @@ type GovernanceVote {
     # choices represents the list of opinions on the Proposal options the vote
     # presented.
     choices: [Long!]!
+
+    # voted is the time stamp of the vote, zero if not known.
+    voted: Long!
+
+    # trxHash is the hash of the transaction placing the vote, if known.
+    trxHash: Bytes32
 }
 # Root schema definition
 schema {
@@ -1990,6 +2350,9 @@ schema {
     subscription: Subscription
 }
 
+# SearchResult represents an entity matching a search phrase.
+union SearchResult = Block | Transaction | Account | ERC20Token
+
 # Entry points for querying the API
 type Query {
     # version represents the API server version responding to your requests.
@@ -2005,9 +2368,25 @@ type Query {
     # Total number of accounts active on the Opera blockchain.
     accountsActive:Long!
 
+    # topAccounts provides a list of accounts sorted by their balance from the highest
+    # to the lowest, known as the rich list. The list is browsed forward only,
+    # the cursor is the rank of the account the list continues after.
+    topAccounts(cursor:Cursor, count:Int = 25):AccountBalanceList!
+
     # Get an Account information by hash address.
     account(address:Address!):Account!
 
+    # search resolves the given phrase as a block number, a transaction or block hash,
+    # an account address, or an ERC20 token symbol and provides the matching entities.
+    search(phrase: String!):[SearchResult!]!
+
+    # _service provides the schema of the API annotated for Apollo Federation gateways.
+    _service: _Service!
+
+    # _entities resolves the entities referenced by other federated services
+    # using their key fields; entities not found are resolved as null.
+    _entities(representations: [_Any!]!): [_Entity]!
+
     # Get list of Contracts with at most <count> edges.
     # If <count> is positive, return edges after the cursor,
     # if negative, return edges before the cursor.
@@ -2017,6 +2396,29 @@ type Query {
     # or just contracts with validated byte code and available source/ABI.
     contracts(validatedOnly: Boolean = false, cursor:Cursor, count:Int!):ContractList!
 
+    # Get list of known account labels, optionally of the given category only.
+    accountLabels(category: AccountLabelCategory): [AccountLabel!]!
+
+    # Get the most recent records of the executed operations audit log,
+    # optionally filtered by the client API key hash and the operation name.
+    # The audit log is available to the API server administrator only.
+    auditLog(client: String, operation: String, count: Int = 25): [AuditRecord!]!
+
+    # Get the progress of the blockchain data indexing, e.g. to monitor the indexing lag.
+    scannerState: ScannerState!
+
+    # Get the read only namespace of the API server administration.
+    # The namespace is available to the API server administrator only.
+    admin: AdminQuery!
+
+    # Get list of address watches owned by the client of the API key.
+    watches: [Watch!]!
+
+    # Get the daily usage of the API key of the client over the given number of days,
+    # at most 90 days back. The administrator can see the usage of any API key
+    # identified by its hash as recorded in the audit log.
+    apiKeyUsage(client: String, days: Int = 30): [ApiKeyUsage!]!
+
     # Get block information by number or by hash.
     # If neither is provided, the most recent block is given.
     block(number:Long, hash: Bytes32):Block
@@ -2026,7 +2428,14 @@ type Query {
     # if negative, return edges before the cursor.
     # For undefined cursor, positive <count> starts the list from top,
     # negative <count> starts the list from bottom.
-    blocks(cursor:Cursor, count:Int!):BlockList!
+    # If <from> and/or <to> UNIX time is provided, only blocks
+    # created in the time range are listed.
+    blocks(cursor:Cursor, count:Int!, from:Long, to:Long):BlockList!
+
+    # Get list of Blocks created in the given UNIX time range
+    # with at most <count> edges. Both ends of the range are included.
+    # Paging follows the same rules as the blocks list.
+    blocksByTimeRange(from:Long!, to:Long!, cursor:Cursor, count:Int!):BlockList!
 
     # Get transaction information for given transaction hash.
     transaction(hash:Bytes32!):Transaction
@@ -2036,7 +2445,17 @@ type Query {
     # if negative, return edges before the cursor.
     # For undefined cursor, positive <count> starts the list from top,
     # negative <count> starts the list from bottom.
-    transactions(cursor:Cursor, count:Int!):TransactionList!
+    # The optional <filter> limits the list to matching transactions;
+    # the direction is relative to the filter counterpart address.
+    transactions(cursor:Cursor, count:Int!, filter:TransactionFilter):TransactionList!
+
+    # transactionCount provides the exact number of transactions indexed by the API server.
+    transactionCount: Long!
+
+    # pendingTransactions provides a list of transactions pending in the node
+    # transaction pool sorted by their gas price from the highest to the lowest.
+    # The pool is volatile, the list is refreshed in short intervals.
+    pendingTransactions(count:Int = 25):[Transaction!]!
 
     # Get filtered list of ERC20 Transactions.
     erc20Transactions(cursor:Cursor, count:Int = 25, token: Address, account: Address, txType: String): ERC20TransactionList!
@@ -2067,6 +2486,9 @@ type Query {
     # or by address. null if none is provided.
     staker(id: BigInt, address: Address): Staker
 
+    # stakerByAddress provides staker information for the given staker address.
+    stakerByAddress(address: Address!): Staker
+
     # List of staker information from SFC smart contract.
     stakers: [Staker!]!
 
@@ -2075,6 +2497,10 @@ type Query {
     # of stakers in a given state of staking process.
     stakersWithFlag(flag: StakerFlagFilter!): [Staker!]!
 
+    # validatorLeaderboard provides list of validators sorted by their performance
+    # over the given number of the most recent sealed epochs.
+    validatorLeaderboard(epochs: Int = 100, count: Int = 25): [ValidatorPerformance!]!
+
     # The list of delegations for the given staker ID.
     # Cursor is used to obtain specific slice of the staker delegations.
     # The most recent delegations are provided if cursor is omitted.
@@ -2090,18 +2516,45 @@ type Query {
     # Returns the current price per gas in WEI units.
     gasPrice: Long!
 
+    # gasPriceSuggestion provides slow, standard and fast gas price suggestions
+    # calculated from gas price percentiles of recent transactions.
+    gasPriceSuggestion: GasPriceSuggestion!
+
     # estimateGas returns the estimated amount of gas required
     # for the transaction described by the parameters of the call.
+    # The data, if provided, must be hex encoded with the 0x prefix.
     estimateGas(from: Address, to: Address, value: BigInt, data: String): Long
 
+    # contractCall executes a read-only call of the contract with the raw call data
+    # on top of the state of the given block, or the latest block if not specified.
+    # The raw output of the call is returned.
+    contractCall(to: Address!, data: Bytes!, block: Long): Bytes!
+
+    # contractCallTyped executes a read-only call of the named function of the contract
+    # using the stored contract ABI. The args represent a JSON array of the function
+    # arguments; big numbers can be provided as strings, bytes as hex encoded strings.
+    # The output values of the function are decoded using the ABI.
+    contractCallTyped(to: Address!, function: String!, args: String, block: Long): [DecodedArgument!]!
+
+    # verifySignature checks if the message has been signed by the given address.
+    # The PERSONAL_SIGN scheme expects the signed text as the message,
+    # the EIP712 scheme expects the JSON of the signed typed data.
+    # Signatures of contract wallets are verified using their ERC1271 interface.
+    verifySignature(address: Address!, message: String!, signature: Bytes!, scheme: SignatureScheme = PERSONAL_SIGN): Boolean!
+
     # Get price details of the Opera blockchain token for the given target symbols.
     price(to:String!):Price!
 
+    # Get the price of the Opera blockchain token in the given target symbol.
+    ftmPrice(to:String = "USD"):Float!
+
     # Get calculated staking rewards for an account or given
     # staking amount in FTM tokens.
     # At least one of the address and amount parameters must be provided.
     # If you provide both, the address takes precedence and the amount is ignored.
-    estimateRewards(address:Address, amount:Long):EstimatedRewards!
+    # The optional stakerId applies the validator commission and the optional lockDuration
+    # in seconds applies the lockup reward scaling of the SFC contract to the estimation.
+    estimateRewards(address:Address, amount:Long, stakerId:BigInt, lockDuration:Long):EstimatedRewards!
 
     # sfcRewardsCollectedAmount provides an amount of rewards collected based on given
     # filtering options, which are all optional. If no filter option is passed,
@@ -2134,6 +2587,10 @@ type Query {
     # by the Uniswap Core contract on Opera blockchain.
     defiUniswapPairs: [UniswapPair!]!
 
+    # defiUniswapPair provides the Uniswap pair of the given address,
+    # if the pair is managed by the Uniswap Core on Opera blockchain.
+    defiUniswapPair(pairAddress: Address!): UniswapPair
+
     # defiUniswapAmountsOut calculates the expected output amounts
     # required to finalize a swap operation specified by a list of
     # tokens involved in the swap steps and the input amount.
@@ -2189,9 +2646,10 @@ type Query {
     # negative <count> starts the list from bottom.
     # Address can be used for specifying actions for one Uniswap pair.
     # ActionType represents action type:
-    # 0 - swap,
-    # 1 - mint,
-    # 2 - burn,
+    # 0 - mint (and swaps indexed before the swap type was introduced),
+    # 1 - burn,
+    # 2 - sync,
+    # 3 - swap,
     defiUniswapActions(pairAddress:Address, cursor:Cursor, count:Int!, actionType:Int):UniswapActionList!
 
     # erc20Token provides the information about an ERC20 token specified by it's
@@ -2221,6 +2679,10 @@ type Query {
     # erc721Contract provides the information about ERC721 non-fungible token (NFT) by it's address.
     erc721Contract(token: Address!):ERC721Contract
 
+    # erc721Token provides the information about a single ERC721 NFT identified
+    # by the address of the contract and the token id.
+    erc721Token(token: Address!, tokenId: BigInt!):ERC721Token
+
     # erc721ContractList provides list of the most active ERC721 non-fungible tokens (NFT) on the block chain.
     erc721ContractList(count: Int = 50):[ERC721Contract!]!
 
@@ -2247,6 +2709,36 @@ type Query {
     # Boundaries are defined in format YYYY-MM-DD, i.e. 2021-01-23 for January 23rd, 2021.
     trxVolume(from:String, to:String):[DailyTrxVolume!]!
 
+    # trxDailyStats provides a list of daily aggregated statistics of the network
+    # transaction flow including gas used, fees paid and unique active accounts.
+    # Boundaries are defined the same way as for the trxVolume query.
+    trxDailyStats(from:String, to:String):[DailyTrxVolume!]!
+
+    # networkLoad provides the recent load of the network calculated
+    # over a sliding window of the most recently processed blocks.
+    networkLoad: NetworkLoad!
+
+    # blockTimeStats provides statistics of the time between blocks and of the observed
+    # time to finality over the given number of the most recent blocks.
+    blockTimeStats(window: Int = 100): BlockTimeStats!
+
+    # blockTimeDaily provides a list of daily aggregations of the block time and the time to finality.
+    # Boundaries are defined the same way as for the trxVolume query.
+    blockTimeDaily(from:String, to:String):[DailyBlockTime!]!
+
+    # topContractsByGas provides a list of the contracts with the highest amount
+    # of gas consumed by their calls over the last 30 days; at most 100 contracts are ranked.
+    topContractsByGas(count: Int = 25): [ContractGasRank!]!
+
+    # ftmBurnedTotal provides the total amount of native FTM tokens burned
+    # from the transaction fees in WEI units.
+    ftmBurnedTotal: BigInt!
+
+    # ftmBurnList provides a list of FTM burns of blocks from the most recent one.
+    # The list is browsed forward only, the cursor is the number of the block
+    # the list continues after.
+    ftmBurnList(cursor:Cursor, count:Int = 25):FtmBurnList!
+
     # trxSpeed provides the recent speed of the network
     # as number of transactions processed per second
     # calculated for the given range denominated in secods. I.e. range:300 means last 5 minutes.
@@ -2265,6 +2757,10 @@ type Query {
 type Mutation {
     # SendTransaction submits a raw signed transaction into the block chain.
     # The tx parameter represents raw signed and RLP encoded transaction data.
+    # If the node did not process the transaction yet, a pending transaction
+    # decoded from the raw data is returned; the transaction can be tracked
+    # by its hash until it's mined.
+    # With the bearer authentication enabled, the mutation requires the write role.
     sendTransaction(tx: Bytes!):Transaction
 
     # Validate a deployed contract byte code with the provided source code
@@ -2272,7 +2768,53 @@ type Mutation {
     # to be able to interact with the contract and get the right metadata.
     # Returns updated contract information. If the contract can not be validated,
     # it raises a GraphQL error.
+    # With the bearer authentication enabled, the mutation requires the write role.
     validateContract(contract: ContractValidationInput!): Contract!
+
+    # createApiKey issues a new API key with the given usage limits.
+    # The mutation is available to the API server administrators only.
+    createApiKey(name: String!, rateLimit: Int!, dailyQuota: Long = 0): ApiKey!
+
+    # revokeApiKey revokes the given API key so it can not be used anymore.
+    # The mutation is available to the API server administrators only.
+    revokeApiKey(key: String!): Boolean!
+
+    # setAccountLabel assigns a name and a category to the given address,
+    # replacing the previous label of the address, if any.
+    # The mutation is available to the API server administrators only.
+    setAccountLabel(address: Address!, name: String!, category: AccountLabelCategory!): AccountLabel!
+
+    # removeAccountLabel removes the label of the given address.
+    # The mutation is available to the API server administrators only.
+    removeAccountLabel(address: Address!): Boolean!
+
+    # createWatch registers a webhook notified about the given events of the address.
+    # The mutation requires an API key; the watch is owned by the client of the key.
+    createWatch(address: Address!, url: String!, events: [WatchEvent!]!): Watch!
+
+    # removeWatch removes the watch of the given id owned by the client.
+    removeWatch(id: String!): Boolean!
+
+    # createDelegation prepares an unsigned SFC call delegating the given amount
+    # of native tokens in WEI to the validator. The amount is validated against
+    # the sender balance and the current delegation limit of the validator.
+    # The transaction has to be signed by the sender and sent by the sendTransaction mutation.
+    createDelegation(from: Address!, validatorId: BigInt!, amount: BigInt!): UnsignedTransaction!
+
+    # claimRewards prepares an unsigned SFC call claiming pending rewards
+    # of the delegation to the validator. If restake is set, the rewards
+    # are added to the delegation instead of being paid to the sender.
+    claimRewards(from: Address!, validatorId: BigInt!, restake: Boolean = false): UnsignedTransaction!
+
+    # undelegate prepares an unsigned SFC call un-delegating the given amount in WEI
+    # from the validator. The amount is validated against the unlocked amount of the delegation.
+    # The requestId identifies the withdraw request, the current UNIX time is used if not given.
+    undelegate(from: Address!, validatorId: BigInt!, amount: BigInt!, requestId: BigInt): UnsignedTransaction!
+
+    # admin provides the operations managing the API server, e.g. chain reindex,
+    # cache purge, or logging level change.
+    # The namespace is available to the API server administrator only.
+    admin: AdminMutation!
 }
 
 # Subscriptions to live events broadcasting
@@ -2281,7 +2823,761 @@ type Subscription {
     onBlock: Block!
 
     # Subscribe to receive information about new transactions in the blockchain.
-    onTransaction: Transaction!
+    # The stream can be limited to transactions sent by the given sender
+    # and/or to the given recipient address. If both filters are provided,
+    # a transaction must match both of them to be delivered.
+    onTransaction(sender: Address, recipient: Address): Transaction!
+
+    # Subscribe to receive status changes of the given transaction.
+    # The current status is sent first, the stream ends when the transaction
+    # is mined, or dropped from the transaction pool.
+    onTransactionStatus(hash: Bytes32!): TransactionStatus!
+}
+
+# ERC20Balance represents the balance of an ERC20 token held by an account.
+type ERC20Balance {
+    # token represents the detail of the ERC20 token.
+    token: ERC20Token!
+
+    # balance represents the current available amount of the token
+    # held by the account.
+    balance: BigInt!
+}
+
+# ERC721Token represents a single non-fungible token (NFT) managed by an ERC721 contract.
+type ERC721Token {
+    # contract represents the address of the ERC721 contract managing the token.
+    contract: Address!
+
+    # token represents the detail of the ERC721 contract managing the token.
+    token: ERC721Contract
+
+    # tokenId represents the identifier of the NFT inside the contract.
+    tokenId: BigInt!
+
+    # owner represents the address of the current owner of the NFT.
+    owner: Address!
+
+    # tokenURI provides URI of Metadata JSON Schema of the NFT.
+    tokenURI: String
+
+    # metadata represents the metadata JSON of the NFT downloaded from the tokenURI;
+    # null if the metadata are not available.
+    metadata: ERC721Metadata
+}
+
+# ERC721Metadata represents the sanitized metadata JSON of an NFT.
+type ERC721Metadata {
+    # uri represents the token URI the metadata were downloaded from.
+    uri: String!
+
+    # name represents the name of the NFT.
+    name: String!
+
+    # description represents the description of the NFT.
+    description: String!
+
+    # image represents the URL of the NFT image; ipfs:// URLs are resolved by the IPFS gateway.
+    image: String
+
+    # externalUrl represents the URL of the NFT page on the issuer site.
+    externalUrl: String
+
+    # attributes represents the list of the NFT traits.
+    attributes: [ERC721Attribute!]!
+
+    # fetched represents the unix time stamp of the metadata download.
+    fetched: Long!
+}
+
+# ERC721Attribute represents a single trait of an NFT.
+type ERC721Attribute {
+    # traitType represents the name of the trait.
+    traitType: String!
+
+    # value represents the value of the trait.
+    value: String!
+
+    # displayType represents a hint of the trait value presentation, if any.
+    displayType: String
+}
+
+# ERC1155Balance represents the balance of an ERC1155 token type held by an account.
+type ERC1155Balance {
+    # contract represents the address of the ERC1155 contract managing the token.
+    contract: Address!
+
+    # token represents the detail of the ERC1155 contract managing the token.
+    token: ERC1155Contract!
+
+    # tokenId represents the identifier of the token type inside the contract.
+    tokenId: BigInt!
+
+    # balance represents the current amount of the token type held by the account.
+    balance: BigInt!
+}
+
+# DecodedArgument represents a single argument of a contract call,
+# or an event, decoded using the contract ABI.
+type DecodedArgument {
+    # name represents the name of the argument as defined in the ABI.
+    name: String!
+
+    # type represents the Solidity type of the argument, i.e. "uint256".
+    type: String!
+
+    # value represents the textual representation of the argument value.
+    # Byte arrays are hex encoded, numbers are decimal.
+    value: String!
+}
+
+# DecodedCall represents a contract function call decoded using the contract ABI.
+type DecodedCall {
+    # name represents the name of the function called.
+    name: String!
+
+    # signature represents the canonical signature of the function,
+    # i.e. "transfer(address,uint256)".
+    signature: String!
+
+    # arguments represents the list of decoded call arguments.
+    arguments: [DecodedArgument!]!
+}
+
+# DecodedEvent represents a contract event decoded using the contract ABI.
+type DecodedEvent {
+    # name represents the name of the event.
+    name: String!
+
+    # signature represents the canonical signature of the event,
+    # i.e. "Transfer(address,address,uint256)".
+    signature: String!
+
+    # arguments represents the list of decoded event arguments,
+    # both indexed and not indexed, in the order of the event definition.
+    arguments: [DecodedArgument!]!
+}
+
+# TransactionLog represents an event log record emitted by a transaction.
+type TransactionLog {
+    # address represents the address of the contract emitting the event.
+    address: Address!
+
+    # topics represents the list of topics of the event;
+    # the first topic is usually the event signature hash.
+    topics: [Bytes32!]!
+
+    # data represents the not indexed data of the event.
+    data: Bytes!
+
+    # index represents the index of the log record in the block.
+    index: Long!
+
+    # decoded represents the event decoded using the ABI of the emitting
+    # contract. Null if the ABI is not known, or the event can not be decoded.
+    decoded: DecodedEvent
+}
+
+# InternalTransaction represents a call executed inside a transaction
+# by a contract, as reported by the transaction trace.
+type InternalTransaction {
+    # trxHash represents the hash of the parent transaction.
+    trxHash: Bytes32!
+
+    # transaction represents the parent transaction.
+    transaction: Transaction!
+
+    # blockNumber represents the number of the block of the parent transaction.
+    blockNumber: Long!
+
+    # traceAddress represents the position of the call in the call tree, i.e. "0_1".
+    traceAddress: String!
+
+    # type represents the type of the trace, i.e. "call", "create", "suicide".
+    type: String!
+
+    # callType represents the type of the call, i.e. "call", "delegatecall", "staticcall".
+    callType: String
+
+    # from represents the address of the caller.
+    from: Address!
+
+    # to represents the address of the callee.
+    to: Address
+
+    # value represents the amount of FTM transferred in the call in WEI.
+    value: BigInt!
+
+    # gas represents the gas provided to the call.
+    gas: Long!
+
+    # gasUsed represents the gas consumed by the call.
+    gasUsed: Long!
+
+    # error represents the error of the call; null if the call succeeded.
+    error: String
+}
+
+# GasPriceSuggestion represents a set of gas price suggestions in WEI units
+# calculated from gas price percentiles of recent transactions.
+type GasPriceSuggestion {
+    # slow represents the gas price for transactions without time preference.
+    slow: BigInt!
+
+    # standard represents the gas price for transactions to be processed in a reasonable time.
+    standard: BigInt!
+
+    # fast represents the gas price for transactions to be processed quickly.
+    fast: BigInt!
+
+    # samples represents the number of recent transactions used for the calculation;
+    # zero if the suggestion is based on the node suggested gas price.
+    samples: Int!
+
+    # updated represents the UNIX timestamp of the last suggestion update.
+    updated: Long!
+}
+
+# NetworkLoad represents the recent load of the network calculated
+# over a sliding window of the most recently processed blocks.
+type NetworkLoad {
+    # blocks represents the number of blocks in the window.
+    blocks: Int!
+
+    # currentTps represents the rate of transactions per second
+    # on the most recent blocks.
+    currentTps: Float!
+
+    # averageTps represents the rate of transactions per second
+    # across the whole window.
+    averageTps: Float!
+
+    # gasUtilization represents the ratio of the gas used to the gas limit
+    # of the most recent block.
+    gasUtilization: Float!
+
+    # averageGasUtilization represents the average gas utilization
+    # of blocks in the window.
+    averageGasUtilization: Float!
+
+    # pendingTransactions represents the number of transactions
+    # pending in the node transaction pool.
+    pendingTransactions: Long!
+
+    # queuedTransactions represents the number of transactions
+    # queued in the node transaction pool.
+    queuedTransactions: Long!
+}
+
+# AccountBalanceList is a list of accounts sorted by their balance
+# from the highest to the lowest. It's used for the native FTM balance
+# and for ERC20 token balances of the token holders.
+type AccountBalanceList {
+    # Edges contains provided edges of the sequential list.
+    edges: [AccountBalanceListEdge!]!
+
+    # TotalCount is the maximum number of accounts
+    # available for sequential access.
+    totalCount: BigInt!
+
+    # PageInfo is an information about the current page of account balance edges.
+    pageInfo: ListPageInfo!
+}
+
+# AccountBalanceListEdge is a single edge in a sequential list of accounts by balance.
+type AccountBalanceListEdge {
+    # Cursor of the edge, the rank of the account.
+    cursor: Cursor!
+
+    # rank represents the position of the account sorted by balance, starting at 1.
+    rank: Long!
+
+    # balance represents the balance of the account in WEI, or in the smallest
+    # token units, as known at the latest activity of the account.
+    balance: BigInt!
+
+    # account represents the account of the edge.
+    account: Account!
+}
+
+# TransactionStatusType represents the status of a transaction.
+enum TransactionStatusType {
+    PENDING
+    MINED
+    DROPPED
+}
+
+# TransactionStatus represents a status change of a transaction.
+type TransactionStatus {
+    # hash of the transaction.
+    hash: Bytes32!
+
+    # status of the transaction; a mined transaction
+    # carries the receipt status in the transaction details.
+    status: TransactionStatusType!
+
+    # transaction details, not available for dropped transactions.
+    transaction: Transaction
+}
+
+# ApiKey represents an API access key issued to a client along with its usage limits.
+type ApiKey {
+    # key represents the API key to be sent by the client in the X-Api-Key header.
+    # The key is available only in the response of the key creation.
+    key: String
+
+    # name represents the name of the client the key was issued to.
+    name: String!
+
+    # rateLimit represents the max number of requests per minute.
+    rateLimit: Int!
+
+    # dailyQuota represents the max number of requests per day; zero for unlimited.
+    dailyQuota: Long!
+
+    # created represents the UNIX timestamp of the key creation.
+    created: Long!
+
+    # revoked signals if the key has been revoked.
+    revoked: Boolean!
+}
+
+# ApiKeyUsage represents the usage of an API key over a single day.
+type ApiKeyUsage {
+    # day represents the UTC day of the usage in format YYYY-MM-DD.
+    day: String!
+
+    # queries represents the number of queries executed on the day.
+    queries: Long!
+
+    # cost represents the cumulative estimated cost of the queries executed on the day;
+    # the cost of a query is estimated the same way as for the query cost limit.
+    cost: Long!
+}
+
+# AccountLabelCategory represents the category of a labeled address.
+enum AccountLabelCategory {
+    EXCHANGE
+    BRIDGE
+    SFC
+    TEAM
+    CONTRACT
+    OTHER
+}
+
+# AccountLabel represents a name and a category of a known address,
+# e.g. an exchange wallet, a bridge, or a team wallet.
+type AccountLabel {
+    # address is the labeled address.
+    address: Address!
+
+    # name is the name of the address owner.
+    name: String!
+
+    # category is the category of the address.
+    category: AccountLabelCategory!
+
+    # updated is the UNIX timestamp of the last label update.
+    updated: Long!
+}
+
+# WatchEvent represents a type of event of a watched address.
+enum WatchEvent {
+    # transaction received by the watched address
+    INCOMING_TRANSACTION
+
+    # transaction sent by the watched address
+    OUTGOING_TRANSACTION
+
+    # ERC20/ERC721/ERC1155 token transfer from, or to the watched address
+    TOKEN_TRANSFER
+
+    # change of a delegation of the watched address
+    DELEGATION
+}
+
+# Watch represents a webhook notified about events of a watched address.
+# The events are sent as JSON encoded POST requests signed by HMAC-SHA256
+# of the request body with the watch secret in the X-Signature header.
+type Watch {
+    # id is the identifier of the watch.
+    id: String!
+
+    # address is the watched address.
+    address: Address!
+
+    # url is the webhook URL receiving the events.
+    url: String!
+
+    # events is the list of event types the webhook is notified about.
+    events: [WatchEvent!]!
+
+    # secret is the key of the webhook payload signature.
+    # The secret is available only in the response of the watch creation.
+    secret: String
+
+    # created is the UNIX timestamp of the watch registration.
+    created: Long!
+}
+
+# _Any represents an entity representation sent by a federation gateway;
+# it carries the __typename of the entity and the fields of its key.
+scalar _Any
+
+# _Entity represents an entity this API can resolve for a federation gateway.
+union _Entity = Account | Block | Transaction | ERC20Token
+
+# _Service represents the federated service definition.
+type _Service {
+    # sdl is the schema of the service annotated with the federation entity keys.
+    sdl: String
+}
+
+# BlockTimeStats represents statistics of the time between blocks
+# and of the observed time to finality over a window of recent blocks.
+# All the times are in seconds.
+type BlockTimeStats {
+    # blocks represents the number of blocks the stats are calculated on.
+    blocks: Int!
+
+    # avgBlockTime represents the average time between blocks.
+    avgBlockTime: Float!
+
+    # medianBlockTime represents the median time between blocks.
+    medianBlockTime: Float!
+
+    # p95BlockTime represents the 95th percentile of the time between blocks.
+    p95BlockTime: Float!
+
+    # ttfBlocks represents the number of blocks in the window with the time
+    # to finality observed. Only blocks received by the API server live are observed.
+    ttfBlocks: Int!
+
+    # avgTtf represents the average time between the block creation
+    # and the block being final and available on the API server.
+    avgTtf: Float!
+
+    # medianTtf represents the median time to finality.
+    medianTtf: Float!
+
+    # p95Ttf represents the 95th percentile of the time to finality.
+    p95Ttf: Float!
+}
+
+# DailyBlockTime represents a daily aggregation of the time between blocks
+# and of the observed time to finality. All the times are in seconds.
+type DailyBlockTime {
+    # day represents the day of the aggregation in format YYYY-MM-DD
+    # i.e. 2021-01-23 for January 23rd, 2021
+    day: String!
+
+    # blocks represents the number of blocks created on the day.
+    blocks: Int!
+
+    # avgBlockTime represents the average time between blocks on the day.
+    avgBlockTime: Float!
+
+    # maxBlockTime represents the longest time between blocks on the day.
+    maxBlockTime: Float!
+
+    # avgTtf represents the average observed time to finality on the day, if observed.
+    avgTtf: Float
+
+    # maxTtf represents the longest observed time to finality on the day, if observed.
+    maxTtf: Float
+}
+
+# FtmBurnList is a list of native FTM burn records sorted from the most recent block.
+type FtmBurnList {
+    # Edges contains provided edges of the sequential list.
+    edges: [FtmBurnListEdge!]!
+
+    # TotalCount is the maximum number of burn records
+    # available for sequential access.
+    totalCount: BigInt!
+
+    # PageInfo is an information about the current page of burn edges.
+    pageInfo: ListPageInfo!
+}
+
+# FtmBurnListEdge is a single edge in a sequential list of FTM burn records.
+type FtmBurnListEdge {
+    # Cursor of the edge, the number of the block.
+    cursor: Cursor!
+
+    # burn represents the FTM burn record of the edge.
+    burn: FtmBurn!
+}
+
+# FtmBurn represents the share of transaction fees of a block burned by the network.
+type FtmBurn {
+    # blockNumber represents the number of the block the fees were burned in.
+    blockNumber: Long!
+
+    # block represents the block the fees were burned in.
+    block: Block!
+
+    # timestamp represents the unix timestamp of the block.
+    timestamp: Long!
+
+    # amount represents the amount of native FTM tokens burned in WEI units.
+    amount: BigInt!
+
+    # txCount represents the number of transactions paying the burned fees.
+    txCount: Int!
+}
+
+# UnsignedTransaction represents a transaction prepared by the API server
+# for the client to sign and send to the blockchain.
+type UnsignedTransaction {
+    # from represents the address of the sender expected to sign the transaction.
+    from: Address!
+
+    # to represents the address of the called contract.
+    to: Address!
+
+    # nonce represents the current nonce of the sender.
+    nonce: Long!
+
+    # value represents the amount of native tokens sent with the transaction in WEI.
+    value: BigInt!
+
+    # gas represents the estimated amount of gas the transaction consumes.
+    gas: Long!
+
+    # gasPrice represents the suggested gas price in WEI.
+    gasPrice: BigInt!
+
+    # data represents the encoded contract call data.
+    data: Bytes!
+}
+
+# DelegationLock represents the lock of a delegation stake.
+type DelegationLock {
+    # lockedAmount represents the amount of delegation stake locked in WEI.
+    lockedAmount: BigInt!
+
+    # lockedFromEpoch represents the id of epoch the lock has been created.
+    lockedFromEpoch: Long!
+
+    # lockedUntil represents the time stamp up to which
+    # the delegation is locked.
+    lockedUntil: Long!
+
+    # duration represents the duration the lock has been placed for in seconds.
+    duration: Long!
+
+    # isLocked indicates if the lock is active right now.
+    isLocked: Boolean!
+
+    # rewardRatio represents the share of full rewards paid
+    # to the stake under the current lock; unlocked stake receives
+    # the base share only.
+    rewardRatio: Float!
+
+    # aprBonus represents the multiplier applied to the APR
+    # of unlocked stake by the current lock.
+    aprBonus: Float!
+}
+
+# DelegationLockEvent represents a lockup or an unlock of a delegation stake.
+type DelegationLockEvent {
+    # delegator represents the address of the delegator.
+    delegator: Address!
+
+    # toValidatorId represents the id of the validator of the delegation.
+    toValidatorId: BigInt!
+
+    # type represents the type of the event; LOCK, or UNLOCK.
+    type: String!
+
+    # amount represents the amount of stake locked, or unlocked in WEI.
+    amount: BigInt!
+
+    # duration represents the duration of the lockup in seconds;
+    # zero for unlock events.
+    duration: Long!
+
+    # penalty represents the penalty applied on a premature unlock in WEI.
+    penalty: BigInt!
+
+    # timeStamp represents the time stamp of the event.
+    timeStamp: Long!
+
+    # trx represents the hash of the transaction emitting the event.
+    trx: Bytes32!
+
+    # logIndex represents the index of the event log in the transaction.
+    logIndex: Int!
+}
+
+# ValidatorPerformance represents the performance of a validator
+# over a range of the most recent sealed epochs.
+type ValidatorPerformance {
+    # validatorId represents the id of the validator.
+    validatorId: BigInt!
+
+    # staker represents the detail of the validator.
+    staker: Staker
+
+    # fromEpoch represents the first epoch of the range.
+    fromEpoch: Long!
+
+    # epochs represents the number of epochs the validator participated in.
+    epochs: Int!
+
+    # duration represents the total duration of the epochs in seconds.
+    duration: Long!
+
+    # uptime represents the total time the validator was online in seconds.
+    uptime: Long!
+
+    # downtime represents the total time the validator was offline in seconds.
+    downtime: Long!
+
+    # missedBlocks represents the highest number of blocks missed in a row.
+    missedBlocks: Long!
+
+    # originatedFee represents the amount of fees originated by the validator in WEI.
+    originatedFee: BigInt!
+
+    # feeShare represents the share of the network fees originated by the validator.
+    feeShare: Float!
+
+    # performanceScore represents the performance score of the validator
+    # in the range of 0 to 100 based on the validator uptime.
+    performanceScore: Float!
+}
+
+# AuditRecord represents a single executed GraphQL operation recorded in the audit log.
+type AuditRecord {
+    # operation represents the name of the executed operation;
+    # empty for anonymous operations.
+    operation: String!
+
+    # variablesHash represents the SHA-256 hash of the operation variables;
+    # empty if no variables were sent.
+    variablesHash: String!
+
+    # client represents the hash of the API key of the client;
+    # empty for anonymous clients.
+    client: String!
+
+    # isAdmin indicates the operation was executed by the API server administrator.
+    isAdmin: Boolean!
+
+    # duration represents the duration of the operation execution in milliseconds.
+    duration: Int!
+
+    # errors represents the errors of the operation execution, if any.
+    errors: [String!]!
+
+    # executed represents the UNIX timestamp of the operation execution.
+    executed: Long!
+}
+
+# AdminQuery represents the read only operations of the API server administration.
+type AdminQuery {
+    # scannerStatus represents the current state of the blockchain data scanner.
+    scannerStatus: ScannerStatus!
+}
+
+# AdminMutation represents the operations managing the API server.
+type AdminMutation {
+    # reindexFrom starts the chain replay from the given block to the current head
+    # to rebuild the indexed data. Only one chain replay can run at a time.
+    reindexFrom(block: Long!): Boolean!
+
+    # purgeCache removes the cached entries of the given key prefix
+    # and returns the number of removed entries. All the entries are removed
+    # if the prefix is empty.
+    purgeCache(prefix: String!): Long!
+
+    # setLogLevel sets the logging level of the given module, e.g. "scanner",
+    # "repository", "api" or "http". The global level is set if the module is empty.
+    # Known levels are CRITICAL, ERROR, WARNING, NOTICE, INFO and DEBUG.
+    setLogLevel(module: String!, level: String!): Boolean!
+}
+
+# ScannerStatus represents the state of the blockchain data scanner.
+type ScannerStatus {
+    # lastProcessedBlock is the number of the last block fully processed by the scanner.
+    lastProcessedBlock: Long!
+
+    # isReindexing indicates a chain reindex is in progress.
+    isReindexing: Boolean!
+
+    # reindexFrom is the first block of the recent chain reindex; zero if none.
+    reindexFrom: Long!
+
+    # reindexTo is the last block of the recent chain reindex; zero if none.
+    reindexTo: Long!
+
+    # reindexNext is the next block to be replayed by the recent chain reindex; zero if none.
+    reindexNext: Long!
+}
+
+# ScannerState represents the progress of the blockchain data indexing.
+type ScannerState {
+    # lastProcessedBlock is the number of the last block fully processed by the scanner.
+    lastProcessedBlock: Long!
+
+    # chainHead is the number of the most recent block observed on the chain.
+    chainHead: Long!
+
+    # blocksBehind is the number of blocks the processing is behind the chain head.
+    blocksBehind: Long!
+
+    # blocksPerSecond is the recent processing rate of the scanner.
+    blocksPerSecond: Float!
+
+    # catchUpTime is the estimated time in seconds the scanner needs
+    # to process the blocks behind the chain head; zero if the rate is not known yet.
+    catchUpTime: Long!
+
+    # isIdle indicates the scanner caught up with the chain and follows new blocks.
+    isIdle: Boolean!
+}
+
+# ContractDailyUsage represents a daily aggregation of the calls of a smart contract.
+type ContractDailyUsage {
+    # day represents the day of the aggregation in format YYYY-MM-DD
+    # i.e. 2021-01-23 for January 23rd, 2021
+    day: String!
+
+    # calls represents the number of calls of the contract made on the day.
+    calls: Long!
+
+    # callers represents the number of unique accounts calling the contract on the day.
+    callers: Long!
+
+    # gasUsed represents the amount of gas consumed by the calls of the contract on the day.
+    gasUsed: Long!
+}
+
+# ContractGasRank represents a smart contract ranked by the gas consumed by its calls
+# over the last 30 days.
+type ContractGasRank {
+    # address represents the address of the contract.
+    address: Address!
+
+    # contract represents the details of the contract.
+    contract: Contract!
+
+    # calls represents the number of calls of the contract.
+    calls: Long!
+
+    # gasUsed represents the amount of gas consumed by the calls of the contract.
+    gasUsed: Long!
+}
+
+# SignatureScheme represents the way a message is signed by a wallet.
+enum SignatureScheme {
+    # EIP-191 personal_sign of a text message.
+    PERSONAL_SIGN
+
+    # EIP-712 signature of typed structured data.
+    EIP712
 }
 
 `
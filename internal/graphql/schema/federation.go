@@ -0,0 +1,78 @@
+// Package gqlschema provides GraphQL schema definition used by GraphQL handler
+// to validate requests and build responses on the API interface.
+package gqlschema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// entityKeys represents the key fields of the entities resolvable by federation gateways.
+var entityKeys = map[string]string{
+	"Account":     "address",
+	"Block":       "number",
+	"Transaction": "hash",
+	"ERC20Token":  "address",
+}
+
+var (
+	// reFederationPlumbing represents the schema elements serving the federation gateway itself.
+	reFederationPlumbing = regexp.MustCompile(`^\s*(_service:|_entities\(|scalar _Any|union _Entity|type _Service )`)
+
+	// reSubscription represents the subscription root of the schema; federation gateways do not route subscriptions.
+	reSubscription = regexp.MustCompile(`(?ms)^\s*subscription: Subscription\n|^(#[^\n]*\n)*type Subscription \{.*?^\}\n`)
+
+	// reEntityType represents the definition of an entity type.
+	reEntityType = regexp.MustCompile(`(?m)^type (\w+) \{`)
+)
+
+var (
+	subgraphOnce sync.Once
+	subgraph     string
+)
+
+// Subgraph provides the schema annotated with entity keys for Apollo Federation gateways.
+// The federation plumbing and the subscriptions are not part of the provided schema.
+func Subgraph() string {
+	subgraphOnce.Do(func() {
+		subgraph = buildSubgraph(schema)
+	})
+	return subgraph
+}
+
+// buildSubgraph annotates the given schema with the federation entity keys.
+func buildSubgraph(sdl string) string {
+	sdl = reSubscription.ReplaceAllString(sdl, "")
+
+	// drop the federation plumbing; each element is a separate paragraph with its comments
+	parts := strings.Split(sdl, "\n\n")
+	keep := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if !reFederationPlumbing.MatchString(uncommented(p)) {
+			keep = append(keep, p)
+		}
+	}
+	sdl = strings.Join(keep, "\n\n")
+
+	// add the keys of the entities
+	return reEntityType.ReplaceAllStringFunc(sdl, func(def string) string {
+		name := reEntityType.FindStringSubmatch(def)[1]
+		if key, ok := entityKeys[name]; ok {
+			return fmt.Sprintf("type %s @key(fields: \"%s\") {", name, key)
+		}
+		return def
+	})
+}
+
+// uncommented provides the given schema paragraph without the leading comment lines.
+func uncommented(p string) string {
+	lines := strings.Split(strings.TrimLeft(p, "\n"), "\n")
+	for i, l := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(l), "#") {
+			return strings.Join(lines[i:], "\n")
+		}
+	}
+	return ""
+}
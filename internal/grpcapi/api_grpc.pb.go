@@ -0,0 +1,325 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: api.proto
+
+package grpcapi
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// FantomApiClient is the client API for FantomApi service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FantomApiClient interface {
+	// GetBlock provides a block by its number, or the latest block.
+	GetBlock(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Block, error)
+	// GetBlocks streams a range of blocks in the ascending order.
+	GetBlocks(ctx context.Context, in *BlockRangeRequest, opts ...grpc.CallOption) (FantomApi_GetBlocksClient, error)
+	// GetTransaction provides a transaction by its hash.
+	GetTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
+	// GetAccount provides the current state of an account.
+	GetAccount(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*Account, error)
+	// GetValidator provides a validator by its ID, or by its address if the ID is not set.
+	GetValidator(ctx context.Context, in *ValidatorRequest, opts ...grpc.CallOption) (*Validator, error)
+	// GetDelegations provides all the delegations of a delegator address.
+	GetDelegations(ctx context.Context, in *DelegationsRequest, opts ...grpc.CallOption) (*DelegationList, error)
+}
+
+type fantomApiClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFantomApiClient(cc grpc.ClientConnInterface) FantomApiClient {
+	return &fantomApiClient{cc}
+}
+
+func (c *fantomApiClient) GetBlock(ctx context.Context, in *BlockRequest, opts ...grpc.CallOption) (*Block, error) {
+	out := new(Block)
+	err := c.cc.Invoke(ctx, "/fantom.api.v1.FantomApi/GetBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fantomApiClient) GetBlocks(ctx context.Context, in *BlockRangeRequest, opts ...grpc.CallOption) (FantomApi_GetBlocksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &FantomApi_ServiceDesc.Streams[0], "/fantom.api.v1.FantomApi/GetBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fantomApiGetBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FantomApi_GetBlocksClient interface {
+	Recv() (*Block, error)
+	grpc.ClientStream
+}
+
+type fantomApiGetBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *fantomApiGetBlocksClient) Recv() (*Block, error) {
+	m := new(Block)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fantomApiClient) GetTransaction(ctx context.Context, in *TransactionRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, "/fantom.api.v1.FantomApi/GetTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fantomApiClient) GetAccount(ctx context.Context, in *AccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/fantom.api.v1.FantomApi/GetAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fantomApiClient) GetValidator(ctx context.Context, in *ValidatorRequest, opts ...grpc.CallOption) (*Validator, error) {
+	out := new(Validator)
+	err := c.cc.Invoke(ctx, "/fantom.api.v1.FantomApi/GetValidator", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fantomApiClient) GetDelegations(ctx context.Context, in *DelegationsRequest, opts ...grpc.CallOption) (*DelegationList, error) {
+	out := new(DelegationList)
+	err := c.cc.Invoke(ctx, "/fantom.api.v1.FantomApi/GetDelegations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FantomApiServer is the server API for FantomApi service.
+// All implementations must embed UnimplementedFantomApiServer
+// for forward compatibility
+type FantomApiServer interface {
+	// GetBlock provides a block by its number, or the latest block.
+	GetBlock(context.Context, *BlockRequest) (*Block, error)
+	// GetBlocks streams a range of blocks in the ascending order.
+	GetBlocks(*BlockRangeRequest, FantomApi_GetBlocksServer) error
+	// GetTransaction provides a transaction by its hash.
+	GetTransaction(context.Context, *TransactionRequest) (*Transaction, error)
+	// GetAccount provides the current state of an account.
+	GetAccount(context.Context, *AccountRequest) (*Account, error)
+	// GetValidator provides a validator by its ID, or by its address if the ID is not set.
+	GetValidator(context.Context, *ValidatorRequest) (*Validator, error)
+	// GetDelegations provides all the delegations of a delegator address.
+	GetDelegations(context.Context, *DelegationsRequest) (*DelegationList, error)
+	mustEmbedUnimplementedFantomApiServer()
+}
+
+// UnimplementedFantomApiServer must be embedded to have forward compatible implementations.
+type UnimplementedFantomApiServer struct {
+}
+
+func (UnimplementedFantomApiServer) GetBlock(context.Context, *BlockRequest) (*Block, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedFantomApiServer) GetBlocks(*BlockRangeRequest, FantomApi_GetBlocksServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetBlocks not implemented")
+}
+func (UnimplementedFantomApiServer) GetTransaction(context.Context, *TransactionRequest) (*Transaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedFantomApiServer) GetAccount(context.Context, *AccountRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccount not implemented")
+}
+func (UnimplementedFantomApiServer) GetValidator(context.Context, *ValidatorRequest) (*Validator, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValidator not implemented")
+}
+func (UnimplementedFantomApiServer) GetDelegations(context.Context, *DelegationsRequest) (*DelegationList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDelegations not implemented")
+}
+func (UnimplementedFantomApiServer) mustEmbedUnimplementedFantomApiServer() {}
+
+// UnsafeFantomApiServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FantomApiServer will
+// result in compilation errors.
+type UnsafeFantomApiServer interface {
+	mustEmbedUnimplementedFantomApiServer()
+}
+
+func RegisterFantomApiServer(s grpc.ServiceRegistrar, srv FantomApiServer) {
+	s.RegisterService(&FantomApi_ServiceDesc, srv)
+}
+
+func _FantomApi_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FantomApiServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fantom.api.v1.FantomApi/GetBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FantomApiServer).GetBlock(ctx, req.(*BlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FantomApi_GetBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BlockRangeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FantomApiServer).GetBlocks(m, &fantomApiGetBlocksServer{stream})
+}
+
+type FantomApi_GetBlocksServer interface {
+	Send(*Block) error
+	grpc.ServerStream
+}
+
+type fantomApiGetBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *fantomApiGetBlocksServer) Send(m *Block) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FantomApi_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FantomApiServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fantom.api.v1.FantomApi/GetTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FantomApiServer).GetTransaction(ctx, req.(*TransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FantomApi_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FantomApiServer).GetAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fantom.api.v1.FantomApi/GetAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FantomApiServer).GetAccount(ctx, req.(*AccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FantomApi_GetValidator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidatorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FantomApiServer).GetValidator(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fantom.api.v1.FantomApi/GetValidator",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FantomApiServer).GetValidator(ctx, req.(*ValidatorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FantomApi_GetDelegations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelegationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FantomApiServer).GetDelegations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fantom.api.v1.FantomApi/GetDelegations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FantomApiServer).GetDelegations(ctx, req.(*DelegationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FantomApi_ServiceDesc is the grpc.ServiceDesc for FantomApi service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FantomApi_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fantom.api.v1.FantomApi",
+	HandlerType: (*FantomApiServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBlock",
+			Handler:    _FantomApi_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _FantomApi_GetTransaction_Handler,
+		},
+		{
+			MethodName: "GetAccount",
+			Handler:    _FantomApi_GetAccount_Handler,
+		},
+		{
+			MethodName: "GetValidator",
+			Handler:    _FantomApi_GetValidator_Handler,
+		},
+		{
+			MethodName: "GetDelegations",
+			Handler:    _FantomApi_GetDelegations_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetBlocks",
+			Handler:       _FantomApi_GetBlocks_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api.proto",
+}
@@ -0,0 +1,384 @@
+// Package grpcapi implements the gRPC API server of the blockchain data.
+package grpcapi
+
+//go:generate protoc --proto_path=proto --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative api.proto
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/handlers"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"math/big"
+	"net"
+	"net/http"
+)
+
+const (
+	// blockRangeMaxCount represents the max number of blocks streamed by a single request.
+	blockRangeMaxCount = 10000
+
+	// blockRangeBatchSize represents the number of blocks loaded in a single batch while streaming.
+	blockRangeBatchSize = 100
+
+	// apiKeyMetadata represents the call metadata carrying the client API key.
+	apiKeyMetadata = "x-api-key"
+
+	// adminTokenMetadata represents the call metadata carrying the administrator token.
+	adminTokenMetadata = "x-admin-token"
+)
+
+// Server implements the gRPC API server sharing the repository with the GraphQL API.
+// The API keys and their usage limits are shared with the HTTP end-points.
+type Server struct {
+	UnimplementedFantomApiServer
+	cfg  *config.Config
+	log  logger.Logger
+	keys *handlers.ApiKeyHandler
+	srv  *grpc.Server
+}
+
+// New creates a new gRPC API server using the given usage state of the API clients.
+func New(cfg *config.Config, log logger.Logger, clients *handlers.ApiClients) *Server {
+	s := &Server{cfg: cfg, log: log, keys: handlers.NewApiKeyHandler(cfg, log, clients, nil)}
+	s.srv = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.intercept, s.limit),
+		grpc.StreamInterceptor(s.limitStream),
+	)
+	RegisterFantomApiServer(s.srv, s)
+	return s
+}
+
+// Run listens on the configured address and serves incoming calls until the server is closed.
+func (s *Server) Run() error {
+	l, err := net.Listen("tcp", s.cfg.Grpc.BindAddress)
+	if err != nil {
+		return err
+	}
+
+	s.log.Infof("listening for gRPC calls on %s", s.cfg.Grpc.BindAddress)
+	if err := s.srv.Serve(l); err != nil && err != grpc.ErrServerStopped {
+		return err
+	}
+	return nil
+}
+
+// Close stops the server waiting for the pending calls to finish.
+func (s *Server) Close() {
+	s.srv.GracefulStop()
+}
+
+// intercept logs failed unary calls.
+func (s *Server) intercept(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	res, err := handler(ctx, req)
+	if err != nil {
+		s.log.Debugf("gRPC call %s failed; %s", info.FullMethod, err.Error())
+	}
+	return res, err
+}
+
+// limit rejects unary calls without a valid API key, or over the usage limits of the client.
+func (s *Server) limit(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := s.admit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// limitStream rejects streaming calls without a valid API key, or over the usage limits of the client.
+// The stream is counted as a single call.
+func (s *Server) limitStream(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.admit(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &callStream{ServerStream: ss, ctx: ctx})
+}
+
+// admit checks the API key and the usage limits of the calling client.
+func (s *Server) admit(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var remote string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remote = p.Addr.String()
+	}
+
+	ctx, code, msg := s.keys.Admit(ctx, first(md, apiKeyMetadata), first(md, adminTokenMetadata), remote)
+	switch code {
+	case http.StatusOK:
+		return ctx, nil
+	case http.StatusUnauthorized:
+		return nil, status.Error(codes.Unauthenticated, msg)
+	case http.StatusTooManyRequests:
+		return nil, status.Error(codes.ResourceExhausted, msg)
+	}
+	return nil, status.Error(codes.Unavailable, msg)
+}
+
+// first provides the first value of the given call metadata key, if any.
+func first(md metadata.MD, key string) string {
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// callStream represents a server stream with the context of the admitted call.
+type callStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context provides the context of the admitted call.
+func (cs *callStream) Context() context.Context {
+	return cs.ctx
+}
+
+// failed converts the repository error into the gRPC status error.
+func (s *Server) failed(err error, what string) error {
+	switch err {
+	case repository.ErrBlockNotFound, repository.ErrTransactionNotFound:
+		return status.Errorf(codes.NotFound, "%s not found", what)
+	}
+
+	s.log.Errorf("can not get %s; %s", what, err.Error())
+	return status.Errorf(codes.Internal, "%s not available", what)
+}
+
+// GetBlock provides a block by its number, or the latest block.
+func (s *Server) GetBlock(ctx context.Context, req *BlockRequest) (*Block, error) {
+	var num *hexutil.Uint64
+	if !req.Latest {
+		num = (*hexutil.Uint64)(&req.Number)
+	}
+
+	blk, err := repository.R().BlockByNumber(ctx, num)
+	if err != nil {
+		return nil, s.failed(err, "block")
+	}
+	return toBlock(blk), nil
+}
+
+// GetBlocks streams a range of blocks in the ascending order.
+// The stream ends with the last block known to the chain.
+func (s *Server) GetBlocks(req *BlockRangeRequest, stream FantomApi_GetBlocksServer) error {
+	if req.Count == 0 || req.Count > blockRangeMaxCount {
+		return status.Errorf(codes.InvalidArgument, "count must be between 1 and %d", blockRangeMaxCount)
+	}
+
+	for from, end := req.From, req.From+uint64(req.Count); from < end; from += blockRangeBatchSize {
+		nums := make([]uint64, 0, blockRangeBatchSize)
+		for n := from; n < end && len(nums) < blockRangeBatchSize; n++ {
+			nums = append(nums, n)
+		}
+
+		list, err := repository.R().BlocksByNumbers(stream.Context(), nums)
+		if err != nil {
+			return s.failed(err, "block")
+		}
+
+		for _, blk := range list {
+			if blk == nil {
+				return nil
+			}
+			if err := stream.Send(toBlock(blk)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GetTransaction provides a transaction by its hash.
+func (s *Server) GetTransaction(ctx context.Context, req *TransactionRequest) (*Transaction, error) {
+	if len(req.Hash) != common.HashLength {
+		return nil, status.Error(codes.InvalidArgument, "invalid transaction hash")
+	}
+
+	hash := common.BytesToHash(req.Hash)
+	trx, err := repository.R().Transaction(ctx, &hash)
+	if err != nil {
+		return nil, s.failed(err, "transaction")
+	}
+	return toTransaction(trx), nil
+}
+
+// GetAccount provides the current state of an account.
+func (s *Server) GetAccount(ctx context.Context, req *AccountRequest) (*Account, error) {
+	if len(req.Address) != common.AddressLength {
+		return nil, status.Error(codes.InvalidArgument, "invalid account address")
+	}
+
+	adr := common.BytesToAddress(req.Address)
+	acc, err := repository.R().Account(ctx, &adr)
+	if err != nil {
+		return nil, s.failed(err, "account")
+	}
+
+	bal, err := repository.R().AccountBalance(ctx, &adr)
+	if err != nil {
+		return nil, s.failed(err, "account balance")
+	}
+
+	nonce, err := repository.R().AccountNonce(ctx, &adr)
+	if err != nil {
+		return nil, s.failed(err, "account nonce")
+	}
+
+	res := &Account{
+		Address:      acc.Address.Bytes(),
+		Type:         acc.Type,
+		Balance:      bigBytes(bal),
+		Nonce:        uint64(*nonce),
+		TxCount:      uint64(acc.TrxCounter),
+		LastActivity: uint64(acc.LastActivity),
+	}
+	if acc.ContractTx != nil {
+		res.ContractTx = acc.ContractTx.Bytes()
+	}
+	return res, nil
+}
+
+// GetValidator provides a validator by its ID, or by its address if the ID is not set.
+func (s *Server) GetValidator(ctx context.Context, req *ValidatorRequest) (*Validator, error) {
+	var val *types.Validator
+	var err error
+
+	switch {
+	case req.Id > 0:
+		val, err = repository.R().Validator(ctx, (*hexutil.Big)(new(big.Int).SetUint64(req.Id)))
+	case len(req.Address) == common.AddressLength:
+		adr := common.BytesToAddress(req.Address)
+		val, err = repository.R().ValidatorByAddress(ctx, &adr)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "validator ID or address expected")
+	}
+
+	if err != nil {
+		return nil, s.failed(err, "validator")
+	}
+	if val == nil {
+		return nil, status.Error(codes.NotFound, "validator not found")
+	}
+
+	return &Validator{
+		Id:               val.Id.ToInt().Uint64(),
+		Address:          val.StakerAddress.Bytes(),
+		TotalStake:       bigBytes(val.TotalStake),
+		Status:           uint64(val.Status),
+		CreatedEpoch:     uint64(val.CreatedEpoch),
+		CreatedTime:      uint64(val.CreatedTime),
+		DeactivatedEpoch: uint64(val.DeactivatedEpoch),
+		DeactivatedTime:  uint64(val.DeactivatedTime),
+	}, nil
+}
+
+// GetDelegations provides all the delegations of a delegator address.
+func (s *Server) GetDelegations(ctx context.Context, req *DelegationsRequest) (*DelegationList, error) {
+	if len(req.Address) != common.AddressLength {
+		return nil, status.Error(codes.InvalidArgument, "invalid delegator address")
+	}
+
+	adr := common.BytesToAddress(req.Address)
+	list, err := repository.R().DelegationsByAddressAll(ctx, &adr)
+	if err != nil {
+		return nil, s.failed(err, "delegations")
+	}
+
+	res := &DelegationList{Delegations: make([]*Delegation, len(list))}
+	for i, dl := range list {
+		res.Delegations[i] = &Delegation{
+			Address:         dl.Address.Bytes(),
+			ValidatorId:     dl.ToStakerId.ToInt().Uint64(),
+			AmountStaked:    bigBytes(dl.AmountStaked),
+			AmountDelegated: bigBytes(dl.AmountDelegated),
+			CreatedTime:     uint64(dl.CreatedTime),
+			Transaction:     dl.Transaction.Bytes(),
+		}
+	}
+	return res, nil
+}
+
+// toBlock converts the block into the gRPC message.
+func toBlock(blk *types.Block) *Block {
+	res := &Block{
+		Number:       uint64(blk.Number),
+		Hash:         blk.Hash.Bytes(),
+		ParentHash:   blk.ParentHash.Bytes(),
+		Miner:        blk.Miner.Bytes(),
+		GasLimit:     uint64(blk.GasLimit),
+		GasUsed:      uint64(blk.GasUsed),
+		Timestamp:    uint64(blk.TimeStamp),
+		Size:         uint64(blk.Size),
+		Transactions: make([][]byte, len(blk.Txs)),
+	}
+	for i, h := range blk.Txs {
+		res.Transactions[i] = h.Bytes()
+	}
+	return res
+}
+
+// toTransaction converts the transaction into the gRPC message.
+func toTransaction(trx *types.Transaction) *Transaction {
+	res := &Transaction{
+		Hash:     trx.Hash.Bytes(),
+		From:     trx.From.Bytes(),
+		Value:    trx.Value.ToInt().Bytes(),
+		Gas:      uint64(trx.Gas),
+		GasPrice: trx.GasPrice.ToInt().Bytes(),
+		Nonce:    uint64(trx.Nonce),
+		Input:    trx.InputData,
+	}
+
+	if trx.BlockHash != nil {
+		res.BlockHash = trx.BlockHash.Bytes()
+	}
+	if trx.BlockNumber != nil {
+		res.BlockNumber = uint64(*trx.BlockNumber)
+	}
+	if trx.To != nil {
+		res.To = trx.To.Bytes()
+	}
+	if trx.ContractAddress != nil {
+		res.Contract = trx.ContractAddress.Bytes()
+	}
+	if trx.GasUsed != nil {
+		res.GasUsed = uint64(*trx.GasUsed)
+	}
+	if trx.TrxIndex != nil {
+		res.Index = uint64(*trx.TrxIndex)
+	}
+	if trx.RevertReason != nil {
+		res.RevertReason = *trx.RevertReason
+	}
+	if !trx.TimeStamp.IsZero() {
+		res.Timestamp = uint64(trx.TimeStamp.Unix())
+	}
+
+	// 0 = pending, 1 = success, 2 = failed
+	if trx.Status != nil {
+		res.Status = 2
+		if uint64(*trx.Status) == types.TransactionStatusSuccess {
+			res.Status = 1
+		}
+	}
+	return res
+}
+
+// bigBytes provides the big-endian bytes of the given amount.
+func bigBytes(val *hexutil.Big) []byte {
+	if val == nil {
+		return nil
+	}
+	return val.ToInt().Bytes()
+}
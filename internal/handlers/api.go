@@ -8,36 +8,116 @@ import (
 	"fantom-api-graphql/internal/logger"
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
-	"github.com/graph-gophers/graphql-transport-ws/graphqlws"
+	"github.com/graph-gophers/graphql-go/trace"
 	"github.com/rs/cors"
 	"net/http"
+	"strings"
 )
 
 // Api constructs and return the API HTTP handlers chain for serving GraphQL API calls.
-func Api(cfg *config.Config, log logger.Logger, rs resolvers.ApiResolver) http.Handler {
+// The API key limits are applied with the given usage state of the clients.
+func Api(cfg *config.Config, log logger.Logger, rs resolvers.ApiResolver, clients *ApiClients) http.Handler {
 	// Create new CORS handler and attach the logger into it so we get information on Debug level if needed
-	corsHandler := cors.New(corsOptions(cfg))
+	corsHandler := cors.New(corsOptions(cfg, log))
 	corsHandler.Log = log
 
 	// we don't want to write a method for each type field if it could be matched directly
-	opts := []graphql.SchemaOpt{graphql.UseFieldResolvers()}
+	// resolvers are traced for the metrics and the distributed tracing
+	// executed operations are recorded in the audit log, if enabled
+	var tracer trace.Tracer = metricsTracer{}
+	if cfg.Server.Audit.Enabled {
+		tracer = newAuditTracer(log)
+	}
+	opts := []graphql.SchemaOpt{graphql.UseFieldResolvers(), graphql.Tracer(tracer)}
+
+	// limit the depth of incoming queries, if configured
+	if cfg.Server.MaxQueryDepth > 0 {
+		opts = append(opts, graphql.MaxDepth(cfg.Server.MaxQueryDepth))
+	}
 
 	// create new parsed GraphQL schema
 	schema := graphql.MustParseSchema(gqlSchema.Schema(), rs, opts...)
 
+	// build the GraphQL request chain; clients with invalid tokens, clients over their limits
+	// and too complex queries are rejected before execution, persisted queries are resolved first
+	// responses of read-only queries are tagged for the conditional requests,
+	// queries sent by the GET method are passed down the chain as POST requests
+	var h http.Handler = &LoadersHandler{handler: &relay.Handler{Schema: schema}}
+	h = &ETagHandler{handler: h}
+	cx := &ComplexityHandler{
+		logger:    log,
+		maxCost:   cfg.Server.MaxQueryCost,
+		listSizes: queryListSizes(gqlSchema.Schema()),
+		usage:     newUsageMeter(log),
+		handler:   h,
+	}
+	pq := NewPersistedQueryHandler(&cfg.Server.Queries, log, cx)
+	h = NewCompressionHandler(&cfg.Server.Compression, &GetQueryHandler{handler: pq})
+
+	// subscriptions are checked the same way as the HTTP requests, the WebSocket connection
+	// is opened by clients passing the API key and the authentication checks
+	h = &SubscriptionHandler{schema: schema, queries: pq, complexity: cx, handler: h}
+	h = NewApiKeyHandler(cfg, log, clients, h)
+	h = NewAuthHandler(&cfg.Server.Auth, log, h)
+	h = &TracingHandler{handler: h}
+
 	// return the constructed API handler chain
 	return &LoggingHandler{
+		logger: log,
+		handler: &SecurityHeadersHandler{
+			strict:  cfg.Server.StrictMode,
+			handler: corsHandler.Handler(h),
+		},
+	}
+}
+
+// Export constructs the HTTP handlers chain of the account history export. The export is limited
+// by the API keys with the given usage state of the clients and accounted the same way the GraphQL API is.
+func Export(cfg *config.Config, log logger.Logger, clients *ApiClients) http.Handler {
+	var h http.Handler = &exportHandler{
 		logger:  log,
-		handler: corsHandler.Handler(graphqlws.NewHandlerFunc(schema, &relay.Handler{Schema: schema})),
+		maxRows: cfg.Server.ExportMaxRows,
+		usage:   newUsageMeter(log),
+	}
+	h = NewApiKeyHandler(cfg, log, clients, h)
+	h = NewAuthHandler(&cfg.Server.Auth, log, h)
+	h = &TracingHandler{handler: h}
+
+	return &LoggingHandler{
+		logger: log,
+		handler: &SecurityHeadersHandler{
+			strict:  cfg.Server.StrictMode,
+			handler: h,
+		},
 	}
 }
 
 // corsOptions constructs new set of options for the CORS handler based on provided configuration.
-func corsOptions(cfg *config.Config) cors.Options {
-	return cors.Options{
-		AllowedOrigins: cfg.Server.CorsOrigin,
-		AllowedMethods: []string{"HEAD", "GET", "POST"},
-		AllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Requested-With"},
-		MaxAge:         300,
+// The strict mode does not allow any origin by the wildcard, the origins must be listed explicitly.
+func corsOptions(cfg *config.Config, log logger.Logger) cors.Options {
+	origins := cfg.Server.CorsOrigin
+	if cfg.Server.StrictMode {
+		origins = make([]string, 0, len(cfg.Server.CorsOrigin))
+		for _, o := range cfg.Server.CorsOrigin {
+			if strings.Contains(o, "*") {
+				log.Warningf("CORS origin %s ignored in strict mode", o)
+				continue
+			}
+			origins = append(origins, o)
+		}
+	}
+
+	opt := cors.Options{
+		AllowedOrigins: origins,
+		AllowedMethods: cfg.Server.CorsMethods,
+		AllowedHeaders: []string{"Origin", "Accept", "Content-Type", "X-Requested-With", "If-None-Match", apiKeyHeader, authHeader},
+		ExposedHeaders: []string{"ETag"},
+		MaxAge:         cfg.Server.CorsMaxAge,
+	}
+
+	// the CORS handler allows any origin if none is listed; the strict mode denies all of them instead
+	if cfg.Server.StrictMode && len(origins) == 0 {
+		opt.AllowOriginFunc = func(string) bool { return false }
 	}
+	return opt
 }
@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	flogger "fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"golang.org/x/time/rate"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// apiKeyHeader represents the HTTP header carrying the client API key.
+	apiKeyHeader = "X-Api-Key"
+
+	// adminTokenHeader represents the HTTP header carrying the administrator token.
+	adminTokenHeader = "X-Admin-Token"
+
+	// apiKeyRefreshPeriod represents the period after which a known API key is re-loaded,
+	// so the key revocation is applied on running servers.
+	apiKeyRefreshPeriod = time.Minute
+
+	// apiClientIdleLimit represents the time after which an idle client is forgotten.
+	apiClientIdleLimit = 15 * time.Minute
+
+	// apiClientsPruneSize represents the number of known clients triggering removal of idle clients.
+	apiClientsPruneSize = 10000
+)
+
+// ApiKeyHandler defines HTTP handler middleware enforcing the API keys usage limits.
+// Requests with a valid API key are limited by the rate and the daily quota of the key;
+// anonymous requests are limited by the remote address, if configured.
+type ApiKeyHandler struct {
+	logger  flogger.Logger
+	cfg     *config.ApiKeys
+	tunable func() *config.Tunables
+	handler http.Handler
+	*ApiClients
+}
+
+// ApiClients represents the usage state of the API clients. The state is shared
+// by the handlers of all the API end-points, so the limits of a client apply to all of them together.
+type ApiClients struct {
+	mu      sync.Mutex
+	clients map[string]*apiClient
+}
+
+// apiClient represents the usage state of a single API client.
+type apiClient struct {
+	key     *types.ApiKey
+	limiter *rate.Limiter
+	loaded  time.Time
+	seen    time.Time
+	day     int64
+	used    int64
+}
+
+// NewApiClients creates a new empty usage state of the API clients.
+func NewApiClients() *ApiClients {
+	return &ApiClients{clients: make(map[string]*apiClient)}
+}
+
+// NewApiKeyHandler creates a new API key handler middleware using the given usage state of the clients.
+func NewApiKeyHandler(cfg *config.Config, log flogger.Logger, clients *ApiClients, h http.Handler) *ApiKeyHandler {
+	return &ApiKeyHandler{
+		logger:     log,
+		cfg:        &cfg.Server.ApiKeys,
+		tunable:    cfg.Tunable,
+		handler:    h,
+		ApiClients: clients,
+	}
+}
+
+// ServeHTTP handles incoming request by checking the client usage limits
+// and passing the request to the next handler in the chain, if allowed.
+func (h *ApiKeyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// the administrator is not limited; it may be authenticated by a bearer token already
+	if _, admin := resolvers.RequestClient(r.Context()); admin || h.isAdmin(r.Header.Get(adminTokenHeader)) {
+		h.handler.ServeHTTP(w, r.WithContext(resolvers.WithAdmin(r.Context())))
+		return
+	}
+
+	ctx, status, msg := h.admit(r.Context(), r.Header.Get(apiKeyHeader), r.RemoteAddr)
+	if status != http.StatusOK {
+		var code string
+		if status == http.StatusTooManyRequests {
+			code = types.ErrCodeRateLimited
+		}
+		writeQueryError(w, status, code, msg)
+		return
+	}
+	h.handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// Admit checks the usage limits of a call received outside of the HTTP chain, e.g. by the gRPC API.
+// The caller is identified by the API key, or by the remote address if the key is empty;
+// the administrator token lifts the limits. The context of the call is provided
+// if the call is allowed, the HTTP status and the reason of the rejection otherwise.
+func (h *ApiKeyHandler) Admit(ctx context.Context, key string, token string, remote string) (context.Context, int, string) {
+	if h.isAdmin(token) {
+		return resolvers.WithAdmin(ctx), http.StatusOK, ""
+	}
+	return h.admit(ctx, key, remote)
+}
+
+// admit checks the usage limits of the client with the given API key,
+// or of the anonymous client calling from the given remote address.
+func (h *ApiKeyHandler) admit(ctx context.Context, key string, remote string) (context.Context, int, string) {
+	var cl *apiClient
+	if key == "" {
+		if h.cfg.Required {
+			return ctx, http.StatusUnauthorized, "API key required"
+		}
+
+		// anonymous calls may not be limited at all
+		if cl = h.anonymous(remote); cl == nil {
+			return ctx, http.StatusOK, ""
+		}
+	} else {
+		var err error
+		cl, err = h.client(ctx, key)
+		if err != nil {
+			return ctx, http.StatusServiceUnavailable, "API key can not be verified"
+		}
+		if cl == nil {
+			return ctx, http.StatusUnauthorized, "invalid API key"
+		}
+
+		// resolvers may need to know the client, e.g. to manage the resources it owns
+		ctx = resolvers.WithApiKey(ctx, types.ApiKeyHash(key))
+	}
+
+	if msg := h.use(cl); msg != "" {
+		return ctx, http.StatusTooManyRequests, msg
+	}
+	return ctx, http.StatusOK, ""
+}
+
+// anonymous provides the usage state of an anonymous client calling from the given remote address.
+// Nil is returned if the anonymous calls are not limited.
+func (h *ApiKeyHandler) anonymous(remote string) *apiClient {
+	perMinute := h.tunable().AnonymousRate
+	if perMinute <= 0 {
+		return nil
+	}
+
+	host, _, err := net.SplitHostPort(remote)
+	if err != nil {
+		host = remote
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	cl, ok := h.clients[host]
+	if !ok {
+		cl = &apiClient{limiter: newLimiter(int32(perMinute))}
+		h.add(host, cl)
+	}
+
+	// the configured rate may have changed on the configuration reload
+	if cl.limiter.Burst() != perMinute {
+		cl.limiter = newLimiter(int32(perMinute))
+	}
+	return cl
+}
+
+// use counts the request on the rate limit and the daily quota of the client.
+// The reason of the rejection is returned if the client is over its limits.
+// Anonymous clients have no daily quota.
+func (h *ApiKeyHandler) use(cl *apiClient) string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	cl.seen = now
+
+	if !cl.limiter.AllowN(now, 1) {
+		return "rate limit exceeded"
+	}
+
+	if day := now.Unix() / 86400; cl.day != day {
+		cl.day = day
+		cl.used = 0
+	}
+	if cl.key != nil && cl.key.DailyQuota > 0 && cl.used >= cl.key.DailyQuota {
+		return "daily quota exceeded"
+	}
+	cl.used++
+	return ""
+}
+
+// client provides the usage state of the client with the given API key.
+// Nil is returned for unknown or revoked keys. Unknown keys are remembered
+// as clients without a key, so they do not hit the database on every request.
+func (h *ApiKeyHandler) client(ctx context.Context, key string) (*apiClient, error) {
+	id := types.ApiKeyHash(key)
+
+	// the state of the client is changed by concurrent requests; read it under the lock
+	h.mu.Lock()
+	cl, ok := h.clients[id]
+	fresh := ok && time.Since(cl.loaded) < apiKeyRefreshPeriod
+	active := fresh && cl.key != nil && cl.key.IsActive()
+	h.mu.Unlock()
+
+	if fresh {
+		if !active {
+			return nil, nil
+		}
+		return cl, nil
+	}
+
+	// load the key; keep the usage state of a known client
+	ak, err := repository.R().ApiKey(ctx, key)
+	if err != nil {
+		h.logger.Errorf("can not load API key; %s", err.Error())
+		return nil, err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if cl, ok = h.clients[id]; !ok {
+		cl = &apiClient{}
+		h.add(id, cl)
+	}
+	cl.loaded = time.Now()
+
+	if ak == nil {
+		cl.key, cl.limiter = nil, nil
+		return nil, nil
+	}
+	if cl.key == nil || cl.key.RateLimit != ak.RateLimit {
+		cl.limiter = newLimiter(ak.RateLimit)
+	}
+	cl.key = ak
+
+	if !ak.IsActive() {
+		return nil, nil
+	}
+	return cl, nil
+}
+
+// add registers a new client removing idle clients if there are too many.
+// The caller is expected to hold the lock.
+func (h *ApiKeyHandler) add(id string, cl *apiClient) {
+	if len(h.clients) >= apiClientsPruneSize {
+		for k, c := range h.clients {
+			// unknown keys are forgotten as soon as they would be re-loaded anyway
+			if time.Since(c.seen) > apiClientIdleLimit || (c.limiter == nil && time.Since(c.loaded) > apiKeyRefreshPeriod) {
+				delete(h.clients, k)
+			}
+		}
+	}
+
+	cl.seen = time.Now()
+	h.clients[id] = cl
+}
+
+// isAdmin checks if the given token is the configured administrator token.
+func (h *ApiKeyHandler) isAdmin(token string) bool {
+	return h.cfg.AdminToken != "" && token != "" &&
+		subtle.ConstantTimeCompare([]byte(token), []byte(h.cfg.AdminToken)) == 1
+}
+
+// newLimiter creates a rate limiter of the given number of requests per minute.
+func newLimiter(perMinute int32) *rate.Limiter {
+	return rate.NewLimiter(rate.Limit(float64(perMinute)/60), int(perMinute))
+}
@@ -0,0 +1,94 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	flogger "fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/introspection"
+	"github.com/graph-gophers/graphql-go/trace"
+	"time"
+)
+
+// auditQueueLength represents the capacity of the audit records queue;
+// records are dropped if the storage can not keep up with the traffic.
+const auditQueueLength = 1000
+
+// auditTracer implements GraphQL tracer recording each executed operation
+// into the audit log. The metrics and the trace spans are collected by the embedded tracer.
+type auditTracer struct {
+	metricsTracer
+	logger flogger.Logger
+	queue  chan *types.AuditRecord
+}
+
+// newAuditTracer creates a new audit tracer and starts the audit records writer.
+func newAuditTracer(log flogger.Logger) *auditTracer {
+	at := &auditTracer{
+		logger: log,
+		queue:  make(chan *types.AuditRecord, auditQueueLength),
+	}
+	go at.write()
+	return at
+}
+
+// TraceQuery traces a GraphQL query execution and records it in the audit log.
+func (at *auditTracer) TraceQuery(ctx context.Context, queryString string, operationName string, variables map[string]interface{}, varTypes map[string]*introspection.Type) (context.Context, trace.TraceQueryFinishFunc) {
+	ctx, finish := at.metricsTracer.TraceQuery(ctx, queryString, operationName, variables, varTypes)
+
+	start := time.Now()
+	client, admin := resolvers.RequestClient(ctx)
+	return ctx, func(errs []*errors.QueryError) {
+		finish(errs)
+
+		rec := &types.AuditRecord{
+			Operation:     operationName,
+			VariablesHash: variablesHash(variables),
+			Client:        client,
+			IsAdmin:       admin,
+			Duration:      time.Since(start).Milliseconds(),
+			Executed:      time.Now().UTC(),
+		}
+		for _, err := range errs {
+			rec.Errors = append(rec.Errors, err.Message)
+		}
+
+		select {
+		case at.queue <- rec:
+		default:
+			at.logger.Warningf("audit log queue full, operation %s not recorded", operationName)
+		}
+	}
+}
+
+// write stores the queued audit records.
+func (at *auditTracer) write() {
+	for rec := range at.queue {
+		if err := repository.R().StoreAuditRecord(context.Background(), rec); err != nil {
+			at.logger.Errorf("can not record operation %s; %s", rec.Operation, err.Error())
+		}
+	}
+}
+
+// variablesHash provides the SHA-256 hash of the operation variables;
+// the variables themselves are not recorded since they may carry sensitive data.
+func variablesHash(vars map[string]interface{}) string {
+	if len(vars) == 0 {
+		return ""
+	}
+
+	// map keys are sorted by the encoder, so the hash is stable
+	data, err := json.Marshal(vars)
+	if err != nil {
+		return ""
+	}
+
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
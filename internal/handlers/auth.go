@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	flogger "fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/types"
+	"net/http"
+	"strings"
+)
+
+// authHeader represents the HTTP header carrying the JWT bearer token.
+const authHeader = "Authorization"
+
+// AuthHandler defines HTTP handler middleware authenticating clients by JWT bearer tokens.
+// Requests without a token pass as they are, so the read queries stay public; the roles
+// of an authenticated client are passed to the resolvers guarding the privileged operations.
+type AuthHandler struct {
+	logger   flogger.Logger
+	cfg      *config.Auth
+	verifier *jwtVerifier
+	handler  http.Handler
+}
+
+// NewAuthHandler creates a new JWT authentication handler middleware; the next handler
+// is returned unchanged if the authentication is disabled.
+func NewAuthHandler(cfg *config.Auth, log flogger.Logger, h http.Handler) http.Handler {
+	if !cfg.Enabled {
+		return h
+	}
+
+	v, err := newJwtVerifier(cfg.Issuer, cfg.Audience, cfg.Secret, cfg.KeyFiles)
+	if err != nil {
+		log.Fatalf("can not setup JWT authentication; %s", err.Error())
+	}
+	return &AuthHandler{logger: log, cfg: cfg, verifier: v, handler: h}
+}
+
+// ServeHTTP handles incoming request by validating the bearer token, if any,
+// and passing the request with the client roles to the next handler in the chain.
+func (h *AuthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get(authHeader)
+	if auth == "" {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	if len(auth) < 7 || !strings.EqualFold(auth[:7], "bearer ") {
+		writeQueryError(w, http.StatusUnauthorized, types.ErrCodeUnauthorized, "bearer token expected")
+		return
+	}
+
+	claims, err := h.verifier.verify(strings.TrimSpace(auth[7:]))
+	if err != nil {
+		h.logger.Debugf("bearer token rejected; %s", err.Error())
+		writeQueryError(w, http.StatusUnauthorized, types.ErrCodeUnauthorized, err.Error())
+		return
+	}
+
+	roles := claimStrings(claims[h.cfg.RolesClaim])
+	ctx := resolvers.WithRoles(r.Context(), roles)
+	if containsString(roles, h.cfg.AdminRole) {
+		ctx = resolvers.WithAdmin(ctx)
+	}
+	h.handler.ServeHTTP(w, r.WithContext(ctx))
+}
@@ -0,0 +1,532 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	flogger "fantom-api-graphql/internal/logger"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+)
+
+// queryCostMaxListSize represents the max number of list items a single field can resolve;
+// it's used for list sizes not known before the query execution.
+const queryCostMaxListSize = 250
+
+// queryCostListArgument represents the name of the field argument defining the list size.
+const queryCostListArgument = "count"
+
+// queryMaxBodySize represents the max size of the GraphQL request body in bytes.
+const queryMaxBodySize = 1 << 20
+
+// queryListArguments matches field arguments in the schema definition.
+var queryListArguments = regexp.MustCompile(`(\w+)\s*\(([^)]*)\)`)
+
+// queryListDefault matches the default value of the list size argument in the schema definition.
+var queryListDefault = regexp.MustCompile(`\b` + queryCostListArgument + `\s*:\s*Int\s*=\s*(\d+)`)
+
+// ComplexityHandler defines HTTP handler middleware rejecting GraphQL queries
+// with estimated cost above the configured limit. The cost of a query is the number
+// of fields it resolves; each field is multiplied by the sizes of all the lists it's nested in.
+// The cost of the executed queries is accounted to the API key of the client, if any.
+// Requests the cost can not be estimated for are rejected.
+type ComplexityHandler struct {
+	logger    flogger.Logger
+	maxCost   int
+	listSizes map[string]float64
+	usage     *usageMeter
+	handler   http.Handler
+}
+
+// queryRequest represents the GraphQL request body.
+type queryRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// ServeHTTP handles incoming request by estimating the query cost
+// and passing the request to the next handler in the chain, if the cost is acceptable.
+func (h *ComplexityHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Body != nil {
+		r.Body = http.MaxBytesReader(w, r.Body, queryMaxBodySize)
+	}
+
+	client, _ := resolvers.RequestClient(r.Context())
+	if h.maxCost <= 0 && client == "" {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	if r.Method != http.MethodPost || r.Body == nil {
+		writeQueryError(w, http.StatusMethodNotAllowed, "", "query cost can not be estimated; POST request expected")
+		return
+	}
+
+	// read the request; the body is restored for the next handler
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeQueryError(w, http.StatusBadRequest, "", err.Error())
+		return
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	var req queryRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeQueryError(w, http.StatusBadRequest, "", fmt.Sprintf("query cost can not be estimated; %s", err.Error()))
+		return
+	}
+
+	if err := h.check(client, &req); err != nil {
+		writeQueryError(w, http.StatusOK, "", err.Error())
+		return
+	}
+	h.handler.ServeHTTP(w, r)
+}
+
+// check estimates the cost of the query and accounts it to the client, if any.
+// An error is returned if the cost can not be estimated, or if it exceeds the limit.
+func (h *ComplexityHandler) check(client string, req *queryRequest) error {
+	if h.maxCost <= 0 && client == "" {
+		return nil
+	}
+
+	// the cost is estimated in full for the accounting if there is no limit
+	limit := h.maxCost
+	if limit <= 0 {
+		limit = math.MaxInt32
+	}
+
+	cost, err := queryCost(req.Query, req.OperationName, req.Variables, limit, h.listSizes)
+	if err != nil {
+		return fmt.Errorf("query cost can not be estimated; %s", err.Error())
+	}
+
+	if h.maxCost > 0 && cost > h.maxCost {
+		h.logger.Warningf("query %s rejected; cost %d exceeds the limit %d", req.OperationName, cost, h.maxCost)
+		return fmt.Errorf("query cost %d exceeds the limit of %d", cost, h.maxCost)
+	}
+
+	if client != "" {
+		h.usage.add(client, cost)
+	}
+	return nil
+}
+
+// queryListSizes provides the default list sizes of the fields of the given schema definition;
+// the default is the default value of the list size argument of the field, if any.
+// Fields of the same name in different types use the largest default.
+func queryListSizes(schema string) map[string]float64 {
+	sizes := make(map[string]float64)
+	for _, m := range queryListArguments.FindAllStringSubmatch(schema, -1) {
+		def := queryListDefault.FindStringSubmatch(m[2])
+		if def == nil {
+			continue
+		}
+
+		size, err := strconv.ParseFloat(def[1], 64)
+		if err != nil || size <= sizes[m[1]] {
+			continue
+		}
+		if size > queryCostMaxListSize {
+			size = queryCostMaxListSize
+		}
+		sizes[m[1]] = size
+	}
+	return sizes
+}
+
+// writeQueryError responds with a GraphQL error of the given message and HTTP status.
+// The error code, if any, is added to the error extensions.
+func writeQueryError(w http.ResponseWriter, status int, code string, msg string) {
+	qe := map[string]interface{}{"message": msg}
+	if code != "" {
+		qe["extensions"] = map[string]string{"code": code}
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"errors": []map[string]interface{}{qe},
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+// costToken represents a lexical token of a GraphQL document.
+// The kind is the punctuator character, 'n' for names, 'v' for values and zero at the end.
+type costToken struct {
+	kind byte
+	val  string
+}
+
+// costParser implements a minimal GraphQL document parser estimating the query cost.
+type costParser struct {
+	src       string
+	pos       int
+	tok       costToken
+	vars      map[string]interface{}
+	fragments map[string]int
+	visiting  map[string]bool
+	sizes     map[string]float64
+	limit     float64
+}
+
+// queryCost estimates the cost of the given operation of a GraphQL document.
+// Fields with the list size argument not specified resolve lists of the given default sizes.
+// The estimation stops once the given limit is reached.
+func queryCost(doc string, opName string, vars map[string]interface{}, limit int, sizes map[string]float64) (cost int, err error) {
+	p := &costParser{
+		src:       doc,
+		vars:      vars,
+		fragments: make(map[string]int),
+		visiting:  make(map[string]bool),
+		sizes:     sizes,
+		limit:     float64(limit),
+	}
+
+	// the parser panics on unexpected input
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid query; %v", r)
+		}
+	}()
+
+	op, err := p.definitions(opName)
+	if err != nil {
+		return 0, err
+	}
+
+	p.seek(op)
+	c := p.selectionSet(1)
+	if c > math.MaxInt32 {
+		return math.MaxInt32, nil
+	}
+	return int(c), nil
+}
+
+// definitions scans the document definitions and provides the position of the selection set
+// of the requested operation. Positions of fragment selection sets are collected on the way.
+func (p *costParser) definitions(opName string) (int, error) {
+	op := -1
+	p.next()
+	for p.tok.kind != 0 {
+		switch {
+		case p.tok.kind == '{':
+			if op < 0 && opName == "" {
+				op = p.pos - 1
+			}
+			p.skipBlock()
+		case p.tok.kind == 'n' && p.tok.val == "fragment":
+			p.next()
+			name := p.expect('n').val
+			p.expect('n') // on
+			p.expect('n') // type condition
+			p.directives()
+			p.fragments[name] = p.pos - 1
+			p.skipBlock()
+		case p.tok.kind == 'n':
+			p.next()
+			name := ""
+			if p.tok.kind == 'n' {
+				name = p.tok.val
+				p.next()
+			}
+			if p.tok.kind == '(' {
+				p.skipBlock()
+			}
+			p.directives()
+			if op < 0 && (opName == "" || opName == name) {
+				op = p.pos - 1
+			}
+			p.skipBlock()
+		default:
+			return 0, fmt.Errorf("unexpected %q", p.tok.val)
+		}
+	}
+
+	if op < 0 {
+		return 0, fmt.Errorf("operation %s not found", opName)
+	}
+	return op, nil
+}
+
+// selectionSet provides the cost of the selection set at the current position
+// nested in lists of the given total size.
+func (p *costParser) selectionSet(mul float64) float64 {
+	p.expect('{')
+
+	var cost float64
+	for p.tok.kind != '}' {
+		if cost > p.limit {
+			return cost
+		}
+
+		// fragments
+		if p.tok.kind == '.' {
+			p.next()
+			cost += p.fragment(mul)
+			continue
+		}
+
+		// field with an optional alias
+		name := p.expect('n').val
+		if p.tok.kind == ':' {
+			p.next()
+			name = p.expect('n').val
+		}
+
+		size, ok := p.sizes[name]
+		if !ok {
+			size = 1
+		}
+		if p.tok.kind == '(' {
+			size = p.arguments(size)
+		}
+		p.directives()
+
+		cost += mul
+		if p.tok.kind == '{' {
+			cost += p.selectionSet(mul * size)
+		}
+	}
+
+	p.next()
+	return cost
+}
+
+// fragment provides the cost of an inline fragment or a fragment spread at the current position.
+func (p *costParser) fragment(mul float64) float64 {
+	// inline fragment
+	if p.tok.kind != 'n' || p.tok.val == "on" {
+		if p.tok.kind == 'n' {
+			p.next()
+			p.expect('n')
+		}
+		p.directives()
+		return p.selectionSet(mul)
+	}
+
+	// fragment spread; recursive spreads are invalid, the GraphQL handler reports them
+	name := p.expect('n').val
+	p.directives()
+
+	at, ok := p.fragments[name]
+	if !ok || p.visiting[name] {
+		return 0
+	}
+
+	back, tok := p.pos, p.tok
+	p.visiting[name] = true
+	p.seek(at)
+	cost := p.selectionSet(mul)
+	p.visiting[name] = false
+	p.pos, p.tok = back, tok
+	return cost
+}
+
+// arguments parses field arguments and provides the size of the list the field resolves;
+// the given default size is used if the list size argument is not present.
+func (p *costParser) arguments(size float64) float64 {
+	p.expect('(')
+
+	for p.tok.kind != ')' {
+		name := p.expect('n').val
+		p.expect(':')
+
+		val := p.value()
+		if name == queryCostListArgument {
+			size = p.listSize(val)
+		}
+	}
+
+	p.next()
+	return size
+}
+
+// listSize provides the size of the list requested by the given argument value.
+func (p *costParser) listSize(val costToken) float64 {
+	var size float64 = queryCostMaxListSize
+	switch val.kind {
+	case 'v':
+		if n, err := strconv.ParseFloat(val.val, 64); err == nil {
+			size = n
+		}
+	case '$':
+		if n, ok := p.vars[val.val].(float64); ok {
+			size = n
+		}
+	}
+
+	size = math.Abs(size)
+	if size == 0 || size > queryCostMaxListSize {
+		return queryCostMaxListSize
+	}
+	return size
+}
+
+// value parses a value at the current position; the token of a scalar value or a variable is provided.
+func (p *costParser) value() costToken {
+	tok := p.tok
+	switch tok.kind {
+	case '$':
+		p.next()
+		tok.val = p.expect('n').val
+		return tok
+	case '[', '{':
+		p.skipBlock()
+		return tok
+	case 'v', 'n':
+		p.next()
+		return tok
+	}
+	panic(fmt.Sprintf("unexpected %q", tok.val))
+}
+
+// directives skips directives at the current position.
+func (p *costParser) directives() {
+	for p.tok.kind == '@' {
+		p.next()
+		p.expect('n')
+		if p.tok.kind == '(' {
+			p.skipBlock()
+		}
+	}
+}
+
+// skipBlock skips a balanced block of braces, brackets or parentheses at the current position.
+func (p *costParser) skipBlock() {
+	depth := 0
+	for {
+		switch p.tok.kind {
+		case '{', '[', '(':
+			depth++
+		case '}', ']', ')':
+			depth--
+		case 0:
+			panic("unexpected end of document")
+		}
+
+		p.next()
+		if depth == 0 {
+			return
+		}
+	}
+}
+
+// expect provides the current token and moves to the next one; it panics if the token is not of the given kind.
+func (p *costParser) expect(kind byte) costToken {
+	tok := p.tok
+	if tok.kind != kind {
+		panic(fmt.Sprintf("unexpected %q", tok.val))
+	}
+	p.next()
+	return tok
+}
+
+// seek moves the parser to the token at the given position.
+func (p *costParser) seek(pos int) {
+	p.pos = pos
+	p.next()
+}
+
+// next reads the next token of the document.
+func (p *costParser) next() {
+	// skip ignored characters and comments
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == '#' {
+			for p.pos < len(p.src) && p.src[p.pos] != '\n' {
+				p.pos++
+			}
+			continue
+		}
+		if c != ' ' && c != '\t' && c != '\n' && c != '\r' && c != ',' && c != 0xef && c != 0xbb && c != 0xbf {
+			break
+		}
+		p.pos++
+	}
+
+	if p.pos >= len(p.src) {
+		p.tok = costToken{}
+		return
+	}
+
+	start := p.pos
+	c := p.src[p.pos]
+	switch {
+	case c == '.':
+		p.pos += 3
+		p.tok = costToken{kind: '.', val: "..."}
+	case c == '"':
+		p.str()
+		p.tok = costToken{kind: 'v', val: p.src[start:p.pos]}
+	case c == '-' || (c >= '0' && c <= '9'):
+		p.pos++
+		for p.pos < len(p.src) && isNumberChar(p.src[p.pos]) {
+			p.pos++
+		}
+		p.tok = costToken{kind: 'v', val: p.src[start:p.pos]}
+	case isNameChar(c):
+		for p.pos < len(p.src) && (isNameChar(p.src[p.pos]) || (p.src[p.pos] >= '0' && p.src[p.pos] <= '9')) {
+			p.pos++
+		}
+		p.tok = costToken{kind: 'n', val: p.src[start:p.pos]}
+	default:
+		p.pos++
+		p.tok = costToken{kind: c, val: string(c)}
+	}
+}
+
+// str skips a string or a block string at the current position.
+func (p *costParser) str() {
+	if len(p.src) >= p.pos+3 && p.src[p.pos:p.pos+3] == `"""` {
+		p.pos += 3
+		for p.pos < len(p.src) {
+			if p.src[p.pos] == '\\' {
+				p.pos += 2
+				continue
+			}
+			if len(p.src) >= p.pos+3 && p.src[p.pos:p.pos+3] == `"""` {
+				p.pos += 3
+				return
+			}
+			p.pos++
+		}
+		panic("unterminated string")
+	}
+
+	p.pos++
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case '\\':
+			p.pos += 2
+			continue
+		case '"':
+			p.pos++
+			return
+		case '\n':
+			panic("unterminated string")
+		}
+		p.pos++
+	}
+	panic("unterminated string")
+}
+
+// isNameChar checks if the character can start a GraphQL name.
+func isNameChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isNumberChar checks if the character can be a part of a GraphQL number.
+func isNumberChar(c byte) bool {
+	return (c >= '0' && c <= '9') || c == '.' || c == 'e' || c == 'E' || c == '+' || c == '-'
+}
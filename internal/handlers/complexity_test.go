@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"bytes"
+	"github.com/onsi/gomega"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestQueryCost(t *testing.T) {
+	sizes := map[string]float64{"erc20TokenList": 50}
+
+	tests := []struct {
+		name string
+		doc  string
+		op   string
+		vars map[string]interface{}
+		cost int
+		err  bool
+	}{
+		{name: "single field", doc: `{ version }`, cost: 1},
+		{name: "nested object", doc: `query { block { number hash } }`, cost: 3},
+		{name: "list size", doc: `{ blocks(count: 10) { edges { cursor } } }`, cost: 21},
+		{name: "list size of variable", doc: `query q($c: Int!) { blocks(count: $c) { edges { cursor } } }`, vars: map[string]interface{}{"c": 5.0}, cost: 11},
+		{name: "list size of missing variable", doc: `query q($c: Int!) { blocks(count: $c) { cursor } }`, cost: 251},
+		{name: "list size above max", doc: `{ blocks(count: 1000) { edges { cursor } } }`, cost: 501},
+		{name: "negative list size", doc: `{ blocks(count: -10) { edges { cursor } } }`, cost: 21},
+		{name: "default list size", doc: `{ erc20TokenList { name } }`, cost: 51},
+		{name: "default list size overridden", doc: `{ erc20TokenList(count: 2) { name } }`, cost: 3},
+		{name: "default list size of aliased field", doc: `{ tokens: erc20TokenList { name } }`, cost: 51},
+		{name: "alias", doc: `{ a: blocks(count: 3) { edges { cursor } } }`, cost: 7},
+		{name: "fragment spread", doc: `query { ...f } fragment f on Query { version block { number } }`, cost: 3},
+		{name: "fragment spread in list", doc: `{ blocks(count: 2) { ...f } } fragment f on BlockList { edges { cursor } }`, cost: 5},
+		{name: "recursive fragment", doc: `{ ...f } fragment f on Query { version ...f }`, cost: 1},
+		{name: "inline fragment", doc: `{ block { ... on Block { number } } }`, cost: 2},
+		{name: "directives", doc: `{ block @include(if: true) { number @skip(if: false) } }`, cost: 2},
+		{name: "named operation", doc: `query a { version } query b { block { number } }`, op: "b", cost: 2},
+		{name: "first operation", doc: `query a { version } query b { block { number } }`, cost: 1},
+		{name: "string with braces", doc: `{ account(address: "{}") { balance } }`, cost: 2},
+		{name: "block string", doc: `{ account(address: """{"}""") { balance } }`, cost: 2},
+		{name: "comment", doc: "{ # comment {\n version }", cost: 1},
+		{name: "unknown operation", doc: `query a { version }`, op: "b", err: true},
+		{name: "unterminated selection", doc: `{ block { number }`, err: true},
+		{name: "unterminated arguments", doc: `{ block(number: "0x1" { number } }`, err: true},
+		{name: "unterminated string", doc: `{ account(address: "0x1) { balance } }`, err: true},
+		{name: "invalid token", doc: `{ block { number } } %`, err: true},
+		{name: "empty document", doc: ``, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			cost, err := queryCost(tt.doc, tt.op, tt.vars, 10000, sizes)
+			if tt.err {
+				g.Expect(err).NotTo(gomega.BeNil())
+				return
+			}
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(cost).To(gomega.Equal(tt.cost))
+		})
+	}
+}
+
+func TestQueryListSizes(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	sizes := queryListSizes(`
+type Query {
+    erc20TokenList(count: Int = 50):[ERC20Token!]!
+    blocks(cursor:Cursor, count:Int!, from:Long):BlockList!
+    validatorLeaderboard(epochs: Int = 100, count: Int = 25): [ValidatorPerformance!]!
+    everything(count: Int = 1000): [Account!]!
+    version: String! @deprecated(reason: "not needed")
+}
+
+type Account {
+    erc20TokenList(count: Int = 10): [ERC20Token!]!
+}`)
+
+	g.Expect(sizes).To(gomega.Equal(map[string]float64{
+		"erc20TokenList":       50,
+		"validatorLeaderboard": 25,
+		"everything":           queryCostMaxListSize,
+	}))
+}
+
+func TestComplexityHandler(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		status int
+		passed bool
+		err    string
+	}{
+		{name: "acceptable cost", method: http.MethodPost, body: `{"query":"{ block { number } }"}`, status: http.StatusOK, passed: true},
+		{name: "cost above limit", method: http.MethodPost, body: `{"query":"{ blocks(count: 10) { edges { cursor } } }"}`, status: http.StatusOK, err: "exceeds the limit"},
+		{name: "GET request", method: http.MethodGet, status: http.StatusMethodNotAllowed, err: "can not be estimated"},
+		{name: "invalid body", method: http.MethodPost, body: `[{"query":"{ version }"}]`, status: http.StatusBadRequest, err: "can not be estimated"},
+		{name: "invalid query", method: http.MethodPost, body: `{"query":"{ block { number }"}`, status: http.StatusOK, err: "can not be estimated"},
+		{name: "body too large", method: http.MethodPost, body: `{"query":"{ version }","x":"` + strings.Repeat("x", queryMaxBodySize) + `"}`, status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			var passed []byte
+			h := &ComplexityHandler{
+				logger:  testLogger(),
+				maxCost: 10,
+				handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					passed, _ = ioutil.ReadAll(r.Body)
+				}),
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, "/graphql", bytes.NewBufferString(tt.body)))
+
+			g.Expect(rec.Code).To(gomega.Equal(tt.status))
+			g.Expect(rec.Body.String()).To(gomega.ContainSubstring(tt.err))
+			if !tt.passed {
+				g.Expect(passed).To(gomega.BeNil())
+				return
+			}
+			g.Expect(string(passed)).To(gomega.Equal(tt.body))
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"fantom-api-graphql/internal/config"
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// brotliLevel represents the brotli compression level; the lower levels
+// are fast enough for on-the-fly compression and still beat gzip.
+const brotliLevel = 4
+
+// CompressionHandler defines HTTP handler middleware compressing responses
+// by brotli or gzip, whichever is preferred by the client. Responses smaller
+// than the configured minimal size, or of other than the configured content types,
+// are sent as they are.
+type CompressionHandler struct {
+	minSize int
+	types   []string
+	handler http.Handler
+}
+
+// NewCompressionHandler creates a new response compression handler; the next handler
+// is returned unchanged if the compression is disabled.
+func NewCompressionHandler(cfg *config.Compression, h http.Handler) http.Handler {
+	if !cfg.Enabled {
+		return h
+	}
+	return &CompressionHandler{minSize: cfg.MinSize, types: cfg.ContentTypes, handler: h}
+}
+
+// ServeHTTP handles incoming request by passing it to the next handler in the chain
+// with the response writer compressing the response, if the client accepts it.
+func (h *CompressionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Vary", "Accept-Encoding")
+
+	enc := acceptedEncoding(r.Header.Get("Accept-Encoding"))
+	if enc == "" {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	cw := &compressWriter{ResponseWriter: w, h: h, encoding: enc}
+	defer cw.close()
+	h.handler.ServeHTTP(cw, r)
+}
+
+// acceptedEncoding provides the supported content encoding accepted by the client, if any.
+func acceptedEncoding(header string) string {
+	var gz bool
+	for _, e := range strings.Split(header, ",") {
+		e = strings.TrimSpace(e)
+		if i := strings.IndexByte(e, ';'); i >= 0 {
+			if strings.TrimSpace(e[i+1:]) == "q=0" {
+				continue
+			}
+			e = strings.TrimSpace(e[:i])
+		}
+
+		switch e {
+		case "br":
+			return "br"
+		case "gzip":
+			gz = true
+		}
+	}
+
+	if gz {
+		return "gzip"
+	}
+	return ""
+}
+
+// isCompressible checks if the response of the given content type should be compressed.
+func (h *CompressionHandler) isCompressible(contentType string) bool {
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	contentType = strings.TrimSpace(contentType)
+
+	for _, t := range h.types {
+		if strings.EqualFold(t, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// compressWriter represents a response writer compressing the response.
+// The beginning of the response is buffered until the minimal size
+// is reached, so small responses are not compressed.
+type compressWriter struct {
+	http.ResponseWriter
+	h        *CompressionHandler
+	encoding string
+	status   int
+	buf      []byte
+	enc      io.WriteCloser
+	decided  bool
+}
+
+// WriteHeader keeps the response status code until the compression is decided.
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.decided {
+		cw.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if cw.status == 0 {
+		cw.status = status
+	}
+}
+
+// Write writes the response content, compressed if decided so.
+func (cw *compressWriter) Write(data []byte) (int, error) {
+	if cw.decided {
+		if cw.enc != nil {
+			return cw.enc.Write(data)
+		}
+		return cw.ResponseWriter.Write(data)
+	}
+
+	cw.buf = append(cw.buf, data...)
+	if len(cw.buf) >= cw.h.minSize {
+		if err := cw.decide(); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+// decide decides whether to compress the response and writes the buffered content.
+func (cw *compressWriter) decide() error {
+	cw.decided = true
+
+	hdr := cw.Header()
+	if len(cw.buf) >= cw.h.minSize &&
+		(cw.status == 0 || cw.status == http.StatusOK) &&
+		hdr.Get("Content-Encoding") == "" &&
+		cw.h.isCompressible(hdr.Get("Content-Type")) {
+		hdr.Set("Content-Encoding", cw.encoding)
+		hdr.Del("Content-Length")
+
+		// the compressed content is not byte-equal to the tagged one
+		if tag := hdr.Get("ETag"); tag != "" && !strings.HasPrefix(tag, "W/") {
+			hdr.Set("ETag", "W/"+tag)
+		}
+
+		if cw.encoding == "br" {
+			cw.enc = brotli.NewWriterLevel(cw.ResponseWriter, brotliLevel)
+		} else {
+			cw.enc = gzip.NewWriter(cw.ResponseWriter)
+		}
+	}
+
+	if cw.status != 0 {
+		cw.ResponseWriter.WriteHeader(cw.status)
+	}
+	if len(cw.buf) == 0 {
+		return nil
+	}
+
+	var err error
+	if cw.enc != nil {
+		_, err = cw.enc.Write(cw.buf)
+	} else {
+		_, err = cw.ResponseWriter.Write(cw.buf)
+	}
+	cw.buf = nil
+	return err
+}
+
+// close writes the rest of the response and finishes the compression.
+func (cw *compressWriter) close() {
+	if !cw.decided {
+		_ = cw.decide()
+	}
+	if cw.enc != nil {
+		_ = cw.enc.Close()
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// reWriteOperation matches the mutation and subscription operations of a GraphQL document.
+var reWriteOperation = regexp.MustCompile(`(^|[\s},])(mutation|subscription)\b`)
+
+// ETagHandler defines HTTP handler middleware supporting conditional requests of read-only
+// GraphQL queries. The response is tagged by a hash of its content; if the client already has
+// the same response, it gets an empty 304 Not Modified response instead.
+// Queries received by the HTTP GET method are rejected here if they try to change the state,
+// GET requests must be safe.
+type ETagHandler struct {
+	handler http.Handler
+}
+
+// etagWriter represents a response writer buffering the response so it can be tagged.
+type etagWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+// Header provides the header map of the buffered response.
+func (w *etagWriter) Header() http.Header {
+	return w.header
+}
+
+// Write buffers the response content.
+func (w *etagWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(data)
+}
+
+// WriteHeader keeps the response status code.
+func (w *etagWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+// ServeHTTP handles incoming request by passing it to the next handler in the chain
+// and tagging the response of read-only queries.
+func (h *ETagHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.Body == nil {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	// read the request; the body is restored for the next handler
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	// only read-only queries can be tagged
+	var req queryRequest
+	if err := json.Unmarshal(body, &req); err != nil || req.Query == "" {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+	if reWriteOperation.MatchString(req.Query) {
+		if isGetQuery(r) {
+			w.Header().Set("Allow", http.MethodPost)
+			writeQueryError(w, http.StatusMethodNotAllowed, "", "mutations and subscriptions are not allowed by the GET method")
+			return
+		}
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	// buffer the response
+	bw := etagWriter{header: w.Header()}
+	h.handler.ServeHTTP(&bw, r)
+	if bw.status == 0 {
+		bw.status = http.StatusOK
+	}
+
+	// tag successful responses only
+	if bw.status == http.StatusOK {
+		tag := responseTag(bw.body.Bytes())
+		w.Header().Set("ETag", tag)
+
+		if matchesTag(r.Header.Get("If-None-Match"), tag) {
+			w.Header().Del("Content-Type")
+			w.Header().Del("Content-Length")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	w.WriteHeader(bw.status)
+	_, _ = w.Write(bw.body.Bytes())
+}
+
+// responseTag calculates the entity tag of the given response content.
+// The content is all that matters, responses of queries depending on the chain head
+// change with it anyway.
+func responseTag(data []byte) string {
+	hash := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(hash[:16]) + `"`
+}
+
+// matchesTag checks if the If-None-Match header value contains the given entity tag.
+func matchesTag(header string, tag string) bool {
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == tag || t == "*" {
+			return true
+		}
+	}
+	return false
+}
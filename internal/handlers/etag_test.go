@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"bytes"
+	"github.com/onsi/gomega"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestMatchesTag(t *testing.T) {
+	const tag = `"0123456789abcdef"`
+
+	tests := []struct {
+		name   string
+		header string
+		match  bool
+	}{
+		{name: "no header", header: "", match: false},
+		{name: "same tag", header: tag, match: true},
+		{name: "other tag", header: `"fedcba9876543210"`, match: false},
+		{name: "weak tag", header: "W/" + tag, match: true},
+		{name: "list of tags", header: `"fedcba9876543210", ` + tag, match: true},
+		{name: "list of other tags", header: `"fedcba9876543210", "aaaa"`, match: false},
+		{name: "wildcard", header: "*", match: true},
+		{name: "unquoted tag", header: "0123456789abcdef", match: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			g.Expect(matchesTag(tt.header, tag)).To(gomega.Equal(tt.match))
+		})
+	}
+}
+
+func TestETagHandler(t *testing.T) {
+	const response = `{"data":{"version":"1.0"}}`
+	query := url.Values{"query": {"query { version }"}}.Encode()
+	mutation := url.Values{"query": {"mutation { sendTx(tx: \"0x0\") { hash } }"}}.Encode()
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		match  bool // the client sends the tag of the response
+		status int
+		tagged bool
+	}{
+		{name: "post query", method: http.MethodPost, target: "/graphql", body: `{"query":"query { version }"}`, status: http.StatusOK, tagged: true},
+		{name: "post query not modified", method: http.MethodPost, target: "/graphql", body: `{"query":"query { version }"}`, match: true, status: http.StatusNotModified, tagged: true},
+		{name: "post mutation", method: http.MethodPost, target: "/graphql", body: `{"query":"mutation { sendTx(tx: \"0x0\") { hash } }"}`, status: http.StatusOK},
+		{name: "get query", method: http.MethodGet, target: "/graphql?" + query, status: http.StatusOK, tagged: true},
+		{name: "get query not modified", method: http.MethodGet, target: "/graphql?" + query, match: true, status: http.StatusNotModified, tagged: true},
+		{name: "get mutation", method: http.MethodGet, target: "/graphql?" + mutation, status: http.StatusMethodNotAllowed},
+		{name: "get invalid variables", method: http.MethodGet, target: "/graphql?" + query + "&variables=%7B", status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			var passed []byte
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				g.Expect(r.Method).To(gomega.Equal(http.MethodPost))
+				passed, _ = ioutil.ReadAll(r.Body)
+				_, _ = w.Write([]byte(response))
+			})
+			h := &GetQueryHandler{handler: &ETagHandler{handler: next}}
+
+			req := httptest.NewRequest(tt.method, tt.target, bytes.NewBufferString(tt.body))
+			if tt.match {
+				req.Header.Set("If-None-Match", responseTag([]byte(response)))
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			g.Expect(rec.Code).To(gomega.Equal(tt.status))
+			if tt.tagged {
+				g.Expect(rec.Header().Get("ETag")).To(gomega.Equal(responseTag([]byte(response))))
+				g.Expect(string(passed)).To(gomega.ContainSubstring(`"query { version }"`))
+			} else {
+				g.Expect(rec.Header().Get("ETag")).To(gomega.BeEmpty())
+			}
+			if tt.status == http.StatusNotModified {
+				g.Expect(rec.Body.Len()).To(gomega.BeZero())
+			}
+		})
+	}
+}
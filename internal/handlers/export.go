@@ -0,0 +1,366 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ExportAccountPath represents the path prefix of the account history export end-point.
+	ExportAccountPath = "/export/account/"
+
+	// exportTransactionsResource represents the name of the exported transactions resource.
+	exportTransactionsResource = "transactions"
+
+	// exportFlushRows represents the number of exported rows after which the output is flushed to the client.
+	exportFlushRows = 100
+
+	// exportDateFormat represents the format of the export range dates.
+	exportDateFormat = "2006-01-02"
+
+	// exportStatusTrailer represents the HTTP trailer carrying the export result,
+	// the response status is sent before the export starts, so it can not carry it.
+	exportStatusTrailer = "X-Export-Status"
+
+	// exportNextTrailer represents the HTTP trailer carrying the UNIX timestamp of the first transaction
+	// not exported due to the rows limit; the export continues by the next request starting there.
+	exportNextTrailer = "X-Export-Next"
+
+	// exportStatusComplete represents the status of the export finished in full.
+	exportStatusComplete = "complete"
+
+	// exportStatusTruncated represents the status of the export cut short by the rows limit.
+	exportStatusTruncated = "truncated"
+
+	// exportStatusFailed represents the status of the export cut short by a failure.
+	exportStatusFailed = "failed"
+)
+
+// errExportRowsLimit represents the export stopped by the rows limit.
+var errExportRowsLimit = fmt.Errorf("rows limit reached")
+
+// exportHandler defines HTTP handler exporting the indexed transaction history of an account.
+// The number of exported rows is limited, so the export fits into the server write timeout.
+// The exported rows are accounted to the API key of the client, if any, one row per cost unit.
+type exportHandler struct {
+	logger  logger.Logger
+	maxRows int
+	usage   *usageMeter
+}
+
+// exportTransaction represents an exported transaction of an account.
+type exportTransaction struct {
+	Hash      string `json:"hash"`
+	Block     uint64 `json:"block"`
+	Time      string `json:"time"`
+	Direction string `json:"direction"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Contract  string `json:"contract,omitempty"`
+	Value     string `json:"value"`
+	GasUsed   uint64 `json:"gasUsed"`
+	GasPrice  string `json:"gasPrice"`
+	Fee       string `json:"fee"`
+	Status    string `json:"status"`
+}
+
+// exportCsvHeader represents the header row of the CSV export.
+var exportCsvHeader = []string{"hash", "block", "time", "direction", "from", "to", "contract", "value", "gasUsed", "gasPrice", "fee", "status"}
+
+// ServeHTTP exports the indexed transaction history of an account
+// on /export/account/{address}/transactions. The optional from and to parameters
+// limit the time range by UNIX timestamps, or YYYY-MM-DD dates; both ends are included.
+// The format parameter selects the CSV, or the JSON output, the JSON is the default.
+// Transactions are streamed in the chronological order as they are loaded from the database.
+// The result of the export is sent in the X-Export-Status trailer.
+func (h *exportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		restRespond(w, h.logger, http.StatusMethodNotAllowed, restError{Error: "method not allowed"})
+		return
+	}
+
+	path := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, ExportAccountPath), "/"), "/")
+	if len(path) != 2 || path[1] != exportTransactionsResource {
+		restRespond(w, h.logger, http.StatusNotFound, restError{Error: "resource not found"})
+		return
+	}
+
+	adr, err := types.ParseAddress(path[0])
+	if err != nil {
+		restRespond(w, h.logger, http.StatusBadRequest, restError{Error: err.Error()})
+		return
+	}
+
+	from, to, err := exportRange(r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		restRespond(w, h.logger, http.StatusBadRequest, restError{Error: err.Error()})
+		return
+	}
+
+	var ew exportWriter
+	switch format := r.URL.Query().Get("format"); format {
+	case "csv":
+		ew = newCsvExportWriter(w)
+	case "", "json":
+		ew = &jsonExportWriter{w: w}
+	default:
+		restRespond(w, h.logger, http.StatusBadRequest, restError{Error: fmt.Sprintf("format %s not supported", format)})
+		return
+	}
+
+	w.Header().Set("Content-Type", ew.contentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s-transactions.%s", strings.ToLower(adr.String()), ew.extension()))
+	w.Header().Set("Trailer", exportStatusTrailer+", "+exportNextTrailer)
+	w.WriteHeader(http.StatusOK)
+
+	rows, status := h.export(r.Context(), w, ew, &adr, from, to)
+	w.Header().Set(exportStatusTrailer, status)
+
+	if client, _ := resolvers.RequestClient(r.Context()); client != "" && rows > 0 {
+		h.usage.add(client, rows)
+	}
+}
+
+// export streams the transactions of the account in the given time range to the export writer.
+// It provides the number of exported rows and the status of the export.
+func (h *exportHandler) export(ctx context.Context, w http.ResponseWriter, ew exportWriter, adr *common.Address, from time.Time, to time.Time) (int, string) {
+	// the response is already on its way, failures can only cut it short
+	if err := ew.begin(); err != nil {
+		return 0, exportStatusFailed
+	}
+
+	var rows int
+	err := repository.R().AccountTransactionsExport(ctx, adr, from, to, func(trx *types.Transaction) error {
+		// the first transaction over the limit is where the next export starts
+		if h.maxRows > 0 && rows >= h.maxRows {
+			w.Header().Set(exportNextTrailer, strconv.FormatInt(trx.TimeStamp.Unix(), 10))
+			return errExportRowsLimit
+		}
+
+		if err := ew.write(newExportTransaction(adr, trx)); err != nil {
+			return err
+		}
+
+		rows++
+		if rows%exportFlushRows == 0 {
+			ew.flush()
+			if f, ok := w.(http.Flusher); ok {
+				f.Flush()
+			}
+		}
+		return nil
+	})
+
+	status := exportStatusComplete
+	switch {
+	case err == errExportRowsLimit:
+		status = exportStatusTruncated
+	case err != nil:
+		h.logger.Errorf("export of transactions of %s failed after %d rows; %s", adr.String(), rows, err.Error())
+		return rows, exportStatusFailed
+	}
+
+	if err := ew.end(); err != nil {
+		h.logger.Errorf("can not finish export of transactions of %s; %s", adr.String(), err.Error())
+		return rows, exportStatusFailed
+	}
+	return rows, status
+}
+
+// exportRange parses the time range of the export; the full history is exported by default.
+func exportRange(from string, to string) (time.Time, time.Time, error) {
+	start, end := time.Unix(0, 0).UTC(), time.Now().UTC()
+
+	if from != "" {
+		t, _, err := exportTime(from)
+		if err != nil {
+			return start, end, fmt.Errorf("invalid from; %s", err.Error())
+		}
+		start = t
+	}
+
+	if to != "" {
+		t, isDate, err := exportTime(to)
+		if err != nil {
+			return start, end, fmt.Errorf("invalid to; %s", err.Error())
+		}
+
+		// the whole day is included
+		if isDate {
+			t = t.Add(24*time.Hour - time.Nanosecond)
+		}
+		end = t
+	}
+
+	if end.Before(start) {
+		return start, end, fmt.Errorf("empty time range")
+	}
+	return start, end, nil
+}
+
+// exportTime parses the given UNIX timestamp, or YYYY-MM-DD date.
+func exportTime(val string) (time.Time, bool, error) {
+	if ts, err := strconv.ParseInt(val, 10, 64); err == nil {
+		return time.Unix(ts, 0).UTC(), false, nil
+	}
+
+	t, err := time.Parse(exportDateFormat, val)
+	if err != nil {
+		return t, false, fmt.Errorf("UNIX timestamp, or YYYY-MM-DD date expected")
+	}
+	return t, true, nil
+}
+
+// newExportTransaction builds the exported record of the transaction of the given account.
+func newExportTransaction(adr *common.Address, trx *types.Transaction) *exportTransaction {
+	et := exportTransaction{
+		Hash:      trx.Hash.String(),
+		Time:      trx.TimeStamp.UTC().Format(time.RFC3339),
+		Direction: "out",
+		From:      trx.From.String(),
+		Value:     trx.Value.ToInt().String(),
+		GasPrice:  trx.GasPrice.ToInt().String(),
+	}
+
+	if trx.To != nil {
+		et.To = trx.To.String()
+		if *trx.To == *adr {
+			et.Direction = "in"
+			if trx.From == *adr {
+				et.Direction = "self"
+			}
+		}
+	}
+	if trx.ContractAddress != nil {
+		et.Contract = trx.ContractAddress.String()
+	}
+	if trx.BlockNumber != nil {
+		et.Block = uint64(*trx.BlockNumber)
+	}
+	if trx.GasUsed != nil {
+		et.GasUsed = uint64(*trx.GasUsed)
+		et.Fee = new(big.Int).Mul(new(big.Int).SetUint64(et.GasUsed), trx.GasPrice.ToInt()).String()
+	}
+	if trx.Status != nil {
+		et.Status = "failed"
+		if uint64(*trx.Status) == types.TransactionStatusSuccess {
+			et.Status = "success"
+		}
+	}
+	return &et
+}
+
+// exportWriter represents a writer of the exported transactions in a specific format.
+type exportWriter interface {
+	contentType() string
+	extension() string
+	begin() error
+	write(*exportTransaction) error
+	flush()
+	end() error
+}
+
+// csvExportWriter writes the exported transactions as CSV rows.
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+// newCsvExportWriter creates a new CSV export writer on top of the given response.
+func newCsvExportWriter(w http.ResponseWriter) *csvExportWriter {
+	return &csvExportWriter{w: csv.NewWriter(w)}
+}
+
+// contentType provides the content type of the CSV export.
+func (cw *csvExportWriter) contentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+// extension provides the file extension of the CSV export.
+func (cw *csvExportWriter) extension() string {
+	return "csv"
+}
+
+// begin writes the header row.
+func (cw *csvExportWriter) begin() error {
+	return cw.w.Write(exportCsvHeader)
+}
+
+// write writes the row of the given transaction.
+func (cw *csvExportWriter) write(et *exportTransaction) error {
+	return cw.w.Write([]string{
+		et.Hash, strconv.FormatUint(et.Block, 10), et.Time, et.Direction, et.From, et.To, et.Contract,
+		et.Value, strconv.FormatUint(et.GasUsed, 10), et.GasPrice, et.Fee, et.Status,
+	})
+}
+
+// flush passes the buffered rows to the response.
+func (cw *csvExportWriter) flush() {
+	cw.w.Flush()
+}
+
+// end flushes the remaining rows.
+func (cw *csvExportWriter) end() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// jsonExportWriter writes the exported transactions as a JSON array.
+type jsonExportWriter struct {
+	w     http.ResponseWriter
+	count int
+}
+
+// contentType provides the content type of the JSON export.
+func (jw *jsonExportWriter) contentType() string {
+	return "application/json"
+}
+
+// extension provides the file extension of the JSON export.
+func (jw *jsonExportWriter) extension() string {
+	return "json"
+}
+
+// begin opens the JSON array.
+func (jw *jsonExportWriter) begin() error {
+	_, err := jw.w.Write([]byte("["))
+	return err
+}
+
+// write writes the given transaction as an element of the JSON array.
+func (jw *jsonExportWriter) write(et *exportTransaction) error {
+	data, err := json.Marshal(et)
+	if err != nil {
+		return err
+	}
+
+	if jw.count > 0 {
+		data = append([]byte(","), data...)
+	}
+	jw.count++
+
+	_, err = jw.w.Write(data)
+	return err
+}
+
+// flush does nothing, the JSON elements are written to the response directly.
+func (jw *jsonExportWriter) flush() {}
+
+// end closes the JSON array.
+func (jw *jsonExportWriter) end() error {
+	_, err := jw.w.Write([]byte("]"))
+	return err
+}
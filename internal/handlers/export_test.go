@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"github.com/onsi/gomega"
+	"testing"
+	"time"
+)
+
+func TestExportRange(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name  string
+		from  string
+		to    string
+		start time.Time
+		end   time.Time // zero for the current time
+		err   bool
+	}{
+		{name: "full history", start: time.Unix(0, 0).UTC()},
+		{name: "from timestamp", from: "1640995200", start: day(2022, 1, 1)},
+		{name: "from date", from: "2022-01-01", start: day(2022, 1, 1)},
+		{name: "to timestamp", to: "1640995200", start: time.Unix(0, 0).UTC(), end: day(2022, 1, 1)},
+		{name: "to date includes the whole day", to: "2022-01-01", start: time.Unix(0, 0).UTC(), end: day(2022, 1, 2).Add(-time.Nanosecond)},
+		{name: "single day", from: "2022-01-01", to: "2022-01-01", start: day(2022, 1, 1), end: day(2022, 1, 2).Add(-time.Nanosecond)},
+		{name: "same timestamp", from: "1640995200", to: "1640995200", start: day(2022, 1, 1), end: day(2022, 1, 1)},
+		{name: "empty range", from: "2022-01-02", to: "2022-01-01", err: true},
+		{name: "invalid from", from: "yesterday", err: true},
+		{name: "invalid to", to: "2022-13-01", err: true},
+		{name: "from in the future", from: "4102444800", err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			start, end, err := exportRange(tt.from, tt.to)
+			if tt.err {
+				g.Expect(err).NotTo(gomega.BeNil())
+				return
+			}
+
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(start.Equal(tt.start)).To(gomega.BeTrue(), "start %s", start)
+			if tt.end.IsZero() {
+				g.Expect(end).To(gomega.BeTemporally("~", time.Now(), time.Minute))
+				return
+			}
+			g.Expect(end.Equal(tt.end)).To(gomega.BeTrue(), "end %s", end)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// getQueryKey represents the context key marking GraphQL requests received by the HTTP GET method.
+type getQueryKey struct{}
+
+// GetQueryHandler defines HTTP handler middleware accepting GraphQL queries sent by the HTTP GET method
+// in the URL query parameters. The request is passed down the chain as a regular POST request,
+// so the persisted queries and the query cost checks apply to it the same way.
+// Write operations are not allowed over GET, see ETagHandler.
+type GetQueryHandler struct {
+	handler http.Handler
+}
+
+// ServeHTTP handles incoming request by converting a GET query into a POST request
+// and passing it to the next handler in the chain.
+func (h *GetQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	body, err := getQueryBody(r)
+	if err != nil {
+		writeQueryError(w, http.StatusBadRequest, "", err.Error())
+		return
+	}
+	if body == nil {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	r = r.WithContext(context.WithValue(r.Context(), getQueryKey{}, true))
+	r.Method = http.MethodPost
+	r.Header.Set("Content-Type", "application/json")
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	h.handler.ServeHTTP(w, r)
+}
+
+// getQueryBody builds the JSON body of the GraphQL request from the URL query parameters;
+// nil if the URL does not contain a query, nor a persisted query extension.
+func getQueryBody(r *http.Request) ([]byte, error) {
+	params := r.URL.Query()
+	if params.Get("query") == "" && params.Get("extensions") == "" {
+		return nil, nil
+	}
+
+	req := make(map[string]json.RawMessage)
+	for _, key := range []string{"query", "operationName"} {
+		if val := params.Get(key); val != "" {
+			req[key], _ = json.Marshal(val)
+		}
+	}
+
+	// variables and extensions are JSON encoded in the parameters
+	for _, key := range []string{"variables", "extensions"} {
+		if val := params.Get(key); val != "" {
+			if !json.Valid([]byte(val)) {
+				return nil, fmt.Errorf("invalid %s parameter; JSON expected", key)
+			}
+			req[key] = json.RawMessage(val)
+		}
+	}
+	return json.Marshal(req)
+}
+
+// isGetQuery checks if the GraphQL request was received by the HTTP GET method.
+func isGetQuery(r *http.Request) bool {
+	v, ok := r.Context().Value(getQueryKey{}).(bool)
+	return ok && v
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// jwtClockSkew represents the tolerated difference of the token issuer clock.
+const jwtClockSkew = time.Minute
+
+// jwtHashes maps the supported JWT signature algorithms to their hash functions.
+var jwtHashes = map[string]crypto.Hash{
+	"HS256": crypto.SHA256, "HS384": crypto.SHA384, "HS512": crypto.SHA512,
+	"RS256": crypto.SHA256, "RS384": crypto.SHA384, "RS512": crypto.SHA512,
+	"ES256": crypto.SHA256, "ES384": crypto.SHA384, "ES512": crypto.SHA512,
+}
+
+// jwtVerifier validates JWT bearer tokens signed by the configured HMAC secret,
+// or by one of the configured RSA/ECDSA keys.
+type jwtVerifier struct {
+	issuer   string
+	audience string
+	secret   []byte
+	keys     []crypto.PublicKey
+}
+
+// jwtHeader represents the header of a JWT token.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+}
+
+// newJwtVerifier creates a JWT verifier loading the public keys from the given PEM files.
+func newJwtVerifier(issuer string, audience string, secret string, files []string) (*jwtVerifier, error) {
+	v := jwtVerifier{issuer: issuer, audience: audience, secret: []byte(secret)}
+	for _, f := range files {
+		key, err := loadPublicKey(f)
+		if err != nil {
+			return nil, fmt.Errorf("can not load key %s; %s", f, err.Error())
+		}
+		v.keys = append(v.keys, key)
+	}
+
+	if len(v.secret) == 0 && len(v.keys) == 0 {
+		return nil, fmt.Errorf("no JWT secret, or key configured")
+	}
+	return &v, nil
+}
+
+// loadPublicKey loads a PEM encoded RSA/ECDSA public key, or certificate, from the given file.
+func loadPublicKey(path string) (crypto.PublicKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("PEM data not found")
+	}
+
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// verify checks the signature and the validity of the given token
+// and provides the claims of the token.
+func (v *jwtVerifier) verify(token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed token")
+	}
+
+	var hdr jwtHeader
+	if err := decodeJwtPart(parts[0], &hdr); err != nil {
+		return nil, fmt.Errorf("invalid token header")
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid token signature")
+	}
+	if !v.isSigned(hdr.Alg, []byte(parts[0]+"."+parts[1]), sig) {
+		return nil, fmt.Errorf("invalid token signature")
+	}
+
+	var claims map[string]interface{}
+	if err := decodeJwtPart(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("invalid token claims")
+	}
+	if err := v.isValid(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// isSigned checks the signature of the signed token content by the given algorithm.
+// The "none" algorithm and algorithms without a configured key are rejected.
+func (v *jwtVerifier) isSigned(alg string, signed []byte, sig []byte) bool {
+	hash, ok := jwtHashes[alg]
+	if !ok || !hash.Available() {
+		return false
+	}
+
+	if alg[0] == 'H' {
+		if len(v.secret) == 0 {
+			return false
+		}
+		mac := hmac.New(hash.New, v.secret)
+		mac.Write(signed)
+		return hmac.Equal(mac.Sum(nil), sig)
+	}
+
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	for _, key := range v.keys {
+		switch k := key.(type) {
+		case *rsa.PublicKey:
+			if alg[0] == 'R' && rsa.VerifyPKCS1v15(k, hash, digest, sig) == nil {
+				return true
+			}
+		case *ecdsa.PublicKey:
+			// the signature is the fixed size R and S concatenated
+			size := (k.Curve.Params().BitSize + 7) / 8
+			if alg[0] == 'E' && len(sig) == 2*size &&
+				ecdsa.Verify(k, digest, new(big.Int).SetBytes(sig[:size]), new(big.Int).SetBytes(sig[size:])) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isValid checks the time validity, the issuer and the audience of the token claims.
+func (v *jwtVerifier) isValid(claims map[string]interface{}) error {
+	now := time.Now()
+
+	exp, ok := claims["exp"].(float64)
+	if !ok || now.After(time.Unix(int64(exp), 0).Add(jwtClockSkew)) {
+		return fmt.Errorf("token expired")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(jwtClockSkew).Before(time.Unix(int64(nbf), 0)) {
+		return fmt.Errorf("token not valid yet")
+	}
+
+	if v.issuer != "" && claims["iss"] != v.issuer {
+		return fmt.Errorf("invalid token issuer")
+	}
+	if v.audience != "" && !hasAudience(claims["aud"], v.audience) {
+		return fmt.Errorf("invalid token audience")
+	}
+	return nil
+}
+
+// decodeJwtPart decodes a base64 encoded JSON part of a JWT token.
+func decodeJwtPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// claimStrings provides the values of a claim given either as a list of strings,
+// or as a single string of space separated values.
+func claimStrings(claim interface{}) []string {
+	switch c := claim.(type) {
+	case string:
+		return strings.Fields(c)
+	case []interface{}:
+		list := make([]string, 0, len(c))
+		for _, v := range c {
+			if s, ok := v.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+// hasAudience checks if the audience claim, either a single string or a list of strings, contains the given audience.
+func hasAudience(claim interface{}, aud string) bool {
+	if s, ok := claim.(string); ok {
+		return s == aud
+	}
+	return containsString(claimStrings(claim), aud)
+}
+
+// containsString checks if the list contains the given value.
+func containsString(list []string, val string) bool {
+	for _, s := range list {
+		if s == val {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"github.com/onsi/gomega"
+	"testing"
+	"time"
+)
+
+// jwtSign creates a token of the given header algorithm and claims signed by the given function.
+func jwtSign(alg string, claims map[string]interface{}, sign func(signed []byte) []byte) string {
+	hdr, _ := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	body, _ := json.Marshal(claims)
+
+	signed := base64.RawURLEncoding.EncodeToString(hdr) + "." + base64.RawURLEncoding.EncodeToString(body)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(signed)))
+}
+
+func TestJwtVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaPem := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	hs256 := func(secret []byte) func([]byte) []byte {
+		return func(signed []byte) []byte {
+			mac := hmac.New(sha256.New, secret)
+			mac.Write(signed)
+			return mac.Sum(nil)
+		}
+	}
+	rs256 := func(signed []byte) []byte {
+		digest := sha256.Sum256(signed)
+		sig, _ := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+		return sig
+	}
+	es256 := func(signed []byte) []byte {
+		digest := sha256.Sum256(signed)
+		r, s, _ := ecdsa.Sign(rand.Reader, ecKey, digest[:])
+		return append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	none := func([]byte) []byte { return nil }
+
+	secret := []byte("top secret")
+	withSecret := &jwtVerifier{issuer: "auth.fantom", audience: "api", secret: secret}
+	withKeys := &jwtVerifier{issuer: "auth.fantom", audience: "api", keys: []crypto.PublicKey{&rsaKey.PublicKey, &ecKey.PublicKey}}
+
+	now := time.Now().Unix()
+	claims := func(change map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{"iss": "auth.fantom", "aud": "api", "sub": "client", "exp": now + 3600}
+		for k, v := range change {
+			if v == nil {
+				delete(c, k)
+				continue
+			}
+			c[k] = v
+		}
+		return c
+	}
+
+	tests := []struct {
+		name     string
+		verifier *jwtVerifier
+		token    string
+		valid    bool
+	}{
+		{name: "HMAC signed", verifier: withSecret, token: jwtSign("HS256", claims(nil), hs256(secret)), valid: true},
+		{name: "RSA signed", verifier: withKeys, token: jwtSign("RS256", claims(nil), rs256), valid: true},
+		{name: "ECDSA signed", verifier: withKeys, token: jwtSign("ES256", claims(nil), es256), valid: true},
+		{name: "audience list", verifier: withSecret, token: jwtSign("HS256", claims(map[string]interface{}{"aud": []string{"web", "api"}}), hs256(secret)), valid: true},
+		{name: "wrong secret", verifier: withSecret, token: jwtSign("HS256", claims(nil), hs256([]byte("guess"))), valid: false},
+		{name: "HMAC by the RSA public key", verifier: withKeys, token: jwtSign("HS256", claims(nil), hs256(rsaPem)), valid: false},
+		{name: "HMAC by the RSA key modulus", verifier: withKeys, token: jwtSign("HS256", claims(nil), hs256(rsaKey.PublicKey.N.Bytes())), valid: false},
+		{name: "RSA signature as ECDSA", verifier: withKeys, token: jwtSign("ES256", claims(nil), rs256), valid: false},
+		{name: "RSA without keys", verifier: withSecret, token: jwtSign("RS256", claims(nil), rs256), valid: false},
+		{name: "none algorithm", verifier: withSecret, token: jwtSign("none", claims(nil), none), valid: false},
+		{name: "none algorithm upper case", verifier: withKeys, token: jwtSign("NONE", claims(nil), none), valid: false},
+		{name: "empty algorithm", verifier: withSecret, token: jwtSign("", claims(nil), none), valid: false},
+		{name: "expired", verifier: withSecret, token: jwtSign("HS256", claims(map[string]interface{}{"exp": now - 3600}), hs256(secret)), valid: false},
+		{name: "expired within clock skew", verifier: withSecret, token: jwtSign("HS256", claims(map[string]interface{}{"exp": now - 10}), hs256(secret)), valid: true},
+		{name: "no expiration", verifier: withSecret, token: jwtSign("HS256", claims(map[string]interface{}{"exp": nil}), hs256(secret)), valid: false},
+		{name: "not valid yet", verifier: withSecret, token: jwtSign("HS256", claims(map[string]interface{}{"nbf": now + 3600}), hs256(secret)), valid: false},
+		{name: "valid since now", verifier: withSecret, token: jwtSign("HS256", claims(map[string]interface{}{"nbf": now}), hs256(secret)), valid: true},
+		{name: "wrong issuer", verifier: withSecret, token: jwtSign("HS256", claims(map[string]interface{}{"iss": "evil"}), hs256(secret)), valid: false},
+		{name: "no issuer", verifier: withSecret, token: jwtSign("HS256", claims(map[string]interface{}{"iss": nil}), hs256(secret)), valid: false},
+		{name: "wrong audience", verifier: withSecret, token: jwtSign("HS256", claims(map[string]interface{}{"aud": "web"}), hs256(secret)), valid: false},
+		{name: "audience with spaces", verifier: withSecret, token: jwtSign("HS256", claims(map[string]interface{}{"aud": "web api"}), hs256(secret)), valid: false},
+		{name: "malformed", verifier: withSecret, token: "not.a-token", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			c, err := tt.verifier.verify(tt.token)
+			if !tt.valid {
+				g.Expect(err).NotTo(gomega.BeNil())
+				return
+			}
+
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(c["sub"]).To(gomega.Equal("client"))
+		})
+	}
+}
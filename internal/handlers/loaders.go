@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"fantom-api-graphql/internal/graphql/resolvers"
+	"net/http"
+)
+
+// LoadersHandler defines HTTP handler middleware attaching a new set of data loaders
+// to each incoming request, so the resolvers can batch data requests of the same API call.
+type LoadersHandler struct {
+	handler http.Handler
+}
+
+// ServeHTTP handles incoming request by attaching the data loaders to the request context
+// and passing it to the next handler in the chain.
+func (h *LoadersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.handler.ServeHTTP(w, r.WithContext(resolvers.WithLoaders(r.Context())))
+}
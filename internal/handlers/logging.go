@@ -3,6 +3,7 @@ package handlers
 import (
 	flogger "fantom-api-graphql/internal/logger"
 	"net/http"
+	"time"
 )
 
 // LoggingHandler defines HTTP handler middleware for logging incoming communication through provided Logger.
@@ -18,5 +19,8 @@ func (h *LoggingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	h.logger.Debugf("[%s <- %s] %s %s (%s)", r.Proto, r.RemoteAddr, r.Method, r.URL, r.UserAgent())
 
 	// Pass request down the chain
+	start := time.Now()
 	h.handler.ServeHTTP(w, r)
+
+	h.logger.With("duration", time.Since(start).String()).Debugf("[%s <- %s] %s %s done", r.Proto, r.RemoteAddr, r.Method, r.URL)
 }
@@ -0,0 +1,36 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/metrics"
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/trace"
+	"net/http"
+	"time"
+)
+
+// metricsTracer implements GraphQL tracer collecting the resolvers duration metrics.
+// The trace spans of the resolvers are created by the embedded tracer.
+type metricsTracer struct {
+	otelTracer
+}
+
+// TraceField traces a GraphQL field resolution; trivial fields are not measured.
+func (mt metricsTracer) TraceField(ctx context.Context, label, typeName, fieldName string, trivial bool, args map[string]interface{}) (context.Context, trace.TraceFieldFinishFunc) {
+	ctx, finish := mt.otelTracer.TraceField(ctx, label, typeName, fieldName, trivial, args)
+	if trivial {
+		return ctx, finish
+	}
+
+	start := time.Now()
+	return ctx, func(err *errors.QueryError) {
+		metrics.ObserveResolver(typeName, fieldName, time.Since(start))
+		finish(err)
+	}
+}
+
+// Metrics constructs the HTTP handler exporting the API server metrics.
+func Metrics() http.Handler {
+	return metrics.Handler()
+}
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fantom-api-graphql/internal/config"
+	flogger "fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const (
+	// persistedQueryNotFound represents the error message asking the client to send the full query.
+	persistedQueryNotFound = "PersistedQueryNotFound"
+
+	// persistedQueryNotAllowed represents the error message of a query outside of the allow-list.
+	persistedQueryNotAllowed = "PersistedQueryNotAllowed"
+
+	// persistedQueryMismatch represents the error message of a query not matching the hash sent along.
+	persistedQueryMismatch = "provided sha does not match query"
+)
+
+// PersistedQueryHandler defines HTTP handler middleware resolving automatic persisted queries (APQ).
+// Clients send only the SHA-256 hash of a known query; the full query is sent only once to register it.
+// In the strict mode only queries of the allow-list are executed.
+type PersistedQueryHandler struct {
+	logger    flogger.Logger
+	cfg       *config.PersistedQueries
+	allowList map[string]string
+	store     persistedQueryStore // repository is used if not set
+	handler   http.Handler
+}
+
+// persistedQueryStore represents the storage of registered persisted queries.
+type persistedQueryStore interface {
+	PersistedQuery(hash string) (string, bool)
+	StorePersistedQuery(hash string, query string)
+}
+
+// persistedQueryExtension represents the APQ extension of the GraphQL request.
+type persistedQueryExtension struct {
+	PersistedQuery *struct {
+		Version int    `json:"version"`
+		Hash    string `json:"sha256Hash"`
+	} `json:"persistedQuery"`
+}
+
+// NewPersistedQueryHandler creates a new persisted queries handler middleware.
+// The allow-list is loaded from the configured file, if any.
+func NewPersistedQueryHandler(cfg *config.PersistedQueries, log flogger.Logger, h http.Handler) *PersistedQueryHandler {
+	return &PersistedQueryHandler{
+		logger:    log,
+		cfg:       cfg,
+		allowList: loadAllowList(cfg.AllowList, log),
+		handler:   h,
+	}
+}
+
+// loadAllowList loads the allow-list of queries from the given JSON file mapping query hashes to queries.
+// Entries with the hash not matching the query are skipped.
+func loadAllowList(path string, log flogger.Logger) map[string]string {
+	list := make(map[string]string)
+	if path == "" {
+		return list
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Criticalf("can not read queries allow-list %s; %s", path, err.Error())
+		return list
+	}
+
+	var entries map[string]string
+	if err := json.Unmarshal(data, &entries); err != nil {
+		log.Criticalf("can not parse queries allow-list %s; %s", path, err.Error())
+		return list
+	}
+
+	for hash, query := range entries {
+		if queryHash(query) != strings.ToLower(hash) {
+			log.Errorf("allow-list query %s does not match its hash", hash)
+			continue
+		}
+		list[strings.ToLower(hash)] = query
+	}
+
+	log.Noticef("%d queries allowed by %s", len(list), path)
+	return list
+}
+
+// ServeHTTP handles incoming request by resolving the persisted query
+// and passing the request with the full query to the next handler in the chain.
+func (h *PersistedQueryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if (!h.cfg.Enabled && !h.cfg.Strict) || r.Method != http.MethodPost || r.Body == nil {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	// read the request; the body is restored for the next handler
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	// invalid requests are left to the GraphQL handler to report,
+	// the strict mode can not check them against the allow-list, so they are rejected
+	req, query, ext, err := parsePersistedQueryRequest(body)
+	if err != nil {
+		if h.cfg.Strict {
+			writeQueryError(w, http.StatusOK, "", persistedQueryNotAllowed)
+			return
+		}
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	// plain query without the APQ extension
+	if ext.PersistedQuery == nil {
+		if h.cfg.Strict && !h.isAllowed(queryHash(query)) {
+			writeQueryError(w, http.StatusOK, "", persistedQueryNotAllowed)
+			return
+		}
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+
+	resolved, msg := h.resolve(strings.ToLower(ext.PersistedQuery.Hash), query)
+	if msg != "" {
+		writeQueryError(w, http.StatusOK, "", msg)
+		return
+	}
+
+	// pass the resolved query down the chain; the query sent by the client is never used as is
+	req["query"], _ = json.Marshal(resolved)
+	if body, err = json.Marshal(req); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	h.handler.ServeHTTP(w, r)
+}
+
+// parsePersistedQueryRequest decodes the GraphQL request body and extracts the query and the APQ extension.
+func parsePersistedQueryRequest(body []byte) (map[string]json.RawMessage, string, persistedQueryExtension, error) {
+	var req map[string]json.RawMessage
+	var ext persistedQueryExtension
+	var query string
+
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, "", ext, err
+	}
+	if raw, ok := req["query"]; ok {
+		if err := json.Unmarshal(raw, &query); err != nil {
+			return nil, "", ext, err
+		}
+	}
+	if raw, ok := req["extensions"]; ok {
+		if err := json.Unmarshal(raw, &ext); err != nil {
+			return nil, "", ext, err
+		}
+	}
+	return req, query, ext, nil
+}
+
+// resolve provides the query of the given hash; the query sent along is registered under the hash.
+// The message of the error to be returned to the client is provided if the query can not be resolved.
+func (h *PersistedQueryHandler) resolve(hash string, query string) (string, string) {
+	// a query sent along must always match the hash
+	if query != "" && queryHash(query) != hash {
+		return "", persistedQueryMismatch
+	}
+
+	// the allow-list always goes first
+	if q, ok := h.allowList[hash]; ok {
+		return q, ""
+	}
+	if h.cfg.Strict {
+		return "", persistedQueryNotAllowed
+	}
+
+	// register a new query
+	if query != "" {
+		h.queries().StorePersistedQuery(hash, query)
+		return query, ""
+	}
+
+	if q, ok := h.queries().PersistedQuery(hash); ok {
+		return q, ""
+	}
+	return "", persistedQueryNotFound
+}
+
+// queries provides the storage of registered persisted queries.
+func (h *PersistedQueryHandler) queries() persistedQueryStore {
+	if h.store != nil {
+		return h.store
+	}
+	return repository.R()
+}
+
+// allowed checks if the given full query can be executed. Only queries
+// of the allow-list are executed in the strict mode.
+func (h *PersistedQueryHandler) allowed(query string) error {
+	if h.cfg.Strict && !h.isAllowed(queryHash(query)) {
+		return fmt.Errorf(persistedQueryNotAllowed)
+	}
+	return nil
+}
+
+// isAllowed checks if the query of the given hash is on the allow-list.
+func (h *PersistedQueryHandler) isAllowed(hash string) bool {
+	_, ok := h.allowList[hash]
+	return ok
+}
+
+// queryHash provides the SHA-256 hash of the given query in hex format.
+func queryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
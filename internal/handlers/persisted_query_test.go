@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/onsi/gomega"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// testLogger provides a logger for the handlers tests.
+func testLogger() logger.Logger {
+	return logger.New(&config.Config{AppName: "test", Log: config.Log{Level: "CRITICAL", Format: "%{message}"}})
+}
+
+// testQueryStore represents an in-memory storage of persisted queries.
+type testQueryStore map[string]string
+
+// PersistedQuery provides the GraphQL query registered under the given hash, if known.
+func (s testQueryStore) PersistedQuery(hash string) (string, bool) {
+	q, ok := s[hash]
+	return q, ok
+}
+
+// StorePersistedQuery registers the GraphQL query under the given hash.
+func (s testQueryStore) StorePersistedQuery(hash string, query string) {
+	s[hash] = query
+}
+
+func TestPersistedQueryHandler(t *testing.T) {
+	const allowed = "query { version }"
+	const other = "query { account(address: \"0x0\") { balance } }"
+
+	apq := func(query string, hash string) string {
+		req := map[string]interface{}{
+			"extensions": map[string]interface{}{"persistedQuery": map[string]interface{}{"version": 1, "sha256Hash": hash}},
+		}
+		if query != "" {
+			req["query"] = query
+		}
+		data, _ := json.Marshal(req)
+		return string(data)
+	}
+
+	tests := []struct {
+		name   string
+		strict bool
+		stored map[string]string
+		body   string
+		query  string // query passed down the chain; empty if rejected
+		err    string
+	}{
+		{name: "allowed hash", body: apq("", queryHash(allowed)), query: allowed},
+		{name: "allowed hash with its query", strict: true, body: apq(allowed, queryHash(allowed)), query: allowed},
+		{name: "allowed hash with other query", strict: true, body: apq(other, queryHash(allowed)), err: persistedQueryMismatch},
+		{name: "allowed hash with other query, not strict", body: apq(other, queryHash(allowed)), err: persistedQueryMismatch},
+		{name: "unknown hash, strict", strict: true, body: apq("", queryHash(other)), err: persistedQueryNotAllowed},
+		{name: "unknown hash with query, strict", strict: true, body: apq(other, queryHash(other)), err: persistedQueryNotAllowed},
+		{name: "unknown hash", body: apq("", queryHash(other)), err: persistedQueryNotFound},
+		{name: "registered hash", stored: map[string]string{queryHash(other): other}, body: apq("", queryHash(other)), query: other},
+		{name: "new query registered", body: apq(other, queryHash(other)), query: other},
+		{name: "plain allowed query, strict", strict: true, body: `{"query":"query { version }"}`, query: allowed},
+		{name: "plain other query, strict", strict: true, body: `{"query":"query { block { number } }"}`, err: persistedQueryNotAllowed},
+		{name: "batched request, strict", strict: true, body: `[{"query":"query { block { number } }"}]`, err: persistedQueryNotAllowed},
+		{name: "non-string query, strict", strict: true, body: `{"query":{"q":"query { block { number } }"}}`, err: persistedQueryNotAllowed},
+		{name: "invalid extensions, strict", strict: true, body: `{"query":"query { version }","extensions":[]}`, err: persistedQueryNotAllowed},
+		{name: "batched request", body: `[{"query":"query { block { number } }"}]`, query: "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			var passed []byte
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				passed, _ = ioutil.ReadAll(r.Body)
+			})
+
+			store := testQueryStore{}
+			for k, v := range tt.stored {
+				store[k] = v
+			}
+
+			h := &PersistedQueryHandler{
+				logger:    testLogger(),
+				cfg:       &config.PersistedQueries{Enabled: true, Strict: tt.strict},
+				allowList: map[string]string{queryHash(allowed): allowed},
+				store:     store,
+				handler:   next,
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(tt.body)))
+
+			if tt.err != "" {
+				g.Expect(passed).To(gomega.BeNil())
+				g.Expect(rec.Body.String()).To(gomega.ContainSubstring(tt.err))
+				return
+			}
+
+			g.Expect(passed).NotTo(gomega.BeNil())
+			if tt.query == "-" {
+				g.Expect(string(passed)).To(gomega.Equal(tt.body))
+				return
+			}
+
+			var req struct {
+				Query string `json:"query"`
+			}
+			g.Expect(json.Unmarshal(passed, &req)).To(gomega.Succeed())
+			g.Expect(req.Query).To(gomega.Equal(tt.query))
+		})
+	}
+}
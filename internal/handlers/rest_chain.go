@@ -0,0 +1,180 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// RestBlockPath represents the path prefix of the REST block end-point.
+	RestBlockPath = "/api/v1/block/"
+
+	// RestTransactionPath represents the path prefix of the REST transaction end-point.
+	RestTransactionPath = "/api/v1/tx/"
+
+	// RestAccountPath represents the path prefix of the REST account end-point.
+	RestAccountPath = "/api/v1/account/"
+
+	// restLatestBlock represents the block identifier of the latest block.
+	restLatestBlock = "latest"
+)
+
+// restError represents the error response of the REST API.
+type restError struct {
+	Error string `json:"error"`
+}
+
+// restAccount represents the account detail response of the REST API.
+type restAccount struct {
+	Address      common.Address `json:"address"`
+	Type         string         `json:"type"`
+	Contract     *common.Hash   `json:"contract,omitempty"`
+	Balance      *hexutil.Big   `json:"balance"`
+	Nonce        hexutil.Uint64 `json:"nonce"`
+	TrxCounter   hexutil.Uint64 `json:"txCount"`
+	LastActivity hexutil.Uint64 `json:"lastActivity"`
+}
+
+// RestBlock constructs the REST API HTTP handler of the block detail.
+// The block is identified by its decimal or hex number, or by the "latest" keyword.
+func RestBlock(log logger.Logger) http.Handler {
+	return restHandler(log, RestBlockPath, func(ctx context.Context, id string) (interface{}, int, string) {
+		var num *hexutil.Uint64
+		if id != restLatestBlock {
+			n, err := restBlockNumber(id)
+			if err != nil {
+				return nil, http.StatusBadRequest, "invalid block number"
+			}
+			num = (*hexutil.Uint64)(&n)
+		}
+
+		blk, err := repository.R().BlockByNumber(ctx, num)
+		if err == repository.ErrBlockNotFound {
+			return nil, http.StatusNotFound, "block not found"
+		}
+		if err != nil {
+			log.Errorf("can not get block %s; %s", id, err.Error())
+			return nil, http.StatusInternalServerError, "block not available"
+		}
+		return blk, http.StatusOK, ""
+	})
+}
+
+// RestTransaction constructs the REST API HTTP handler of the transaction detail.
+func RestTransaction(log logger.Logger) http.Handler {
+	return restHandler(log, RestTransactionPath, func(ctx context.Context, id string) (interface{}, int, string) {
+		hash, err := types.ParseHash(id)
+		if err != nil {
+			return nil, http.StatusBadRequest, err.Error()
+		}
+
+		trx, err := repository.R().Transaction(ctx, &hash)
+		if err == repository.ErrTransactionNotFound {
+			return nil, http.StatusNotFound, "transaction not found"
+		}
+		if err != nil {
+			log.Errorf("can not get transaction %s; %s", hash.String(), err.Error())
+			return nil, http.StatusInternalServerError, "transaction not available"
+		}
+		return trx, http.StatusOK, ""
+	})
+}
+
+// RestAccount constructs the REST API HTTP handler of the account detail.
+func RestAccount(log logger.Logger) http.Handler {
+	return restHandler(log, RestAccountPath, func(ctx context.Context, id string) (interface{}, int, string) {
+		adr, err := types.ParseAddress(id)
+		if err != nil {
+			return nil, http.StatusBadRequest, err.Error()
+		}
+
+		acc, err := repository.R().Account(ctx, &adr)
+		if err != nil {
+			log.Errorf("can not get account %s; %s", adr.String(), err.Error())
+			return nil, http.StatusInternalServerError, "account not available"
+		}
+
+		res, err := restAccountDetail(ctx, acc)
+		if err != nil {
+			log.Errorf("can not get account %s state; %s", adr.String(), err.Error())
+			return nil, http.StatusInternalServerError, "account not available"
+		}
+		return res, http.StatusOK, ""
+	})
+}
+
+// restAccountDetail builds the account detail response including the current account state.
+func restAccountDetail(ctx context.Context, acc *types.Account) (*restAccount, error) {
+	bal, err := repository.R().AccountBalance(ctx, &acc.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := repository.R().AccountNonce(ctx, &acc.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &restAccount{
+		Address:      acc.Address,
+		Type:         acc.Type,
+		Contract:     acc.ContractTx,
+		Balance:      bal,
+		Nonce:        *nonce,
+		TrxCounter:   acc.TrxCounter,
+		LastActivity: acc.LastActivity,
+	}, nil
+}
+
+// restHandler constructs a GET only REST API HTTP handler resolving the resource identified
+// by the remainder of the request path after the given prefix.
+// The resolver gets the request context and provides the response value,
+// or the HTTP status and the error message.
+func restHandler(log logger.Logger, prefix string, resolve func(context.Context, string) (interface{}, int, string)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			restRespond(w, log, http.StatusMethodNotAllowed, restError{Error: "method not allowed"})
+			return
+		}
+
+		id := strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/")
+		if id == "" || strings.Contains(id, "/") {
+			restRespond(w, log, http.StatusNotFound, restError{Error: "resource not found"})
+			return
+		}
+
+		val, status, msg := resolve(r.Context(), id)
+		if val == nil {
+			restRespond(w, log, status, restError{Error: msg})
+			return
+		}
+		restRespond(w, log, status, val)
+	})
+}
+
+// restRespond writes the JSON encoded value with the given HTTP status.
+func restRespond(w http.ResponseWriter, log logger.Logger, status int, val interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(val); err != nil {
+		log.Errorf("can not encode REST response; %s", err.Error())
+	}
+}
+
+// restBlockNumber parses the decimal or hex encoded block number.
+func restBlockNumber(id string) (uint64, error) {
+	if strings.HasPrefix(id, "0x") {
+		return hexutil.DecodeUint64(id)
+	}
+	return strconv.ParseUint(id, 10, 64)
+}
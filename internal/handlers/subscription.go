@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/graphql/resolvers"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-transport-ws/graphqlws"
+	"net/http"
+)
+
+// SubscriptionHandler defines HTTP handler middleware serving GraphQL subscriptions over WebSocket.
+// The WebSocket connection is opened by a single HTTP request, so the query checks made
+// on the HTTP requests by the persisted query and the complexity handlers are made
+// on each subscription instead. Other requests are passed to the next handler in the chain.
+type SubscriptionHandler struct {
+	schema     *graphql.Schema
+	queries    *PersistedQueryHandler
+	complexity *ComplexityHandler
+	handler    http.Handler
+}
+
+// subscriptionService implements the GraphQL service of a single WebSocket connection.
+type subscriptionService struct {
+	h   *SubscriptionHandler
+	ctx context.Context
+}
+
+// requestContext represents the context of a subscription carrying the values
+// of the request context which opened the WebSocket connection, e.g. the client identity.
+type requestContext struct {
+	context.Context
+	request context.Context
+}
+
+// ServeHTTP handles incoming request by opening the WebSocket connection, if requested,
+// or passing the request to the next handler in the chain.
+func (h *SubscriptionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	graphqlws.NewHandlerFunc(&subscriptionService{h: h, ctx: r.Context()}, h.handler).ServeHTTP(w, r)
+}
+
+// Subscribe checks the subscription query and subscribes it with the schema, if allowed.
+func (ss *subscriptionService) Subscribe(ctx context.Context, document string, operationName string, variables map[string]interface{}) (<-chan interface{}, error) {
+	ctx = &requestContext{Context: ctx, request: ss.ctx}
+	if err := ss.h.queries.allowed(document); err != nil {
+		return nil, err
+	}
+
+	client, _ := resolvers.RequestClient(ctx)
+	if err := ss.h.complexity.check(client, &queryRequest{Query: document, OperationName: operationName, Variables: variables}); err != nil {
+		return nil, err
+	}
+	return ss.h.schema.Subscribe(ctx, document, operationName, variables)
+}
+
+// Value provides the value of the subscription context, or of the connection request context.
+func (rc *requestContext) Value(key interface{}) interface{} {
+	if v := rc.Context.Value(key); v != nil {
+		return v
+	}
+	return rc.request.Value(key)
+}
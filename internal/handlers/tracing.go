@@ -0,0 +1,67 @@
+// Package handlers holds HTTP/WS handlers chain along with separate middleware implementations.
+package handlers
+
+import (
+	"context"
+	"fantom-api-graphql/internal/tracing"
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/introspection"
+	"github.com/graph-gophers/graphql-go/trace"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"net/http"
+)
+
+// TracingHandler defines HTTP handler middleware starting the trace span of each incoming request.
+// The trace context sent by the caller, if any, is continued.
+type TracingHandler struct {
+	handler http.Handler
+}
+
+// ServeHTTP handles incoming request by starting the request span
+// and passing the request to the next handler in the chain.
+func (h *TracingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	ctx, span := tracing.Root(ctx, "api.request", attribute.String("http.method", r.Method))
+	defer span.End()
+
+	h.handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// otelTracer implements GraphQL tracer creating trace spans of the queries and the resolved fields.
+type otelTracer struct{}
+
+// TraceQuery traces a GraphQL query execution.
+func (ot otelTracer) TraceQuery(ctx context.Context, queryString string, operationName string, _ map[string]interface{}, _ map[string]*introspection.Type) (context.Context, trace.TraceQueryFinishFunc) {
+	ctx, span := tracing.Start(ctx, "graphql.query",
+		attribute.String("graphql.operation", operationName),
+		attribute.String("graphql.query", queryString))
+
+	return ctx, func(errs []*errors.QueryError) {
+		if len(errs) > 0 {
+			tracing.Finish(span, errs[0])
+			return
+		}
+		span.End()
+	}
+}
+
+// TraceField traces a GraphQL field resolution; trivial fields are not traced.
+func (ot otelTracer) TraceField(ctx context.Context, label, typeName, fieldName string, trivial bool, _ map[string]interface{}) (context.Context, trace.TraceFieldFinishFunc) {
+	if trivial {
+		return ctx, func(*errors.QueryError) {}
+	}
+
+	ctx, span := tracing.Start(ctx, label,
+		attribute.String("graphql.type", typeName),
+		attribute.String("graphql.field", fieldName))
+
+	return ctx, func(err *errors.QueryError) {
+		if err != nil {
+			tracing.Finish(span, err)
+			return
+		}
+		span.End()
+	}
+}
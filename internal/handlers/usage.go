@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	flogger "fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"sync"
+	"time"
+)
+
+// usageFlushPeriod represents the period the collected API key usage is written to the database;
+// the usage collected since the last write is lost if the server stops.
+const usageFlushPeriod = time.Minute
+
+// usageMeter collects the number of queries and their cost per API key and day
+// and writes the collected usage to the database periodically.
+type usageMeter struct {
+	logger  flogger.Logger
+	mu      sync.Mutex
+	pending map[string]*types.ApiKeyUsage
+}
+
+// newUsageMeter creates a new API key usage meter and starts the usage writer.
+func newUsageMeter(log flogger.Logger) *usageMeter {
+	um := &usageMeter{
+		logger:  log,
+		pending: make(map[string]*types.ApiKeyUsage),
+	}
+	go um.write()
+	return um
+}
+
+// add counts a query of the given cost on the usage of the API key of the given hash.
+func (um *usageMeter) add(key string, cost int) {
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+	id := types.ApiKeyUsageId(key, day)
+
+	um.mu.Lock()
+	defer um.mu.Unlock()
+
+	u, ok := um.pending[id]
+	if !ok {
+		u = &types.ApiKeyUsage{Key: key, Day: day}
+		um.pending[id] = u
+	}
+	u.Queries++
+	u.Cost += int64(cost)
+}
+
+// write stores the collected usage periodically.
+func (um *usageMeter) write() {
+	ticker := time.NewTicker(usageFlushPeriod)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		um.mu.Lock()
+		list := um.pending
+		um.pending = make(map[string]*types.ApiKeyUsage)
+		um.mu.Unlock()
+
+		for _, u := range list {
+			if err := repository.R().AddApiKeyUsage(context.Background(), u); err != nil {
+				um.logger.Errorf("can not record usage of API key %s; %s", u.Key, err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+)
+
+// field represents a single structured field of a log record.
+type field struct {
+	key   string
+	value interface{}
+}
+
+// fields represents the structured fields attached to log records.
+// The fields are passed to the backend as the last argument of the record;
+// the text output renders them as key=value pairs after the message.
+type fields []field
+
+// with provides a copy of the fields with the given field added;
+// a field of the same key is replaced.
+func (f fields) with(key string, value interface{}) fields {
+	out := make(fields, 0, len(f)+1)
+	for _, fi := range f {
+		if fi.key != key {
+			out = append(out, fi)
+		}
+	}
+	return append(out, field{key: key, value: value})
+}
+
+// String renders the fields as key=value pairs.
+func (f fields) String() string {
+	var sb strings.Builder
+	for _, fi := range f {
+		sb.WriteString(" ")
+		sb.WriteString(fi.key)
+		sb.WriteString("=")
+		sb.WriteString(fmt.Sprint(fi.value))
+	}
+	return sb.String()
+}
@@ -12,6 +12,12 @@ Supported logging levels, ordered by increasing details of internal state change
     - INFO
     - DEBUG
 Corresponding configuration package accepts one of these levels as a string literal.
+The level can be set for each module separately, e.g. to filter out the blockchain
+scanner noise while keeping the API request records.
+
+Records can carry structured fields, e.g. the block number or the transaction hash.
+The fields are rendered as key=value pairs in the text output, or as separate keys
+if the JSON output format is configured.
 
 For formatting specification see pkg/fmt package.
 */
@@ -69,4 +75,10 @@ type Logger interface {
 
 	// Printf logs regular and detailed state change with formatting and placeholder constituents replacements.
 	Printf(string, ...interface{})
+
+	// ModuleLogger provides a logger of the given module; the module level is configurable.
+	ModuleLogger(module string) Logger
+
+	// With provides a logger adding the given structured field to all the records.
+	With(key string, value interface{}) Logger
 }
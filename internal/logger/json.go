@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"encoding/json"
+	"github.com/op/go-logging"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// jsonBackend implements logging backend writing the records as JSON lines.
+// Structured fields of a record are written as top level keys.
+type jsonBackend struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// newJsonBackend creates a new JSON logging backend writing into the given output.
+func newJsonBackend(out io.Writer) *jsonBackend {
+	return &jsonBackend{out: out}
+}
+
+// Log writes the given record.
+func (b *jsonBackend) Log(level logging.Level, _ int, rec *logging.Record) error {
+	msg := rec.Message()
+	row := map[string]interface{}{}
+
+	// the structured fields are the last argument of the record, if any
+	if n := len(rec.Args); n > 0 {
+		if f, ok := rec.Args[n-1].(fields); ok {
+			msg = strings.TrimSpace(strings.TrimSuffix(msg, f.String()))
+			for _, fi := range f {
+				row[fi.key] = fi.value
+			}
+		}
+	}
+
+	row["time"] = rec.Time.UTC().Format(time.RFC3339Nano)
+	row["level"] = level.String()
+	row["module"] = rec.Module
+	row["msg"] = msg
+
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	_, err = b.out.Write(append(data, '\n'))
+	return err
+}
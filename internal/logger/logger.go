@@ -4,41 +4,228 @@ import (
 	"fantom-api-graphql/internal/config"
 	"github.com/op/go-logging"
 	"os"
+	"strings"
+	"sync"
 )
 
+// FormatJSON represents the configured log format producing JSON records.
+const FormatJSON = "json"
+
+// names of the API server modules logging separately
+const (
+	ModuleRepository = "repository"
+	ModuleApi        = "api"
+	ModuleScanner    = "scanner"
+	ModuleHttp       = "http"
+)
+
+// lvlBackend represents the leveled backend of all the loggers.
+var lvlBackend logging.LeveledBackend
+
+// levelModules keeps the modules which have ever had an explicitly configured logging level.
+var levelModules = make(map[string]bool)
+
+// levelsMu synchronizes the logging levels update.
+var levelsMu sync.Mutex
+
 // ApiLogger defines extended logger with generic no-level logging option
+// and structured fields attached to all the records.
 type ApiLogger struct {
 	logging.Logger
-}
-
-// Printf implements default non-leveled output.
-// We assume the information is low in importance if passed to this function so we relay it to Debug level.
-func (a ApiLogger) Printf(format string, args ...interface{}) {
-	a.Debugf(format, args...)
+	fields fields
 }
 
 // New provides pre-configured Logger with stderr output and leveled filtering.
-// Modules are not supported at the moment, but may be added in the future to make the logging setup more granular.
+// The level of each module can be configured separately; modules without
+// configured level use the global level.
 func New(cfg *config.Config) Logger {
 	// Prep the backend for exporting the log records
-	// @todo Allow app to define different logging backend by configuration means.
-	backend := logging.NewLogBackend(os.Stderr, "", 0)
+	var backend logging.Backend = logging.NewLogBackend(os.Stderr, "", 0)
 
 	// Parse log format from configuration and apply it to the backend
-	format := logging.MustStringFormatter(cfg.Log.Format)
-	fmtBackend := logging.NewBackendFormatter(backend, format)
+	if strings.EqualFold(cfg.Log.Format, FormatJSON) {
+		backend = newJsonBackend(os.Stderr)
+	} else {
+		backend = logging.NewBackendFormatter(backend, logging.MustStringFormatter(cfg.Log.Format))
+	}
+
+	// apply the configured levels on which the recording will be emitted
+	lvlBackend = logging.AddModuleLevel(backend)
+	SetLevels(cfg)
+
+	// assign the backend and return the new logger
+	logging.SetBackend(lvlBackend)
+	return newApiLogger(cfg.AppName)
+}
+
+// SetModuleLevel sets the logging level of the given module;
+// the global level is set if the module is empty.
+func SetModuleLevel(module string, level string) error {
+	lvl, err := logging.LogLevel(level)
+	if err != nil {
+		return err
+	}
+
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	lvlBackend.SetLevel(lvl, module)
+	if module != "" {
+		levelModules[module] = true
+	}
+	return nil
+}
+
+// SetLevels applies the configured global and per module logging levels.
+// Modules removed from the configuration fall back to the global level.
+func SetLevels(cfg *config.Config) {
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
 
-	// Parse and apply the configured level on which the recording will be emitted
 	level, err := logging.LogLevel(cfg.Log.Level)
 	if err != nil {
 		level = logging.INFO
 	}
-	lvlBackend := logging.AddModuleLevel(fmtBackend)
 	lvlBackend.SetLevel(level, "")
 
-	// assign the backend and return the new logger
-	logging.SetBackend(lvlBackend)
-	l := logging.MustGetLogger(cfg.AppName)
+	// modules not configured anymore follow the global level
+	for module := range levelModules {
+		if _, ok := cfg.Log.Modules[module]; !ok {
+			lvlBackend.SetLevel(level, module)
+		}
+	}
+
+	// apply per module levels
+	for module, lvl := range cfg.Log.Modules {
+		ml, err := logging.LogLevel(lvl)
+		if err != nil {
+			ml = level
+		}
+		lvlBackend.SetLevel(ml, module)
+		levelModules[module] = true
+	}
+}
+
+// newApiLogger creates a new logger of the given module.
+func newApiLogger(module string) *ApiLogger {
+	l := logging.MustGetLogger(module)
+
+	// the records are created from the ApiLogger methods, skip them on caller detection
+	l.ExtraCalldepth = 1
+	return &ApiLogger{Logger: *l}
+}
+
+// ModuleLogger provides a logger of the given module.
+// Fields of the parent logger are kept.
+func (a *ApiLogger) ModuleLogger(module string) Logger {
+	ml := newApiLogger(module)
+	ml.fields = a.fields
+	return ml
+}
+
+// With provides a logger adding the given structured field to all the records.
+func (a *ApiLogger) With(key string, value interface{}) Logger {
+	return &ApiLogger{Logger: a.Logger, fields: a.fields.with(key, value)}
+}
+
+// args adds the structured fields, if any, to the given record arguments.
+func (a *ApiLogger) args(args []interface{}) []interface{} {
+	if len(a.fields) == 0 {
+		return args
+	}
+	return append(args, a.fields)
+}
+
+// format adds the structured fields, if any, to the given record format.
+func (a *ApiLogger) format(format string) string {
+	if len(a.fields) == 0 {
+		return format
+	}
+	return format + "%s"
+}
+
+// Printf implements default non-leveled output.
+// We assume the information is low in importance if passed to this function so we relay it to Debug level.
+func (a *ApiLogger) Printf(format string, args ...interface{}) {
+	a.Logger.Debugf(a.format(format), a.args(args)...)
+}
+
+// Fatal logs fatal error without formatting.
+func (a *ApiLogger) Fatal(args ...interface{}) {
+	a.Logger.Fatal(a.args(args)...)
+}
+
+// Fatalf logs fatal error with formatting.
+func (a *ApiLogger) Fatalf(format string, args ...interface{}) {
+	a.Logger.Fatalf(a.format(format), a.args(args)...)
+}
+
+// Panic logs critical panic error without formatting.
+func (a *ApiLogger) Panic(args ...interface{}) {
+	a.Logger.Panic(a.args(args)...)
+}
+
+// Panicf logs critical panic error with formatting.
+func (a *ApiLogger) Panicf(format string, args ...interface{}) {
+	a.Logger.Panicf(a.format(format), a.args(args)...)
+}
+
+// Critical logs critical error without formatting.
+func (a *ApiLogger) Critical(args ...interface{}) {
+	a.Logger.Critical(a.args(args)...)
+}
+
+// Criticalf logs critical error with formatting.
+func (a *ApiLogger) Criticalf(format string, args ...interface{}) {
+	a.Logger.Criticalf(a.format(format), a.args(args)...)
+}
+
+// Error logs regular error without formatting.
+func (a *ApiLogger) Error(args ...interface{}) {
+	a.Logger.Error(a.args(args)...)
+}
+
+// Errorf logs regular error with formatting.
+func (a *ApiLogger) Errorf(format string, args ...interface{}) {
+	a.Logger.Errorf(a.format(format), a.args(args)...)
+}
+
+// Warning logs suspicious state situation without formatting.
+func (a *ApiLogger) Warning(args ...interface{}) {
+	a.Logger.Warning(a.args(args)...)
+}
+
+// Warningf logs suspicious state situation with formatting.
+func (a *ApiLogger) Warningf(format string, args ...interface{}) {
+	a.Logger.Warningf(a.format(format), a.args(args)...)
+}
+
+// Notice logs significant state change without formatting.
+func (a *ApiLogger) Notice(args ...interface{}) {
+	a.Logger.Notice(a.args(args)...)
+}
+
+// Noticef logs significant state change with formatting.
+func (a *ApiLogger) Noticef(format string, args ...interface{}) {
+	a.Logger.Noticef(a.format(format), a.args(args)...)
+}
+
+// Info logs common and regular state change without formatting.
+func (a *ApiLogger) Info(args ...interface{}) {
+	a.Logger.Info(a.args(args)...)
+}
+
+// Infof logs common and regular state change with formatting.
+func (a *ApiLogger) Infof(format string, args ...interface{}) {
+	a.Logger.Infof(a.format(format), a.args(args)...)
+}
+
+// Debug logs regular and detailed state change without formatting.
+func (a *ApiLogger) Debug(args ...interface{}) {
+	a.Logger.Debug(a.args(args)...)
+}
 
-	return &ApiLogger{*l}
+// Debugf logs regular and detailed state change with formatting.
+func (a *ApiLogger) Debugf(format string, args ...interface{}) {
+	a.Logger.Debugf(a.format(format), a.args(args)...)
 }
@@ -0,0 +1,146 @@
+// Package metrics implements the API server operational metrics
+// exported for Prometheus scraping.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"time"
+)
+
+// namespace represents the common prefix of all the API server metrics.
+const namespace = "fantom_api"
+
+var (
+	// scannerLag represents the number of blocks the processing is behind the chain head.
+	scannerLag = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "scanner",
+		Name:      "lag_blocks",
+		Help:      "Number of blocks the processing is behind the chain head.",
+	})
+
+	// blocksProcessed represents the number of blocks processed by the block dispatcher.
+	blocksProcessed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "scanner",
+		Name:      "blocks_processed_total",
+		Help:      "Number of blocks processed.",
+	})
+
+	// rpcCallDuration represents the duration of the node RPC calls.
+	rpcCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "rpc",
+		Name:      "call_duration_seconds",
+		Help:      "Duration of the blockchain node RPC calls.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
+	}, []string{"method"})
+
+	// dbCommandDuration represents the duration of the database commands.
+	dbCommandDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "command_duration_seconds",
+		Help:      "Duration of the persistent database commands.",
+		Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 15),
+	}, []string{"command"})
+
+	// cacheRequests represents the number of cache lookups by the cached entity and the result.
+	cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "requests_total",
+		Help:      "Number of cache lookups by the cached entity and the result, hit or miss.",
+	}, []string{"entity", "result"})
+
+	// cacheEvictions represents the number of entries removed from the in-memory cache by the reason.
+	cacheEvictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "evictions_total",
+		Help:      "Number of entries evicted from the in-memory cache by the cached entity and the reason.",
+	}, []string{"entity", "reason"})
+
+	// resolverDuration represents the duration of the GraphQL field resolvers.
+	resolverDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "graphql",
+		Name:      "resolver_duration_seconds",
+		Help:      "Duration of the GraphQL field resolvers.",
+		Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 16),
+	}, []string{"type", "field"})
+)
+
+// init registers the metrics with the default registry.
+func init() {
+	prometheus.MustRegister(scannerLag, blocksProcessed, rpcCallDuration, dbCommandDuration, cacheRequests, cacheEvictions, resolverDuration)
+}
+
+// Handler provides the HTTP handler exporting the metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// SetScannerLag sets the number of blocks the processing is behind the chain head.
+func SetScannerLag(blocks uint64) {
+	scannerLag.Set(float64(blocks))
+}
+
+// BlockProcessed counts a processed block.
+func BlockProcessed() {
+	blocksProcessed.Inc()
+}
+
+// ObserveRpcCall records the duration of a node RPC call.
+func ObserveRpcCall(method string, d time.Duration) {
+	rpcCallDuration.WithLabelValues(method).Observe(d.Seconds())
+}
+
+// ObserveDbCommand records the duration of a database command.
+func ObserveDbCommand(command string, d time.Duration) {
+	dbCommandDuration.WithLabelValues(command).Observe(d.Seconds())
+}
+
+// CacheHit counts a successful cache lookup of the given entity.
+func CacheHit(entity string) {
+	cacheRequests.WithLabelValues(entity, "hit").Inc()
+}
+
+// CacheMiss counts a failed cache lookup of the given entity.
+func CacheMiss(entity string) {
+	cacheRequests.WithLabelValues(entity, "miss").Inc()
+}
+
+// CacheEviction counts an entry of the given entity evicted from the in-memory cache.
+func CacheEviction(entity string, reason string) {
+	cacheEvictions.WithLabelValues(entity, reason).Inc()
+}
+
+// ObserveCacheEntries exports the number of entries of the in-memory cache
+// of the given entity, as provided by the callback on each scrape.
+func ObserveCacheEntries(entity string, count func() int) {
+	gauge := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Subsystem:   "cache",
+		Name:        "entries",
+		Help:        "Number of entries in the in-memory cache by the cached entity.",
+		ConstLabels: prometheus.Labels{"entity": entity},
+	}, func() float64 { return float64(count()) })
+
+	// the cache may be re-created; the gauge of the previous cache would report stale counts
+	err := prometheus.Register(gauge)
+	if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
+		prometheus.Unregister(are.ExistingCollector)
+		err = prometheus.Register(gauge)
+	}
+	if err != nil {
+		panic(err)
+	}
+}
+
+// ObserveResolver records the duration of a GraphQL field resolver.
+func ObserveResolver(typeName string, field string, d time.Duration) {
+	resolverDuration.WithLabelValues(typeName, field).Observe(d.Seconds())
+}
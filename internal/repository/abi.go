@@ -0,0 +1,329 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+// abiMethodIdLength represents the length of the function selector
+// at the beginning of a contract call input.
+const abiMethodIdLength = 4
+
+// parsedAbi represents a parsed ABI of a contract kept in memory
+// along with the source it has been parsed from.
+type parsedAbi struct {
+	src string
+	abi *abi.ABI
+}
+
+// ContractAbi provides the parsed ABI of the contract at the given address.
+// Nil is returned if the contract is not known, or the ABI is not available.
+func (p *proxy) ContractAbi(ctx context.Context, addr *common.Address) (*abi.ABI, error) {
+	// get the contract
+	sc, err := p.Contract(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// no contract, or no ABI known
+	if sc == nil || len(sc.Abi) == 0 {
+		return nil, nil
+	}
+
+	// do we have the ABI already parsed? the source may change on validation
+	if val, ok := p.abiMap.Load(addr.String()); ok && val.(*parsedAbi).src == sc.Abi {
+		return val.(*parsedAbi).abi, nil
+	}
+
+	// parse the ABI
+	ab, err := abi.JSON(strings.NewReader(sc.Abi))
+	if err != nil {
+		p.log.Errorf("invalid ABI of contract %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+
+	// keep the parsed ABI for future use
+	p.abiMap.Store(addr.String(), &parsedAbi{src: sc.Abi, abi: &ab})
+	return &ab, nil
+}
+
+// TransactionInputDecoded decodes the input data of the given transaction
+// using the ABI of the recipient contract. Nil is returned if the ABI is not known.
+func (p *proxy) TransactionInputDecoded(ctx context.Context, trx *types.Transaction) (*types.DecodedCall, error) {
+	// contract creation, or a simple transfer can not be decoded
+	if trx.To == nil || len(trx.InputData) < abiMethodIdLength {
+		return nil, nil
+	}
+
+	// get the ABI of the recipient
+	ab, err := p.ContractAbi(ctx, trx.To)
+	if err != nil || ab == nil {
+		return nil, err
+	}
+
+	return decodeCallInput(ab, trx.InputData)
+}
+
+// DecodeLog decodes the given event log record using the ABI of the emitting contract.
+// Nil is returned if the ABI is not known.
+func (p *proxy) DecodeLog(ctx context.Context, lg *retypes.Log) (*types.DecodedEvent, error) {
+	// anonymous events can not be identified
+	if len(lg.Topics) == 0 {
+		return nil, nil
+	}
+
+	// get the ABI of the emitting contract
+	ab, err := p.ContractAbi(ctx, &lg.Address)
+	if err != nil || ab == nil {
+		return nil, err
+	}
+
+	return decodeLog(ab, lg)
+}
+
+// decodeCallInput decodes the given contract call input using the provided ABI.
+func decodeCallInput(ab *abi.ABI, data []byte) (*types.DecodedCall, error) {
+	// find the method called
+	method, err := ab.MethodById(data[:abiMethodIdLength])
+	if err != nil {
+		return nil, err
+	}
+
+	// unpack call arguments
+	values, err := method.Inputs.Unpack(data[abiMethodIdLength:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DecodedCall{
+		Name:      method.RawName,
+		Signature: method.Sig,
+		Arguments: decodedArguments(method.Inputs, values),
+	}, nil
+}
+
+// decodeLog decodes the given event log record using the provided ABI.
+func decodeLog(ab *abi.ABI, lg *retypes.Log) (*types.DecodedEvent, error) {
+	// find the event emitted
+	event, err := ab.EventByID(lg.Topics[0])
+	if err != nil {
+		return nil, err
+	}
+
+	// unpack not indexed arguments from the data
+	values, err := event.Inputs.Unpack(lg.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	// unpack indexed arguments from the topics
+	var indexed abi.Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+
+	topics := make(map[string]interface{})
+	if err := abi.ParseTopicsIntoMap(topics, indexed, lg.Topics[1:]); err != nil {
+		return nil, err
+	}
+
+	// merge both sets in the order of the event definition
+	list := make([]types.DecodedArgument, 0, len(event.Inputs))
+	for _, arg := range event.Inputs {
+		var val interface{}
+		if arg.Indexed {
+			val = topics[arg.Name]
+		} else {
+			if len(values) == 0 {
+				return nil, fmt.Errorf("missing event argument %s", arg.Name)
+			}
+			val, values = values[0], values[1:]
+		}
+
+		list = append(list, types.DecodedArgument{
+			Name:  arg.Name,
+			Type:  arg.Type.String(),
+			Value: formatAbiValue(val),
+		})
+	}
+
+	return &types.DecodedEvent{
+		Name:      event.RawName,
+		Signature: event.Sig,
+		Arguments: list,
+	}, nil
+}
+
+// decodedArguments builds the list of decoded arguments from the ABI definition
+// and the unpacked values.
+func decodedArguments(args abi.Arguments, values []interface{}) []types.DecodedArgument {
+	list := make([]types.DecodedArgument, len(values))
+	for i, v := range values {
+		list[i] = types.DecodedArgument{
+			Name:  args[i].Name,
+			Type:  args[i].Type.String(),
+			Value: formatAbiValue(v),
+		}
+	}
+	return list
+}
+
+// formatAbiValue provides a textual representation of an unpacked ABI value.
+// Byte arrays and slices are hex encoded, other values use the default format.
+func formatAbiValue(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Type().Elem().Kind() == reflect.Uint8 {
+		buf := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(buf), rv)
+		return hexutil.Encode(buf)
+	}
+
+	// addresses and hashes have their own string formatting
+	if s, ok := v.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// ContractCall executes a read-only call of the given contract with the raw call data.
+func (p *proxy) ContractCall(ctx context.Context, to *common.Address, data hexutil.Bytes, block *hexutil.Uint64) (hexutil.Bytes, error) {
+	return p.rpc.ContractCall(ctx, to, data, block)
+}
+
+// ContractCallAbi executes a read-only call of the named function of the given contract
+// using the stored contract ABI. The arguments are provided as a JSON array and the output
+// values are decoded using the ABI as well.
+func (p *proxy) ContractCallAbi(ctx context.Context, to *common.Address, fn string, args *string, block *hexutil.Uint64) ([]types.DecodedArgument, error) {
+	// get the ABI of the contract
+	ab, err := p.ContractAbi(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+	if ab == nil {
+		return nil, fmt.Errorf("ABI of contract %s not known", to.String())
+	}
+
+	// find the function
+	method, ok := ab.Methods[fn]
+	if !ok {
+		return nil, fmt.Errorf("function %s not found in ABI of %s", fn, to.String())
+	}
+
+	// decode the arguments
+	values, err := abiArgumentValues(method.Inputs, args)
+	if err != nil {
+		return nil, err
+	}
+
+	// pack the call data
+	data, err := ab.Pack(fn, values...)
+	if err != nil {
+		return nil, err
+	}
+
+	// do the call
+	res, err := p.rpc.ContractCall(ctx, to, data, block)
+	if err != nil {
+		return nil, err
+	}
+
+	// unpack the output
+	out, err := method.Outputs.Unpack(res)
+	if err != nil {
+		return nil, err
+	}
+	return decodedArguments(method.Outputs, out), nil
+}
+
+// abiArgumentValues converts the JSON array of arguments into
+// a list of values with types expected by the ABI.
+func abiArgumentValues(inputs abi.Arguments, args *string) ([]interface{}, error) {
+	// parse the JSON array
+	raw := make([]json.RawMessage, 0)
+	if args != nil && len(*args) > 0 {
+		if err := json.Unmarshal([]byte(*args), &raw); err != nil {
+			return nil, fmt.Errorf("arguments must be a JSON array; %s", err.Error())
+		}
+	}
+
+	// check the number of arguments
+	if len(raw) != len(inputs) {
+		return nil, fmt.Errorf("expected %d arguments, received %d", len(inputs), len(raw))
+	}
+
+	values := make([]interface{}, len(inputs))
+	for i, in := range inputs {
+		val, err := abiArgumentValue(in.Type, raw[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument %s; %s", in.Name, err.Error())
+		}
+		values[i] = val
+	}
+	return values, nil
+}
+
+// abiArgumentValue converts a single JSON argument into a value of the type expected by the ABI.
+func abiArgumentValue(t abi.Type, raw json.RawMessage) (interface{}, error) {
+	switch t.T {
+	case abi.BytesTy, abi.FixedBytesTy:
+		// bytes are expected as hex encoded string
+		var str string
+		if err := json.Unmarshal(raw, &str); err != nil {
+			return nil, err
+		}
+		buf, err := hexutil.Decode(str)
+		if err != nil {
+			return nil, err
+		}
+		if t.T == abi.BytesTy {
+			return buf, nil
+		}
+
+		// fixed bytes need the exact array type
+		if len(buf) != t.Size {
+			return nil, fmt.Errorf("expected %d bytes, received %d", t.Size, len(buf))
+		}
+		val := reflect.New(t.GetType()).Elem()
+		reflect.Copy(val, reflect.ValueOf(buf))
+		return val.Interface(), nil
+	case abi.IntTy, abi.UintTy:
+		// big numbers may be provided as strings to avoid precision loss
+		var str string
+		if err := json.Unmarshal(raw, &str); err == nil {
+			raw = json.RawMessage(str)
+		}
+		if t.GetType() == reflect.TypeOf(&big.Int{}) {
+			val, ok := new(big.Int).SetString(string(raw), 0)
+			if !ok {
+				return nil, fmt.Errorf("invalid number %s", string(raw))
+			}
+			return val, nil
+		}
+	}
+
+	// use the JSON decoder for the remaining types
+	val := reflect.New(t.GetType())
+	if err := json.Unmarshal(raw, val.Interface()); err != nil {
+		return nil, err
+	}
+	return val.Elem().Interface(), nil
+}
@@ -9,20 +9,27 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 package repository
 
 import (
+	"context"
+	"fantom-api-graphql/internal/repository/cache"
+	"fantom-api-graphql/internal/tracing"
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.opentelemetry.io/otel/attribute"
+	"math/big"
+	"strconv"
+	"time"
 )
 
 // Account returns account at Opera blockchain for an address, nil if not found.
-func (p *proxy) Account(addr *common.Address) (acc *types.Account, err error) {
+func (p *proxy) Account(ctx context.Context, addr *common.Address) (acc *types.Account, err error) {
 	// try to get the account from cache
 	acc = p.cache.PullAccount(addr)
 
 	// we still don't know the account? try to manually construct it if possible
 	if acc == nil {
-		acc, err = p.getAccount(addr)
+		acc, err = p.getAccount(ctx, addr)
 		if err != nil {
 			return nil, err
 		}
@@ -32,16 +39,65 @@ func (p *proxy) Account(addr *common.Address) (acc *types.Account, err error) {
 	return acc, nil
 }
 
+// Accounts returns the accounts at Opera blockchain for the given addresses.
+// Accounts not in cache are loaded from the database in a single batch.
+func (p *proxy) Accounts(ctx context.Context, adr []*common.Address) ([]*types.Account, error) {
+	ctx, span := tracing.Start(ctx, "repository.Accounts", attribute.Int("count", len(adr)))
+	defer span.End()
+
+	list := make([]*types.Account, len(adr))
+
+	// try to use the in-memory cache first
+	missing := make([]*common.Address, 0, len(adr))
+	for i, a := range adr {
+		if list[i] = p.cache.PullAccount(a); list[i] == nil {
+			missing = append(missing, a)
+		}
+	}
+	if len(missing) == 0 {
+		return list, nil
+	}
+
+	// load the rest from the database
+	known, err := p.db.Accounts(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, a := range adr {
+		if list[i] != nil {
+			continue
+		}
+
+		// unknown account still may exist; build it the regular way
+		acc, ok := known[*a]
+		if !ok {
+			if acc, err = p.getAccount(ctx, a); err != nil {
+				return nil, err
+			}
+		} else if err = p.cache.PushAccount(acc); err != nil {
+			p.log.Warningf("can not keep account [%s] information in memory; %s", a.Hex(), err.Error())
+		}
+		list[i] = acc
+	}
+	return list, nil
+}
+
 // getAccount builds the account representation after validating it against Lachesis node.
-func (p *proxy) getAccount(addr *common.Address) (*types.Account, error) {
+func (p *proxy) getAccount(ctx context.Context, addr *common.Address) (*types.Account, error) {
 	// any address given?
 	if addr == nil {
 		p.log.Error("no address given")
 		return nil, fmt.Errorf("no address given")
 	}
 
+	// recently looked up and not known to the database?
+	if p.cache.IsNotFound(cache.NotFoundAccount, addr.String()) {
+		return &types.Account{Address: *addr, Type: types.AccountTypeWallet}, nil
+	}
+
 	// try to get the account from database first
-	acc, err := p.db.Account(addr)
+	acc, err := p.db.Account(ctx, addr)
 	if err != nil {
 		p.log.Errorf("can not get the account %s; %s", addr.String(), err.Error())
 		return nil, err
@@ -56,7 +112,13 @@ func (p *proxy) getAccount(addr *common.Address) (*types.Account, error) {
 		acc = &types.Account{Address: *addr, Type: types.AccountTypeWallet}
 
 		// check if this is a smart contract account; we log the error on the call
-		acc.ContractTx, _ = p.db.ContractTransaction(addr)
+		acc.ContractTx, _ = p.db.ContractTransaction(ctx, addr)
+
+		// plain unknown address; remember it only shortly, it may appear on the chain soon
+		if acc.ContractTx == nil {
+			p.cache.PushNotFound(cache.NotFoundAccount, addr.String())
+			return acc, nil
+		}
 	}
 
 	// also keep a copy at the in-memory cache
@@ -67,13 +129,13 @@ func (p *proxy) getAccount(addr *common.Address) (*types.Account, error) {
 }
 
 // AccountBalance returns the current balance of an account at Opera blockchain.
-func (p *proxy) AccountBalance(addr *common.Address) (*hexutil.Big, error) {
-	return p.rpc.AccountBalance(addr)
+func (p *proxy) AccountBalance(ctx context.Context, addr *common.Address) (*hexutil.Big, error) {
+	return p.rpc.AccountBalance(ctx, addr)
 }
 
 // AccountNonce returns the current number of sent transactions of an account at Opera blockchain.
-func (p *proxy) AccountNonce(addr *common.Address) (*hexutil.Uint64, error) {
-	val, err := p.rpc.AccountNonce(addr)
+func (p *proxy) AccountNonce(ctx context.Context, addr *common.Address) (*hexutil.Uint64, error) {
+	val, err := p.rpc.AccountNonce(ctx, addr)
 	if err != nil {
 		return nil, err
 	}
@@ -83,25 +145,37 @@ func (p *proxy) AccountNonce(addr *common.Address) (*hexutil.Uint64, error) {
 	return &nonce, nil
 }
 
+// AccountProof provides the Merkle proof of the account state and of the given storage slots
+// of the account at the given block, or at the latest block if no block is given.
+func (p *proxy) AccountProof(ctx context.Context, addr *common.Address, keys []common.Hash, block *hexutil.Uint64) (*types.AccountStateProof, error) {
+	return p.rpc.AccountProof(ctx, addr, keys, block)
+}
+
 // AccountTransactions returns slice of AccountTransaction structure for a given account at Opera blockchain.
-func (p *proxy) AccountTransactions(addr *common.Address, rec *common.Address, cursor *string, count int32) (*types.TransactionList, error) {
+func (p *proxy) AccountTransactions(ctx context.Context, addr *common.Address, rec *common.Address, cursor *string, count int32, filter *types.TransactionFilter) (*types.TransactionList, error) {
 	// do we have an account?
 	if addr == nil {
 		return nil, fmt.Errorf("can not get transaction list for empty account")
 	}
 
 	// go to the database for the list of hashes of transaction searched
-	return p.db.AccountTransactions(addr, rec, cursor, count)
+	return p.db.AccountTransactions(ctx, addr, rec, cursor, count, filter)
+}
+
+// AccountTransactionsExport passes all the transactions of the given account made in the given
+// time range to the given callback in the chronological order.
+func (p *proxy) AccountTransactionsExport(ctx context.Context, addr *common.Address, from time.Time, to time.Time, fn func(*types.Transaction) error) error {
+	return p.db.AccountTransactionsExport(ctx, addr, from, to, fn)
 }
 
 // AccountsActive returns total number of accounts known to repository.
-func (p *proxy) AccountsActive() (hexutil.Uint64, error) {
-	val, err := p.db.AccountCount()
+func (p *proxy) AccountsActive(ctx context.Context) (hexutil.Uint64, error) {
+	val, err := p.db.AccountCount(ctx)
 	return hexutil.Uint64(val), err
 }
 
 // AccountIsKnown checks if the account of the given address is known to the API server.
-func (p *proxy) AccountIsKnown(addr *common.Address) bool {
+func (p *proxy) AccountIsKnown(ctx context.Context, addr *common.Address) bool {
 	// try cache first
 	stat := p.cache.CheckAccountKnown(addr)
 	if nil != stat {
@@ -109,7 +183,7 @@ func (p *proxy) AccountIsKnown(addr *common.Address) bool {
 	}
 
 	// check if the database knows the address
-	known, err := p.db.IsAccountKnown(addr)
+	known, err := p.db.IsAccountKnown(ctx, addr)
 	if err != nil {
 		p.log.Errorf("can not check account %s existence; %s", addr.String(), err.Error())
 		return false
@@ -123,16 +197,133 @@ func (p *proxy) AccountIsKnown(addr *common.Address) bool {
 }
 
 // StoreAccount adds specified account detail into the repository.
-func (p *proxy) StoreAccount(acc *types.Account) error {
+func (p *proxy) StoreAccount(ctx context.Context, acc *types.Account) error {
 	// add this account to the database and remember it's been added
-	err := p.db.AddAccount(acc)
+	err := p.db.AddAccount(ctx, acc)
 	if err == nil {
 		p.cache.PushAccountKnown(&acc.Address)
+		p.cache.EvictNotFound(cache.NotFoundAccount, acc.Address.String())
 	}
 	return err
 }
 
 // AccountMarkActivity marks the latest account activity in the repository.
-func (p *proxy) AccountMarkActivity(addr *common.Address, ts uint64) error {
-	return p.db.AccountMarkActivity(addr, ts)
+func (p *proxy) AccountMarkActivity(ctx context.Context, addr *common.Address, ts uint64) error {
+	return p.db.AccountMarkActivity(ctx, addr, ts)
+}
+
+// AccountFirstAppearance provides the block number and the time stamp
+// of the first indexed transaction of the account. Accounts indexed before
+// the first appearance has been tracked get it derived from their transactions.
+func (p *proxy) AccountFirstAppearance(ctx context.Context, acc *types.Account) (uint64, uint64, error) {
+	if acc.FirstBlock > 0 {
+		return uint64(acc.FirstBlock), uint64(acc.FirstSeen), nil
+	}
+
+	blk, ts, err := p.db.AccountFirstAppearance(ctx, &acc.Address)
+	if err != nil || blk == 0 {
+		return 0, 0, err
+	}
+
+	// keep the cached account up-to-date so the lookup is not repeated
+	acc.FirstBlock, acc.FirstSeen = hexutil.Uint64(blk), hexutil.Uint64(ts)
+	if err := p.cache.PushAccount(acc); err != nil {
+		p.log.Errorf("can not cache account %s; %s", acc.Address.String(), err.Error())
+	}
+	return blk, ts, nil
+}
+
+// AccountUpdateBalance reconciles the known balance of the account with the blockchain.
+func (p *proxy) AccountUpdateBalance(ctx context.Context, addr *common.Address) error {
+	return p.AccountsUpdateBalance(ctx, []*common.Address{addr})
+}
+
+// AccountBalances returns the current balances of the given accounts loaded in a single batch.
+func (p *proxy) AccountBalances(ctx context.Context, adr []*common.Address) ([]*hexutil.Big, error) {
+	return p.rpc.AccountBalances(ctx, adr)
+}
+
+// AccountsUpdateBalance reconciles the known balances of the given accounts with the blockchain
+// using a single batch of balance calls. The balances are read at the current head, the balance
+// changes of the blocks processed later are added to them by the scanner.
+func (p *proxy) AccountsUpdateBalance(ctx context.Context, adr []*common.Address) error {
+	head, err := p.rpc.BlockHeight(ctx)
+	if err != nil {
+		return err
+	}
+
+	blk := head.ToInt().Uint64()
+	bal, err := p.rpc.AccountBalancesAt(ctx, adr, hexutil.Uint64(blk))
+	if err != nil {
+		return err
+	}
+
+	for i, a := range adr {
+		if bal[i] == nil {
+			continue
+		}
+		if err := p.db.AccountUpdateBalance(ctx, a, bal[i], blk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AccountsSeedBalance reads the balances of the given accounts not known yet from the blockchain.
+// The blockchain is not consulted for accounts with known balance, their balances
+// are kept up-to-date by the balance changes of the processed transactions.
+func (p *proxy) AccountsSeedBalance(ctx context.Context, adr []*common.Address) error {
+	list, err := p.db.AccountsWithoutBalance(ctx, adr)
+	if err != nil || len(list) == 0 {
+		return err
+	}
+	return p.AccountsUpdateBalance(ctx, list)
+}
+
+// AccountsAddBalance adds the balance changes of the given block to the known balances of the accounts.
+func (p *proxy) AccountsAddBalance(ctx context.Context, blk uint64, diff map[common.Address]*big.Int) error {
+	return p.db.AccountsAddBalance(ctx, blk, diff)
+}
+
+// TopAccounts provides a list of accounts sorted by their known balance
+// from the highest to the lowest. The cursor is the rank of the account
+// the list continues after.
+func (p *proxy) TopAccounts(ctx context.Context, cursor *string, count int32) (*types.AccountBalanceList, error) {
+	// decode the starting position
+	skip, err := rankCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// load the list
+	bl, err := p.db.AccountsByBalance(ctx, skip, count)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := p.db.AccountCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.AccountBalanceList{
+		Collection: bl,
+		Total:      total,
+		IsStart:    skip == 0,
+		IsEnd:      len(bl) < int(count),
+	}, nil
+}
+
+// rankCursor decodes the rank cursor of a balance sorted list
+// into the number of items to be skipped.
+func rankCursor(cursor *string) (uint64, error) {
+	if cursor == nil {
+		return 0, nil
+	}
+
+	rank, err := strconv.ParseUint(*cursor, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor; %s", err.Error())
+	}
+	return rank, nil
 }
@@ -0,0 +1,23 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+// AccountGasStats provides a summary of the gas used and the fees paid by the transactions
+// sent from the given account over the given number of the most recent days.
+func (p *proxy) AccountGasStats(ctx context.Context, addr *common.Address, days int32) (*types.AccountGasStats, error) {
+	from := time.Now().UTC().Add(-time.Duration(days) * 24 * time.Hour)
+	return p.db.AccountGasStats(ctx, addr, from)
+}
@@ -0,0 +1,66 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+// AccountLabel provides the label of the given address; nil is returned for unlabeled addresses.
+func (p *proxy) AccountLabel(ctx context.Context, addr *common.Address) (*types.AccountLabel, error) {
+	// try the cache first
+	if al, ok := p.cache.PullAccountLabel(addr); ok {
+		return al, nil
+	}
+
+	al, err := p.db.AccountLabel(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	p.cache.PushAccountLabel(addr, al)
+	return al, nil
+}
+
+// AccountLabels provides the list of account labels, optionally of the given category only.
+func (p *proxy) AccountLabels(ctx context.Context, category *string) ([]*types.AccountLabel, error) {
+	return p.db.AccountLabels(ctx, category)
+}
+
+// SetAccountLabel assigns the given name and category to the address.
+func (p *proxy) SetAccountLabel(ctx context.Context, addr *common.Address, name string, category string) (*types.AccountLabel, error) {
+	al := types.AccountLabel{
+		Address:  addr.String(),
+		Name:     name,
+		Category: category,
+		Updated:  time.Now().UTC(),
+	}
+	if err := p.db.SetAccountLabel(ctx, &al); err != nil {
+		return nil, err
+	}
+
+	p.cache.EvictAccountLabel(addr)
+	p.log.Noticef("address %s labeled as %s [%s]", al.Address, name, category)
+	return &al, nil
+}
+
+// RemoveAccountLabel removes the label of the given address.
+// It returns FALSE if the address was not labeled.
+func (p *proxy) RemoveAccountLabel(ctx context.Context, addr *common.Address) (bool, error) {
+	ok, err := p.db.RemoveAccountLabel(ctx, addr)
+	if err != nil {
+		return false, err
+	}
+
+	p.cache.EvictAccountLabel(addr)
+	return ok, nil
+}
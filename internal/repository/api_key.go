@@ -0,0 +1,63 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"time"
+)
+
+// apiKeySize represents the number of random bytes of a new API key.
+const apiKeySize = 24
+
+// CreateApiKey issues a new API key with the given usage limits.
+// The key is returned along with its stored record; only the hash of the key is kept.
+func (p *proxy) CreateApiKey(ctx context.Context, name string, rateLimit int32, dailyQuota int64) (string, *types.ApiKey, error) {
+	key, err := randomHex(apiKeySize)
+	if err != nil {
+		return "", nil, err
+	}
+
+	ak := types.ApiKey{
+		Hash:       types.ApiKeyHash(key),
+		Name:       name,
+		RateLimit:  rateLimit,
+		DailyQuota: dailyQuota,
+		Created:    time.Now().UTC(),
+	}
+	if err := p.db.AddApiKey(ctx, &ak); err != nil {
+		return "", nil, err
+	}
+
+	p.log.Noticef("API key issued to %s", name)
+	return key, &ak, nil
+}
+
+// ApiKey provides the stored record of the given API key; nil is returned for unknown keys.
+func (p *proxy) ApiKey(ctx context.Context, key string) (*types.ApiKey, error) {
+	return p.db.ApiKey(ctx, types.ApiKeyHash(key))
+}
+
+// RevokeApiKey revokes the given API key. It returns FALSE if the key is not known or already revoked.
+func (p *proxy) RevokeApiKey(ctx context.Context, key string) (bool, error) {
+	return p.db.RevokeApiKey(ctx, types.ApiKeyHash(key))
+}
+
+// AddApiKeyUsage adds the given number of queries and their cost to the daily usage of the API key.
+func (p *proxy) AddApiKeyUsage(ctx context.Context, u *types.ApiKeyUsage) error {
+	return p.db.AddApiKeyUsage(ctx, u)
+}
+
+// ApiKeyUsage provides the daily usage of the API key of the given hash over the given number of days,
+// the current day included.
+func (p *proxy) ApiKeyUsage(ctx context.Context, hash string, days int) ([]*types.ApiKeyUsage, error) {
+	from := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1-days)
+	return p.db.ApiKeyUsage(ctx, hash, from)
+}
@@ -0,0 +1,25 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+)
+
+// StoreAuditRecord stores a record of an executed operation in the audit log.
+func (p *proxy) StoreAuditRecord(ctx context.Context, rec *types.AuditRecord) error {
+	return p.db.AddAuditRecord(ctx, rec)
+}
+
+// AuditLog provides the given number of the most recent audit records
+// optionally filtered by the client API key hash and the operation name.
+func (p *proxy) AuditLog(ctx context.Context, client *string, operation *string, count int32) ([]*types.AuditRecord, error) {
+	return p.db.AuditLog(ctx, client, operation, count)
+}
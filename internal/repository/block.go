@@ -9,19 +9,21 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 package repository
 
 import (
-	"errors"
+	"context"
 	"fantom-api-graphql/internal/repository/cache"
 	"fantom-api-graphql/internal/repository/rpc"
+	"fantom-api-graphql/internal/tracing"
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	etc "github.com/ethereum/go-ethereum/core/types"
 	eth "github.com/ethereum/go-ethereum/rpc"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // ErrBlockNotFound represents an error returned if a block can not be found.
-var ErrBlockNotFound = errors.New("requested block can not be found in Opera blockchain")
+var ErrBlockNotFound = types.NewError(types.ErrCodeNotFound, "requested block can not be found in Opera blockchain")
 
 // ObservedHeaders provides a channel fed with new headers observed
 // by the connected blockchain node.
@@ -30,18 +32,60 @@ func (p *proxy) ObservedHeaders() chan *etc.Header {
 }
 
 // BlockHeight returns the current height of the Opera blockchain in blocks.
-func (p *proxy) BlockHeight() (*hexutil.Big, error) {
-	return p.rpc.BlockHeight()
+func (p *proxy) BlockHeight(ctx context.Context) (*hexutil.Big, error) {
+	return p.rpc.BlockHeight(ctx)
 }
 
 // LastKnownBlock returns number of the last block known to the repository.
-func (p *proxy) LastKnownBlock() (uint64, error) {
-	return p.db.LastKnownBlock()
+func (p *proxy) LastKnownBlock(ctx context.Context) (uint64, error) {
+	return p.db.LastKnownBlock(ctx)
 }
 
 // UpdateLastKnownBlock update record about last known block.
-func (p *proxy) UpdateLastKnownBlock(blockNo *hexutil.Uint64) error {
-	return p.db.UpdateLastKnownBlock(blockNo)
+func (p *proxy) UpdateLastKnownBlock(ctx context.Context, blockNo *hexutil.Uint64) error {
+	return p.db.UpdateLastKnownBlock(ctx, blockNo)
+}
+
+// RemoveBlocks removes blocks of the given range from the repository
+// so they can be re-ingested after a chain reorganization.
+// The balances and the aggregations affected by the removed blocks are refreshed.
+func (p *proxy) RemoveBlocks(ctx context.Context, from uint64, to uint64) error {
+	for n := from; n <= to; n++ {
+		p.cache.EvictBlock(hexutil.Uint64(n).String())
+	}
+
+	rb, err := p.db.RemoveBlocksFrom(ctx, from)
+	if err != nil {
+		return err
+	}
+
+	// the node already follows the canonical chain
+	adr := make([]*common.Address, len(rb.Accounts))
+	for i := range rb.Accounts {
+		adr[i] = &rb.Accounts[i]
+	}
+	if len(adr) > 0 {
+		if err := p.AccountsUpdateBalance(ctx, adr); err != nil {
+			return err
+		}
+	}
+
+	// the daily aggregations replace the days they cover
+	p.TrxFlowUpdate(ctx)
+	p.BlockTimeDailyUpdate(ctx)
+	p.ContractUsageUpdate(ctx)
+	return nil
+}
+
+// ProcessedBlockHash provides the hash of the processed block of the given number;
+// nil if the block is not known.
+func (p *proxy) ProcessedBlockHash(ctx context.Context, number uint64) (*common.Hash, error) {
+	return p.db.BlockTimeHash(ctx, number)
+}
+
+// TransactionsCountByBlock provides the number of stored transactions of blocks in the given range.
+func (p *proxy) TransactionsCountByBlock(ctx context.Context, from uint64, to uint64) (map[uint64]int, error) {
+	return p.db.TransactionsCountByBlock(ctx, from, to)
 }
 
 // CacheBlock puts a block to the internal block cache.
@@ -52,29 +96,79 @@ func (p *proxy) CacheBlock(blk *types.Block) {
 // BlockByNumber returns a block at Opera blockchain represented by a number. Top block is returned if the number
 // is not provided.
 // If the block is not found, ErrBlockNotFound error is returned.
-func (p *proxy) BlockByNumber(num *hexutil.Uint64) (*types.Block, error) {
+func (p *proxy) BlockByNumber(ctx context.Context, num *hexutil.Uint64) (*types.Block, error) {
 	// return the top block if block number is not provided
 	if num == nil {
 		tag := rpc.BlockTypeLatest
-		return p.blockByTag(&tag)
+		return p.blockByTag(ctx, &tag)
+	}
+	return p.getBlock(ctx, num.String(), p.blockByTag)
+}
+
+// BlocksByNumber returns a range of blocks at Opera blockchain. Blocks not in cache
+// are loaded in a single batch. Blocks not available are returned as nil.
+func (p *proxy) BlocksByNumber(ctx context.Context, from uint64, count int) ([]*types.Block, error) {
+	nums := make([]uint64, count)
+	for i := range nums {
+		nums[i] = from + uint64(i)
 	}
-	return p.getBlock(num.String(), p.blockByTag)
+	return p.BlocksByNumbers(ctx, nums)
+}
+
+// BlocksByNumbers returns the blocks at Opera blockchain of the given numbers. Blocks not in cache
+// are loaded in a single batch. Blocks not available are returned as nil.
+func (p *proxy) BlocksByNumbers(ctx context.Context, nums []uint64) ([]*types.Block, error) {
+	ctx, span := tracing.Start(ctx, "repository.BlocksByNumbers", attribute.Int("count", len(nums)))
+	defer span.End()
+
+	list := make([]*types.Block, len(nums))
+
+	// try to use the in-memory cache first
+	missing := make([]uint64, 0, len(nums))
+	index := make([]int, 0, len(nums))
+	for i, n := range nums {
+		if list[i] = p.cache.PullBlock(hexutil.Uint64(n).String()); list[i] == nil {
+			missing = append(missing, n)
+			index = append(index, i)
+		}
+	}
+	if len(missing) == 0 {
+		return list, nil
+	}
+
+	// pull the rest from the chain
+	pulled, err := p.rpc.Blocks(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, blk := range pulled {
+		if blk == nil {
+			continue
+		}
+
+		list[index[i]] = blk
+		if err := p.cache.PushBlock(hexutil.Uint64(blk.Number).String(), blk); err != nil {
+			p.log.Errorf("can not cache; %s", err.Error())
+		}
+	}
+	return list, nil
 }
 
 // BlockByHash returns a block at Opera blockchain represented by a hash. Top block is returned if the hash
 // is not provided.
 // If the block is not found, ErrBlockNotFound error is returned.
-func (p *proxy) BlockByHash(hash *common.Hash) (*types.Block, error) {
+func (p *proxy) BlockByHash(ctx context.Context, hash *common.Hash) (*types.Block, error) {
 	// do we have a hash?
 	if hash == nil {
 		tag := rpc.BlockTypeLatest
-		return p.blockByTag(&tag)
+		return p.blockByTag(ctx, &tag)
 	}
-	return p.getBlock(hash.String(), p.rpc.BlockByHash)
+	return p.getBlock(ctx, hash.String(), p.rpc.BlockByHash)
 }
 
 // getBlock gets a block of given tag from cache, or from a repository pull function.
-func (p *proxy) getBlock(tag string, pull func(*string) (*types.Block, error)) (*types.Block, error) {
+func (p *proxy) getBlock(ctx context.Context, tag string, pull func(context.Context, *string) (*types.Block, error)) (*types.Block, error) {
 	// inform what we do
 	p.log.Debugf("block [%s] requested", tag)
 
@@ -87,12 +181,18 @@ func (p *proxy) getBlock(tag string, pull func(*string) (*types.Block, error)) (
 		return blk, nil
 	}
 
+	// recently looked up and not found?
+	if p.cache.IsNotFound(cache.NotFoundBlock, tag) {
+		return nil, ErrBlockNotFound
+	}
+
 	// extract the block from the chain
-	blk, err := pull(&tag)
+	blk, err := pull(ctx, &tag)
 	if err != nil {
 		// block simply not found?
-		if err == eth.ErrNoResult {
+		if err == eth.ErrNoResult || types.ErrorCode(err) == types.ErrCodeNotFound {
 			p.log.Warning("block not found in the blockchain")
+			p.cache.PushNotFound(cache.NotFoundBlock, tag)
 			return nil, ErrBlockNotFound
 		}
 
@@ -113,12 +213,12 @@ func (p *proxy) getBlock(tag string, pull func(*string) (*types.Block, error)) (
 
 // blockByTag returns a block at Opera blockchain represented by given tag.
 // The tag could be an encoded block number, or a predefined string tag for "earliest", "latest" or "pending" block.
-func (p *proxy) blockByTag(tag *string) (*types.Block, error) {
+func (p *proxy) blockByTag(ctx context.Context, tag *string) (*types.Block, error) {
 	// inform what we do
 	p.log.Debugf("loading block [%s]", *tag)
 
 	// extract the block
-	block, err := p.rpc.Block(tag)
+	block, err := p.rpc.Block(ctx, tag)
 	if err != nil {
 		// block simply not found?
 		if err == eth.ErrNoResult {
@@ -134,7 +234,7 @@ func (p *proxy) blockByTag(tag *string) (*types.Block, error) {
 }
 
 // initBlockList finds and returns the first block of the list and initializes the list internals accordingly.
-func (p *proxy) initBlockList(num *uint64, count int32) (*types.Block, *types.BlockList, error) {
+func (p *proxy) initBlockList(ctx context.Context, num *uint64, count int32) (*types.Block, *types.BlockList, error) {
 	// start from the latest block by default
 	var tag = rpc.BlockTypeLatest
 
@@ -149,7 +249,7 @@ func (p *proxy) initBlockList(num *uint64, count int32) (*types.Block, *types.Bl
 	p.log.Debugf("initializing a new blocks list using tag [%s]", tag)
 
 	// get the latest block to start from
-	fb, err := p.blockByTag(&tag)
+	fb, err := p.blockByTag(ctx, &tag)
 	if err != nil {
 		p.log.Critical("the starting block not found in the blockchain")
 		return nil, nil, err
@@ -175,7 +275,7 @@ func (p *proxy) initBlockList(num *uint64, count int32) (*types.Block, *types.Bl
 }
 
 // pullBlocks pulls specified list of blocks from repository and calculates boundary situation.
-func (p *proxy) pullBlocks(num *uint64, count int32, toPull int32, current *types.Block, list *types.BlockList) {
+func (p *proxy) pullBlocks(ctx context.Context, num *uint64, count int32, toPull int32, current *types.Block, list *types.BlockList) {
 	// prep the scan vars
 	var next *types.Block
 	var tag hexutil.Uint64
@@ -203,7 +303,7 @@ func (p *proxy) pullBlocks(num *uint64, count int32, toPull int32, current *type
 		}
 
 		// try to get next block; break the loop on search issue; in that case <next> will be nil
-		next, err = p.BlockByNumber(&tag)
+		next, err = p.BlockByNumber(ctx, &tag)
 		if err != nil {
 			break
 		}
@@ -227,9 +327,9 @@ func checkBlocksListBoundary(count int32, next *types.Block, list *types.BlockLi
 // If the initial block number is not provided, we start on top, or bottom based on count value.
 //
 // No-number boundaries are handled as follows:
-// 	- For positive count we start from the most recent block and scan to older blocks.
-// 	- For negative count we start from the first block and scan to newer blocks.
-func (p *proxy) Blocks(num *uint64, count int32) (*types.BlockList, error) {
+//   - For positive count we start from the most recent block and scan to older blocks.
+//   - For negative count we start from the first block and scan to newer blocks.
+func (p *proxy) Blocks(ctx context.Context, num *uint64, count int32) (*types.BlockList, error) {
 	// nothing to load?
 	if count == 0 {
 		return nil, fmt.Errorf("nothing to do, zero blocks requested")
@@ -244,13 +344,13 @@ func (p *proxy) Blocks(num *uint64, count int32) (*types.BlockList, error) {
 	}
 
 	// slow block list
-	return p.makeBlocksList(num, count)
+	return p.makeBlocksList(ctx, num, count)
 }
 
 // makeBlocksList creates a block list for defined blocks range.
-func (p *proxy) makeBlocksList(num *uint64, count int32) (*types.BlockList, error) {
+func (p *proxy) makeBlocksList(ctx context.Context, num *uint64, count int32) (*types.BlockList, error) {
 	// init the list
-	current, list, err := p.initBlockList(num, count)
+	current, list, err := p.initBlockList(ctx, num, count)
 	if err != nil {
 		return nil, err
 	}
@@ -267,7 +367,7 @@ func (p *proxy) makeBlocksList(num *uint64, count int32) (*types.BlockList, erro
 	}
 
 	// get specified list of block from repository
-	p.pullBlocks(num, count, toPull, current, list)
+	p.pullBlocks(ctx, num, count, toPull, current, list)
 
 	// if we scanned from bottom up, we need to reverse the list so newer blocks are on top
 	if count < 0 {
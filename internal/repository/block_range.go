@@ -0,0 +1,128 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BlockNumberAtTime finds the number of the first block created at, or after the given UNIX time.
+// The block following the current head is returned if no such block exists yet.
+func (p *proxy) BlockNumberAtTime(ctx context.Context, ts uint64) (uint64, error) {
+	bh, err := p.BlockHeight(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// binary search over the block time stamps; blocks are always ordered by time
+	lo, hi := uint64(0), bh.ToInt().Uint64()+1
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		num := hexutil.Uint64(mid)
+
+		blk, err := p.BlockByNumber(ctx, &num)
+		if err != nil {
+			p.log.Errorf("block #%d not available for time search; %s", mid, err.Error())
+			return 0, err
+		}
+
+		if uint64(blk.TimeStamp) < ts {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, nil
+}
+
+// BlocksByTimeRange provides a list of blocks created in the given UNIX time range, both ends included.
+// Positive count loads blocks below the given block number, from the newest to the oldest,
+// negative count loads blocks above the given block number. The total number of blocks in the range
+// is returned along with the list.
+func (p *proxy) BlocksByTimeRange(ctx context.Context, from uint64, to uint64, num *uint64, count int32) (*types.BlockList, uint64, error) {
+	// find the block numbers range
+	lo, err := p.BlockNumberAtTime(ctx, from)
+	if err != nil {
+		return nil, 0, err
+	}
+	top, err := p.BlockNumberAtTime(ctx, to+1)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	list := types.BlockList{Collection: make([]*types.Block, 0), IsStart: true, IsEnd: true}
+	if top <= lo {
+		return &list, 0, nil
+	}
+	hi := top - 1
+
+	nums := blockRangeNumbers(lo, hi, num, count)
+	if len(nums) == 0 {
+		return &list, hi - lo + 1, nil
+	}
+
+	blocks, err := p.BlocksByNumbers(ctx, nums)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, blk := range blocks {
+		if blk != nil {
+			list.Collection = append(list.Collection, blk)
+		}
+	}
+
+	// newer blocks are on top of the list
+	first, last := nums[0], nums[len(nums)-1]
+	if count < 0 {
+		list.Reverse()
+		first, last = last, first
+	}
+	list.IsStart, list.IsEnd = first == hi, last == lo
+	return &list, hi - lo + 1, nil
+}
+
+// blockRangeNumbers provides the numbers of blocks of the <lo, hi> range to be listed
+// after the given block number in the direction of the count.
+func blockRangeNumbers(lo uint64, hi uint64, num *uint64, count int32) []uint64 {
+	nums := make([]uint64, 0)
+	if count > 0 {
+		start := hi
+		if num != nil {
+			if *num <= lo {
+				return nums
+			}
+			if *num-1 < start {
+				start = *num - 1
+			}
+		}
+		for n := start; n >= lo && len(nums) < int(count); n-- {
+			nums = append(nums, n)
+			if n == 0 {
+				break
+			}
+		}
+		return nums
+	}
+
+	start := lo
+	if num != nil {
+		if *num >= hi {
+			return nums
+		}
+		if *num+1 > start {
+			start = *num + 1
+		}
+	}
+	for n := start; n <= hi && len(nums) < int(-count); n++ {
+		nums = append(nums, n)
+	}
+	return nums
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+func TestBlockRangeNumbers(t *testing.T) {
+	num := func(n uint64) *uint64 {
+		return &n
+	}
+
+	tests := []struct {
+		name  string
+		lo    uint64
+		hi    uint64
+		num   *uint64
+		count int32
+		want  []uint64
+	}{
+		{name: "newest first", lo: 10, hi: 20, count: 3, want: []uint64{20, 19, 18}},
+		{name: "below the cursor", lo: 10, hi: 20, num: num(15), count: 3, want: []uint64{14, 13, 12}},
+		{name: "below the cursor to the range start", lo: 10, hi: 20, num: num(12), count: 5, want: []uint64{11, 10}},
+		{name: "below the range start", lo: 10, hi: 20, num: num(10), count: 3, want: []uint64{}},
+		{name: "cursor above the range", lo: 10, hi: 20, num: num(100), count: 3, want: []uint64{20, 19, 18}},
+		{name: "whole range", lo: 10, hi: 12, count: 100, want: []uint64{12, 11, 10}},
+		{name: "down to the genesis", lo: 0, hi: 1, count: 5, want: []uint64{1, 0}},
+		{name: "oldest first", lo: 10, hi: 20, count: -3, want: []uint64{10, 11, 12}},
+		{name: "above the cursor", lo: 10, hi: 20, num: num(18), count: -3, want: []uint64{19, 20}},
+		{name: "above the range end", lo: 10, hi: 20, num: num(20), count: -3, want: []uint64{}},
+		{name: "cursor below the range", lo: 10, hi: 20, num: num(5), count: -3, want: []uint64{10, 11, 12}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			g.Expect(blockRangeNumbers(tt.lo, tt.hi, tt.num, tt.count)).To(gomega.Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"math"
+	"sort"
+	"time"
+)
+
+// blockTimeUpdateRange represents the range for which we do the daily block time update.
+const blockTimeUpdateRange = -2 * 24 * time.Hour
+
+// StoreBlockTime stores the timing of a processed block.
+func (p *proxy) StoreBlockTime(ctx context.Context, bt *types.BlockTime) error {
+	return p.db.AddBlockTime(ctx, bt)
+}
+
+// BlockTimeStats provides statistics of the block time and the observed time to finality
+// over the given number of the most recent blocks.
+func (p *proxy) BlockTimeStats(ctx context.Context, window int32) (*types.BlockTimeStats, error) {
+	list, err := p.db.BlockTimes(ctx, window)
+	if err != nil {
+		return nil, err
+	}
+
+	// collect the block times and the finality times
+	dlt := make([]int64, 0, len(list))
+	ttf := make([]int64, 0, len(list))
+	for _, bt := range list {
+		if bt.Delta != nil {
+			dlt = append(dlt, *bt.Delta)
+		}
+		if bt.Ttf != nil {
+			ttf = append(ttf, *bt.Ttf)
+		}
+	}
+
+	st := types.BlockTimeStats{
+		Blocks:    int32(len(list)),
+		TtfBlocks: int32(len(ttf)),
+	}
+	st.AvgBlockTime, st.MedianBlockTime, st.P95BlockTime = durationStats(dlt)
+	st.AvgTtf, st.MedianTtf, st.P95Ttf = durationStats(ttf)
+	return &st, nil
+}
+
+// BlockTimeDaily provides the list of daily block time aggregations.
+func (p *proxy) BlockTimeDaily(ctx context.Context, from *time.Time, to *time.Time) ([]*types.DailyBlockTime, error) {
+	return p.db.BlockTimeDailyList(ctx, from, to)
+}
+
+// BlockTimeDailyUpdate executes the daily block time aggregation update in the database.
+func (p *proxy) BlockTimeDailyUpdate(ctx context.Context) {
+	// calculate previous midnight
+	now := time.Now().UTC()
+	from := now.Truncate(24 * time.Hour).Add(blockTimeUpdateRange)
+
+	if err := p.db.BlockTimeDailyUpdate(ctx, from); err != nil {
+		p.log.Criticalf("can not update daily block times; %s", err.Error())
+		return
+	}
+	p.log.Debugf("daily block times updated")
+}
+
+// durationStats calculates the average, the median and the 95th percentile
+// of the given durations in milliseconds; the results are in seconds.
+func durationStats(list []int64) (avg float64, median float64, p95 float64) {
+	if len(list) == 0 {
+		return 0, 0, 0
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+
+	var sum int64
+	for _, v := range list {
+		sum += v
+	}
+
+	avg = float64(sum) / float64(len(list)) / 1000
+	median = float64(percentile(list, 50)) / 1000
+	p95 = float64(percentile(list, 95)) / 1000
+	return avg, median, p95
+}
+
+// percentile provides the nearest rank percentile of the given sorted list.
+func percentile(sorted []int64, pct float64) int64 {
+	rank := int(math.Ceil(pct / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
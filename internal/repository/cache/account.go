@@ -24,7 +24,7 @@ func accountId(addr *common.Address) string {
 // PullAccount extracts account information from the in-memory cache if available.
 func (b *MemBridge) PullAccount(addr *common.Address) *types.Account {
 	// try to get the account data from the cache
-	data, err := b.cache.Get(accountId(addr))
+	data, err := b.storeOf(entityAccounts).Get(accountId(addr))
 	if err != nil {
 		// cache returns ErrEntryNotFound if the key does not exist
 		return nil
@@ -55,14 +55,14 @@ func (b *MemBridge) PushAccount(acc *types.Account) error {
 	}
 
 	// set the data to cache
-	return b.cache.Set(accountId(&acc.Address), data)
+	return b.storeOf(entityAccounts).Set(accountId(&acc.Address), data)
 }
 
 // CheckAccountKnown verifies if the cache is aware of the account existence
 // in the database.
 func (b *MemBridge) CheckAccountKnown(addr *common.Address) *bool {
 	// try to get the account data from the cache
-	data, err := b.cache.Get(addr.Hex())
+	data, err := b.storeOf(entityAccounts).Get(addr.Hex())
 	if err != nil {
 		return nil
 	}
@@ -75,7 +75,7 @@ func (b *MemBridge) CheckAccountKnown(addr *common.Address) *bool {
 // PushAccountKnown caches the known account state.
 func (b *MemBridge) PushAccountKnown(addr *common.Address) {
 	// cache the account existence
-	err := b.cache.Set(addr.Hex(), []byte{1})
+	err := b.storeOf(entityAccounts).Set(addr.Hex(), []byte{1})
 	if err != nil {
 		b.log.Errorf("can not cache account %s existence; %s", addr.String(), err.Error())
 	}
@@ -0,0 +1,62 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/allegro/bigcache"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// accountLabelCacheKeyPrefix is the prefix of the cache keys of account labels.
+const accountLabelCacheKeyPrefix = "lbl_"
+
+// accountLabelNone represents the cached mark of an address without label.
+var accountLabelNone = []byte{0}
+
+// PullAccountLabel extracts the label of the given address from the in-memory cache if available.
+// The second value signals if the address is known to the cache, even without a label.
+func (b *MemBridge) PullAccountLabel(addr *common.Address) (*types.AccountLabel, bool) {
+	// try to get the data from the cache
+	data, err := b.cache.Get(accountLabelCacheKeyPrefix + addr.String())
+	if err != nil {
+		// cache returns ErrEntryNotFound if the key does not exist
+		return nil, false
+	}
+
+	// the address has no label
+	if len(data) == 1 && data[0] == accountLabelNone[0] {
+		return nil, true
+	}
+
+	al, err := types.UnmarshalAccountLabel(data)
+	if err != nil {
+		b.log.Criticalf("can not decode account label from in-memory cache; %s", err.Error())
+		return nil, false
+	}
+	return al, true
+}
+
+// PushAccountLabel stores the label of the given address in the in-memory cache.
+// Nil label marks the address as not labeled.
+func (b *MemBridge) PushAccountLabel(addr *common.Address, al *types.AccountLabel) {
+	data := accountLabelNone
+	if al != nil {
+		var err error
+		if data, err = al.Marshal(); err != nil {
+			b.log.Criticalf("can not marshal account label to JSON; %s", err.Error())
+			return
+		}
+	}
+
+	if err := b.cache.Set(accountLabelCacheKeyPrefix+addr.String(), data); err != nil {
+		b.log.Errorf("can not cache label of %s; %s", addr.String(), err.Error())
+	}
+}
+
+// EvictAccountLabel makes sure the label of the given address is not kept in the cache.
+func (b *MemBridge) EvictAccountLabel(addr *common.Address) {
+	err := b.cache.Delete(accountLabelCacheKeyPrefix + addr.String())
+	if err != nil && err != bigcache.ErrEntryNotFound {
+		b.log.Criticalf("cache error %s", err.Error())
+	}
+}
@@ -7,9 +7,12 @@ import (
 )
 
 // AddBlock adds a new block to the in-memory ring for fast load.
+// The block is not considered missing anymore.
 func (b *MemBridge) AddBlock(blk *types.Block) {
 	if blk != nil {
 		b.blkRing.Add((unsafe.Pointer)(blk))
+		b.EvictNotFound(NotFoundBlock, blk.Number.String())
+		b.EvictNotFound(NotFoundBlock, blk.Hash.String())
 	}
 }
 
@@ -4,12 +4,13 @@ package cache
 import (
 	"fantom-api-graphql/internal/types"
 	"fmt"
+	"github.com/allegro/bigcache"
 )
 
 // PullBlock extracts block information from the in-memory cache if available.
 func (b *MemBridge) PullBlock(key string) *types.Block {
 	// try to get the account data from the cache
-	data, err := b.cache.Get(key)
+	data, err := b.storeOf(entityBlocks).Get(key)
 	if err != nil {
 		// cache returns ErrEntryNotFound if the key does not exist
 		return nil
@@ -40,5 +41,13 @@ func (b *MemBridge) PushBlock(key string, blk *types.Block) error {
 	}
 
 	// set the data to cache by block number
-	return b.cache.Set(key, data)
+	return b.storeOf(entityBlocks).Set(key, data)
+}
+
+// EvictBlock makes sure the block of the given key is not kept in the cache.
+func (b *MemBridge) EvictBlock(key string) {
+	err := b.storeOf(entityBlocks).Delete(key)
+	if err != nil && err != bigcache.ErrEntryNotFound {
+		b.log.Criticalf("cache error %s", err.Error())
+	}
 }
@@ -4,8 +4,10 @@ package cache
 import (
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/metrics"
 	"fantom-api-graphql/internal/repository/cache/ring"
 	"github.com/allegro/bigcache"
+	"io"
 	"time"
 )
 
@@ -17,43 +19,212 @@ const TransactionRingCacheSize = 75
 // in fast in-memory ring cache for fast loading.
 const BlockRingCacheSize = 75
 
-// MemBridge represents BigCache abstraction layer.
+// CacheTypeRedis represents the configured cache type of a shared Redis cache.
+const CacheTypeRedis = "redis"
+
+// Cached entities with a configurable cache policy; entities without
+// their own policy share the default cache store.
+const (
+	entityDefault      = "default"
+	entityBlocks       = "blocks"
+	entityTransactions = "transactions"
+	entityAccounts     = "accounts"
+	entityTokens       = "tokens"
+)
+
+// store represents the key-value storage of the cached entries.
+// The local in-memory BigCache is used by default, a shared Redis server can be configured instead
+// so multiple API servers share the cache, and the cache survives restarts.
+type store interface {
+	// Get reads entry for the key; bigcache.ErrEntryNotFound is returned if not found.
+	Get(key string) ([]byte, error)
+
+	// Set saves entry under the key.
+	Set(key string, entry []byte) error
+
+	// Delete removes the key.
+	Delete(key string) error
+}
+
+// MemBridge represents cache abstraction layer.
+// The rings of recent blocks and transactions, and the pending pool snapshot
+// are always kept in the local memory.
 type MemBridge struct {
-	cache *bigcache.BigCache
+	cache store
 	log   logger.Logger
 
+	// stores of the entities with their own cache policy
+	entities map[string]store
+
 	// ring of the most recent blocks and transactions
 	blkRing *ring.Ring
 	trxRing *ring.Ring
+
+	// snapshot of the node transaction pool
+	pending pendingPool
+
+	// short living local cache of not found lookups
+	notFound    *bigcache.BigCache
+	notFoundTTL time.Duration
 }
 
-// New creates a new BigCache bridge.
+// New creates a new cache bridge.
 func New(cfg *config.Config, log logger.Logger) (*MemBridge, error) {
 	// create the cache
-	c, err := bigcache.NewBigCache(cacheConfig(cfg, log))
+	c, err := newStore(cfg, log)
 	if err != nil {
 		log.Critical(err)
 		return nil, err
 	}
 
-	// log the event
-	log.Notice("memory cache initialized")
-
 	// make a new Bridge
-	return &MemBridge{
+	b := &MemBridge{
 		cache: c,
 		log:   log,
 
 		// make rings
 		blkRing: ring.New(BlockRingCacheSize),
 		trxRing: ring.New(TransactionRingCacheSize),
-	}, nil
+
+		// remember recent misses
+		notFound:    newNotFoundCache(cfg.Cache.NotFoundTTL, log),
+		notFoundTTL: cfg.Cache.NotFoundTTL,
+	}
+
+	// make the stores of the entities with their own cache policy
+	b.entities, err = newEntityStores(cfg, c, log)
+	if err != nil {
+		log.Critical(err)
+		return nil, err
+	}
+	return b, nil
+}
+
+// newStore creates the configured default cache store.
+// The store is metered so the cache hit ratio can be observed.
+func newStore(cfg *config.Config, log logger.Logger) (store, error) {
+	if cfg.Cache.Type == CacheTypeRedis {
+		rs, err := newRedisStore(cfg, log)
+		if err != nil {
+			return nil, err
+		}
+		return &meteredStore{store: rs, entity: entityDefault}, nil
+	}
+	return newMemoryStore(entityDefault, cfg.Cache.Eviction, cfg.Cache.MaxSize, log)
+}
+
+// newEntityStores creates the cache stores of the entities with their own cache policy.
+// The options not set by the entity policy are inherited from the default cache policy.
+// Redis stores of the entities share the connection of the default store.
+func newEntityStores(cfg *config.Config, def store, log logger.Logger) (map[string]store, error) {
+	list := make(map[string]store, len(cfg.Cache.Entities))
+	for name, ec := range cfg.Cache.Entities {
+		if ec.Eviction <= 0 {
+			ec.Eviction = cfg.Cache.Eviction
+		}
+		if ec.MaxSize <= 0 {
+			ec.MaxSize = cfg.Cache.MaxSize
+		}
+
+		if rs, ok := def.(*meteredStore).store.(*redisStore); ok {
+			list[name] = &meteredStore{store: &redisStore{cli: rs.cli, ttl: ec.Eviction, shared: true}, entity: name}
+			log.Noticef("redis cache of %s set to %s eviction", name, ec.Eviction)
+			continue
+		}
+
+		c, err := newMemoryStore(name, ec.Eviction, ec.MaxSize, log)
+		if err != nil {
+			return nil, err
+		}
+		list[name] = c
+	}
+	return list, nil
+}
+
+// newMemoryStore creates a local in-memory cache store of the given entity.
+// The number of entries of the store is exported as a metric.
+func newMemoryStore(entity string, eviction time.Duration, size int, log logger.Logger) (store, error) {
+	c, err := bigcache.NewBigCache(cacheConfig(entity, eviction, size, log))
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.ObserveCacheEntries(entity, func() int { return c.Len() })
+
+	// log the event
+	log.Noticef("memory cache of %s initialized, %s eviction, %d MB", entity, eviction, size)
+	return &meteredStore{store: c, entity: entity}, nil
+}
+
+// meteredStore represents a cache store counting the cache hits and misses.
+type meteredStore struct {
+	store
+	entity string
+}
+
+// Get reads entry for the key and counts the lookup result.
+func (ms *meteredStore) Get(key string) ([]byte, error) {
+	data, err := ms.store.Get(key)
+	if err != nil {
+		metrics.CacheMiss(ms.entity)
+	} else {
+		metrics.CacheHit(ms.entity)
+	}
+	return data, err
+}
+
+// storeOf provides the cache store of the given entity; the default store
+// is used if the entity does not have its own cache policy.
+func (b *MemBridge) storeOf(entity string) store {
+	if st, ok := b.entities[entity]; ok {
+		return st
+	}
+	return b.cache
+}
+
+// stores provides all the cache stores, the default one first.
+func (b *MemBridge) stores() []store {
+	list := make([]store, 0, len(b.entities)+1)
+	list = append(list, b.cache)
+	for _, st := range b.entities {
+		list = append(list, st)
+	}
+	return list
+}
+
+// Close releases the cache stores.
+func (b *MemBridge) Close() {
+	for _, st := range b.stores() {
+		if ms, ok := st.(*meteredStore); ok {
+			if cl, ok := ms.store.(io.Closer); ok {
+				if err := cl.Close(); err != nil {
+					b.log.Errorf("can not close %s cache; %s", ms.entity, err.Error())
+				}
+			}
+		}
+	}
+	b.log.Info("cache is closed")
+}
+
+// SetEviction updates the time after which the cached entries of the default
+// cache policy expire. The in-memory cache can not change the eviction time on the fly,
+// the new time is applied there after restart.
+func (b *MemBridge) SetEviction(ttl time.Duration) {
+	if ms, ok := b.cache.(*meteredStore); ok {
+		if rs, ok := ms.store.(*redisStore); ok {
+			rs.setTTL(ttl)
+			b.log.Noticef("cache eviction set to %s", ttl)
+			return
+		}
+	}
+	b.log.Warningf("memory cache eviction change to %s requires restart", ttl)
 }
 
-// cacheConfig constructs a configuration structure for BigCache initialization.
-func cacheConfig(cfg *config.Config, log logger.Logger) bigcache.Config {
+// cacheConfig constructs a configuration structure for BigCache initialization
+// of the given entity.
+func cacheConfig(entity string, eviction time.Duration, size int, log logger.Logger) bigcache.Config {
 	// log the info
-	log.Debugf("memory cache eviction set to %s", cfg.Cache.Eviction)
+	log.Debugf("memory cache of %s eviction set to %s", entity, eviction)
 
 	// return the cache config
 	return bigcache.Config{
@@ -61,7 +232,7 @@ func cacheConfig(cfg *config.Config, log logger.Logger) bigcache.Config {
 		Shards: 2048,
 
 		// time after which entry can be evicted
-		LifeWindow: cfg.Cache.Eviction,
+		LifeWindow: eviction,
 
 		// Interval between removing expired entries (clean up).
 		// If set to <= 0 then no action is performed.
@@ -77,7 +248,7 @@ func cacheConfig(cfg *config.Config, log logger.Logger) bigcache.Config {
 		// cache will not allocate more memory than this limit, value in MB
 		// if value is reached then the oldest entries can be overridden for the new ones
 		// 0 value means no size limit
-		HardMaxCacheSize: cfg.Cache.MaxSize,
+		HardMaxCacheSize: size,
 
 		// callback fired when the oldest entry is removed because of its expiration time or no space left
 		// for the new entry, or because delete was called. A bitmask representing the reason will be returned.
@@ -88,7 +259,15 @@ func cacheConfig(cfg *config.Config, log logger.Logger) bigcache.Config {
 		// for the new entry, or because delete was called. A constant representing the reason will be passed through.
 		// Default value is nil which means no callback and it prevents from unwrapping the oldest entry.
 		// Ignored if OnRemove is specified.
-		OnRemoveWithReason: nil,
+		// We count the evictions to be able to tune the cache size and eviction time.
+		OnRemoveWithReason: func(_ string, _ []byte, reason bigcache.RemoveReason) {
+			switch reason {
+			case bigcache.Expired:
+				metrics.CacheEviction(entity, "expired")
+			case bigcache.NoSpace:
+				metrics.CacheEviction(entity, "no_space")
+			}
+		},
 
 		// prints information about additional memory allocation
 		Verbose: true,
@@ -28,7 +28,7 @@ func ErcTokenId(addr *common.Address, tp string) string {
 // PullErc20Token extracts ERC20 token information from the in-memory cache if available.
 func (b *MemBridge) PullErc20Token(addr *common.Address) *types.Erc20Token {
 	// try to get the account data from the cache
-	data, err := b.cache.Get(ErcTokenId(addr, Erc20CacheIdPrefix))
+	data, err := b.storeOf(entityTokens).Get(ErcTokenId(addr, Erc20CacheIdPrefix))
 	if err != nil {
 		// cache returns ErrEntryNotFound if the key does not exist
 		return nil
@@ -59,13 +59,13 @@ func (b *MemBridge) PushErc20Token(token *types.Erc20Token) error {
 	}
 
 	// set the data to cache
-	return b.cache.Set(ErcTokenId(&token.Address, Erc20CacheIdPrefix), data)
+	return b.storeOf(entityTokens).Set(ErcTokenId(&token.Address, Erc20CacheIdPrefix), data)
 }
 
 // PullErc721Contract pulls ERC-721 token contract details from cache, if available.
 func (b *MemBridge) PullErc721Contract(addr *common.Address) *types.Erc721Contract {
 	// try to get the account data from the cache
-	data, err := b.cache.Get(ErcTokenId(addr, Erc721CacheIdPrefix))
+	data, err := b.storeOf(entityTokens).Get(ErcTokenId(addr, Erc721CacheIdPrefix))
 	if err != nil {
 		return nil
 	}
@@ -92,5 +92,5 @@ func (b *MemBridge) PushErc721Contract(tok *types.Erc721Contract) error {
 		b.log.Criticalf("can not marshal ERC721 token to JSON; %s", err.Error())
 		return err
 	}
-	return b.cache.Set(ErcTokenId(&tok.Address, Erc721CacheIdPrefix), data)
+	return b.storeOf(entityTokens).Set(ErcTokenId(&tok.Address, Erc721CacheIdPrefix), data)
 }
@@ -0,0 +1,44 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+)
+
+// gasPriceSuggestionCacheKey is the cache key used to store the gas price suggestion.
+const gasPriceSuggestionCacheKey = "gas_price_suggestion"
+
+// PullGasPriceSuggestion extracts the gas price suggestion from the in-memory cache if available.
+func (b *MemBridge) PullGasPriceSuggestion() *types.GasPriceSuggestion {
+	// try to get the data from the cache
+	data, err := b.cache.Get(gasPriceSuggestionCacheKey)
+	if err != nil {
+		// cache returns ErrEntryNotFound if the key does not exist
+		return nil
+	}
+
+	// do we have the data?
+	gps, err := types.UnmarshalGasPriceSuggestion(data)
+	if err != nil {
+		b.log.Criticalf("can not decode gas price suggestion from in-memory cache; %s", err.Error())
+		return nil
+	}
+	return gps
+}
+
+// PushGasPriceSuggestion stores the provided gas price suggestion in the in-memory cache.
+func (b *MemBridge) PushGasPriceSuggestion(gps *types.GasPriceSuggestion) error {
+	// we need valid suggestion
+	if nil == gps {
+		return fmt.Errorf("undefined gas price suggestion can not be pushed to the in-memory cache")
+	}
+
+	// encode the suggestion
+	data, err := gps.Marshal()
+	if err != nil {
+		b.log.Criticalf("can not marshal gas price suggestion to JSON; %s", err.Error())
+		return err
+	}
+	return b.cache.Set(gasPriceSuggestionCacheKey, data)
+}
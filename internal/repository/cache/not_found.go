@@ -0,0 +1,86 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"encoding/binary"
+	"fantom-api-graphql/internal/logger"
+	"github.com/allegro/bigcache"
+	"time"
+)
+
+// Kinds of the entities remembered as not found.
+const (
+	NotFoundBlock       = "nfb_"
+	NotFoundTransaction = "nft_"
+	NotFoundAccount     = "nfa_"
+)
+
+// notFoundCacheMaxSize represents the max size of the not found lookups cache in MB.
+const notFoundCacheMaxSize = 64
+
+// newNotFoundCache creates the local cache of not found lookups; the entries live
+// only for the given short time so entities appearing on the chain are picked up soon.
+// Nil is returned if the negative caching is disabled.
+func newNotFoundCache(ttl time.Duration, log logger.Logger) *bigcache.BigCache {
+	if ttl <= 0 {
+		return nil
+	}
+
+	cfg := bigcache.DefaultConfig(ttl)
+	cfg.Shards = 64
+	cfg.CleanWindow = ttl
+	cfg.MaxEntrySize = 8
+	cfg.HardMaxCacheSize = notFoundCacheMaxSize
+	cfg.Verbose = false
+	cfg.Logger = log
+
+	c, err := bigcache.NewBigCache(cfg)
+	if err != nil {
+		log.Errorf("can not create not found lookups cache; %s", err.Error())
+		return nil
+	}
+
+	log.Noticef("not found lookups cached for %s", ttl)
+	return c
+}
+
+// IsNotFound checks if the entity of the given kind and key was recently looked up
+// and not found, so the lookup does not need to be repeated.
+func (b *MemBridge) IsNotFound(kind string, key string) bool {
+	if b.notFound == nil {
+		return false
+	}
+
+	data, err := b.notFound.Get(kind + key)
+	if err != nil || len(data) != 8 {
+		return false
+	}
+
+	// the clean up runs only periodically, check the expiration here
+	return time.Now().UnixNano() < int64(binary.BigEndian.Uint64(data))
+}
+
+// PushNotFound remembers the entity of the given kind and key was not found.
+func (b *MemBridge) PushNotFound(kind string, key string) {
+	if b.notFound == nil {
+		return
+	}
+
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, uint64(time.Now().Add(b.notFoundTTL).UnixNano()))
+	if err := b.notFound.Set(kind+key, data); err != nil {
+		b.log.Errorf("can not cache not found %s%s; %s", kind, key, err.Error())
+	}
+}
+
+// EvictNotFound forgets the entity of the given kind and key was not found, e.g. since it just appeared.
+func (b *MemBridge) EvictNotFound(kind string, key string) {
+	if b.notFound == nil {
+		return
+	}
+
+	err := b.notFound.Delete(kind + key)
+	if err != nil && err != bigcache.ErrEntryNotFound {
+		b.log.Errorf("cache error %s", err.Error())
+	}
+}
@@ -0,0 +1,61 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"fantom-api-graphql/internal/types"
+	"sync"
+	"time"
+)
+
+// pendingPoolMinAge represents the minimal age of the pending pool snapshot
+// before it can be replaced by a fresh one after the pool content changed.
+const pendingPoolMinAge = 500 * time.Millisecond
+
+// pendingPoolMaxAge represents the maximal age of the pending pool snapshot;
+// transactions leave the pool by being mined without us being notified.
+const pendingPoolMaxAge = 5 * time.Second
+
+// pendingPool represents a snapshot of pending transactions of the node transaction pool.
+type pendingPool struct {
+	sync.RWMutex
+	list   []*types.Transaction
+	loaded time.Time
+	dirty  bool
+}
+
+// PullPendingTransactions extracts the snapshot of pending transactions from the in-memory cache,
+// if available and still fresh.
+func (b *MemBridge) PullPendingTransactions() []*types.Transaction {
+	b.pending.RLock()
+	defer b.pending.RUnlock()
+
+	// no snapshot yet?
+	if b.pending.list == nil {
+		return nil
+	}
+
+	// is the snapshot too old to be used?
+	age := time.Since(b.pending.loaded)
+	if age > pendingPoolMaxAge || (b.pending.dirty && age > pendingPoolMinAge) {
+		return nil
+	}
+	return b.pending.list
+}
+
+// PushPendingTransactions stores the snapshot of pending transactions in the in-memory cache.
+func (b *MemBridge) PushPendingTransactions(list []*types.Transaction) {
+	b.pending.Lock()
+	defer b.pending.Unlock()
+
+	b.pending.list = list
+	b.pending.loaded = time.Now()
+	b.pending.dirty = false
+}
+
+// MarkPendingTransactionsDirty signals the content of the transaction pool changed
+// and the cached snapshot should be replaced as soon as possible.
+func (b *MemBridge) MarkPendingTransactionsDirty() {
+	b.pending.Lock()
+	b.pending.dirty = true
+	b.pending.Unlock()
+}
@@ -0,0 +1,23 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+// persistedQueryCacheKeyPrefix is the prefix of the cache keys of persisted GraphQL queries.
+const persistedQueryCacheKeyPrefix = "apq_"
+
+// PullPersistedQuery extracts the persisted GraphQL query of the given hash from the in-memory cache if available.
+func (b *MemBridge) PullPersistedQuery(hash string) (string, bool) {
+	// try to get the data from the cache
+	data, err := b.cache.Get(persistedQueryCacheKeyPrefix + hash)
+	if err != nil {
+		// cache returns ErrEntryNotFound if the key does not exist
+		return "", false
+	}
+	return string(data), true
+}
+
+// PushPersistedQuery stores the GraphQL query under the given hash in the in-memory cache.
+func (b *MemBridge) PushPersistedQuery(hash string, query string) {
+	if err := b.cache.Set(persistedQueryCacheKeyPrefix+hash, []byte(query)); err != nil {
+		b.log.Errorf("can not cache persisted query %s; %s", hash, err.Error())
+	}
+}
@@ -0,0 +1,90 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"context"
+	"fmt"
+	"github.com/allegro/bigcache"
+	"strings"
+)
+
+// redisScanBatch represents the number of keys requested from Redis in a single scan step.
+const redisScanBatch = 1000
+
+// redisGlobEscape escapes the glob pattern special characters of Redis key matching.
+var redisGlobEscape = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
+// Purge removes the cached entries of the given key prefix and provides the number of removed entries.
+// All the entries are removed if the prefix is empty. The rings of the recent blocks
+// and transactions are not affected.
+func (b *MemBridge) Purge(prefix string) (int, error) {
+	var total int
+	for _, st := range b.stores() {
+		count, err := purgeStore(st, prefix)
+		total += count
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// purgeStore removes the entries of the given key prefix from the cache store.
+func purgeStore(st store, prefix string) (int, error) {
+	ms, ok := st.(*meteredStore)
+	if !ok {
+		return 0, fmt.Errorf("unknown cache store")
+	}
+
+	switch st := ms.store.(type) {
+	case *bigcache.BigCache:
+		return purgeMemory(st, prefix)
+	case *redisStore:
+		return st.purge(prefix)
+	}
+	return 0, fmt.Errorf("unknown cache store")
+}
+
+// purgeMemory removes the entries of the given key prefix from the in-memory cache.
+func purgeMemory(c *bigcache.BigCache, prefix string) (int, error) {
+	if prefix == "" {
+		count := c.Len()
+		return count, c.Reset()
+	}
+
+	// collect the keys first, the iterator does not support removal
+	keys := make([]string, 0)
+	it := c.Iterator()
+	for it.SetNext() {
+		ei, err := it.Value()
+		if err != nil {
+			continue
+		}
+		if strings.HasPrefix(ei.Key(), prefix) {
+			keys = append(keys, ei.Key())
+		}
+	}
+
+	var count int
+	for _, k := range keys {
+		if err := c.Delete(k); err == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// purge removes the entries of the given key prefix from the Redis server.
+func (rs *redisStore) purge(prefix string) (int, error) {
+	ctx := context.Background()
+
+	var count int
+	it := rs.cli.Scan(ctx, 0, redisKeyPrefix+redisGlobEscape.Replace(prefix)+"*", redisScanBatch).Iterator()
+	for it.Next(ctx) {
+		if err := rs.cli.Del(ctx, it.Val()).Err(); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, it.Err()
+}
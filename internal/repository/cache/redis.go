@@ -0,0 +1,95 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/allegro/bigcache"
+	"github.com/go-redis/redis/v8"
+	"sync/atomic"
+	"time"
+)
+
+// redisCallTimeout represents the time limit of a single Redis call.
+const redisCallTimeout = 2 * time.Second
+
+// redisKeyPrefix represents the prefix of all the keys stored in Redis by the API server.
+const redisKeyPrefix = "fapi:"
+
+// redisStore implements the cache store backed by a shared Redis server.
+// Entries expire after the configured eviction time.
+type redisStore struct {
+	cli *redis.Client
+	ttl time.Duration
+
+	// shared marks a store using the connection of another store
+	shared bool
+}
+
+// newRedisStore connects the Redis server configured for the cache.
+func newRedisStore(cfg *config.Config, log logger.Logger) (*redisStore, error) {
+	opt, err := redis.ParseURL(cfg.Cache.RedisUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	// make sure the server responds
+	cli := redis.NewClient(opt)
+	ctx, cancel := context.WithTimeout(context.Background(), redisCallTimeout)
+	defer cancel()
+
+	if err := cli.Ping(ctx).Err(); err != nil {
+		_ = cli.Close()
+		return nil, err
+	}
+
+	log.Noticef("redis cache connected at %s", opt.Addr)
+	return &redisStore{cli: cli, ttl: cfg.Cache.Eviction}, nil
+}
+
+// Get reads entry for the key. bigcache.ErrEntryNotFound is returned
+// if the entry is not available, same as for the in-memory store.
+func (rs *redisStore) Get(key string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisCallTimeout)
+	defer cancel()
+
+	data, err := rs.cli.Get(ctx, redisKeyPrefix+key).Bytes()
+	if err == redis.Nil {
+		return nil, bigcache.ErrEntryNotFound
+	}
+	return data, err
+}
+
+// Set saves entry under the key.
+func (rs *redisStore) Set(key string, entry []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisCallTimeout)
+	defer cancel()
+
+	return rs.cli.Set(ctx, redisKeyPrefix+key, entry, time.Duration(atomic.LoadInt64((*int64)(&rs.ttl)))).Err()
+}
+
+// setTTL updates the expiration time of the entries stored from now on.
+func (rs *redisStore) setTTL(ttl time.Duration) {
+	atomic.StoreInt64((*int64)(&rs.ttl), int64(ttl))
+}
+
+// Close closes the Redis client, unless it's shared with another store.
+func (rs *redisStore) Close() error {
+	if rs.shared {
+		return nil
+	}
+	return rs.cli.Close()
+}
+
+// Delete removes the key.
+func (rs *redisStore) Delete(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisCallTimeout)
+	defer cancel()
+
+	n, err := rs.cli.Del(ctx, redisKeyPrefix+key).Result()
+	if err == nil && n == 0 {
+		return bigcache.ErrEntryNotFound
+	}
+	return err
+}
@@ -0,0 +1,23 @@
+// Package cache implements bridge to fast in-memory object cache.
+package cache
+
+// responseCacheKeyPrefix is the prefix of the cache keys of resolver responses.
+const responseCacheKeyPrefix = "rsp_"
+
+// PullResolverResponse extracts the encoded resolver response of the given key from the in-memory cache if available.
+func (b *MemBridge) PullResolverResponse(key string) []byte {
+	// try to get the data from the cache
+	data, err := b.cache.Get(responseCacheKeyPrefix + key)
+	if err != nil {
+		// cache returns ErrEntryNotFound if the key does not exist
+		return nil
+	}
+	return data
+}
+
+// PushResolverResponse stores the encoded resolver response under the given key in the in-memory cache.
+func (b *MemBridge) PushResolverResponse(key string, data []byte) {
+	if err := b.cache.Set(responseCacheKeyPrefix+key, data); err != nil {
+		b.log.Errorf("can not cache resolver response %s; %s", key, err.Error())
+	}
+}
@@ -7,9 +7,11 @@ import (
 )
 
 // AddTransaction adds a new transaction to the in-memory ring for fast load.
+// The transaction is not considered missing anymore.
 func (b *MemBridge) AddTransaction(trx *types.Transaction) {
 	if trx != nil {
 		b.trxRing.Add((unsafe.Pointer)(trx))
+		b.EvictNotFound(NotFoundTransaction, trx.Hash.String())
 	}
 }
 
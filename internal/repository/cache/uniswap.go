@@ -91,3 +91,10 @@ func (b *MemBridge) PullAllPairsList() []common.Address {
 	}
 	return list
 }
+
+// EvictAllPairsList removes the list of all uniswap pairs from memory cache.
+func (b *MemBridge) EvictAllPairsList() {
+	if err := b.cache.Delete(uniswapPairListKey); err != nil {
+		b.log.Debugf("can not evict uniswap pairs list; %s", err.Error())
+	}
+}
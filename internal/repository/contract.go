@@ -10,6 +10,7 @@ package repository
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fantom-api-graphql/internal/types"
 	"fmt"
@@ -20,14 +21,14 @@ import (
 )
 
 // Contract extract a smart contract information by account address, if available.
-func (p *proxy) Contract(addr *common.Address) (*types.Contract, error) {
+func (p *proxy) Contract(ctx context.Context, addr *common.Address) (*types.Contract, error) {
 	// try cache first
 	sc := p.cache.PullContract(addr)
 
 	// we still don't know the contract? call the db for that
 	if sc == nil {
 		var err error
-		sc, err = p.db.Contract(addr)
+		sc, err = p.db.Contract(ctx, addr)
 		if err != nil {
 			return nil, err
 		}
@@ -44,9 +45,9 @@ func (p *proxy) Contract(addr *common.Address) (*types.Contract, error) {
 }
 
 // Contracts returns list of smart contracts at Opera blockchain.
-func (p *proxy) Contracts(validatedOnly bool, cursor *string, count int32) (*types.ContractList, error) {
+func (p *proxy) Contracts(ctx context.Context, validatedOnly bool, cursor *string, count int32) (*types.ContractList, error) {
 	// go to the database for the list of contracts searched
-	return p.db.Contracts(validatedOnly, cursor, count)
+	return p.db.Contracts(ctx, validatedOnly, cursor, count)
 }
 
 // cutCodeMetadata removes the IPFS/Swarm metadata information from the code
@@ -54,6 +55,11 @@ func (p *proxy) Contracts(validatedOnly bool, cursor *string, count int32) (*typ
 // adds metadata to the end of the deployed byte code.
 // @see https://solidity.readthedocs.io/en/latest/metadata.html
 func cutCodeMetadata(bc []byte) []byte {
+	// the code is too short to contain any metadata
+	if len(bc) < 2 {
+		return bc
+	}
+
 	// last 2 bytes are expected to contain metadata length
 	bcLen := uint64(len(bc))
 	cut := uint64(bc[bcLen-2])<<8 | uint64(bc[bcLen-1])
@@ -95,6 +101,34 @@ func compareContractCode(tx *types.Transaction, code string) (bool, error) {
 	return res == 0, nil
 }
 
+// compareRuntimeCode compares provided compiled runtime code with the byte code
+// deployed on chain. Constructor parameters are not part of the runtime code,
+// so the comparison covers the whole code except the metadata.
+func compareRuntimeCode(deployed []byte, code string) (bool, error) {
+	// nothing to compare against
+	if len(deployed) == 0 || len(code) == 0 {
+		return false, nil
+	}
+
+	// decode the detail into byte array
+	bc, err := hexutil.Decode(code)
+	if err != nil {
+		return false, err
+	}
+
+	// compare the code without metadata hash on both sides
+	return bytes.Equal(cutCodeMetadata(bc), cutCodeMetadata(deployed)), nil
+}
+
+// solCompilerPath provides the path to the Solidity compiler of the given version.
+// The default compiler is used if the version is not specified, or not configured.
+func (p *proxy) solCompilerPath(ver string) string {
+	if path, ok := p.solCompilers[ver]; ok {
+		return path
+	}
+	return p.solCompiler
+}
+
 // updateContractDetails updates local contract details from the provided compiler
 // output.
 func updateContractDetails(sc *types.Contract, detail *compiler.Contract) {
@@ -118,16 +152,24 @@ func updateContractDetails(sc *types.Contract, detail *compiler.Contract) {
 // ValidateContract tries to validate contract byte code using
 // provided source code. If successful, the contract information
 // is updated the the repository.
-func (p *proxy) ValidateContract(sc *types.Contract) error {
+func (p *proxy) ValidateContract(ctx context.Context, sc *types.Contract) error {
 	// get the byte code of the actual contract
-	tx, err := p.Transaction(&sc.TransactionHash)
+	tx, err := p.Transaction(ctx, &sc.TransactionHash)
 	if err != nil {
 		p.log.Errorf("can not get contract deployment transaction; %s", err.Error())
 		return err
 	}
 
-	// try to compile the source code provided
-	contracts, err := compiler.CompileSolidityString(p.solCompiler, sc.SourceCode)
+	// get the runtime byte code deployed on chain
+	deployed, err := p.rpc.AccountCode(ctx, &sc.Address)
+	if err != nil {
+		p.log.Errorf("can not get contract runtime code; %s", err.Error())
+		return err
+	}
+
+	// try to compile the source code provided; the requested compiler version
+	// is passed in the contract compiler field
+	contracts, err := compiler.CompileSolidityString(p.solCompilerPath(sc.Compiler), sc.SourceCode)
 	if err != nil {
 		p.log.Errorf("solidity code compilation failed")
 		return err
@@ -142,6 +184,15 @@ func (p *proxy) ValidateContract(sc *types.Contract) error {
 			return err
 		}
 
+		// check the runtime code against the code deployed on chain
+		if !match {
+			match, err = compareRuntimeCode(deployed, detail.RuntimeCode)
+			if err != nil {
+				p.log.Errorf("contract runtime code comparison failed")
+				return err
+			}
+		}
+
 		// we have the winner
 		if match {
 			// set the contract name if not done already
@@ -149,11 +200,17 @@ func (p *proxy) ValidateContract(sc *types.Contract) error {
 				sc.Name = strings.TrimPrefix(name, "<stdin>:")
 			}
 
+			// extract the storage layout while the compiler version is still requested one
+			sc.StorageLayout = p.contractStorageLayout(sc, name)
+
 			// update the contract data
 			updateContractDetails(sc, detail)
+			if sc.Deployer == nil {
+				sc.Deployer = &tx.From
+			}
 
 			// write update to the database
-			if err := p.db.UpdateContract(sc); err != nil {
+			if err := p.db.UpdateContract(ctx, sc); err != nil {
 				p.log.Errorf("contract validation failed due to db error; %s", err.Error())
 				return err
 			}
@@ -172,12 +229,12 @@ func (p *proxy) ValidateContract(sc *types.Contract) error {
 }
 
 // StoreContract adds new contract into the repository.
-func (p *proxy) StoreContract(con *types.Contract) error {
+func (p *proxy) StoreContract(ctx context.Context, con *types.Contract) error {
 	// is the a known contract which will be updated?
-	isUpdate := p.db.IsContractKnown(&con.Address)
+	isUpdate := p.db.IsContractKnown(ctx, &con.Address)
 
 	// do the add/update op
-	if err := p.db.AddContract(con); err != nil {
+	if err := p.db.AddContract(ctx, con); err != nil {
 		p.log.Errorf("contract %s store failed; %s", con.Address.String(), err.Error())
 		return err
 	}
@@ -0,0 +1,187 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// contractStorageMaxSlots represents the max number of storage slots read to decode
+// the state variables of a contract; variables in further slots are not decoded.
+const contractStorageMaxSlots = 128
+
+// solStandardOutput represents the part of the Solidity compiler standard JSON output
+// describing the storage layout of the compiled contracts.
+type solStandardOutput struct {
+	Contracts map[string]map[string]struct {
+		StorageLayout struct {
+			Storage []struct {
+				Label  string `json:"label"`
+				Offset int32  `json:"offset"`
+				Slot   string `json:"slot"`
+				Type   string `json:"type"`
+			} `json:"storage"`
+			Types map[string]struct {
+				Encoding      string `json:"encoding"`
+				Label         string `json:"label"`
+				NumberOfBytes string `json:"numberOfBytes"`
+			} `json:"types"`
+		} `json:"storageLayout"`
+	} `json:"contracts"`
+}
+
+// ContractByteCode provides the runtime byte code deployed on the given contract address.
+func (p *proxy) ContractByteCode(ctx context.Context, addr *common.Address) (hexutil.Bytes, error) {
+	return p.rpc.AccountCode(ctx, addr)
+}
+
+// ContractStorageAt provides the value of the given storage slot of the contract
+// at the given block, or at the latest block if no block is given.
+func (p *proxy) ContractStorageAt(ctx context.Context, addr *common.Address, slot common.Hash, block *hexutil.Uint64) (common.Hash, error) {
+	return p.rpc.StorageAt(ctx, addr, slot, block)
+}
+
+// ContractStorage provides the state variables of the contract with the values decoded
+// from the contract storage at the given block, or at the latest block if no block is given.
+// Only the value types placed inside a single slot are decoded, other values are left empty.
+func (p *proxy) ContractStorage(ctx context.Context, sc *types.Contract, block *hexutil.Uint64) ([]types.ContractStorageValue, error) {
+	slots := make(map[string]common.Hash)
+	list := make([]types.ContractStorageValue, len(sc.StorageLayout))
+
+	for i, v := range sc.StorageLayout {
+		list[i].ContractStorageVariable = v
+		if v.Encoding != "inplace" || v.Size <= 0 || v.Offset+v.Size > common.HashLength {
+			continue
+		}
+
+		val, ok := slots[v.Slot]
+		if !ok {
+			if len(slots) >= contractStorageMaxSlots {
+				continue
+			}
+
+			idx, ok := new(big.Int).SetString(v.Slot, 10)
+			if !ok {
+				continue
+			}
+
+			var err error
+			val, err = p.rpc.StorageAt(ctx, &sc.Address, common.BigToHash(idx), block)
+			if err != nil {
+				return nil, err
+			}
+			slots[v.Slot] = val
+		}
+		list[i].Value = decodeStorageValue(&v, val)
+	}
+	return list, nil
+}
+
+// decodeStorageValue decodes the value of the given state variable from its storage slot.
+// Nil is returned for types which can not be decoded from the slot content alone.
+func decodeStorageValue(v *types.ContractStorageVariable, slot common.Hash) *string {
+	raw := slot[common.HashLength-v.Offset-v.Size : common.HashLength-v.Offset]
+
+	var val string
+	switch {
+	case v.Type == "bool":
+		val = strconv.FormatBool(raw[len(raw)-1] != 0)
+	case v.Size == common.AddressLength && (strings.HasPrefix(v.Type, "address") || strings.HasPrefix(v.Type, "contract ")):
+		val = common.BytesToAddress(raw).String()
+	case strings.HasPrefix(v.Type, "uint") || strings.HasPrefix(v.Type, "enum "):
+		val = new(big.Int).SetBytes(raw).String()
+	case strings.HasPrefix(v.Type, "int"):
+		// negative values are stored in two's complement
+		num := new(big.Int).SetBytes(raw)
+		if raw[0]&0x80 != 0 {
+			num.Sub(num, new(big.Int).Lsh(big.NewInt(1), uint(8*len(raw))))
+		}
+		val = num.String()
+	case strings.HasPrefix(v.Type, "bytes"):
+		val = hexutil.Encode(raw)
+	default:
+		return nil
+	}
+	return &val
+}
+
+// contractStorageLayout provides the storage layout of the given contract compiled
+// from the contract source code. The layout is extracted on best effort basis,
+// older compilers do not provide it and nil is returned.
+func (p *proxy) contractStorageLayout(sc *types.Contract, name string) []types.ContractStorageVariable {
+	input, err := json.Marshal(map[string]interface{}{
+		"language": "Solidity",
+		"sources": map[string]interface{}{
+			"<stdin>": map[string]string{"content": sc.SourceCode},
+		},
+		"settings": map[string]interface{}{
+			"outputSelection": map[string]interface{}{
+				"*": map[string][]string{"*": {"storageLayout"}},
+			},
+		},
+	})
+	if err != nil {
+		return nil
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.Command(p.solCompilerPath(sc.Compiler), "--standard-json")
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		p.log.Warningf("can not get storage layout of contract %s; %s", sc.Address.String(), err.Error())
+		return nil
+	}
+
+	var out solStandardOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		p.log.Warningf("can not decode storage layout of contract %s; %s", sc.Address.String(), err.Error())
+		return nil
+	}
+
+	source := strings.SplitN(name, ":", 2)
+	if len(source) != 2 {
+		return nil
+	}
+
+	detail, ok := out.Contracts[source[0]][source[1]]
+	if !ok || len(detail.StorageLayout.Storage) == 0 {
+		return nil
+	}
+
+	list := make([]types.ContractStorageVariable, len(detail.StorageLayout.Storage))
+	for i, s := range detail.StorageLayout.Storage {
+		t := detail.StorageLayout.Types[s.Type]
+		size, err := strconv.ParseInt(t.NumberOfBytes, 10, 32)
+		if err != nil {
+			p.log.Warningf("invalid size %s of storage type %s", t.NumberOfBytes, s.Type)
+		}
+
+		list[i] = types.ContractStorageVariable{
+			Label:    s.Label,
+			Type:     t.Label,
+			Slot:     s.Slot,
+			Offset:   s.Offset,
+			Size:     int32(size),
+			Encoding: t.Encoding,
+		}
+	}
+
+	p.log.Debugf("storage layout of contract %s has %d variables", sc.Address.String(), len(list))
+	return list
+}
@@ -0,0 +1,57 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+const (
+	// contractUsageUpdateRange represents the range for which we do the daily contract usage update.
+	contractUsageUpdateRange = -2 * 24 * time.Hour
+
+	// contractTopGasRange represents the range of the daily contract usage ranked by the gas used.
+	contractTopGasRange = -30 * 24 * time.Hour
+
+	// contractTopGasSize represents the number of the contracts kept in the gas used ranking.
+	contractTopGasSize = 100
+)
+
+// ContractUsage provides the list of daily usage aggregations of the given contract.
+func (p *proxy) ContractUsage(ctx context.Context, adr *common.Address, from *time.Time, to *time.Time) ([]*types.ContractDailyUsage, error) {
+	return p.db.ContractUsage(ctx, adr, from, to)
+}
+
+// TopContractsByGas provides the given number of the contracts with the highest gas used
+// by their calls over the recent days.
+func (p *proxy) TopContractsByGas(ctx context.Context, count int32) ([]*types.ContractGasRank, error) {
+	return p.db.TopContractsByGas(ctx, count)
+}
+
+// ContractUsageUpdate executes the daily contract usage aggregation update
+// and recalculates the ranking of the contracts by the gas used.
+func (p *proxy) ContractUsageUpdate(ctx context.Context) {
+	// calculate previous midnight
+	now := time.Now().UTC()
+	from := now.Truncate(24 * time.Hour).Add(contractUsageUpdateRange)
+
+	if err := p.db.ContractUsageDailyUpdate(ctx, from); err != nil {
+		p.log.Criticalf("can not update daily contract usage; %s", err.Error())
+		return
+	}
+
+	if err := p.db.ContractTopGasUpdate(ctx, now.Truncate(24*time.Hour).Add(contractTopGasRange), contractTopGasSize); err != nil {
+		p.log.Criticalf("can not update contracts ranking; %s", err.Error())
+		return
+	}
+	p.log.Debugf("daily contract usage updated")
+}
@@ -8,8 +8,10 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"math/big"
 	"time"
 )
 
@@ -30,6 +32,20 @@ const (
 	// fiAccountTransactionCounter is the name of the field of the account transaction counter.
 	fiAccountTransactionCounter = "atc"
 
+	// fiAccountFirstBlock is the name of the field of the block of the account first appearance.
+	fiAccountFirstBlock = "fab"
+
+	// fiAccountFirstSeen is the name of the field of the time stamp of the account first appearance.
+	fiAccountFirstSeen = "fat"
+
+	// fiAccountBalance is the name of the field of the account balance in WEI;
+	// the balance is kept as a decimal, so it can be sorted and adjusted in place.
+	fiAccountBalance = "bal"
+
+	// fiAccountBalanceBlock is the name of the field of the block the account balance has been read at;
+	// balance changes of this and older blocks are already included in the balance.
+	fiAccountBalanceBlock = "bal_blk"
+
 	// fiScCreationTx is the name of the field of the transaction hash
 	// which created the contract, if the account is a contract.
 	fiScCreationTx = "sc"
@@ -45,23 +61,31 @@ type AccountRow struct {
 	Sc       *string      `bson:"sc"`
 	Activity uint64       `bson:"ats"`
 	Counter  uint64       `bson:"atc"`
+	FirstBlk uint64       `bson:"fab"`
+	FirstTs  uint64       `bson:"fat"`
 	ScHash   *common.Hash `bson:"-"`
 }
 
 // initAccountsCollection initializes the account collection with
 // indexes and additional parameters needed by the app.
-func (db *MongoDbBridge) initAccountsCollection() {
+func (db *MongoDbBridge) initAccountsCollection(col *mongo.Collection) {
+	// index the balance so the rich list can be loaded fast
+	if _, err := col.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: fiAccountBalance, Value: -1}},
+	}); err != nil {
+		db.log.Panicf("can not create indexes for accounts collection; %s", err.Error())
+	}
 	db.log.Debugf("accounts collection initialized")
 }
 
 // Account tries to load an account identified by the address given from
 // the off-chain database.
-func (db *MongoDbBridge) Account(addr *common.Address) (*types.Account, error) {
+func (db *MongoDbBridge) Account(ctx context.Context, addr *common.Address) (*types.Account, error) {
 	// get the collection for account transactions
 	col := db.client.Database(db.dbName).Collection(coAccounts)
 
 	// try to find the account
-	sr := col.FindOne(context.Background(), bson.D{{Key: fiAccountPk, Value: addr.String()}}, options.FindOne())
+	sr := col.FindOne(ctx, bson.D{{Key: fiAccountPk, Value: addr.String()}}, options.FindOne())
 
 	// error on lookup?
 	if sr.Err() != nil {
@@ -82,6 +106,11 @@ func (db *MongoDbBridge) Account(addr *common.Address) (*types.Account, error) {
 		return nil, err
 	}
 
+	return row.account(addr), nil
+}
+
+// account builds the account from the account row.
+func (row *AccountRow) account(addr *common.Address) *types.Account {
 	// any hash?
 	if row.Sc != nil {
 		h := common.HexToHash(*row.Sc)
@@ -94,11 +123,52 @@ func (db *MongoDbBridge) Account(addr *common.Address) (*types.Account, error) {
 		Type:         row.Type,
 		LastActivity: hexutil.Uint64(row.Activity),
 		TrxCounter:   hexutil.Uint64(row.Counter),
-	}, nil
+		FirstBlock:   hexutil.Uint64(row.FirstBlk),
+		FirstSeen:    hexutil.Uint64(row.FirstTs),
+	}
+}
+
+// Accounts loads the accounts identified by the given addresses from the off-chain database
+// in a single query. Accounts not found in the database are not included in the result.
+func (db *MongoDbBridge) Accounts(ctx context.Context, adr []*common.Address) (map[common.Address]*types.Account, error) {
+	// get the collection for accounts
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+
+	ids := make(bson.A, len(adr))
+	for i, a := range adr {
+		ids[i] = a.String()
+	}
+
+	// find all the accounts
+	cursor, err := col.Find(ctx, bson.D{{Key: fiAccountPk, Value: bson.D{{Key: "$in", Value: ids}}}})
+	if err != nil {
+		db.log.Errorf("can not load %d accounts; %s", len(adr), err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	// decode the rows
+	res := make(map[common.Address]*types.Account, len(adr))
+	for cursor.Next(ctx) {
+		var row AccountRow
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode account; %s", err.Error())
+			return nil, err
+		}
+
+		addr := common.HexToAddress(row.Address)
+		res[addr] = row.account(&addr)
+	}
+	return res, nil
 }
 
 // AddAccount stores an account in the blockchain if not exists.
-func (db *MongoDbBridge) AddAccount(acc *types.Account) error {
+func (db *MongoDbBridge) AddAccount(ctx context.Context, acc *types.Account) error {
 	// do we have account data?
 	if acc == nil {
 		return fmt.Errorf("can not add empty account")
@@ -115,12 +185,14 @@ func (db *MongoDbBridge) AddAccount(acc *types.Account) error {
 	}
 
 	// do the update based on given PK; we don't need to pull the document updated
-	_, err := col.InsertOne(context.Background(), bson.D{
+	_, err := col.InsertOne(ctx, bson.D{
 		{Key: fiAccountPk, Value: acc.Address.String()},
 		{Key: fiScCreationTx, Value: conTx},
 		{Key: fiAccountType, Value: acc.Type},
 		{Key: fiAccountLastActivity, Value: uint64(acc.LastActivity)},
 		{Key: fiAccountTransactionCounter, Value: uint64(acc.TrxCounter)},
+		{Key: fiAccountFirstBlock, Value: uint64(acc.FirstBlock)},
+		{Key: fiAccountFirstSeen, Value: uint64(acc.FirstSeen)},
 	})
 
 	// error on lookup?
@@ -132,7 +204,7 @@ func (db *MongoDbBridge) AddAccount(acc *types.Account) error {
 	// check init state
 	// make sure transactions collection is initialized
 	if db.initAccounts != nil {
-		db.initAccounts.Do(func() { db.initAccountsCollection(); db.initAccounts = nil })
+		db.initAccounts.Do(func() { db.initAccountsCollection(col); db.initAccounts = nil })
 	}
 
 	// log what we have done
@@ -141,12 +213,12 @@ func (db *MongoDbBridge) AddAccount(acc *types.Account) error {
 }
 
 // IsAccountKnown checks if an account document already exists in the database.
-func (db *MongoDbBridge) IsAccountKnown(addr *common.Address) (bool, error) {
+func (db *MongoDbBridge) IsAccountKnown(ctx context.Context, addr *common.Address) (bool, error) {
 	// get the collection for account transactions
 	col := db.client.Database(db.dbName).Collection(coAccounts)
 
 	// try to find the account in the database (it may already exist)
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(ctx, bson.D{
 		{Key: fiAccountPk, Value: addr.String()},
 	}, options.FindOne().SetProjection(bson.D{{Key: fiAccountPk, Value: true}}))
 
@@ -165,12 +237,12 @@ func (db *MongoDbBridge) IsAccountKnown(addr *common.Address) (bool, error) {
 }
 
 // AccountCount calculates total number of accounts in the database.
-func (db *MongoDbBridge) AccountCount() (uint64, error) {
-	return db.EstimateCount(db.client.Database(db.dbName).Collection(coAccounts))
+func (db *MongoDbBridge) AccountCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(coAccounts))
 }
 
 // AccountTransactions loads list of transaction hashes of an account.
-func (db *MongoDbBridge) AccountTransactions(addr *common.Address, rec *common.Address, cursor *string, count int32) (*types.TransactionList, error) {
+func (db *MongoDbBridge) AccountTransactions(ctx context.Context, addr *common.Address, rec *common.Address, cursor *string, count int32, filter *types.TransactionFilter) (*types.TransactionList, error) {
 	// nothing to load?
 	if count == 0 {
 		return nil, fmt.Errorf("nothing to do, zero blocks requested")
@@ -184,19 +256,24 @@ func (db *MongoDbBridge) AccountTransactions(addr *common.Address, rec *common.A
 	// log what we do here
 	db.log.Debugf("loading transactions of %s", addr.String())
 
-	// make the filter for [(from = Account) OR (to = Account)]
-	if rec == nil {
-		filter := bson.D{{Key: "$or", Value: bson.A{bson.D{{Key: "from", Value: addr.String()}}, bson.D{{Key: "to", Value: addr.String()}}}}}
-		return db.Transactions(cursor, count, &filter)
+	// the recipient is an outgoing transactions counterpart
+	if rec != nil {
+		fi := types.TransactionFilter{}
+		if filter != nil {
+			fi = *filter
+		}
+		fi.Direction = types.TransactionDirectionOut
+		fi.Counterpart = rec
+		filter = &fi
 	}
 
-	// return list of transactions filtered by the account and recipient
-	filter := bson.D{{Key: "from", Value: addr.String()}, {Key: "to", Value: rec.String()}}
-	return db.Transactions(cursor, count, &filter)
+	// return list of transactions filtered by the account and the conditions
+	return db.Transactions(ctx, cursor, count, addr, filter)
 }
 
 // AccountMarkActivity marks the latest account activity in the repository.
-func (db *MongoDbBridge) AccountMarkActivity(addr *common.Address, ts uint64) error {
+// The last activity never moves back, so re-processed blocks do not change it.
+func (db *MongoDbBridge) AccountMarkActivity(ctx context.Context, addr *common.Address, ts uint64) error {
 	// log what we do
 	db.log.Debugf("account %s activity at %s", addr.String(), time.Unix(int64(ts), 0).String())
 
@@ -204,10 +281,10 @@ func (db *MongoDbBridge) AccountMarkActivity(addr *common.Address, ts uint64) er
 	col := db.client.Database(db.dbName).Collection(coAccounts)
 
 	// update the contract details
-	if _, err := col.UpdateOne(context.Background(),
+	if _, err := col.UpdateOne(ctx,
 		bson.D{{Key: fiAccountPk, Value: addr.String()}},
 		bson.D{
-			{Key: "$set", Value: bson.D{{Key: fiAccountLastActivity, Value: ts}}},
+			{Key: "$max", Value: bson.D{{Key: fiAccountLastActivity, Value: ts}}},
 			{Key: "$inc", Value: bson.D{{Key: fiAccountTransactionCounter, Value: 1}}},
 		}); err != nil {
 		// log the issue
@@ -218,8 +295,202 @@ func (db *MongoDbBridge) AccountMarkActivity(addr *common.Address, ts uint64) er
 	return nil
 }
 
+// AccountFirstAppearance derives the block number and the time stamp of the first appearance
+// of the account from the indexed transactions and stores them with the account.
+// Zero values are returned if the account has no indexed transaction.
+func (db *MongoDbBridge) AccountFirstAppearance(ctx context.Context, addr *common.Address) (uint64, uint64, error) {
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	// the oldest transaction of the account as the sender, or the recipient
+	var row struct {
+		Block *uint64   `bson:"blk"`
+		Stamp time.Time `bson:"stamp"`
+	}
+	err := col.FindOne(ctx,
+		bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: fiTransactionSender, Value: addr.String()}},
+			bson.D{{Key: fiTransactionRecipient, Value: addr.String()}},
+		}}},
+		options.FindOne().
+			SetSort(bson.D{{Key: fiTransactionOrdinalIndex, Value: 1}}).
+			SetProjection(bson.D{{Key: fiTransactionBlock, Value: 1}, {Key: fiTransactionTimeStamp, Value: 1}}),
+	).Decode(&row)
+	if err == mongo.ErrNoDocuments || (err == nil && row.Block == nil) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		db.log.Errorf("can not find the first transaction of %s; %s", addr.String(), err.Error())
+		return 0, 0, err
+	}
+
+	blk, ts := *row.Block, uint64(row.Stamp.Unix())
+	if _, err := db.client.Database(db.dbName).Collection(coAccounts).UpdateOne(ctx,
+		bson.D{{Key: fiAccountPk, Value: addr.String()}},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: fiAccountFirstBlock, Value: blk},
+			{Key: fiAccountFirstSeen, Value: ts},
+		}}}); err != nil {
+		db.log.Errorf("can not update account %s first appearance; %s", addr.String(), err.Error())
+	}
+	return blk, ts, nil
+}
+
+// AccountUpdateBalance sets the known balance of the account read at the given block.
+func (db *MongoDbBridge) AccountUpdateBalance(ctx context.Context, addr *common.Address, bal *hexutil.Big, blk uint64) error {
+	// get the collection for accounts
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+
+	val, ok := primitive.ParseDecimal128FromBigInt(bal.ToInt(), 0)
+	if !ok {
+		return fmt.Errorf("balance %s of %s out of range", bal.String(), addr.String())
+	}
+
+	if _, err := col.UpdateOne(ctx,
+		bson.D{{Key: fiAccountPk, Value: addr.String()}},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: fiAccountBalance, Value: val},
+			{Key: fiAccountBalanceBlock, Value: blk},
+		}}}); err != nil {
+		db.log.Errorf("can not update account %s balance; %s", addr.String(), err.Error())
+		return err
+	}
+	return nil
+}
+
+// AccountsAddBalance adds the balance changes of the given block to the known balances of the accounts.
+// Accounts with the balance read at the block, or later, already include the changes and are skipped;
+// so are accounts without known balance.
+func (db *MongoDbBridge) AccountsAddBalance(ctx context.Context, blk uint64, diff map[common.Address]*big.Int) error {
+	// get the collection for accounts
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+
+	list := make([]mongo.WriteModel, 0, len(diff))
+	for adr, val := range diff {
+		if val.Sign() == 0 {
+			continue
+		}
+
+		dec, ok := primitive.ParseDecimal128FromBigInt(val, 0)
+		if !ok {
+			return fmt.Errorf("balance change %s of %s out of range", val.String(), adr.String())
+		}
+
+		list = append(list, mongo.NewUpdateOneModel().
+			SetFilter(bson.D{
+				{Key: fiAccountPk, Value: adr.String()},
+				{Key: fiAccountBalanceBlock, Value: bson.D{{Key: "$lt", Value: blk}}},
+			}).
+			SetUpdate(bson.D{{Key: "$inc", Value: bson.D{{Key: fiAccountBalance, Value: dec}}}}))
+	}
+	if len(list) == 0 {
+		return nil
+	}
+
+	if _, err := col.BulkWrite(ctx, list, options.BulkWrite().SetOrdered(false)); err != nil {
+		db.log.Errorf("can not update balances of block #%d; %s", blk, err.Error())
+		return err
+	}
+	return nil
+}
+
+// AccountsWithoutBalance filters the given accounts to those stored without known balance.
+func (db *MongoDbBridge) AccountsWithoutBalance(ctx context.Context, adr []*common.Address) ([]*common.Address, error) {
+	// get the collection for accounts
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+
+	ids := make(bson.A, len(adr))
+	for i, a := range adr {
+		ids[i] = a.String()
+	}
+
+	cursor, err := col.Find(ctx, bson.D{
+		{Key: fiAccountPk, Value: bson.D{{Key: "$in", Value: ids}}},
+		{Key: fiAccountBalanceBlock, Value: bson.D{{Key: "$exists", Value: false}}},
+	}, options.Find().SetProjection(bson.D{{Key: fiAccountPk, Value: true}}))
+	if err != nil {
+		db.log.Errorf("can not load accounts without balance; %s", err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Errorf("error closing accounts cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*common.Address, 0, len(adr))
+	for cursor.Next(ctx) {
+		var row struct {
+			Address string `bson:"_id"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode account; %s", err.Error())
+			continue
+		}
+
+		a := common.HexToAddress(row.Address)
+		list = append(list, &a)
+	}
+	return list, cursor.Err()
+}
+
+// AccountsByBalance loads a list of accounts sorted by their balance
+// from the highest to the lowest, skipping the given number of accounts.
+func (db *MongoDbBridge) AccountsByBalance(ctx context.Context, skip uint64, count int32) ([]*types.AccountBalance, error) {
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+
+	// load the range of accounts with known balance
+	ld, err := col.Find(ctx,
+		bson.D{{Key: fiAccountBalance, Value: bson.D{{Key: "$exists", Value: true}}}},
+		options.Find().
+			SetSort(bson.D{{Key: fiAccountBalance, Value: -1}, {Key: fiAccountPk, Value: 1}}).
+			SetSkip(int64(skip)).
+			SetLimit(int64(count)).
+			SetProjection(bson.D{{Key: fiAccountPk, Value: true}, {Key: fiAccountBalance, Value: true}}),
+	)
+	if err != nil {
+		db.log.Errorf("can not load accounts by balance; %s", err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing accounts cursor; %s", err.Error())
+		}
+	}()
+
+	// load the list
+	list := make([]*types.AccountBalance, 0)
+	for ld.Next(ctx) {
+		var row struct {
+			Address string               `bson:"_id"`
+			Balance primitive.Decimal128 `bson:"bal"`
+		}
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode account balance; %s", err.Error())
+			continue
+		}
+
+		bal, err := decimalToBig(row.Balance)
+		if err != nil {
+			db.log.Errorf("invalid balance of account %s; %s", row.Address, err.Error())
+			continue
+		}
+
+		list = append(list, &types.AccountBalance{
+			Address: common.HexToAddress(row.Address),
+			Balance: hexutil.Big(*bal),
+			Rank:    skip + uint64(len(list)) + 1,
+		})
+	}
+	return list, nil
+}
+
 // Erc20TokensList returns a list of known ERC20 tokens ordered by their activity.
-func (db *MongoDbBridge) Erc20TokensList(count int32) ([]common.Address, error) {
+func (db *MongoDbBridge) Erc20TokensList(ctx context.Context, count int32) ([]common.Address, error) {
 	// make sure the count is positive; use default size if not
 	if count <= 0 {
 		count = defaultTokenListLength
@@ -239,17 +510,17 @@ func (db *MongoDbBridge) Erc20TokensList(count int32) ([]common.Address, error)
 	}).SetLimit(int64(count))
 
 	// load the data
-	cursor, err := col.Find(context.Background(), filter, opt)
+	cursor, err := col.Find(ctx, filter, opt)
 	if err != nil {
 		db.log.Errorf("error loading ERC20 tokens list; %s", err.Error())
 		return nil, err
 	}
 
-	return db.loadErcContractsList(cursor)
+	return db.loadErcContractsList(ctx, cursor)
 }
 
 // Erc721ContractsList returns a list of known ERC20 tokens ordered by their activity.
-func (db *MongoDbBridge) Erc721ContractsList(count int32) ([]common.Address, error) {
+func (db *MongoDbBridge) Erc721ContractsList(ctx context.Context, count int32) ([]common.Address, error) {
 	// make sure the count is positive; use default size if not
 	if count <= 0 {
 		count = defaultTokenListLength
@@ -269,17 +540,17 @@ func (db *MongoDbBridge) Erc721ContractsList(count int32) ([]common.Address, err
 	}).SetLimit(int64(count))
 
 	// load the data
-	cursor, err := col.Find(context.Background(), filter, opt)
+	cursor, err := col.Find(ctx, filter, opt)
 	if err != nil {
 		db.log.Errorf("error loading ERC721 tokens list; %s", err.Error())
 		return nil, err
 	}
 
-	return db.loadErcContractsList(cursor)
+	return db.loadErcContractsList(ctx, cursor)
 }
 
 // Erc1155ContractsList returns a list of known ERC1155 contracts ordered by their activity.
-func (db *MongoDbBridge) Erc1155ContractsList(count int32) ([]common.Address, error) {
+func (db *MongoDbBridge) Erc1155ContractsList(ctx context.Context, count int32) ([]common.Address, error) {
 	// make sure the count is positive; use default size if not
 	if count <= 0 {
 		count = defaultTokenListLength
@@ -299,19 +570,19 @@ func (db *MongoDbBridge) Erc1155ContractsList(count int32) ([]common.Address, er
 	}).SetLimit(int64(count))
 
 	// load the data
-	cursor, err := col.Find(context.Background(), filter, opt)
+	cursor, err := col.Find(ctx, filter, opt)
 	if err != nil {
 		db.log.Errorf("error loading ERC1155 tokens list; %s", err.Error())
 		return nil, err
 	}
 
-	return db.loadErcContractsList(cursor)
+	return db.loadErcContractsList(ctx, cursor)
 }
 
-func (db *MongoDbBridge) loadErcContractsList(cursor *mongo.Cursor) ([]common.Address, error) {
+func (db *MongoDbBridge) loadErcContractsList(ctx context.Context, cursor *mongo.Cursor) ([]common.Address, error) {
 	// close the cursor as we leave
 	defer func() {
-		err := cursor.Close(context.Background())
+		err := cursor.Close(ctx)
 		if err != nil {
 			db.log.Errorf("error closing ERC contracts list cursor; %s", err.Error())
 		}
@@ -320,7 +591,7 @@ func (db *MongoDbBridge) loadErcContractsList(cursor *mongo.Cursor) ([]common.Ad
 	// loop and load
 	list := make([]common.Address, 0)
 	var row AccountRow
-	for cursor.Next(context.Background()) {
+	for cursor.Next(ctx) {
 		// try to decode the next row
 		if err := cursor.Decode(&row); err != nil {
 			db.log.Errorf("can not decode ERC contracts list row; %s", err.Error())
@@ -333,3 +604,30 @@ func (db *MongoDbBridge) loadErcContractsList(cursor *mongo.Cursor) ([]common.Ad
 
 	return list, nil
 }
+
+// decimalToBig converts the given decimal value to an integer, the fraction is truncated.
+func decimalToBig(d primitive.Decimal128) (*big.Int, error) {
+	val, exp, err := d.BigInt()
+	if err != nil {
+		return nil, err
+	}
+
+	if exp < 0 {
+		return val.Quo(val, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-exp)), nil)), nil
+	}
+	return val.Mul(val, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)), nil
+}
+
+// migrateAccountBalances drops the account balances kept with the reduced precision;
+// the balances are read again from the blockchain as the accounts become active
+// and the balance changes are applied to them from there on.
+func (db *MongoDbBridge) migrateAccountBalances() error {
+	col := db.client.Database(db.dbName).Collection(coAccounts)
+	_, err := col.UpdateMany(context.Background(),
+		bson.D{{Key: fiAccountBalance, Value: bson.D{{Key: "$exists", Value: true}}}},
+		bson.D{{Key: "$unset", Value: bson.D{
+			{Key: fiAccountBalance, Value: ""},
+			{Key: "bal_w", Value: ""},
+		}}})
+	return err
+}
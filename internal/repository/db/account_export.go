@@ -0,0 +1,54 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// accountExportBatchSize represents the number of transactions loaded from the database at once
+// while the transactions of an account are exported.
+const accountExportBatchSize = 500
+
+// AccountTransactionsExport passes all the transactions of the given account made in the given
+// time range to the given callback in the chronological order. The export stops on the first
+// error of the callback, or when the context is cancelled.
+func (db *MongoDbBridge) AccountTransactionsExport(ctx context.Context, addr *common.Address, from time.Time, to time.Time, fn func(*types.Transaction) error) error {
+	col := db.listCollection(coTransactions)
+
+	filter := *transactionListFilter(addr, nil)
+	filter = append(filter, bson.E{Key: fiTransactionTimeStamp, Value: bson.D{
+		{Key: "$gte", Value: from},
+		{Key: "$lte", Value: to},
+	}})
+
+	cursor, err := col.Find(ctx, filter, options.Find().
+		SetSort(bson.D{{Key: fiTransactionOrdinalIndex, Value: 1}}).
+		SetBatchSize(accountExportBatchSize))
+	if err != nil {
+		db.log.Errorf("can not export transactions of %s; %s", addr.String(), err.Error())
+		return err
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	for cursor.Next(ctx) {
+		var trx types.Transaction
+		if err := cursor.Decode(&trx); err != nil {
+			db.log.Errorf("can not decode exported transaction; %s", err.Error())
+			return err
+		}
+		if err := fn(&trx); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
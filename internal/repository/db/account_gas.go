@@ -0,0 +1,54 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+)
+
+// AccountGasStats aggregates the gas used and the fees paid by the transactions
+// sent from the given account after the given time.
+func (db *MongoDbBridge) AccountGasStats(ctx context.Context, addr *common.Address, from time.Time) (*types.AccountGasStats, error) {
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	cr, err := col.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: fiTransactionSender, Value: addr.String()},
+			{Key: fiTransactionTimeStamp, Value: bson.D{{Key: "$gte", Value: from}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: nil},
+			{Key: "trx", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "gas", Value: bson.D{{Key: "$sum", Value: "$gas_use"}}},
+			{Key: "fee", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$multiply", Value: bson.A{"$gas_use", "$gwx100"}}}}}},
+			{Key: "price", Value: bson.D{{Key: "$avg", Value: "$gwx100"}}},
+		}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not aggregate gas stats of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := cr.Close(ctx); err != nil {
+			db.log.Errorf("can not close aggregate cursor; %s", err.Error())
+		}
+	}()
+
+	// no transactions in the window
+	var row types.AccountGasStats
+	if !cr.Next(ctx) {
+		return &row, cr.Err()
+	}
+
+	if err := cr.Decode(&row); err != nil {
+		db.log.Errorf("can not decode gas stats of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	return &row, nil
+}
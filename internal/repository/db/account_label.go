@@ -0,0 +1,97 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colAccountLabels represents the name of the account labels collection in database.
+const colAccountLabels = "account_labels"
+
+// SetAccountLabel stores the given account label, replacing the previous label of the address.
+func (db *MongoDbBridge) SetAccountLabel(ctx context.Context, al *types.AccountLabel) error {
+	// get the collection for account labels
+	col := db.client.Database(db.dbName).Collection(colAccountLabels)
+
+	if _, err := col.ReplaceOne(ctx,
+		bson.D{{Key: types.FiAccountLabelPk, Value: al.Address}},
+		al,
+		options.Replace().SetUpsert(true),
+	); err != nil {
+		db.log.Errorf("can not store label of %s; %s", al.Address, err.Error())
+		return err
+	}
+	return nil
+}
+
+// AccountLabel loads the label of the given address; nil is returned for unlabeled addresses.
+func (db *MongoDbBridge) AccountLabel(ctx context.Context, addr *common.Address) (*types.AccountLabel, error) {
+	// get the collection for account labels
+	col := db.client.Database(db.dbName).Collection(colAccountLabels)
+
+	var row types.AccountLabel
+	err := col.FindOne(ctx, bson.D{{Key: types.FiAccountLabelPk, Value: addr.String()}}).Decode(&row)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		db.log.Errorf("can not load label of %s; %s", addr.String(), err.Error())
+		return nil, err
+	}
+	return &row, nil
+}
+
+// AccountLabels loads the list of account labels, optionally of the given category only.
+// The labels are sorted by name.
+func (db *MongoDbBridge) AccountLabels(ctx context.Context, category *string) ([]*types.AccountLabel, error) {
+	// get the collection for account labels
+	col := db.client.Database(db.dbName).Collection(colAccountLabels)
+
+	filter := bson.D{}
+	if category != nil {
+		filter = append(filter, bson.E{Key: types.FiAccountLabelCategory, Value: *category})
+	}
+
+	cursor, err := col.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: types.FiAccountLabelName, Value: 1}}))
+	if err != nil {
+		db.log.Errorf("can not load account labels; %s", err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.AccountLabel, 0)
+	for cursor.Next(ctx) {
+		var row types.AccountLabel
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode account label; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// RemoveAccountLabel removes the label of the given address.
+// It returns FALSE if the address was not labeled.
+func (db *MongoDbBridge) RemoveAccountLabel(ctx context.Context, addr *common.Address) (bool, error) {
+	// get the collection for account labels
+	col := db.client.Database(db.dbName).Collection(colAccountLabels)
+
+	res, err := col.DeleteOne(ctx, bson.D{{Key: types.FiAccountLabelPk, Value: addr.String()}})
+	if err != nil {
+		db.log.Errorf("can not remove label of %s; %s", addr.String(), err.Error())
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
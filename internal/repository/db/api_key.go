@@ -0,0 +1,60 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+)
+
+// colApiKeys represents the name of the API keys collection in database.
+const colApiKeys = "api_keys"
+
+// AddApiKey stores a new API key in the database.
+func (db *MongoDbBridge) AddApiKey(ctx context.Context, key *types.ApiKey) error {
+	// get the collection for API keys
+	col := db.client.Database(db.dbName).Collection(colApiKeys)
+
+	if _, err := col.InsertOne(ctx, key); err != nil {
+		db.log.Errorf("can not store API key %s; %s", key.Name, err.Error())
+		return err
+	}
+	return nil
+}
+
+// ApiKey loads the API key of the given hash; nil is returned for unknown keys.
+func (db *MongoDbBridge) ApiKey(ctx context.Context, hash string) (*types.ApiKey, error) {
+	// get the collection for API keys
+	col := db.client.Database(db.dbName).Collection(colApiKeys)
+
+	var row types.ApiKey
+	err := col.FindOne(ctx, bson.D{{Key: types.FiApiKeyPk, Value: hash}}).Decode(&row)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+
+		db.log.Errorf("can not load API key; %s", err.Error())
+		return nil, err
+	}
+	return &row, nil
+}
+
+// RevokeApiKey marks the API key of the given hash as revoked.
+// It returns FALSE if no active key of the hash is known.
+func (db *MongoDbBridge) RevokeApiKey(ctx context.Context, hash string) (bool, error) {
+	// get the collection for API keys
+	col := db.client.Database(db.dbName).Collection(colApiKeys)
+
+	res, err := col.UpdateOne(ctx,
+		bson.D{{Key: types.FiApiKeyPk, Value: hash}, {Key: types.FiApiKeyRevoked, Value: nil}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: types.FiApiKeyRevoked, Value: time.Now().UTC()}}}},
+	)
+	if err != nil {
+		db.log.Errorf("can not revoke API key; %s", err.Error())
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
@@ -0,0 +1,81 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// colApiKeyUsage represents the name of the daily API key usage collection.
+const colApiKeyUsage = "api_key_usage"
+
+// migrateApiKeyUsage adds the index of the daily API key usage by the key and the day.
+func (db *MongoDbBridge) migrateApiKeyUsage() error {
+	col := db.client.Database(db.dbName).Collection(colApiKeyUsage)
+	_, err := col.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: types.FiApiKeyUsageKey, Value: 1}, {Key: types.FiApiKeyUsageDay, Value: 1}},
+	})
+	return err
+}
+
+// AddApiKeyUsage adds the given number of queries and their cost to the daily usage of the API key.
+func (db *MongoDbBridge) AddApiKeyUsage(ctx context.Context, u *types.ApiKeyUsage) error {
+	// get the collection for API key usage
+	col := db.client.Database(db.dbName).Collection(colApiKeyUsage)
+
+	_, err := col.UpdateOne(ctx,
+		bson.D{{Key: "_id", Value: types.ApiKeyUsageId(u.Key, u.Day)}},
+		bson.D{
+			{Key: "$setOnInsert", Value: bson.D{
+				{Key: types.FiApiKeyUsageKey, Value: u.Key},
+				{Key: types.FiApiKeyUsageDay, Value: u.Day},
+			}},
+			{Key: "$inc", Value: bson.D{
+				{Key: "queries", Value: u.Queries},
+				{Key: "cost", Value: u.Cost},
+			}},
+		},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		db.log.Errorf("can not update API key usage; %s", err.Error())
+		return err
+	}
+	return nil
+}
+
+// ApiKeyUsage loads the daily usage of the API key of the given hash since the given day.
+func (db *MongoDbBridge) ApiKeyUsage(ctx context.Context, key string, from time.Time) ([]*types.ApiKeyUsage, error) {
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colApiKeyUsage)
+
+	cursor, err := col.Find(ctx, bson.D{
+		{Key: types.FiApiKeyUsageKey, Value: key},
+		{Key: types.FiApiKeyUsageDay, Value: bson.D{{Key: "$gte", Value: from}}},
+	}, options.Find().SetSort(bson.D{{Key: types.FiApiKeyUsageDay, Value: 1}}))
+	if err != nil {
+		db.log.Errorf("can not load API key usage; %s", err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.ApiKeyUsage, 0)
+	for cursor.Next(ctx) {
+		var row types.ApiKeyUsage
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode API key usage; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
@@ -0,0 +1,86 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colAuditLog represents the name of the operations audit log collection in database.
+const colAuditLog = "audit"
+
+// initAuditLogCollection creates the audit log as a capped collection,
+// so the oldest records are dropped once the configured size is reached.
+func (db *MongoDbBridge) initAuditLogCollection() {
+	if err := db.client.Database(db.dbName).CreateCollection(context.Background(), colAuditLog,
+		options.CreateCollection().SetCapped(true).SetSizeInBytes(db.auditSize),
+	); err != nil {
+		db.log.Errorf("can not create audit log collection; %s", err.Error())
+		return
+	}
+	db.log.Debugf("audit log collection initialized")
+}
+
+// AddAuditRecord stores a record of an executed operation in the audit log.
+func (db *MongoDbBridge) AddAuditRecord(ctx context.Context, rec *types.AuditRecord) error {
+	// the capped collection must be created before the first insert
+	if db.initAudit != nil {
+		db.initAudit.Do(func() { db.initAuditLogCollection(); db.initAudit = nil })
+	}
+
+	// get the collection for the audit log
+	col := db.client.Database(db.dbName).Collection(colAuditLog)
+	if _, err := col.InsertOne(ctx, rec); err != nil {
+		db.log.Errorf("can not store audit record of %s; %s", rec.Operation, err.Error())
+		return err
+	}
+	return nil
+}
+
+// AuditCount calculates total number of records in the audit log.
+func (db *MongoDbBridge) AuditCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(colAuditLog))
+}
+
+// AuditLog loads the given number of the most recent audit records
+// optionally filtered by the client and the operation name.
+func (db *MongoDbBridge) AuditLog(ctx context.Context, client *string, operation *string, count int32) ([]*types.AuditRecord, error) {
+	// get the collection
+	col := db.listCollection(colAuditLog)
+
+	filter := bson.D{}
+	if client != nil {
+		filter = append(filter, bson.E{Key: types.FiAuditRecordClient, Value: *client})
+	}
+	if operation != nil {
+		filter = append(filter, bson.E{Key: types.FiAuditRecordOperation, Value: *operation})
+	}
+
+	// the capped collection keeps the insertion order
+	cursor, err := col.Find(ctx, filter, options.Find().
+		SetSort(bson.D{{Key: "$natural", Value: -1}}).
+		SetLimit(int64(count)))
+	if err != nil {
+		db.log.Errorf("can not load audit log; %s", err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.AuditRecord, 0, count)
+	for cursor.Next(ctx) {
+		var row types.AuditRecord
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode audit record; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
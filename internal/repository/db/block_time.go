@@ -0,0 +1,201 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const (
+	// colBlockTimes represents the name of the block times collection in database.
+	colBlockTimes = "blk_time"
+
+	// colBlockTimesDaily represents the name of the daily block times aggregation collection in database.
+	colBlockTimesDaily = "blk_time_daily"
+
+	// fiBlockTimeDailyStamp is the name of the day time stamp field of the daily aggregation.
+	fiBlockTimeDailyStamp = "stamp"
+)
+
+// initBlockTimesCollection initializes the block times collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initBlockTimesCollection(col *mongo.Collection) {
+	// the daily aggregation selects the blocks by time stamp
+	if _, err := col.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{{Key: types.FiBlockTimeStamp, Value: 1}},
+	}); err != nil {
+		db.log.Panicf("can not create indexes for block times collection; %s", err.Error())
+	}
+	db.log.Debugf("block times collection initialized")
+}
+
+// AddBlockTime stores the timing of a processed block; the previous record of the block is replaced.
+func (db *MongoDbBridge) AddBlockTime(ctx context.Context, bt *types.BlockTime) error {
+	// get the collection for block times
+	col := db.client.Database(db.dbName).Collection(colBlockTimes)
+
+	if _, err := col.ReplaceOne(ctx,
+		bson.D{{Key: types.FiBlockTimePk, Value: bt.Number}},
+		bt,
+		options.Replace().SetUpsert(true),
+	); err != nil {
+		db.log.Errorf("can not store time of block #%d; %s", bt.Number, err.Error())
+		return err
+	}
+
+	// make sure block times collection is initialized
+	if db.initBlockTimes != nil {
+		db.initBlockTimes.Do(func() { db.initBlockTimesCollection(col); db.initBlockTimes = nil })
+	}
+	return nil
+}
+
+// BlockTimesCount calculates total number of block time records in the database.
+func (db *MongoDbBridge) BlockTimesCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(colBlockTimes))
+}
+
+// BlockTimeHash loads the hash of the processed block of the given number;
+// nil if the block is not known, or its hash was not recorded.
+func (db *MongoDbBridge) BlockTimeHash(ctx context.Context, number uint64) (*common.Hash, error) {
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colBlockTimes)
+
+	var row types.BlockTime
+	err := col.FindOne(ctx, bson.D{{Key: types.FiBlockTimePk, Value: number}}).Decode(&row)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		db.log.Errorf("can not load time of block #%d; %s", number, err.Error())
+		return nil, err
+	}
+
+	if row.Hash == "" {
+		return nil, nil
+	}
+	hash := common.HexToHash(row.Hash)
+	return &hash, nil
+}
+
+// BlockTimes loads the timing of the given number of the most recent blocks, newest first.
+func (db *MongoDbBridge) BlockTimes(ctx context.Context, count int32) ([]*types.BlockTime, error) {
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colBlockTimes)
+
+	cursor, err := col.Find(ctx, bson.D{}, options.Find().
+		SetSort(bson.D{{Key: types.FiBlockTimePk, Value: -1}}).
+		SetLimit(int64(count)))
+	if err != nil {
+		db.log.Errorf("can not load block times; %s", err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.BlockTime, 0, count)
+	for cursor.Next(ctx) {
+		var row types.BlockTime
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode block time; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// BlockTimeDailyUpdate aggregates the block times created after the given time
+// into the daily block times collection.
+func (db *MongoDbBridge) BlockTimeDailyUpdate(ctx context.Context, from time.Time) error {
+	// log what we do
+	db.log.Noticef("updating daily block times after %s", from)
+
+	// we aggregate block times
+	col := db.client.Database(db.dbName).Collection(colBlockTimes)
+
+	cr, err := col.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: types.FiBlockTimeStamp, Value: bson.D{{Key: "$gte", Value: from}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "$dateToString", Value: bson.D{
+					{Key: "format", Value: "%Y-%m-%d"},
+					{Key: "date", Value: "$stamp"},
+				}},
+			}},
+			{Key: "blocks", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "avg_dlt", Value: bson.D{{Key: "$avg", Value: "$dlt"}}},
+			{Key: "max_dlt", Value: bson.D{{Key: "$max", Value: "$dlt"}}},
+			{Key: "avg_ttf", Value: bson.D{{Key: "$avg", Value: "$ttf"}}},
+			{Key: "max_ttf", Value: bson.D{{Key: "$max", Value: "$ttf"}}},
+		}}},
+		{{Key: "$addFields", Value: bson.D{
+			{Key: fiBlockTimeDailyStamp, Value: bson.D{{Key: "$toDate", Value: "$_id"}}},
+		}}},
+		{{Key: "$merge", Value: bson.D{
+			{Key: "into", Value: colBlockTimesDaily},
+			{Key: "on", Value: "_id"},
+			{Key: "whenMatched", Value: "replace"},
+			{Key: "whenNotMatched", Value: "insert"},
+		}}},
+	}, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		db.log.Errorf("can not update daily block times; %s", err.Error())
+		return err
+	}
+
+	// close the cursor, we don't really need the data
+	if err := cr.Close(ctx); err != nil {
+		db.log.Errorf("can not close aggregate cursor; %s", err.Error())
+	}
+	return nil
+}
+
+// BlockTimeDailyList loads a range of daily block time aggregations from the database.
+func (db *MongoDbBridge) BlockTimeDailyList(ctx context.Context, from *time.Time, to *time.Time) ([]*types.DailyBlockTime, error) {
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colBlockTimesDaily)
+
+	filter := bson.D{}
+	if from != nil {
+		filter = append(filter, bson.E{Key: fiBlockTimeDailyStamp, Value: bson.D{{Key: "$gte", Value: *from}}})
+	}
+	if to != nil {
+		filter = append(filter, bson.E{Key: fiBlockTimeDailyStamp, Value: bson.D{{Key: "$lte", Value: *to}}})
+	}
+
+	// pull the data; make sure there is a limit to the range
+	cursor, err := col.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetLimit(365))
+	if err != nil {
+		db.log.Errorf("can not load daily block times; %s", err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.DailyBlockTime, 0)
+	for cursor.Next(ctx) {
+		var row types.DailyBlockTime
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode daily block time; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
@@ -5,14 +5,21 @@ import (
 	"context"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/metrics"
+	"fantom-api-graphql/internal/tracing"
 	"fmt"
 	"math/big"
 	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // MongoDbBridge represents Mongo DB abstraction layer.
@@ -21,6 +28,10 @@ type MongoDbBridge struct {
 	log    logger.Logger
 	dbName string
 
+	// listDb represents the database used by the heavy list queries,
+	// it may read from secondary members of the replica set
+	listDb *mongo.Database
+
 	// init state marks
 	initAccounts     *sync.Once
 	initTransactions *sync.Once
@@ -33,6 +44,23 @@ type MongoDbBridge struct {
 	initFMintTrx     *sync.Once
 	initEpochs       *sync.Once
 	initGasPrice     *sync.Once
+	initInternalTrx  *sync.Once
+	initGovVotes     *sync.Once
+	initErc20Holders *sync.Once
+	initBlockTimes   *sync.Once
+	initLockups      *sync.Once
+	initValPerf      *sync.Once
+	initEpochVals    *sync.Once
+	initAudit        *sync.Once
+
+	// auditSize represents the max size of the audit log in bytes
+	auditSize int64
+
+	// bulk writers of the scanner writes, if the batching is enabled
+	bulkTrx    *bulkWriter
+	bulkErcTrx *bulkWriter
+	sigFlush   chan bool
+	wg         sync.WaitGroup
 }
 
 // docListCountAggregationTimeout represents a max duration of DB query executed to calculate
@@ -60,13 +88,28 @@ func New(cfg *config.Config, log logger.Logger) (*MongoDbBridge, error) {
 
 	// return the bridge
 	db := &MongoDbBridge{
-		client: con,
-		log:    log,
-		dbName: cfg.Db.DbName,
+		client:    con,
+		log:       log,
+		dbName:    cfg.Db.DbName,
+		auditSize: cfg.Server.Audit.Size,
+	}
+
+	// open the list queries database with the configured read preference
+	db.listDb, err = db.listDatabase(&cfg.Db)
+	if err != nil {
+		log.Criticalf("invalid database read options; %s", err.Error())
+		return nil, err
 	}
 
-	// check the state
+	// check the state and bring the schema up to date
 	db.CheckDatabaseInitState()
+	if err := db.Migrate(); err != nil {
+		db.log.Criticalf("can not migrate the database; %s", err.Error())
+		return nil, err
+	}
+
+	// batch the scanner writes, if enabled
+	db.initBulkWriters(cfg.Db.BatchSize, cfg.Db.FlushInterval)
 	return db, nil
 }
 
@@ -75,8 +118,8 @@ func connectDb(cfg *config.Database) (*mongo.Client, error) {
 	// get empty unrestricted context
 	ctx := context.Background()
 
-	// create new Mongo client
-	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.Url))
+	// create new Mongo client; commands are monitored for metrics
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.Url).SetMonitor(commandMonitor()))
 	if err != nil {
 		return nil, err
 	}
@@ -90,8 +133,73 @@ func connectDb(cfg *config.Database) (*mongo.Client, error) {
 	return client, nil
 }
 
+// listDatabase opens the database handle of the heavy list queries
+// with the read preference and read concern configured.
+func (db *MongoDbBridge) listDatabase(cfg *config.Database) (*mongo.Database, error) {
+	opt := options.Database()
+
+	if cfg.ReadPreference != "" {
+		mode, err := readpref.ModeFromString(cfg.ReadPreference)
+		if err != nil {
+			return nil, err
+		}
+
+		rp, err := readpref.New(mode)
+		if err != nil {
+			return nil, err
+		}
+		opt.SetReadPreference(rp)
+	}
+
+	if cfg.ReadConcern != "" {
+		opt.SetReadConcern(readconcern.New(readconcern.Level(cfg.ReadConcern)))
+	}
+
+	db.log.Noticef("list queries read preference %s, read concern %s", cfg.ReadPreference, cfg.ReadConcern)
+	return db.client.Database(db.dbName, opt), nil
+}
+
+// listCollection provides the collection of the given name for the heavy list queries.
+// The collection may be read from a secondary member of the replica set, so it must not
+// be used by the scanner, which needs to see its own writes.
+func (db *MongoDbBridge) listCollection(name string) *mongo.Collection {
+	return db.listDb.Collection(name)
+}
+
+// commandMonitor creates a Mongo commands monitor collecting the commands duration metrics.
+// Commands issued with a traced context are recorded as trace spans.
+func commandMonitor() *event.CommandMonitor {
+	var spans sync.Map
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, ev *event.CommandStartedEvent) {
+			if _, span := tracing.Start(ctx, "mongo."+ev.CommandName, attribute.String("db.name", ev.DatabaseName)); span.IsRecording() {
+				spans.Store(ev.RequestID, span)
+			}
+		},
+		Succeeded: func(_ context.Context, ev *event.CommandSucceededEvent) {
+			metrics.ObserveDbCommand(ev.CommandName, time.Duration(ev.DurationNanos))
+			if span, ok := spans.LoadAndDelete(ev.RequestID); ok {
+				span.(trace.Span).End()
+			}
+		},
+		Failed: func(_ context.Context, ev *event.CommandFailedEvent) {
+			metrics.ObserveDbCommand(ev.CommandName, time.Duration(ev.DurationNanos))
+			if span, ok := spans.LoadAndDelete(ev.RequestID); ok {
+				tracing.Finish(span.(trace.Span), fmt.Errorf("%s", ev.Failure))
+			}
+		},
+	}
+}
+
 // Close will terminate or finish all operations and close the connection to Mongo database.
 func (db *MongoDbBridge) Close() {
+	// stop the periodic flush and write the pending batches
+	if db.sigFlush != nil {
+		db.sigFlush <- true
+		db.wg.Wait()
+		_ = db.Flush()
+	}
+
 	// do we have a client?
 	if db.client != nil {
 		// prep context
@@ -110,10 +218,7 @@ func (db *MongoDbBridge) Close() {
 }
 
 // getAggregateValue extract single aggregate value for a given collection and aggregation pipeline.
-func (db *MongoDbBridge) getAggregateValue(col *mongo.Collection, pipeline *bson.A) (uint64, error) {
-	// work with context
-	ctx := context.Background()
-
+func (db *MongoDbBridge) getAggregateValue(ctx context.Context, col *mongo.Collection, pipeline *bson.A) (uint64, error) {
 	// use aggregate pipeline to get the result set, should be just one row
 	res, err := col.Aggregate(ctx, *pipeline)
 	if err != nil {
@@ -176,12 +281,20 @@ func (db *MongoDbBridge) CheckDatabaseInitState() {
 	db.collectionNeedInit("fmint transactions", db.FMintTransactionCount, &db.initFMintTrx)
 	db.collectionNeedInit("epochs", db.EpochsCount, &db.initEpochs)
 	db.collectionNeedInit("gas price periods", db.GasPricePeriodCount, &db.initGasPrice)
+	db.collectionNeedInit("internal transactions", db.InternalTransactionsCount, &db.initInternalTrx)
+	db.collectionNeedInit("governance votes", db.GovernanceVotesCount, &db.initGovVotes)
+	db.collectionNeedInit("erc20 holders", db.Erc20HoldersCount, &db.initErc20Holders)
+	db.collectionNeedInit("block times", db.BlockTimesCount, &db.initBlockTimes)
+	db.collectionNeedInit("lockups", db.DelegationLockEventsCount, &db.initLockups)
+	db.collectionNeedInit("validator performance", db.ValidatorPerformanceCount, &db.initValPerf)
+	db.collectionNeedInit("epoch validators", db.EpochValidatorsCount, &db.initEpochVals)
+	db.collectionNeedInit("audit log", db.AuditCount, &db.initAudit)
 }
 
 // checkAccountCollectionState checks the Accounts collection state.
-func (db *MongoDbBridge) collectionNeedInit(name string, counter func() (uint64, error), init **sync.Once) {
+func (db *MongoDbBridge) collectionNeedInit(name string, counter func(context.Context) (uint64, error), init **sync.Once) {
 	// use the counter to get the collection size
-	count, err := counter()
+	count, err := counter(context.Background())
 	if err != nil {
 		db.log.Errorf("can not check %s count; %s", name, err.Error())
 		return
@@ -200,14 +313,14 @@ func (db *MongoDbBridge) collectionNeedInit(name string, counter func() (uint64,
 }
 
 // CountFiltered calculates total number of documents in the given collection for the given filter.
-func (db *MongoDbBridge) CountFiltered(col *mongo.Collection, filter *bson.D) (uint64, error) {
+func (db *MongoDbBridge) CountFiltered(ctx context.Context, col *mongo.Collection, filter *bson.D) (uint64, error) {
 	// make sure some filter is used
 	if nil == filter {
 		filter = &bson.D{}
 	}
 
 	// do the counting
-	val, err := col.CountDocuments(context.Background(), *filter)
+	val, err := col.CountDocuments(ctx, *filter)
 	if err != nil {
 		db.log.Errorf("can not count documents in rewards collection; %s", err.Error())
 		return 0, err
@@ -216,9 +329,9 @@ func (db *MongoDbBridge) CountFiltered(col *mongo.Collection, filter *bson.D) (u
 }
 
 // EstimateCount calculates an estimated number of documents in the given collection.
-func (db *MongoDbBridge) EstimateCount(col *mongo.Collection) (uint64, error) {
+func (db *MongoDbBridge) EstimateCount(ctx context.Context, col *mongo.Collection) (uint64, error) {
 	// do the counting
-	val, err := col.EstimatedDocumentCount(context.Background())
+	val, err := col.EstimatedDocumentCount(ctx)
 	if err != nil {
 		db.log.Errorf("can not count documents in rewards collection; %s", err.Error())
 		return 0, err
@@ -228,9 +341,9 @@ func (db *MongoDbBridge) EstimateCount(col *mongo.Collection) (uint64, error) {
 
 // listDocumentsCount tries to calculate precise documents count and if it's not counted in limited
 // time, use general estimation to speed up the loader.
-func (db *MongoDbBridge) listDocumentsCount(col *mongo.Collection, filter *bson.D) (int64, error) {
+func (db *MongoDbBridge) listDocumentsCount(ctx context.Context, col *mongo.Collection, filter *bson.D) (int64, error) {
 	// try to count the proper way
-	total, err := col.CountDocuments(context.Background(), filter, options.Count().SetMaxTime(docListCountAggregationTimeout))
+	total, err := col.CountDocuments(ctx, filter, options.Count().SetMaxTime(docListCountAggregationTimeout))
 	if err == nil {
 		return total, nil
 	}
@@ -239,7 +352,7 @@ func (db *MongoDbBridge) listDocumentsCount(col *mongo.Collection, filter *bson.
 	db.log.Errorf("can not count documents properly; %s", err.Error())
 
 	// just estimate the whole collection size
-	total, err = col.EstimatedDocumentCount(context.Background())
+	total, err = col.EstimatedDocumentCount(ctx)
 	if err != nil {
 		db.log.Errorf("can not count documents")
 		return 0, err
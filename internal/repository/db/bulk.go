@@ -0,0 +1,137 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// bulkWriter collects write models of a collection and sends them
+// to the database in bulk writes, either once the batch is full, or on flush.
+// The writes are idempotent upserts, so a batch can be safely repeated.
+type bulkWriter struct {
+	mu      sync.Mutex
+	db      *MongoDbBridge
+	col     string
+	size    int
+	queue   []mongo.WriteModel
+	written func(col *mongo.Collection, res *mongo.BulkWriteResult)
+}
+
+// newBulkWriter creates a new bulk writer of the given collection. The written callback
+// is called with the collection and the result after each successful bulk write, if set.
+func (db *MongoDbBridge) newBulkWriter(col string, size int, written func(col *mongo.Collection, res *mongo.BulkWriteResult)) *bulkWriter {
+	return &bulkWriter{
+		db:      db,
+		col:     col,
+		size:    size,
+		queue:   make([]mongo.WriteModel, 0, size),
+		written: written,
+	}
+}
+
+// add queues the write model; the batch is written if it's full.
+func (bw *bulkWriter) add(wm mongo.WriteModel) error {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+
+	bw.queue = append(bw.queue, wm)
+	if len(bw.queue) < bw.size {
+		return nil
+	}
+	return bw.write()
+}
+
+// flush writes the queued write models.
+func (bw *bulkWriter) flush() error {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	return bw.write()
+}
+
+// write sends the queued write models to the database; the caller holds the lock.
+func (bw *bulkWriter) write() error {
+	if len(bw.queue) == 0 {
+		return nil
+	}
+
+	col := bw.db.client.Database(bw.db.dbName).Collection(bw.col)
+	res, err := col.BulkWrite(context.Background(), bw.queue, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		bw.db.log.Errorf("can not write %d documents into %s; %s", len(bw.queue), bw.col, err.Error())
+		return err
+	}
+
+	bw.db.log.Debugf("%d documents written into %s", len(bw.queue), bw.col)
+	bw.queue = bw.queue[:0]
+
+	if bw.written != nil {
+		bw.written(col, res)
+	}
+	return nil
+}
+
+// initBulkWriters prepares the bulk writers of the scanner writes, if the batching is enabled.
+func (db *MongoDbBridge) initBulkWriters(size int, interval time.Duration) {
+	if size <= 0 {
+		return
+	}
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	db.bulkTrx = db.newBulkWriter(coTransactions, size, func(col *mongo.Collection, res *mongo.BulkWriteResult) {
+		if db.initTransactions != nil {
+			db.initTransactions.Do(func() { db.initTransactionsCollection(col); db.initTransactions = nil })
+		}
+
+		// only the new transactions are counted, the replaced ones are already known
+		db.incCounter(context.Background(), counterTransactions, res.UpsertedCount)
+	})
+	db.bulkErcTrx = db.newBulkWriter(colErcTransactions, size, func(col *mongo.Collection, _ *mongo.BulkWriteResult) {
+		if db.initErc20Trx != nil {
+			db.initErc20Trx.Do(func() { db.initErc20TrxCollection(col); db.initErc20Trx = nil })
+		}
+	})
+
+	// flush the batches periodically
+	db.sigFlush = make(chan bool, 1)
+	db.wg.Add(1)
+	go db.flushBulkWriters(interval)
+}
+
+// flushBulkWriters writes the pending batches in the given interval until the bridge is closed.
+func (db *MongoDbBridge) flushBulkWriters(interval time.Duration) {
+	tick := time.NewTicker(interval)
+	defer func() {
+		tick.Stop()
+		db.wg.Done()
+	}()
+
+	for {
+		select {
+		case <-db.sigFlush:
+			return
+		case <-tick.C:
+			_ = db.Flush()
+		}
+	}
+}
+
+// Flush writes the pending batches of the scanner writes, if any.
+// The failed batches are kept for the next flush.
+func (db *MongoDbBridge) Flush() (err error) {
+	for _, bw := range []*bulkWriter{db.bulkTrx, db.bulkErcTrx} {
+		if bw == nil {
+			continue
+		}
+		if e := bw.flush(); e != nil {
+			err = e
+		}
+	}
+	return err
+}
@@ -31,17 +31,22 @@ type ConfigRow struct {
 }
 
 // UpdateLastKnownBlock stores the last known block into the config collection.
-func (db *MongoDbBridge) UpdateLastKnownBlock(blockNo *hexutil.Uint64) error {
+func (db *MongoDbBridge) UpdateLastKnownBlock(ctx context.Context, blockNo *hexutil.Uint64) error {
 	// do we have all needed data?
 	if blockNo == nil {
 		return fmt.Errorf("can not add empty block")
 	}
 
+	// the processed blocks must be written before the checkpoint moves over them
+	if err := db.Flush(); err != nil {
+		return err
+	}
+
 	// get the collection for cfg
 	col := db.client.Database(db.dbName).Collection(coConfiguration)
 
 	// insert/update
-	_, err := col.UpdateByID(context.Background(), keyConfigLastKnownBlock, bson.D{{Key: "$set", Value: bson.D{
+	_, err := col.UpdateByID(ctx, keyConfigLastKnownBlock, bson.D{{Key: "$set", Value: bson.D{
 		{Key: fiConfigPk, Value: keyConfigLastKnownBlock},
 		{Key: fiConfigValue, Value: blockNo.String()},
 	}}}, new(options.UpdateOptions).SetUpsert(true))
@@ -52,12 +57,12 @@ func (db *MongoDbBridge) UpdateLastKnownBlock(blockNo *hexutil.Uint64) error {
 }
 
 // LastKnownBlock returns the last known block from the database.
-func (db *MongoDbBridge) LastKnownBlock() (uint64, error) {
+func (db *MongoDbBridge) LastKnownBlock(ctx context.Context) (uint64, error) {
 	// get the collection for cfg
 	col := db.client.Database(db.dbName).Collection(coConfiguration)
 
 	// get the last known block from the config collection
-	res := col.FindOne(context.Background(), bson.D{{Key: fiConfigPk, Value: keyConfigLastKnownBlock}})
+	res := col.FindOne(ctx, bson.D{{Key: fiConfigPk, Value: keyConfigLastKnownBlock}})
 	if res.Err() == nil {
 		// get the data
 		var row ConfigRow
@@ -76,11 +81,11 @@ func (db *MongoDbBridge) LastKnownBlock() (uint64, error) {
 	}
 
 	// load the slow way
-	return db.lastKnownBlock()
+	return db.lastKnownBlock(ctx)
 }
 
 // lastKnownBlock returns number of the last known block stored in the transactions table.
-func (db *MongoDbBridge) lastKnownBlock() (uint64, error) {
+func (db *MongoDbBridge) lastKnownBlock(ctx context.Context) (uint64, error) {
 	// prep search options
 	opt := options.FindOne()
 	opt.SetSort(bson.D{{Key: fiTransactionBlock, Value: -1}})
@@ -88,7 +93,7 @@ func (db *MongoDbBridge) lastKnownBlock() (uint64, error) {
 
 	// get the collection for account transactions
 	col := db.client.Database(db.dbName).Collection(coTransactions)
-	res := col.FindOne(context.Background(), bson.D{}, opt)
+	res := col.FindOne(ctx, bson.D{}, opt)
 	if res.Err() != nil {
 		// may be no block at all
 		if res.Err() == mongo.ErrNoDocuments {
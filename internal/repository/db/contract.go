@@ -26,8 +26,18 @@ const (
 	// fiContractSourceValidated is the name of the contract source code
 	// validation timestamp field.
 	fiContractSourceValidated = "val"
+
+	// fiContractType is the name of the contract type field.
+	fiContractType = "type"
+
+	// fiContractSymbol is the name of the token symbol field of the contract.
+	fiContractSymbol = "sym"
 )
 
+// contractSymbolCollation represents the collation of the token symbols,
+// the symbols are matched case-insensitive.
+var contractSymbolCollation = options.Collation{Locale: "en", Strength: 2}
+
 // initContractsCollection initializes the contracts collection with
 // indexes and additional parameters needed by the app.
 func (db *MongoDbBridge) initContractsCollection(col *mongo.Collection) {
@@ -42,6 +52,7 @@ func (db *MongoDbBridge) initContractsCollection(col *mongo.Collection) {
 			Unique: &unique,
 		},
 	})
+	ix = append(ix, contractSymbolIndex())
 
 	// create indexes
 	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
@@ -52,8 +63,27 @@ func (db *MongoDbBridge) initContractsCollection(col *mongo.Collection) {
 	db.log.Debugf("contracts collection initialized")
 }
 
+// contractSymbolIndex provides the index of the token contracts by their symbol.
+func contractSymbolIndex() mongo.IndexModel {
+	name := "type_sym"
+	return mongo.IndexModel{
+		Keys: bson.D{{Key: fiContractType, Value: 1}, {Key: fiContractSymbol, Value: 1}},
+		Options: &options.IndexOptions{
+			Name:      &name,
+			Collation: &contractSymbolCollation,
+		},
+	}
+}
+
+// migrateContractSymbol adds the index of the token contracts by their symbol.
+func (db *MongoDbBridge) migrateContractSymbol() error {
+	col := db.client.Database(db.dbName).Collection(coContract)
+	_, err := col.Indexes().CreateOne(context.Background(), contractSymbolIndex())
+	return err
+}
+
 // AddContract stores a smart contract reference in connected persistent storage.
-func (db *MongoDbBridge) AddContract(sc *types.Contract) error {
+func (db *MongoDbBridge) AddContract(ctx context.Context, sc *types.Contract) error {
 	// do we have all needed data?
 	if sc == nil {
 		return fmt.Errorf("can not add empty contract")
@@ -63,7 +93,7 @@ func (db *MongoDbBridge) AddContract(sc *types.Contract) error {
 	col := db.client.Database(db.dbName).Collection(coContract)
 
 	// check if the contract already exists
-	exists, err := db.isContractKnown(col, &sc.Address)
+	exists, err := db.isContractKnown(ctx, col, &sc.Address)
 	if err != nil {
 		db.log.Critical(err)
 		return err
@@ -72,11 +102,11 @@ func (db *MongoDbBridge) AddContract(sc *types.Contract) error {
 	// if the contract already exists, we update it to match the new content
 	if exists {
 		db.log.Debugf("contract %s known, updating", sc.Address.String())
-		return db.UpdateContract(sc)
+		return db.UpdateContract(ctx, sc)
 	}
 
 	// try to do the insert
-	if _, err = col.InsertOne(context.Background(), sc); err != nil {
+	if _, err = col.InsertOne(ctx, sc); err != nil {
 		db.log.Critical(err)
 		return err
 	}
@@ -92,7 +122,7 @@ func (db *MongoDbBridge) AddContract(sc *types.Contract) error {
 
 // UpdateContract updates smart contract information in database to reflect
 // new validation or similar changes passed from repository.
-func (db *MongoDbBridge) UpdateContract(sc *types.Contract) error {
+func (db *MongoDbBridge) UpdateContract(ctx context.Context, sc *types.Contract) error {
 	// complain about missing contract data
 	if sc == nil {
 		db.log.Criticalf("can not update empty contract")
@@ -103,7 +133,7 @@ func (db *MongoDbBridge) UpdateContract(sc *types.Contract) error {
 	col := db.client.Database(db.dbName).Collection(coContract)
 
 	// update the contract details
-	if _, err := col.UpdateOne(context.Background(),
+	if _, err := col.UpdateOne(ctx,
 		bson.D{{Key: fiContractPk, Value: sc.Address.String()}},
 		bson.D{{Key: "$set", Value: sc}}); err != nil {
 		// log the issue
@@ -115,9 +145,9 @@ func (db *MongoDbBridge) UpdateContract(sc *types.Contract) error {
 }
 
 // IsContractKnown checks if a smart contract document already exists in the database.
-func (db *MongoDbBridge) IsContractKnown(addr *common.Address) bool {
+func (db *MongoDbBridge) IsContractKnown(ctx context.Context, addr *common.Address) bool {
 	// check the contract existence in the database
-	known, err := db.isContractKnown(db.client.Database(db.dbName).Collection(coContract), addr)
+	known, err := db.isContractKnown(ctx, db.client.Database(db.dbName).Collection(coContract), addr)
 	if err != nil {
 		return false
 	}
@@ -126,9 +156,9 @@ func (db *MongoDbBridge) IsContractKnown(addr *common.Address) bool {
 }
 
 // isContractKnown checks if a smart contract document already exists in the database.
-func (db *MongoDbBridge) isContractKnown(col *mongo.Collection, addr *common.Address) (bool, error) {
+func (db *MongoDbBridge) isContractKnown(ctx context.Context, col *mongo.Collection, addr *common.Address) (bool, error) {
 	// try to find the contract in the database (it may already exist)
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(ctx, bson.D{
 		{Key: fiContractPk, Value: addr.String()},
 	}, options.FindOne().SetProjection(bson.D{
 		{Key: fiContractPk, Value: true},
@@ -150,9 +180,9 @@ func (db *MongoDbBridge) isContractKnown(col *mongo.Collection, addr *common.Add
 }
 
 // ContractTransaction returns contract creation transaction hash if available.
-func (db *MongoDbBridge) ContractTransaction(addr *common.Address) (*common.Hash, error) {
+func (db *MongoDbBridge) ContractTransaction(ctx context.Context, addr *common.Address) (*common.Hash, error) {
 	// get the contract details from database
-	c, err := db.Contract(addr)
+	c, err := db.Contract(ctx, addr)
 	if err != nil {
 		db.log.Errorf("can not get the contract transaction for [%s]; %s", addr.String(), err.Error())
 		return nil, err
@@ -169,12 +199,12 @@ func (db *MongoDbBridge) ContractTransaction(addr *common.Address) (*common.Hash
 
 // Contract returns details of a smart contract stored in the Mongo database
 // if available, or nil if contract does not exist.
-func (db *MongoDbBridge) Contract(addr *common.Address) (*types.Contract, error) {
+func (db *MongoDbBridge) Contract(ctx context.Context, addr *common.Address) (*types.Contract, error) {
 	// get the collection for transactions
 	col := db.client.Database(db.dbName).Collection(coContract)
 
 	// try to find the contract in the database (it may already exist)
-	sr := col.FindOne(context.Background(), bson.D{{Key: fiContractPk, Value: addr.String()}})
+	sr := col.FindOne(ctx, bson.D{{Key: fiContractPk, Value: addr.String()}})
 
 	// error on lookup?
 	if sr.Err() != nil {
@@ -201,13 +231,81 @@ func (db *MongoDbBridge) Contract(addr *common.Address) (*types.Contract, error)
 	return &con, nil
 }
 
+// ContractsBySymbol provides the addresses of the contracts of the given type
+// issuing a token with the given symbol; the symbol is matched case-insensitive.
+func (db *MongoDbBridge) ContractsBySymbol(ctx context.Context, cType string, sym string, count int32) ([]common.Address, error) {
+	col := db.client.Database(db.dbName).Collection(coContract)
+	ld, err := col.Find(ctx,
+		bson.D{{Key: fiContractType, Value: cType}, {Key: fiContractSymbol, Value: sym}},
+		options.Find().
+			SetCollation(&contractSymbolCollation).
+			SetProjection(bson.D{{Key: fiContractPk, Value: true}}).
+			SetLimit(int64(count)),
+	)
+	if err != nil {
+		db.log.Errorf("can not load %s contracts by symbol %s; %s", cType, sym, err.Error())
+		return nil, err
+	}
+	return db.loadContractAddresses(ctx, ld)
+}
+
+// ContractsWithoutSymbol provides the addresses of the contracts of the given type
+// with the symbol of the token not known yet.
+func (db *MongoDbBridge) ContractsWithoutSymbol(ctx context.Context, cType string) ([]common.Address, error) {
+	col := db.client.Database(db.dbName).Collection(coContract)
+	ld, err := col.Find(ctx,
+		bson.D{{Key: fiContractType, Value: cType}, {Key: fiContractSymbol, Value: bson.D{{Key: "$exists", Value: false}}}},
+		options.Find().SetProjection(bson.D{{Key: fiContractPk, Value: true}}),
+	)
+	if err != nil {
+		db.log.Errorf("can not load %s contracts without symbol; %s", cType, err.Error())
+		return nil, err
+	}
+	return db.loadContractAddresses(ctx, ld)
+}
+
+// ContractUpdateSymbol sets the symbol of the token issued by the given contract.
+func (db *MongoDbBridge) ContractUpdateSymbol(ctx context.Context, addr *common.Address, sym string) error {
+	col := db.client.Database(db.dbName).Collection(coContract)
+	if _, err := col.UpdateOne(ctx,
+		bson.D{{Key: fiContractPk, Value: addr.String()}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: fiContractSymbol, Value: sym}}}},
+	); err != nil {
+		db.log.Errorf("can not update contract %s symbol; %s", addr.String(), err.Error())
+		return err
+	}
+	return nil
+}
+
+// loadContractAddresses loads the addresses of the contracts from the given cursor.
+func (db *MongoDbBridge) loadContractAddresses(ctx context.Context, ld *mongo.Cursor) ([]common.Address, error) {
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing contracts cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]common.Address, 0)
+	for ld.Next(ctx) {
+		var row struct {
+			Address string `bson:"_id"`
+		}
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode contract address; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, common.HexToAddress(row.Address))
+	}
+	return list, nil
+}
+
 // ContractCount calculates total number of contracts in the database.
-func (db *MongoDbBridge) ContractCount() (uint64, error) {
-	return db.EstimateCount(db.client.Database(db.dbName).Collection(coContract))
+func (db *MongoDbBridge) ContractCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(coContract))
 }
 
 // contractListTotal find the total amount of contracts for the criteria and populates the list
-func (db *MongoDbBridge) contractListTotal(col *mongo.Collection, validatedOnly bool, list *types.ContractList) error {
+func (db *MongoDbBridge) contractListTotal(ctx context.Context, col *mongo.Collection, validatedOnly bool, list *types.ContractList) error {
 	// validation filter
 	filter := bson.D{}
 	if validatedOnly {
@@ -215,7 +313,7 @@ func (db *MongoDbBridge) contractListTotal(col *mongo.Collection, validatedOnly
 	}
 
 	// find how many contracts do we have in the database
-	total, err := col.CountDocuments(context.Background(), filter)
+	total, err := col.CountDocuments(ctx, filter)
 	if err != nil {
 		db.log.Errorf("can not count contracts")
 		return err
@@ -237,7 +335,7 @@ func contractListTopFilter(validatedOnly bool, cursor *string) (*bson.D, error)
 		// get the ordinal index based on cursor
 		ix, err = strconv.ParseUint(*cursor, 10, 64)
 		if err != nil {
-			return nil, fmt.Errorf("invalid cursor value; %s", err.Error())
+			return nil, types.Errorf(types.ErrCodeInvalidCursor, "invalid cursor value; %s", err.Error())
 		}
 	}
 
@@ -260,7 +358,7 @@ func contractListTopFilter(validatedOnly bool, cursor *string) (*bson.D, error)
 }
 
 // contractListTop find the first contract of the list based on provided criteria and populates the list.
-func (db *MongoDbBridge) contractListTop(col *mongo.Collection, validatedOnly bool, cursor *string, count int32, list *types.ContractList) error {
+func (db *MongoDbBridge) contractListTop(ctx context.Context, col *mongo.Collection, validatedOnly bool, cursor *string, count int32, list *types.ContractList) error {
 	// get the filter
 	filter, err := contractListTopFilter(validatedOnly, cursor)
 	if err != nil {
@@ -271,21 +369,21 @@ func (db *MongoDbBridge) contractListTop(col *mongo.Collection, validatedOnly bo
 	// find out the cursor ordinal index
 	if cursor == nil && count > 0 {
 		// get the highest available ordinal index (top smart contract)
-		list.First, err = db.findBorderOrdinalIndex(col,
+		list.First, err = db.findBorderOrdinalIndex(ctx, col,
 			*filter,
 			options.FindOne().SetSort(bson.D{{Key: fiContractOrdinalIndex, Value: -1}}))
 		list.IsStart = true
 
 	} else if cursor == nil && count < 0 {
 		// get the lowest available ordinal index (bottom smart contract)
-		list.First, err = db.findBorderOrdinalIndex(col,
+		list.First, err = db.findBorderOrdinalIndex(ctx, col,
 			*filter,
 			options.FindOne().SetSort(bson.D{{Key: fiContractOrdinalIndex, Value: 1}}))
 		list.IsEnd = true
 
 	} else if cursor != nil {
 		// get the highest available ordinal index (top smart contract)
-		list.First, err = db.findBorderOrdinalIndex(col,
+		list.First, err = db.findBorderOrdinalIndex(ctx, col,
 			*filter,
 			options.FindOne())
 	}
@@ -299,7 +397,7 @@ func (db *MongoDbBridge) contractListTop(col *mongo.Collection, validatedOnly bo
 }
 
 // contractListInit initializes list of contracts based on provided cursor and count.
-func (db *MongoDbBridge) contractListInit(col *mongo.Collection, validatedOnly bool, cursor *string, count int32) (*types.ContractList, error) {
+func (db *MongoDbBridge) contractListInit(ctx context.Context, col *mongo.Collection, validatedOnly bool, cursor *string, count int32) (*types.ContractList, error) {
 	// make the list
 	list := types.ContractList{
 		Collection: make([]*types.Contract, 0),
@@ -311,7 +409,7 @@ func (db *MongoDbBridge) contractListInit(col *mongo.Collection, validatedOnly b
 	}
 
 	// calculate the total number of contracts in the list
-	if err := db.contractListTotal(col, validatedOnly, &list); err != nil {
+	if err := db.contractListTotal(ctx, col, validatedOnly, &list); err != nil {
 		return nil, err
 	}
 
@@ -319,7 +417,7 @@ func (db *MongoDbBridge) contractListInit(col *mongo.Collection, validatedOnly b
 	db.log.Debugf("found %d contracts in off-chain database", list.Total)
 
 	// find the top contract of the list
-	if err := db.contractListTop(col, validatedOnly, cursor, count, &list); err != nil {
+	if err := db.contractListTop(ctx, col, validatedOnly, cursor, count, &list); err != nil {
 		return nil, err
 	}
 
@@ -393,10 +491,7 @@ func (db *MongoDbBridge) contractListOptions(count int32) *options.FindOptions {
 }
 
 // contractListLoad loads the initialized contract list from persistent database.
-func (db *MongoDbBridge) contractListLoad(col *mongo.Collection, validatedOnly bool, cursor *string, count int32, list *types.ContractList) error {
-	// get the context for loader
-	ctx := context.Background()
-
+func (db *MongoDbBridge) contractListLoad(ctx context.Context, col *mongo.Collection, validatedOnly bool, cursor *string, count int32, list *types.ContractList) error {
 	// load the data
 	ld, err := col.Find(ctx, db.contractListFilter(validatedOnly, cursor, count, list), db.contractListOptions(count))
 	if err != nil {
@@ -447,7 +542,7 @@ func (db *MongoDbBridge) contractListLoad(col *mongo.Collection, validatedOnly b
 }
 
 // Contracts provides list of smart contracts stored in the persistent storage.
-func (db *MongoDbBridge) Contracts(validatedOnly bool, cursor *string, count int32) (*types.ContractList, error) {
+func (db *MongoDbBridge) Contracts(ctx context.Context, validatedOnly bool, cursor *string, count int32) (*types.ContractList, error) {
 	// nothing to load?
 	if count == 0 {
 		return nil, fmt.Errorf("nothing to do, zero contracts requested")
@@ -457,14 +552,14 @@ func (db *MongoDbBridge) Contracts(validatedOnly bool, cursor *string, count int
 	col := db.client.Database(db.dbName).Collection(coContract)
 
 	// init the list
-	list, err := db.contractListInit(col, validatedOnly, cursor, count)
+	list, err := db.contractListInit(ctx, col, validatedOnly, cursor, count)
 	if err != nil {
 		db.log.Errorf("can not build contract list; %s", err.Error())
 		return nil, err
 	}
 
 	// load data
-	err = db.contractListLoad(col, validatedOnly, cursor, count, list)
+	err = db.contractListLoad(ctx, col, validatedOnly, cursor, count, list)
 	if err != nil {
 		db.log.Errorf("can not load contracts list from database; %s", err.Error())
 		return nil, err
@@ -0,0 +1,202 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+const (
+	// colContractUsage represents the name of the daily contract usage aggregation collection.
+	colContractUsage = "contract_usage"
+
+	// colContractTopGas represents the name of the collection of the contracts ranked by the gas used.
+	colContractTopGas = "contract_top_gas"
+
+	// fiContractUsageContract is the name of the contract address field of the daily usage.
+	fiContractUsageContract = "contract"
+
+	// fiContractUsageStamp is the name of the day time stamp field of the daily usage.
+	fiContractUsageStamp = "stamp"
+
+	// fiContractUsageGas is the name of the gas used field of the daily usage and the ranking.
+	fiContractUsageGas = "gas"
+)
+
+// contractUsageIndex provides the index of the daily usage by the contract and the day.
+func contractUsageIndex() mongo.IndexModel {
+	return mongo.IndexModel{Keys: bson.D{{Key: fiContractUsageContract, Value: 1}, {Key: fiContractUsageStamp, Value: 1}}}
+}
+
+// migrateContractUsage adds the index of the daily contract usage aggregation.
+func (db *MongoDbBridge) migrateContractUsage() error {
+	col := db.client.Database(db.dbName).Collection(colContractUsage)
+	_, err := col.Indexes().CreateOne(context.Background(), contractUsageIndex())
+	return err
+}
+
+// ContractUsageDailyUpdate aggregates the calls of smart contracts made after the given time
+// into the daily contract usage collection. Only recipients known to be contracts are aggregated.
+func (db *MongoDbBridge) ContractUsageDailyUpdate(ctx context.Context, from time.Time) error {
+	// log what we do
+	db.log.Noticef("updating daily contract usage after %s", from)
+
+	// we aggregate transactions
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	cr, err := col.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: fiTransactionTimeStamp, Value: bson.D{{Key: "$gte", Value: from}}},
+			{Key: fiTransactionRecipient, Value: bson.D{{Key: "$ne", Value: nil}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "contract", Value: "$to"},
+				{Key: "day", Value: bson.D{
+					{Key: "$dateToString", Value: bson.D{
+						{Key: "format", Value: "%Y-%m-%d"},
+						{Key: "date", Value: "$stamp"},
+					}},
+				}},
+			}},
+			{Key: "calls", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "callers", Value: bson.D{{Key: "$addToSet", Value: "$from"}}},
+			{Key: "gas", Value: bson.D{{Key: "$sum", Value: "$gas_use"}}},
+		}}},
+		{{Key: "$lookup", Value: bson.D{
+			{Key: "from", Value: coContract},
+			{Key: "localField", Value: "_id.contract"},
+			{Key: "foreignField", Value: fiContractPk},
+			{Key: "as", Value: "sc"},
+		}}},
+		{{Key: "$match", Value: bson.D{{Key: "sc", Value: bson.D{{Key: "$ne", Value: bson.A{}}}}}}},
+		{{Key: "$project", Value: bson.D{
+			{Key: "_id", Value: bson.D{{Key: "$concat", Value: bson.A{"$_id.contract", "/", "$_id.day"}}}},
+			{Key: fiContractUsageContract, Value: "$_id.contract"},
+			{Key: "day", Value: "$_id.day"},
+			{Key: fiContractUsageStamp, Value: bson.D{{Key: "$toDate", Value: "$_id.day"}}},
+			{Key: "calls", Value: 1},
+			{Key: "callers", Value: bson.D{{Key: "$size", Value: "$callers"}}},
+			{Key: fiContractUsageGas, Value: 1},
+		}}},
+		{{Key: "$merge", Value: bson.D{
+			{Key: "into", Value: colContractUsage},
+			{Key: "on", Value: "_id"},
+			{Key: "whenMatched", Value: "replace"},
+			{Key: "whenNotMatched", Value: "insert"},
+		}}},
+	}, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		db.log.Errorf("can not update daily contract usage; %s", err.Error())
+		return err
+	}
+
+	// close the cursor, we don't really need the data
+	if err := cr.Close(ctx); err != nil {
+		db.log.Errorf("can not close aggregate cursor; %s", err.Error())
+	}
+	return nil
+}
+
+// ContractTopGasUpdate ranks the contracts by the gas used by their calls made after the given time.
+// The given number of the top contracts replaces the previous ranking.
+func (db *MongoDbBridge) ContractTopGasUpdate(ctx context.Context, from time.Time, count int64) error {
+	col := db.client.Database(db.dbName).Collection(colContractUsage)
+
+	cr, err := col.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: fiContractUsageStamp, Value: bson.D{{Key: "$gte", Value: from}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$contract"},
+			{Key: "calls", Value: bson.D{{Key: "$sum", Value: "$calls"}}},
+			{Key: fiContractUsageGas, Value: bson.D{{Key: "$sum", Value: "$gas"}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: fiContractUsageGas, Value: -1}}}},
+		{{Key: "$limit", Value: count}},
+		{{Key: "$out", Value: colContractTopGas}},
+	}, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		db.log.Errorf("can not update contracts ranking; %s", err.Error())
+		return err
+	}
+
+	if err := cr.Close(ctx); err != nil {
+		db.log.Errorf("can not close aggregate cursor; %s", err.Error())
+	}
+	return nil
+}
+
+// ContractUsage loads a range of daily usage aggregations of the given contract from the database.
+func (db *MongoDbBridge) ContractUsage(ctx context.Context, adr *common.Address, from *time.Time, to *time.Time) ([]*types.ContractDailyUsage, error) {
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colContractUsage)
+
+	filter := bson.D{{Key: fiContractUsageContract, Value: adr.String()}}
+	if from != nil {
+		filter = append(filter, bson.E{Key: fiContractUsageStamp, Value: bson.D{{Key: "$gte", Value: *from}}})
+	}
+	if to != nil {
+		filter = append(filter, bson.E{Key: fiContractUsageStamp, Value: bson.D{{Key: "$lte", Value: *to}}})
+	}
+
+	// pull the data; make sure there is a limit to the range
+	cursor, err := col.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: fiContractUsageStamp, Value: 1}}).SetLimit(365))
+	if err != nil {
+		db.log.Errorf("can not load daily usage of %s; %s", adr.String(), err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.ContractDailyUsage, 0)
+	for cursor.Next(ctx) {
+		var row types.ContractDailyUsage
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode daily contract usage; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// TopContractsByGas loads the given number of the contracts with the highest gas used.
+func (db *MongoDbBridge) TopContractsByGas(ctx context.Context, count int32) ([]*types.ContractGasRank, error) {
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colContractTopGas)
+
+	cursor, err := col.Find(ctx, bson.D{}, options.Find().
+		SetSort(bson.D{{Key: fiContractUsageGas, Value: -1}}).
+		SetLimit(int64(count)))
+	if err != nil {
+		db.log.Errorf("can not load contracts ranking; %s", err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.ContractGasRank, 0, count)
+	for cursor.Next(ctx) {
+		var row types.ContractGasRank
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode contract rank; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
@@ -0,0 +1,87 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// colCounters represents the name of the collection of the aggregated counters.
+	colCounters = "counters"
+
+	// fiCounterValue represents the name of the value field of a counter.
+	fiCounterValue = "val"
+
+	// counterTransactions represents the counter of the indexed transactions.
+	counterTransactions = "trx"
+)
+
+// counterRow represents a row of the counters collection.
+type counterRow struct {
+	Name  string `bson:"_id"`
+	Value int64  `bson:"val"`
+}
+
+// incCounter adds the given difference to the value of the counter of the given name.
+// The counter is created if it does not exist yet.
+func (db *MongoDbBridge) incCounter(ctx context.Context, name string, diff int64) {
+	if diff == 0 {
+		return
+	}
+
+	col := db.client.Database(db.dbName).Collection(colCounters)
+	if _, err := col.UpdateByID(ctx, name,
+		bson.D{{Key: "$inc", Value: bson.D{{Key: fiCounterValue, Value: diff}}}},
+		options.Update().SetUpsert(true)); err != nil {
+		db.log.Errorf("can not update counter %s; %s", name, err.Error())
+	}
+}
+
+// setCounter sets the value of the counter of the given name.
+func (db *MongoDbBridge) setCounter(ctx context.Context, name string, val int64) error {
+	col := db.client.Database(db.dbName).Collection(colCounters)
+	_, err := col.UpdateByID(ctx, name,
+		bson.D{{Key: "$set", Value: bson.D{{Key: fiCounterValue, Value: val}}}},
+		options.Update().SetUpsert(true))
+	return err
+}
+
+// counter provides the value of the counter of the given name; zero if the counter does not exist.
+func (db *MongoDbBridge) counter(ctx context.Context, name string) (uint64, error) {
+	col := db.client.Database(db.dbName).Collection(colCounters)
+
+	var row counterRow
+	err := col.FindOne(ctx, bson.D{{Key: "_id", Value: name}}).Decode(&row)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	if err != nil {
+		db.log.Errorf("can not load counter %s; %s", name, err.Error())
+		return 0, err
+	}
+
+	if row.Value < 0 {
+		return 0, nil
+	}
+	return uint64(row.Value), nil
+}
+
+// TransactionsTotal provides the exact number of the indexed transactions
+// maintained as a counter by the scanner.
+func (db *MongoDbBridge) TransactionsTotal(ctx context.Context) (uint64, error) {
+	return db.counter(ctx, counterTransactions)
+}
+
+// migrateTransactionsCounter initializes the counter of the indexed transactions
+// by counting the transactions already stored in the database.
+func (db *MongoDbBridge) migrateTransactionsCounter() error {
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+	total, err := col.CountDocuments(context.Background(), bson.D{})
+	if err != nil {
+		return err
+	}
+	return db.setCounter(context.Background(), counterTransactions, total)
+}
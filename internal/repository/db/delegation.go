@@ -52,12 +52,12 @@ func (db *MongoDbBridge) initDelegationCollection(col *mongo.Collection) {
 }
 
 // Delegation returns details of a delegation from an address to a validator ID.
-func (db *MongoDbBridge) Delegation(addr *common.Address, valID *hexutil.Big) (*types.Delegation, error) {
+func (db *MongoDbBridge) Delegation(ctx context.Context, addr *common.Address, valID *hexutil.Big) (*types.Delegation, error) {
 	// get the collection for delegations
 	col := db.client.Database(db.dbName).Collection(colDelegations)
 
 	// try to find the delegation in the database
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(ctx, bson.D{
 		{Key: types.FiDelegationAddress, Value: addr.String()},
 		{Key: types.FiDelegationToValidator, Value: valID.String()},
 	})
@@ -80,17 +80,17 @@ func (db *MongoDbBridge) Delegation(addr *common.Address, valID *hexutil.Big) (*
 }
 
 // AddDelegation stores a delegation in the database if it doesn't exist.
-func (db *MongoDbBridge) AddDelegation(dl *types.Delegation) error {
+func (db *MongoDbBridge) AddDelegation(ctx context.Context, dl *types.Delegation) error {
 	// get the collection for delegations
 	col := db.client.Database(db.dbName).Collection(colDelegations)
 
 	// if the delegation already exists, update it with the new data
-	if db.isDelegationKnown(col, dl) {
-		return db.UpdateDelegation(dl)
+	if db.isDelegationKnown(ctx, col, dl) {
+		return db.UpdateDelegation(ctx, dl)
 	}
 
 	// try to do the insert
-	if _, err := col.InsertOne(context.Background(), dl); err != nil {
+	if _, err := col.InsertOne(ctx, dl); err != nil {
 		db.log.Criticalf("can not add delegation %s to %d; %s", dl.Address.String(), dl.ToStakerId.ToInt().Uint64(), err.Error())
 		return err
 	}
@@ -103,7 +103,7 @@ func (db *MongoDbBridge) AddDelegation(dl *types.Delegation) error {
 }
 
 // UpdateDelegation updates the given delegation in database.
-func (db *MongoDbBridge) UpdateDelegation(dl *types.Delegation) error {
+func (db *MongoDbBridge) UpdateDelegation(ctx context.Context, dl *types.Delegation) error {
 	// get the collection for delegations
 	col := db.client.Database(db.dbName).Collection(colDelegations)
 
@@ -116,7 +116,7 @@ func (db *MongoDbBridge) UpdateDelegation(dl *types.Delegation) error {
 
 	// try to update a delegation by replacing it in the database
 	// we use address and validator ID to identify unique delegation
-	er, err := col.UpdateOne(context.Background(), bson.D{
+	er, err := col.UpdateOne(ctx, bson.D{
 		{Key: types.FiDelegationAddress, Value: dl.Address.String()},
 		{Key: types.FiDelegationToValidator, Value: dl.ToStakerId.String()},
 	}, bson.D{{Key: "$set", Value: bson.D{
@@ -145,7 +145,7 @@ func (db *MongoDbBridge) UpdateDelegation(dl *types.Delegation) error {
 }
 
 // UpdateDelegationBalance updates the given delegation active balance in database to the given amount.
-func (db *MongoDbBridge) UpdateDelegationBalance(addr *common.Address, valID *hexutil.Big, amo *hexutil.Big) error {
+func (db *MongoDbBridge) UpdateDelegationBalance(ctx context.Context, addr *common.Address, valID *hexutil.Big, amo *hexutil.Big) error {
 	// get the collection for delegations
 	col := db.client.Database(db.dbName).Collection(colDelegations)
 	val := new(big.Int).Div(amo.ToInt(), types.DelegationDecimalsCorrection).Uint64()
@@ -154,7 +154,7 @@ func (db *MongoDbBridge) UpdateDelegationBalance(addr *common.Address, valID *he
 	db.log.Debugf("%s delegation to #%d value changed to %d", addr.String(), valID.ToInt().Uint64(), val)
 
 	// update the transaction details
-	ur, err := col.UpdateOne(context.Background(),
+	ur, err := col.UpdateOne(ctx,
 		bson.D{
 			{Key: types.FiDelegationAddress, Value: addr.String()},
 			{Key: types.FiDelegationToValidator, Value: valID.String()},
@@ -178,9 +178,9 @@ func (db *MongoDbBridge) UpdateDelegationBalance(addr *common.Address, valID *he
 }
 
 // isDelegationKnown checks if the given delegation exists in the database.
-func (db *MongoDbBridge) isDelegationKnown(col *mongo.Collection, dl *types.Delegation) bool {
+func (db *MongoDbBridge) isDelegationKnown(ctx context.Context, col *mongo.Collection, dl *types.Delegation) bool {
 	// try to find the delegation in the database
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(ctx, bson.D{
 		{Key: types.FiDelegationAddress, Value: dl.Address.String()},
 		{Key: types.FiDelegationToValidator, Value: dl.ToStakerId.String()},
 	}, options.FindOne().SetProjection(bson.D{
@@ -202,24 +202,24 @@ func (db *MongoDbBridge) isDelegationKnown(col *mongo.Collection, dl *types.Dele
 }
 
 // DelegationsCountFiltered calculates total number of delegations in the database for the given filter.
-func (db *MongoDbBridge) DelegationsCountFiltered(filter *bson.D) (uint64, error) {
-	return db.CountFiltered(db.client.Database(db.dbName).Collection(colDelegations), filter)
+func (db *MongoDbBridge) DelegationsCountFiltered(ctx context.Context, filter *types.DelegationFilter) (uint64, error) {
+	return db.CountFiltered(ctx, db.client.Database(db.dbName).Collection(colDelegations), delegationFilter(filter))
 }
 
 // DelegationsCount calculates total number of delegations in the database.
-func (db *MongoDbBridge) DelegationsCount() (uint64, error) {
-	return db.EstimateCount(db.client.Database(db.dbName).Collection(colDelegations))
+func (db *MongoDbBridge) DelegationsCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(colDelegations))
 }
 
 // dlgListInit initializes list of delegations based on provided cursor, count, and filter.
-func (db *MongoDbBridge) dlgListInit(col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.DelegationList, error) {
+func (db *MongoDbBridge) dlgListInit(ctx context.Context, col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.DelegationList, error) {
 	// make sure some filter is used
 	if nil == filter {
 		filter = &bson.D{}
 	}
 
 	// find how many transactions do we have in the database
-	total, err := col.CountDocuments(context.Background(), *filter)
+	total, err := col.CountDocuments(ctx, *filter)
 	if err != nil {
 		db.log.Errorf("can not count delegations")
 		return nil, err
@@ -239,7 +239,7 @@ func (db *MongoDbBridge) dlgListInit(col *mongo.Collection, cursor *string, coun
 
 	// is the list non-empty? return the list with properly calculated range marks
 	if 0 < total {
-		return db.dlgListCollectRangeMarks(col, &list, cursor, count)
+		return db.dlgListCollectRangeMarks(ctx, col, &list, cursor, count)
 	}
 
 	// this is an empty list
@@ -248,20 +248,20 @@ func (db *MongoDbBridge) dlgListInit(col *mongo.Collection, cursor *string, coun
 }
 
 // trxListWithRangeMarks returns a list of delegations with proper First/Last marks.
-func (db *MongoDbBridge) dlgListCollectRangeMarks(col *mongo.Collection, list *types.DelegationList, cursor *string, count int32) (*types.DelegationList, error) {
+func (db *MongoDbBridge) dlgListCollectRangeMarks(ctx context.Context, col *mongo.Collection, list *types.DelegationList, cursor *string, count int32) (*types.DelegationList, error) {
 	var err error
 
 	// find out the cursor ordinal index
 	if cursor == nil && count > 0 {
 		// get the highest available pk
-		list.First, err = db.dlgListBorderPk(col,
+		list.First, err = db.dlgListBorderPk(ctx, col,
 			list.Filter,
 			options.FindOne().SetSort(bson.D{{Key: types.FiDelegationOrdinal, Value: -1}}))
 		list.IsStart = true
 
 	} else if cursor == nil && count < 0 {
 		// get the lowest available pk
-		list.First, err = db.dlgListBorderPk(col,
+		list.First, err = db.dlgListBorderPk(ctx, col,
 			list.Filter,
 			options.FindOne().SetSort(bson.D{{Key: types.FiDelegationOrdinal, Value: 1}}))
 		list.IsEnd = true
@@ -271,11 +271,11 @@ func (db *MongoDbBridge) dlgListCollectRangeMarks(col *mongo.Collection, list *t
 		id, err := primitive.ObjectIDFromHex(*cursor)
 		if err != nil {
 			db.log.Errorf("invalid delegation cursor ID; %s", err.Error())
-			return nil, err
+			return nil, types.Errorf(types.ErrCodeInvalidCursor, "invalid cursor value; %s", err.Error())
 		}
 
 		// look for the first ordinal to make sure it's there
-		list.First, err = db.dlgListBorderPk(col,
+		list.First, err = db.dlgListBorderPk(ctx, col,
 			append(list.Filter, bson.E{Key: types.FiDelegationPk, Value: id}),
 			options.FindOne())
 	}
@@ -292,7 +292,7 @@ func (db *MongoDbBridge) dlgListCollectRangeMarks(col *mongo.Collection, list *t
 }
 
 // dlgListBorderPk finds the top PK of the delegations collection based on given filter and options.
-func (db *MongoDbBridge) dlgListBorderPk(col *mongo.Collection, filter bson.D, opt *options.FindOneOptions) (uint64, error) {
+func (db *MongoDbBridge) dlgListBorderPk(ctx context.Context, col *mongo.Collection, filter bson.D, opt *options.FindOneOptions) (uint64, error) {
 	// prep container
 	var row struct {
 		Value uint64 `bson:"orx"`
@@ -300,7 +300,7 @@ func (db *MongoDbBridge) dlgListBorderPk(col *mongo.Collection, filter bson.D, o
 
 	// make sure we pull only what we need
 	opt.SetProjection(bson.D{{Key: types.FiDelegationOrdinal, Value: true}})
-	sr := col.FindOne(context.Background(), filter, opt)
+	sr := col.FindOne(ctx, filter, opt)
 
 	// try to decode
 	err := sr.Decode(&row)
@@ -354,10 +354,7 @@ func (db *MongoDbBridge) dlgListOptions(count int32) *options.FindOptions {
 }
 
 // dlgListLoad load the initialized list of delegations from database.
-func (db *MongoDbBridge) dlgListLoad(col *mongo.Collection, cursor *string, count int32, list *types.DelegationList) (err error) {
-	// get the context for loader
-	ctx := context.Background()
-
+func (db *MongoDbBridge) dlgListLoad(ctx context.Context, col *mongo.Collection, cursor *string, count int32, list *types.DelegationList) (err error) {
 	// load the data
 	ld, err := col.Find(ctx, db.dlgListFilter(cursor, count, list), db.dlgListOptions(count))
 	if err != nil {
@@ -404,17 +401,17 @@ func (db *MongoDbBridge) dlgListLoad(col *mongo.Collection, cursor *string, coun
 }
 
 // Delegations pulls list of delegations starting at the specified cursor.
-func (db *MongoDbBridge) Delegations(cursor *string, count int32, filter *bson.D) (*types.DelegationList, error) {
+func (db *MongoDbBridge) Delegations(ctx context.Context, cursor *string, count int32, filter *types.DelegationFilter) (*types.DelegationList, error) {
 	// nothing to load?
 	if count == 0 {
 		return nil, fmt.Errorf("nothing to do, zero delegations requested")
 	}
 
 	// get the collection and context
-	col := db.client.Database(db.dbName).Collection(colDelegations)
+	col := db.listCollection(colDelegations)
 
 	// init the list
-	list, err := db.dlgListInit(col, cursor, count, filter)
+	list, err := db.dlgListInit(ctx, col, cursor, count, delegationFilter(filter))
 	if err != nil {
 		db.log.Errorf("can not build delegation list; %s", err.Error())
 		return nil, err
@@ -422,7 +419,7 @@ func (db *MongoDbBridge) Delegations(cursor *string, count int32, filter *bson.D
 
 	// load data if there are any
 	if list.Total > 0 {
-		err = db.dlgListLoad(col, cursor, count, list)
+		err = db.dlgListLoad(ctx, col, cursor, count, list)
 		if err != nil {
 			db.log.Errorf("can not load delegation list from database; %s", err.Error())
 			return nil, err
@@ -444,14 +441,13 @@ func (db *MongoDbBridge) Delegations(cursor *string, count int32, filter *bson.D
 }
 
 // DelegationsAll pulls list of delegations for the given filter un-paged.
-func (db *MongoDbBridge) DelegationsAll(filter *bson.D) ([]*types.Delegation, error) {
+func (db *MongoDbBridge) DelegationsAll(ctx context.Context, filter *types.DelegationFilter) ([]*types.Delegation, error) {
 	// get the collection and context
-	col := db.client.Database(db.dbName).Collection(colDelegations)
+	col := db.listCollection(colDelegations)
 	list := make([]*types.Delegation, 0)
-	ctx := context.Background()
 
 	// load the data
-	ld, err := col.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: types.FiDelegationStamp, Value: -1}}))
+	ld, err := col.Find(ctx, delegationFilter(filter), options.Find().SetSort(bson.D{{Key: types.FiDelegationStamp, Value: -1}}))
 	if err != nil {
 		db.log.Errorf("error loading full delegations list; %s", err.Error())
 		return nil, err
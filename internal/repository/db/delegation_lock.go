@@ -0,0 +1,93 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colLockups represents the name of the delegation lock events collection in database.
+const colLockups = "lockups"
+
+// initLockupsCollection initializes the delegation lock events collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initLockupsCollection(col *mongo.Collection) {
+	// index delegation and the event time stamp
+	if _, err := col.Indexes().CreateOne(context.Background(), mongo.IndexModel{
+		Keys: bson.D{
+			{Key: types.FiDelegationLockEventAddress, Value: 1},
+			{Key: types.FiDelegationLockEventValidator, Value: 1},
+			{Key: types.FiDelegationLockEventTimeStamp, Value: -1},
+		},
+	}); err != nil {
+		db.log.Panicf("can not create indexes for lockups collection; %s", err.Error())
+	}
+	db.log.Debugf("lockups collection initialized")
+}
+
+// AddDelegationLockEvent stores a delegation lock event in the database;
+// the previous record of the same event is replaced.
+func (db *MongoDbBridge) AddDelegationLockEvent(ctx context.Context, dle *types.DelegationLockEvent) error {
+	// get the collection for lockups
+	col := db.client.Database(db.dbName).Collection(colLockups)
+
+	if _, err := col.ReplaceOne(ctx,
+		bson.D{{Key: types.FiDelegationLockEventPk, Value: dle.Pk()}},
+		dle,
+		options.Replace().SetUpsert(true),
+	); err != nil {
+		db.log.Errorf("can not store delegation lock event %s; %s", dle.Pk(), err.Error())
+		return err
+	}
+
+	// make sure lockups collection is initialized
+	if db.initLockups != nil {
+		db.initLockups.Do(func() { db.initLockupsCollection(col); db.initLockups = nil })
+	}
+	return nil
+}
+
+// DelegationLockEventsCount calculates total number of delegation lock events in the database.
+func (db *MongoDbBridge) DelegationLockEventsCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(colLockups))
+}
+
+// DelegationLockEvents loads the given number of the most recent lock events of the delegation.
+func (db *MongoDbBridge) DelegationLockEvents(ctx context.Context, addr *common.Address, valID *hexutil.Big, count int32) ([]*types.DelegationLockEvent, error) {
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colLockups)
+
+	ld, err := col.Find(ctx, bson.D{
+		{Key: types.FiDelegationLockEventAddress, Value: addr.String()},
+		{Key: types.FiDelegationLockEventValidator, Value: valID.String()},
+	}, options.Find().
+		SetSort(bson.D{{Key: types.FiDelegationLockEventTimeStamp, Value: -1}}).
+		SetLimit(int64(count)))
+	if err != nil {
+		db.log.Errorf("can not load delegation lock events; %s", err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing lockups cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.DelegationLockEvent, 0, count)
+	for ld.Next(ctx) {
+		var row types.DelegationLockEvent
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode delegation lock event; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
@@ -9,6 +9,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
 )
 
 const (
@@ -42,7 +43,7 @@ func (db *MongoDbBridge) initEpochsCollection(col *mongo.Collection) {
 }
 
 // AddEpoch stores an epoch reference in connected persistent storage.
-func (db *MongoDbBridge) AddEpoch(e *types.Epoch) error {
+func (db *MongoDbBridge) AddEpoch(ctx context.Context, e *types.Epoch) error {
 	// do we have all needed data? we reject epochs without any stake
 	if e == nil || e.EndTime == 0 || e.StakeTotalAmount.ToInt().Cmp(intZero) <= 0 {
 		return fmt.Errorf("empty epoch received")
@@ -53,12 +54,12 @@ func (db *MongoDbBridge) AddEpoch(e *types.Epoch) error {
 
 	// if the transaction already exists, we don't need to add it
 	// just make sure the transaction accounts were processed
-	if db.isEpochKnown(col, e) {
+	if db.isEpochKnown(ctx, col, e) {
 		return nil
 	}
 
 	// try to do the insert
-	if _, err := col.InsertOne(context.Background(), e); err != nil {
+	if _, err := col.InsertOne(ctx, e); err != nil {
 		db.log.Critical(err)
 		return err
 	}
@@ -74,9 +75,9 @@ func (db *MongoDbBridge) AddEpoch(e *types.Epoch) error {
 }
 
 // isEpochKnown checks if the given epoch has already been added to the database
-func (db *MongoDbBridge) isEpochKnown(col *mongo.Collection, e *types.Epoch) bool {
+func (db *MongoDbBridge) isEpochKnown(ctx context.Context, col *mongo.Collection, e *types.Epoch) bool {
 	// try to find the epoch in the database (it may already exist)
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(ctx, bson.D{
 		{Key: fiEpochPk, Value: int64(e.Id)},
 	}, options.FindOne().SetProjection(bson.D{
 		{Key: fiEpochPk, Value: true},
@@ -92,19 +93,43 @@ func (db *MongoDbBridge) isEpochKnown(col *mongo.Collection, e *types.Epoch) boo
 }
 
 // LastKnownEpoch provides the number of the newest epoch stored in the database.
-func (db *MongoDbBridge) LastKnownEpoch() (uint64, error) {
-	return db.epochListBorderPk(db.client.Database(db.dbName).Collection(colEpochs), options.FindOne().SetSort(bson.D{{Key: fiEpochEndTime, Value: -1}}))
+func (db *MongoDbBridge) LastKnownEpoch(ctx context.Context) (uint64, error) {
+	return db.epochListBorderPk(ctx, db.client.Database(db.dbName).Collection(colEpochs), options.FindOne().SetSort(bson.D{{Key: fiEpochEndTime, Value: -1}}))
+}
+
+// EpochIdAt provides the id of the epoch which was active at the given time stamp.
+// The epoch is identified as the first sealed epoch ending after the time stamp,
+// zero is returned if no such epoch has been sealed yet.
+func (db *MongoDbBridge) EpochIdAt(ctx context.Context, ts time.Time) (uint64, error) {
+	// prep container
+	var row struct {
+		Value uint64 `bson:"_id"`
+	}
+
+	// find the first epoch sealed after the time stamp
+	col := db.client.Database(db.dbName).Collection(colEpochs)
+	sr := col.FindOne(ctx,
+		bson.D{{Key: fiEpochEndTime, Value: bson.D{{Key: "$gte", Value: ts}}}},
+		options.FindOne().SetSort(bson.D{{Key: fiEpochEndTime, Value: 1}}).SetProjection(bson.D{{Key: fiEpochPk, Value: true}}))
+	if err := sr.Decode(&row); err != nil {
+		// may be ErrNoDocuments, the epoch is not sealed yet
+		if err == mongo.ErrNoDocuments {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return row.Value, nil
 }
 
 // EpochsCount calculates total number of epochs in the database.
-func (db *MongoDbBridge) EpochsCount() (uint64, error) {
-	return db.EstimateCount(db.client.Database(db.dbName).Collection(colEpochs))
+func (db *MongoDbBridge) EpochsCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(colEpochs))
 }
 
 // epochListInit initializes list of epochs based on provided cursor, count.
-func (db *MongoDbBridge) epochListInit(col *mongo.Collection, cursor *string, count int32) (*types.EpochList, error) {
+func (db *MongoDbBridge) epochListInit(ctx context.Context, col *mongo.Collection, cursor *string, count int32) (*types.EpochList, error) {
 	// find how many transactions do we have in the database
-	total, err := db.EpochsCount()
+	total, err := db.EpochsCount(ctx)
 	if err != nil {
 		db.log.Errorf("can not count epochs")
 		return nil, err
@@ -122,7 +147,7 @@ func (db *MongoDbBridge) epochListInit(col *mongo.Collection, cursor *string, co
 
 	// is the list non-empty? return the list with properly calculated range marks
 	if 0 < total {
-		return db.epochListCollectRangeMarks(col, &list, cursor, count)
+		return db.epochListCollectRangeMarks(ctx, col, &list, cursor, count)
 	}
 
 	// this is an empty list
@@ -131,23 +156,26 @@ func (db *MongoDbBridge) epochListInit(col *mongo.Collection, cursor *string, co
 }
 
 // epochListCollectRangeMarks returns a list of epochs with proper First/Last marks.
-func (db *MongoDbBridge) epochListCollectRangeMarks(col *mongo.Collection, list *types.EpochList, cursor *string, count int32) (*types.EpochList, error) {
+func (db *MongoDbBridge) epochListCollectRangeMarks(ctx context.Context, col *mongo.Collection, list *types.EpochList, cursor *string, count int32) (*types.EpochList, error) {
 	var err error
 
 	// find out the cursor ordinal index
 	if cursor == nil && count > 0 {
 		// get the highest available pk
-		list.First, err = db.epochListBorderPk(col, options.FindOne().SetSort(bson.D{{Key: fiEpochEndTime, Value: -1}}))
+		list.First, err = db.epochListBorderPk(ctx, col, options.FindOne().SetSort(bson.D{{Key: fiEpochEndTime, Value: -1}}))
 		list.IsStart = true
 
 	} else if cursor == nil && count < 0 {
 		// get the lowest available pk
-		list.First, err = db.epochListBorderPk(col, options.FindOne().SetSort(bson.D{{Key: fiEpochEndTime, Value: 1}}))
+		list.First, err = db.epochListBorderPk(ctx, col, options.FindOne().SetSort(bson.D{{Key: fiEpochEndTime, Value: 1}}))
 		list.IsEnd = true
 
 	} else if cursor != nil {
 		// the cursor itself is the starting point
 		list.First, err = hexutil.DecodeUint64(*cursor)
+		if err != nil {
+			return nil, types.Errorf(types.ErrCodeInvalidCursor, "invalid cursor value; %s", err.Error())
+		}
 	}
 
 	// check the error
@@ -162,7 +190,7 @@ func (db *MongoDbBridge) epochListCollectRangeMarks(col *mongo.Collection, list
 }
 
 // rewListBorderPk finds the top PK of the reward claims collection based on given filter and options.
-func (db *MongoDbBridge) epochListBorderPk(col *mongo.Collection, opt *options.FindOneOptions) (uint64, error) {
+func (db *MongoDbBridge) epochListBorderPk(ctx context.Context, col *mongo.Collection, opt *options.FindOneOptions) (uint64, error) {
 	// prep container
 	var row struct {
 		Value uint64 `bson:"_id"`
@@ -172,7 +200,7 @@ func (db *MongoDbBridge) epochListBorderPk(col *mongo.Collection, opt *options.F
 	opt.SetProjection(bson.D{{Key: fiEpochPk, Value: true}})
 
 	// try to decode
-	sr := col.FindOne(context.Background(), bson.D{}, opt)
+	sr := col.FindOne(ctx, bson.D{}, opt)
 	err := sr.Decode(&row)
 	if err != nil {
 		return 0, err
@@ -224,10 +252,7 @@ func (db *MongoDbBridge) epochListOptions(count int32) *options.FindOptions {
 }
 
 // epochListLoad loads the initialized list of epochs from database.
-func (db *MongoDbBridge) epochListLoad(col *mongo.Collection, cursor *string, count int32, list *types.EpochList) (err error) {
-	// get the context for loader
-	ctx := context.Background()
-
+func (db *MongoDbBridge) epochListLoad(ctx context.Context, col *mongo.Collection, cursor *string, count int32, list *types.EpochList) (err error) {
 	// load the data
 	ld, err := col.Find(ctx, db.epochListFilter(cursor, count, list), db.epochListOptions(count))
 	if err != nil {
@@ -273,7 +298,7 @@ func (db *MongoDbBridge) epochListLoad(col *mongo.Collection, cursor *string, co
 }
 
 // Epochs pulls list of epochs starting at the specified cursor.
-func (db *MongoDbBridge) Epochs(cursor *string, count int32) (*types.EpochList, error) {
+func (db *MongoDbBridge) Epochs(ctx context.Context, cursor *string, count int32) (*types.EpochList, error) {
 	// nothing to load?
 	if count == 0 {
 		return nil, fmt.Errorf("nothing to do, zero epochs requested")
@@ -283,7 +308,7 @@ func (db *MongoDbBridge) Epochs(cursor *string, count int32) (*types.EpochList,
 	col := db.client.Database(db.dbName).Collection(colEpochs)
 
 	// init the list
-	list, err := db.epochListInit(col, cursor, count)
+	list, err := db.epochListInit(ctx, col, cursor, count)
 	if err != nil {
 		db.log.Errorf("can not build epoch list; %s", err.Error())
 		return nil, err
@@ -291,7 +316,7 @@ func (db *MongoDbBridge) Epochs(cursor *string, count int32) (*types.EpochList,
 
 	// load data if there are any
 	if list.Total > 0 {
-		err = db.epochListLoad(col, cursor, count, list)
+		err = db.epochListLoad(ctx, col, cursor, count, list)
 		if err != nil {
 			db.log.Errorf("can not load epoch list; %s", err.Error())
 			return nil, err
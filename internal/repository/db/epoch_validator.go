@@ -0,0 +1,162 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"math/big"
+)
+
+const (
+	// colEpochValidators represents the name of the epoch validators snapshot collection in database.
+	colEpochValidators = "epoch_val"
+
+	// fiEpochValidatorPk is the name of the primary key field of the epoch validator snapshot.
+	fiEpochValidatorPk = "_id"
+
+	// fiEpochValidatorEpoch is the name of the field of the epoch id.
+	fiEpochValidatorEpoch = "epoch"
+
+	// fiEpochValidatorValidator is the name of the field of the validator id.
+	fiEpochValidatorValidator = "val"
+)
+
+// epochValidatorRow represents BSON row structure of the validator snapshot at the end of an epoch.
+type epochValidatorRow struct {
+	ID                        string `bson:"_id"`
+	Epoch                     int64  `bson:"epoch"`
+	Validator                 int64  `bson:"val"`
+	ReceivedStake             string `bson:"stake"`
+	RewardPerToken            string `bson:"rpt"`
+	AccumulatedRewardPerToken string `bson:"arpt"`
+	OriginatedTxsFee          string `bson:"fee"`
+	Uptime                    string `bson:"up"`
+}
+
+// initEpochValidatorsCollection initializes the epoch validators collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initEpochValidatorsCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{
+		{Key: fiEpochValidatorEpoch, Value: 1},
+		{Key: fiEpochValidatorValidator, Value: 1},
+	}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for epoch validators collection; %s", err.Error())
+	}
+	db.log.Debugf("epoch validators collection initialized")
+}
+
+// AddEpochValidators stores the snapshot of the validators at the end of the given epoch;
+// the previous snapshot of the epoch validators is replaced.
+func (db *MongoDbBridge) AddEpochValidators(ctx context.Context, id hexutil.Uint64, list []types.EpochValidator) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	// get the collection for epoch validators
+	col := db.client.Database(db.dbName).Collection(colEpochValidators)
+
+	wm := make([]mongo.WriteModel, len(list))
+	for i := range list {
+		row := epochValidatorRow{
+			ID:                        fmt.Sprintf("%d.%d", uint64(id), list[i].ValidatorId.ToInt().Uint64()),
+			Epoch:                     int64(id),
+			Validator:                 list[i].ValidatorId.ToInt().Int64(),
+			ReceivedStake:             list[i].ReceivedStake.String(),
+			RewardPerToken:            list[i].RewardPerToken.String(),
+			AccumulatedRewardPerToken: list[i].AccumulatedRewardPerToken.String(),
+			OriginatedTxsFee:          list[i].OriginatedTxsFee.String(),
+			Uptime:                    list[i].Uptime.String(),
+		}
+		wm[i] = mongo.NewReplaceOneModel().
+			SetFilter(bson.D{{Key: fiEpochValidatorPk, Value: row.ID}}).
+			SetReplacement(row).
+			SetUpsert(true)
+	}
+
+	if _, err := col.BulkWrite(ctx, wm, options.BulkWrite().SetOrdered(false)); err != nil {
+		db.log.Errorf("can not store validators of epoch #%d; %s", uint64(id), err.Error())
+		return err
+	}
+
+	// make sure epoch validators collection is initialized
+	if db.initEpochVals != nil {
+		db.initEpochVals.Do(func() { db.initEpochValidatorsCollection(col); db.initEpochVals = nil })
+	}
+	return nil
+}
+
+// EpochValidatorsCount calculates total number of epoch validator snapshots in the database.
+func (db *MongoDbBridge) EpochValidatorsCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(colEpochValidators))
+}
+
+// EpochValidators loads the snapshot of the validators at the end of the given epoch
+// ordered by the validator id; an empty list is provided if the snapshot is not known.
+func (db *MongoDbBridge) EpochValidators(ctx context.Context, id hexutil.Uint64) ([]types.EpochValidator, error) {
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(colEpochValidators)
+
+	ld, err := col.Find(ctx,
+		bson.D{{Key: fiEpochValidatorEpoch, Value: int64(id)}},
+		options.Find().SetSort(bson.D{{Key: fiEpochValidatorValidator, Value: 1}}),
+	)
+	if err != nil {
+		db.log.Errorf("can not load validators of epoch #%d; %s", uint64(id), err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing epoch validators cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]types.EpochValidator, 0)
+	for ld.Next(ctx) {
+		var row epochValidatorRow
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode validator of epoch #%d; %s", uint64(id), err.Error())
+			return nil, err
+		}
+
+		ev, err := row.epochValidator()
+		if err != nil {
+			db.log.Errorf("invalid validator #%d of epoch #%d; %s", row.Validator, uint64(id), err.Error())
+			return nil, err
+		}
+		list = append(list, *ev)
+	}
+	return list, nil
+}
+
+// epochValidator decodes the validator snapshot from the BSON row.
+func (row *epochValidatorRow) epochValidator() (*types.EpochValidator, error) {
+	ev := types.EpochValidator{ValidatorId: (hexutil.Big)(*big.NewInt(row.Validator))}
+	for _, v := range []struct {
+		val *hexutil.Big
+		src string
+	}{
+		{&ev.ReceivedStake, row.ReceivedStake},
+		{&ev.RewardPerToken, row.RewardPerToken},
+		{&ev.AccumulatedRewardPerToken, row.AccumulatedRewardPerToken},
+		{&ev.OriginatedTxsFee, row.OriginatedTxsFee},
+		{&ev.Uptime, row.Uptime},
+	} {
+		val, err := hexutil.DecodeBig(v.src)
+		if err != nil {
+			return nil, err
+		}
+		*v.val = hexutil.Big(*val)
+	}
+	return &ev, nil
+}
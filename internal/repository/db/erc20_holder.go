@@ -0,0 +1,220 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"math/big"
+)
+
+const (
+	// colErc20Holders represents the name of the ERC20 token holders collection in database.
+	colErc20Holders = "erc20holders"
+
+	// fiErc20HolderPk is the name of the primary key field of the token holder row.
+	fiErc20HolderPk = "_id"
+
+	// fiErc20HolderToken is the name of the field of the token address.
+	fiErc20HolderToken = "tok"
+
+	// fiErc20HolderAddress is the name of the field of the holder address.
+	fiErc20HolderAddress = "adr"
+
+	// fiErc20HolderBalance is the name of the field of the exact holder balance;
+	// the balance is kept as a decimal, so it can be sorted and adjusted in place.
+	fiErc20HolderBalance = "bal"
+
+	// fiErc20HolderPosition is the name of the field of the position of the last transfer applied to the row.
+	fiErc20HolderPosition = "pos"
+)
+
+// initErc20HoldersCollection initializes the ERC20 token holders collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initErc20HoldersCollection(col *mongo.Collection) {
+	// holders of a token are always listed by their balance
+	if _, err := col.Indexes().CreateOne(context.Background(), erc20HoldersIndex()); err != nil {
+		db.log.Panicf("can not create indexes for ERC20 holders collection; %s", err.Error())
+	}
+
+	// log we are done that
+	db.log.Debugf("ERC20 holders collection initialized")
+}
+
+// erc20HoldersIndex provides the index of the ERC20 token holders by their balance.
+func erc20HoldersIndex() mongo.IndexModel {
+	return mongo.IndexModel{Keys: bson.D{{Key: fiErc20HolderToken, Value: 1}, {Key: fiErc20HolderBalance, Value: -1}}}
+}
+
+// erc20HolderPk creates the primary key of the token holder row.
+func erc20HolderPk(token *common.Address, holder *common.Address) string {
+	return fmt.Sprintf("%s.%s", token.String(), holder.String())
+}
+
+// Erc20UpdateHolders moves the amount of the given ERC20 transfer between the known balances
+// of the token holders. Transfers older than the last one applied to a holder are ignored,
+// so the transfers can be replayed safely. The zero address is not a holder, it mints and burns tokens.
+func (db *MongoDbBridge) Erc20UpdateHolders(ctx context.Context, trx *types.TokenTransaction) error {
+	pos := tokenTrxPosition(trx)
+	if config.EmptyAddress != trx.Sender.String() {
+		if err := db.erc20AddHolderBalance(ctx, &trx.TokenAddress, &trx.Sender, new(big.Int).Neg(trx.Amount.ToInt()), pos); err != nil {
+			return err
+		}
+	}
+	if config.EmptyAddress != trx.Recipient.String() {
+		if err := db.erc20AddHolderBalance(ctx, &trx.TokenAddress, &trx.Recipient, trx.Amount.ToInt(), pos); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// erc20AddHolderBalance adds the given difference to the balance of the ERC20 token holder,
+// unless a transfer of the given position, or a later one, has already been applied.
+// Holders with zero balance are kept, so the position of their last transfer is not lost.
+func (db *MongoDbBridge) erc20AddHolderBalance(ctx context.Context, token *common.Address, holder *common.Address, diff *big.Int, pos string) error {
+	// get the collection for token holders
+	col := db.client.Database(db.dbName).Collection(colErc20Holders)
+	pk := erc20HolderPk(token, holder)
+
+	val, ok := primitive.ParseDecimal128FromBigInt(diff, 0)
+	if !ok {
+		return fmt.Errorf("ERC20 amount %s of holder %s out of range", diff.String(), pk)
+	}
+
+	_, err := col.UpdateOne(ctx,
+		bson.D{
+			{Key: fiErc20HolderPk, Value: pk},
+			{Key: fiErc20HolderPosition, Value: bson.D{{Key: "$lt", Value: pos}}},
+		},
+		bson.D{
+			{Key: "$inc", Value: bson.D{{Key: fiErc20HolderBalance, Value: val}}},
+			{Key: "$set", Value: bson.D{
+				{Key: fiErc20HolderToken, Value: token.String()},
+				{Key: fiErc20HolderAddress, Value: holder.String()},
+				{Key: fiErc20HolderPosition, Value: pos},
+			}},
+		},
+		options.Update().SetUpsert(true))
+
+	// the row exists with the same or a later position; the transfer has been applied already
+	if mongo.IsDuplicateKeyError(err) {
+		return nil
+	}
+	if err != nil {
+		db.log.Errorf("can not update ERC20 holder %s; %s", pk, err.Error())
+		return err
+	}
+
+	// make sure token holders collection is initialized
+	if db.initErc20Holders != nil {
+		db.initErc20Holders.Do(func() { db.initErc20HoldersCollection(col); db.initErc20Holders = nil })
+	}
+	return nil
+}
+
+// Erc20HoldersCount returns the number of ERC20 token holders stored in the database.
+func (db *MongoDbBridge) Erc20HoldersCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(colErc20Holders))
+}
+
+// Erc20TokenHoldersCount returns the number of known holders of the given ERC20 token.
+func (db *MongoDbBridge) Erc20TokenHoldersCount(ctx context.Context, token *common.Address) (uint64, error) {
+	// get the collection for token holders
+	col := db.client.Database(db.dbName).Collection(colErc20Holders)
+
+	val, err := col.CountDocuments(ctx, erc20HoldersFilter(token))
+	if err != nil {
+		db.log.Errorf("can not count ERC20 holders of %s; %s", token.String(), err.Error())
+		return 0, err
+	}
+	return uint64(val), nil
+}
+
+// Erc20Holders loads a list of holders of the given ERC20 token sorted by their balance
+// from the highest to the lowest, skipping the given number of holders.
+func (db *MongoDbBridge) Erc20Holders(ctx context.Context, token *common.Address, skip uint64, count int32) ([]*types.AccountBalance, error) {
+	// get the collection
+	col := db.listCollection(colErc20Holders)
+
+	// load the range of holders
+	ld, err := col.Find(ctx,
+		erc20HoldersFilter(token),
+		options.Find().
+			SetSort(bson.D{{Key: fiErc20HolderBalance, Value: -1}, {Key: fiErc20HolderPk, Value: 1}}).
+			SetSkip(int64(skip)).
+			SetLimit(int64(count)).
+			SetProjection(bson.D{{Key: fiErc20HolderAddress, Value: true}, {Key: fiErc20HolderBalance, Value: true}}),
+	)
+	if err != nil {
+		db.log.Errorf("can not load ERC20 holders of %s; %s", token.String(), err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing ERC20 holders cursor; %s", err.Error())
+		}
+	}()
+
+	// load the list
+	list := make([]*types.AccountBalance, 0)
+	for ld.Next(ctx) {
+		var row struct {
+			Address string               `bson:"adr"`
+			Balance primitive.Decimal128 `bson:"bal"`
+		}
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode ERC20 holder; %s", err.Error())
+			continue
+		}
+
+		bal, err := decimalToBig(row.Balance)
+		if err != nil {
+			db.log.Errorf("invalid ERC20 balance of holder %s; %s", row.Address, err.Error())
+			continue
+		}
+
+		list = append(list, &types.AccountBalance{
+			Address: common.HexToAddress(row.Address),
+			Balance: hexutil.Big(*bal),
+			Rank:    skip + uint64(len(list)) + 1,
+		})
+	}
+	return list, nil
+}
+
+// erc20HoldersFilter builds the filter of the holders of the given token with positive balance.
+func erc20HoldersFilter(token *common.Address) bson.D {
+	return bson.D{
+		{Key: fiErc20HolderToken, Value: token.String()},
+		{Key: fiErc20HolderBalance, Value: bson.D{{Key: "$gt", Value: 0}}},
+	}
+}
+
+// migrateErc20Holders rebuilds the ERC20 token holders collection from the recorded token transfers;
+// the holder balances read from the token contracts are replaced with the sums of the transfers.
+func (db *MongoDbBridge) migrateErc20Holders() error {
+	col := db.client.Database(db.dbName).Collection(colErc20Holders)
+	if err := col.Drop(context.Background()); err != nil {
+		return err
+	}
+	if _, err := col.Indexes().CreateOne(context.Background(), erc20HoldersIndex()); err != nil {
+		return err
+	}
+
+	count, err := db.replayTokenTransfers(context.Background(),
+		bson.D{{Key: types.FiTokenTransactionTokenType, Value: types.AccountTypeERC20Token}},
+		db.Erc20UpdateHolders)
+
+	db.log.Noticef("%d ERC20 transfers replayed", count)
+	return err
+}
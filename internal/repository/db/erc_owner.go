@@ -0,0 +1,382 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"math/big"
+)
+
+const (
+	// colErc721Owners represents the name of the ERC721 token owners collection in database.
+	colErc721Owners = "erc721owners"
+
+	// colErc1155Balances represents the name of the ERC1155 token balances collection in database.
+	colErc1155Balances = "erc1155balances"
+
+	// fiErcOwnerPk is the name of the primary key field of the token ownership row.
+	fiErcOwnerPk = "_id"
+
+	// fiErcOwnerToken is the name of the field of the token contract address.
+	fiErcOwnerToken = "tok"
+
+	// fiErcOwnerTokenId is the name of the field of the token id.
+	fiErcOwnerTokenId = "tid"
+
+	// fiErcOwnerAddress is the name of the field of the owner address.
+	fiErcOwnerAddress = "own"
+
+	// fiErcOwnerBalance is the name of the field of the exact balance of ERC1155 token owner.
+	fiErcOwnerBalance = "bal"
+
+	// fiErcOwnerActive is the name of the field marking ERC1155 token owners with non-zero balance.
+	fiErcOwnerActive = "act"
+
+	// fiErcOwnerPosition is the name of the field of the position of the last transfer applied to the row.
+	// The position is the primary key of the token transaction, it sorts by block, log index and batch sequence.
+	fiErcOwnerPosition = "pos"
+)
+
+// erc1155BalanceRow represents the structure of the ERC1155 token balance row.
+type erc1155BalanceRow struct {
+	Token    string `bson:"tok"`
+	TokenId  string `bson:"tid"`
+	Owner    string `bson:"own"`
+	Balance  string `bson:"bal"`
+	Position string `bson:"pos"`
+}
+
+// erc721OwnersIndex provides the index of the ERC721 tokens by their owner.
+func erc721OwnersIndex() mongo.IndexModel {
+	return mongo.IndexModel{Keys: bson.D{{Key: fiErcOwnerAddress, Value: 1}, {Key: fiErcOwnerPk, Value: 1}}}
+}
+
+// erc1155BalancesIndex provides the index of the ERC1155 token balances by their owner.
+func erc1155BalancesIndex() mongo.IndexModel {
+	return mongo.IndexModel{Keys: bson.D{{Key: fiErcOwnerAddress, Value: 1}, {Key: fiErcOwnerActive, Value: 1}, {Key: fiErcOwnerPk, Value: 1}}}
+}
+
+// erc721OwnerPk creates the primary key of the ERC721 token ownership row.
+func erc721OwnerPk(token string, tokenId string) string {
+	return fmt.Sprintf("%s.%s", token, tokenId)
+}
+
+// erc1155BalancePk creates the primary key of the ERC1155 token balance row.
+func erc1155BalancePk(token string, tokenId string, owner string) string {
+	return fmt.Sprintf("%s.%s.%s", token, tokenId, owner)
+}
+
+// tokenTrxPosition provides the position of the token transaction in the chain.
+// Transactions loaded from the database carry their primary key already.
+func tokenTrxPosition(trx *types.TokenTransaction) string {
+	if trx.ID != "" {
+		return trx.ID
+	}
+	return trx.Pk()
+}
+
+// Erc721UpdateOwner records the recipient of the given ERC721 transfer as the owner of the token.
+// Burned tokens are removed. Transfers older than the one already recorded for the token are ignored,
+// so the transfers can be replayed safely.
+func (db *MongoDbBridge) Erc721UpdateOwner(ctx context.Context, trx *types.TokenTransaction) error {
+	// get the collection for token owners
+	col := db.client.Database(db.dbName).Collection(colErc721Owners)
+	pk := erc721OwnerPk(trx.TokenAddress.String(), trx.TokenId.String())
+	pos := tokenTrxPosition(trx)
+
+	// the token does not exist anymore
+	if config.EmptyAddress == trx.Recipient.String() {
+		if _, err := col.DeleteOne(ctx, bson.D{
+			{Key: fiErcOwnerPk, Value: pk},
+			{Key: fiErcOwnerPosition, Value: bson.D{{Key: "$lt", Value: pos}}},
+		}); err != nil {
+			db.log.Errorf("can not remove ERC721 token %s; %s", pk, err.Error())
+			return err
+		}
+		return nil
+	}
+
+	// a newer transfer already recorded makes the upsert collide with the existing row
+	_, err := col.UpdateOne(ctx,
+		bson.D{
+			{Key: fiErcOwnerPk, Value: pk},
+			{Key: fiErcOwnerPosition, Value: bson.D{{Key: "$lt", Value: pos}}},
+		},
+		bson.D{{Key: "$set", Value: bson.D{
+			{Key: fiErcOwnerToken, Value: trx.TokenAddress.String()},
+			{Key: fiErcOwnerTokenId, Value: trx.TokenId.String()},
+			{Key: fiErcOwnerAddress, Value: trx.Recipient.String()},
+			{Key: fiErcOwnerPosition, Value: pos},
+		}}},
+		options.Update().SetUpsert(true))
+	if err != nil && !mongo.IsDuplicateKeyError(err) {
+		db.log.Errorf("can not update ERC721 token %s owner; %s", pk, err.Error())
+		return err
+	}
+	return nil
+}
+
+// Erc1155UpdateBalances applies the given ERC1155 transfer to the recorded balances
+// of the sender and the recipient. The zero address is not a real holder, it's used
+// to mint and burn tokens. Each balance remembers the last transfer applied to it,
+// so the transfers can be replayed safely.
+func (db *MongoDbBridge) Erc1155UpdateBalances(ctx context.Context, trx *types.TokenTransaction) error {
+	// get the collection for token balances
+	col := db.client.Database(db.dbName).Collection(colErc1155Balances)
+	pos := tokenTrxPosition(trx)
+
+	if config.EmptyAddress != trx.Sender.String() {
+		diff := new(big.Int).Neg(trx.Amount.ToInt())
+		if err := db.erc1155AddBalance(ctx, col, trx, trx.Sender.String(), diff, pos); err != nil {
+			return err
+		}
+	}
+	if config.EmptyAddress != trx.Recipient.String() {
+		if err := db.erc1155AddBalance(ctx, col, trx, trx.Recipient.String(), trx.Amount.ToInt(), pos); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// erc1155AddBalance adds the given difference to the ERC1155 token balance of the owner.
+// The row is updated only if it did not change since it was read, concurrent updates are retried.
+func (db *MongoDbBridge) erc1155AddBalance(ctx context.Context, col *mongo.Collection, trx *types.TokenTransaction, owner string, diff *big.Int, pos string) error {
+	pk := erc1155BalancePk(trx.TokenAddress.String(), trx.TokenId.String(), owner)
+	for {
+		// load the current state of the balance, if any
+		var row erc1155BalanceRow
+		err := col.FindOne(ctx, bson.D{{Key: fiErcOwnerPk, Value: pk}}).Decode(&row)
+		if err != nil && err != mongo.ErrNoDocuments {
+			db.log.Errorf("can not load ERC1155 balance %s; %s", pk, err.Error())
+			return err
+		}
+
+		// the transfer has already been applied
+		if err == nil && row.Position >= pos {
+			return nil
+		}
+
+		bal := new(big.Int).Set(diff)
+		if err == nil {
+			bal.Add(bal, hexutil.MustDecodeBig(row.Balance))
+		}
+
+		set := bson.D{
+			{Key: fiErcOwnerToken, Value: trx.TokenAddress.String()},
+			{Key: fiErcOwnerTokenId, Value: trx.TokenId.String()},
+			{Key: fiErcOwnerAddress, Value: owner},
+			{Key: fiErcOwnerBalance, Value: (*hexutil.Big)(bal).String()},
+			{Key: fiErcOwnerActive, Value: bal.Sign() > 0},
+			{Key: fiErcOwnerPosition, Value: pos},
+		}
+
+		// a new balance; another update creating the row first makes us retry
+		if err == mongo.ErrNoDocuments {
+			_, err = col.InsertOne(ctx, append(bson.D{{Key: fiErcOwnerPk, Value: pk}}, set...))
+			if err == nil {
+				return nil
+			}
+			if !mongo.IsDuplicateKeyError(err) {
+				db.log.Errorf("can not add ERC1155 balance %s; %s", pk, err.Error())
+				return err
+			}
+			continue
+		}
+
+		// update the balance only if nobody changed it since we loaded it
+		res, err := col.UpdateOne(ctx,
+			bson.D{{Key: fiErcOwnerPk, Value: pk}, {Key: fiErcOwnerPosition, Value: row.Position}},
+			bson.D{{Key: "$set", Value: set}})
+		if err != nil {
+			db.log.Errorf("can not update ERC1155 balance %s; %s", pk, err.Error())
+			return err
+		}
+		if res.MatchedCount > 0 {
+			return nil
+		}
+	}
+}
+
+// Erc721TokensOfOwner loads the list of ERC721 NFT tokens currently owned by the given account.
+func (db *MongoDbBridge) Erc721TokensOfOwner(ctx context.Context, owner common.Address, count int32) ([]*types.Erc721Token, error) {
+	// make sure the count is positive; use default size if not
+	if count <= 0 {
+		count = defaultTokenListLength
+	}
+
+	// log what we do
+	db.log.Debugf("loading %d ERC721 tokens of %s", count, owner.String())
+
+	col := db.client.Database(db.dbName).Collection(colErc721Owners)
+	cursor, err := col.Find(ctx,
+		bson.D{{Key: fiErcOwnerAddress, Value: owner.String()}},
+		options.Find().SetSort(bson.D{{Key: fiErcOwnerPk, Value: 1}}).SetLimit(int64(count)))
+	if err != nil {
+		db.log.Errorf("can not load ERC721 tokens of %s; %s", owner.String(), err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	// iterate through results and construct data
+	list := make([]*types.Erc721Token, 0)
+	for cursor.Next(ctx) {
+		var row struct {
+			Token   string `bson:"tok"`
+			TokenId string `bson:"tid"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode ERC721 token row; %s", err.Error())
+			return nil, err
+		}
+
+		// decode the token id
+		tid, err := hexutil.DecodeBig(row.TokenId)
+		if err != nil {
+			db.log.Errorf("invalid ERC721 token id %s; %s", row.TokenId, err.Error())
+			continue
+		}
+
+		list = append(list, &types.Erc721Token{
+			Contract: common.HexToAddress(row.Token),
+			TokenId:  hexutil.Big(*tid),
+			Owner:    owner,
+		})
+	}
+	return list, nil
+}
+
+// Erc1155Balances loads the list of ERC1155 token types held by the given account
+// along with the current balance. Token types with zero balance are not included.
+func (db *MongoDbBridge) Erc1155Balances(ctx context.Context, owner common.Address, count int32) ([]*types.Erc1155Balance, error) {
+	// make sure the count is positive; use default size if not
+	if count <= 0 {
+		count = defaultTokenListLength
+	}
+
+	// log what we do
+	db.log.Debugf("loading %d ERC1155 balances of %s", count, owner.String())
+
+	col := db.client.Database(db.dbName).Collection(colErc1155Balances)
+	cursor, err := col.Find(ctx,
+		bson.D{{Key: fiErcOwnerAddress, Value: owner.String()}, {Key: fiErcOwnerActive, Value: true}},
+		options.Find().SetSort(bson.D{{Key: fiErcOwnerPk, Value: 1}}).SetLimit(int64(count)))
+	if err != nil {
+		db.log.Errorf("can not load ERC1155 balances of %s; %s", owner.String(), err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	// iterate through results and construct data
+	list := make([]*types.Erc1155Balance, 0)
+	for cursor.Next(ctx) {
+		var row erc1155BalanceRow
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode ERC1155 balance row; %s", err.Error())
+			return nil, err
+		}
+
+		// decode the token id and the balance
+		tid, err := hexutil.DecodeBig(row.TokenId)
+		if err != nil {
+			db.log.Errorf("invalid ERC1155 token id %s; %s", row.TokenId, err.Error())
+			continue
+		}
+		bal, err := hexutil.DecodeBig(row.Balance)
+		if err != nil {
+			db.log.Errorf("invalid ERC1155 balance %s; %s", row.Balance, err.Error())
+			continue
+		}
+
+		list = append(list, &types.Erc1155Balance{
+			Contract: common.HexToAddress(row.Token),
+			TokenId:  hexutil.Big(*tid),
+			Owner:    owner,
+			Balance:  hexutil.Big(*bal),
+		})
+	}
+	return list, nil
+}
+
+// migrateErc721Owners builds the ERC721 token owners collection from the recorded token transfers.
+func (db *MongoDbBridge) migrateErc721Owners() error {
+	col := db.client.Database(db.dbName).Collection(colErc721Owners)
+	if _, err := col.Indexes().CreateOne(context.Background(), erc721OwnersIndex()); err != nil {
+		return err
+	}
+
+	count, err := db.replayTokenTransfers(context.Background(),
+		bson.D{{Key: types.FiTokenTransactionTokenType, Value: types.AccountTypeERC721Contract}},
+		db.Erc721UpdateOwner)
+
+	db.log.Noticef("%d ERC721 transfers replayed", count)
+	return err
+}
+
+// migrateErc1155Balances builds the ERC1155 token balances collection from the recorded token transfers.
+func (db *MongoDbBridge) migrateErc1155Balances() error {
+	col := db.client.Database(db.dbName).Collection(colErc1155Balances)
+	if _, err := col.Indexes().CreateOne(context.Background(), erc1155BalancesIndex()); err != nil {
+		return err
+	}
+
+	count, err := db.replayTokenTransfers(context.Background(),
+		bson.D{{Key: types.FiTokenTransactionTokenType, Value: types.AccountTypeERC1155Contract}},
+		db.Erc1155UpdateBalances)
+
+	db.log.Noticef("%d ERC1155 transfers replayed", count)
+	return err
+}
+
+// replayTokenTransfers passes the recorded transfers matching the given filter
+// to the given function in the order they happened on chain.
+// It returns the number of the transfers replayed.
+func (db *MongoDbBridge) replayTokenTransfers(ctx context.Context, filter bson.D, fn func(context.Context, *types.TokenTransaction) error) (int, error) {
+	col := db.client.Database(db.dbName).Collection(colErcTransactions)
+
+	// approvals do not move tokens
+	cursor, err := col.Find(ctx,
+		append(filter, bson.E{Key: types.FiTokenTransactionType, Value: bson.D{
+			{Key: "$in", Value: bson.A{types.TokenTrxTypeTransfer, types.TokenTrxTypeMint, types.TokenTrxTypeBurn}},
+		}}),
+		options.Find().SetSort(bson.D{{Key: types.FiTokenTransactionPk, Value: 1}}))
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	var count int
+	for cursor.Next(ctx) {
+		var trx types.TokenTransaction
+		if err := cursor.Decode(&trx); err != nil {
+			return count, err
+		}
+		if err := fn(ctx, &trx); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, cursor.Err()
+}
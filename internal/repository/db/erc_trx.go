@@ -27,7 +27,7 @@ func (db *MongoDbBridge) initErc20TrxCollection(col *mongo.Collection) {
 	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiTokenTransactionOrdinal, Value: -1}}})
 	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiTokenTransactionCallHash, Value: 1}}})
 
-	// sender + ordinal index
+	// recipient + token index
 	tox := "to_tok"
 	ix = append(ix, mongo.IndexModel{
 		Keys: bson.D{{Key: types.FiTokenTransactionRecipient, Value: 1}, {Key: types.FiTokenTransactionToken, Value: 1}},
@@ -35,6 +35,7 @@ func (db *MongoDbBridge) initErc20TrxCollection(col *mongo.Collection) {
 			Name: &tox,
 		},
 	})
+	ix = append(ix, erc20TrxSenderTokenIndex())
 
 	// create indexes
 	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
@@ -45,18 +46,37 @@ func (db *MongoDbBridge) initErc20TrxCollection(col *mongo.Collection) {
 	db.log.Debugf("ERC20 trx collection initialized")
 }
 
+// erc20TrxSenderTokenIndex provides the index model of ERC20 transfers by the sender and the token.
+func erc20TrxSenderTokenIndex() mongo.IndexModel {
+	fox := "from_tok"
+	return mongo.IndexModel{
+		Keys: bson.D{{Key: types.FiTokenTransactionSender, Value: 1}, {Key: types.FiTokenTransactionToken, Value: 1}},
+		Options: &options.IndexOptions{
+			Name: &fox,
+		},
+	}
+}
+
 // AddERC20Transaction stores an ERC20 transaction in the database if it doesn't exist.
-func (db *MongoDbBridge) AddERC20Transaction(trx *types.TokenTransaction) error {
+func (db *MongoDbBridge) AddERC20Transaction(ctx context.Context, trx *types.TokenTransaction) error {
+	// batched writes are upserts, the existing transaction is replaced
+	if db.bulkErcTrx != nil {
+		return db.bulkErcTrx.add(mongo.NewReplaceOneModel().
+			SetFilter(bson.D{{Key: types.FiTokenTransactionPk, Value: trx.Pk()}}).
+			SetReplacement(trx).
+			SetUpsert(true))
+	}
+
 	// get the collection for delegations
 	col := db.client.Database(db.dbName).Collection(colErcTransactions)
 
 	// is it a new one?
-	if db.isErcTransactionKnown(col, trx) {
+	if db.isErcTransactionKnown(ctx, col, trx) {
 		return nil
 	}
 
 	// try to do the insert
-	if _, err := col.InsertOne(context.Background(), trx); err != nil {
+	if _, err := col.InsertOne(ctx, trx); err != nil {
 		db.log.Critical(err)
 		return err
 	}
@@ -69,9 +89,9 @@ func (db *MongoDbBridge) AddERC20Transaction(trx *types.TokenTransaction) error
 }
 
 // isErcTransactionKnown checks if the given delegation exists in the database.
-func (db *MongoDbBridge) isErcTransactionKnown(col *mongo.Collection, trx *types.TokenTransaction) bool {
+func (db *MongoDbBridge) isErcTransactionKnown(ctx context.Context, col *mongo.Collection, trx *types.TokenTransaction) bool {
 	// try to find the delegation in the database
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(ctx, bson.D{
 		{Key: types.FiTokenTransactionPk, Value: trx.Pk()},
 	}, options.FindOne().SetProjection(bson.D{
 		{Key: types.FiTokenTransactionPk, Value: true},
@@ -92,24 +112,24 @@ func (db *MongoDbBridge) isErcTransactionKnown(col *mongo.Collection, trx *types
 
 // ErcTransactionCountFiltered calculates total number of ERC20 transactions
 // in the database for the given filter.
-func (db *MongoDbBridge) ErcTransactionCountFiltered(filter *bson.D) (uint64, error) {
-	return db.CountFiltered(db.client.Database(db.dbName).Collection(colErcTransactions), filter)
+func (db *MongoDbBridge) ErcTransactionCountFiltered(ctx context.Context, filter *bson.D) (uint64, error) {
+	return db.CountFiltered(ctx, db.client.Database(db.dbName).Collection(colErcTransactions), filter)
 }
 
 // ErcTransactionCount calculates total number of ERC20 transactions in the database.
-func (db *MongoDbBridge) ErcTransactionCount() (uint64, error) {
-	return db.EstimateCount(db.client.Database(db.dbName).Collection(colErcTransactions))
+func (db *MongoDbBridge) ErcTransactionCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(colErcTransactions))
 }
 
 // ercTrxListInit initializes list of ERC20 transactions based on provided cursor, count, and filter.
-func (db *MongoDbBridge) ercTrxListInit(col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.TokenTransactionList, error) {
+func (db *MongoDbBridge) ercTrxListInit(ctx context.Context, col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.TokenTransactionList, error) {
 	// make sure some filter is used
 	if nil == filter {
 		filter = &bson.D{}
 	}
 
 	// find how many transactions do we have in the database
-	total, err := col.CountDocuments(context.Background(), *filter)
+	total, err := col.CountDocuments(ctx, *filter)
 	if err != nil {
 		db.log.Errorf("can not count ERC20 transactions")
 		return nil, err
@@ -129,7 +149,7 @@ func (db *MongoDbBridge) ercTrxListInit(col *mongo.Collection, cursor *string, c
 
 	// is the list non-empty? return the list with properly calculated range marks
 	if 0 < total {
-		return db.ercTrxListCollectRangeMarks(col, &list, cursor, count)
+		return db.ercTrxListCollectRangeMarks(ctx, col, &list, cursor, count)
 	}
 	// this is an empty list
 	db.log.Debug("empty erc trx list created")
@@ -137,27 +157,27 @@ func (db *MongoDbBridge) ercTrxListInit(col *mongo.Collection, cursor *string, c
 }
 
 // ercTrxListCollectRangeMarks returns a list of ERC20 transactions with proper First/Last marks.
-func (db *MongoDbBridge) ercTrxListCollectRangeMarks(col *mongo.Collection, list *types.TokenTransactionList, cursor *string, count int32) (*types.TokenTransactionList, error) {
+func (db *MongoDbBridge) ercTrxListCollectRangeMarks(ctx context.Context, col *mongo.Collection, list *types.TokenTransactionList, cursor *string, count int32) (*types.TokenTransactionList, error) {
 	var err error
 
 	// find out the cursor ordinal index
 	if cursor == nil && count > 0 {
 		// get the highest available pk
-		list.First, err = db.ercTrxListBorderPk(col,
+		list.First, err = db.ercTrxListBorderPk(ctx, col,
 			list.Filter,
 			options.FindOne().SetSort(bson.D{{Key: types.FiTokenTransactionOrdinal, Value: -1}}))
 		list.IsStart = true
 
 	} else if cursor == nil && count < 0 {
 		// get the lowest available pk
-		list.First, err = db.ercTrxListBorderPk(col,
+		list.First, err = db.ercTrxListBorderPk(ctx, col,
 			list.Filter,
 			options.FindOne().SetSort(bson.D{{Key: types.FiTokenTransactionOrdinal, Value: 1}}))
 		list.IsEnd = true
 
 	} else if cursor != nil {
 		// the cursor itself is the starting point
-		list.First, err = db.ercTrxListBorderPk(col,
+		list.First, err = db.ercTrxListBorderPk(ctx, col,
 			bson.D{{Key: types.FiTokenTransactionPk, Value: *cursor}},
 			options.FindOne())
 	}
@@ -174,7 +194,7 @@ func (db *MongoDbBridge) ercTrxListCollectRangeMarks(col *mongo.Collection, list
 }
 
 // ercTrxListBorderPk finds the top PK of the ERC20 transactions collection based on given filter and options.
-func (db *MongoDbBridge) ercTrxListBorderPk(col *mongo.Collection, filter bson.D, opt *options.FindOneOptions) (uint64, error) {
+func (db *MongoDbBridge) ercTrxListBorderPk(ctx context.Context, col *mongo.Collection, filter bson.D, opt *options.FindOneOptions) (uint64, error) {
 	// prep container
 	var row struct {
 		Value uint64 `bson:"orx"`
@@ -184,7 +204,7 @@ func (db *MongoDbBridge) ercTrxListBorderPk(col *mongo.Collection, filter bson.D
 	opt.SetProjection(bson.D{{Key: types.FiTokenTransactionOrdinal, Value: true}})
 
 	// try to decode
-	sr := col.FindOne(context.Background(), filter, opt)
+	sr := col.FindOne(ctx, filter, opt)
 	err := sr.Decode(&row)
 	if err != nil {
 		return 0, err
@@ -239,10 +259,7 @@ func (db *MongoDbBridge) ercTrxListOptions(count int32) *options.FindOptions {
 }
 
 // ercTrxListLoad load the initialized list of ERC20 transactions from database.
-func (db *MongoDbBridge) ercTrxListLoad(col *mongo.Collection, cursor *string, count int32, list *types.TokenTransactionList) (err error) {
-	// get the context for loader
-	ctx := context.Background()
-
+func (db *MongoDbBridge) ercTrxListLoad(ctx context.Context, col *mongo.Collection, cursor *string, count int32, list *types.TokenTransactionList) (err error) {
 	// load the data
 	ld, err := col.Find(ctx, db.ercTrxListFilter(cursor, count, list), db.ercTrxListOptions(count))
 	if err != nil {
@@ -289,17 +306,17 @@ func (db *MongoDbBridge) ercTrxListLoad(col *mongo.Collection, cursor *string, c
 }
 
 // Erc20Transactions pulls list of ERC20 transactions starting at the specified cursor.
-func (db *MongoDbBridge) Erc20Transactions(cursor *string, count int32, filter *bson.D) (*types.TokenTransactionList, error) {
+func (db *MongoDbBridge) Erc20Transactions(ctx context.Context, cursor *string, count int32, filter *types.TokenTransactionFilter) (*types.TokenTransactionList, error) {
 	// nothing to load?
 	if count == 0 {
 		return nil, fmt.Errorf("nothing to do, zero erc transactions requested")
 	}
 
 	// get the collection and context
-	col := db.client.Database(db.dbName).Collection(colErcTransactions)
+	col := db.listCollection(colErcTransactions)
 
 	// init the list
-	list, err := db.ercTrxListInit(col, cursor, count, filter)
+	list, err := db.ercTrxListInit(ctx, col, cursor, count, tokenTransactionFilter(filter))
 	if err != nil {
 		db.log.Errorf("can not build erc transaction list; %s", err.Error())
 		return nil, err
@@ -307,7 +324,7 @@ func (db *MongoDbBridge) Erc20Transactions(cursor *string, count int32, filter *
 
 	// load data if there are any
 	if list.Total > 0 {
-		err = db.ercTrxListLoad(col, cursor, count, list)
+		err = db.ercTrxListLoad(ctx, col, cursor, count, list)
 		if err != nil {
 			db.log.Errorf("can not load erc transaction list from database; %s", err.Error())
 			return nil, err
@@ -322,7 +339,7 @@ func (db *MongoDbBridge) Erc20Transactions(cursor *string, count int32, filter *
 }
 
 // Erc20Assets provides list of unique token addresses linked by transactions to the given owner address.
-func (db *MongoDbBridge) Erc20Assets(owner common.Address, count int32) ([]common.Address, error) {
+func (db *MongoDbBridge) Erc20Assets(ctx context.Context, owner common.Address, count int32) ([]common.Address, error) {
 	// nothing to load?
 	if count <= 1 {
 		return nil, fmt.Errorf("nothing to do, zero erc assets requested")
@@ -330,8 +347,11 @@ func (db *MongoDbBridge) Erc20Assets(owner common.Address, count int32) ([]commo
 
 	// get the collection and context
 	col := db.client.Database(db.dbName).Collection(colErcTransactions)
-	refs, err := col.Distinct(context.Background(), types.FiTokenTransactionToken,
-		bson.D{{Key: "to", Value: owner.String()}},
+	refs, err := col.Distinct(ctx, types.FiTokenTransactionToken,
+		bson.D{
+			{Key: types.FiTokenTransactionRecipient, Value: owner.String()},
+			{Key: types.FiTokenTransactionTokenType, Value: types.AccountTypeERC20Token},
+		},
 	)
 	if err != nil {
 		db.log.Errorf("can not pull assets for %s; %s", owner.String(), err.Error())
@@ -347,19 +367,19 @@ func (db *MongoDbBridge) Erc20Assets(owner common.Address, count int32) ([]commo
 }
 
 // TokenTransactionsByCall provides list of token transactions for the given blockchain transaction call.
-func (db *MongoDbBridge) TokenTransactionsByCall(trxHash *common.Hash) ([]*types.TokenTransaction, error) {
+func (db *MongoDbBridge) TokenTransactionsByCall(ctx context.Context, trxHash *common.Hash) ([]*types.TokenTransaction, error) {
 	col := db.client.Database(db.dbName).Collection(colErcTransactions)
 
 	// search for values
 	ld, err := col.Find(
-		context.Background(),
+		ctx,
 		bson.D{{Key: types.FiTokenTransactionCallHash, Value: trxHash.String()}},
 		options.Find().SetSort(bson.D{{Key: types.FiTokenTransactionOrdinal, Value: -1}}),
 	)
 
 	// close the cursor as we leave
 	defer func() {
-		err = ld.Close(context.Background())
+		err = ld.Close(ctx)
 		if err != nil {
 			db.log.Errorf("error closing token transactions list cursor; %s", err.Error())
 		}
@@ -367,7 +387,7 @@ func (db *MongoDbBridge) TokenTransactionsByCall(trxHash *common.Hash) ([]*types
 
 	// loop and load the list; we may not store the last value
 	list := make([]*types.TokenTransaction, 0)
-	for ld.Next(context.Background()) {
+	for ld.Next(ctx) {
 		var row types.TokenTransaction
 		if err = ld.Decode(&row); err != nil {
 			db.log.Errorf("can not decode the token transaction; %s", err.Error())
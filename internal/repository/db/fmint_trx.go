@@ -35,17 +35,17 @@ func (db *MongoDbBridge) initFMintTrxCollection(col *mongo.Collection) {
 }
 
 // AddFMintTransaction stores an fMint transaction in the database if it doesn't exist.
-func (db *MongoDbBridge) AddFMintTransaction(trx *types.FMintTransaction) error {
+func (db *MongoDbBridge) AddFMintTransaction(ctx context.Context, trx *types.FMintTransaction) error {
 	// get the collection for delegations
 	col := db.client.Database(db.dbName).Collection(colFMintTransactions)
 
 	// is it a new one?
-	if db.isFMintTransactionKnown(col, trx) {
+	if db.isFMintTransactionKnown(ctx, col, trx) {
 		return nil
 	}
 
 	// try to do the insert
-	if _, err := col.InsertOne(context.Background(), trx); err != nil {
+	if _, err := col.InsertOne(ctx, trx); err != nil {
 		db.log.Critical(err)
 		return err
 	}
@@ -58,9 +58,9 @@ func (db *MongoDbBridge) AddFMintTransaction(trx *types.FMintTransaction) error
 }
 
 // isFMintTransactionKnown checks if the given delegation exists in the database.
-func (db *MongoDbBridge) isFMintTransactionKnown(col *mongo.Collection, trx *types.FMintTransaction) bool {
+func (db *MongoDbBridge) isFMintTransactionKnown(ctx context.Context, col *mongo.Collection, trx *types.FMintTransaction) bool {
 	// try to find the delegation in the database
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(ctx, bson.D{
 		{Key: types.FiFMintTransactionId, Value: trx.Pk()},
 	}, options.FindOne().SetProjection(bson.D{
 		{Key: types.FiFMintTransactionId, Value: true},
@@ -80,18 +80,18 @@ func (db *MongoDbBridge) isFMintTransactionKnown(col *mongo.Collection, trx *typ
 }
 
 // FMintTransactionCount calculates total number of fMint transactions in the database.
-func (db *MongoDbBridge) FMintTransactionCount() (uint64, error) {
-	return db.EstimateCount(db.client.Database(db.dbName).Collection(colFMintTransactions))
+func (db *MongoDbBridge) FMintTransactionCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(colFMintTransactions))
 }
 
 // FMintTransactionCountFiltered calculates total number of sMint transactions
 // in the database for the given filter.
-func (db *MongoDbBridge) FMintTransactionCountFiltered(filter *bson.D) (uint64, error) {
-	return db.CountFiltered(db.client.Database(db.dbName).Collection(colFMintTransactions), filter)
+func (db *MongoDbBridge) FMintTransactionCountFiltered(ctx context.Context, filter *bson.D) (uint64, error) {
+	return db.CountFiltered(ctx, db.client.Database(db.dbName).Collection(colFMintTransactions), filter)
 }
 
 // FMintTransactions pulls list of fMint transactions starting at the specified cursor.
-func (db *MongoDbBridge) FMintTransactions(cursor *string, count int32, filter *bson.D) (*types.FMintTransactionList, error) {
+func (db *MongoDbBridge) FMintTransactions(ctx context.Context, cursor *string, count int32, filter *bson.D) (*types.FMintTransactionList, error) {
 	// nothing to load?
 	if count == 0 {
 		return nil, fmt.Errorf("nothing to do, zero fMint transactions requested")
@@ -101,7 +101,7 @@ func (db *MongoDbBridge) FMintTransactions(cursor *string, count int32, filter *
 	col := db.client.Database(db.dbName).Collection(colFMintTransactions)
 
 	// init the list
-	list, err := db.fMintTrxListInit(col, cursor, count, filter)
+	list, err := db.fMintTrxListInit(ctx, col, cursor, count, filter)
 	if err != nil {
 		db.log.Errorf("can not build fMint transaction list; %s", err.Error())
 		return nil, err
@@ -109,7 +109,7 @@ func (db *MongoDbBridge) FMintTransactions(cursor *string, count int32, filter *
 
 	// load data if there are any
 	if list.Total > 0 {
-		err = db.fMintTrxListLoad(col, cursor, count, list)
+		err = db.fMintTrxListLoad(ctx, col, cursor, count, list)
 		if err != nil {
 			db.log.Errorf("can not load fMint transaction list from database; %s", err.Error())
 			return nil, err
@@ -124,14 +124,14 @@ func (db *MongoDbBridge) FMintTransactions(cursor *string, count int32, filter *
 }
 
 // fMintTrxListInit initializes list of fMint transactions based on provided cursor, count, and filter.
-func (db *MongoDbBridge) fMintTrxListInit(col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.FMintTransactionList, error) {
+func (db *MongoDbBridge) fMintTrxListInit(ctx context.Context, col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.FMintTransactionList, error) {
 	// make sure some filter is used
 	if nil == filter {
 		filter = &bson.D{}
 	}
 
 	// find how many transactions do we have in the database
-	total, err := col.CountDocuments(context.Background(), *filter)
+	total, err := col.CountDocuments(ctx, *filter)
 	if err != nil {
 		db.log.Errorf("can not count fMint transactions")
 		return nil, err
@@ -151,7 +151,7 @@ func (db *MongoDbBridge) fMintTrxListInit(col *mongo.Collection, cursor *string,
 
 	// is the list non-empty? return the list with properly calculated range marks
 	if 0 < total {
-		return db.fMintTrxListCollectRangeMarks(col, &list, cursor, count)
+		return db.fMintTrxListCollectRangeMarks(ctx, col, &list, cursor, count)
 	}
 	// this is an empty list
 	db.log.Debug("empty fMint trx list created")
@@ -159,27 +159,27 @@ func (db *MongoDbBridge) fMintTrxListInit(col *mongo.Collection, cursor *string,
 }
 
 // fMintTrxListCollectRangeMarks finds range marks of a list of fMint transactions with proper First/Last marks.
-func (db *MongoDbBridge) fMintTrxListCollectRangeMarks(col *mongo.Collection, list *types.FMintTransactionList, cursor *string, count int32) (*types.FMintTransactionList, error) {
+func (db *MongoDbBridge) fMintTrxListCollectRangeMarks(ctx context.Context, col *mongo.Collection, list *types.FMintTransactionList, cursor *string, count int32) (*types.FMintTransactionList, error) {
 	var err error
 
 	// find out the cursor ordinal index
 	if cursor == nil && count > 0 {
 		// get the highest available pk
-		list.First, err = db.fMintTrxListBorderPk(col,
+		list.First, err = db.fMintTrxListBorderPk(ctx, col,
 			list.Filter,
 			options.FindOne().SetSort(bson.D{{Key: types.FiFMintTransactionOrdinal, Value: -1}}))
 		list.IsStart = true
 
 	} else if cursor == nil && count < 0 {
 		// get the lowest available pk
-		list.First, err = db.fMintTrxListBorderPk(col,
+		list.First, err = db.fMintTrxListBorderPk(ctx, col,
 			list.Filter,
 			options.FindOne().SetSort(bson.D{{Key: types.FiFMintTransactionOrdinal, Value: 1}}))
 		list.IsEnd = true
 
 	} else if cursor != nil {
 		// the cursor itself is the starting point
-		list.First, err = db.fMintTrxListBorderPk(col,
+		list.First, err = db.fMintTrxListBorderPk(ctx, col,
 			bson.D{{Key: types.FiFMintTransactionId, Value: *cursor}},
 			options.FindOne())
 	}
@@ -196,7 +196,7 @@ func (db *MongoDbBridge) fMintTrxListCollectRangeMarks(col *mongo.Collection, li
 }
 
 // fMintTrxListBorderPk finds the top PK of the ERC20 transactions collection based on given filter and options.
-func (db *MongoDbBridge) fMintTrxListBorderPk(col *mongo.Collection, filter bson.D, opt *options.FindOneOptions) (uint64, error) {
+func (db *MongoDbBridge) fMintTrxListBorderPk(ctx context.Context, col *mongo.Collection, filter bson.D, opt *options.FindOneOptions) (uint64, error) {
 	// prep container
 	var row struct {
 		Value uint64 `bson:"orx"`
@@ -206,7 +206,7 @@ func (db *MongoDbBridge) fMintTrxListBorderPk(col *mongo.Collection, filter bson
 	opt.SetProjection(bson.D{{Key: types.FiFMintTransactionOrdinal, Value: true}})
 
 	// try to decode
-	sr := col.FindOne(context.Background(), filter, opt)
+	sr := col.FindOne(ctx, filter, opt)
 	err := sr.Decode(&row)
 	if err != nil {
 		return 0, err
@@ -261,9 +261,7 @@ func (db *MongoDbBridge) fMintTrxListOptions(count int32) *options.FindOptions {
 }
 
 // fMintTrxListLoad load the initialized list of fMint transactions from database.
-func (db *MongoDbBridge) fMintTrxListLoad(col *mongo.Collection, cursor *string, count int32, list *types.FMintTransactionList) (err error) {
-	ctx := context.Background()
-
+func (db *MongoDbBridge) fMintTrxListLoad(ctx context.Context, col *mongo.Collection, cursor *string, count int32, list *types.FMintTransactionList) (err error) {
 	// load the data
 	ld, err := col.Find(ctx, db.fMintTrxListFilter(cursor, count, list), db.fMintTrxListOptions(count))
 	if err != nil {
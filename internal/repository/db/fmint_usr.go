@@ -17,7 +17,7 @@ type fMintUserTokensRow struct {
 
 // FMintUsers loads the list of fMint users and their associated tokens
 // used for a specified transaction type  from the collected database using aggregation pipeline.
-func (db *MongoDbBridge) FMintUsers(tt int32) ([]*types.FMintUserTokens, error) {
+func (db *MongoDbBridge) FMintUsers(ctx context.Context, tt int32) ([]*types.FMintUserTokens, error) {
 	// prep the aggregation pipeline to be executed
 	ap := mongo.Pipeline{
 		/* match transactions of the given trx type */
@@ -38,20 +38,20 @@ func (db *MongoDbBridge) FMintUsers(tt int32) ([]*types.FMintUserTokens, error)
 
 	// execute aggregation pipeline on the fMint transactions collection and collect results
 	col := db.client.Database(db.dbName).Collection(colFMintTransactions)
-	cursor, err := col.Aggregate(context.Background(), ap)
+	cursor, err := col.Aggregate(ctx, ap)
 	if err != nil {
 		db.log.Errorf("can not aggregate fMint users; %s", err.Error())
 		return nil, err
 	}
 
 	defer func() {
-		if err := cursor.Close(context.Background()); err != nil {
+		if err := cursor.Close(ctx); err != nil {
 			db.log.Errorf("can not close cursor; %s", err.Error())
 		}
 	}()
 
 	// iterate through results and construct data
-	for cursor.Next(context.Background()) {
+	for cursor.Next(ctx) {
 		var row fMintUserTokensRow
 		if err := cursor.Decode(&row); err != nil {
 			db.log.Errorf("can not decode aggregation row; %s", err.Error())
@@ -0,0 +1,104 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"math/big"
+)
+
+// colBurns represents the name of the native FTM burns collection in database.
+const colBurns = "burns"
+
+// StoreBurn stores the FTM burn record of a block; the previous record of the block is replaced.
+func (db *MongoDbBridge) StoreBurn(ctx context.Context, burn *types.FtmBurn) error {
+	// get the collection for burns
+	col := db.client.Database(db.dbName).Collection(colBurns)
+
+	if _, err := col.ReplaceOne(ctx,
+		bson.D{{Key: types.FiFtmBurnPk, Value: uint64(burn.BlockNumber)}},
+		burn,
+		options.Replace().SetUpsert(true),
+	); err != nil {
+		db.log.Errorf("can not store burn of block #%d; %s", uint64(burn.BlockNumber), err.Error())
+		return err
+	}
+	return nil
+}
+
+// BurnByBlock loads the FTM burn record of the given block, if any.
+func (db *MongoDbBridge) BurnByBlock(ctx context.Context, block uint64) (*types.FtmBurn, error) {
+	// get the collection for burns
+	col := db.client.Database(db.dbName).Collection(colBurns)
+
+	sr := col.FindOne(ctx, bson.D{{Key: types.FiFtmBurnPk, Value: block}})
+	if sr.Err() != nil {
+		if sr.Err() == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		db.log.Errorf("can not load burn of block #%d; %s", block, sr.Err().Error())
+		return nil, sr.Err()
+	}
+
+	var burn types.FtmBurn
+	if err := sr.Decode(&burn); err != nil {
+		db.log.Errorf("can not decode burn of block #%d; %s", block, err.Error())
+		return nil, err
+	}
+	return &burn, nil
+}
+
+// BurnCount calculates total number of FTM burn records in the database.
+func (db *MongoDbBridge) BurnCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(colBurns))
+}
+
+// BurnTotal calculates the total amount of FTM burned.
+func (db *MongoDbBridge) BurnTotal(ctx context.Context) (*big.Int, error) {
+	return db.sumFieldValue(ctx,
+		db.client.Database(db.dbName).Collection(colBurns),
+		types.FiFtmBurnValue,
+		nil,
+		types.FtmBurnDecimalsCorrection)
+}
+
+// BurnList loads a list of FTM burn records from the most recent block,
+// starting below the given block number, if any.
+func (db *MongoDbBridge) BurnList(ctx context.Context, below *uint64, count int32) ([]*types.FtmBurn, error) {
+	// get the collection
+	col := db.listCollection(colBurns)
+
+	filter := bson.D{}
+	if below != nil {
+		filter = bson.D{{Key: types.FiFtmBurnPk, Value: bson.D{{Key: "$lt", Value: *below}}}}
+	}
+
+	ld, err := col.Find(ctx, filter, options.Find().
+		SetSort(bson.D{{Key: types.FiFtmBurnPk, Value: -1}}).
+		SetLimit(int64(count)))
+	if err != nil {
+		db.log.Errorf("can not load burns; %s", err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing burns cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.FtmBurn, 0, count)
+	for ld.Next(ctx) {
+		var row types.FtmBurn
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode burn; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
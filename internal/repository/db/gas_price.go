@@ -35,7 +35,7 @@ func (db *MongoDbBridge) initGasPriceCollection(col *mongo.Collection) {
 
 // AddGasPricePeriod stores a new record for the gas price evaluation
 // into the persistent collection.
-func (db *MongoDbBridge) AddGasPricePeriod(gp *types.GasPricePeriod) error {
+func (db *MongoDbBridge) AddGasPricePeriod(ctx context.Context, gp *types.GasPricePeriod) error {
 	// do we have anything to store at all?
 	if gp == nil {
 		return fmt.Errorf("no value to store")
@@ -45,7 +45,7 @@ func (db *MongoDbBridge) AddGasPricePeriod(gp *types.GasPricePeriod) error {
 	col := db.client.Database(db.dbName).Collection(colGasPrice)
 
 	// try to do the insert
-	if _, err := col.InsertOne(context.Background(), gp); err != nil {
+	if _, err := col.InsertOne(ctx, gp); err != nil {
 		db.log.Errorf("can not store gas price value; %s", err)
 		return err
 	}
@@ -58,6 +58,6 @@ func (db *MongoDbBridge) AddGasPricePeriod(gp *types.GasPricePeriod) error {
 }
 
 // GasPricePeriodCount calculates total number of gas price period records in the database.
-func (db *MongoDbBridge) GasPricePeriodCount() (uint64, error) {
-	return db.EstimateCount(db.client.Database(db.dbName).Collection(colGasPrice))
+func (db *MongoDbBridge) GasPricePeriodCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(colGasPrice))
 }
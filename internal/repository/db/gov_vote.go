@@ -0,0 +1,111 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colGovVotes represents the name of the governance votes collection in database.
+const colGovVotes = "gov_votes"
+
+// initGovVotesCollection initializes the governance votes collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initGovVotesCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+
+	// index specific elements
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiGovVoteFrom, Value: 1}, {Key: types.FiGovVoteTimeStamp, Value: -1}}})
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiGovVoteGovernance, Value: 1}, {Key: types.FiGovVoteProposal, Value: 1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for governance votes collection; %s", err.Error())
+	}
+
+	// log we are done that
+	db.log.Debugf("governance votes collection initialized")
+}
+
+// AddGovernanceVote stores a governance vote in the database;
+// a known vote of the same voter and delegation on the proposal is replaced.
+func (db *MongoDbBridge) AddGovernanceVote(ctx context.Context, gv *types.GovernanceVote) error {
+	// get the collection for governance votes
+	col := db.client.Database(db.dbName).Collection(colGovVotes)
+
+	// try to do the upsert
+	if _, err := col.ReplaceOne(ctx,
+		bson.D{{Key: types.FiGovVotePk, Value: gv.Pk()}},
+		gv,
+		options.Replace().SetUpsert(true),
+	); err != nil {
+		db.log.Errorf("can not store governance vote %s; %s", gv.Pk(), err.Error())
+		return err
+	}
+
+	// make sure governance votes collection is initialized
+	if db.initGovVotes != nil {
+		db.initGovVotes.Do(func() { db.initGovVotesCollection(col); db.initGovVotes = nil })
+	}
+	return nil
+}
+
+// RemoveGovernanceVote removes a canceled governance vote from the database.
+func (db *MongoDbBridge) RemoveGovernanceVote(ctx context.Context, gov *common.Address, prop *hexutil.Big, from *common.Address, delegatedTo *common.Address) error {
+	// get the collection for governance votes
+	col := db.client.Database(db.dbName).Collection(colGovVotes)
+
+	pk := types.GovernanceVotePk(gov, prop, from, delegatedTo)
+	if _, err := col.DeleteOne(ctx, bson.D{{Key: types.FiGovVotePk, Value: pk}}); err != nil {
+		db.log.Errorf("can not remove governance vote %s; %s", pk, err.Error())
+		return err
+	}
+	return nil
+}
+
+// GovernanceVotesCount returns the number of governance votes stored in the database.
+func (db *MongoDbBridge) GovernanceVotesCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(colGovVotes))
+}
+
+// GovernanceVotesOf loads the list of the most recent governance votes
+// placed by the given account.
+func (db *MongoDbBridge) GovernanceVotesOf(ctx context.Context, adr *common.Address, count int32) ([]*types.GovernanceVote, error) {
+	// get the collection
+	col := db.listCollection(colGovVotes)
+
+	// search for values
+	ld, err := col.Find(ctx,
+		bson.D{{Key: types.FiGovVoteFrom, Value: adr.String()}},
+		options.Find().SetSort(bson.D{{Key: types.FiGovVoteTimeStamp, Value: -1}}).SetLimit(int64(count)),
+	)
+	if err != nil {
+		db.log.Errorf("can not load governance votes of %s; %s", adr.String(), err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing governance votes cursor; %s", err.Error())
+		}
+	}()
+
+	// load the list
+	list := make([]*types.GovernanceVote, 0)
+	for ld.Next(ctx) {
+		var row types.GovernanceVote
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode governance vote; %s", err.Error())
+			continue
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
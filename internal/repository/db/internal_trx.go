@@ -0,0 +1,208 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// colInternalTransactions represents the name of the internal transactions collection in database.
+const colInternalTransactions = "internal_trx"
+
+// initInternalTrxCollection initializes the internal transactions collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initInternalTrxCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+
+	// index specific elements
+	ix = append(ix, internalTrxOrdinalIndex())
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiInternalTrxSender, Value: 1}, {Key: types.FiInternalTrxBlock, Value: -1}}})
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiInternalTrxRecipient, Value: 1}, {Key: types.FiInternalTrxBlock, Value: -1}}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for internal trx collection; %s", err.Error())
+	}
+
+	// log we are done that
+	db.log.Debugf("internal trx collection initialized")
+}
+
+// internalTrxOrdinalIndex provides the index of internal transactions by the parent transaction
+// and the order of their execution.
+func internalTrxOrdinalIndex() mongo.IndexModel {
+	return mongo.IndexModel{Keys: bson.D{{Key: types.FiInternalTrxHash, Value: 1}, {Key: types.FiInternalTrxOrdinal, Value: 1}}}
+}
+
+// AddInternalTransaction stores an internal transaction in the database;
+// a known internal transaction is replaced.
+func (db *MongoDbBridge) AddInternalTransaction(ctx context.Context, itx *types.InternalTransaction) error {
+	// get the collection for internal transactions
+	col := db.client.Database(db.dbName).Collection(colInternalTransactions)
+
+	// try to do the upsert
+	if _, err := col.ReplaceOne(ctx,
+		bson.D{{Key: types.FiInternalTrxPk, Value: itx.Pk()}},
+		itx,
+		options.Replace().SetUpsert(true),
+	); err != nil {
+		db.log.Errorf("can not store internal trx %s; %s", itx.Pk(), err.Error())
+		return err
+	}
+
+	// make sure internal transactions collection is initialized
+	if db.initInternalTrx != nil {
+		db.initInternalTrx.Do(func() { db.initInternalTrxCollection(col); db.initInternalTrx = nil })
+	}
+	return nil
+}
+
+// InternalTransactionsCount returns the number of internal transactions stored in the database.
+func (db *MongoDbBridge) InternalTransactionsCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(colInternalTransactions))
+}
+
+// InternalTransactions loads the list of internal transactions of the given parent transaction
+// in the order of their execution.
+func (db *MongoDbBridge) InternalTransactions(ctx context.Context, hash *common.Hash) ([]*types.InternalTransaction, error) {
+	return db.internalTransactions(ctx,
+		bson.D{{Key: types.FiInternalTrxHash, Value: hash.String()}},
+		options.Find().SetSort(bson.D{{Key: types.FiInternalTrxOrdinal, Value: 1}}),
+	)
+}
+
+// InternalTransactionsOfAccount loads the list of the most recent internal transactions
+// the given account is involved with, either as the caller, or the callee.
+func (db *MongoDbBridge) InternalTransactionsOfAccount(ctx context.Context, adr *common.Address, count int32) ([]*types.InternalTransaction, error) {
+	return db.internalTransactions(ctx,
+		bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: types.FiInternalTrxSender, Value: adr.String()}},
+			bson.D{{Key: types.FiInternalTrxRecipient, Value: adr.String()}},
+		}}},
+		options.Find().SetSort(bson.D{{Key: types.FiInternalTrxBlock, Value: -1}}).SetLimit(int64(count)),
+	)
+}
+
+// internalTransactions loads a list of internal transactions for the given filter and options.
+func (db *MongoDbBridge) internalTransactions(ctx context.Context, filter bson.D, opt *options.FindOptions) ([]*types.InternalTransaction, error) {
+	// get the collection
+	col := db.listCollection(colInternalTransactions)
+
+	// search for values
+	ld, err := col.Find(ctx, filter, opt)
+	if err != nil {
+		db.log.Errorf("can not load internal transactions; %s", err.Error())
+		return nil, err
+	}
+
+	// close the cursor as we leave
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing internal transactions cursor; %s", err.Error())
+		}
+	}()
+
+	// load the list
+	list := make([]*types.InternalTransaction, 0)
+	for ld.Next(ctx) {
+		var row types.InternalTransaction
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode internal transaction; %s", err.Error())
+			continue
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
+
+// internalTrxCall represents the BSON record of an internal transaction
+// reduced to the position of the call in the call tree.
+type internalTrxCall struct {
+	ID       string `bson:"_id"`
+	Trx      string `bson:"trx"`
+	TraceAdr string `bson:"tad"`
+}
+
+// migrateInternalTrxOrdinal adds the order of execution to the internal transactions stored
+// before it had been recorded; the order is derived from the trace addresses of the calls.
+func (db *MongoDbBridge) migrateInternalTrxOrdinal() error {
+	ctx := context.Background()
+	col := db.client.Database(db.dbName).Collection(colInternalTransactions)
+	if _, err := col.Indexes().CreateOne(ctx, internalTrxOrdinalIndex()); err != nil {
+		return err
+	}
+
+	cursor, err := col.Find(ctx, bson.D{}, options.Find().
+		SetSort(bson.D{{Key: types.FiInternalTrxHash, Value: 1}}).
+		SetProjection(bson.D{{Key: types.FiInternalTrxHash, Value: 1}, {Key: types.FiInternalTrxTraceAddress, Value: 1}}))
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	// collect the calls of each parent transaction and number them
+	calls := make([]internalTrxCall, 0)
+	for cursor.Next(ctx) {
+		var row internalTrxCall
+		if err := cursor.Decode(&row); err != nil {
+			return err
+		}
+
+		if len(calls) > 0 && calls[0].Trx != row.Trx {
+			if err := updateInternalTrxOrdinal(ctx, col, calls); err != nil {
+				return err
+			}
+			calls = calls[:0]
+		}
+		calls = append(calls, row)
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+	return updateInternalTrxOrdinal(ctx, col, calls)
+}
+
+// updateInternalTrxOrdinal stores the order of execution of the given calls of a single transaction.
+// The node executes a call before its sub-calls and sub-calls in the order of their index,
+// so the order is given by the numeric comparison of the trace addresses.
+func updateInternalTrxOrdinal(ctx context.Context, col *mongo.Collection, calls []internalTrxCall) error {
+	sort.Slice(calls, func(i, j int) bool {
+		return traceAddressLess(calls[i].TraceAdr, calls[j].TraceAdr)
+	})
+
+	for i, c := range calls {
+		if _, err := col.UpdateOne(ctx,
+			bson.D{{Key: types.FiInternalTrxPk, Value: c.ID}},
+			bson.D{{Key: "$set", Value: bson.D{{Key: types.FiInternalTrxOrdinal, Value: uint32(i)}}}},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// traceAddressLess checks if the call at the trace address a is executed before the call at b.
+func traceAddressLess(a string, b string) bool {
+	pa, pb := strings.Split(a, "_"), strings.Split(b, "_")
+	for i := 0; i < len(pa) && i < len(pb); i++ {
+		va, _ := strconv.Atoi(pa[i])
+		vb, _ := strconv.Atoi(pb[i])
+		if va != vb {
+			return va < vb
+		}
+	}
+	return len(pa) < len(pb)
+}
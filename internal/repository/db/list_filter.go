@@ -0,0 +1,108 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"fantom-api-graphql/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// bsonNullType represents the BSON type number of the null value.
+const bsonNullType = 10
+
+// delegationFilter builds the database filter of a delegation list.
+func delegationFilter(f *types.DelegationFilter) *bson.D {
+	fi := bson.D{}
+	if f == nil {
+		return &fi
+	}
+
+	if f.Address != nil {
+		fi = append(fi, bson.E{Key: types.FiDelegationAddress, Value: f.Address.String()})
+	}
+	if f.ToValidator != nil {
+		fi = append(fi, bson.E{Key: types.FiDelegationToValidator, Value: f.ToValidator.String()})
+	}
+	if f.ActiveOnly {
+		fi = append(fi, bson.E{Key: types.FiDelegationValue, Value: bson.D{{Key: "$gt", Value: 0}}})
+	}
+	return &fi
+}
+
+// tokenTransactionFilter builds the database filter of a token transaction list.
+func tokenTransactionFilter(f *types.TokenTransactionFilter) *bson.D {
+	fi := bson.D{}
+	if f == nil {
+		return &fi
+	}
+
+	if f.TokenType != "" {
+		fi = append(fi, bson.E{Key: types.FiTokenTransactionTokenType, Value: f.TokenType})
+	}
+	if f.Token != nil {
+		fi = append(fi, bson.E{Key: types.FiTokenTransactionToken, Value: f.Token.String()})
+	}
+	if f.TokenId != nil {
+		fi = append(fi, bson.E{Key: types.FiTokenTransactionTokenId, Value: f.TokenId.String()})
+	}
+
+	// common address (sender or recipient)
+	if f.Account != nil {
+		fi = append(fi, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: types.FiTokenTransactionSender, Value: f.Account.String()}},
+			bson.D{{Key: types.FiTokenTransactionRecipient, Value: f.Account.String()}},
+		}})
+	}
+	if f.Type != nil {
+		fi = append(fi, bson.E{Key: types.FiTokenTransactionType, Value: *f.Type})
+	}
+	return &fi
+}
+
+// rewardClaimFilter builds the database filter of a reward claim list.
+func rewardClaimFilter(f *types.RewardClaimFilter) *bson.D {
+	fi := bson.D{}
+	if f == nil {
+		return &fi
+	}
+
+	if f.Address != nil {
+		fi = append(fi, bson.E{Key: types.FiRewardClaimAddress, Value: f.Address.String()})
+	}
+	if f.ToValidator != nil {
+		fi = append(fi, bson.E{Key: types.FiRewardClaimToValidator, Value: f.ToValidator.String()})
+	}
+
+	// time range of the claims
+	if f.Since != nil || f.Until != nil {
+		rng := bson.D{}
+		if f.Since != nil {
+			rng = append(rng, bson.E{Key: "$gte", Value: *f.Since})
+		}
+		if f.Until != nil {
+			rng = append(rng, bson.E{Key: "$lte", Value: *f.Until})
+		}
+		fi = append(fi, bson.E{Key: types.FiRewardClaimedTimeStamp, Value: rng})
+	}
+	return &fi
+}
+
+// withdrawalFilter builds the database filter of a withdraw request list.
+func withdrawalFilter(f *types.WithdrawalFilter) *bson.D {
+	fi := bson.D{}
+	if f == nil {
+		return &fi
+	}
+
+	if f.Address != nil {
+		fi = append(fi, bson.E{Key: types.FiWithdrawalAddress, Value: f.Address.String()})
+	}
+	if f.ToValidator != nil {
+		fi = append(fi, bson.E{Key: types.FiWithdrawalToValidator, Value: f.ToValidator.String()})
+	}
+
+	// pending requests do not have the finalizing transaction
+	if f.PendingOnly {
+		fi = append(fi, bson.E{Key: types.FiWithdrawalFinTrx, Value: bson.D{{Key: "$type", Value: bsonNullType}}})
+	}
+	return &fi
+}
@@ -0,0 +1,125 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"time"
+)
+
+// colMigrations represents the name of the applied database migrations collection.
+const colMigrations = "migrations"
+
+// migration represents a single versioned change of the database schema, e.g. a new index.
+type migration struct {
+	version int32
+	name    string
+	apply   func(db *MongoDbBridge) error
+}
+
+// migrationRecord represents the BSON record of an applied migration.
+type migrationRecord struct {
+	Version int32     `bson:"_id"`
+	Name    string    `bson:"name"`
+	Applied time.Time `bson:"applied"`
+}
+
+// migrations lists the database schema changes in the order of their versions.
+// New migrations are appended to the end of the list; applied migrations must not be changed.
+var migrations = []migration{
+	{version: 1, name: "collection indexes", apply: (*MongoDbBridge).migrateCollectionIndexes},
+	{version: 2, name: "erc20 transfers by sender and token", apply: (*MongoDbBridge).migrateErc20TrxSenderToken},
+	{version: 3, name: "transactions counter", apply: (*MongoDbBridge).migrateTransactionsCounter},
+	{version: 4, name: "daily contract usage", apply: (*MongoDbBridge).migrateContractUsage},
+	{version: 5, name: "daily API key usage", apply: (*MongoDbBridge).migrateApiKeyUsage},
+	{version: 6, name: "erc721 token owners", apply: (*MongoDbBridge).migrateErc721Owners},
+	{version: 7, name: "erc1155 token balances", apply: (*MongoDbBridge).migrateErc1155Balances},
+	{version: 8, name: "internal transactions order", apply: (*MongoDbBridge).migrateInternalTrxOrdinal},
+	{version: 9, name: "account balances in WEI", apply: (*MongoDbBridge).migrateAccountBalances},
+	{version: 10, name: "erc20 holders by transfers", apply: (*MongoDbBridge).migrateErc20Holders},
+	{version: 11, name: "token contracts by symbol", apply: (*MongoDbBridge).migrateContractSymbol},
+}
+
+// Migrate applies the database migrations not applied yet, in the order of their versions.
+func (db *MongoDbBridge) Migrate() error {
+	current, err := db.migrationVersion()
+	if err != nil {
+		db.log.Errorf("can not check database version; %s", err.Error())
+		return err
+	}
+
+	col := db.client.Database(db.dbName).Collection(colMigrations)
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+
+		db.log.Noticef("applying database migration #%d, %s", m.version, m.name)
+		if err := m.apply(db); err != nil {
+			db.log.Errorf("database migration #%d failed; %s", m.version, err.Error())
+			return err
+		}
+
+		if _, err := col.InsertOne(context.Background(), migrationRecord{
+			Version: m.version,
+			Name:    m.name,
+			Applied: time.Now().UTC(),
+		}); err != nil {
+			db.log.Errorf("can not record database migration #%d; %s", m.version, err.Error())
+			return err
+		}
+	}
+
+	db.log.Noticef("database schema is at version #%d", migrations[len(migrations)-1].version)
+	return nil
+}
+
+// migrationVersion provides the version of the most recent migration applied to the database.
+func (db *MongoDbBridge) migrationVersion() (int32, error) {
+	col := db.client.Database(db.dbName).Collection(colMigrations)
+
+	var row migrationRecord
+	err := col.FindOne(context.Background(), bson.D{}, options.FindOne().SetSort(bson.D{{Key: "_id", Value: -1}})).Decode(&row)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	return row.Version, err
+}
+
+// migrateCollectionIndexes creates the indexes of all the collections, so databases created
+// before an index had been introduced get it, too. Existing indexes are kept intact.
+func (db *MongoDbBridge) migrateCollectionIndexes() error {
+	inits := map[string]func(*mongo.Collection){
+		coAccounts:              db.initAccountsCollection,
+		coTransactions:          db.initTransactionsCollection,
+		coContract:              db.initContractsCollection,
+		coUniswap:               db.initUniswapCollection,
+		colDelegations:          db.initDelegationCollection,
+		colWithdrawals:          db.initWithdrawalsCollection,
+		colRewards:              db.initRewardsCollection,
+		colErcTransactions:      db.initErc20TrxCollection,
+		colFMintTransactions:    db.initFMintTrxCollection,
+		colEpochs:               db.initEpochsCollection,
+		colGasPrice:             db.initGasPriceCollection,
+		colInternalTransactions: db.initInternalTrxCollection,
+		colGovVotes:             db.initGovVotesCollection,
+		colErc20Holders:         db.initErc20HoldersCollection,
+		colBlockTimes:           db.initBlockTimesCollection,
+		colLockups:              db.initLockupsCollection,
+		colValidatorPerformance: db.initValidatorPerformanceCollection,
+	}
+
+	for name, init := range inits {
+		init(db.client.Database(db.dbName).Collection(name))
+	}
+	return nil
+}
+
+// migrateErc20TrxSenderToken adds the index of ERC20 transfers by the sender and the token.
+func (db *MongoDbBridge) migrateErc20TrxSenderToken() error {
+	col := db.client.Database(db.dbName).Collection(colErcTransactions)
+	_, err := col.Indexes().CreateOne(context.Background(), erc20TrxSenderTokenIndex())
+	return err
+}
@@ -0,0 +1,39 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colNftMetadata represents the name of the NFT metadata collection.
+const colNftMetadata = "nft_metadata"
+
+// StoreNftMetadata stores the downloaded NFT metadata; the previous record of the token is replaced.
+func (db *MongoDbBridge) StoreNftMetadata(ctx context.Context, md *types.NftMetadata) error {
+	col := db.client.Database(db.dbName).Collection(colNftMetadata)
+
+	_, err := col.ReplaceOne(ctx, bson.D{{Key: "_id", Value: md.Id}}, md, options.Replace().SetUpsert(true))
+	if err != nil {
+		db.log.Errorf("can not store metadata of NFT %s; %s", md.Id, err.Error())
+	}
+	return err
+}
+
+// NftMetadata loads the stored metadata of the NFT of the given identifier, nil if not known.
+func (db *MongoDbBridge) NftMetadata(ctx context.Context, id string) (*types.NftMetadata, error) {
+	col := db.client.Database(db.dbName).Collection(colNftMetadata)
+
+	var md types.NftMetadata
+	if err := col.FindOne(ctx, bson.D{{Key: "_id", Value: id}}).Decode(&md); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		db.log.Errorf("can not load metadata of NFT %s; %s", id, err.Error())
+		return nil, err
+	}
+	return &md, nil
+}
@@ -0,0 +1,287 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"math/big"
+	"regexp"
+)
+
+// fiRecordTrxHash is the name of the transaction hash field
+// shared by records derived from transactions.
+const fiRecordTrxHash = "trx"
+
+// removedTokenTransfers represents the tokens moved by the token transfers of removed blocks.
+type removedTokenTransfers struct {
+	erc20Holders  map[common.Address]map[common.Address]bool
+	erc721Tokens  map[[2]string]bool
+	erc1155Tokens map[[2]string]bool
+}
+
+// RemoveBlocksFrom removes transactions of all the blocks starting with the given block number
+// and the indexed data derived from them so the blocks can be re-ingested
+// after a chain reorganization. Aggregates derived from the token transfers are rebuilt
+// from the remaining transfers; the state the database can not rebuild is returned
+// so it can be refreshed from the node.
+func (db *MongoDbBridge) RemoveBlocksFrom(ctx context.Context, from uint64) (*types.BlockRollback, error) {
+	// pending bulk writes may contain records of the removed blocks
+	if err := db.Flush(); err != nil {
+		db.log.Errorf("can not flush pending writes; %s", err.Error())
+		return nil, err
+	}
+
+	// get the database
+	base := db.client.Database(db.dbName)
+	rb := types.BlockRollback{}
+
+	// collect the affected transactions
+	hashes, err := db.removedTransactions(ctx, from, &rb)
+	if err != nil {
+		return nil, err
+	}
+
+	moved, err := db.removedTokenTransfers(ctx, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	// remove data referencing the affected transactions
+	byTrx := bson.D{{Key: fiRecordTrxHash, Value: bson.D{{Key: "$in", Value: hashes}}}}
+	for _, col := range []string{colErcTransactions, colFMintTransactions, colGovVotes} {
+		if _, err := base.Collection(col).DeleteMany(ctx, byTrx); err != nil {
+			db.log.Errorf("can not remove %s records since block #%d; %s", col, from, err.Error())
+			return nil, err
+		}
+	}
+
+	// remove data referencing the affected blocks
+	byNumber := bson.D{{Key: "_id", Value: bson.D{{Key: "$gte", Value: from}}}}
+	for _, col := range []string{colBurns, colBlockTimes} {
+		if _, err := base.Collection(col).DeleteMany(ctx, byNumber); err != nil {
+			db.log.Errorf("can not remove %s records since block #%d; %s", col, from, err.Error())
+			return nil, err
+		}
+	}
+
+	// the transactions go last
+	byBlock := bson.D{{Key: fiTransactionBlock, Value: bson.D{{Key: "$gte", Value: from}}}}
+	for _, col := range []string{colInternalTransactions, coUniswap, coTransactions} {
+		if _, err := base.Collection(col).DeleteMany(ctx, byBlock); err != nil {
+			db.log.Errorf("can not remove %s records since block #%d; %s", col, from, err.Error())
+			return nil, err
+		}
+	}
+	db.incCounter(ctx, counterTransactions, -int64(len(hashes)))
+
+	// rebuild the tokens moved by the removed transfers
+	if err := db.rebuildErc20Holders(ctx, moved.erc20Holders); err != nil {
+		return nil, err
+	}
+	if err := db.rebuildErc721Owners(ctx, moved.erc721Tokens); err != nil {
+		return nil, err
+	}
+	if err := db.rebuildErc1155Balances(ctx, moved.erc1155Tokens); err != nil {
+		return nil, err
+	}
+
+	db.log.Noticef("removed %d transactions since block #%d", len(hashes), from)
+	return &rb, nil
+}
+
+// removedTransactions collects the hashes of the transactions of the blocks starting
+// with the given block number, and their senders and recipients.
+func (db *MongoDbBridge) removedTransactions(ctx context.Context, from uint64, rb *types.BlockRollback) (bson.A, error) {
+	ld, err := db.client.Database(db.dbName).Collection(coTransactions).Find(ctx,
+		bson.D{{Key: fiTransactionBlock, Value: bson.D{{Key: "$gte", Value: from}}}},
+		options.Find().SetProjection(bson.D{
+			{Key: fiTransactionPk, Value: true},
+			{Key: fiTransactionSender, Value: true},
+			{Key: fiTransactionRecipient, Value: true},
+		}),
+	)
+	if err != nil {
+		db.log.Errorf("can not load transactions since block #%d; %s", from, err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing transactions cursor; %s", err.Error())
+		}
+	}()
+
+	hashes := make(bson.A, 0)
+	accounts := make(map[string]bool)
+	for ld.Next(ctx) {
+		var row struct {
+			Hash string  `bson:"_id"`
+			From string  `bson:"from"`
+			To   *string `bson:"to"`
+		}
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode transaction; %s", err.Error())
+			continue
+		}
+		hashes = append(hashes, row.Hash)
+
+		accounts[row.From] = true
+		if row.To != nil {
+			accounts[*row.To] = true
+		}
+	}
+
+	for adr := range accounts {
+		rb.Accounts = append(rb.Accounts, common.HexToAddress(adr))
+	}
+	return hashes, nil
+}
+
+// removedTokenTransfers collects the tokens moved by the token transfers of the given transactions.
+func (db *MongoDbBridge) removedTokenTransfers(ctx context.Context, hashes bson.A) (*removedTokenTransfers, error) {
+	ld, err := db.client.Database(db.dbName).Collection(colErcTransactions).Find(ctx,
+		bson.D{
+			{Key: types.FiTokenTransactionCallHash, Value: bson.D{{Key: "$in", Value: hashes}}},
+			{Key: types.FiTokenTransactionType, Value: bson.D{
+				{Key: "$in", Value: bson.A{types.TokenTrxTypeTransfer, types.TokenTrxTypeMint, types.TokenTrxTypeBurn}},
+			}},
+		},
+		options.Find().SetProjection(bson.D{
+			{Key: types.FiTokenTransactionTokenType, Value: true},
+			{Key: types.FiTokenTransactionToken, Value: true},
+			{Key: types.FiTokenTransactionTokenId, Value: true},
+			{Key: types.FiTokenTransactionSender, Value: true},
+			{Key: types.FiTokenTransactionRecipient, Value: true},
+		}),
+	)
+	if err != nil {
+		db.log.Errorf("can not load removed token transfers; %s", err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := ld.Close(ctx); err != nil {
+			db.log.Errorf("error closing token transfers cursor; %s", err.Error())
+		}
+	}()
+
+	moved := removedTokenTransfers{
+		erc20Holders:  make(map[common.Address]map[common.Address]bool),
+		erc721Tokens:  make(map[[2]string]bool),
+		erc1155Tokens: make(map[[2]string]bool),
+	}
+	for ld.Next(ctx) {
+		var row struct {
+			TokenType string `bson:"tty"`
+			Token     string `bson:"tok"`
+			TokenId   string `bson:"tid"`
+			From      string `bson:"from"`
+			To        string `bson:"to"`
+		}
+		if err := ld.Decode(&row); err != nil {
+			db.log.Errorf("can not decode token transfer; %s", err.Error())
+			continue
+		}
+
+		switch row.TokenType {
+		case types.AccountTypeERC20Token:
+			token := common.HexToAddress(row.Token)
+			if moved.erc20Holders[token] == nil {
+				moved.erc20Holders[token] = make(map[common.Address]bool)
+			}
+			for _, adr := range []string{row.From, row.To} {
+				if adr != config.EmptyAddress {
+					moved.erc20Holders[token][common.HexToAddress(adr)] = true
+				}
+			}
+		case types.AccountTypeERC721Contract:
+			moved.erc721Tokens[[2]string{row.Token, row.TokenId}] = true
+		case types.AccountTypeERC1155Contract:
+			moved.erc1155Tokens[[2]string{row.Token, row.TokenId}] = true
+		}
+	}
+	return &moved, nil
+}
+
+// rebuildErc20Holders rebuilds the balances of the given ERC20 token holders from the recorded transfers.
+func (db *MongoDbBridge) rebuildErc20Holders(ctx context.Context, holders map[common.Address]map[common.Address]bool) error {
+	col := db.client.Database(db.dbName).Collection(colErc20Holders)
+	for token, list := range holders {
+		token := token
+		for adr := range list {
+			adr := adr
+			if _, err := col.DeleteOne(ctx, bson.D{{Key: fiErc20HolderPk, Value: erc20HolderPk(&token, &adr)}}); err != nil {
+				db.log.Errorf("can not remove ERC20 %s holder %s; %s", token.String(), adr.String(), err.Error())
+				return err
+			}
+
+			// only the side of the holder is replayed, the other side of the transfers is not affected
+			filter := bson.D{
+				{Key: types.FiTokenTransactionTokenType, Value: types.AccountTypeERC20Token},
+				{Key: types.FiTokenTransactionToken, Value: token.String()},
+				{Key: "$or", Value: bson.A{
+					bson.D{{Key: types.FiTokenTransactionSender, Value: adr.String()}},
+					bson.D{{Key: types.FiTokenTransactionRecipient, Value: adr.String()}},
+				}},
+			}
+			if _, err := db.replayTokenTransfers(ctx, filter, func(ctx context.Context, trx *types.TokenTransaction) error {
+				diff := new(big.Int)
+				if trx.Sender == adr {
+					diff.Sub(diff, trx.Amount.ToInt())
+				}
+				if trx.Recipient == adr {
+					diff.Add(diff, trx.Amount.ToInt())
+				}
+				return db.erc20AddHolderBalance(ctx, &token, &adr, diff, tokenTrxPosition(trx))
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// rebuildErc721Owners rebuilds the owners of the given ERC721 tokens from the recorded transfers.
+func (db *MongoDbBridge) rebuildErc721Owners(ctx context.Context, tokens map[[2]string]bool) error {
+	col := db.client.Database(db.dbName).Collection(colErc721Owners)
+	for tok := range tokens {
+		if _, err := col.DeleteOne(ctx, bson.D{{Key: fiErcOwnerPk, Value: erc721OwnerPk(tok[0], tok[1])}}); err != nil {
+			db.log.Errorf("can not remove ERC721 token %s #%s; %s", tok[0], tok[1], err.Error())
+			return err
+		}
+		if _, err := db.replayTokenTransfers(ctx, tokenTransfersFilter(types.AccountTypeERC721Contract, tok), db.Erc721UpdateOwner); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rebuildErc1155Balances rebuilds the balances of the given ERC1155 tokens from the recorded transfers.
+func (db *MongoDbBridge) rebuildErc1155Balances(ctx context.Context, tokens map[[2]string]bool) error {
+	col := db.client.Database(db.dbName).Collection(colErc1155Balances)
+	for tok := range tokens {
+		// the balances of the token share the prefix of their primary key
+		prefix := "^" + regexp.QuoteMeta(erc1155BalancePk(tok[0], tok[1], ""))
+		if _, err := col.DeleteMany(ctx, bson.D{{Key: fiErcOwnerPk, Value: bson.D{{Key: "$regex", Value: prefix}}}}); err != nil {
+			db.log.Errorf("can not remove ERC1155 token %s #%s balances; %s", tok[0], tok[1], err.Error())
+			return err
+		}
+		if _, err := db.replayTokenTransfers(ctx, tokenTransfersFilter(types.AccountTypeERC1155Contract, tok), db.Erc1155UpdateBalances); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tokenTransfersFilter builds the filter of the recorded transfers of a single token.
+func tokenTransfersFilter(tokenType string, tok [2]string) bson.D {
+	return bson.D{
+		{Key: types.FiTokenTransactionTokenType, Value: tokenType},
+		{Key: types.FiTokenTransactionToken, Value: tok[0]},
+		{Key: types.FiTokenTransactionTokenId, Value: tok[1]},
+	}
+}
@@ -36,17 +36,17 @@ func (db *MongoDbBridge) initRewardsCollection(col *mongo.Collection) {
 }
 
 // AddRewardClaim stores a reward claim in the database if it doesn't exist.
-func (db *MongoDbBridge) AddRewardClaim(rc *types.RewardClaim) error {
+func (db *MongoDbBridge) AddRewardClaim(ctx context.Context, rc *types.RewardClaim) error {
 	// get the collection for delegations
 	col := db.client.Database(db.dbName).Collection(colRewards)
 
 	// if the delegation already exists, update it with the new one
-	if db.isRewardClaimKnown(col, rc) {
+	if db.isRewardClaimKnown(ctx, col, rc) {
 		return nil
 	}
 
 	// try to do the insert
-	if _, err := col.InsertOne(context.Background(), rc); err != nil {
+	if _, err := col.InsertOne(ctx, rc); err != nil {
 		db.log.Critical(err)
 		return err
 	}
@@ -59,9 +59,9 @@ func (db *MongoDbBridge) AddRewardClaim(rc *types.RewardClaim) error {
 }
 
 // isDelegationKnown checks if the given delegation exists in the database.
-func (db *MongoDbBridge) isRewardClaimKnown(col *mongo.Collection, rc *types.RewardClaim) bool {
+func (db *MongoDbBridge) isRewardClaimKnown(ctx context.Context, col *mongo.Collection, rc *types.RewardClaim) bool {
 	// try to find the delegation in the database
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(ctx, bson.D{
 		{Key: types.FiRewardClaimPk, Value: rc.Pk()},
 	}, options.FindOne().SetProjection(bson.D{
 		{Key: types.FiRewardClaimPk, Value: true},
@@ -82,24 +82,24 @@ func (db *MongoDbBridge) isRewardClaimKnown(col *mongo.Collection, rc *types.Rew
 }
 
 // RewardsCountFiltered calculates total number of reward claims in the database for the given filter.
-func (db *MongoDbBridge) RewardsCountFiltered(filter *bson.D) (uint64, error) {
-	return db.CountFiltered(db.client.Database(db.dbName).Collection(colRewards), filter)
+func (db *MongoDbBridge) RewardsCountFiltered(ctx context.Context, filter *bson.D) (uint64, error) {
+	return db.CountFiltered(ctx, db.client.Database(db.dbName).Collection(colRewards), filter)
 }
 
 // RewardsCount calculates total number of reward claims in the database.
-func (db *MongoDbBridge) RewardsCount() (uint64, error) {
-	return db.EstimateCount(db.client.Database(db.dbName).Collection(colRewards))
+func (db *MongoDbBridge) RewardsCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(colRewards))
 }
 
 // rewListInit initializes list of delegations based on provided cursor, count, and filter.
-func (db *MongoDbBridge) rewListInit(col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.RewardClaimsList, error) {
+func (db *MongoDbBridge) rewListInit(ctx context.Context, col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.RewardClaimsList, error) {
 	// make sure some filter is used
 	if nil == filter {
 		filter = &bson.D{}
 	}
 
 	// find how many transactions do we have in the database
-	total, err := col.CountDocuments(context.Background(), *filter)
+	total, err := col.CountDocuments(ctx, *filter)
 	if err != nil {
 		db.log.Errorf("can not count reward claims")
 		return nil, err
@@ -119,7 +119,7 @@ func (db *MongoDbBridge) rewListInit(col *mongo.Collection, cursor *string, coun
 
 	// is the list non-empty? return the list with properly calculated range marks
 	if 0 < total {
-		return db.rewListCollectRangeMarks(col, &list, cursor, count)
+		return db.rewListCollectRangeMarks(ctx, col, &list, cursor, count)
 	}
 
 	// this is an empty list
@@ -128,27 +128,27 @@ func (db *MongoDbBridge) rewListInit(col *mongo.Collection, cursor *string, coun
 }
 
 // rewListCollectRangeMarks returns a list of reward claims with proper First/Last marks.
-func (db *MongoDbBridge) rewListCollectRangeMarks(col *mongo.Collection, list *types.RewardClaimsList, cursor *string, count int32) (*types.RewardClaimsList, error) {
+func (db *MongoDbBridge) rewListCollectRangeMarks(ctx context.Context, col *mongo.Collection, list *types.RewardClaimsList, cursor *string, count int32) (*types.RewardClaimsList, error) {
 	var err error
 
 	// find out the cursor ordinal index
 	if cursor == nil && count > 0 {
 		// get the highest available pk
-		list.First, err = db.rewListBorderPk(col,
+		list.First, err = db.rewListBorderPk(ctx, col,
 			list.Filter,
 			options.FindOne().SetSort(bson.D{{Key: types.FiRewardClaimOrdinal, Value: -1}}))
 		list.IsStart = true
 
 	} else if cursor == nil && count < 0 {
 		// get the lowest available pk
-		list.First, err = db.rewListBorderPk(col,
+		list.First, err = db.rewListBorderPk(ctx, col,
 			list.Filter,
 			options.FindOne().SetSort(bson.D{{Key: types.FiRewardClaimOrdinal, Value: 1}}))
 		list.IsEnd = true
 
 	} else if cursor != nil {
 		// the cursor itself is the starting point
-		list.First, err = db.rewListBorderPk(col,
+		list.First, err = db.rewListBorderPk(ctx, col,
 			bson.D{{Key: types.FiRewardClaimPk, Value: *cursor}},
 			options.FindOne())
 	}
@@ -165,7 +165,7 @@ func (db *MongoDbBridge) rewListCollectRangeMarks(col *mongo.Collection, list *t
 }
 
 // rewListBorderPk finds the top PK of the reward claims collection based on given filter and options.
-func (db *MongoDbBridge) rewListBorderPk(col *mongo.Collection, filter bson.D, opt *options.FindOneOptions) (uint64, error) {
+func (db *MongoDbBridge) rewListBorderPk(ctx context.Context, col *mongo.Collection, filter bson.D, opt *options.FindOneOptions) (uint64, error) {
 	// prep container
 	var row struct {
 		Value uint64 `bson:"orx"`
@@ -175,7 +175,7 @@ func (db *MongoDbBridge) rewListBorderPk(col *mongo.Collection, filter bson.D, o
 	opt.SetProjection(bson.D{{Key: types.FiRewardClaimOrdinal, Value: true}})
 
 	// try to decode
-	sr := col.FindOne(context.Background(), filter, opt)
+	sr := col.FindOne(ctx, filter, opt)
 	err := sr.Decode(&row)
 	if err != nil {
 		return 0, err
@@ -230,10 +230,7 @@ func (db *MongoDbBridge) rewListOptions(count int32) *options.FindOptions {
 }
 
 // rewListLoad load the initialized list of reward claims from database.
-func (db *MongoDbBridge) rewListLoad(col *mongo.Collection, cursor *string, count int32, list *types.RewardClaimsList) (err error) {
-	// get the context for loader
-	ctx := context.Background()
-
+func (db *MongoDbBridge) rewListLoad(ctx context.Context, col *mongo.Collection, cursor *string, count int32, list *types.RewardClaimsList) (err error) {
 	// load the data
 	ld, err := col.Find(ctx, db.rewListFilter(cursor, count, list), db.rewListOptions(count))
 	if err != nil {
@@ -280,17 +277,17 @@ func (db *MongoDbBridge) rewListLoad(col *mongo.Collection, cursor *string, coun
 }
 
 // RewardClaims pulls list of reward claims starting at the specified cursor.
-func (db *MongoDbBridge) RewardClaims(cursor *string, count int32, filter *bson.D) (*types.RewardClaimsList, error) {
+func (db *MongoDbBridge) RewardClaims(ctx context.Context, cursor *string, count int32, filter *types.RewardClaimFilter) (*types.RewardClaimsList, error) {
 	// nothing to load?
 	if count == 0 {
 		return nil, fmt.Errorf("nothing to do, zero reward claims requested")
 	}
 
 	// get the collection and context
-	col := db.client.Database(db.dbName).Collection(colRewards)
+	col := db.listCollection(colRewards)
 
 	// init the list
-	list, err := db.rewListInit(col, cursor, count, filter)
+	list, err := db.rewListInit(ctx, col, cursor, count, rewardClaimFilter(filter))
 	if err != nil {
 		db.log.Errorf("can not build reward claims list; %s", err.Error())
 		return nil, err
@@ -298,7 +295,7 @@ func (db *MongoDbBridge) RewardClaims(cursor *string, count int32, filter *bson.
 
 	// load data if there are any
 	if list.Total > 0 {
-		err = db.rewListLoad(col, cursor, count, list)
+		err = db.rewListLoad(ctx, col, cursor, count, list)
 		if err != nil {
 			db.log.Errorf("can not load reward claims list from database; %s", err.Error())
 			return nil, err
@@ -319,10 +316,10 @@ func (db *MongoDbBridge) RewardClaims(cursor *string, count int32, filter *bson.
 }
 
 // RewardsSumValue calculates sum of values for all the reward claims by a filter.
-func (db *MongoDbBridge) RewardsSumValue(filter *bson.D) (*big.Int, error) {
-	return db.sumFieldValue(
-		db.client.Database(db.dbName).Collection(colRewards),
+func (db *MongoDbBridge) RewardsSumValue(ctx context.Context, filter *types.RewardClaimFilter) (*big.Int, error) {
+	return db.sumFieldValue(ctx,
+		db.listCollection(colRewards),
 		types.FiRewardClaimedValue,
-		filter,
+		rewardClaimFilter(filter),
 		types.RewardDecimalsCorrection)
 }
@@ -9,6 +9,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"math/big"
 )
 
 const (
@@ -37,6 +38,13 @@ const (
 	// fiTransactionValue is the name of the field of the transaction value.
 	fiTransactionValue = "value"
 
+	// fiTransactionAmount is the name of the field of the transaction value
+	// with the precision reduced by types.TransactionDecimalsCorrection.
+	fiTransactionAmount = "amo"
+
+	// fiTransactionStatus is the name of the field of the transaction receipt status.
+	fiTransactionStatus = "stat"
+
 	// fiTransactionTimeStamp is the name of the field of the transaction time stamp.
 	fiTransactionTimeStamp = "stamp"
 )
@@ -81,6 +89,20 @@ func (db *MongoDbBridge) initTransactionsCollection(col *mongo.Collection) {
 		},
 	})
 
+	// sender + recipient + ordinal index used by counterpart filters
+	ftox := "from_to_orx"
+	ix = append(ix, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: fiTransactionSender, Value: 1},
+			{Key: fiTransactionRecipient, Value: 1},
+			{Key: fiTransactionOrdinalIndex, Value: -1},
+		},
+		Options: &options.IndexOptions{
+			Name:   &ftox,
+			Unique: &unique,
+		},
+	})
+
 	// create indexes
 	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
 		db.log.Panicf("can not create indexes for transaction collection; %s", err.Error())
@@ -91,9 +113,9 @@ func (db *MongoDbBridge) initTransactionsCollection(col *mongo.Collection) {
 }
 
 // shouldAddTransaction validates if the transaction should be added to the persistent storage.
-func (db *MongoDbBridge) shouldAddTransaction(col *mongo.Collection, trx *types.Transaction) bool {
+func (db *MongoDbBridge) shouldAddTransaction(ctx context.Context, col *mongo.Collection, trx *types.Transaction) bool {
 	// check if the transaction already exists
-	exists, err := db.IsTransactionKnown(col, &trx.Hash)
+	exists, err := db.IsTransactionKnown(ctx, col, &trx.Hash)
 	if err != nil {
 		db.log.Critical(err)
 		return false
@@ -104,26 +126,35 @@ func (db *MongoDbBridge) shouldAddTransaction(col *mongo.Collection, trx *types.
 }
 
 // AddTransaction stores a transaction reference in connected persistent storage.
-func (db *MongoDbBridge) AddTransaction(block *types.Block, trx *types.Transaction) error {
+func (db *MongoDbBridge) AddTransaction(ctx context.Context, block *types.Block, trx *types.Transaction) error {
 	// do we have all needed data?
 	if block == nil || trx == nil {
 		return fmt.Errorf("can not add empty transaction")
 	}
 
+	// batched writes are upserts, the existing transaction is replaced
+	if db.bulkTrx != nil {
+		return db.bulkTrx.add(mongo.NewReplaceOneModel().
+			SetFilter(bson.D{{Key: fiTransactionPk, Value: trx.Hash.String()}}).
+			SetReplacement(trx).
+			SetUpsert(true))
+	}
+
 	// get the collection for transactions
 	col := db.client.Database(db.dbName).Collection(coTransactions)
 
 	// if the transaction already exists, we don't need to add it
 	// just make sure the transaction accounts were processed
-	if !db.shouldAddTransaction(col, trx) {
-		return db.UpdateTransaction(col, trx)
+	if !db.shouldAddTransaction(ctx, col, trx) {
+		return db.UpdateTransaction(ctx, col, trx)
 	}
 
 	// try to do the insert
-	if _, err := col.InsertOne(context.Background(), trx); err != nil {
+	if _, err := col.InsertOne(ctx, trx); err != nil {
 		db.log.Critical(err)
 		return err
 	}
+	db.incCounter(ctx, counterTransactions, 1)
 
 	// add transaction to the db
 	db.log.Debugf("transaction %s added to database", trx.Hash.String())
@@ -137,13 +168,13 @@ func (db *MongoDbBridge) AddTransaction(block *types.Block, trx *types.Transacti
 }
 
 // UpdateTransaction updates transaction data in the database collection.
-func (db *MongoDbBridge) UpdateTransaction(col *mongo.Collection, trx *types.Transaction) error {
+func (db *MongoDbBridge) UpdateTransaction(ctx context.Context, col *mongo.Collection, trx *types.Transaction) error {
 	// notify
 	db.log.Debugf("updating transaction %s", trx.Hash.String())
 
 	// try to update a delegation by replacing it in the database
 	// we use address and validator ID to identify unique delegation
-	er, err := col.UpdateOne(context.Background(), bson.D{
+	er, err := col.UpdateOne(ctx, bson.D{
 		{Key: fiTransactionPk, Value: trx.Hash.String()},
 	}, bson.D{{Key: "$set", Value: bson.D{
 		{Key: fiTransactionOrdinalIndex, Value: trx.Uid()},
@@ -164,9 +195,9 @@ func (db *MongoDbBridge) UpdateTransaction(col *mongo.Collection, trx *types.Tra
 }
 
 // IsTransactionKnown checks if a transaction document already exists in the database.
-func (db *MongoDbBridge) IsTransactionKnown(col *mongo.Collection, hash *common.Hash) (bool, error) {
+func (db *MongoDbBridge) IsTransactionKnown(ctx context.Context, col *mongo.Collection, hash *common.Hash) (bool, error) {
 	// try to find the transaction in the database (it may already exist)
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(ctx, bson.D{
 		{Key: fiTransactionPk, Value: hash.String()},
 	}, options.FindOne().SetProjection(bson.D{
 		{Key: fiTransactionPk, Value: true},
@@ -191,14 +222,14 @@ func (db *MongoDbBridge) IsTransactionKnown(col *mongo.Collection, hash *common.
 }
 
 // initTrxList initializes list of transactions based on provided cursor and count.
-func (db *MongoDbBridge) initTrxList(col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.TransactionList, error) {
+func (db *MongoDbBridge) initTrxList(ctx context.Context, col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.TransactionList, error) {
 	// make sure some filter is used
 	if nil == filter {
 		filter = &bson.D{}
 	}
 
 	// find how many transactions do we have in the database
-	total, err := db.listDocumentsCount(col, filter)
+	total, err := db.listDocumentsCount(ctx, col, filter)
 	if err != nil {
 		db.log.Errorf("can not count transactions")
 		return nil, err
@@ -218,7 +249,7 @@ func (db *MongoDbBridge) initTrxList(col *mongo.Collection, cursor *string, coun
 
 	// is the list non-empty? return the list with properly calculated range marks
 	if 0 < total {
-		return db.trxListWithRangeMarks(col, &list, cursor, count, filter)
+		return db.trxListWithRangeMarks(ctx, col, &list, cursor, count, filter)
 	}
 
 	// this is an empty list
@@ -227,7 +258,7 @@ func (db *MongoDbBridge) initTrxList(col *mongo.Collection, cursor *string, coun
 }
 
 // trxListWithRangeMarks returns the transaction list with proper First/Last marks of the transaction range.
-func (db *MongoDbBridge) trxListWithRangeMarks(
+func (db *MongoDbBridge) trxListWithRangeMarks(ctx context.Context,
 	col *mongo.Collection,
 	list *types.TransactionList,
 	cursor *string,
@@ -239,21 +270,21 @@ func (db *MongoDbBridge) trxListWithRangeMarks(
 	// find out the cursor ordinal index
 	if cursor == nil && count > 0 {
 		// get the highest available ordinal index (top transaction)
-		list.First, err = db.findBorderOrdinalIndex(col,
+		list.First, err = db.findBorderOrdinalIndex(ctx, col,
 			*filter,
 			options.FindOne().SetSort(bson.D{{Key: fiTransactionOrdinalIndex, Value: -1}}))
 		list.IsStart = true
 
 	} else if cursor == nil && count < 0 {
 		// get the lowest available ordinal index (top transaction)
-		list.First, err = db.findBorderOrdinalIndex(col,
+		list.First, err = db.findBorderOrdinalIndex(ctx, col,
 			*filter,
 			options.FindOne().SetSort(bson.D{{Key: fiTransactionOrdinalIndex, Value: 1}}))
 		list.IsEnd = true
 
 	} else if cursor != nil {
 		// get the highest available ordinal index (top transaction)
-		list.First, err = db.findBorderOrdinalIndex(col,
+		list.First, err = db.findBorderOrdinalIndex(ctx, col,
 			bson.D{{Key: fiTransactionPk, Value: *cursor}},
 			options.FindOne())
 	}
@@ -271,7 +302,7 @@ func (db *MongoDbBridge) trxListWithRangeMarks(
 
 // findBorderOrdinalIndex finds the highest, or lowest ordinal index in the collection.
 // For negative sort it will return highest and for positive sort it will return lowest available value.
-func (db *MongoDbBridge) findBorderOrdinalIndex(col *mongo.Collection, filter bson.D, opt *options.FindOneOptions) (uint64, error) {
+func (db *MongoDbBridge) findBorderOrdinalIndex(ctx context.Context, col *mongo.Collection, filter bson.D, opt *options.FindOneOptions) (uint64, error) {
 	// prep container
 	var row struct {
 		Value uint64 `bson:"orx"`
@@ -279,7 +310,7 @@ func (db *MongoDbBridge) findBorderOrdinalIndex(col *mongo.Collection, filter bs
 
 	// make sure we pull only what we need
 	opt.SetProjection(bson.D{{Key: "orx", Value: true}})
-	sr := col.FindOne(context.Background(), filter, opt)
+	sr := col.FindOne(ctx, filter, opt)
 
 	// try to decode
 	err := sr.Decode(&row)
@@ -341,10 +372,7 @@ func (db *MongoDbBridge) txListOptions(count int32) *options.FindOptions {
 }
 
 // txListLoad load the initialized list from database
-func (db *MongoDbBridge) txListLoad(col *mongo.Collection, cursor *string, count int32, list *types.TransactionList) error {
-	// get the context for loader
-	ctx := context.Background()
-
+func (db *MongoDbBridge) txListLoad(ctx context.Context, col *mongo.Collection, cursor *string, count int32, list *types.TransactionList) error {
 	// load the data
 	ld, err := col.Find(ctx, db.txListFilter(cursor, count, list), db.txListOptions(count))
 	if err != nil {
@@ -391,22 +419,23 @@ func (db *MongoDbBridge) txListLoad(col *mongo.Collection, cursor *string, count
 }
 
 // TransactionsCount returns the number of transactions stored in the database.
-func (db *MongoDbBridge) TransactionsCount() (uint64, error) {
-	return db.EstimateCount(db.client.Database(db.dbName).Collection(coTransactions))
+func (db *MongoDbBridge) TransactionsCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(coTransactions))
 }
 
 // Transactions pulls list of transaction hashes starting on the specified cursor.
-func (db *MongoDbBridge) Transactions(cursor *string, count int32, filter *bson.D) (*types.TransactionList, error) {
+// The optional account and filter limit the list to transactions matching the conditions.
+func (db *MongoDbBridge) Transactions(ctx context.Context, cursor *string, count int32, acc *common.Address, filter *types.TransactionFilter) (*types.TransactionList, error) {
 	// nothing to load?
 	if count == 0 {
 		return nil, fmt.Errorf("nothing to do, zero transactions requested")
 	}
 
 	// get the collection and context
-	col := db.client.Database(db.dbName).Collection(coTransactions)
+	col := db.listCollection(coTransactions)
 
 	// init the list
-	list, err := db.initTrxList(col, cursor, count, filter)
+	list, err := db.initTrxList(ctx, col, cursor, count, transactionListFilter(acc, filter))
 	if err != nil {
 		db.log.Errorf("can not build transactions list; %s", err.Error())
 		return nil, err
@@ -414,7 +443,7 @@ func (db *MongoDbBridge) Transactions(cursor *string, count int32, filter *bson.
 
 	// load data if there are any
 	if list.Total > 0 {
-		err = db.txListLoad(col, cursor, count, list)
+		err = db.txListLoad(ctx, col, cursor, count, list)
 		if err != nil {
 			db.log.Errorf("can not load transactions list from database; %s", err.Error())
 			return nil, err
@@ -434,3 +463,115 @@ func (db *MongoDbBridge) Transactions(cursor *string, count int32, filter *bson.
 
 	return list, nil
 }
+
+// transactionListFilter builds the database filter of a transaction list
+// for the given account and set of conditions. The account is optional;
+// if not given, the direction is relative to the counterpart address.
+// The value range is applied with the precision of types.TransactionDecimalsCorrection.
+func transactionListFilter(acc *common.Address, f *types.TransactionFilter) *bson.D {
+	fi := bson.D{}
+	if f == nil {
+		f = &types.TransactionFilter{}
+	}
+
+	// sender and recipient conditions
+	switch {
+	case acc != nil && f.Counterpart != nil:
+		fi = append(fi, trxPartiesFilter(acc.String(), f.Counterpart.String(), f.Direction)...)
+	case acc != nil:
+		fi = append(fi, trxPartyFilter(acc.String(), f.Direction)...)
+	case f.Counterpart != nil:
+		fi = append(fi, trxPartyFilter(f.Counterpart.String(), f.Direction)...)
+	}
+
+	// value range
+	if f.MinValue != nil || f.MaxValue != nil {
+		rng := bson.D{}
+		if f.MinValue != nil {
+			rng = append(rng, bson.E{Key: "$gte", Value: new(big.Int).Div(f.MinValue, types.TransactionDecimalsCorrection).Int64()})
+		}
+		if f.MaxValue != nil {
+			rng = append(rng, bson.E{Key: "$lte", Value: new(big.Int).Div(f.MaxValue, types.TransactionDecimalsCorrection).Int64()})
+		}
+		fi = append(fi, bson.E{Key: fiTransactionAmount, Value: rng})
+	}
+
+	// receipt status
+	if f.Status != nil {
+		fi = append(fi, bson.E{Key: fiTransactionStatus, Value: *f.Status})
+	}
+	return &fi
+}
+
+// trxPartyFilter provides the filter of transactions of the given address in the given direction.
+func trxPartyFilter(adr string, dir int) bson.D {
+	switch dir {
+	case types.TransactionDirectionIn:
+		return bson.D{{Key: fiTransactionRecipient, Value: adr}}
+	case types.TransactionDirectionOut:
+		return bson.D{{Key: fiTransactionSender, Value: adr}}
+	}
+	return bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: fiTransactionSender, Value: adr}},
+		bson.D{{Key: fiTransactionRecipient, Value: adr}},
+	}}}
+}
+
+// trxPartiesFilter provides the filter of transactions between the account
+// and the counterpart address in the given direction relative to the account.
+func trxPartiesFilter(acc string, cp string, dir int) bson.D {
+	switch dir {
+	case types.TransactionDirectionIn:
+		return bson.D{{Key: fiTransactionSender, Value: cp}, {Key: fiTransactionRecipient, Value: acc}}
+	case types.TransactionDirectionOut:
+		return bson.D{{Key: fiTransactionSender, Value: acc}, {Key: fiTransactionRecipient, Value: cp}}
+	}
+	return bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: fiTransactionSender, Value: acc}, {Key: fiTransactionRecipient, Value: cp}},
+		bson.D{{Key: fiTransactionSender, Value: cp}, {Key: fiTransactionRecipient, Value: acc}},
+	}}}
+}
+
+// TransactionsCountByBlock provides the number of stored transactions of blocks in the given range.
+// Blocks without any stored transaction are not included.
+func (db *MongoDbBridge) TransactionsCountByBlock(ctx context.Context, from uint64, to uint64) (map[uint64]int, error) {
+	// get the collection
+	col := db.client.Database(db.dbName).Collection(coTransactions)
+
+	// count transactions of the blocks in range
+	cursor, err := col.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{{Key: fiTransactionBlock, Value: bson.D{
+			{Key: "$gte", Value: from},
+			{Key: "$lte", Value: to},
+		}}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$" + fiTransactionBlock},
+			{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not count transactions of blocks #%d to #%d; %s", from, to, err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	// collect the counters
+	res := make(map[uint64]int)
+	for cursor.Next(ctx) {
+		var row struct {
+			Block uint64 `bson:"_id"`
+			Count int    `bson:"count"`
+		}
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode block transactions count; %s", err.Error())
+			return nil, err
+		}
+		res[row.Block] = row.Count
+	}
+	return res, nil
+}
@@ -24,12 +24,11 @@ const (
 )
 
 // TrxDailyFlowList loads a range of daily trx volumes from the database.
-func (db *MongoDbBridge) TrxDailyFlowList(from *time.Time, to *time.Time) ([]*types.DailyTrxVolume, error) {
+func (db *MongoDbBridge) TrxDailyFlowList(ctx context.Context, from *time.Time, to *time.Time) ([]*types.DailyTrxVolume, error) {
 	// log what we do
 	db.log.Debugf("loading trx flow between %s and %s", from.String(), to.String())
 
-	// get the collection and context
-	ctx := context.Background()
+	// get the collection
 	col := db.client.Database(db.dbName).Collection(coTransactionVolume)
 
 	// pull the data; make sure there is a limit to the range
@@ -48,19 +47,18 @@ func (db *MongoDbBridge) TrxDailyFlowList(from *time.Time, to *time.Time) ([]*ty
 	}()
 
 	// load the list
-	return loadTrxDailyFlowList(ld)
+	return loadTrxDailyFlowList(ctx, ld)
 }
 
 // TrxGasSpeed provides amount of gas consumed by transaction per second
 // in the given time range.
-func (db *MongoDbBridge) TrxGasSpeed(from *time.Time, to *time.Time) (float64, error) {
+func (db *MongoDbBridge) TrxGasSpeed(ctx context.Context, from *time.Time, to *time.Time) (float64, error) {
 	// check the time range
 	if !from.Before(*to) {
 		return 0.0, fmt.Errorf("invalid time range requested")
 	}
 
-	// get the collection and context
-	ctx := context.Background()
+	// get the collection
 	col := db.client.Database(db.dbName).Collection(coTransactions)
 
 	// aggregate the gas used from the given time range
@@ -82,13 +80,13 @@ func (db *MongoDbBridge) TrxGasSpeed(from *time.Time, to *time.Time) (float64, e
 			db.log.Errorf("error closing gas speed cursor; %s", err.Error())
 		}
 	}()
-	return db.trxGasSpeed(cr, from, to)
+	return db.trxGasSpeed(ctx, cr, from, to)
 }
 
 // trxGasSpeed makes the gas speed calculation from the given aggregation cursor.
-func (db *MongoDbBridge) trxGasSpeed(cr *mongo.Cursor, from *time.Time, to *time.Time) (float64, error) {
+func (db *MongoDbBridge) trxGasSpeed(ctx context.Context, cr *mongo.Cursor, from *time.Time, to *time.Time) (float64, error) {
 	// get the row
-	if !cr.Next(context.Background()) {
+	if !cr.Next(ctx) {
 		db.log.Errorf("can not navigate gas speed results")
 		return 0.0, fmt.Errorf("gas speed aggregation failure")
 	}
@@ -107,7 +105,7 @@ func (db *MongoDbBridge) trxGasSpeed(cr *mongo.Cursor, from *time.Time, to *time
 }
 
 // TrxRecentTrxSpeed provides the number of transaction per second on the defined range in seconds.
-func (db *MongoDbBridge) TrxRecentTrxSpeed(sec int32) (float64, error) {
+func (db *MongoDbBridge) TrxRecentTrxSpeed(ctx context.Context, sec int32) (float64, error) {
 	// make sure the request makes sense and calculate the left boundary
 	if sec < 60 {
 		sec = 60
@@ -116,7 +114,7 @@ func (db *MongoDbBridge) TrxRecentTrxSpeed(sec int32) (float64, error) {
 	col := db.client.Database(db.dbName).Collection(coTransactions)
 
 	// find how many transactions do we have in the database
-	total, err := col.CountDocuments(context.Background(), bson.D{
+	total, err := col.CountDocuments(ctx, bson.D{
 		{Key: fiTransactionTimeStamp, Value: bson.D{
 			{Key: "$gte", Value: from},
 		}},
@@ -153,9 +151,8 @@ func trxDailyFlowListFilter(from *time.Time, to *time.Time) *bson.D {
 }
 
 // loadTrxDailyFlowList load the trx flow list from provided DB cursor.
-func loadTrxDailyFlowList(ld *mongo.Cursor) ([]*types.DailyTrxVolume, error) {
+func loadTrxDailyFlowList(ctx context.Context, ld *mongo.Cursor) ([]*types.DailyTrxVolume, error) {
 	// prep the result list
-	ctx := context.Background()
 	list := make([]*types.DailyTrxVolume, 0)
 
 	// loop and load
@@ -174,34 +171,64 @@ func loadTrxDailyFlowList(ld *mongo.Cursor) ([]*types.DailyTrxVolume, error) {
 
 // TrxDailyFlowUpdate performs an update on the daily trx flow data
 // for the given date range directly.
-func (db *MongoDbBridge) TrxDailyFlowUpdate(from time.Time) error {
+func (db *MongoDbBridge) TrxDailyFlowUpdate(ctx context.Context, from time.Time) error {
 	// log what we do
 	db.log.Noticef("updating trx flow after %s", from)
 
 	// we aggregate transactions
 	col := db.client.Database(db.dbName).Collection(coTransactions)
 
-	// get the collection
-	cr, err := col.Aggregate(context.Background(), mongo.Pipeline{
+	// get the collection; each transaction is unwound into its sender and recipient
+	// so the active accounts can be counted by grouping on the day and the address,
+	// only the sender row carries the transaction values so they are not counted twice
+	cr, err := col.Aggregate(ctx, mongo.Pipeline{
 		{{Key: "$match", Value: bson.D{
 			{Key: "stamp", Value: bson.D{{Key: "$gte", Value: from}}},
 		}}},
-		{{Key: "$group", Value: bson.D{
-			{Key: "_id", Value: bson.D{
+		{{Key: "$project", Value: bson.D{
+			{Key: "day", Value: bson.D{
 				{Key: "$dateToString", Value: bson.D{
 					{Key: "format", Value: "%Y-%m-%d"},
 					{Key: "date", Value: "$stamp"},
 				}},
 			}},
-			{Key: "volume", Value: bson.D{{Key: "$sum", Value: "$amo"}}},
-			{Key: "gas", Value: bson.D{{Key: "$sum", Value: "$gas_use"}}},
-			{Key: "value", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "amo", Value: 1},
+			{Key: "gas_use", Value: 1},
+			{Key: "fee", Value: bson.D{{Key: "$multiply", Value: bson.A{"$gas_use", "$gwx100"}}}},
+			{Key: "acc", Value: bson.D{{Key: "$filter", Value: bson.D{
+				{Key: "input", Value: bson.A{
+					bson.D{{Key: "adr", Value: "$from"}, {Key: "trx", Value: 1}},
+					bson.D{{Key: "adr", Value: "$to"}, {Key: "trx", Value: 0}},
+				}},
+				{Key: "cond", Value: bson.D{{Key: "$ne", Value: bson.A{"$$this.adr", nil}}}},
+			}}}},
+		}}},
+		{{Key: "$unwind", Value: "$acc"}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: bson.D{
+				{Key: "day", Value: "$day"},
+				{Key: "adr", Value: "$acc.adr"},
+			}},
+			{Key: "volume", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$multiply", Value: bson.A{"$amo", "$acc.trx"}}}}}},
+			{Key: "gas", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$multiply", Value: bson.A{"$gas_use", "$acc.trx"}}}}}},
+			{Key: "fee", Value: bson.D{{Key: "$sum", Value: bson.D{{Key: "$multiply", Value: bson.A{"$fee", "$acc.trx"}}}}}},
+			{Key: "value", Value: bson.D{{Key: "$sum", Value: "$acc.trx"}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$_id.day"},
+			{Key: "volume", Value: bson.D{{Key: "$sum", Value: "$volume"}}},
+			{Key: "gas", Value: bson.D{{Key: "$sum", Value: "$gas"}}},
+			{Key: "fee", Value: bson.D{{Key: "$sum", Value: "$fee"}}},
+			{Key: "value", Value: bson.D{{Key: "$sum", Value: "$value"}}},
+			{Key: "active", Value: bson.D{{Key: "$sum", Value: 1}}},
 		}}},
 		{{Key: "$project", Value: bson.D{
 			{Key: "stamp", Value: bson.D{{Key: "$toDate", Value: "$_id"}}},
 			{Key: "volume", Value: 1},
 			{Key: "value", Value: 1},
 			{Key: "gas", Value: 1},
+			{Key: "fee", Value: 1},
+			{Key: "active", Value: 1},
 		}}},
 		{{Key: "$merge", Value: bson.D{
 			{Key: "into", Value: "trx_volume"},
@@ -209,14 +236,14 @@ func (db *MongoDbBridge) TrxDailyFlowUpdate(from time.Time) error {
 			{Key: "whenMatched", Value: "replace"},
 			{Key: "whenNotMatched", Value: "insert"},
 		}}},
-	})
+	}, options.Aggregate().SetAllowDiskUse(true))
 	if err != nil {
 		db.log.Errorf("can not update trx flow; %s", err.Error())
 		return err
 	}
 
 	// close the cursor, we don't really need the data
-	if err := cr.Close(context.Background()); err != nil {
+	if err := cr.Close(ctx); err != nil {
 		db.log.Errorf("can not close aggregate cursor; %s", err.Error())
 	}
 	return nil
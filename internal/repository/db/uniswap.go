@@ -91,10 +91,10 @@ func (db *MongoDbBridge) initUniswapCollection(col *mongo.Collection) {
 }
 
 // shouldAddSwap validates if the swap should be added to the persistent storage.
-func (db *MongoDbBridge) shouldAddSwap(col *mongo.Collection, swap *types.Swap) bool {
+func (db *MongoDbBridge) shouldAddSwap(ctx context.Context, col *mongo.Collection, swap *types.Swap) bool {
 	// check if swap already exists
 	swapHash := getHash(swap)
-	exists, err := db.IsSwapKnown(col, swapHash, swap)
+	exists, err := db.IsSwapKnown(ctx, col, swapHash, swap)
 	if err != nil {
 		db.log.Critical(err)
 		return false
@@ -115,7 +115,7 @@ func isZeroSwap(swap *types.Swap) bool {
 }
 
 // UniswapAdd stores a swap reference in connected persistent storage.
-func (db *MongoDbBridge) UniswapAdd(swap *types.Swap) error {
+func (db *MongoDbBridge) UniswapAdd(ctx context.Context, swap *types.Swap) error {
 	// do we have all needed data?
 	if swap == nil {
 		return fmt.Errorf("can not add empty swap")
@@ -132,7 +132,7 @@ func (db *MongoDbBridge) UniswapAdd(swap *types.Swap) error {
 
 	// if the swap already exists, we don't need to add it
 	// just make sure the transaction accounts were processed
-	if !db.shouldAddSwap(col, swap) {
+	if !db.shouldAddSwap(ctx, col, swap) {
 		return nil
 	}
 
@@ -140,7 +140,7 @@ func (db *MongoDbBridge) UniswapAdd(swap *types.Swap) error {
 	swapHash := getHash(swap)
 
 	// try to do the insert
-	if _, err := col.InsertOne(context.Background(),
+	if _, err := col.InsertOne(ctx,
 		swapData(&bson.D{
 			{Key: fiSwapPk, Value: swapHash.String()},
 			{Key: fiSwapBlock, Value: uint64(*swap.BlockNumber)},
@@ -186,9 +186,9 @@ func swapData(base *bson.D, swap *types.Swap) bson.D {
 }
 
 // IsSwapKnown checks if swap document already exists in the database.
-func (db *MongoDbBridge) IsSwapKnown(col *mongo.Collection, hash *common.Hash, swap *types.Swap) (bool, error) {
+func (db *MongoDbBridge) IsSwapKnown(ctx context.Context, col *mongo.Collection, hash *common.Hash, swap *types.Swap) (bool, error) {
 	// try to find swap in the database (it may already exist)
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(ctx, bson.D{
 		{Key: fiSwapPk, Value: hash.String()}})
 
 	// error on lookup?
@@ -211,7 +211,7 @@ func (db *MongoDbBridge) IsSwapKnown(col *mongo.Collection, hash *common.Hash, s
 	// if swap is sync type, then update reserves
 	if swap.Type == types.SwapSync {
 		db.log.Debugf("Updating reserves for Swap %s", hash.String())
-		_, err := col.UpdateOne(context.Background(),
+		_, err := col.UpdateOne(ctx,
 			bson.M{fiSwapPk: hash.String()},
 			bson.D{
 				{Key: "$set", Value: bson.M{fiSwapReserve0: removeDecimals(swap.Reserve0, swapReserveDecimalsCorrection)}},
@@ -236,7 +236,7 @@ func (db *MongoDbBridge) IsSwapKnown(col *mongo.Collection, hash *common.Hash, s
 		if types.SwapSync == values.Type {
 			// log issue
 			db.log.Debugf("updating reserve for swap: %s, reserve0: %v, reserve1: %v", hash.String(), values.Reserve0, values.Reserve1)
-			if _, err := col.DeleteOne(context.Background(), bson.D{{Key: fiSwapPk, Value: hash.String()}}); err != nil {
+			if _, err := col.DeleteOne(ctx, bson.D{{Key: fiSwapPk, Value: hash.String()}}); err != nil {
 				db.log.Errorf("can not delete swap data; %s", err.Error())
 			}
 
@@ -249,12 +249,12 @@ func (db *MongoDbBridge) IsSwapKnown(col *mongo.Collection, hash *common.Hash, s
 }
 
 // SwapCount returns the number of swaps stored in the database.
-func (db *MongoDbBridge) SwapCount() (uint64, error) {
-	return db.EstimateCount(db.client.Database(db.dbName).Collection(coUniswap))
+func (db *MongoDbBridge) SwapCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(coUniswap))
 }
 
 // LastKnownSwapBlock returns number of the last known block stored in the database.
-func (db *MongoDbBridge) LastKnownSwapBlock() (uint64, error) {
+func (db *MongoDbBridge) LastKnownSwapBlock(ctx context.Context) (uint64, error) {
 	// search for document with last swap block number
 	query := bson.D{
 		{Key: "lastSwapSyncBlk", Value: bson.D{
@@ -263,7 +263,7 @@ func (db *MongoDbBridge) LastKnownSwapBlock() (uint64, error) {
 
 	// get the swaps collection
 	col := db.client.Database(db.dbName).Collection(coUniswap)
-	res := col.FindOne(context.Background(), query)
+	res := col.FindOne(ctx, query)
 	if res.Err() != nil {
 		// may be no block at all
 		if res.Err() == mongo.ErrNoDocuments {
@@ -292,7 +292,7 @@ func (db *MongoDbBridge) LastKnownSwapBlock() (uint64, error) {
 }
 
 // UniswapUpdateLastKnownSwapBlock stores a last correctly saved swap block number into persistent storage.
-func (db *MongoDbBridge) UniswapUpdateLastKnownSwapBlock(blkNumber uint64) error {
+func (db *MongoDbBridge) UniswapUpdateLastKnownSwapBlock(ctx context.Context, blkNumber uint64) error {
 	// is valid block number
 	if blkNumber == 0 {
 		return fmt.Errorf("no need to store zero value, will start from 0 next time")
@@ -311,7 +311,7 @@ func (db *MongoDbBridge) UniswapUpdateLastKnownSwapBlock(blkNumber uint64) error
 
 	// get the collection for transactions and insert data
 	col := db.client.Database(db.dbName).Collection(coUniswap)
-	if _, err := col.UpdateOne(context.Background(),
+	if _, err := col.UpdateOne(ctx,
 		query, data, options.Update().SetUpsert(true)); err != nil {
 
 		db.log.Critical(err)
@@ -331,7 +331,7 @@ type Volume struct {
 
 // UniswapVolume resolves volume of swap trades for specified pair and date interval.
 // If toTime is 0, then it calculates volumes till now
-func (db *MongoDbBridge) UniswapVolume(pairAddress *common.Address, fromTime int64, toTime int64) (types.DefiSwapVolume, error) {
+func (db *MongoDbBridge) UniswapVolume(ctx context.Context, pairAddress *common.Address, fromTime int64, toTime int64) (types.DefiSwapVolume, error) {
 
 	// translate unix time into mongo primitive date
 	fTime := primitive.NewDateTimeFromTime(time.Unix(fromTime, 0))
@@ -360,7 +360,7 @@ func (db *MongoDbBridge) UniswapVolume(pairAddress *common.Address, fromTime int
 
 	// query collection
 	col := db.client.Database(db.dbName).Collection(coUniswap)
-	cursor, err := col.Aggregate(context.Background(), pipe)
+	cursor, err := col.Aggregate(ctx, pipe)
 	def := types.DefiSwapVolume{
 		PairAddress: pairAddress,
 		Volume:      big.NewInt(0)}
@@ -372,13 +372,13 @@ func (db *MongoDbBridge) UniswapVolume(pairAddress *common.Address, fromTime int
 
 	// make sure to close the cursor
 	defer func() {
-		if err := cursor.Close(context.Background()); err != nil {
+		if err := cursor.Close(ctx); err != nil {
 			db.log.Errorf("can not close cursor; %s", err.Error())
 		}
 	}()
 
 	// get result and fill return data
-	for cursor.Next(context.Background()) {
+	for cursor.Next(ctx) {
 		var val Volume
 		err := cursor.Decode(&val)
 		if err != nil {
@@ -394,7 +394,7 @@ func (db *MongoDbBridge) UniswapVolume(pairAddress *common.Address, fromTime int
 
 // UniswapTimeVolumes resolves volumes of swap trades for specified pair grouped by date interval.
 // If toTime is 0, then it calculates volumes till now
-func (db *MongoDbBridge) UniswapTimeVolumes(pairAddress *common.Address, resolution string, fromTime int64, toTime int64) ([]types.DefiSwapVolume, error) {
+func (db *MongoDbBridge) UniswapTimeVolumes(ctx context.Context, pairAddress *common.Address, resolution string, fromTime int64, toTime int64) ([]types.DefiSwapVolume, error) {
 
 	fTime := primitive.NewDateTimeFromTime(time.Unix(fromTime, 0))
 
@@ -426,7 +426,7 @@ func (db *MongoDbBridge) UniswapTimeVolumes(pairAddress *common.Address, resolut
 
 	// execute query
 	col := db.client.Database(db.dbName).Collection(coUniswap)
-	cursor, err := col.Aggregate(context.Background(), pipe)
+	cursor, err := col.Aggregate(ctx, pipe)
 
 	if err != nil {
 		db.log.Errorf(err.Error())
@@ -434,13 +434,13 @@ func (db *MongoDbBridge) UniswapTimeVolumes(pairAddress *common.Address, resolut
 	}
 
 	defer func() {
-		if err := cursor.Close(context.Background()); err != nil {
+		if err := cursor.Close(ctx); err != nil {
 			db.log.Errorf("can not close cursor; %s", err.Error())
 		}
 	}()
 
 	// iterate thru results and construct data
-	for cursor.Next(context.Background()) {
+	for cursor.Next(ctx) {
 		var val Volume
 		err := cursor.Decode(&val)
 		if err != nil {
@@ -523,7 +523,7 @@ func getDateBsonD(fromTime int64, toTime int64) bson.D {
 
 // UniswapTimePrices resolves price of swap trades for specified pair grouped by date interval.
 // If toTime is 0, then it calculates prices till now
-func (db *MongoDbBridge) UniswapTimePrices(pairAddress *common.Address, resolution string, fromTime int64, toTime int64, direction int32) ([]types.DefiTimePrice, error) {
+func (db *MongoDbBridge) UniswapTimePrices(ctx context.Context, pairAddress *common.Address, resolution string, fromTime int64, toTime int64, direction int32) ([]types.DefiTimePrice, error) {
 	tokenASum := bson.D{{Key: "$add", Value: bson.A{"$am0in", "$am0out"}}}
 	tokenBSum := bson.D{{Key: "$add", Value: bson.A{"$am1in", "$am1out"}}}
 
@@ -568,20 +568,20 @@ func (db *MongoDbBridge) UniswapTimePrices(pairAddress *common.Address, resoluti
 
 	// execute query
 	col := db.client.Database(db.dbName).Collection(coUniswap)
-	cursor, err := col.Aggregate(context.Background(), pipe)
+	cursor, err := col.Aggregate(ctx, pipe)
 	if err != nil {
 		db.log.Errorf(err.Error())
 		return list, nil
 	}
 
 	defer func() {
-		if err := cursor.Close(context.Background()); err != nil {
+		if err := cursor.Close(ctx); err != nil {
 			db.log.Errorf("can not close cursor; %s", err.Error())
 		}
 	}()
 
 	// iterate thru results and construct data
-	for cursor.Next(context.Background()) {
+	for cursor.Next(ctx) {
 		var priceVal types.DefiTimePrice
 		err := cursor.Decode(&priceVal)
 		if err != nil {
@@ -596,7 +596,7 @@ func (db *MongoDbBridge) UniswapTimePrices(pairAddress *common.Address, resoluti
 
 // UniswapTimeReserves resolves reserves of uniswap trades for specified pair grouped by date interval.
 // If toTime is 0, then it calculates prices till now
-func (db *MongoDbBridge) UniswapTimeReserves(pairAddress *common.Address, resolution string, fromTime int64, toTime int64) ([]types.DefiTimeReserve, error) {
+func (db *MongoDbBridge) UniswapTimeReserves(ctx context.Context, pairAddress *common.Address, resolution string, fromTime int64, toTime int64) ([]types.DefiTimeReserve, error) {
 
 	// create query pipeline
 	pipe := mongo.Pipeline{
@@ -633,20 +633,20 @@ func (db *MongoDbBridge) UniswapTimeReserves(pairAddress *common.Address, resolu
 
 	// execute query
 	col := db.client.Database(db.dbName).Collection(coUniswap)
-	cursor, err := col.Aggregate(context.Background(), pipe)
+	cursor, err := col.Aggregate(ctx, pipe)
 	if err != nil {
 		db.log.Errorf(err.Error())
 		return list, nil
 	}
 
 	defer func() {
-		if err := cursor.Close(context.Background()); err != nil {
+		if err := cursor.Close(ctx); err != nil {
 			db.log.Errorf("can not close cursor; %s", err.Error())
 		}
 	}()
 
 	// iterate thru results and construct data
-	for cursor.Next(context.Background()) {
+	for cursor.Next(ctx) {
 		var reserveVal TimeReserve
 		err := cursor.Decode(&reserveVal)
 		if err != nil {
@@ -667,7 +667,7 @@ func (db *MongoDbBridge) UniswapTimeReserves(pairAddress *common.Address, resolu
 }
 
 // UniswapActions provides list of uniswap actions stored in the persistent storage.
-func (db *MongoDbBridge) UniswapActions(pairAddress *common.Address, cursor *string, count int32, actionType int32) (*types.UniswapActionList, error) {
+func (db *MongoDbBridge) UniswapActions(ctx context.Context, pairAddress *common.Address, cursor *string, count int32, actionType int32) (*types.UniswapActionList, error) {
 	// nothing to load?
 	if count == 0 {
 		return nil, fmt.Errorf("nothing to do, zero uniswap actions requested")
@@ -677,14 +677,14 @@ func (db *MongoDbBridge) UniswapActions(pairAddress *common.Address, cursor *str
 	col := db.client.Database(db.dbName).Collection(coUniswap)
 
 	// init the list
-	list, err := db.uniswapActionListInit(col, pairAddress, cursor, count, actionType)
+	list, err := db.uniswapActionListInit(ctx, col, pairAddress, cursor, count, actionType)
 	if err != nil {
 		db.log.Errorf("can not build uniswap action list; %s", err.Error())
 		return nil, err
 	}
 
 	// load data
-	err = db.uniswapActionListLoad(col, pairAddress, actionType, cursor, count, list)
+	err = db.uniswapActionListLoad(ctx, col, pairAddress, actionType, cursor, count, list)
 	if err != nil {
 		db.log.Errorf("can not load uniswap action list from database; %s", err.Error())
 		return nil, err
@@ -699,7 +699,7 @@ func (db *MongoDbBridge) UniswapActions(pairAddress *common.Address, cursor *str
 }
 
 // contractListInit initializes list of contracts based on provided cursor and count.
-func (db *MongoDbBridge) uniswapActionListInit(col *mongo.Collection, pairAddress *common.Address, cursor *string, count int32, actionType int32) (*types.UniswapActionList, error) {
+func (db *MongoDbBridge) uniswapActionListInit(ctx context.Context, col *mongo.Collection, pairAddress *common.Address, cursor *string, count int32, actionType int32) (*types.UniswapActionList, error) {
 	// make the list
 	list := types.UniswapActionList{
 		Collection: make([]*types.UniswapAction, 0),
@@ -711,7 +711,7 @@ func (db *MongoDbBridge) uniswapActionListInit(col *mongo.Collection, pairAddres
 	}
 
 	// calculate the total number of contracts in the list
-	if err := db.uniswapActionListTotal(col, pairAddress, &list, actionType); err != nil {
+	if err := db.uniswapActionListTotal(ctx, col, pairAddress, &list, actionType); err != nil {
 		return nil, err
 	}
 
@@ -719,7 +719,7 @@ func (db *MongoDbBridge) uniswapActionListInit(col *mongo.Collection, pairAddres
 	db.log.Debugf("Found %d uniswap actions in off-chain database for specified criteria", list.Total)
 
 	// find the top uniswap action of the list
-	if err := db.uniswapActionListTop(col, pairAddress, actionType, cursor, count, &list); err != nil {
+	if err := db.uniswapActionListTop(ctx, col, pairAddress, actionType, cursor, count, &list); err != nil {
 		return nil, err
 	}
 
@@ -729,7 +729,7 @@ func (db *MongoDbBridge) uniswapActionListInit(col *mongo.Collection, pairAddres
 }
 
 // uniswapActionListTotal find the total amount of uniswap events for the criteria and populates the list
-func (db *MongoDbBridge) uniswapActionListTotal(col *mongo.Collection, pairAddress *common.Address, list *types.UniswapActionList, actionType int32) error {
+func (db *MongoDbBridge) uniswapActionListTotal(ctx context.Context, col *mongo.Collection, pairAddress *common.Address, list *types.UniswapActionList, actionType int32) error {
 	// prep the empty filter
 	filter := bson.D{}
 	filterPair := bson.D{}
@@ -750,7 +750,7 @@ func (db *MongoDbBridge) uniswapActionListTotal(col *mongo.Collection, pairAddre
 	filter = bson.D{{Key: "$and", Value: bson.A{filterPair, filterType, filterBlk}}}
 
 	// find how many uniswap events do we have in the database
-	total, err := col.CountDocuments(context.Background(), filter)
+	total, err := col.CountDocuments(ctx, filter)
 	if err != nil {
 		db.log.Errorf("Can not count uniswap actions: %v", err.Error())
 		return err
@@ -762,7 +762,7 @@ func (db *MongoDbBridge) uniswapActionListTotal(col *mongo.Collection, pairAddre
 }
 
 // uniswapActionListTop find the first uniswap action of the list based on provided criteria and populates the list.
-func (db *MongoDbBridge) uniswapActionListTop(col *mongo.Collection, pairAddress *common.Address, actionType int32, cursor *string, count int32, list *types.UniswapActionList) error {
+func (db *MongoDbBridge) uniswapActionListTop(ctx context.Context, col *mongo.Collection, pairAddress *common.Address, actionType int32, cursor *string, count int32, list *types.UniswapActionList) error {
 	// get the filter
 	filter, err := uniswapActionListTopFilter(pairAddress, cursor, actionType)
 	if err != nil {
@@ -773,21 +773,21 @@ func (db *MongoDbBridge) uniswapActionListTop(col *mongo.Collection, pairAddress
 	// find out the cursor ordinal index
 	if cursor == nil && count > 0 {
 		// get the highest available ordinal index (top uniswap action)
-		list.First, err = db.findUniswapActionBorderOrdinalIndex(col,
+		list.First, err = db.findUniswapActionBorderOrdinalIndex(ctx, col,
 			*filter,
 			options.FindOne().SetSort(bson.D{{Key: fiSwapOrdIndex, Value: -1}}))
 		list.IsStart = true
 
 	} else if cursor == nil && count < 0 {
 		// get the lowest available ordinal index (bottom uniswap action)
-		list.First, err = db.findUniswapActionBorderOrdinalIndex(col,
+		list.First, err = db.findUniswapActionBorderOrdinalIndex(ctx, col,
 			*filter,
 			options.FindOne().SetSort(bson.D{{Key: fiSwapOrdIndex, Value: 1}}))
 		list.IsEnd = true
 
 	} else if cursor != nil {
 		// get the highest available ordinal index (top uniswap action)
-		list.First, err = db.findUniswapActionBorderOrdinalIndex(col,
+		list.First, err = db.findUniswapActionBorderOrdinalIndex(ctx, col,
 			*filter,
 			options.FindOne())
 	}
@@ -811,7 +811,7 @@ func uniswapActionListTopFilter(pairAddress *common.Address, cursor *string, act
 		// get the ordinal index based on cursor
 		ix, err = strconv.ParseUint(*cursor, 10, 64)
 		if err != nil {
-			return nil, fmt.Errorf("invalid cursor value; %s", err.Error())
+			return nil, types.Errorf(types.ErrCodeInvalidCursor, "invalid cursor value; %s", err.Error())
 		}
 	}
 
@@ -842,10 +842,7 @@ func uniswapActionListTopFilter(pairAddress *common.Address, cursor *string, act
 }
 
 // uniswapActionListLoad loads the initialized uniswap action list from persistent database.
-func (db *MongoDbBridge) uniswapActionListLoad(col *mongo.Collection, pairAddress *common.Address, actionType int32, cursor *string, count int32, list *types.UniswapActionList) error {
-	// get the context for loader
-	ctx := context.Background()
-
+func (db *MongoDbBridge) uniswapActionListLoad(ctx context.Context, col *mongo.Collection, pairAddress *common.Address, actionType int32, cursor *string, count int32, list *types.UniswapActionList) error {
 	// load the data
 	ld, err := col.Find(ctx, db.uniswapActionListFilter(pairAddress, actionType, cursor, count, list), db.uniswapActionListOptions(count))
 	if err != nil {
@@ -1001,7 +998,7 @@ func (db *MongoDbBridge) uniswapActionListOptions(count int32) *options.FindOpti
 
 // findUniswapActionBorderOrdinalIndex finds the highest, or lowest ordinal index in the collection.
 // For negative sort it will return highest and for positive sort it will return lowest available value.
-func (db *MongoDbBridge) findUniswapActionBorderOrdinalIndex(col *mongo.Collection, filter bson.D, opt *options.FindOneOptions) (uint64, error) {
+func (db *MongoDbBridge) findUniswapActionBorderOrdinalIndex(ctx context.Context, col *mongo.Collection, filter bson.D, opt *options.FindOneOptions) (uint64, error) {
 	// prep container
 	var row struct {
 		Value uint64 `bson:"orx"`
@@ -1009,7 +1006,7 @@ func (db *MongoDbBridge) findUniswapActionBorderOrdinalIndex(col *mongo.Collecti
 
 	// make sure we pull only what we need
 	opt.SetProjection(bson.D{{Key: fiSwapOrdIndex, Value: true}})
-	sr := col.FindOne(context.Background(), filter, opt)
+	sr := col.FindOne(ctx, filter, opt)
 
 	// try to decode
 	err := sr.Decode(&row)
@@ -0,0 +1,93 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UniswapReservesAt loads the reserves of the given pair recorded by the most recent
+// sync event before the given time. Nil reserves are returned if no event is known.
+// Only the sync events carry the reserves, the other events have them zeroed.
+func (db *MongoDbBridge) UniswapReservesAt(ctx context.Context, pair *common.Address, at time.Time) ([]*big.Int, error) {
+	col := db.client.Database(db.dbName).Collection(coUniswap)
+
+	var row struct {
+		Reserve0 uint64 `bson:"reserve0"`
+		Reserve1 uint64 `bson:"reserve1"`
+	}
+
+	err := col.FindOne(ctx, bson.D{
+		{Key: fiSwapPair, Value: pair.String()},
+		{Key: fiSwapType, Value: types.SwapSync},
+		{Key: fiSwapDate, Value: bson.D{{Key: "$lte", Value: primitive.NewDateTimeFromTime(at)}}},
+	}, options.FindOne().
+		SetSort(bson.D{{Key: fiSwapDate, Value: -1}}).
+		SetProjection(bson.D{{Key: fiSwapReserve0, Value: 1}, {Key: fiSwapReserve1, Value: 1}})).
+		Decode(&row)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		db.log.Errorf("can not load reserves of pair %s; %s", pair.String(), err.Error())
+		return nil, err
+	}
+
+	return []*big.Int{
+		returnDecimals(new(big.Int).SetUint64(row.Reserve0), swapReserveDecimalsCorrection),
+		returnDecimals(new(big.Int).SetUint64(row.Reserve1), swapReserveDecimalsCorrection),
+	}, nil
+}
+
+// UniswapTokenVolume resolves the amount of the token of the given side of the pair
+// traded by swaps since the given time.
+func (db *MongoDbBridge) UniswapTokenVolume(ctx context.Context, pair *common.Address, side int, from time.Time) (*big.Int, error) {
+	if side < 0 || side > 1 {
+		return nil, fmt.Errorf("invalid pair side %d", side)
+	}
+
+	col := db.client.Database(db.dbName).Collection(coUniswap)
+	cursor, err := col.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: fiSwapPair, Value: pair.String()},
+			{Key: fiSwapDate, Value: bson.D{{Key: "$gte", Value: primitive.NewDateTimeFromTime(from)}}},
+			{Key: fiSwapType, Value: types.SwapNormal},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$pair"},
+			{Key: "total", Value: bson.D{{Key: "$sum", Value: bson.D{
+				{Key: "$add", Value: bson.A{fmt.Sprintf("$am%din", side), fmt.Sprintf("$am%dout", side)}},
+			}}}},
+		}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not aggregate volume of pair %s; %s", pair.String(), err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	if !cursor.Next(ctx) {
+		return new(big.Int), cursor.Err()
+	}
+
+	var val Volume
+	if err := cursor.Decode(&val); err != nil {
+		db.log.Errorf("can not decode volume of pair %s; %s", pair.String(), err.Error())
+		return nil, err
+	}
+	return returnDecimals(big.NewInt(val.Total), swapAmountDecimalsCorrection), nil
+}
@@ -0,0 +1,127 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"math/big"
+)
+
+// colValidatorPerformance represents the name of the validator epoch performance collection in database.
+const colValidatorPerformance = "val_perf"
+
+// initValidatorPerformanceCollection initializes the validator performance collection with
+// indexes and additional parameters needed by the app.
+func (db *MongoDbBridge) initValidatorPerformanceCollection(col *mongo.Collection) {
+	// prepare index models
+	ix := make([]mongo.IndexModel, 0)
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{{Key: types.FiValidatorPerfEpoch, Value: 1}}})
+	ix = append(ix, mongo.IndexModel{Keys: bson.D{
+		{Key: types.FiValidatorPerfValidator, Value: 1},
+		{Key: types.FiValidatorPerfEpoch, Value: 1},
+	}})
+
+	// create indexes
+	if _, err := col.Indexes().CreateMany(context.Background(), ix); err != nil {
+		db.log.Panicf("can not create indexes for validator performance collection; %s", err.Error())
+	}
+	db.log.Debugf("validator performance collection initialized")
+}
+
+// AddValidatorEpochPerformance stores the performance of a validator within an epoch;
+// the previous record of the validator and epoch is replaced.
+func (db *MongoDbBridge) AddValidatorEpochPerformance(ctx context.Context, vep *types.ValidatorEpochPerformance) error {
+	// get the collection for validator performance
+	col := db.client.Database(db.dbName).Collection(colValidatorPerformance)
+
+	if _, err := col.ReplaceOne(ctx,
+		bson.D{{Key: types.FiValidatorPerfPk, Value: vep.Pk()}},
+		vep,
+		options.Replace().SetUpsert(true),
+	); err != nil {
+		db.log.Errorf("can not store performance of validator #%d at epoch #%d; %s",
+			vep.ValidatorId.ToInt().Uint64(), uint64(vep.Epoch), err.Error())
+		return err
+	}
+
+	// make sure validator performance collection is initialized
+	if db.initValPerf != nil {
+		db.initValPerf.Do(func() { db.initValidatorPerformanceCollection(col); db.initValPerf = nil })
+	}
+	return nil
+}
+
+// ValidatorPerformanceCount calculates total number of validator epoch performance records in the database.
+func (db *MongoDbBridge) ValidatorPerformanceCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(colValidatorPerformance))
+}
+
+// ValidatorPerformance aggregates the performance of validators over the epochs
+// starting with the given epoch; all the validators are included if the validator id is not given.
+func (db *MongoDbBridge) ValidatorPerformance(ctx context.Context, valID *hexutil.Big, fromEpoch uint64) ([]*types.ValidatorPerformance, error) {
+	// get the collection
+	col := db.listCollection(colValidatorPerformance)
+
+	filter := bson.D{{Key: types.FiValidatorPerfEpoch, Value: bson.D{{Key: "$gte", Value: int64(fromEpoch)}}}}
+	if valID != nil {
+		filter = append(filter, bson.E{Key: types.FiValidatorPerfValidator, Value: valID.ToInt().Int64()})
+	}
+
+	cr, err := col.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$val"},
+			{Key: "from", Value: bson.D{{Key: "$min", Value: "$epoch"}}},
+			{Key: "epochs", Value: bson.D{{Key: "$sum", Value: 1}}},
+			{Key: "dur", Value: bson.D{{Key: "$sum", Value: "$dur"}}},
+			{Key: "up", Value: bson.D{{Key: "$sum", Value: "$up"}}},
+			{Key: "missed", Value: bson.D{{Key: "$max", Value: "$missed"}}},
+			{Key: "fee", Value: bson.D{{Key: "$sum", Value: "$fee_val"}}},
+			{Key: "ep_fee", Value: bson.D{{Key: "$sum", Value: "$ep_fee_val"}}},
+		}}},
+	})
+	if err != nil {
+		db.log.Errorf("can not aggregate validator performance; %s", err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := cr.Close(ctx); err != nil {
+			db.log.Errorf("can not close aggregate cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.ValidatorPerformance, 0)
+	for cr.Next(ctx) {
+		var row struct {
+			Validator    int64 `bson:"_id"`
+			From         int64 `bson:"from"`
+			Epochs       int64 `bson:"epochs"`
+			Duration     int64 `bson:"dur"`
+			Uptime       int64 `bson:"up"`
+			MissedBlocks int64 `bson:"missed"`
+			Fee          int64 `bson:"fee"`
+			EpochFee     int64 `bson:"ep_fee"`
+		}
+		if err := cr.Decode(&row); err != nil {
+			db.log.Errorf("can not decode validator performance; %s", err.Error())
+			return nil, err
+		}
+
+		list = append(list, &types.ValidatorPerformance{
+			ValidatorId:   (hexutil.Big)(*big.NewInt(row.Validator)),
+			FromEpoch:     hexutil.Uint64(row.From),
+			Epochs:        uint64(row.Epochs),
+			Duration:      uint64(row.Duration),
+			Uptime:        uint64(row.Uptime),
+			MissedBlocks:  uint64(row.MissedBlocks),
+			OriginatedFee: new(big.Int).Mul(big.NewInt(row.Fee), types.ValidatorPerfFeeDecimalsCorrection),
+			EpochFee:      new(big.Int).Mul(big.NewInt(row.EpochFee), types.ValidatorPerfFeeDecimalsCorrection),
+		})
+	}
+	return list, nil
+}
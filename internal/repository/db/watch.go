@@ -0,0 +1,77 @@
+// Package db implements bridge to persistent storage represented by Mongo database.
+package db
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// colWatches represents the name of the address watches collection in database.
+const colWatches = "watches"
+
+// AddWatch stores a new address watch in the database.
+func (db *MongoDbBridge) AddWatch(ctx context.Context, w *types.Watch) error {
+	// get the collection for watches
+	col := db.client.Database(db.dbName).Collection(colWatches)
+
+	if _, err := col.InsertOne(ctx, w); err != nil {
+		db.log.Errorf("can not store watch of %s; %s", w.Address, err.Error())
+		return err
+	}
+	return nil
+}
+
+// RemoveWatch removes the address watch of the given id, optionally owned by the given client only.
+// It returns FALSE if no such watch is known.
+func (db *MongoDbBridge) RemoveWatch(ctx context.Context, id string, owner *string) (bool, error) {
+	// get the collection for watches
+	col := db.client.Database(db.dbName).Collection(colWatches)
+
+	filter := bson.D{{Key: types.FiWatchPk, Value: id}}
+	if owner != nil {
+		filter = append(filter, bson.E{Key: types.FiWatchOwner, Value: *owner})
+	}
+
+	res, err := col.DeleteOne(ctx, filter)
+	if err != nil {
+		db.log.Errorf("can not remove watch %s; %s", id, err.Error())
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
+// Watches loads the list of address watches, optionally owned by the given client only.
+func (db *MongoDbBridge) Watches(ctx context.Context, owner *string) ([]*types.Watch, error) {
+	// get the collection for watches
+	col := db.client.Database(db.dbName).Collection(colWatches)
+
+	filter := bson.D{}
+	if owner != nil {
+		filter = append(filter, bson.E{Key: types.FiWatchOwner, Value: *owner})
+	}
+
+	cursor, err := col.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: types.FiWatchCreated, Value: 1}}))
+	if err != nil {
+		db.log.Errorf("can not load watches; %s", err.Error())
+		return nil, err
+	}
+
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			db.log.Errorf("can not close cursor; %s", err.Error())
+		}
+	}()
+
+	list := make([]*types.Watch, 0)
+	for cursor.Next(ctx) {
+		var row types.Watch
+		if err := cursor.Decode(&row); err != nil {
+			db.log.Errorf("can not decode watch; %s", err.Error())
+			return nil, err
+		}
+		list = append(list, &row)
+	}
+	return list, nil
+}
@@ -51,12 +51,12 @@ func (db *MongoDbBridge) initWithdrawalsCollection(col *mongo.Collection) {
 }
 
 // Withdrawal returns details of a withdrawal request specified by the request ID.
-func (db *MongoDbBridge) Withdrawal(addr *common.Address, valID *hexutil.Big, reqID *hexutil.Big) (*types.WithdrawRequest, error) {
+func (db *MongoDbBridge) Withdrawal(ctx context.Context, addr *common.Address, valID *hexutil.Big, reqID *hexutil.Big) (*types.WithdrawRequest, error) {
 	// get the collection for withdrawals
 	col := db.client.Database(db.dbName).Collection(colWithdrawals)
 
 	// try to find the delegation in the database
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(ctx, bson.D{
 		{Key: types.FiWithdrawalAddress, Value: addr.String()},
 		{Key: types.FiWithdrawalToValidator, Value: valID.String()},
 		{Key: types.FiWithdrawalRequestID, Value: reqID.String()},
@@ -77,12 +77,12 @@ func (db *MongoDbBridge) Withdrawal(addr *common.Address, valID *hexutil.Big, re
 }
 
 // AddWithdrawal stores a withdrawal request in the database if it doesn't exist.
-func (db *MongoDbBridge) AddWithdrawal(wr *types.WithdrawRequest) error {
+func (db *MongoDbBridge) AddWithdrawal(ctx context.Context, wr *types.WithdrawRequest) error {
 	// get the collection for withdrawals
 	col := db.client.Database(db.dbName).Collection(colWithdrawals)
 
 	// do we already know this withdraws request
-	uni, err := db.isUniqueWithdrawRequest(col, wr)
+	uni, err := db.isUniqueWithdrawRequest(ctx, col, wr)
 	if err != nil {
 		db.log.Errorf("can not proceed with withdraw request; %s", err.Error())
 		return err
@@ -90,11 +90,11 @@ func (db *MongoDbBridge) AddWithdrawal(wr *types.WithdrawRequest) error {
 
 	// non-unique requests will be updated instead
 	if !uni {
-		return db.UpdateWithdrawal(wr)
+		return db.UpdateWithdrawal(ctx, wr)
 	}
 
 	// try to do the insert
-	if _, err := col.InsertOne(context.Background(), wr); err != nil {
+	if _, err := col.InsertOne(ctx, wr); err != nil {
 		db.log.Criticalf("failed to store %s to %d, %s, %s; %s",
 			wr.Address.String(),
 			wr.StakerID.ToInt().Uint64(),
@@ -113,9 +113,9 @@ func (db *MongoDbBridge) AddWithdrawal(wr *types.WithdrawRequest) error {
 // isUniqueWithdrawRequest checks if the withdrawal request is unique
 // and if not, it tries to push the existing and closed request to a different ID
 // to keep the history even for repeated requests.
-func (db *MongoDbBridge) isUniqueWithdrawRequest(col *mongo.Collection, wr *types.WithdrawRequest) (bool, error) {
+func (db *MongoDbBridge) isUniqueWithdrawRequest(ctx context.Context, col *mongo.Collection, wr *types.WithdrawRequest) (bool, error) {
 	// do we already know this withdraws request? if not than let it be saved
-	if !db.isWithdrawalKnown(col, wr) {
+	if !db.isWithdrawalKnown(ctx, col, wr) {
 		return true, nil
 	}
 
@@ -127,7 +127,7 @@ func (db *MongoDbBridge) isUniqueWithdrawRequest(col *mongo.Collection, wr *type
 		wr.RequestTrx.String())
 
 	// try to shift finalised withdraw request
-	shifted, err := db.shiftClosedWithdrawRequest(col, wr)
+	shifted, err := db.shiftClosedWithdrawRequest(ctx, col, wr)
 	if err != nil {
 		db.log.Errorf("withdrawal shift failed; %s", err.Error())
 		return false, err
@@ -137,12 +137,12 @@ func (db *MongoDbBridge) isUniqueWithdrawRequest(col *mongo.Collection, wr *type
 
 // shiftClosedWithdrawRequest updates a request ID of an existing withdrawal request to preserve requests
 // history if the withdrawal request is already closed.
-func (db *MongoDbBridge) shiftClosedWithdrawRequest(col *mongo.Collection, wr *types.WithdrawRequest) (bool, error) {
+func (db *MongoDbBridge) shiftClosedWithdrawRequest(ctx context.Context, col *mongo.Collection, wr *types.WithdrawRequest) (bool, error) {
 	// generate new ID
 	reqID := (*hexutil.Big)(new(big.Int).SetBytes(wr.RequestTrx.Bytes()[:16])).String()
 
 	// try to shift a closed withdrawal request to a different reqID by updating it in the database
-	er, err := col.UpdateOne(context.Background(), bson.D{
+	er, err := col.UpdateOne(ctx, bson.D{
 		{Key: types.FiWithdrawalAddress, Value: wr.Address.String()},
 		{Key: types.FiWithdrawalToValidator, Value: wr.StakerID.String()},
 		{Key: types.FiWithdrawalRequestID, Value: wr.WithdrawRequestID.String()},
@@ -171,7 +171,7 @@ func (db *MongoDbBridge) shiftClosedWithdrawRequest(col *mongo.Collection, wr *t
 }
 
 // UpdateWithdrawal updates the given withdraw request in database.
-func (db *MongoDbBridge) UpdateWithdrawal(wr *types.WithdrawRequest) error {
+func (db *MongoDbBridge) UpdateWithdrawal(ctx context.Context, wr *types.WithdrawRequest) error {
 	// get the collection for withdrawals
 	col := db.client.Database(db.dbName).Collection(colWithdrawals)
 
@@ -194,7 +194,7 @@ func (db *MongoDbBridge) UpdateWithdrawal(wr *types.WithdrawRequest) error {
 
 	// try to update a withdraw request by replacing it in the database
 	// we use request ID identify unique withdrawal
-	er, err := col.UpdateOne(context.Background(), bson.D{
+	er, err := col.UpdateOne(ctx, bson.D{
 		{Key: types.FiWithdrawalAddress, Value: wr.Address.String()},
 		{Key: types.FiWithdrawalToValidator, Value: wr.StakerID.String()},
 		{Key: types.FiWithdrawalRequestID, Value: wr.WithdrawRequestID.String()},
@@ -222,9 +222,9 @@ func (db *MongoDbBridge) UpdateWithdrawal(wr *types.WithdrawRequest) error {
 }
 
 // isWithdrawalKnown checks if the given delegation exists in the database.
-func (db *MongoDbBridge) isWithdrawalKnown(col *mongo.Collection, wr *types.WithdrawRequest) bool {
+func (db *MongoDbBridge) isWithdrawalKnown(ctx context.Context, col *mongo.Collection, wr *types.WithdrawRequest) bool {
 	// try to find the delegation in the database
-	sr := col.FindOne(context.Background(), bson.D{
+	sr := col.FindOne(ctx, bson.D{
 		{Key: types.FiWithdrawalAddress, Value: wr.Address.String()},
 		{Key: types.FiWithdrawalToValidator, Value: wr.StakerID.String()},
 		{Key: types.FiWithdrawalRequestID, Value: wr.WithdrawRequestID.String()},
@@ -247,24 +247,24 @@ func (db *MongoDbBridge) isWithdrawalKnown(col *mongo.Collection, wr *types.With
 }
 
 // WithdrawalCountFiltered calculates total number of withdraw requests in the database for the given filter.
-func (db *MongoDbBridge) WithdrawalCountFiltered(filter *bson.D) (uint64, error) {
-	return db.CountFiltered(db.client.Database(db.dbName).Collection(colWithdrawals), filter)
+func (db *MongoDbBridge) WithdrawalCountFiltered(ctx context.Context, filter *bson.D) (uint64, error) {
+	return db.CountFiltered(ctx, db.client.Database(db.dbName).Collection(colWithdrawals), filter)
 }
 
 // WithdrawalsCount calculates total number of withdraws in the database.
-func (db *MongoDbBridge) WithdrawalsCount() (uint64, error) {
-	return db.EstimateCount(db.client.Database(db.dbName).Collection(colWithdrawals))
+func (db *MongoDbBridge) WithdrawalsCount(ctx context.Context) (uint64, error) {
+	return db.EstimateCount(ctx, db.client.Database(db.dbName).Collection(colWithdrawals))
 }
 
 // wrListInit initializes list of withdraw requests based on provided cursor, count, and filter.
-func (db *MongoDbBridge) wrListInit(col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.WithdrawRequestList, error) {
+func (db *MongoDbBridge) wrListInit(ctx context.Context, col *mongo.Collection, cursor *string, count int32, filter *bson.D) (*types.WithdrawRequestList, error) {
 	// make sure some filter is used
 	if nil == filter {
 		filter = &bson.D{}
 	}
 
 	// find how many transactions do we have in the database
-	total, err := col.CountDocuments(context.Background(), *filter)
+	total, err := col.CountDocuments(ctx, *filter)
 	if err != nil {
 		db.log.Errorf("can not count withdraw requests")
 		return nil, err
@@ -284,7 +284,7 @@ func (db *MongoDbBridge) wrListInit(col *mongo.Collection, cursor *string, count
 
 	// is the list non-empty? return the list with properly calculated range marks
 	if 0 < total {
-		return db.wrListCollectRangeMarks(col, &list, cursor, count)
+		return db.wrListCollectRangeMarks(ctx, col, &list, cursor, count)
 	}
 
 	// this is an empty list
@@ -293,27 +293,27 @@ func (db *MongoDbBridge) wrListInit(col *mongo.Collection, cursor *string, count
 }
 
 // wrListCollectRangeMarks returns the list of withdraw requests with proper First/Last marks.
-func (db *MongoDbBridge) wrListCollectRangeMarks(col *mongo.Collection, list *types.WithdrawRequestList, cursor *string, count int32) (*types.WithdrawRequestList, error) {
+func (db *MongoDbBridge) wrListCollectRangeMarks(ctx context.Context, col *mongo.Collection, list *types.WithdrawRequestList, cursor *string, count int32) (*types.WithdrawRequestList, error) {
 	var err error
 
 	// find out the cursor ordinal index
 	if cursor == nil && count > 0 {
 		// get the highest available pk
-		list.First, err = db.wrListBorderPk(col,
+		list.First, err = db.wrListBorderPk(ctx, col,
 			list.Filter,
 			options.FindOne().SetSort(bson.D{{Key: types.FiWithdrawalOrdinal, Value: -1}}))
 		list.IsStart = true
 
 	} else if cursor == nil && count < 0 {
 		// get the lowest available pk
-		list.First, err = db.wrListBorderPk(col,
+		list.First, err = db.wrListBorderPk(ctx, col,
 			list.Filter,
 			options.FindOne().SetSort(bson.D{{Key: types.FiWithdrawalOrdinal, Value: 1}}))
 		list.IsEnd = true
 
 	} else if cursor != nil {
 		// the cursor itself is the starting point
-		list.First, err = db.wrListBorderPk(col,
+		list.First, err = db.wrListBorderPk(ctx, col,
 			bson.D{{Key: types.FiWithdrawalPk, Value: *cursor}},
 			options.FindOne())
 	}
@@ -330,7 +330,7 @@ func (db *MongoDbBridge) wrListCollectRangeMarks(col *mongo.Collection, list *ty
 }
 
 // wrListBorderPk finds the top PK of the withdraw requests collection based on given filter and options.
-func (db *MongoDbBridge) wrListBorderPk(col *mongo.Collection, filter bson.D, opt *options.FindOneOptions) (uint64, error) {
+func (db *MongoDbBridge) wrListBorderPk(ctx context.Context, col *mongo.Collection, filter bson.D, opt *options.FindOneOptions) (uint64, error) {
 	// prep container
 	var row struct {
 		Value uint64 `bson:"orx"`
@@ -338,7 +338,7 @@ func (db *MongoDbBridge) wrListBorderPk(col *mongo.Collection, filter bson.D, op
 
 	// make sure we pull only what we need
 	opt.SetProjection(bson.D{{Key: types.FiWithdrawalOrdinal, Value: true}})
-	sr := col.FindOne(context.Background(), filter, opt)
+	sr := col.FindOne(ctx, filter, opt)
 
 	// try to decode
 	if err := sr.Decode(&row); err != nil {
@@ -395,10 +395,7 @@ func (db *MongoDbBridge) wrListOptions(count int32) *options.FindOptions {
 }
 
 // wrListLoad load the initialized list of withdraw requests from database.
-func (db *MongoDbBridge) wrListLoad(col *mongo.Collection, cursor *string, count int32, list *types.WithdrawRequestList) (err error) {
-	// get the context for loader
-	ctx := context.Background()
-
+func (db *MongoDbBridge) wrListLoad(ctx context.Context, col *mongo.Collection, cursor *string, count int32, list *types.WithdrawRequestList) (err error) {
 	// load the data
 	ld, err := col.Find(ctx, db.wrListFilter(cursor, count, list), db.wrListOptions(count))
 	if err != nil {
@@ -445,17 +442,17 @@ func (db *MongoDbBridge) wrListLoad(col *mongo.Collection, cursor *string, count
 }
 
 // Withdrawals pulls list of withdraw requests starting at the specified cursor.
-func (db *MongoDbBridge) Withdrawals(cursor *string, count int32, filter *bson.D) (*types.WithdrawRequestList, error) {
+func (db *MongoDbBridge) Withdrawals(ctx context.Context, cursor *string, count int32, filter *types.WithdrawalFilter) (*types.WithdrawRequestList, error) {
 	// nothing to load?
 	if count == 0 {
 		return nil, fmt.Errorf("nothing to do, zero withdrawals requested")
 	}
 
 	// get the collection and context
-	col := db.client.Database(db.dbName).Collection(colWithdrawals)
+	col := db.listCollection(colWithdrawals)
 
 	// init the list
-	list, err := db.wrListInit(col, cursor, count, filter)
+	list, err := db.wrListInit(ctx, col, cursor, count, withdrawalFilter(filter))
 	if err != nil {
 		db.log.Errorf("can not build withdraw requests list; %s", err.Error())
 		return nil, err
@@ -463,7 +460,7 @@ func (db *MongoDbBridge) Withdrawals(cursor *string, count int32, filter *bson.D
 
 	// load data if there are any
 	if list.Total > 0 {
-		err = db.wrListLoad(col, cursor, count, list)
+		err = db.wrListLoad(ctx, col, cursor, count, list)
 		if err != nil {
 			db.log.Errorf("can not load withdraw requests list from database; %s", err.Error())
 			return nil, err
@@ -485,16 +482,16 @@ func (db *MongoDbBridge) Withdrawals(cursor *string, count int32, filter *bson.D
 }
 
 // WithdrawalsSumValue calculates sum of values for all the withdrawals by a filter.
-func (db *MongoDbBridge) WithdrawalsSumValue(filter *bson.D) (*big.Int, error) {
-	return db.sumFieldValue(
-		db.client.Database(db.dbName).Collection(colWithdrawals),
+func (db *MongoDbBridge) WithdrawalsSumValue(ctx context.Context, filter *types.WithdrawalFilter) (*big.Int, error) {
+	return db.sumFieldValue(ctx,
+		db.listCollection(colWithdrawals),
 		types.FiWithdrawalValue,
-		filter,
+		withdrawalFilter(filter),
 		types.WithdrawDecimalsCorrection)
 }
 
 // sumFieldValue calculates sum of values for specified field of a specified collection by a given filter.
-func (db *MongoDbBridge) sumFieldValue(col *mongo.Collection, field string, filter *bson.D, decCorrection *big.Int) (*big.Int, error) {
+func (db *MongoDbBridge) sumFieldValue(ctx context.Context, col *mongo.Collection, field string, filter *bson.D, decCorrection *big.Int) (*big.Int, error) {
 	// make sure we have at least some filter
 	if filter == nil {
 		filter = &bson.D{}
@@ -505,7 +502,7 @@ func (db *MongoDbBridge) sumFieldValue(col *mongo.Collection, field string, filt
 	sb.WriteString(field)
 
 	// get the collection
-	cr, err := col.Aggregate(context.Background(), mongo.Pipeline{
+	cr, err := col.Aggregate(ctx, mongo.Pipeline{
 		{{Key: "$match", Value: filter}},
 		{{Key: "$group", Value: bson.D{
 			{Key: "_id", Value: nil},
@@ -517,20 +514,20 @@ func (db *MongoDbBridge) sumFieldValue(col *mongo.Collection, field string, filt
 		return nil, err
 	}
 	// read the data and return result
-	return db.readAggregatedSumFieldValue(cr, decCorrection)
+	return db.readAggregatedSumFieldValue(ctx, cr, decCorrection)
 }
 
 // readAggregatedSumFieldValue extract the aggregated value from the given result set.
-func (db *MongoDbBridge) readAggregatedSumFieldValue(cr *mongo.Cursor, decCorrection *big.Int) (*big.Int, error) {
+func (db *MongoDbBridge) readAggregatedSumFieldValue(ctx context.Context, cr *mongo.Cursor, decCorrection *big.Int) (*big.Int, error) {
 	// make sure to close the cursor after we got the data
 	defer func() {
-		if err := cr.Close(context.Background()); err != nil {
+		if err := cr.Close(ctx); err != nil {
 			db.log.Errorf("can not close aggregate cursor; %s", err.Error())
 		}
 	}()
 
 	// do we have any data to read?
-	if !cr.Next(context.Background()) {
+	if !cr.Next(ctx) {
 		return new(big.Int), nil
 	}
 
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"fantom-api-graphql/internal/types"
 
@@ -9,102 +10,108 @@ import (
 )
 
 // DefiConfiguration resolves the current DeFi contract settings.
-func (p *proxy) DefiConfiguration() (*types.DefiSettings, error) {
-	return p.rpc.DefiConfiguration()
+func (p *proxy) DefiConfiguration(ctx context.Context) (*types.DefiSettings, error) {
+	return p.rpc.DefiConfiguration(ctx)
 }
 
 // DefiToken loads details of a single DeFi token by it's address.
-func (p *proxy) DefiToken(token *common.Address) (*types.DefiToken, error) {
-	return p.rpc.DefiToken(token)
+func (p *proxy) DefiToken(ctx context.Context, token *common.Address) (*types.DefiToken, error) {
+	return p.rpc.DefiToken(ctx, token)
 }
 
 // DefiTokens resolves list of DeFi tokens available for the DeFi functions.
-func (p *proxy) DefiTokens() ([]types.DefiToken, error) {
-	return p.rpc.DefiTokens()
+func (p *proxy) DefiTokens(ctx context.Context) ([]types.DefiToken, error) {
+	return p.rpc.DefiTokens(ctx)
 }
 
 // DefiTokenPrice loads the current price of the given token
 // from on-chain price oracle.
-func (p *proxy) DefiTokenPrice(token *common.Address) (hexutil.Big, error) {
-	return p.rpc.FMintTokenPrice(token)
+func (p *proxy) DefiTokenPrice(ctx context.Context, token *common.Address) (hexutil.Big, error) {
+	return p.rpc.FMintTokenPrice(ctx, token)
 }
 
 // FMintAccount loads details of a DeFi/fMint account identified by the owner address.
-func (p *proxy) FMintAccount(owner common.Address) (*types.FMintAccount, error) {
-	return p.rpc.FMintAccount(&owner)
+func (p *proxy) FMintAccount(ctx context.Context, owner common.Address) (*types.FMintAccount, error) {
+	return p.rpc.FMintAccount(ctx, &owner)
 }
 
 // FMintTokenBalance loads balance of a single DeFi token by it's address.
-func (p *proxy) FMintTokenBalance(owner *common.Address, token *common.Address, tp types.DefiTokenType) (hexutil.Big, error) {
-	return p.rpc.FMintTokenBalance(owner, token, tp)
+func (p *proxy) FMintTokenBalance(ctx context.Context, owner *common.Address, token *common.Address, tp types.DefiTokenType) (hexutil.Big, error) {
+	return p.rpc.FMintTokenBalance(ctx, owner, token, tp)
 }
 
 // FMintTokenTotalBalance loads total balance of a single DeFi token by it's address.
-func (p *proxy) FMintTokenTotalBalance(token *common.Address, tp types.DefiTokenType) (hexutil.Big, error) {
-	return p.rpc.FMintTokenTotalBalance(token, tp)
+func (p *proxy) FMintTokenTotalBalance(ctx context.Context, token *common.Address, tp types.DefiTokenType) (hexutil.Big, error) {
+	return p.rpc.FMintTokenTotalBalance(ctx, token, tp)
 }
 
 // FMintTokenValue loads value of a single DeFi token by it's address in fUSD.
-func (p *proxy) FMintTokenValue(owner *common.Address, token *common.Address, tp types.DefiTokenType) (hexutil.Big, error) {
-	return p.rpc.FMintTokenValue(owner, token, tp)
+func (p *proxy) FMintTokenValue(ctx context.Context, owner *common.Address, token *common.Address, tp types.DefiTokenType) (hexutil.Big, error) {
+	return p.rpc.FMintTokenValue(ctx, owner, token, tp)
+}
+
+// FMintLiquidationPrice calculates the price of the given collateral token on which
+// the account collateral to debt ratio drops below the lowest allowed ratio.
+func (p *proxy) FMintLiquidationPrice(ctx context.Context, owner *common.Address, token *common.Address) (*hexutil.Big, error) {
+	return p.rpc.FMintLiquidationPrice(ctx, owner, token)
 }
 
 // FMintRewardsEarned represents the total amount of rewards
 // accumulated on the account for the excessive collateral deposits.
-func (p *proxy) FMintRewardsEarned(addr *common.Address) (hexutil.Big, error) {
-	return p.rpc.FMintRewardsEarned(addr)
+func (p *proxy) FMintRewardsEarned(ctx context.Context, addr *common.Address) (hexutil.Big, error) {
+	return p.rpc.FMintRewardsEarned(ctx, addr)
 }
 
 // FMintRewardsStashed represents the total amount of rewards
 // accumulated on the account in stash.
-func (p *proxy) FMintRewardsStashed(addr *common.Address) (hexutil.Big, error) {
-	return p.rpc.FMintRewardsStashed(addr)
+func (p *proxy) FMintRewardsStashed(ctx context.Context, addr *common.Address) (hexutil.Big, error) {
+	return p.rpc.FMintRewardsStashed(ctx, addr)
 }
 
 // FMintCanClaimRewards resolves the fMint account flag for being allowed
 // to claim earned rewards.
-func (p *proxy) FMintCanClaimRewards(addr *common.Address) (bool, error) {
-	return p.rpc.FMintCanClaimRewards(addr)
+func (p *proxy) FMintCanClaimRewards(ctx context.Context, addr *common.Address) (bool, error) {
+	return p.rpc.FMintCanClaimRewards(ctx, addr)
 }
 
 // FMintCanReceiveRewards resolves the fMint account flag for being eligible
 // to receive earned rewards. If the collateral to debt ration drop below
 // certain value, earned rewards are burned.
-func (p *proxy) FMintCanReceiveRewards(addr *common.Address) (bool, error) {
-	return p.rpc.FMintCanReceiveRewards(addr)
+func (p *proxy) FMintCanReceiveRewards(ctx context.Context, addr *common.Address) (bool, error) {
+	return p.rpc.FMintCanReceiveRewards(ctx, addr)
 }
 
 // FMintCanPushRewards signals if there are any rewards unlocked
 // on the rewards distribution contract and can be pushed to accounts.
-func (p *proxy) FMintCanPushRewards() (bool, error) {
-	return p.rpc.FMintCanPushRewards()
+func (p *proxy) FMintCanPushRewards(ctx context.Context) (bool, error) {
+	return p.rpc.FMintCanPushRewards(ctx)
 }
 
 // FLendGetLendingPool resolves lending pool contract instace
 // to be able to get calls and informations from this contract
-func (p *proxy) FLendGetLendingPool() (*contracts.ILendingPool, error) {
+func (p *proxy) FLendGetLendingPool(ctx context.Context) (*contracts.ILendingPool, error) {
 	return p.rpc.FLendGetLendingPool()
 }
 
 // FLendGetLendingPoolReserveData resolves reserve data
 // according to given address
-func (p *proxy) FLendGetLendingPoolReserveData(assetAddress *common.Address) (*types.ReserveData, error) {
-	return p.rpc.FLendGetLendingPoolReserveData(assetAddress)
+func (p *proxy) FLendGetLendingPoolReserveData(ctx context.Context, assetAddress *common.Address) (*types.ReserveData, error) {
+	return p.rpc.FLendGetLendingPoolReserveData(ctx, assetAddress)
 }
 
 // FLendGetUserAccountData resolves user account data for
 // specified address
-func (p *proxy) FLendGetUserAccountData(userAddress *common.Address) (*types.FLendUserAccountData, error) {
-	return p.rpc.FLendGetUserAccountData(userAddress)
+func (p *proxy) FLendGetUserAccountData(ctx context.Context, userAddress *common.Address) (*types.FLendUserAccountData, error) {
+	return p.rpc.FLendGetUserAccountData(ctx, userAddress)
 }
 
 // FLendGetReserveList resolves list of reserves in lending pool
-func (p *proxy) FLendGetReserveList() ([]common.Address, error) {
-	return p.rpc.FLendGetReserveList()
+func (p *proxy) FLendGetReserveList(ctx context.Context) ([]common.Address, error) {
+	return p.rpc.FLendGetReserveList(ctx)
 }
 
 // FLendGetUserDepositHistory resolves deposit history
 // data for specified user and asset address
-func (p *proxy) FLendGetUserDepositHistory(userAddress *common.Address, assetAddress *common.Address) ([]*types.FLendDeposit, error) {
-	return p.rpc.FLendGetUserDepositHistory(userAddress, assetAddress)
+func (p *proxy) FLendGetUserDepositHistory(ctx context.Context, userAddress *common.Address, assetAddress *common.Address) ([]*types.FLendDeposit, error) {
+	return p.rpc.FLendGetUserDepositHistory(ctx, userAddress, assetAddress)
 }
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"math/big"
@@ -12,26 +13,38 @@ func (p *proxy) Erc1155Contract(addr *common.Address) (*types.Erc1155Contract, e
 }
 
 // Erc1155Uri provides URI of Metadata JSON Schema of the token.
-func (p *proxy) Erc1155Uri(token *common.Address, tokenId *big.Int) (string, error) {
-	return p.rpc.Erc1155Uri(token, tokenId)
+func (p *proxy) Erc1155Uri(ctx context.Context, token *common.Address, tokenId *big.Int) (string, error) {
+	return p.rpc.Erc1155Uri(ctx, token, tokenId)
 }
 
 // Erc1155BalanceOf provides amount of NFT tokens owned by given owner.
-func (p *proxy) Erc1155BalanceOf(token *common.Address, owner *common.Address, tokenId *big.Int) (*big.Int, error) {
-	return p.rpc.Erc1155BalanceOf(token, owner, tokenId)
+func (p *proxy) Erc1155BalanceOf(ctx context.Context, token *common.Address, owner *common.Address, tokenId *big.Int) (*big.Int, error) {
+	return p.rpc.Erc1155BalanceOf(ctx, token, owner, tokenId)
 }
 
 // Erc1155BalanceOfBatch provides amount of NFT tokens owned by given owner.
-func (p *proxy) Erc1155BalanceOfBatch(token *common.Address, owners *[]common.Address, tokenIds []*big.Int) ([]*big.Int, error) {
-	return p.rpc.Erc1155BalanceOfBatch(token, owners, tokenIds)
+func (p *proxy) Erc1155BalanceOfBatch(ctx context.Context, token *common.Address, owners *[]common.Address, tokenIds []*big.Int) ([]*big.Int, error) {
+	return p.rpc.Erc1155BalanceOfBatch(ctx, token, owners, tokenIds)
 }
 
 // Erc1155IsApprovedForAll provides information about operator approved to manipulate with NFT tokens of given owner.
-func (p *proxy) Erc1155IsApprovedForAll(token *common.Address, owner *common.Address, operator *common.Address) (bool, error) {
-	return p.rpc.Erc1155IsApprovedForAll(token, owner, operator)
+func (p *proxy) Erc1155IsApprovedForAll(ctx context.Context, token *common.Address, owner *common.Address, operator *common.Address) (bool, error) {
+	return p.rpc.Erc1155IsApprovedForAll(ctx, token, owner, operator)
 }
 
 // Erc1155ContractsList returns a list of known ERC1155 tokens ordered by their activity.
-func (p *proxy) Erc1155ContractsList(count int32) ([]common.Address, error) {
-	return p.db.Erc1155ContractsList(count)
+func (p *proxy) Erc1155ContractsList(ctx context.Context, count int32) ([]common.Address, error) {
+	return p.db.Erc1155ContractsList(ctx, count)
+}
+
+// Erc1155Balances provides a list of ERC1155 token types held by the given account
+// along with the current balance.
+func (p *proxy) Erc1155Balances(ctx context.Context, owner *common.Address, count int32) ([]*types.Erc1155Balance, error) {
+	return p.db.Erc1155Balances(ctx, *owner, count)
+}
+
+// Erc1155UpdateBalances updates the balances of the ERC1155 token holders
+// by the token amount moved by the given token transaction.
+func (p *proxy) Erc1155UpdateBalances(ctx context.Context, trx *types.TokenTransaction) error {
+	return p.db.Erc1155UpdateBalances(ctx, trx)
 }
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/repository/cache"
 	"fantom-api-graphql/internal/types"
@@ -9,7 +10,7 @@ import (
 )
 
 // Erc20Token returns an ERC20 token for the given address, if available.
-func (p *proxy) Erc20Token(addr *common.Address) (*types.Erc20Token, error) {
+func (p *proxy) Erc20Token(ctx context.Context, addr *common.Address) (*types.Erc20Token, error) {
 	// get the token
 	val, err, _ := p.apiRequestGroup.Do(cache.ErcTokenId(addr, cache.Erc20CacheIdPrefix), func() (interface{}, error) {
 		// try the cache first
@@ -20,8 +21,10 @@ func (p *proxy) Erc20Token(addr *common.Address) (*types.Erc20Token, error) {
 		}
 
 		// load the slow way; build the structure and pull needed details
+		// the token is shared with all the callers waiting for it, so the load
+		// must not be cancelled by any single one of them
 		var err error
-		token, err = p.loadErc20TokenDetails(&types.Erc20Token{Address: *addr})
+		token, err = p.loadErc20TokenDetails(context.Background(), &types.Erc20Token{Address: *addr})
 		if err != nil {
 			p.log.Errorf("can not load ERC20 token at %s; %s", addr.String(), err.Error())
 			return nil, err
@@ -45,25 +48,25 @@ func (p *proxy) Erc20Token(addr *common.Address) (*types.Erc20Token, error) {
 
 // loadErc20TokenDetails loads details of the given ERC20 token using ERC20
 // contract calls.
-func (p *proxy) loadErc20TokenDetails(token *types.Erc20Token) (*types.Erc20Token, error) {
+func (p *proxy) loadErc20TokenDetails(ctx context.Context, token *types.Erc20Token) (*types.Erc20Token, error) {
 	var err error
 
 	// get the name
-	token.Name, err = p.rpc.Erc20Name(&token.Address)
+	token.Name, err = p.rpc.Erc20Name(ctx, &token.Address)
 	if err != nil {
 		p.log.Errorf("ERC20 token name not recognized at %s; %s", token.Address.String(), err.Error())
 		token.Name = token.Address.String()
 	}
 
 	// get symbol
-	token.Symbol, err = p.rpc.Erc20Symbol(&token.Address)
+	token.Symbol, err = p.rpc.Erc20Symbol(ctx, &token.Address)
 	if err != nil {
 		p.log.Errorf("ERC20 token symbol not recognized at %s; %s", token.Address.String(), err.Error())
 		token.Symbol = "-"
 	}
 
 	// get decimals
-	token.Decimals, err = p.rpc.Erc20Decimals(&token.Address)
+	token.Decimals, err = p.rpc.Erc20Decimals(ctx, &token.Address)
 	if err != nil {
 		p.log.Errorf("ERC20 token decimals not recognized at %s; %s", token.Address.String(), err.Error())
 		token.Decimals = 0
@@ -73,8 +76,8 @@ func (p *proxy) loadErc20TokenDetails(token *types.Erc20Token) (*types.Erc20Toke
 }
 
 // Erc20Name provides information about the name of the ERC20 token.
-func (p *proxy) Erc20Name(token *common.Address) (string, error) {
-	tk, err := p.Erc20Token(token)
+func (p *proxy) Erc20Name(ctx context.Context, token *common.Address) (string, error) {
+	tk, err := p.Erc20Token(ctx, token)
 	if err != nil {
 		return "", err
 	}
@@ -82,8 +85,8 @@ func (p *proxy) Erc20Name(token *common.Address) (string, error) {
 }
 
 // Erc20Symbol provides information about the symbol of the ERC20 token.
-func (p *proxy) Erc20Symbol(token *common.Address) (string, error) {
-	tk, err := p.Erc20Token(token)
+func (p *proxy) Erc20Symbol(ctx context.Context, token *common.Address) (string, error) {
+	tk, err := p.Erc20Token(ctx, token)
 	if err != nil {
 		return "", err
 	}
@@ -91,8 +94,8 @@ func (p *proxy) Erc20Symbol(token *common.Address) (string, error) {
 }
 
 // Erc20Decimals provides information about the decimals of the ERC20 token.
-func (p *proxy) Erc20Decimals(token *common.Address) (int32, error) {
-	tk, err := p.Erc20Token(token)
+func (p *proxy) Erc20Decimals(ctx context.Context, token *common.Address) (int32, error) {
+	tk, err := p.Erc20Token(ctx, token)
 	if err != nil {
 		return 0, err
 	}
@@ -101,32 +104,103 @@ func (p *proxy) Erc20Decimals(token *common.Address) (int32, error) {
 
 // Erc20BalanceOf load the current available balance of and ERC20 token identified by the token
 // contract address for an identified owner address.
-func (p *proxy) Erc20BalanceOf(token *common.Address, owner *common.Address) (hexutil.Big, error) {
-	return p.rpc.Erc20BalanceOf(token, owner)
+func (p *proxy) Erc20BalanceOf(ctx context.Context, token *common.Address, owner *common.Address) (hexutil.Big, error) {
+	return p.rpc.Erc20BalanceOf(ctx, token, owner)
+}
+
+// Erc20BalancesOf loads the current available balances of the given ERC20 tokens
+// for the given owner in a single batch; nil balance is provided for failed reads.
+func (p *proxy) Erc20BalancesOf(ctx context.Context, owner *common.Address, tokens []common.Address) ([]*hexutil.Big, error) {
+	return p.rpc.Erc20BalancesOf(ctx, owner, tokens)
 }
 
 // Erc20Allowance loads the current amount of ERC20 tokens unlocked for DeFi
 // contract by the token owner.
-func (p *proxy) Erc20Allowance(token *common.Address, owner *common.Address, spender *common.Address) (hexutil.Big, error) {
-	return p.rpc.Erc20Allowance(token, owner, spender)
+func (p *proxy) Erc20Allowance(ctx context.Context, token *common.Address, owner *common.Address, spender *common.Address) (hexutil.Big, error) {
+	return p.rpc.Erc20Allowance(ctx, token, owner, spender)
 }
 
 // Erc20TotalSupply provides information about all available tokens
-func (p *proxy) Erc20TotalSupply(token *common.Address) (hexutil.Big, error) {
-	return p.rpc.Erc20TotalSupply(token)
+func (p *proxy) Erc20TotalSupply(ctx context.Context, token *common.Address) (hexutil.Big, error) {
+	return p.rpc.Erc20TotalSupply(ctx, token)
 }
 
 // Erc20TokensList returns a list of known ERC20 tokens ordered by their activity.
-func (p *proxy) Erc20TokensList(count int32) ([]common.Address, error) {
-	return p.db.Erc20TokensList(count)
+func (p *proxy) Erc20TokensList(ctx context.Context, count int32) ([]common.Address, error) {
+	return p.db.Erc20TokensList(ctx, count)
+}
+
+// Erc20TokensBySymbol returns a list of known ERC20 tokens with the given symbol,
+// the symbol is matched case-insensitive.
+func (p *proxy) Erc20TokensBySymbol(ctx context.Context, sym string, count int32) ([]common.Address, error) {
+	return p.db.ContractsBySymbol(ctx, types.AccountTypeERC20Token, sym, count)
+}
+
+// Erc20IndexSymbols stores the symbols of the known ERC20 tokens registered
+// before their symbols have been kept, so the tokens can be found by the symbol.
+func (p *proxy) Erc20IndexSymbols(ctx context.Context) error {
+	list, err := p.db.ContractsWithoutSymbol(ctx, types.AccountTypeERC20Token)
+	if err != nil {
+		return err
+	}
+
+	for i := range list {
+		sym, err := p.rpc.Erc20Symbol(ctx, &list[i])
+		if err != nil {
+			p.log.Warningf("ERC20 token symbol not recognized at %s; %s", list[i].String(), err.Error())
+			continue
+		}
+		if err := p.db.ContractUpdateSymbol(ctx, &list[i], sym); err != nil {
+			return err
+		}
+	}
+
+	p.log.Noticef("%d ERC20 token symbols indexed", len(list))
+	return nil
 }
 
 // Erc20LogoURL provides URL address of a logo of the ERC20 token.
-func (p *proxy) Erc20LogoURL(addr *common.Address) string {
+func (p *proxy) Erc20LogoURL(ctx context.Context, addr *common.Address) string {
+	logos := p.cfg.Tunable().TokenLogo
+
 	// do we know the token?
-	logo, ok := p.cfg.TokenLogo[*addr]
+	logo, ok := logos[*addr]
 	if !ok {
-		logo = p.cfg.TokenLogo[common.HexToAddress(config.EmptyAddress)]
+		logo = logos[common.HexToAddress(config.EmptyAddress)]
 	}
 	return logo
 }
+
+// Erc20UpdateHolders updates the balances of the ERC20 token holders
+// by the token amount moved by the given token transaction.
+func (p *proxy) Erc20UpdateHolders(ctx context.Context, trx *types.TokenTransaction) error {
+	return p.db.Erc20UpdateHolders(ctx, trx)
+}
+
+// Erc20Holders provides a list of holders of the given ERC20 token sorted by their balance
+// from the highest to the lowest. The cursor is the rank of the holder the list continues after.
+func (p *proxy) Erc20Holders(ctx context.Context, token *common.Address, cursor *string, count int32) (*types.AccountBalanceList, error) {
+	// decode the starting position
+	skip, err := rankCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	// load the list
+	bl, err := p.db.Erc20Holders(ctx, token, skip, count)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := p.db.Erc20TokenHoldersCount(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.AccountBalanceList{
+		Collection: bl,
+		Total:      total,
+		IsStart:    skip == 0,
+		IsEnd:      len(bl) < int(count),
+	}, nil
+}
@@ -0,0 +1,162 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math"
+	"math/big"
+	"time"
+)
+
+const (
+	// erc20MarketWindow represents the window of the token price change and the trade volume.
+	erc20MarketWindow = 24 * time.Hour
+
+	// nativeTokenDecimals represents the number of decimals of the wrapped native token.
+	nativeTokenDecimals = 18
+)
+
+// Erc20Price provides the current price of a whole ERC20 token in native FTM tokens
+// derived from the reserves of the token pair with the wrapped native token on the DEX.
+func (p *proxy) Erc20Price(ctx context.Context, token *types.Erc20Token) (float64, error) {
+	native, err := p.NativeTokenAddress(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if token.Address == *native {
+		return 1, nil
+	}
+
+	pair, side, err := p.erc20NativePair(ctx, &token.Address, native)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := p.UniswapReserves(ctx, pair)
+	if err != nil {
+		return 0, err
+	}
+	return reservesPrice(res[side].ToInt(), res[1-side].ToInt(), token.Decimals), nil
+}
+
+// Erc20PriceChange24h provides the relative change of the token price in native FTM tokens
+// over the last 24 hours in percents. Nil is returned if the past price is not known.
+func (p *proxy) Erc20PriceChange24h(ctx context.Context, token *types.Erc20Token) (*float64, error) {
+	native, err := p.NativeTokenAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the native token price never changes
+	if token.Address == *native {
+		var zero float64
+		return &zero, nil
+	}
+
+	pair, side, err := p.erc20NativePair(ctx, &token.Address, native)
+	if err != nil {
+		return nil, err
+	}
+
+	past, err := p.db.UniswapReservesAt(ctx, pair, time.Now().UTC().Add(-erc20MarketWindow))
+	if err != nil || past == nil {
+		return nil, err
+	}
+
+	res, err := p.UniswapReserves(ctx, pair)
+	if err != nil {
+		return nil, err
+	}
+
+	then := reservesPrice(past[side], past[1-side], token.Decimals)
+	if then == 0 {
+		return nil, nil
+	}
+
+	change := (reservesPrice(res[side].ToInt(), res[1-side].ToInt(), token.Decimals)/then - 1) * 100
+	return &change, nil
+}
+
+// Erc20Volume24h provides the amount of the token traded on the known DEX pairs
+// over the last 24 hours.
+func (p *proxy) Erc20Volume24h(ctx context.Context, token *common.Address) (hexutil.Big, error) {
+	pairs, err := p.UniswapKnownPairs(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+
+	from := time.Now().UTC().Add(-erc20MarketWindow)
+	total := new(big.Int)
+	for i := range pairs {
+		tl, err := p.UniswapTokens(ctx, &pairs[i])
+		if err != nil {
+			p.log.Errorf("tokens of pair %s not known; %s", pairs[i].String(), err.Error())
+			continue
+		}
+
+		side := pairSide(tl, token)
+		if side < 0 {
+			continue
+		}
+
+		vol, err := p.db.UniswapTokenVolume(ctx, &pairs[i], side, from)
+		if err != nil {
+			return hexutil.Big{}, err
+		}
+		total.Add(total, vol)
+	}
+	return hexutil.Big(*total), nil
+}
+
+// erc20NativePair provides the DEX pair of the given token with the native token
+// and the side of the pair the token is on.
+func (p *proxy) erc20NativePair(ctx context.Context, token *common.Address, native *common.Address) (*common.Address, int, error) {
+	pair, err := p.UniswapPair(ctx, token, native)
+	if err != nil {
+		return nil, 0, err
+	}
+	if *pair == (common.Address{}) {
+		return nil, 0, types.Errorf(types.ErrCodeNotFound, "token %s is not paired with the native token", token.String())
+	}
+
+	tl, err := p.UniswapTokens(ctx, pair)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	side := pairSide(tl, token)
+	if side < 0 {
+		return nil, 0, types.Errorf(types.ErrCodeNotFound, "token %s not found in pair %s", token.String(), pair.String())
+	}
+	return pair, side, nil
+}
+
+// pairSide provides the index of the token in the list of the pair tokens, or -1 if not found.
+func pairSide(tokens []common.Address, token *common.Address) int {
+	for i, t := range tokens {
+		if i < 2 && t == *token {
+			return i
+		}
+	}
+	return -1
+}
+
+// reservesPrice calculates the price of a whole token in native tokens from the pair reserves.
+func reservesPrice(token *big.Int, native *big.Int, decimals int32) float64 {
+	if token == nil || native == nil || token.Sign() == 0 {
+		return 0
+	}
+
+	price, _ := new(big.Float).Quo(new(big.Float).SetInt(native), new(big.Float).SetInt(token)).Float64()
+	return price * math.Pow10(int(decimals)-nativeTokenDecimals)
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository/cache"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -9,7 +10,7 @@ import (
 )
 
 // Erc721Contract returns an ERC721 token for the given address, if available.
-func (p *proxy) Erc721Contract(addr *common.Address) (*types.Erc721Contract, error) {
+func (p *proxy) Erc721Contract(ctx context.Context, addr *common.Address) (*types.Erc721Contract, error) {
 	// get the token
 	val, err, _ := p.apiRequestGroup.Do(cache.ErcTokenId(addr, cache.Erc721CacheIdPrefix), func() (interface{}, error) {
 		// try cache first
@@ -20,7 +21,9 @@ func (p *proxy) Erc721Contract(addr *common.Address) (*types.Erc721Contract, err
 			return tok, nil
 		}
 
-		tok, err = p.loadErc721ContractDetails(&types.Erc721Contract{Address: *addr})
+		// the token is shared with all the callers waiting for it, so the load
+		// must not be cancelled by any single one of them
+		tok, err = p.loadErc721ContractDetails(context.Background(), &types.Erc721Contract{Address: *addr})
 		if err != nil {
 			p.log.Errorf("can not load ERC721 token at %s; %s", addr.String(), err.Error())
 			return nil, err
@@ -40,18 +43,18 @@ func (p *proxy) Erc721Contract(addr *common.Address) (*types.Erc721Contract, err
 	return val.(*types.Erc721Contract), nil
 }
 
-func (p *proxy) loadErc721ContractDetails(token *types.Erc721Contract) (*types.Erc721Contract, error) {
+func (p *proxy) loadErc721ContractDetails(ctx context.Context, token *types.Erc721Contract) (*types.Erc721Contract, error) {
 	var err error
 
 	// get the name (ignore fail - name is optional in ERC721)
-	token.Name, err = p.rpc.Erc721Name(&token.Address)
+	token.Name, err = p.rpc.Erc721Name(ctx, &token.Address)
 	if err != nil {
 		p.log.Noticef("ERC721 name failed for %s; %s", token.Address.String(), err.Error())
 		return nil, err
 	}
 
 	// get symbol (ignore fail - symbol is optional in ERC721)
-	token.Symbol, err = p.rpc.Erc721Symbol(&token.Address)
+	token.Symbol, err = p.rpc.Erc721Symbol(ctx, &token.Address)
 	if err != nil {
 		p.log.Noticef("ERC721 symbol failed for %s; %s", token.Address.String(), err.Error())
 		return nil, err
@@ -61,8 +64,8 @@ func (p *proxy) loadErc721ContractDetails(token *types.Erc721Contract) (*types.E
 }
 
 // Erc721Name provides information about the name of the ERC721 token.
-func (p *proxy) Erc721Name(adr *common.Address) (string, error) {
-	tk, err := p.Erc721Contract(adr)
+func (p *proxy) Erc721Name(ctx context.Context, adr *common.Address) (string, error) {
+	tk, err := p.Erc721Contract(ctx, adr)
 	if err != nil {
 		return "", err
 	}
@@ -70,8 +73,8 @@ func (p *proxy) Erc721Name(adr *common.Address) (string, error) {
 }
 
 // Erc721Symbol provides information about the symbol of the ERC721 token.
-func (p *proxy) Erc721Symbol(adr *common.Address) (string, error) {
-	tk, err := p.Erc721Contract(adr)
+func (p *proxy) Erc721Symbol(ctx context.Context, adr *common.Address) (string, error) {
+	tk, err := p.Erc721Contract(ctx, adr)
 	if err != nil {
 		return "", err
 	}
@@ -79,36 +82,46 @@ func (p *proxy) Erc721Symbol(adr *common.Address) (string, error) {
 }
 
 // Erc721BalanceOf provides amount of NFT tokens owned by given owner in given ERC721 contract.
-func (p *proxy) Erc721BalanceOf(token *common.Address, owner *common.Address) (hexutil.Big, error) {
-	return p.rpc.Erc721BalanceOf(token, owner)
+func (p *proxy) Erc721BalanceOf(ctx context.Context, token *common.Address, owner *common.Address) (hexutil.Big, error) {
+	return p.rpc.Erc721BalanceOf(ctx, token, owner)
 }
 
 // Erc721TotalSupply provides information about all available tokens.
-func (p *proxy) Erc721TotalSupply(token *common.Address) (hexutil.Big, error) {
-	return p.rpc.Erc721TotalSupply(token)
+func (p *proxy) Erc721TotalSupply(ctx context.Context, token *common.Address) (hexutil.Big, error) {
+	return p.rpc.Erc721TotalSupply(ctx, token)
 }
 
 // Erc721TokenURI provides URI of Metadata JSON Schema of the ERC721 token.
-func (p *proxy) Erc721TokenURI(token *common.Address, tokenId *big.Int) (string, error) {
-	return p.rpc.Erc721TokenURI(token, tokenId)
+func (p *proxy) Erc721TokenURI(ctx context.Context, token *common.Address, tokenId *big.Int) (string, error) {
+	return p.rpc.Erc721TokenURI(ctx, token, tokenId)
 }
 
 // Erc721OwnerOf provides information about NFT token ownership.
-func (p *proxy) Erc721OwnerOf(token *common.Address, tokenId *big.Int) (common.Address, error) {
-	return p.rpc.Erc721OwnerOf(token, tokenId)
+func (p *proxy) Erc721OwnerOf(ctx context.Context, token *common.Address, tokenId *big.Int) (common.Address, error) {
+	return p.rpc.Erc721OwnerOf(ctx, token, tokenId)
 }
 
 // Erc721GetApproved provides information about operator approved to manipulate with the NFT token.
-func (p *proxy) Erc721GetApproved(token *common.Address, tokenId *big.Int) (common.Address, error) {
-	return p.rpc.Erc721GetApproved(token, tokenId)
+func (p *proxy) Erc721GetApproved(ctx context.Context, token *common.Address, tokenId *big.Int) (common.Address, error) {
+	return p.rpc.Erc721GetApproved(ctx, token, tokenId)
 }
 
 // Erc721IsApprovedForAll provides information about operator approved to manipulate with NFT tokens of given owner.
-func (p *proxy) Erc721IsApprovedForAll(token *common.Address, owner *common.Address, operator *common.Address) (bool, error) {
-	return p.rpc.Erc721IsApprovedForAll(token, owner, operator)
+func (p *proxy) Erc721IsApprovedForAll(ctx context.Context, token *common.Address, owner *common.Address, operator *common.Address) (bool, error) {
+	return p.rpc.Erc721IsApprovedForAll(ctx, token, owner, operator)
 }
 
 // Erc721ContractsList returns a list of known ERC721 tokens ordered by their activity.
-func (p *proxy) Erc721ContractsList(count int32) ([]common.Address, error) {
-	return p.db.Erc721ContractsList(count)
+func (p *proxy) Erc721ContractsList(ctx context.Context, count int32) ([]common.Address, error) {
+	return p.db.Erc721ContractsList(ctx, count)
+}
+
+// Erc721TokensOfOwner provides a list of ERC721 NFT tokens currently owned by the given account.
+func (p *proxy) Erc721TokensOfOwner(ctx context.Context, owner *common.Address, count int32) ([]*types.Erc721Token, error) {
+	return p.db.Erc721TokensOfOwner(ctx, *owner, count)
+}
+
+// Erc721UpdateOwner records the new owner of the ERC721 token moved by the given token transaction.
+func (p *proxy) Erc721UpdateOwner(ctx context.Context, trx *types.TokenTransaction) error {
+	return p.db.Erc721UpdateOwner(ctx, trx)
 }
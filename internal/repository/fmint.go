@@ -8,15 +8,18 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 */
 package repository
 
-import "fantom-api-graphql/internal/types"
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+)
 
 // AddFMintTransaction adds the specified fMint transaction to persistent storage.
-func (p *proxy) AddFMintTransaction(trx *types.FMintTransaction) error {
-	return p.db.AddFMintTransaction(trx)
+func (p *proxy) AddFMintTransaction(ctx context.Context, trx *types.FMintTransaction) error {
+	return p.db.AddFMintTransaction(ctx, trx)
 }
 
 // FMintUsers loads the list of fMint users and their associated tokens
 // used for a specified transaction type.
-func (p *proxy) FMintUsers(tt int32) ([]*types.FMintUserTokens, error) {
-	return p.db.FMintUsers(tt)
+func (p *proxy) FMintUsers(ctx context.Context, tt int32) ([]*types.FMintUserTokens, error) {
+	return p.db.FMintUsers(ctx, tt)
 }
@@ -0,0 +1,71 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// StoreFtmBurn stores the FTM burn record of a block.
+func (p *proxy) StoreFtmBurn(ctx context.Context, burn *types.FtmBurn) error {
+	return p.db.StoreBurn(ctx, burn)
+}
+
+// FtmBurnedTotal provides the total amount of native FTM tokens burned.
+func (p *proxy) FtmBurnedTotal(ctx context.Context) (*big.Int, error) {
+	return p.db.BurnTotal(ctx)
+}
+
+// FtmBurnList provides a list of FTM burn records from the most recent block.
+// The cursor is the number of the block the list continues after.
+func (p *proxy) FtmBurnList(ctx context.Context, cursor *string, count int32) (*types.FtmBurnList, error) {
+	// decode the starting position
+	var below *uint64
+	if cursor != nil {
+		num, err := strconv.ParseUint(*cursor, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor; %s", err.Error())
+		}
+		below = &num
+	}
+
+	// load the list
+	bl, err := p.db.BurnList(ctx, below, count)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := p.db.BurnCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.FtmBurnList{
+		Collection: bl,
+		Total:      total,
+		IsStart:    below == nil,
+		IsEnd:      len(bl) < int(count),
+	}, nil
+}
+
+// FtmBurnOfBlock provides the amount of native FTM tokens burned by the given block.
+func (p *proxy) FtmBurnOfBlock(ctx context.Context, block uint64) (*big.Int, error) {
+	burn, err := p.db.BurnByBlock(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	if burn == nil {
+		return new(big.Int), nil
+	}
+	return burn.Amount.ToInt(), nil
+}
@@ -9,6 +9,7 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 package repository
 
 import (
+	"context"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"sort"
@@ -36,19 +37,19 @@ func (s GovernanceProposalsByStart) Swap(i, j int) {
 }
 
 // GovernanceProposalsBy loads list of proposals of the given Governance contract.
-func (p *proxy) GovernanceProposalsBy(gov *common.Address) ([]*types.GovernanceProposal, error) {
-	return p.rpc.GovernanceProposalsBy(gov)
+func (p *proxy) GovernanceProposalsBy(ctx context.Context, gov *common.Address) ([]*types.GovernanceProposal, error) {
+	return p.rpc.GovernanceProposalsBy(ctx, gov)
 }
 
 // GovernanceProposals loads list of proposals from given set of Governance contracts.
-func (p *proxy) GovernanceProposals(govs []*common.Address, cursor *string, count int32, activeOnly bool) (*types.GovernanceProposalList, error) {
+func (p *proxy) GovernanceProposals(ctx context.Context, govs []*common.Address, cursor *string, count int32, activeOnly bool) (*types.GovernanceProposalList, error) {
 	// prep the container
 	var result []*types.GovernanceProposal
 
 	// loop all the governance contracts queried
 	for _, addr := range govs {
 		// get the list
-		gpl, err := p.rpc.GovernanceProposalsBy(addr)
+		gpl, err := p.rpc.GovernanceProposalsBy(ctx, addr)
 		if err != nil {
 			p.log.Errorf("list of proposals not available for %s; %s", addr.String(), err.Error())
 			return nil, err
@@ -9,6 +9,7 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 package repository
 
 import (
+	"context"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/types"
 	"fmt"
@@ -18,43 +19,43 @@ import (
 
 // GovernanceProposalsCount provides the total number of proposals
 // in a given Governance contract.
-func (p *proxy) GovernanceProposalsCount(address *common.Address) (hexutil.Big, error) {
-	return p.rpc.GovernanceProposalsCount(address)
+func (p *proxy) GovernanceProposalsCount(ctx context.Context, address *common.Address) (hexutil.Big, error) {
+	return p.rpc.GovernanceProposalsCount(ctx, address)
 }
 
 // GovernanceProposal provides a detail of Proposal of a governance contract
 // specified by its id.
-func (p *proxy) GovernanceProposal(gov *common.Address, id *hexutil.Big) (*types.GovernanceProposal, error) {
-	return p.rpc.GovernanceProposal(gov, id)
+func (p *proxy) GovernanceProposal(ctx context.Context, gov *common.Address, id *hexutil.Big) (*types.GovernanceProposal, error) {
+	return p.rpc.GovernanceProposal(ctx, gov, id)
 }
 
 // GovernanceProposalState provides a state of Proposal of a governance contract
 // specified by its id.
-func (p *proxy) GovernanceProposalState(gov *common.Address, id *hexutil.Big) (*types.GovernanceProposalState, error) {
-	return p.rpc.GovernanceProposalState(gov, id)
+func (p *proxy) GovernanceProposalState(ctx context.Context, gov *common.Address, id *hexutil.Big) (*types.GovernanceProposalState, error) {
+	return p.rpc.GovernanceProposalState(ctx, gov, id)
 }
 
 // GovernanceOptionState returns a state of the given option of a proposal.
-func (p *proxy) GovernanceOptionState(gov *common.Address, propId *hexutil.Big, optId *hexutil.Big) (*types.GovernanceOptionState, error) {
-	return p.rpc.GovernanceOptionState(gov, propId, optId)
+func (p *proxy) GovernanceOptionState(ctx context.Context, gov *common.Address, propId *hexutil.Big, optId *hexutil.Big) (*types.GovernanceOptionState, error) {
+	return p.rpc.GovernanceOptionState(ctx, gov, propId, optId)
 }
 
 // GovernanceOptionStates returns a list of states of options of a proposal.
-func (p *proxy) GovernanceOptionStates(gov *common.Address, propId *hexutil.Big, optRange int) ([]*types.GovernanceOptionState, error) {
-	return p.rpc.GovernanceOptionStates(gov, propId, optRange)
+func (p *proxy) GovernanceOptionStates(ctx context.Context, gov *common.Address, propId *hexutil.Big, optRange int) ([]*types.GovernanceOptionState, error) {
+	return p.rpc.GovernanceOptionStates(ctx, gov, propId, optRange)
 }
 
 // GovernanceVote provides a single vote in the Governance Proposal context.
-func (p *proxy) GovernanceVote(
+func (p *proxy) GovernanceVote(ctx context.Context,
 	gov *common.Address,
 	propId *hexutil.Big,
 	from *common.Address,
 	delegatedTo *common.Address) (*types.GovernanceVote, error) {
-	return p.rpc.GovernanceVote(gov, propId, from, delegatedTo)
+	return p.rpc.GovernanceVote(ctx, gov, propId, from, delegatedTo)
 }
 
 // GovernanceContractBy provides governance contract details by its address.
-func (p *proxy) GovernanceContractBy(addr *common.Address) (*config.GovernanceContract, error) {
+func (p *proxy) GovernanceContractBy(ctx context.Context, addr *common.Address) (*config.GovernanceContract, error) {
 	// try to pull the config from the map
 	if gc, ok := p.govContracts[addr.String()]; ok {
 		return gc, nil
@@ -66,13 +67,13 @@ func (p *proxy) GovernanceContractBy(addr *common.Address) (*config.GovernanceCo
 
 // GovernanceProposalFee returns the fee payable for a new proposal
 // in given Governance contract context.
-func (p *proxy) GovernanceProposalFee(gov *common.Address) (hexutil.Big, error) {
-	return p.rpc.GovernanceProposalFee(gov)
+func (p *proxy) GovernanceProposalFee(ctx context.Context, gov *common.Address) (hexutil.Big, error) {
+	return p.rpc.GovernanceProposalFee(ctx, gov)
 }
 
 // GovernanceTotalWeight provides the total weight of all available votes
 // in the governance contract identified by the address.
-func (p *proxy) GovernanceTotalWeight(gov *common.Address) (hexutil.Big, error) {
+func (p *proxy) GovernanceTotalWeight(ctx context.Context, gov *common.Address) (hexutil.Big, error) {
 	// try cache
 	we := p.cache.PullGovernanceTotalWeight(gov)
 	if we == nil {
@@ -84,7 +85,7 @@ func (p *proxy) GovernanceTotalWeight(gov *common.Address) (hexutil.Big, error)
 
 		// do it slow way
 		var err error
-		we, err = p.rpc.GovernanceTotalWeight(&cfg.Governable)
+		we, err = p.rpc.GovernanceTotalWeight(ctx, &cfg.Governable)
 		if err != nil {
 			p.log.Errorf("can not pull governance total weight for %s; %s", gov.String(), err.Error())
 			return hexutil.Big{}, err
@@ -98,3 +99,18 @@ func (p *proxy) GovernanceTotalWeight(gov *common.Address) (hexutil.Big, error)
 
 	return *we, nil
 }
+
+// StoreGovernanceVote stores a vote placed on a Governance contract.
+func (p *proxy) StoreGovernanceVote(ctx context.Context, gv *types.GovernanceVote) error {
+	return p.db.AddGovernanceVote(ctx, gv)
+}
+
+// RemoveGovernanceVote removes a canceled vote of the given voter and delegation.
+func (p *proxy) RemoveGovernanceVote(ctx context.Context, gov *common.Address, prop *hexutil.Big, from *common.Address, delegatedTo *common.Address) error {
+	return p.db.RemoveGovernanceVote(ctx, gov, prop, from, delegatedTo)
+}
+
+// GovernanceVotesOf provides a list of the most recent votes placed by the given account.
+func (p *proxy) GovernanceVotesOf(ctx context.Context, adr *common.Address, count int32) ([]*types.GovernanceVote, error) {
+	return p.db.GovernanceVotesOf(ctx, adr, count)
+}
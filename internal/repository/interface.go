@@ -9,27 +9,41 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 package repository
 
 import (
+	"context"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"fantom-api-graphql/internal/types"
 	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	etc "github.com/ethereum/go-ethereum/core/types"
 )
 
 // Repository interface defines functions the underlying implementation provides to API resolvers.
+// Functions reaching the node or the database take the context of the caller,
+// so a cancelled request stops the pending calls. Cache only functions do not.
 type Repository interface {
 	// Account returns account at Opera blockchain for an address, nil if not found.
-	Account(*common.Address) (*types.Account, error)
+	Account(context.Context, *common.Address) (*types.Account, error)
+
+	// Accounts returns the accounts at Opera blockchain for the given addresses loaded in a single batch.
+	Accounts(context.Context, []*common.Address) ([]*types.Account, error)
 
 	// AccountBalance returns the current balance of an account at Opera blockchain.
-	AccountBalance(*common.Address) (*hexutil.Big, error)
+	AccountBalance(context.Context, *common.Address) (*hexutil.Big, error)
+
+	// AccountBalances returns the current balances of the given accounts loaded in a single batch.
+	AccountBalances(context.Context, []*common.Address) ([]*hexutil.Big, error)
 
 	// AccountNonce returns the current number of sent transactions of an account at Opera blockchain.
-	AccountNonce(*common.Address) (*hexutil.Uint64, error)
+	AccountNonce(context.Context, *common.Address) (*hexutil.Uint64, error)
+
+	// AccountProof provides the Merkle proof of the account state and of the given storage slots
+	// of the account at the given block, or at the latest block if no block is given.
+	AccountProof(context.Context, *common.Address, []common.Hash, *hexutil.Uint64) (*types.AccountStateProof, error)
 
 	// AccountTransactions returns list of transaction hashes for account at Opera blockchain.
 	//
@@ -45,28 +59,67 @@ type Repository interface {
 	// of transactions newer than that.
 	//
 	// Transactions are always sorted from newer to older.
-	AccountTransactions(*common.Address, *common.Address, *string, int32) (*types.TransactionList, error)
+	// The optional filter limits the list to transactions matching its conditions.
+	AccountTransactions(context.Context, *common.Address, *common.Address, *string, int32, *types.TransactionFilter) (*types.TransactionList, error)
+
+	// AccountTransactionsExport passes all the transactions of the given account made in the given
+	// time range to the given callback in the chronological order.
+	AccountTransactionsExport(context.Context, *common.Address, time.Time, time.Time, func(*types.Transaction) error) error
 
 	// AccountsActive total number of accounts known to repository.
-	AccountsActive() (hexutil.Uint64, error)
+	AccountsActive(context.Context) (hexutil.Uint64, error)
 
 	// AccountIsKnown checks if the account of the given address is known to the API server.
-	AccountIsKnown(*common.Address) bool
+	AccountIsKnown(context.Context, *common.Address) bool
 
 	// StoreAccount adds specified account detail into the repository.
-	StoreAccount(*types.Account) error
+	StoreAccount(context.Context, *types.Account) error
 
 	// AccountMarkActivity marks the latest account activity in the repository.
-	AccountMarkActivity(*common.Address, uint64) error
+	AccountMarkActivity(context.Context, *common.Address, uint64) error
+
+	// AccountFirstAppearance provides the block number and the time stamp
+	// of the first indexed transaction of the account.
+	AccountFirstAppearance(context.Context, *types.Account) (uint64, uint64, error)
+
+	// AccountGasStats provides a summary of the gas used and the fees paid by the transactions
+	// sent from the account over the given number of the most recent days.
+	AccountGasStats(context.Context, *common.Address, int32) (*types.AccountGasStats, error)
+
+	// AccountUpdateBalance reconciles the known balance of the account with the blockchain.
+	AccountUpdateBalance(context.Context, *common.Address) error
+
+	// AccountsUpdateBalance reconciles the known balances of the given accounts with the blockchain.
+	AccountsUpdateBalance(context.Context, []*common.Address) error
+
+	// AccountsSeedBalance reads the balances of the given accounts not known yet from the blockchain.
+	AccountsSeedBalance(context.Context, []*common.Address) error
+
+	// AccountsAddBalance adds the balance changes of the given block to the known balances of the accounts.
+	AccountsAddBalance(context.Context, uint64, map[common.Address]*big.Int) error
+
+	// TopAccounts provides a list of accounts sorted by their known balance.
+	TopAccounts(ctx context.Context, cursor *string, count int32) (*types.AccountBalanceList, error)
 
 	// BlockHeight returns the current height of the Opera blockchain in blocks.
-	BlockHeight() (*hexutil.Big, error)
+	BlockHeight(context.Context) (*hexutil.Big, error)
 
 	// LastKnownBlock returns number of the last block known to the repository.
-	LastKnownBlock() (uint64, error)
+	LastKnownBlock(context.Context) (uint64, error)
 
 	// UpdateLastKnownBlock update record about last known block.
-	UpdateLastKnownBlock(blockNo *hexutil.Uint64) error
+	UpdateLastKnownBlock(ctx context.Context, blockNo *hexutil.Uint64) error
+
+	// TransactionsCountByBlock provides the number of stored transactions of blocks in the given range.
+	TransactionsCountByBlock(ctx context.Context, from uint64, to uint64) (map[uint64]int, error)
+
+	// RemoveBlocks removes blocks of the given range from the repository
+	// so they can be re-ingested after a chain reorganization.
+	RemoveBlocks(ctx context.Context, from uint64, to uint64) error
+
+	// ProcessedBlockHash provides the hash of the processed block of the given number;
+	// nil if the block is not known.
+	ProcessedBlockHash(ctx context.Context, number uint64) (*common.Hash, error)
 
 	// ObservedHeaders provides a channel fed with new headers observed
 	// by the connected blockchain node.
@@ -75,88 +128,200 @@ type Repository interface {
 	// BlockByNumber returns a block at Opera blockchain represented by a number.
 	// Top block is returned if the number is not provided.
 	// If the block is not found, ErrBlockNotFound error is returned.
-	BlockByNumber(*hexutil.Uint64) (*types.Block, error)
+	BlockByNumber(context.Context, *hexutil.Uint64) (*types.Block, error)
+
+	// BlocksByNumber returns a range of blocks at Opera blockchain loaded in a single batch.
+	// Blocks not available are returned as nil.
+	BlocksByNumber(ctx context.Context, from uint64, count int) ([]*types.Block, error)
+
+	// BlocksByNumbers returns the blocks at Opera blockchain of the given numbers loaded in a single batch.
+	// Blocks not available are returned as nil.
+	BlocksByNumbers(context.Context, []uint64) ([]*types.Block, error)
 
 	// BlockByHash returns a block at Opera blockchain represented by a hash.
 	// Top block is returned if the hash is not provided.
 	// If the block is not found, ErrBlockNotFound error is returned.
-	BlockByHash(*common.Hash) (*types.Block, error)
+	BlockByHash(context.Context, *common.Hash) (*types.Block, error)
 
 	// Blocks pulls list of blocks starting on the specified block number
 	// and going up, or down based on count number.
-	Blocks(*uint64, int32) (*types.BlockList, error)
+	Blocks(context.Context, *uint64, int32) (*types.BlockList, error)
+
+	// BlockNumberAtTime finds the number of the first block created at, or after the given UNIX time.
+	BlockNumberAtTime(context.Context, uint64) (uint64, error)
+
+	// BlocksByTimeRange provides a list of blocks created in the given UNIX time range
+	// along with the total number of blocks in the range.
+	BlocksByTimeRange(ctx context.Context, from uint64, to uint64, num *uint64, count int32) (*types.BlockList, uint64, error)
 
 	// CacheBlock puts a block to the internal block ring cache.
 	CacheBlock(blk *types.Block)
 
+	// NetworkLoad provides the recent load of the network calculated
+	// over the ring of the most recent blocks.
+	NetworkLoad(context.Context) (*types.NetworkLoad, error)
+
+	// StoreBlockTime stores the timing of a processed block.
+	StoreBlockTime(context.Context, *types.BlockTime) error
+
+	// BlockTimeStats provides statistics of the block time and the observed time to finality
+	// over the given number of the most recent blocks.
+	BlockTimeStats(ctx context.Context, window int32) (*types.BlockTimeStats, error)
+
+	// BlockTimeDaily provides the list of daily block time aggregations.
+	BlockTimeDaily(ctx context.Context, from *time.Time, to *time.Time) ([]*types.DailyBlockTime, error)
+
+	// BlockTimeDailyUpdate executes the daily block time aggregation update in the database.
+	BlockTimeDailyUpdate(context.Context)
+
+	// ContractUsage provides the list of daily usage aggregations of the given contract.
+	ContractUsage(ctx context.Context, adr *common.Address, from *time.Time, to *time.Time) ([]*types.ContractDailyUsage, error)
+
+	// TopContractsByGas provides the given number of the contracts with the highest gas used
+	// by their calls over the recent days.
+	TopContractsByGas(ctx context.Context, count int32) ([]*types.ContractGasRank, error)
+
+	// ContractUsageUpdate executes the daily contract usage aggregation update
+	// and recalculates the ranking of the contracts by the gas used.
+	ContractUsageUpdate(context.Context)
+
+	// StoreFtmBurn stores the FTM burn record of a block.
+	StoreFtmBurn(context.Context, *types.FtmBurn) error
+
+	// FtmBurnedTotal provides the total amount of native FTM tokens burned.
+	FtmBurnedTotal(context.Context) (*big.Int, error)
+
+	// FtmBurnList provides a list of FTM burn records from the most recent block.
+	// The cursor is the number of the block the list continues after.
+	FtmBurnList(ctx context.Context, cursor *string, count int32) (*types.FtmBurnList, error)
+
+	// FtmBurnOfBlock provides the amount of native FTM tokens burned by the given block.
+	FtmBurnOfBlock(ctx context.Context, block uint64) (*big.Int, error)
+
 	// Contract extract a smart contract information by address if available.
-	Contract(*common.Address) (*types.Contract, error)
+	Contract(context.Context, *common.Address) (*types.Contract, error)
 
 	// Contracts returns list of smart contracts at Opera blockchain.
-	Contracts(bool, *string, int32) (*types.ContractList, error)
+	Contracts(context.Context, bool, *string, int32) (*types.ContractList, error)
 
 	// ValidateContract tries to validate contract byte code using
 	// provided source code. If successful, the contract information
 	// is updated the the repository.
-	ValidateContract(*types.Contract) error
+	ValidateContract(context.Context, *types.Contract) error
+
+	// ContractByteCode provides the runtime byte code deployed on the given contract address.
+	ContractByteCode(context.Context, *common.Address) (hexutil.Bytes, error)
+
+	// ContractStorageAt provides the value of the given storage slot of the contract
+	// at the given block, or at the latest block if no block is given.
+	ContractStorageAt(context.Context, *common.Address, common.Hash, *hexutil.Uint64) (common.Hash, error)
+
+	// ContractStorage provides the state variables of the contract with the values decoded
+	// from the contract storage at the given block, or at the latest block if no block is given.
+	ContractStorage(context.Context, *types.Contract, *hexutil.Uint64) ([]types.ContractStorageValue, error)
+
+	// ContractAbi provides the parsed ABI of the contract at the given address, if available.
+	ContractAbi(context.Context, *common.Address) (*abi.ABI, error)
+
+	// TransactionInputDecoded decodes the input data of the given transaction
+	// using the ABI of the recipient contract, if available.
+	TransactionInputDecoded(context.Context, *types.Transaction) (*types.DecodedCall, error)
+
+	// DecodeLog decodes the given event log record using the ABI
+	// of the emitting contract, if available.
+	DecodeLog(context.Context, *etc.Log) (*types.DecodedEvent, error)
+
+	// ContractCall executes a read-only call of the given contract with the raw call data.
+	ContractCall(context.Context, *common.Address, hexutil.Bytes, *hexutil.Uint64) (hexutil.Bytes, error)
+
+	// ContractCallAbi executes a read-only call of the named function of the given contract
+	// using the stored contract ABI to encode the JSON arguments and decode the output.
+	ContractCallAbi(context.Context, *common.Address, string, *string, *hexutil.Uint64) ([]types.DecodedArgument, error)
+
+	// VerifySignature checks if the given signature of the message has been made by the given address.
+	// The message is either a text signed by EIP-191 personal_sign, or a JSON of the EIP-712 typed data.
+	VerifySignature(context.Context, *common.Address, string, []byte, bool) (bool, error)
 
 	// StoreContract updates the contract in repository.
-	StoreContract(*types.Contract) error
+	StoreContract(context.Context, *types.Contract) error
 
 	// SfcVersion returns current version of the SFC contract.
-	SfcVersion() (hexutil.Uint64, error)
+	SfcVersion(context.Context) (hexutil.Uint64, error)
 
 	// SfcDecimalUnit returns the decimal unit adjustment used by the SFC contract.
 	SfcDecimalUnit() *big.Int
 
 	// CurrentEpoch returns the id of the current epoch.
-	CurrentEpoch() (hexutil.Uint64, error)
+	CurrentEpoch(context.Context) (hexutil.Uint64, error)
 
 	// LastKnownEpoch returns the id of the last known and scanned epoch.
-	LastKnownEpoch() (uint64, error)
+	LastKnownEpoch(context.Context) (uint64, error)
+
+	// EpochIdAt returns the id of the epoch active at the given time stamp.
+	EpochIdAt(ctx context.Context, ts hexutil.Uint64) (hexutil.Uint64, error)
 
 	// AddEpoch stores an epoch reference in connected persistent storage.
-	AddEpoch(e *types.Epoch) error
+	AddEpoch(ctx context.Context, e *types.Epoch) error
 
 	// Epoch returns the id of the current epoch.
-	Epoch(*hexutil.Uint64) (*types.Epoch, error)
+	Epoch(context.Context, *hexutil.Uint64) (*types.Epoch, error)
+
+	// EpochValidators returns the list of validators of the given sealed epoch
+	// with their received stake and rewards.
+	EpochValidators(ctx context.Context, id hexutil.Uint64) ([]types.EpochValidator, error)
+
+	// StoreEpochValidators collects the snapshot of validators at the end
+	// of the given sealed epoch and stores it in the persistent storage.
+	StoreEpochValidators(context.Context, hexutil.Uint64) error
 
 	// CurrentSealedEpoch returns the data of the latest sealed epoch.
-	CurrentSealedEpoch() (*types.Epoch, error)
+	CurrentSealedEpoch(context.Context) (*types.Epoch, error)
 
 	// Epochs pulls list of epochs starting at the specified cursor.
-	Epochs(cursor *string, count int32) (*types.EpochList, error)
+	Epochs(ctx context.Context, cursor *string, count int32) (*types.EpochList, error)
 
 	// TotalStaked calculates current total staked amount for all stakers.
-	TotalStaked() (*hexutil.Big, error)
+	TotalStaked(context.Context) (*hexutil.Big, error)
 
 	// RewardsAllowed returns the reward lock status from SFC.
-	RewardsAllowed() (bool, error)
+	RewardsAllowed(context.Context) (bool, error)
 
 	// LockingAllowed indicates if the stake locking has been enabled in SFC.
-	LockingAllowed() (bool, error)
+	LockingAllowed(context.Context) (bool, error)
 
 	// IsSfcContract returns true if the given address points to the SFC contract.
-	IsSfcContract(*common.Address) bool
+	IsSfcContract(context.Context, *common.Address) bool
 
 	// IsStiContract returns true if the given address points to the STI contract.
-	IsStiContract(*common.Address) bool
+	IsStiContract(context.Context, *common.Address) bool
 
 	// StoreTransaction adds a new incoming transaction from blockchain to the repository.
-	StoreTransaction(*types.Block, *types.Transaction) error
+	StoreTransaction(context.Context, *types.Block, *types.Transaction) error
 
 	// LoadTransaction returns a transaction at Opera blockchain
 	// by a hash loaded directly from the node.
-	LoadTransaction(hash *common.Hash) (*types.Transaction, error)
+	LoadTransaction(ctx context.Context, hash *common.Hash) (*types.Transaction, error)
 
 	// Transaction returns a transaction at Opera blockchain by a hash, nil if not found.
-	Transaction(*common.Hash) (*types.Transaction, error)
+	Transaction(context.Context, *common.Hash) (*types.Transaction, error)
+
+	// TransactionRevertReason provides the reason of failure of the given failed transaction.
+	TransactionRevertReason(context.Context, *types.Transaction) (string, error)
+
+	// TransactionsByHash returns the given transactions at Opera blockchain loaded in a single batch.
+	// Transactions not available are returned as nil.
+	TransactionsByHash(context.Context, []*common.Hash) ([]*types.Transaction, error)
 
 	// Transactions returns list of transaction hashes at Opera blockchain.
-	Transactions(*string, int32) (*types.TransactionList, error)
+	// The optional filter limits the list to transactions matching its conditions.
+	Transactions(context.Context, *string, int32, *types.TransactionFilter) (*types.TransactionList, error)
 
 	// TransactionsCount returns total number of transactions in the block chain.
-	TransactionsCount() (uint64, error)
+	TransactionsCount(context.Context) (uint64, error)
+
+	// TransactionsTotal provides the exact number of the indexed transactions
+	// maintained as a counter by the scanner.
+	TransactionsTotal(context.Context) (uint64, error)
 
 	// EstimateTransactionsCount returns an approximate amount of transactions on the network.
 	EstimateTransactionsCount() (hexutil.Uint64, error)
@@ -167,137 +332,205 @@ type Repository interface {
 	// UpdateTrxCountEstimate updates the value of transaction counter estimator.
 	UpdateTrxCountEstimate(val uint64)
 
+	// TransactionLogs provides the list of event log records emitted by the transaction.
+	TransactionLogs(context.Context, *common.Hash) ([]etc.Log, error)
+
+	// InternalTransactions provides the list of calls executed inside the given transaction.
+	InternalTransactions(context.Context, *common.Hash) ([]*types.InternalTransaction, error)
+
+	// TraceInternalTransactions traces the given transaction on the node
+	// and stores the internal transactions found.
+	TraceInternalTransactions(context.Context, *common.Hash) ([]*types.InternalTransaction, error)
+
+	// InternalTransactionsOfAccount provides the list of the most recent known
+	// internal transactions the given account is involved with.
+	InternalTransactionsOfAccount(context.Context, *common.Address, int32) ([]*types.InternalTransaction, error)
+
 	// CacheTransaction puts a transaction to the internal ring cache.
 	CacheTransaction(trx *types.Transaction)
 
 	// SendTransaction sends raw signed and RLP encoded transaction to the block chain.
-	SendTransaction(hexutil.Bytes) (*types.Transaction, error)
+	SendTransaction(context.Context, hexutil.Bytes) (*types.Transaction, error)
+
+	// ObservedPendingTransactions provides a channel fed with hashes of new pending transactions
+	// observed by the connected blockchain node.
+	ObservedPendingTransactions() chan common.Hash
+
+	// PendingTransactionsChanged signals the content of the node transaction pool changed.
+	PendingTransactionsChanged()
+
+	// PendingTransactions provides a list of transactions pending in the node transaction pool.
+	PendingTransactions(context.Context, int32) ([]*types.Transaction, error)
+
+	// PendingTransactionsOf provides a list of pending transactions sent by the given account.
+	PendingTransactionsOf(context.Context, *common.Address) ([]*types.Transaction, error)
 
 	// LastValidatorId returns the last validator id in Opera blockchain.
-	LastValidatorId() (uint64, error)
+	LastValidatorId(context.Context) (uint64, error)
 
 	// ValidatorsCount returns the number of stakers in Opera blockchain.
-	ValidatorsCount() (uint64, error)
+	ValidatorsCount(context.Context) (uint64, error)
 
 	// IsValidator returns TRUE if the given address is an SFC staker.
-	IsValidator(*common.Address) (bool, error)
+	IsValidator(context.Context, *common.Address) (bool, error)
 
 	// ValidatorAddress extract a staker address for the given staker ID.
-	ValidatorAddress(*hexutil.Big) (*common.Address, error)
+	ValidatorAddress(context.Context, *hexutil.Big) (*common.Address, error)
 
 	// Validator extract a staker information from SFC smart contract.
-	Validator(*hexutil.Big) (*types.Validator, error)
+	Validator(context.Context, *hexutil.Big) (*types.Validator, error)
 
 	// ValidatorByAddress extract a staker information by address.
-	ValidatorByAddress(*common.Address) (*types.Validator, error)
+	ValidatorByAddress(context.Context, *common.Address) (*types.Validator, error)
 
 	// ValidatorDowntime pulls information about validator downtime from the RPC interface.
-	ValidatorDowntime(*hexutil.Big) (uint64, uint64, error)
+	ValidatorDowntime(context.Context, *hexutil.Big) (uint64, uint64, error)
+
+	// StoreEpochValidatorsPerformance collects the performance of validators
+	// within the given sealed epoch and stores it in the persistent storage.
+	StoreEpochValidatorsPerformance(context.Context, hexutil.Uint64) error
+
+	// ValidatorPerformance provides the performance of the given validator
+	// aggregated over the given number of the most recent sealed epochs.
+	ValidatorPerformance(context.Context, *hexutil.Big, int32) (*types.ValidatorPerformance, error)
+
+	// ValidatorPerformanceList provides the performance of all the validators
+	// aggregated over the given number of the most recent sealed epochs.
+	ValidatorPerformanceList(context.Context, int32) ([]*types.ValidatorPerformance, error)
 
 	// SfcConfiguration provides SFC contract configuration.
-	SfcConfiguration() (*types.SfcConfig, error)
+	SfcConfiguration(context.Context) (*types.SfcConfig, error)
+
+	// SfcValidatorCommission provides the share of delegators rewards paid to the validator;
+	// the share is provided with 18 decimals.
+	SfcValidatorCommission(context.Context) (*big.Int, error)
 
 	// SfcMaxDelegatedRatio extracts a ratio between self delegation and received stake.
-	SfcMaxDelegatedRatio() (*big.Int, error)
+	SfcMaxDelegatedRatio(context.Context) (*big.Int, error)
 
 	// PullStakerInfo extracts an extended staker information from smart contact.
-	PullStakerInfo(*hexutil.Big) (*types.StakerInfo, error)
+	PullStakerInfo(context.Context, *hexutil.Big) (*types.StakerInfo, error)
 
 	// StoreStakerInfo stores staker information to in-memory cache for future use.
-	StoreStakerInfo(*hexutil.Big, *types.StakerInfo) error
+	StoreStakerInfo(context.Context, *hexutil.Big, *types.StakerInfo) error
 
 	// RetrieveStakerInfo gets staker information from in-memory if available.
-	RetrieveStakerInfo(*hexutil.Big) *types.StakerInfo
+	RetrieveStakerInfo(context.Context, *hexutil.Big) *types.StakerInfo
 
 	// IsDelegating returns if the given address is an SFC delegator.
-	IsDelegating(*common.Address) (bool, error)
+	IsDelegating(context.Context, *common.Address) (bool, error)
 
 	// StoreDelegation stores a delegation in the persistent repository.
-	StoreDelegation(*types.Delegation) error
+	StoreDelegation(context.Context, *types.Delegation) error
 
 	// UpdateDelegationBalance updates active balance of the given delegation.
-	UpdateDelegationBalance(*common.Address, *hexutil.Big, func(*big.Int) error) error
+	UpdateDelegationBalance(context.Context, *common.Address, *hexutil.Big, func(*big.Int) error) error
 
 	// Delegation returns a detail of delegation for the given address and validator ID.
-	Delegation(*common.Address, *hexutil.Big) (*types.Delegation, error)
+	Delegation(context.Context, *common.Address, *hexutil.Big) (*types.Delegation, error)
 
 	// DelegationAmountStaked returns the current amount of staked tokens
 	// for the given delegation.
-	DelegationAmountStaked(*common.Address, *hexutil.Big) (*big.Int, error)
+	DelegationAmountStaked(context.Context, *common.Address, *hexutil.Big) (*big.Int, error)
 
 	// DelegationsByAddress returns a list of all delegations of a given delegator address.
-	DelegationsByAddress(*common.Address, *string, int32) (*types.DelegationList, error)
+	DelegationsByAddress(context.Context, *common.Address, *string, int32) (*types.DelegationList, error)
 
 	// DelegationsByAddressAll returns a list of all delegations of the given address un-paged.
-	DelegationsByAddressAll(addr *common.Address) ([]*types.Delegation, error)
+	DelegationsByAddressAll(ctx context.Context, addr *common.Address) ([]*types.Delegation, error)
 
 	// DelegationsOfValidator extracts a list of delegations for a validator by its ID.
-	DelegationsOfValidator(*hexutil.Big, *string, int32) (*types.DelegationList, error)
+	DelegationsOfValidator(context.Context, *hexutil.Big, *string, int32) (*types.DelegationList, error)
 
 	// DelegationLock returns delegation lock information using SFC contract binding.
-	DelegationLock(*common.Address, *hexutil.Big) (*types.DelegationLock, error)
+	DelegationLock(context.Context, *common.Address, *hexutil.Big) (*types.DelegationLock, error)
+
+	// StoreDelegationLockEvent stores a delegation lock event in the persistent storage.
+	StoreDelegationLockEvent(context.Context, *types.DelegationLockEvent) error
+
+	// DelegationLockHistory provides the given number of the most recent lock events of the delegation.
+	DelegationLockHistory(context.Context, *common.Address, *hexutil.Big, int32) ([]*types.DelegationLockEvent, error)
+
+	// DelegationLockRewardRatio calculates the share of full rewards paid to stake locked
+	// for the given duration in seconds; the ratio is provided with 18 decimals.
+	DelegationLockRewardRatio(context.Context, hexutil.Uint64) (*big.Int, error)
 
 	// DelegationUnlockPenalty returns the amount of penalty applied on given stake unlock.
-	DelegationUnlockPenalty(addr *common.Address, valID *big.Int, amount *big.Int) (hexutil.Big, error)
+	DelegationUnlockPenalty(ctx context.Context, addr *common.Address, valID *big.Int, amount *big.Int) (hexutil.Big, error)
 
 	// DelegationAmountUnlocked returns delegation lock information using SFC contract binding.
-	DelegationAmountUnlocked(addr *common.Address, valID *big.Int) (hexutil.Big, error)
+	DelegationAmountUnlocked(ctx context.Context, addr *common.Address, valID *big.Int) (hexutil.Big, error)
+
+	// SfcDelegateCall prepares an unsigned SFC call delegating the given amount to the validator.
+	SfcDelegateCall(ctx context.Context, from *common.Address, valID *hexutil.Big, amount *hexutil.Big) (*types.UnsignedTransaction, error)
+
+	// SfcClaimRewardsCall prepares an unsigned SFC call claiming pending rewards of the delegation.
+	SfcClaimRewardsCall(ctx context.Context, from *common.Address, valID *hexutil.Big, restake bool) (*types.UnsignedTransaction, error)
+
+	// SfcUndelegateCall prepares an unsigned SFC call un-delegating the given amount from the validator.
+	SfcUndelegateCall(ctx context.Context, from *common.Address, valID *hexutil.Big, wrID *hexutil.Big, amount *hexutil.Big) (*types.UnsignedTransaction, error)
 
 	// PendingRewards returns a detail of pending rewards for the given delegation.
-	PendingRewards(*common.Address, *hexutil.Big) (*types.PendingRewards, error)
+	PendingRewards(context.Context, *common.Address, *hexutil.Big) (*types.PendingRewards, error)
 
 	// DelegationOutstandingSFTM returns the amount of sFTM tokens for the delegation
 	// identified by the delegator address and the staker id.
-	DelegationOutstandingSFTM(*common.Address, *hexutil.Big) (*hexutil.Big, error)
+	DelegationOutstandingSFTM(context.Context, *common.Address, *hexutil.Big) (*hexutil.Big, error)
 
 	// DelegationTokenizerUnlocked returns the status of SFC Tokenizer lock
 	// for a delegation identified by the address and staker id.
-	DelegationTokenizerUnlocked(*common.Address, *hexutil.Big) (bool, error)
+	DelegationTokenizerUnlocked(context.Context, *common.Address, *hexutil.Big) (bool, error)
 
 	// DelegationFluidStakingActive signals if the delegation is upgraded to Fluid Staking model.
-	DelegationFluidStakingActive(*common.Address, *hexutil.Big) (bool, error)
+	DelegationFluidStakingActive(context.Context, *common.Address, *hexutil.Big) (bool, error)
 
 	// StoreWithdrawRequest stores the given withdraw request in persistent storage.
-	StoreWithdrawRequest(*types.WithdrawRequest) error
+	StoreWithdrawRequest(context.Context, *types.WithdrawRequest) error
 
 	// UpdateWithdrawRequest stores the updated withdraw request in persistent storage.
-	UpdateWithdrawRequest(*types.WithdrawRequest) error
+	UpdateWithdrawRequest(context.Context, *types.WithdrawRequest) error
 
 	// WithdrawRequest extracts details of a withdraw request specified by the delegator, validator and request ID.
-	WithdrawRequest(*common.Address, *hexutil.Big, *hexutil.Big) (*types.WithdrawRequest, error)
+	WithdrawRequest(context.Context, *common.Address, *hexutil.Big, *hexutil.Big) (*types.WithdrawRequest, error)
 
 	// WithdrawRequests extracts a list of withdraw requests for the given address and validator.
-	WithdrawRequests(*common.Address, *hexutil.Big, *string, int32) (*types.WithdrawRequestList, error)
+	WithdrawRequests(context.Context, *common.Address, *hexutil.Big, *string, int32) (*types.WithdrawRequestList, error)
 
 	// WithdrawRequestsPendingTotal is the total value of all pending withdrawal requests
 	// for the given delegator and target staker ID.
-	WithdrawRequestsPendingTotal(*common.Address, *hexutil.Big) (*big.Int, error)
+	WithdrawRequestsPendingTotal(context.Context, *common.Address, *hexutil.Big) (*big.Int, error)
 
 	// StoreRewardClaim stores reward claim record in the persistent repository.
-	StoreRewardClaim(*types.RewardClaim) error
+	StoreRewardClaim(context.Context, *types.RewardClaim) error
 
 	// RewardsClaimed returns the sum of all the claimed rewards
 	// for the given delegator address and validator ID.
-	RewardsClaimed(adr *common.Address, valId *big.Int, since *int64, until *int64) (*big.Int, error)
+	RewardsClaimed(ctx context.Context, adr *common.Address, valId *big.Int, since *int64, until *int64) (*big.Int, error)
 
 	// RewardClaims provides list of reward claims for the given criteria.
-	RewardClaims(*common.Address, *big.Int, *string, int32) (*types.RewardClaimsList, error)
+	RewardClaims(context.Context, *common.Address, *big.Int, *string, int32) (*types.RewardClaimsList, error)
 
 	// Price returns a price information for the given target symbol.
-	Price(sym string) (types.Price, error)
+	Price(ctx context.Context, sym string) (types.Price, error)
 
 	// GasPrice provides the raw suggested value for the gas price.
-	GasPrice() (hexutil.Big, error)
+	GasPrice(context.Context) (hexutil.Big, error)
 
 	// GasPriceExtended provides extended gas price information.
-	GasPriceExtended() (*types.GasPrice, error)
+	GasPriceExtended(context.Context) (*types.GasPrice, error)
+
+	// GasPriceSuggestion provides the gas price suggestion calculated from recent transactions.
+	GasPriceSuggestion(context.Context) (*types.GasPriceSuggestion, error)
+
+	// StoreGasPriceSuggestion keeps the calculated gas price suggestion for future use.
+	StoreGasPriceSuggestion(context.Context, *types.GasPriceSuggestion) error
 
 	// StoreGasPricePeriod stores gas price period data into the persistent storage.
-	StoreGasPricePeriod(*types.GasPricePeriod) error
+	StoreGasPricePeriod(context.Context, *types.GasPricePeriod) error
 
 	// GasEstimate calculates the estimated amount of Gas required to perform
 	// transaction described by the input params.
-	GasEstimate(*struct {
+	GasEstimate(context.Context, *struct {
 		From  *common.Address
 		To    *common.Address
 		Value *hexutil.Big
@@ -305,281 +538,414 @@ type Repository interface {
 	}) (*hexutil.Uint64, error)
 
 	// DefiConfiguration loads the current DeFi contract settings.
-	DefiConfiguration() (*types.DefiSettings, error)
+	DefiConfiguration(context.Context) (*types.DefiSettings, error)
 
 	// DefiTokens resolves list of DeFi tokens available for the DeFi functions.
-	DefiTokens() ([]types.DefiToken, error)
+	DefiTokens(context.Context) ([]types.DefiToken, error)
 
 	// DefiToken loads details of a single DeFi token by it's address.
-	DefiToken(*common.Address) (*types.DefiToken, error)
+	DefiToken(context.Context, *common.Address) (*types.DefiToken, error)
 
 	// DefiTokenPrice loads the current price of the given token
 	// from on-chain price oracle.
-	DefiTokenPrice(*common.Address) (hexutil.Big, error)
+	DefiTokenPrice(context.Context, *common.Address) (hexutil.Big, error)
 
 	// FMintAccount loads details of a DeFi/fMint account identified by the owner address.
-	FMintAccount(common.Address) (*types.FMintAccount, error)
+	FMintAccount(context.Context, common.Address) (*types.FMintAccount, error)
 
 	// FMintTokenBalance loads balance of a single DeFi token by it's address.
-	FMintTokenBalance(*common.Address, *common.Address, types.DefiTokenType) (hexutil.Big, error)
+	FMintTokenBalance(context.Context, *common.Address, *common.Address, types.DefiTokenType) (hexutil.Big, error)
 
 	// FMintTokenTotalBalance loads total balance of a single DeFi token by it's address.
-	FMintTokenTotalBalance(*common.Address, types.DefiTokenType) (hexutil.Big, error)
+	FMintTokenTotalBalance(context.Context, *common.Address, types.DefiTokenType) (hexutil.Big, error)
 
 	// FMintTokenValue loads value of a single DeFi token by it's address in fUSD.
-	FMintTokenValue(*common.Address, *common.Address, types.DefiTokenType) (hexutil.Big, error)
+	FMintTokenValue(context.Context, *common.Address, *common.Address, types.DefiTokenType) (hexutil.Big, error)
+
+	// FMintLiquidationPrice calculates the price of the given collateral token on which
+	// the account collateral to debt ratio drops below the lowest allowed ratio.
+	FMintLiquidationPrice(ctx context.Context, owner *common.Address, token *common.Address) (*hexutil.Big, error)
 
 	// FMintRewardsEarned resolves the total amount of rewards
 	// accumulated on the account for the excessive collateral deposits.
-	FMintRewardsEarned(*common.Address) (hexutil.Big, error)
+	FMintRewardsEarned(context.Context, *common.Address) (hexutil.Big, error)
 
 	// FMintRewardsStashed represents the total amount of rewards
 	// accumulated on the account in stash.
-	FMintRewardsStashed(*common.Address) (hexutil.Big, error)
+	FMintRewardsStashed(context.Context, *common.Address) (hexutil.Big, error)
 
 	// FMintCanClaimRewards resolves the fMint account flag for being allowed
 	// to claim earned rewards.
-	FMintCanClaimRewards(*common.Address) (bool, error)
+	FMintCanClaimRewards(context.Context, *common.Address) (bool, error)
 
 	// FMintCanReceiveRewards resolves the fMint account flag for being eligible
 	// to receive earned rewards. If the collateral to debt ration drop below
 	// certain value, earned rewards are burned.
-	FMintCanReceiveRewards(*common.Address) (bool, error)
+	FMintCanReceiveRewards(context.Context, *common.Address) (bool, error)
 
 	// FMintCanPushRewards signals if there are any rewards unlocked
 	// on the rewards' distribution contract and can be pushed to account.
-	FMintCanPushRewards() (bool, error)
+	FMintCanPushRewards(context.Context) (bool, error)
 
 	// FMintUsers loads the list of fMint users and their associated tokens used for a specified purpose.
-	FMintUsers(int32) ([]*types.FMintUserTokens, error)
+	FMintUsers(context.Context, int32) ([]*types.FMintUserTokens, error)
 
 	// AddFMintTransaction adds the specified fMint transaction to persistent storage.
-	AddFMintTransaction(*types.FMintTransaction) error
+	AddFMintTransaction(context.Context, *types.FMintTransaction) error
 
 	// UniswapPairs returns list of all token pairs managed by Uniswap core.
-	UniswapPairs() ([]common.Address, error)
+	UniswapPairs(context.Context) ([]common.Address, error)
+
+	// UniswapPairCreated registers a new pair created by the Uniswap core.
+	UniswapPairCreated(ctx context.Context, pair *common.Address)
 
 	// UniswapKnownPairs returns list of all known and whitelisted token pairs managed by Uniswap core.
-	UniswapKnownPairs() ([]common.Address, error)
+	UniswapKnownPairs(context.Context) ([]common.Address, error)
 
 	// UniswapPair returns an address of an Uniswap pair for the given tokens.
-	UniswapPair(*common.Address, *common.Address) (*common.Address, error)
+	UniswapPair(context.Context, *common.Address, *common.Address) (*common.Address, error)
 
 	// UniswapAmountsOut resolves a list of output amounts for the given
 	// input amount and a list of tokens to be used to make the swap operation.
-	UniswapAmountsOut(amountIn hexutil.Big, tokens []common.Address) ([]hexutil.Big, error)
+	UniswapAmountsOut(ctx context.Context, amountIn hexutil.Big, tokens []common.Address) ([]hexutil.Big, error)
 
 	// UniswapAmountsIn resolves a list of input amounts for the given
 	// output amount and a list of tokens to be used to make the swap operation.
-	UniswapAmountsIn(amountOut hexutil.Big, tokens []common.Address) ([]hexutil.Big, error)
+	UniswapAmountsIn(ctx context.Context, amountOut hexutil.Big, tokens []common.Address) ([]hexutil.Big, error)
 
 	// UniswapQuoteInput calculates optimal input on sibling token based on input amount and
 	// self reserves of the analyzed token.
-	UniswapQuoteInput(amountIn hexutil.Big, reserveMy hexutil.Big, reserveSibling hexutil.Big) (hexutil.Big, error)
+	UniswapQuoteInput(ctx context.Context, amountIn hexutil.Big, reserveMy hexutil.Big, reserveSibling hexutil.Big) (hexutil.Big, error)
 
 	// UniswapTokens returns list of addresses of tokens involved in an Uniswap pair.
-	UniswapTokens(*common.Address) ([]common.Address, error)
+	UniswapTokens(context.Context, *common.Address) ([]common.Address, error)
 
 	// UniswapReserves returns list of token reserve amounts in a Uniswap pair.
-	UniswapReserves(*common.Address) ([]hexutil.Big, error)
+	UniswapReserves(context.Context, *common.Address) ([]hexutil.Big, error)
 
 	// UniswapReservesTimeStamp returns the timestamp of the reserves of a Uniswap pair.
-	UniswapReservesTimeStamp(*common.Address) (hexutil.Uint64, error)
+	UniswapReservesTimeStamp(context.Context, *common.Address) (hexutil.Uint64, error)
 
 	// UniswapCumulativePrices returns list of token cumulative prices of a Uniswap pair.
-	UniswapCumulativePrices(*common.Address) ([]hexutil.Big, error)
+	UniswapCumulativePrices(context.Context, *common.Address) ([]hexutil.Big, error)
 
 	// UniswapLastKValue returns the last value of the pool control coefficient.
-	UniswapLastKValue(*common.Address) (hexutil.Big, error)
+	UniswapLastKValue(context.Context, *common.Address) (hexutil.Big, error)
 
 	// UniswapPairContract returns instance of this contract according to given pair address
-	UniswapPairContract(*common.Address) (*contracts.UniswapPair, error)
+	UniswapPairContract(context.Context, *common.Address) (*contracts.UniswapPair, error)
 
 	// UniswapAdd adds a new incoming swap from blockchain to the repository.
-	UniswapAdd(*types.Swap) error
+	UniswapAdd(context.Context, *types.Swap) error
 
 	// LastKnownSwapBlock returns number of the last block known to the repository with swap event.
-	LastKnownSwapBlock() (uint64, error)
+	LastKnownSwapBlock(context.Context) (uint64, error)
 
 	// UniswapUpdateLastKnownSwapBlock stores a last correctly saved swap block number into persistent storage.
-	UniswapUpdateLastKnownSwapBlock(blkNumber uint64) error
+	UniswapUpdateLastKnownSwapBlock(ctx context.Context, blkNumber uint64) error
 
 	// UniswapFactoryContract returns an instance of an Uniswap factory
-	UniswapFactoryContract() (*contracts.UniswapFactory, error)
+	UniswapFactoryContract(context.Context) (*contracts.UniswapFactory, error)
 
 	// UniswapVolume returns swap volume for specified uniswap pair
-	UniswapVolume(*common.Address, int64, int64) (types.DefiSwapVolume, error)
+	UniswapVolume(context.Context, *common.Address, int64, int64) (types.DefiSwapVolume, error)
 
 	// UniswapTimeVolumes returns grouped volumes for specified pair, time and resolution
-	UniswapTimeVolumes(*common.Address, string, int64, int64) ([]types.DefiSwapVolume, error)
+	UniswapTimeVolumes(context.Context, *common.Address, string, int64, int64) ([]types.DefiSwapVolume, error)
 
 	// UniswapTimePrices returns grouped prices for specified pair, time and resolution
-	UniswapTimePrices(*common.Address, string, int64, int64, int32) ([]types.DefiTimePrice, error)
+	UniswapTimePrices(context.Context, *common.Address, string, int64, int64, int32) ([]types.DefiTimePrice, error)
 
 	// UniswapTimeReserves returns grouped reserves for specified pair, time and resolution
-	UniswapTimeReserves(*common.Address, string, int64, int64) ([]types.DefiTimeReserve, error)
+	UniswapTimeReserves(context.Context, *common.Address, string, int64, int64) ([]types.DefiTimeReserve, error)
 
 	// UniswapActions provides list of uniswap actions stored in the persistent db.
-	UniswapActions(*common.Address, *string, int32, int32) (*types.UniswapActionList, error)
+	UniswapActions(context.Context, *common.Address, *string, int32, int32) (*types.UniswapActionList, error)
 
 	// NativeTokenAddress returns address of the native token wrapper, if available.
-	NativeTokenAddress() (*common.Address, error)
+	NativeTokenAddress(context.Context) (*common.Address, error)
 
 	// TokenTransactions provides list of ERC20/ERC721/ERC1155 transactions based on given filters.
-	TokenTransactions(tokenType string, token *common.Address, tokenId *big.Int, acc *common.Address, txType *int32, cursor *string, count int32) (*types.TokenTransactionList, error)
+	TokenTransactions(ctx context.Context, tokenType string, token *common.Address, tokenId *big.Int, acc *common.Address, txType *int32, cursor *string, count int32) (*types.TokenTransactionList, error)
 
 	// TokenTransactionsByCall provides a list of token transaction made inside a specific
 	// transaction call (blockchain transaction).
-	TokenTransactionsByCall(*common.Hash) ([]*types.TokenTransaction, error)
+	TokenTransactionsByCall(context.Context, *common.Hash) ([]*types.TokenTransaction, error)
 
 	// Erc20Token returns an ERC20 token for the given address, if available.
-	Erc20Token(*common.Address) (*types.Erc20Token, error)
+	Erc20Token(context.Context, *common.Address) (*types.Erc20Token, error)
 
 	// Erc20TokensList returns a list of known ERC20 tokens ordered by their activity.
-	Erc20TokensList(int32) ([]common.Address, error)
+	Erc20TokensList(context.Context, int32) ([]common.Address, error)
+
+	// Erc20TokensBySymbol returns a list of known ERC20 tokens with the given symbol,
+	// the symbol is matched case-insensitive.
+	Erc20TokensBySymbol(context.Context, string, int32) ([]common.Address, error)
+
+	// Erc20IndexSymbols stores the symbols of the known ERC20 tokens registered
+	// before their symbols have been kept, so the tokens can be found by the symbol.
+	Erc20IndexSymbols(context.Context) error
 
 	// Erc20Assets provides list of ERC20 tokens involved with the given owner.
-	Erc20Assets(common.Address, int32) ([]common.Address, error)
+	Erc20Assets(context.Context, common.Address, int32) ([]common.Address, error)
 
 	// Erc20BalanceOf load the current available balance of and ERC20 token identified by the token
 	// contract address for an identified owner address.
-	Erc20BalanceOf(*common.Address, *common.Address) (hexutil.Big, error)
+	Erc20BalanceOf(context.Context, *common.Address, *common.Address) (hexutil.Big, error)
+
+	// Erc20BalancesOf loads the current available balances of the given ERC20 tokens
+	// for the given owner in a single batch; nil balance is provided for failed reads.
+	Erc20BalancesOf(context.Context, *common.Address, []common.Address) ([]*hexutil.Big, error)
 
 	// Erc20Allowance loads the current amount of ERC20 tokens unlocked for DeFi
 	// contract by the token owner.
-	Erc20Allowance(*common.Address, *common.Address, *common.Address) (hexutil.Big, error)
+	Erc20Allowance(context.Context, *common.Address, *common.Address, *common.Address) (hexutil.Big, error)
 
 	// Erc20TotalSupply provides information about all available tokens
-	Erc20TotalSupply(*common.Address) (hexutil.Big, error)
+	Erc20TotalSupply(context.Context, *common.Address) (hexutil.Big, error)
 
 	// Erc20Name provides information about the name of the ERC20 token.
-	Erc20Name(*common.Address) (string, error)
+	Erc20Name(context.Context, *common.Address) (string, error)
 
 	// Erc20Symbol provides information about the symbol of the ERC20 token.
-	Erc20Symbol(*common.Address) (string, error)
+	Erc20Symbol(context.Context, *common.Address) (string, error)
 
 	// Erc20Decimals provides information about the decimals of the ERC20 token.
-	Erc20Decimals(*common.Address) (int32, error)
+	Erc20Decimals(context.Context, *common.Address) (int32, error)
+
+	// Erc20Price provides the current price of a whole ERC20 token in native FTM tokens
+	// derived from the reserves of the token pair with the wrapped native token on the DEX.
+	Erc20Price(context.Context, *types.Erc20Token) (float64, error)
+
+	// Erc20PriceChange24h provides the relative change of the token price in native FTM tokens
+	// over the last 24 hours in percents. Nil is returned if the past price is not known.
+	Erc20PriceChange24h(context.Context, *types.Erc20Token) (*float64, error)
+
+	// Erc20Volume24h provides the amount of the token traded on the known DEX pairs
+	// over the last 24 hours.
+	Erc20Volume24h(context.Context, *common.Address) (hexutil.Big, error)
 
 	// Erc20LogoURL provides URL address of a logo of the ERC20 token.
-	Erc20LogoURL(*common.Address) string
+	Erc20LogoURL(context.Context, *common.Address) string
+
+	// Erc20UpdateHolders updates the balances of the ERC20 token holders
+	// by the token amount moved by the given token transaction.
+	Erc20UpdateHolders(context.Context, *types.TokenTransaction) error
+
+	// Erc20Holders provides a list of holders of the given ERC20 token sorted by their balance.
+	Erc20Holders(context.Context, *common.Address, *string, int32) (*types.AccountBalanceList, error)
 
 	// StoreTokenTransaction stores ERC20/ERC721/ERC1155 transaction into the repository.
-	StoreTokenTransaction(*types.TokenTransaction) error
+	StoreTokenTransaction(context.Context, *types.TokenTransaction) error
 
 	// Erc165SupportsInterface provides information about support of the interface by the contract.
-	Erc165SupportsInterface(contract *common.Address, interfaceID [4]byte) (bool, error)
+	Erc165SupportsInterface(ctx context.Context, contract *common.Address, interfaceID [4]byte) (bool, error)
 
 	// Erc721Contract returns an ERC721 token for the given address, if available.
-	Erc721Contract(*common.Address) (*types.Erc721Contract, error)
+	Erc721Contract(context.Context, *common.Address) (*types.Erc721Contract, error)
 
 	// Erc721ContractsList returns a list of known ERC721 tokens ordered by their activity.
-	Erc721ContractsList(int32) ([]common.Address, error)
+	Erc721ContractsList(context.Context, int32) ([]common.Address, error)
 
 	// Erc721Name provides information about the name of the ERC721 token.
-	Erc721Name(*common.Address) (string, error)
+	Erc721Name(context.Context, *common.Address) (string, error)
 
 	// Erc721Symbol provides information about the symbol of the ERC721 token.
-	Erc721Symbol(*common.Address) (string, error)
+	Erc721Symbol(context.Context, *common.Address) (string, error)
 
 	// Erc721TotalSupply provides information about all available tokens.
-	Erc721TotalSupply(token *common.Address) (hexutil.Big, error)
+	Erc721TotalSupply(ctx context.Context, token *common.Address) (hexutil.Big, error)
 
 	// Erc721BalanceOf provides amount of NFT tokens owned by given owner in given ERC721 contract.
-	Erc721BalanceOf(token *common.Address, owner *common.Address) (hexutil.Big, error)
+	Erc721BalanceOf(ctx context.Context, token *common.Address, owner *common.Address) (hexutil.Big, error)
 
 	// Erc721TokenURI provides URI of Metadata JSON Schema of the ERC721 token.
-	Erc721TokenURI(token *common.Address, tokenId *big.Int) (string, error)
+	Erc721TokenURI(ctx context.Context, token *common.Address, tokenId *big.Int) (string, error)
+
+	// StoreNftMetadata stores the downloaded metadata of an NFT token.
+	StoreNftMetadata(context.Context, *types.NftMetadata) error
+
+	// NftMetadata provides the stored metadata of the given NFT token, nil if not known.
+	NftMetadata(ctx context.Context, contract *common.Address, tokenId *big.Int) (*types.NftMetadata, error)
 
 	// Erc721OwnerOf provides information about NFT token ownership.
-	Erc721OwnerOf(token *common.Address, tokenId *big.Int) (common.Address, error)
+	Erc721OwnerOf(ctx context.Context, token *common.Address, tokenId *big.Int) (common.Address, error)
 
 	// Erc721GetApproved provides information about operator approved to manipulate with the NFT token.
-	Erc721GetApproved(token *common.Address, tokenId *big.Int) (common.Address, error)
+	Erc721GetApproved(ctx context.Context, token *common.Address, tokenId *big.Int) (common.Address, error)
 
 	// Erc721IsApprovedForAll provides information about operator approved to manipulate with NFT tokens of given owner.
-	Erc721IsApprovedForAll(token *common.Address, owner *common.Address, operator *common.Address) (bool, error)
+	Erc721IsApprovedForAll(ctx context.Context, token *common.Address, owner *common.Address, operator *common.Address) (bool, error)
+
+	// Erc721TokensOfOwner provides a list of ERC721 NFT tokens currently owned by the given account.
+	Erc721TokensOfOwner(ctx context.Context, owner *common.Address, count int32) ([]*types.Erc721Token, error)
+
+	// Erc721UpdateOwner records the new owner of the ERC721 token moved by the given token transaction.
+	Erc721UpdateOwner(ctx context.Context, trx *types.TokenTransaction) error
 
 	// Erc1155ContractsList returns a list of known ERC1155 contracts ordered by their activity.
-	Erc1155ContractsList(int32) ([]common.Address, error)
+	Erc1155ContractsList(context.Context, int32) ([]common.Address, error)
 
 	// Erc1155Uri provides URI of Metadata JSON Schema of the token.
-	Erc1155Uri(token *common.Address, tokenId *big.Int) (string, error)
+	Erc1155Uri(ctx context.Context, token *common.Address, tokenId *big.Int) (string, error)
 
 	// Erc1155BalanceOf provides amount of NFT tokens owned by given owner.
-	Erc1155BalanceOf(token *common.Address, owner *common.Address, tokenId *big.Int) (*big.Int, error)
+	Erc1155BalanceOf(ctx context.Context, token *common.Address, owner *common.Address, tokenId *big.Int) (*big.Int, error)
 
 	// Erc1155BalanceOfBatch provides amount of NFT tokens owned by given owner.
-	Erc1155BalanceOfBatch(token *common.Address, owners *[]common.Address, tokenIds []*big.Int) ([]*big.Int, error)
+	Erc1155BalanceOfBatch(ctx context.Context, token *common.Address, owners *[]common.Address, tokenIds []*big.Int) ([]*big.Int, error)
+
+	// Erc1155Balances provides a list of ERC1155 token types held by the given account
+	// along with the current balance.
+	Erc1155Balances(ctx context.Context, owner *common.Address, count int32) ([]*types.Erc1155Balance, error)
+
+	// Erc1155UpdateBalances updates the balances of the ERC1155 token holders
+	// by the token amount moved by the given token transaction.
+	Erc1155UpdateBalances(ctx context.Context, trx *types.TokenTransaction) error
 
 	// Erc1155IsApprovedForAll provides information about operator approved to manipulate with NFT tokens of given owner.
-	Erc1155IsApprovedForAll(token *common.Address, owner *common.Address, operator *common.Address) (bool, error)
+	Erc1155IsApprovedForAll(ctx context.Context, token *common.Address, owner *common.Address, operator *common.Address) (bool, error)
 
 	// GovernanceContractBy provides governance contract details by its address.
-	GovernanceContractBy(*common.Address) (*config.GovernanceContract, error)
+	GovernanceContractBy(context.Context, *common.Address) (*config.GovernanceContract, error)
 
 	// GovernanceProposalsCount provides the total number of proposals
 	// in a given Governance contract.
-	GovernanceProposalsCount(*common.Address) (hexutil.Big, error)
+	GovernanceProposalsCount(context.Context, *common.Address) (hexutil.Big, error)
 
 	// GovernanceProposal provides a detail of Proposal of a governance contract
 	// specified by its id.
-	GovernanceProposal(*common.Address, *hexutil.Big) (*types.GovernanceProposal, error)
+	GovernanceProposal(context.Context, *common.Address, *hexutil.Big) (*types.GovernanceProposal, error)
 
 	// GovernanceProposalState provides a state of Proposal of a governance contract
 	// specified by its id.
-	GovernanceProposalState(*common.Address, *hexutil.Big) (*types.GovernanceProposalState, error)
+	GovernanceProposalState(context.Context, *common.Address, *hexutil.Big) (*types.GovernanceProposalState, error)
 
 	// GovernanceOptionState returns a state of the given option of a proposal.
-	GovernanceOptionState(*common.Address, *hexutil.Big, *hexutil.Big) (*types.GovernanceOptionState, error)
+	GovernanceOptionState(context.Context, *common.Address, *hexutil.Big, *hexutil.Big) (*types.GovernanceOptionState, error)
 
 	// GovernanceOptionStates returns a list of states of options of a proposal.
-	GovernanceOptionStates(*common.Address, *hexutil.Big, int) ([]*types.GovernanceOptionState, error)
+	GovernanceOptionStates(context.Context, *common.Address, *hexutil.Big, int) ([]*types.GovernanceOptionState, error)
 
 	// GovernanceVote provides a single vote in the Governance Proposal context.
-	GovernanceVote(*common.Address, *hexutil.Big, *common.Address, *common.Address) (*types.GovernanceVote, error)
+	GovernanceVote(context.Context, *common.Address, *hexutil.Big, *common.Address, *common.Address) (*types.GovernanceVote, error)
+
+	// StoreGovernanceVote stores a vote placed on a Governance contract.
+	StoreGovernanceVote(context.Context, *types.GovernanceVote) error
+
+	// RemoveGovernanceVote removes a canceled vote of the given voter and delegation.
+	RemoveGovernanceVote(context.Context, *common.Address, *hexutil.Big, *common.Address, *common.Address) error
+
+	// GovernanceVotesOf provides a list of the most recent votes placed by the given account.
+	GovernanceVotesOf(context.Context, *common.Address, int32) ([]*types.GovernanceVote, error)
 
 	// GovernanceProposals loads list of proposals from given set of Governance contracts.
-	GovernanceProposals([]*common.Address, *string, int32, bool) (*types.GovernanceProposalList, error)
+	GovernanceProposals(context.Context, []*common.Address, *string, int32, bool) (*types.GovernanceProposalList, error)
 
 	// GovernanceProposalFee returns the fee payable for a new proposal
 	// in given Governance contract context.
-	GovernanceProposalFee(*common.Address) (hexutil.Big, error)
+	GovernanceProposalFee(context.Context, *common.Address) (hexutil.Big, error)
 
 	// GovernanceTotalWeight provides the total weight of all available votes
 	// in the governance contract identified by the address.
-	GovernanceTotalWeight(*common.Address) (hexutil.Big, error)
+	GovernanceTotalWeight(context.Context, *common.Address) (hexutil.Big, error)
 
 	// FLendGetLendingPool resolves lending pool contract instance
 	// to be able to get calls and information from this contract
-	FLendGetLendingPool() (*contracts.ILendingPool, error)
+	FLendGetLendingPool(context.Context) (*contracts.ILendingPool, error)
 
 	// FLendGetLendingPoolReserveData resolves reserve data
 	// according to given address
-	FLendGetLendingPoolReserveData(*common.Address) (*types.ReserveData, error)
+	FLendGetLendingPoolReserveData(context.Context, *common.Address) (*types.ReserveData, error)
 
 	// FLendGetUserAccountData resolves user account data for
 	// specified address
-	FLendGetUserAccountData(*common.Address) (*types.FLendUserAccountData, error)
+	FLendGetUserAccountData(context.Context, *common.Address) (*types.FLendUserAccountData, error)
 
 	// FLendGetReserveList resolves list of reserves in lending pool
-	FLendGetReserveList() ([]common.Address, error)
+	FLendGetReserveList(context.Context) ([]common.Address, error)
 
 	// FLendGetUserDepositHistory resolves deposit history
 	// data for specified user and asset address
-	FLendGetUserDepositHistory(*common.Address, *common.Address) ([]*types.FLendDeposit, error)
+	FLendGetUserDepositHistory(context.Context, *common.Address, *common.Address) ([]*types.FLendDeposit, error)
 
 	// TrxFlowVolume resolves the list of daily trx flow aggregations.
-	TrxFlowVolume(from *time.Time, to *time.Time) ([]*types.DailyTrxVolume, error)
+	TrxFlowVolume(ctx context.Context, from *time.Time, to *time.Time) ([]*types.DailyTrxVolume, error)
 
 	// TrxGasSpeed provides speed of gas consumption per second by transactions.
-	TrxGasSpeed(from *time.Time, to *time.Time) (float64, error)
+	TrxGasSpeed(ctx context.Context, from *time.Time, to *time.Time) (float64, error)
 
 	// TrxFlowUpdate executes the trx flow update in the database.
-	TrxFlowUpdate()
+	TrxFlowUpdate(context.Context)
 
 	// TrxFlowSpeed provides speed of transaction per second for the last <sec> seconds.
-	TrxFlowSpeed(sec int32) (float64, error)
+	TrxFlowSpeed(ctx context.Context, sec int32) (float64, error)
+
+	// CreateApiKey issues a new API key with the given usage limits.
+	CreateApiKey(ctx context.Context, name string, rateLimit int32, dailyQuota int64) (string, *types.ApiKey, error)
+
+	// ApiKey provides the stored record of the given API key; nil is returned for unknown keys.
+	ApiKey(ctx context.Context, key string) (*types.ApiKey, error)
+
+	// RevokeApiKey revokes the given API key. It returns FALSE if the key is not known or already revoked.
+	RevokeApiKey(ctx context.Context, key string) (bool, error)
+
+	// AddApiKeyUsage adds the given number of queries and their cost to the daily usage of the API key.
+	AddApiKeyUsage(context.Context, *types.ApiKeyUsage) error
+
+	// ApiKeyUsage provides the daily usage of the API key of the given hash over the given number of days.
+	ApiKeyUsage(ctx context.Context, hash string, days int) ([]*types.ApiKeyUsage, error)
+
+	// StoreAuditRecord stores a record of an executed operation in the audit log.
+	StoreAuditRecord(context.Context, *types.AuditRecord) error
+
+	// AuditLog provides the given number of the most recent audit records
+	// optionally filtered by the client API key hash and the operation name.
+	AuditLog(ctx context.Context, client *string, operation *string, count int32) ([]*types.AuditRecord, error)
+
+	// AccountLabel provides the label of the given address; nil is returned for unlabeled addresses.
+	AccountLabel(context.Context, *common.Address) (*types.AccountLabel, error)
+
+	// AccountLabels provides the list of account labels, optionally of the given category only.
+	AccountLabels(ctx context.Context, category *string) ([]*types.AccountLabel, error)
+
+	// SetAccountLabel assigns the given name and category to the address.
+	SetAccountLabel(ctx context.Context, addr *common.Address, name string, category string) (*types.AccountLabel, error)
+
+	// RemoveAccountLabel removes the label of the given address.
+	// It returns FALSE if the address was not labeled.
+	RemoveAccountLabel(context.Context, *common.Address) (bool, error)
+
+	// CreateWatch registers a new webhook notified about the given events of the address.
+	CreateWatch(ctx context.Context, owner string, addr *common.Address, url string, events []string) (*types.Watch, error)
+
+	// RemoveWatch removes the watch of the given id, optionally owned by the given client only.
+	RemoveWatch(ctx context.Context, id string, owner *string) (bool, error)
+
+	// Watches provides the list of address watches, optionally owned by the given client only.
+	Watches(ctx context.Context, owner *string) ([]*types.Watch, error)
+
+	// PersistedQuery provides the GraphQL query registered under the given hash, if known.
+	PersistedQuery(hash string) (string, bool)
+
+	// StorePersistedQuery registers the GraphQL query under the given hash.
+	StorePersistedQuery(hash string, query string)
+
+	// ResolverResponse provides the encoded resolver response cached under the given key; nil if not available.
+	ResolverResponse(key string) []byte
+
+	// StoreResolverResponse caches the encoded resolver response under the given key.
+	StoreResolverResponse(key string, data []byte)
+
+	// PurgeCache removes the cached entries of the given key prefix; all the entries
+	// are removed if the prefix is empty. The number of removed entries is returned.
+	PurgeCache(prefix string) (int, error)
+
+	// WarmUp preloads the frequently accessed entities into the cache.
+	WarmUp(cfg *config.CacheWarmUp)
+
+	// Reconfigure applies the changed tunable options of the given configuration.
+	Reconfigure(cfg *config.Config)
 
 	// Close and cleanup the repository.
 	Close()
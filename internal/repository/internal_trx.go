@@ -0,0 +1,45 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// InternalTransactions provides the list of calls executed inside the given transaction.
+// The calls are traced by the scanner when the internal calls tracing is enabled,
+// transactions are not traced on demand.
+func (p *proxy) InternalTransactions(ctx context.Context, hash *common.Hash) ([]*types.InternalTransaction, error) {
+	return p.db.InternalTransactions(ctx, hash)
+}
+
+// TraceInternalTransactions traces the given transaction on the node
+// and stores the internal transactions found in the database.
+func (p *proxy) TraceInternalTransactions(ctx context.Context, hash *common.Hash) ([]*types.InternalTransaction, error) {
+	list, err := p.rpc.InternalTransactions(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// store the calls found
+	for _, itx := range list {
+		if err := p.db.AddInternalTransaction(ctx, itx); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+// InternalTransactionsOfAccount provides the list of the most recent known internal transactions
+// the given account is involved with.
+func (p *proxy) InternalTransactionsOfAccount(ctx context.Context, adr *common.Address, count int32) ([]*types.InternalTransaction, error) {
+	return p.db.InternalTransactionsOfAccount(ctx, adr, count)
+}
@@ -0,0 +1,80 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository/cache"
+	"fantom-api-graphql/internal/types"
+)
+
+// netLoadCurrentWindow represents the number of the most recent blocks
+// used to calculate the current transactions per second rate.
+const netLoadCurrentWindow = 10
+
+// NetworkLoad provides the recent load of the network calculated
+// over the ring of the most recent blocks processed by the block scanner.
+func (p *proxy) NetworkLoad(ctx context.Context) (*types.NetworkLoad, error) {
+	// the ring provides blocks from the newest to the oldest
+	blocks := p.cache.ListBlocks(cache.BlockRingCacheSize)
+	nl := types.NetworkLoad{Blocks: int32(len(blocks))}
+
+	if 0 < len(blocks) {
+		// gas utilization of the most recent block and the average
+		var sum float64
+		for _, blk := range blocks {
+			sum += blockGasUtilization(blk)
+		}
+		nl.GasUtilization = blockGasUtilization(blocks[0])
+		nl.AverageGasUtilization = sum / float64(len(blocks))
+
+		// transactions per second rates
+		nl.AverageTps = blocksTps(blocks)
+		if len(blocks) > netLoadCurrentWindow {
+			blocks = blocks[:netLoadCurrentWindow]
+		}
+		nl.CurrentTps = blocksTps(blocks)
+	}
+
+	// the pool status is not critical for the load information
+	var err error
+	nl.PendingTransactions, nl.QueuedTransactions, err = p.rpc.TxPoolStatus(ctx)
+	if err != nil {
+		p.log.Errorf("transaction pool status not available; %s", err.Error())
+	}
+	return &nl, nil
+}
+
+// blockGasUtilization calculates the ratio of the gas used by the block to the block gas limit.
+func blockGasUtilization(blk *types.Block) float64 {
+	if blk.GasLimit == 0 {
+		return 0
+	}
+	return float64(blk.GasUsed) / float64(blk.GasLimit)
+}
+
+// blocksTps calculates the transactions per second rate on the given list of blocks
+// sorted from the newest to the oldest one.
+func blocksTps(blocks []*types.Block) float64 {
+	if len(blocks) < 2 {
+		return 0
+	}
+
+	// the oldest block only marks the start of the window
+	last := blocks[len(blocks)-1]
+	if blocks[0].TimeStamp <= last.TimeStamp {
+		return 0
+	}
+
+	var count int
+	for _, blk := range blocks[:len(blocks)-1] {
+		count += len(blk.Txs)
+	}
+	return float64(count) / float64(blocks[0].TimeStamp-last.TimeStamp)
+}
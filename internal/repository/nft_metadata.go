@@ -0,0 +1,32 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+)
+
+// NftMetadataId provides the identifier of the metadata of the given NFT token.
+func NftMetadataId(contract *common.Address, tokenId *big.Int) string {
+	return fmt.Sprintf("%s/%s", contract.String(), tokenId.String())
+}
+
+// StoreNftMetadata stores the downloaded metadata of an NFT token.
+func (p *proxy) StoreNftMetadata(ctx context.Context, md *types.NftMetadata) error {
+	return p.db.StoreNftMetadata(ctx, md)
+}
+
+// NftMetadata provides the stored metadata of the given NFT token, nil if not known.
+func (p *proxy) NftMetadata(ctx context.Context, contract *common.Address, tokenId *big.Int) (*types.NftMetadata, error) {
+	return p.db.NftMetadata(ctx, NftMetadataId(contract, tokenId))
+}
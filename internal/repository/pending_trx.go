@@ -0,0 +1,94 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"sort"
+)
+
+// pendingPoolRequestKey is the key of the shared request for the node transaction pool snapshot.
+const pendingPoolRequestKey = "pending_pool"
+
+// ObservedPendingTransactions provides a channel fed with hashes of new pending transactions
+// observed by the connected blockchain node.
+func (p *proxy) ObservedPendingTransactions() chan common.Hash {
+	return p.rpc.ObservedPendingProxy()
+}
+
+// PendingTransactionsChanged signals the content of the node transaction pool changed.
+func (p *proxy) PendingTransactionsChanged() {
+	p.cache.MarkPendingTransactionsDirty()
+}
+
+// PendingTransactions provides a list of transactions pending in the node transaction pool
+// sorted by their gas price from the highest to the lowest.
+func (p *proxy) PendingTransactions(ctx context.Context, count int32) ([]*types.Transaction, error) {
+	pool, err := p.pendingPool()
+	if err != nil {
+		return nil, err
+	}
+
+	if int(count) < len(pool) {
+		pool = pool[:count]
+	}
+	return pool, nil
+}
+
+// PendingTransactionsOf provides a list of transactions pending in the node transaction pool
+// sent by the given account sorted by their nonce.
+func (p *proxy) PendingTransactionsOf(ctx context.Context, addr *common.Address) ([]*types.Transaction, error) {
+	pool, err := p.pendingPool()
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*types.Transaction, 0)
+	for _, trx := range pool {
+		if bytes.Equal(trx.From.Bytes(), addr.Bytes()) {
+			list = append(list, trx)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Nonce < list[j].Nonce
+	})
+	return list, nil
+}
+
+// pendingPool provides the snapshot of the node transaction pool sorted by gas price.
+// The pool is volatile, the snapshot is kept in the in-memory cache for a short time only.
+func (p *proxy) pendingPool() ([]*types.Transaction, error) {
+	val, err, _ := p.apiRequestGroup.Do(pendingPoolRequestKey, func() (interface{}, error) {
+		// try the cache first
+		if pool := p.cache.PullPendingTransactions(); pool != nil {
+			return pool, nil
+		}
+
+		// load the slow way
+		pool, err := p.rpc.TxPoolPending(context.Background())
+		if err != nil {
+			return nil, err
+		}
+
+		sort.Slice(pool, func(i, j int) bool {
+			return pool[i].GasPrice.ToInt().Cmp(pool[j].GasPrice.ToInt()) > 0
+		})
+
+		p.cache.PushPendingTransactions(pool)
+		return pool, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return val.([]*types.Transaction), nil
+}
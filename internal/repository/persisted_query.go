@@ -0,0 +1,19 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+// PersistedQuery provides the GraphQL query registered under the given hash, if known.
+func (p *proxy) PersistedQuery(hash string) (string, bool) {
+	return p.cache.PullPersistedQuery(hash)
+}
+
+// StorePersistedQuery registers the GraphQL query under the given hash.
+func (p *proxy) StorePersistedQuery(hash string, query string) {
+	p.cache.PushPersistedQuery(hash, query)
+}
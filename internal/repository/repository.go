@@ -16,6 +16,7 @@ import (
 	"fantom-api-graphql/internal/repository/rpc"
 	"fmt"
 	"golang.org/x/sync/singleflight"
+	"reflect"
 	"sync"
 )
 
@@ -58,7 +59,7 @@ func R() Repository {
 // trough several low level bridges.
 type proxy struct {
 	cache *cache.MemBridge
-	db    *db.MongoDbBridge
+	db    persistentStore
 	rpc   *rpc.FtmBridge
 	log   logger.Logger
 	cfg   *config.Config
@@ -73,7 +74,11 @@ type proxy struct {
 	govContracts map[string]*config.GovernanceContract
 
 	// smart contract compilers
-	solCompiler string
+	solCompiler  string
+	solCompilers map[string]string
+
+	// parsed contract ABIs keyed by the contract address
+	abiMap sync.Map
 }
 
 // newRepository creates new instance of Repository implementation, namely proxy structure.
@@ -104,7 +109,8 @@ func newRepository() Repository {
 		govContracts: governanceContractsMap(&cfg.Governance),
 
 		// keep reference to the SOL compiler
-		solCompiler: cfg.Compiler.DefaultSolCompilerPath,
+		solCompiler:  cfg.Compiler.DefaultSolCompilerPath,
+		solCompilers: cfg.Compiler.SolCompilers,
 	}
 
 	// return the proxy
@@ -125,7 +131,7 @@ func governanceContractsMap(cfg *config.Governance) map[string]*config.Governanc
 }
 
 // connect opens connections to the external sources we need.
-func connect(cfg *config.Config, log logger.Logger) (*cache.MemBridge, *db.MongoDbBridge, *rpc.FtmBridge, error) {
+func connect(cfg *config.Config, log logger.Logger) (*cache.MemBridge, persistentStore, *rpc.FtmBridge, error) {
 	// create new in-memory cache bridge
 	caBridge, err := cache.New(cfg, log)
 	if err != nil {
@@ -134,7 +140,7 @@ func connect(cfg *config.Config, log logger.Logger) (*cache.MemBridge, *db.Mongo
 	}
 
 	// create new database connection bridge
-	dbBridge, err := db.New(cfg, log)
+	dbBridge, err := connectStore(cfg, log)
 	if err != nil {
 		log.Criticalf("can not connect backend persistent storage, %s", err.Error())
 		return nil, nil, nil, err
@@ -149,14 +155,44 @@ func connect(cfg *config.Config, log logger.Logger) (*cache.MemBridge, *db.Mongo
 	return caBridge, dbBridge, rpcBridge, nil
 }
 
+// connectStore opens the configured persistent storage.
+func connectStore(cfg *config.Config, log logger.Logger) (persistentStore, error) {
+	if cfg.Db.Type != config.DbTypeMongo {
+		return nil, fmt.Errorf("unsupported persistent storage type %s", cfg.Db.Type)
+	}
+
+	mdb, err := db.New(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+	return mdb, nil
+}
+
+// Reconfigure applies the changed tunable options of the given configuration
+// to the repository, e.g. the cache eviction time and the blockchain nodes.
+// The configuration of the repository is not updated here.
+func (p *proxy) Reconfigure(cfg *config.Config) {
+	current := p.cfg.Tunable()
+	if cfg.Cache.Eviction != current.CacheEviction {
+		p.cache.SetEviction(cfg.Cache.Eviction)
+	}
+
+	if !reflect.DeepEqual(cfg.Lachesis, current.Lachesis) {
+		p.log.Notice("blockchain nodes configuration changed")
+		p.rpc.UpdateNodes(cfg)
+	}
+}
+
 // Close with close all connections and clean up the pending work for graceful termination.
 func (p *proxy) Close() {
 	// inform about actions
 	p.log.Notice("repository is closing")
 
-	// close connections
-	p.db.Close()
+	// close connections; the blockchain observers go first
+	// so they don't feed new data into the closing stores
 	p.rpc.Close()
+	p.cache.Close()
+	p.db.Close()
 
 	// inform about actions
 	p.log.Notice("repository done")
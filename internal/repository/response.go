@@ -0,0 +1,32 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+// ResolverResponse provides the encoded resolver response cached under the given key; nil if not available.
+func (p *proxy) ResolverResponse(key string) []byte {
+	return p.cache.PullResolverResponse(key)
+}
+
+// PurgeCache removes the cached entries of the given key prefix; all the entries
+// are removed if the prefix is empty. The number of removed entries is returned.
+func (p *proxy) PurgeCache(prefix string) (int, error) {
+	count, err := p.cache.Purge(prefix)
+	if err != nil {
+		p.log.Errorf("can not purge cache of prefix %s; %s", prefix, err.Error())
+		return count, err
+	}
+
+	p.log.Noticef("%d cached entries of prefix '%s' purged", count, prefix)
+	return count, nil
+}
+
+// StoreResolverResponse caches the encoded resolver response under the given key.
+func (p *proxy) StoreResolverResponse(key string, data []byte) {
+	p.cache.PushResolverResponse(key, data)
+}
@@ -14,15 +14,17 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
+	"context"
+	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // AccountBalance reads balance of account from Lachesis node.
-func (ftm *FtmBridge) AccountBalance(addr *common.Address) (*hexutil.Big, error) {
+func (ftm *FtmBridge) AccountBalance(ctx context.Context, addr *common.Address) (*hexutil.Big, error) {
 	// use RPC to make the call
 	var balance string
-	err := ftm.rpc.Call(&balance, "ftm_getBalance", addr.Hex(), "latest")
+	err := ftm.rpc.CallContext(ctx, &balance, "ftm_getBalance", addr.Hex(), "latest")
 	if err != nil {
 		ftm.log.Errorf("can not get balance of account [%s]", addr.Hex())
 		return nil, err
@@ -39,10 +41,10 @@ func (ftm *FtmBridge) AccountBalance(addr *common.Address) (*hexutil.Big, error)
 }
 
 // AccountNonce returns the total number of transaction of account from Lachesis node.
-func (ftm *FtmBridge) AccountNonce(addr *common.Address) (uint64, error) {
+func (ftm *FtmBridge) AccountNonce(ctx context.Context, addr *common.Address) (uint64, error) {
 	// use RPC to make the call
 	var nonce string
-	err := ftm.rpc.Call(&nonce, "ftm_getTransactionCount", addr.Hex(), "latest")
+	err := ftm.rpc.CallContext(ctx, &nonce, "ftm_getTransactionCount", addr.Hex(), "latest")
 	if err != nil {
 		ftm.log.Errorf("can not get number of transaction of account [%s]", addr.Hex())
 		return 0, err
@@ -57,3 +59,49 @@ func (ftm *FtmBridge) AccountNonce(addr *common.Address) (uint64, error) {
 
 	return val, nil
 }
+
+// AccountCode reads the runtime byte code deployed on the given account address.
+func (ftm *FtmBridge) AccountCode(ctx context.Context, addr *common.Address) (hexutil.Bytes, error) {
+	// use RPC to make the call
+	var code hexutil.Bytes
+	err := ftm.rpc.CallContext(ctx, &code, "ftm_getCode", addr.Hex(), "latest")
+	if err != nil {
+		ftm.log.Errorf("can not get code of account [%s]", addr.Hex())
+		return nil, err
+	}
+	return code, nil
+}
+
+// AccountProof loads the Merkle proof of the account state and of the given storage slots
+// of the account at the given block, or at the latest block if no block is given.
+func (ftm *FtmBridge) AccountProof(ctx context.Context, addr *common.Address, keys []common.Hash, block *hexutil.Uint64) (*types.AccountStateProof, error) {
+	blk := "latest"
+	if block != nil {
+		blk = block.String()
+	}
+
+	var proof types.AccountStateProof
+	err := ftm.rpc.CallContext(ctx, &proof, "eth_getProof", addr.Hex(), keys, blk)
+	if err != nil {
+		ftm.log.Errorf("can not get proof of account [%s] at %s; %s", addr.Hex(), blk, err.Error())
+		return nil, err
+	}
+	return &proof, nil
+}
+
+// StorageAt reads the value of the given storage slot of the account
+// at the given block, or at the latest block if no block is given.
+func (ftm *FtmBridge) StorageAt(ctx context.Context, addr *common.Address, slot common.Hash, block *hexutil.Uint64) (common.Hash, error) {
+	blk := "latest"
+	if block != nil {
+		blk = block.String()
+	}
+
+	var val hexutil.Bytes
+	err := ftm.rpc.CallContext(ctx, &val, "ftm_getStorageAt", addr.Hex(), slot.Hex(), blk)
+	if err != nil {
+		ftm.log.Errorf("can not get storage slot %s of account [%s] at %s; %s", slot.Hex(), addr.Hex(), blk, err.Error())
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(val), nil
+}
@@ -0,0 +1,169 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	client "github.com/ethereum/go-ethereum/rpc"
+)
+
+// BatchCall sends all the given requests to the node in a single round trip
+// and waits for the responses. The error is returned only if the batch itself failed;
+// errors of individual requests are set on their batch elements.
+// The context carries the trace of the call, if any.
+func (ftm *FtmBridge) BatchCall(ctx context.Context, b []client.BatchElem) error {
+	// keep track of the operation
+	ftm.log.Debugf("sending batch of %d calls", len(b))
+
+	err := ftm.rpc.BatchCall(ctx, b)
+	if err != nil {
+		ftm.log.Errorf("batch of %d calls failed; %s", len(b), err.Error())
+		return err
+	}
+	return nil
+}
+
+// Blocks returns information about the given blockchain blocks loaded in a single batch.
+// Blocks not available are returned as nil.
+func (ftm *FtmBridge) Blocks(ctx context.Context, nums []uint64) ([]*types.Block, error) {
+	blocks := make([]types.Block, len(nums))
+	batch := make([]client.BatchElem, len(nums))
+	for i, n := range nums {
+		batch[i] = client.BatchElem{
+			Method: "ftm_getBlockByNumber",
+			Args:   []interface{}{hexutil.EncodeUint64(n), false},
+			Result: &blocks[i],
+		}
+	}
+
+	if err := ftm.BatchCall(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	// collect the blocks found
+	list := make([]*types.Block, len(nums))
+	for i, n := range nums {
+		if batch[i].Error != nil {
+			ftm.log.Errorf("block #%d could not be extracted; %s", n, batch[i].Error.Error())
+			continue
+		}
+
+		// the node responds with an empty block if not found
+		if blocks[i].Hash == (common.Hash{}) {
+			ftm.log.Debugf("block #%d not found", n)
+			continue
+		}
+		list[i] = &blocks[i]
+	}
+	return list, nil
+}
+
+// Transactions returns information about the given blockchain transactions
+// including their receipts loaded in a single batch.
+// Transactions not available are returned as nil.
+func (ftm *FtmBridge) Transactions(ctx context.Context, hashes []*common.Hash) ([]*types.Transaction, error) {
+	txs := make([]types.Transaction, len(hashes))
+	recs := make([]trxReceipt, len(hashes))
+	batch := make([]client.BatchElem, 0, 2*len(hashes))
+	for i, h := range hashes {
+		batch = append(batch, client.BatchElem{
+			Method: "ftm_getTransactionByHash",
+			Args:   []interface{}{h},
+			Result: &txs[i],
+		}, client.BatchElem{
+			Method: "ftm_getTransactionReceipt",
+			Args:   []interface{}{h},
+			Result: &recs[i],
+		})
+	}
+
+	if err := ftm.BatchCall(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	// collect the transactions found
+	list := make([]*types.Transaction, len(hashes))
+	for i, h := range hashes {
+		if err := batchError(batch[2*i], batch[2*i+1]); err != nil {
+			ftm.log.Errorf("transaction %s could not be extracted; %s", h.String(), err.Error())
+			continue
+		}
+
+		// the node responds with an empty structure for unknown transactions
+		if txs[i].Hash == (common.Hash{}) {
+			ftm.log.Debugf("transaction %s not found", h.String())
+			continue
+		}
+
+		if txs[i].BlockNumber != nil {
+			recs[i].apply(&txs[i])
+		}
+		list[i] = &txs[i]
+	}
+	return list, nil
+}
+
+// AccountBalances reads balances of the given accounts from Lachesis node in a single batch.
+// Balances not available are returned as nil.
+func (ftm *FtmBridge) AccountBalances(ctx context.Context, adr []*common.Address) ([]*hexutil.Big, error) {
+	return ftm.accountBalances(ctx, adr, "latest")
+}
+
+// AccountBalancesAt reads balances of the given accounts at the given block from Lachesis node
+// in a single batch. Balances not available are returned as nil.
+func (ftm *FtmBridge) AccountBalancesAt(ctx context.Context, adr []*common.Address, blk hexutil.Uint64) ([]*hexutil.Big, error) {
+	return ftm.accountBalances(ctx, adr, blk.String())
+}
+
+// accountBalances reads balances of the given accounts at the given block tag in a single batch.
+func (ftm *FtmBridge) accountBalances(ctx context.Context, adr []*common.Address, tag string) ([]*hexutil.Big, error) {
+	bal := make([]hexutil.Big, len(adr))
+	batch := make([]client.BatchElem, len(adr))
+	for i, a := range adr {
+		batch[i] = client.BatchElem{
+			Method: "ftm_getBalance",
+			Args:   []interface{}{a.Hex(), tag},
+			Result: &bal[i],
+		}
+	}
+
+	if err := ftm.BatchCall(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	// collect the balances
+	list := make([]*hexutil.Big, len(adr))
+	for i, a := range adr {
+		if batch[i].Error != nil {
+			ftm.log.Errorf("can not get balance of account [%s]; %s", a.Hex(), batch[i].Error.Error())
+			continue
+		}
+		list[i] = &bal[i]
+	}
+	return list, nil
+}
+
+// batchError provides the first error of the given batch elements, if any.
+func batchError(elems ...client.BatchElem) error {
+	for _, el := range elems {
+		if el.Error != nil {
+			return fmt.Errorf("%s failed; %s", el.Method, el.Error.Error())
+		}
+	}
+	return nil
+}
@@ -16,14 +16,25 @@ package rpc
 import (
 	"context"
 	"github.com/ethereum/go-ethereum"
+	"math/big"
 	"time"
 )
 
 // ftmHeadsObserverSubscribeTick represents the time between subscription attempts.
 const ftmHeadsObserverSubscribeTick = 30 * time.Second
 
+// ftmHeadsPollTick represents the time between new heads polls
+// used while the heads subscription is not available.
+const ftmHeadsPollTick = 2 * time.Second
+
+// ftmHeadsPollBackfill represents the number of recent blocks re-sent on the first poll
+// so the blocks received around the subscription failure are not missed.
+const ftmHeadsPollBackfill = 5
+
 // observeBlocks collects new blocks from the blockchain network
 // and posts them into the proxy channel for processing.
+// New heads are received by the node subscription; if the subscription drops,
+// we poll the node for new heads until the subscription is re-established.
 func (ftm *FtmBridge) observeBlocks() {
 	var sub ethereum.Subscription
 	defer func() {
@@ -34,18 +45,36 @@ func (ftm *FtmBridge) observeBlocks() {
 		ftm.wg.Done()
 	}()
 
+	// the last head sent by polling, zero if not polling
+	var last uint64
+
 	sub = ftm.blockSubscription()
 	for {
-		// re-subscribe if the subscription ref is not valid
+		// poll for new heads and re-subscribe if the subscription ref is not valid
 		if sub == nil {
 			tm := time.NewTimer(ftmHeadsObserverSubscribeTick)
-			select {
-			case <-ftm.sigClose:
-				return
-			case <-tm.C:
-				sub = ftm.blockSubscription()
-				continue
+			poll := time.NewTicker(ftmHeadsPollTick)
+
+			for sub == nil {
+				select {
+				case <-ftm.sigClose:
+					tm.Stop()
+					poll.Stop()
+					return
+				case <-poll.C:
+					last = ftm.pollHeads(last)
+				case <-tm.C:
+					sub = ftm.blockSubscription()
+					tm.Reset(ftmHeadsObserverSubscribeTick)
+				}
 			}
+
+			// cover the gap between the last poll and the new subscription
+			tm.Stop()
+			poll.Stop()
+			ftm.pollHeads(last)
+			last = 0
+			ftm.log.Noticef("block subscription restored")
 		}
 
 		// use the subscriptions
@@ -53,12 +82,47 @@ func (ftm *FtmBridge) observeBlocks() {
 		case <-ftm.sigClose:
 			return
 		case err := <-sub.Err():
-			ftm.log.Errorf("block subscription failed; %s", err.Error())
+			ftm.log.Errorf("block subscription failed, polling for new blocks; %s", err.Error())
 			sub = nil
 		}
 	}
 }
 
+// pollHeads loads heads of blocks following the given block number
+// from the connected node and posts them into the proxy channel.
+// It returns the number of the last head posted.
+func (ftm *FtmBridge) pollHeads(last uint64) uint64 {
+	// get the current head
+	head, err := ftm.eth.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		ftm.log.Errorf("can not poll new blocks; %s", err.Error())
+		return last
+	}
+
+	// first poll since the subscription failure? re-send a few recent heads, too
+	top := head.Number.Uint64()
+	if last == 0 {
+		last = top
+		if top > ftmHeadsPollBackfill {
+			last = top - ftmHeadsPollBackfill
+		}
+	}
+
+	// post all the heads we did not send yet
+	for bn := last + 1; bn < top; bn++ {
+		h, err := ftm.eth.HeaderByNumber(context.Background(), new(big.Int).SetUint64(bn))
+		if err != nil {
+			ftm.log.Errorf("can not poll block #%d; %s", bn, err.Error())
+			return bn - 1
+		}
+		ftm.headers <- h
+	}
+	if top > last {
+		ftm.headers <- head
+	}
+	return top
+}
+
 // blockSubscription provides a subscription for new blocks received
 // by the connected blockchain node.
 func (ftm *FtmBridge) blockSubscription() ethereum.Subscription {
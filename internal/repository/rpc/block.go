@@ -14,8 +14,8 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/types"
-	"fmt"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
 	"time"
@@ -39,13 +39,13 @@ func (ftm *FtmBridge) MustBlockHeight() *big.Int {
 }
 
 // BlockHeight returns the current block height of the Opera blockchain.
-func (ftm *FtmBridge) BlockHeight() (*hexutil.Big, error) {
+func (ftm *FtmBridge) BlockHeight(ctx context.Context) (*hexutil.Big, error) {
 	// keep track of the operation
 	ftm.log.Debugf("checking current block height")
 
 	// call for data
 	var height hexutil.Big
-	err := ftm.rpc.Call(&height, "ftm_blockNumber")
+	err := ftm.rpc.CallContext(ctx, &height, "ftm_blockNumber")
 	if err != nil {
 		ftm.log.Error("block height could not be obtained")
 		return nil, err
@@ -58,13 +58,13 @@ func (ftm *FtmBridge) BlockHeight() (*hexutil.Big, error) {
 
 // Block returns information about a blockchain block by encoded hex number, or by a type tag.
 // For tag based loading use predefined BlockType contacts.
-func (ftm *FtmBridge) Block(numTag *string) (*types.Block, error) {
+func (ftm *FtmBridge) Block(ctx context.Context, numTag *string) (*types.Block, error) {
 	// keep track of the operation
 	ftm.log.Debugf("loading details of block num/tag %s", *numTag)
 
 	// call for data
 	var block types.Block
-	err := ftm.rpc.Call(&block, "ftm_getBlockByNumber", numTag, false)
+	err := ftm.rpc.CallContext(ctx, &block, "ftm_getBlockByNumber", numTag, false)
 	if err != nil {
 		ftm.log.Error("block could not be extracted")
 		return nil, err
@@ -73,7 +73,7 @@ func (ftm *FtmBridge) Block(numTag *string) (*types.Block, error) {
 	// detect block not found situation; block number is zero and the hash is also zero
 	if uint64(block.Number) == 0 && block.Hash.Big().Cmp(big.NewInt(0)) == 0 {
 		ftm.log.Debugf("block [%s] not found", *numTag)
-		return nil, fmt.Errorf("block not found")
+		return nil, types.NewError(types.ErrCodeNotFound, "block not found")
 	}
 
 	// keep track of the operation
@@ -83,13 +83,13 @@ func (ftm *FtmBridge) Block(numTag *string) (*types.Block, error) {
 }
 
 // BlockByHash returns information about a blockchain block by hash.
-func (ftm *FtmBridge) BlockByHash(hash *string) (*types.Block, error) {
+func (ftm *FtmBridge) BlockByHash(ctx context.Context, hash *string) (*types.Block, error) {
 	// keep track of the operation
 	ftm.log.Debugf("loading details of block %s", *hash)
 
 	// call for data
 	var block types.Block
-	err := ftm.rpc.Call(&block, "ftm_getBlockByHash", hash, false)
+	err := ftm.rpc.CallContext(ctx, &block, "ftm_getBlockByHash", hash, false)
 	if err != nil {
 		ftm.log.Error("block could not be extracted")
 		return nil, err
@@ -98,7 +98,7 @@ func (ftm *FtmBridge) BlockByHash(hash *string) (*types.Block, error) {
 	// detect block not found situation
 	if uint64(block.Number) == 0 {
 		ftm.log.Debugf("block [%s] not found", *hash)
-		return nil, fmt.Errorf("block not found")
+		return nil, types.NewError(types.ErrCodeNotFound, "block not found")
 	}
 
 	// inform and return
@@ -20,10 +20,9 @@ import (
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
 	etc "github.com/ethereum/go-ethereum/core/types"
-	eth "github.com/ethereum/go-ethereum/ethclient"
 	ftm "github.com/ethereum/go-ethereum/rpc"
-	"golang.org/x/sync/singleflight"
 	"strings"
 	"sync"
 )
@@ -31,18 +30,24 @@ import (
 // rpcHeadProxyChannelCapacity represents the capacity of the new received blocks proxy channel.
 const rpcHeadProxyChannelCapacity = 10000
 
+// rpcPendingProxyChannelCapacity represents the capacity of the new pending transactions proxy channel.
+const rpcPendingProxyChannelCapacity = 10000
+
 // FtmBridge represents Lachesis RPC abstraction layer.
 type FtmBridge struct {
-	rpc *ftm.Client
-	eth *eth.Client
-	log logger.Logger
-	cg  *singleflight.Group
+	rpc   *rpcProxy
+	eth   *ethProxy
+	nodes *nodePool
+	log   logger.Logger
 
 	// fMintCfg represents the configuration of the fMint protocol
 	sigConfig     *config.ServerSignature
 	sfcConfig     *config.Staking
 	uniswapConfig *config.DeFiUniswap
 
+	// multicall represents the address of the contract batching contract reads
+	multicall common.Address
+
 	// extended minter config
 	fMintCfg fMintConfig
 	fLendCfg fLendConfig
@@ -55,27 +60,31 @@ type FtmBridge struct {
 	wg       *sync.WaitGroup
 	sigClose chan bool
 	headers  chan *etc.Header
+
+	// received pending transactions proxy
+	pending chan common.Hash
 }
 
 // New creates new Lachesis RPC connection bridge.
 func New(cfg *config.Config, log logger.Logger) (*FtmBridge, error) {
-	cli, con, err := connect(cfg, log)
+	nodes, err := newNodePool(cfg, log)
 	if err != nil {
 		log.Criticalf("can not open connection; %s", err.Error())
 		return nil, err
 	}
 
-	// build the bridge structure using the con we have
+	// build the bridge structure using the nodes we have
 	br := &FtmBridge{
-		rpc: cli,
-		eth: con,
-		log: log,
-		cg:  new(singleflight.Group),
+		rpc:   &rpcProxy{pool: nodes},
+		eth:   &ethProxy{pool: nodes},
+		nodes: nodes,
+		log:   log,
 
 		// special configuration options below this line
 		sigConfig:     &cfg.MySignature,
 		sfcConfig:     &cfg.Staking,
 		uniswapConfig: &cfg.DeFi.Uniswap,
+		multicall:     cfg.Lachesis.Multicall,
 		fMintCfg: fMintConfig{
 			addressProvider: cfg.DeFi.FMint.AddressProvider,
 		},
@@ -85,6 +94,7 @@ func New(cfg *config.Config, log logger.Logger) (*FtmBridge, error) {
 		wg:       new(sync.WaitGroup),
 		sigClose: make(chan bool, 1),
 		headers:  make(chan *etc.Header, rpcHeadProxyChannelCapacity),
+		pending:  make(chan common.Hash, rpcPendingProxyChannelCapacity),
 	}
 
 	// inform about the local address of the API node
@@ -96,39 +106,17 @@ func New(cfg *config.Config, log logger.Logger) (*FtmBridge, error) {
 	return br, nil
 }
 
-// connect opens connections we need to communicate with the blockchain node.
-func connect(cfg *config.Config, log logger.Logger) (*ftm.Client, *eth.Client, error) {
-	// log what we do
-	log.Debugf("connecting blockchain node at %s", cfg.Lachesis.Url)
-
-	// try to establish a connection
-	client, err := ftm.Dial(cfg.Lachesis.Url)
-	if err != nil {
-		log.Critical(err)
-		return nil, nil, err
-	}
-
-	// try to establish a for smart contract interaction
-	con, err := eth.Dial(cfg.Lachesis.Url)
-	if err != nil {
-		log.Critical(err)
-		return nil, nil, err
-	}
-
-	// log
-	log.Notice("node connection open")
-	return client, con, nil
-}
-
 // run starts the bridge threads required to collect blockchain data.
 func (ftm *FtmBridge) run() {
-	ftm.wg.Add(1)
+	ftm.wg.Add(3)
 	go ftm.observeBlocks()
+	go ftm.observePending()
+	go ftm.observeNodes()
 }
 
 // terminate kills the bridge threads to end the bridge gracefully.
 func (ftm *FtmBridge) terminate() {
-	ftm.sigClose <- true
+	close(ftm.sigClose)
 	ftm.wg.Wait()
 	ftm.log.Noticef("rpc threads terminated")
 }
@@ -138,31 +126,32 @@ func (ftm *FtmBridge) Close() {
 	// terminate threads before we close connections
 	ftm.terminate()
 
-	// do we have a connection?
-	if ftm.rpc != nil {
-		ftm.rpc.Close()
-		ftm.eth.Close()
-		ftm.log.Info("blockchain connections are closed")
-	}
+	// close the nodes connections
+	ftm.nodes.close()
+	ftm.log.Info("blockchain connections are closed")
 }
 
-// Connection returns open Opera/Lachesis connection.
+// UpdateNodes applies the configured blockchain nodes to the bridge;
+// new nodes are connected, removed nodes are disconnected.
+func (ftm *FtmBridge) UpdateNodes(cfg *config.Config) {
+	ftm.nodes.update(cfg)
+}
+
+// Connection returns open Opera/Lachesis connection of the active node.
 func (ftm *FtmBridge) Connection() *ftm.Client {
-	return ftm.rpc
+	rc, _ := ftm.nodes.current().clients()
+	return rc
 }
 
-// DefaultCallOpts creates a default record for call options.
-func (ftm *FtmBridge) DefaultCallOpts() *bind.CallOpts {
-	// get the default call opts only once if called in parallel
-	co, _, _ := ftm.cg.Do("default-call-opts", func() (interface{}, error) {
-		return &bind.CallOpts{
-			Pending:     false,
-			From:        ftm.sigConfig.Address,
-			BlockNumber: nil,
-			Context:     context.Background(),
-		}, nil
-	})
-	return co.(*bind.CallOpts)
+// DefaultCallOpts creates a default record for call options
+// bound to the given context.
+func (ftm *FtmBridge) DefaultCallOpts(ctx context.Context) *bind.CallOpts {
+	return &bind.CallOpts{
+		Pending:     false,
+		From:        ftm.sigConfig.Address,
+		BlockNumber: nil,
+		Context:     ctx,
+	}
 }
 
 // SfcContract returns instance of SFC contract for interaction.
@@ -198,3 +187,9 @@ func (ftm *FtmBridge) SfcAbi() *abi.ABI {
 func (ftm *FtmBridge) ObservedBlockProxy() chan *etc.Header {
 	return ftm.headers
 }
+
+// ObservedPendingProxy provides a channel fed with hashes of new pending transactions
+// observed by the connected blockchain node.
+func (ftm *FtmBridge) ObservedPendingProxy() chan common.Hash {
+	return ftm.pending
+}
@@ -14,6 +14,7 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"fantom-api-graphql/internal/types"
 
@@ -46,7 +47,7 @@ func (ftm *FtmBridge) FLendGetLendingPool() (*contracts.ILendingPool, error) {
 }
 
 // FLendGetLendingPoolReserveData resolves reserve data
-func (ftm *FtmBridge) FLendGetLendingPoolReserveData(assetAddress *common.Address) (*types.ReserveData, error) {
+func (ftm *FtmBridge) FLendGetLendingPoolReserveData(ctx context.Context, assetAddress *common.Address) (*types.ReserveData, error) {
 
 	// get the lending pool contract
 	lp, err := ftm.FLendGetLendingPool()
@@ -55,7 +56,7 @@ func (ftm *FtmBridge) FLendGetLendingPoolReserveData(assetAddress *common.Addres
 		return nil, err
 	}
 
-	rd, err := lp.GetReserveData(&bind.CallOpts{}, *assetAddress)
+	rd, err := lp.GetReserveData(&bind.CallOpts{Context: ctx}, *assetAddress)
 	if err != nil {
 		ftm.log.Errorf("Cannot get reserve data for asset %s: %s", assetAddress.String(), err.Error())
 		return nil, err
@@ -81,7 +82,7 @@ func (ftm *FtmBridge) FLendGetLendingPoolReserveData(assetAddress *common.Addres
 }
 
 // FLendGetReserveList resolves list of reserve addresses
-func (ftm *FtmBridge) FLendGetReserveList() ([]common.Address, error) {
+func (ftm *FtmBridge) FLendGetReserveList(ctx context.Context) ([]common.Address, error) {
 
 	// get the lending pool contract
 	lp, err := ftm.FLendGetLendingPool()
@@ -90,7 +91,7 @@ func (ftm *FtmBridge) FLendGetReserveList() ([]common.Address, error) {
 		return nil, err
 	}
 
-	rl, err := lp.GetReservesList(&bind.CallOpts{})
+	rl, err := lp.GetReservesList(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("Cannot get reserves list: %s", err.Error())
 		return nil, err
@@ -99,7 +100,7 @@ func (ftm *FtmBridge) FLendGetReserveList() ([]common.Address, error) {
 }
 
 // FLendGetUserAccountData resolves user account data for fLend
-func (ftm *FtmBridge) FLendGetUserAccountData(userAddress *common.Address) (*types.FLendUserAccountData, error) {
+func (ftm *FtmBridge) FLendGetUserAccountData(ctx context.Context, userAddress *common.Address) (*types.FLendUserAccountData, error) {
 
 	// get the lending pool contract
 	lp, err := ftm.FLendGetLendingPool()
@@ -108,13 +109,13 @@ func (ftm *FtmBridge) FLendGetUserAccountData(userAddress *common.Address) (*typ
 		return nil, err
 	}
 
-	ua, err := lp.GetUserAccountData(&bind.CallOpts{}, *userAddress)
+	ua, err := lp.GetUserAccountData(&bind.CallOpts{Context: ctx}, *userAddress)
 	if err != nil {
 		ftm.log.Errorf("Cannot get user account data for address %s: %s", userAddress.String(), err.Error())
 		return nil, err
 	}
 
-	uc, err := lp.GetUserConfiguration(&bind.CallOpts{}, *userAddress)
+	uc, err := lp.GetUserConfiguration(&bind.CallOpts{Context: ctx}, *userAddress)
 	if err != nil {
 		ftm.log.Errorf("Cannot get user account configuration data for address %s: %s", userAddress.String(), err.Error())
 		return nil, err
@@ -133,7 +134,7 @@ func (ftm *FtmBridge) FLendGetUserAccountData(userAddress *common.Address) (*typ
 }
 
 // FLendGetUserDepositHistory resolves deposit event history data for specified user and asset address
-func (ftm *FtmBridge) FLendGetUserDepositHistory(userAddress *common.Address, assetAddress *common.Address) ([]*types.FLendDeposit, error) {
+func (ftm *FtmBridge) FLendGetUserDepositHistory(ctx context.Context, userAddress *common.Address, assetAddress *common.Address) ([]*types.FLendDeposit, error) {
 	// create user filter
 	userFilter := make([]common.Address, 0)
 	if userAddress != nil {
@@ -167,7 +168,7 @@ func (ftm *FtmBridge) FLendGetUserDepositHistory(userAddress *common.Address, as
 	for fdi.Next() {
 		// get block for timestamp information
 		blkHash := fdi.Event.Raw.BlockHash.String()
-		blk, err := ftm.BlockByHash(&blkHash)
+		blk, err := ftm.BlockByHash(ctx, &blkHash)
 		if err != nil {
 			ftm.log.Errorf("fLend block with hash %s was not found: %s", blkHash, err.Error())
 			continue
@@ -32,40 +32,50 @@ import (
 // We use this barrier to subtract from current time, hence the negative value.
 const fMintRewardsPushTimeBarrier = time.Duration(-70) * time.Minute
 
+// fMintRatioDecimalsCorrection represents the correction of the fMint ratios represented in 4 digits.
+var fMintRatioDecimalsCorrection = big.NewInt(10000)
+
 // FMintAccount loads details of a DeFi/fMint protocol account identified by the owner address.
-func (ftm *FtmBridge) FMintAccount(owner *common.Address) (*types.FMintAccount, error) {
+func (ftm *FtmBridge) FMintAccount(ctx context.Context, owner *common.Address) (*types.FMintAccount, error) {
 	// make the container
 	var err error
 	da := types.FMintAccount{Address: *owner}
 
 	// load list of collateral tokens
-	tokenList, err := ftm.DefiTokenList()
+	tokenList, err := ftm.DefiTokenList(ctx)
 	if err != nil {
 		ftm.log.Errorf("collateral tokens list loader failed; %s", err.Error())
 		return nil, err
 	}
 
 	// debt tokens are the same
-	da.CollateralList = ftm.FMintFilterTokens(owner, tokenList, types.DefiTokenTypeCollateral)
-	da.DebtList = ftm.FMintFilterTokens(owner, tokenList, types.DefiTokenTypeDebt)
+	da.CollateralList = ftm.FMintFilterTokens(ctx, owner, tokenList, types.DefiTokenTypeCollateral)
+	da.DebtList = ftm.FMintFilterTokens(ctx, owner, tokenList, types.DefiTokenTypeDebt)
 
 	// get the current values of the account tokens on both collateral and debt
-	da.CollateralValue, da.DebtValue, err = ftm.fMintAccountValue(*owner)
+	da.CollateralValue, da.DebtValue, err = ftm.fMintAccountValue(ctx, *owner)
 	if err != nil {
 		ftm.log.Errorf("can not pull account tokens value; %s", err.Error())
 		return nil, err
 	}
 
+	// calculate the current collateral to debt ratio, if there is any debt
+	if da.DebtValue.ToInt().Sign() > 0 {
+		ratio := new(big.Int).Mul(da.CollateralValue.ToInt(), fMintRatioDecimalsCorrection)
+		ratio.Div(ratio, da.DebtValue.ToInt())
+		da.CollateralRatio4 = (*hexutil.Big)(ratio)
+	}
+
 	// return the account detail
 	return &da, nil
 }
 
 // FMintFilterTokens filter list of tokens to include only tokens with a balance of given type.
-func (ftm *FtmBridge) FMintFilterTokens(owner *common.Address, list []common.Address, tp types.DefiTokenType) []common.Address {
+func (ftm *FtmBridge) FMintFilterTokens(ctx context.Context, owner *common.Address, list []common.Address, tp types.DefiTokenType) []common.Address {
 	zero := new(big.Int)
 	result := make([]common.Address, 0)
 	for _, adr := range list {
-		val, err := ftm.FMintTokenBalance(owner, &adr, tp)
+		val, err := ftm.FMintTokenBalance(ctx, owner, &adr, tp)
 		if err != nil {
 			ftm.log.Errorf("failed to get the token balance; %s", err.Error())
 			continue
@@ -79,9 +89,9 @@ func (ftm *FtmBridge) FMintFilterTokens(owner *common.Address, list []common.Add
 }
 
 // FMintPoolBalance loads balance of an fMint token from the given pool contract.
-func (ftm *FtmBridge) FMintPoolBalance(pool *contracts.DeFiTokenStorage, owner *common.Address, token *common.Address) (hexutil.Big, error) {
+func (ftm *FtmBridge) FMintPoolBalance(ctx context.Context, pool *contracts.DeFiTokenStorage, owner *common.Address, token *common.Address) (hexutil.Big, error) {
 	// get the collateral token balance
-	val, err := pool.BalanceOf(nil, *owner, *token)
+	val, err := pool.BalanceOf(&bind.CallOpts{Context: ctx}, *owner, *token)
 	if err != nil {
 		ftm.log.Debugf("pool balance failed on token %s, account %s; %s", token.String(), owner.String(), err.Error())
 		return hexutil.Big{}, err
@@ -97,7 +107,7 @@ func (ftm *FtmBridge) FMintPoolBalance(pool *contracts.DeFiTokenStorage, owner *
 }
 
 // FMintTokenBalance loads balance of a single DeFi token in fMint contract by its address.
-func (ftm *FtmBridge) FMintTokenBalance(owner *common.Address, token *common.Address, tp types.DefiTokenType) (hexutil.Big, error) {
+func (ftm *FtmBridge) FMintTokenBalance(ctx context.Context, owner *common.Address, token *common.Address, tp types.DefiTokenType) (hexutil.Big, error) {
 	var err error
 	var pool *contracts.DeFiTokenStorage
 
@@ -121,11 +131,11 @@ func (ftm *FtmBridge) FMintTokenBalance(owner *common.Address, token *common.Add
 		return hexutil.Big{}, nil
 	}
 
-	return ftm.FMintPoolBalance(pool, owner, token)
+	return ftm.FMintPoolBalance(ctx, pool, owner, token)
 }
 
 // FMintTokenTotalBalance loads total balance of a single DeFi token by it's address.
-func (ftm *FtmBridge) FMintTokenTotalBalance(token *common.Address, tp types.DefiTokenType) (hexutil.Big, error) {
+func (ftm *FtmBridge) FMintTokenTotalBalance(ctx context.Context, token *common.Address, tp types.DefiTokenType) (hexutil.Big, error) {
 	var err error
 	var pool *contracts.DeFiTokenStorage
 
@@ -150,7 +160,7 @@ func (ftm *FtmBridge) FMintTokenTotalBalance(token *common.Address, tp types.Def
 	}
 
 	// get the collateral token balance
-	val, err := pool.TotalBalance(nil, *token)
+	val, err := pool.TotalBalance(&bind.CallOpts{Context: ctx}, *token)
 	if err != nil {
 		ftm.log.Debugf("pool total balance failed on token %s", token.String(), err.Error())
 		return hexutil.Big{}, err
@@ -166,16 +176,16 @@ func (ftm *FtmBridge) FMintTokenTotalBalance(token *common.Address, tp types.Def
 }
 
 // FMintTokenValue loads value of a single DeFi token by it's address in fUSD.
-func (ftm *FtmBridge) FMintTokenValue(owner *common.Address, token *common.Address, tp types.DefiTokenType) (hexutil.Big, error) {
+func (ftm *FtmBridge) FMintTokenValue(ctx context.Context, owner *common.Address, token *common.Address, tp types.DefiTokenType) (hexutil.Big, error) {
 	// get the balance
-	balance, err := ftm.FMintTokenBalance(owner, token, tp)
+	balance, err := ftm.FMintTokenBalance(ctx, owner, token, tp)
 	if err != nil {
 		ftm.log.Errorf("token %s balance unknown; %s", token.String(), err.Error())
 		return hexutil.Big{}, err
 	}
 
 	// get the price for the given token from oracle
-	val, err := ftm.FMintTokenPrice(token)
+	val, err := ftm.FMintTokenPrice(ctx, token)
 	if err != nil {
 		ftm.log.Errorf("token %s price not available; %s", token.String(), err.Error())
 		return hexutil.Big{}, err
@@ -187,7 +197,7 @@ func (ftm *FtmBridge) FMintTokenValue(owner *common.Address, token *common.Addre
 }
 
 // FMintTokenPrice loads the current price of the given token from on-chain price oracle.
-func (ftm *FtmBridge) FMintTokenPrice(token *common.Address) (hexutil.Big, error) {
+func (ftm *FtmBridge) FMintTokenPrice(ctx context.Context, token *common.Address) (hexutil.Big, error) {
 	// get the price oracle address
 	oracle, err := ftm.fMintCfg.priceOracleProxyContract()
 	if err != nil {
@@ -195,7 +205,7 @@ func (ftm *FtmBridge) FMintTokenPrice(token *common.Address) (hexutil.Big, error
 	}
 
 	// get the price for the given token from oracle
-	val, err := oracle.GetPrice(nil, *token)
+	val, err := oracle.GetPrice(&bind.CallOpts{Context: ctx}, *token)
 	if err != nil {
 		ftm.log.Errorf("price not available for token %s; %s", token.String(), err.Error())
 		return hexutil.Big{}, nil
@@ -210,8 +220,58 @@ func (ftm *FtmBridge) FMintTokenPrice(token *common.Address) (hexutil.Big, error
 	return hexutil.Big(*val), nil
 }
 
+// FMintLiquidationPrice calculates the price of the given collateral token on which
+// the collateral to debt ratio of the account drops below the lowest allowed ratio,
+// considering the prices of other tokens stay the same.
+// Nil is returned if the account does not have any debt, or the collateral token.
+func (ftm *FtmBridge) FMintLiquidationPrice(ctx context.Context, owner *common.Address, token *common.Address) (*hexutil.Big, error) {
+	// get the balance of the collateral token
+	balance, err := ftm.FMintTokenBalance(ctx, owner, token, types.DefiTokenTypeCollateral)
+	if err != nil {
+		return nil, err
+	}
+
+	// get the current values of the account tokens on both collateral and debt
+	cValue, dValue, err := ftm.fMintAccountValue(ctx, *owner)
+	if err != nil {
+		return nil, err
+	}
+	if balance.ToInt().Sign() == 0 || dValue.ToInt().Sign() == 0 {
+		return nil, nil
+	}
+
+	// get the lowest allowed collateral to debt ratio
+	contract, err := ftm.fMintCfg.fMintMinterContract()
+	if err != nil {
+		return nil, err
+	}
+	ratio, err := contract.GetCollateralLowestDebtRatio4dec(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		ftm.log.Errorf("lowest collateral ratio not available; %s", err.Error())
+		return nil, err
+	}
+
+	// get the current price of the token
+	price, err := ftm.FMintTokenPrice(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	// the value of the token the collateral can lose before reaching the lowest ratio
+	required := new(big.Int).Div(new(big.Int).Mul(dValue.ToInt(), ratio), fMintRatioDecimalsCorrection)
+	excess := new(big.Int).Sub(cValue.ToInt(), required)
+
+	// the token value is calculated as price x balance
+	drop := new(big.Int).Div(excess, balance.ToInt())
+	lp := new(big.Int).Sub(price.ToInt(), drop)
+	if lp.Sign() < 0 {
+		lp.SetUint64(0)
+	}
+	return (*hexutil.Big)(lp), nil
+}
+
 // fMintAccountTokensValue loads total value status of a given fMint account.
-func (ftm *FtmBridge) fMintAccountValue(owner common.Address) (hexutil.Big, hexutil.Big, error) {
+func (ftm *FtmBridge) fMintAccountValue(ctx context.Context, owner common.Address) (hexutil.Big, hexutil.Big, error) {
 	// connect the contract
 	contract, err := ftm.fMintCfg.fMintMinterContract()
 	if err != nil {
@@ -219,14 +279,14 @@ func (ftm *FtmBridge) fMintAccountValue(owner common.Address) (hexutil.Big, hexu
 	}
 
 	// get joined collateral value
-	cValue, err := contract.CollateralValueOf(nil, owner, common.Address{}, new(big.Int))
+	cValue, err := contract.CollateralValueOf(&bind.CallOpts{Context: ctx}, owner, common.Address{}, new(big.Int))
 	if err != nil {
 		ftm.log.Errorf("joined collateral value loader failed")
 		return hexutil.Big{}, hexutil.Big{}, err
 	}
 
 	// get joined debt value
-	dValue, err := contract.DebtValueOf(nil, owner, common.Address{}, new(big.Int))
+	dValue, err := contract.DebtValueOf(&bind.CallOpts{Context: ctx}, owner, common.Address{}, new(big.Int))
 	if err != nil {
 		ftm.log.Errorf("joined debt value loader failed")
 		return hexutil.Big{}, hexutil.Big{}, err
@@ -238,7 +298,7 @@ func (ftm *FtmBridge) fMintAccountValue(owner common.Address) (hexutil.Big, hexu
 
 // FMintRewardsEarned resolves the total amount of rewards
 // accumulated on the account for the excessive collateral deposits.
-func (ftm *FtmBridge) FMintRewardsEarned(addr *common.Address) (hexutil.Big, error) {
+func (ftm *FtmBridge) FMintRewardsEarned(ctx context.Context, addr *common.Address) (hexutil.Big, error) {
 	// connect the contract
 	contract, err := ftm.fMintCfg.fMintRewardsDistribution()
 	if err != nil {
@@ -246,7 +306,7 @@ func (ftm *FtmBridge) FMintRewardsEarned(addr *common.Address) (hexutil.Big, err
 	}
 
 	// get the block height
-	bl, err := ftm.BlockHeight()
+	bl, err := ftm.BlockHeight(ctx)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -257,7 +317,7 @@ func (ftm *FtmBridge) FMintRewardsEarned(addr *common.Address) (hexutil.Big, err
 		Pending:     false,
 		From:        *addr,
 		BlockNumber: bl.ToInt(),
-		Context:     context.Background(),
+		Context:     ctx,
 	}
 
 	// get the rewards
@@ -272,7 +332,7 @@ func (ftm *FtmBridge) FMintRewardsEarned(addr *common.Address) (hexutil.Big, err
 
 // FMintRewardsStashed resolves the total amount of rewards
 // accumulated on the account for the excessive collateral deposits.
-func (ftm *FtmBridge) FMintRewardsStashed(addr *common.Address) (hexutil.Big, error) {
+func (ftm *FtmBridge) FMintRewardsStashed(ctx context.Context, addr *common.Address) (hexutil.Big, error) {
 	// connect the contract
 	contract, err := ftm.fMintCfg.fMintRewardsDistribution()
 	if err != nil {
@@ -280,7 +340,7 @@ func (ftm *FtmBridge) FMintRewardsStashed(addr *common.Address) (hexutil.Big, er
 	}
 
 	// get the rewards
-	rw, err := contract.RewardStash(nil, *addr)
+	rw, err := contract.RewardStash(&bind.CallOpts{Context: ctx}, *addr)
 	if err != nil {
 		ftm.log.Errorf("can not calculate stashed rewards; %s", err.Error())
 		return hexutil.Big{}, err
@@ -291,7 +351,7 @@ func (ftm *FtmBridge) FMintRewardsStashed(addr *common.Address) (hexutil.Big, er
 
 // FMintCanClaimRewards resolves the fMint account flag for being allowed
 // to claim earned rewards.
-func (ftm *FtmBridge) FMintCanClaimRewards(addr *common.Address) (bool, error) {
+func (ftm *FtmBridge) FMintCanClaimRewards(ctx context.Context, addr *common.Address) (bool, error) {
 	// connect the contract
 	contract, err := ftm.fMintCfg.fMintMinterContract()
 	if err != nil {
@@ -300,7 +360,7 @@ func (ftm *FtmBridge) FMintCanClaimRewards(addr *common.Address) (bool, error) {
 	}
 
 	// ask if the claim is possible
-	flag, err := contract.RewardCanClaim(nil, *addr)
+	flag, err := contract.RewardCanClaim(&bind.CallOpts{Context: ctx}, *addr)
 	if err != nil {
 		ftm.log.Errorf("can not check rewards claim flag; %s", err.Error())
 		return false, err
@@ -312,7 +372,7 @@ func (ftm *FtmBridge) FMintCanClaimRewards(addr *common.Address) (bool, error) {
 // FMintCanReceiveRewards resolves the fMint account flag for being eligible
 // to receive earned rewards. If the collateral to debt ration drop below
 // certain value, earned rewards are burned.
-func (ftm *FtmBridge) FMintCanReceiveRewards(addr *common.Address) (bool, error) {
+func (ftm *FtmBridge) FMintCanReceiveRewards(ctx context.Context, addr *common.Address) (bool, error) {
 	// connect the contract
 	contract, err := ftm.fMintCfg.fMintMinterContract()
 	if err != nil {
@@ -321,7 +381,7 @@ func (ftm *FtmBridge) FMintCanReceiveRewards(addr *common.Address) (bool, error)
 	}
 
 	// ask if the claim is possible
-	flag, err := contract.RewardIsEligible(nil, *addr)
+	flag, err := contract.RewardIsEligible(&bind.CallOpts{Context: ctx}, *addr)
 	if err != nil {
 		ftm.log.Errorf("can not check rewards eligibility flag; %s", err.Error())
 		return false, err
@@ -332,7 +392,7 @@ func (ftm *FtmBridge) FMintCanReceiveRewards(addr *common.Address) (bool, error)
 
 // FMintCanPushRewards signals if there are any rewards unlocked
 // on the rewards distribution contract and can be pushed to accounts.
-func (ftm *FtmBridge) FMintCanPushRewards() (bool, error) {
+func (ftm *FtmBridge) FMintCanPushRewards(ctx context.Context) (bool, error) {
 	// connect the contract
 	contract, err := ftm.fMintCfg.fMintRewardsDistribution()
 	if err != nil {
@@ -340,7 +400,7 @@ func (ftm *FtmBridge) FMintCanPushRewards() (bool, error) {
 	}
 
 	// get the last time rewards were pushed
-	lastPush, err := contract.LastRewardPush(nil)
+	lastPush, err := contract.LastRewardPush(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("can not check rewards last push; %s", err.Error())
 		return false, err
@@ -14,6 +14,7 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"fantom-api-graphql/internal/types"
 	"fmt"
@@ -28,7 +29,7 @@ import (
 type tConfigItemsLoaders map[*hexutil.Big]func(*bind.CallOpts) (*big.Int, error)
 
 // DefiConfiguration resolves the current DeFi contract settings.
-func (ftm *FtmBridge) DefiConfiguration() (*types.DefiSettings, error) {
+func (ftm *FtmBridge) DefiConfiguration(ctx context.Context) (*types.DefiSettings, error) {
 	// access the contract
 	contract, err := ftm.fMintCfg.fMintMinterContract()
 	if err != nil {
@@ -54,13 +55,13 @@ func (ftm *FtmBridge) DefiConfiguration() (*types.DefiSettings, error) {
 	}
 
 	// load all the configured values
-	if err := ftm.pullSetOfDefiConfigValues(loaders); err != nil {
+	if err := ftm.pullSetOfDefiConfigValues(ctx, loaders); err != nil {
 		ftm.log.Errorf("can not pull defi config values; %s", err.Error())
 		return nil, err
 	}
 
 	// load the decimals correction
-	if ds.Decimals, err = ftm.pullDefiDecimalCorrection(contract); err != nil {
+	if ds.Decimals, err = ftm.pullDefiDecimalCorrection(ctx, contract); err != nil {
 		ftm.log.Errorf("can not pull defi decimals correction; %s", err.Error())
 		return nil, err
 	}
@@ -71,9 +72,9 @@ func (ftm *FtmBridge) DefiConfiguration() (*types.DefiSettings, error) {
 
 // pullSetOfDefiConfigValues pulls set of DeFi configuration values for the given
 // config loaders map.
-func (ftm *FtmBridge) pullDefiDecimalCorrection(con *contracts.DefiFMintMinter) (int32, error) {
+func (ftm *FtmBridge) pullDefiDecimalCorrection(ctx context.Context, con *contracts.DefiFMintMinter) (int32, error) {
 	// load the decimals correction
-	val, err := ftm.pullDefiConfigValue(con.FMintFeeDigitsCorrection)
+	val, err := ftm.pullDefiConfigValue(ctx, con.FMintFeeDigitsCorrection)
 	if err != nil {
 		ftm.log.Errorf("can not pull decimals correction; %s", err.Error())
 		return 0, err
@@ -93,13 +94,13 @@ func (ftm *FtmBridge) pullDefiDecimalCorrection(con *contracts.DefiFMintMinter)
 
 // pullSetOfDefiConfigValues pulls set of DeFi configuration values for the given
 // config loaders map.
-func (ftm *FtmBridge) pullSetOfDefiConfigValues(loaders tConfigItemsLoaders) error {
+func (ftm *FtmBridge) pullSetOfDefiConfigValues(ctx context.Context, loaders tConfigItemsLoaders) error {
 	// collect loaders error
 	var err error
 
 	// loop the map and load the values
 	for ref, fn := range loaders {
-		*ref, err = ftm.pullDefiConfigValue(fn)
+		*ref, err = ftm.pullDefiConfigValue(ctx, fn)
 		if err != nil {
 			return err
 		}
@@ -109,9 +110,9 @@ func (ftm *FtmBridge) pullSetOfDefiConfigValues(loaders tConfigItemsLoaders) err
 }
 
 // tradeFee4 pulls DeFi trading fee from the Liquidity Pool contract.
-func (ftm *FtmBridge) pullDefiConfigValue(cf func(*bind.CallOpts) (*big.Int, error)) (hexutil.Big, error) {
+func (ftm *FtmBridge) pullDefiConfigValue(ctx context.Context, cf func(*bind.CallOpts) (*big.Int, error)) (hexutil.Big, error) {
 	// pull the trading fee value
-	val, err := cf(nil)
+	val, err := cf(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		return hexutil.Big{}, err
 	}
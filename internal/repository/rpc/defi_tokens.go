@@ -16,6 +16,7 @@ package rpc
 //go:generate tools/abigen.sh --abi ./contracts/abi/defi-tokens-registry.abi --pkg contracts --type DefiFMintTokenRegistry --out ./contracts/fmint_tokens.go
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"fantom-api-graphql/internal/types"
 	"fmt"
@@ -26,47 +27,47 @@ import (
 )
 
 // DefiTokens resolves list of DeFi tokens available for the DeFi functions.
-func (ftm *FtmBridge) DefiTokens() ([]types.DefiToken, error) {
+func (ftm *FtmBridge) DefiTokens(ctx context.Context) ([]types.DefiToken, error) {
 	// connect the contract
 	contract, err := ftm.fMintCfg.tokenRegistryContract()
 	if err != nil {
 		return nil, err
 	}
 
-	return ftm.defiTokensList(contract)
+	return ftm.defiTokensList(ctx, contract)
 }
 
 // DefiTokenList creates a list of addresses / identifiers of all the ERC20 tokens
 // involved with the fMint protocol.
-func (ftm *FtmBridge) DefiTokenList() ([]common.Address, error) {
+func (ftm *FtmBridge) DefiTokenList(ctx context.Context) ([]common.Address, error) {
 	// connect the contract
 	contract, err := ftm.fMintCfg.tokenRegistryContract()
 	if err != nil {
 		return nil, err
 	}
 
-	return ftm.defiTokenAddressList(contract.TokensCount, contract.TokensList)
+	return ftm.defiTokenAddressList(ctx, contract.TokensCount, contract.TokensList)
 }
 
 // DefiToken loads details of a single DeFi token by it's address.
-func (ftm *FtmBridge) DefiToken(token *common.Address) (*types.DefiToken, error) {
+func (ftm *FtmBridge) DefiToken(ctx context.Context, token *common.Address) (*types.DefiToken, error) {
 	// connect the contract
 	contract, err := ftm.fMintCfg.tokenRegistryContract()
 	if err != nil {
 		return nil, err
 	}
 
-	return ftm.defiTokenDetail(contract, token)
+	return ftm.defiTokenDetail(ctx, contract, token)
 }
 
 // defiTokenAddressList load list of addresses of tokens using given
 // count function and item access function to do the loading.
-func (ftm *FtmBridge) defiTokenAddressList(
+func (ftm *FtmBridge) defiTokenAddressList(ctx context.Context,
 	fCount func(*bind.CallOpts) (*big.Int, error),
 	fItem func(*bind.CallOpts, *big.Int) (common.Address, error),
 ) ([]common.Address, error) {
 	// get the number of tokens in the reference aggregator
-	count, err := fCount(nil)
+	count, err := fCount(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("can not get tokens range; %s", err.Error())
 		return nil, err
@@ -79,7 +80,7 @@ func (ftm *FtmBridge) defiTokenAddressList(
 	// load all the tokens in the contract
 	for i := uint64(0); i < count.Uint64(); i++ {
 		// read the indexed token from contract
-		list[i], err = fItem(nil, index.SetUint64(i))
+		list[i], err = fItem(&bind.CallOpts{Context: ctx}, index.SetUint64(i))
 		if err != nil {
 			ftm.log.Errorf("token %d address not found; %s", i, err.Error())
 			return nil, err
@@ -90,9 +91,9 @@ func (ftm *FtmBridge) defiTokenAddressList(
 }
 
 // defiTokenDetail loads details of a token specified by the token address.
-func (ftm *FtmBridge) defiTokenDetail(contract *contracts.DefiFMintTokenRegistry, token *common.Address) (*types.DefiToken, error) {
+func (ftm *FtmBridge) defiTokenDetail(ctx context.Context, contract *contracts.DefiFMintTokenRegistry, token *common.Address) (*types.DefiToken, error) {
 	// get the token details
-	tk, err := contract.Tokens(nil, *token)
+	tk, err := contract.Tokens(&bind.CallOpts{Context: ctx}, *token)
 	if err != nil {
 		ftm.log.Errorf("token %s not found; %s", token.String(), err.Error())
 		return nil, err
@@ -109,9 +110,9 @@ func (ftm *FtmBridge) defiTokenDetail(contract *contracts.DefiFMintTokenRegistry
 }
 
 // defiTokensList loads list of DeFi tokens from the smart contract.
-func (ftm *FtmBridge) defiTokensList(contract *contracts.DefiFMintTokenRegistry) ([]types.DefiToken, error) {
+func (ftm *FtmBridge) defiTokensList(ctx context.Context, contract *contracts.DefiFMintTokenRegistry) ([]types.DefiToken, error) {
 	// get tge list of addresses
-	al, err := ftm.defiTokenAddressList(contract.TokensCount, contract.TokensList)
+	al, err := ftm.defiTokenAddressList(ctx, contract.TokensCount, contract.TokensList)
 	if err != nil {
 		ftm.log.Errorf("tokens list not available; %s", err.Error())
 		return nil, err
@@ -123,7 +124,7 @@ func (ftm *FtmBridge) defiTokensList(contract *contracts.DefiFMintTokenRegistry)
 	// load all the tokens in the contract
 	for i, addr := range al {
 		// decode the token
-		tk, err := ftm.defiTokenDetail(contract, &addr)
+		tk, err := ftm.defiTokenDetail(ctx, contract, &addr)
 		if err != nil {
 			ftm.log.Errorf("invalid token #%d; %s", i, err.Error())
 			return nil, err
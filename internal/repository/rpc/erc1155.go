@@ -14,8 +14,10 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"math/big"
 	"strings"
@@ -24,7 +26,7 @@ import (
 //go:generate tools/abigen.sh --abi ./contracts/abi/erc1155.abi --pkg contracts --type ERC1155 --out ./contracts/erc1155_token.go
 
 // Erc1155Uri provides URI of Metadata JSON Schema of the ERC1155 token.
-func (ftm *FtmBridge) Erc1155Uri(token *common.Address, tokenId *big.Int) (string, error) {
+func (ftm *FtmBridge) Erc1155Uri(ctx context.Context, token *common.Address, tokenId *big.Int) (string, error) {
 	// connect the contract
 	contract, err := contracts.NewERC1155(*token, ftm.eth)
 	if err != nil {
@@ -33,7 +35,7 @@ func (ftm *FtmBridge) Erc1155Uri(token *common.Address, tokenId *big.Int) (strin
 	}
 
 	// get the token name
-	uri, err := contract.Uri(nil, tokenId)
+	uri, err := contract.Uri(&bind.CallOpts{Context: ctx}, tokenId)
 	if err != nil {
 		ftm.log.Errorf("ERC1155 token %s/%s URI not available; %s", token.String(), tokenId.String(), err.Error())
 		return "", err
@@ -43,7 +45,7 @@ func (ftm *FtmBridge) Erc1155Uri(token *common.Address, tokenId *big.Int) (strin
 }
 
 // Erc1155BalanceOf provides amount of tokens owned by given owner in given ERC1155 contract.
-func (ftm *FtmBridge) Erc1155BalanceOf(token *common.Address, owner *common.Address, tokenId *big.Int) (*big.Int, error) {
+func (ftm *FtmBridge) Erc1155BalanceOf(ctx context.Context, token *common.Address, owner *common.Address, tokenId *big.Int) (*big.Int, error) {
 	// connect the contract
 	contract, err := contracts.NewERC1155(*token, ftm.eth)
 	if err != nil {
@@ -51,7 +53,7 @@ func (ftm *FtmBridge) Erc1155BalanceOf(token *common.Address, owner *common.Addr
 		return nil, err
 	}
 
-	balance, err := contract.BalanceOf(nil, *owner, tokenId)
+	balance, err := contract.BalanceOf(&bind.CallOpts{Context: ctx}, *owner, tokenId)
 	if err != nil {
 		ftm.log.Errorf("can not get ERC1155 %s/%s balance for %s; %s", token.String(), tokenId.String(), owner.String(), err.Error())
 		return nil, err
@@ -61,7 +63,7 @@ func (ftm *FtmBridge) Erc1155BalanceOf(token *common.Address, owner *common.Addr
 }
 
 // Erc1155BalanceOfBatch provides amounts of tokens owned by given owners in given ERC1155 contract.
-func (ftm *FtmBridge) Erc1155BalanceOfBatch(token *common.Address, owners *[]common.Address, tokenIds []*big.Int) ([]*big.Int, error) {
+func (ftm *FtmBridge) Erc1155BalanceOfBatch(ctx context.Context, token *common.Address, owners *[]common.Address, tokenIds []*big.Int) ([]*big.Int, error) {
 	// connect the contract
 	contract, err := contracts.NewERC1155(*token, ftm.eth)
 	if err != nil {
@@ -69,7 +71,7 @@ func (ftm *FtmBridge) Erc1155BalanceOfBatch(token *common.Address, owners *[]com
 		return nil, err
 	}
 
-	balances, err := contract.BalanceOfBatch(nil, *owners, tokenIds)
+	balances, err := contract.BalanceOfBatch(&bind.CallOpts{Context: ctx}, *owners, tokenIds)
 	if err != nil {
 		ftm.log.Errorf("can not get ERC1155 batch balance for %s; %s", token.String(), err.Error())
 		return nil, err
@@ -79,7 +81,7 @@ func (ftm *FtmBridge) Erc1155BalanceOfBatch(token *common.Address, owners *[]com
 }
 
 // Erc1155IsApprovedForAll provides information about operator approved to manipulate with tokens of given owner.
-func (ftm *FtmBridge) Erc1155IsApprovedForAll(token *common.Address, owner *common.Address, operator *common.Address) (bool, error) {
+func (ftm *FtmBridge) Erc1155IsApprovedForAll(ctx context.Context, token *common.Address, owner *common.Address, operator *common.Address) (bool, error) {
 	// connect the contract
 	contract, err := contracts.NewERC1155(*token, ftm.eth)
 	if err != nil {
@@ -87,7 +89,7 @@ func (ftm *FtmBridge) Erc1155IsApprovedForAll(token *common.Address, owner *comm
 		return false, err
 	}
 
-	isApproved, err := contract.IsApprovedForAll(nil, *owner, *operator)
+	isApproved, err := contract.IsApprovedForAll(&bind.CallOpts{Context: ctx}, *owner, *operator)
 	if err != nil {
 		ftm.log.Errorf("can not get ERC1155 %s approved-for-all status for owner %s and operator %s; %s", token.String(), owner.String(), operator.String(), err.Error())
 		return false, err
@@ -0,0 +1,43 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// erc1271MagicValue represents the value returned by an ERC1271 contract for a valid signature.
+var erc1271MagicValue = hexutil.MustDecode("0x1626ba7e")
+
+// erc1271Abi represents the ABI of the ERC1271 signature validation function.
+var erc1271Abi = mustParseAbi(`[{"inputs":[{"name":"hash","type":"bytes32"},{"name":"signature","type":"bytes"}],"name":"isValidSignature","outputs":[{"name":"magicValue","type":"bytes4"}],"stateMutability":"view","type":"function"}]`)
+
+// Erc1271IsValidSignature checks if the given signature of the hash is valid
+// for the contract wallet at the given address using the ERC1271 interface.
+func (ftm *FtmBridge) Erc1271IsValidSignature(ctx context.Context, address *common.Address, hash common.Hash, sig []byte) (bool, error) {
+	data, err := erc1271Abi.Pack("isValidSignature", hash, sig)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := ftm.ContractCall(ctx, address, data, nil)
+	if err != nil {
+		ftm.log.Noticef("ERC1271 signature check of %s failed; %s", address.String(), err.Error())
+		return false, err
+	}
+	return len(res) >= len(erc1271MagicValue) && bytes.Equal(res[:len(erc1271MagicValue)], erc1271MagicValue), nil
+}
@@ -14,13 +14,15 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 //go:generate tools/abigen.sh --abi ./contracts/abi/erc165.abi --pkg contracts --type ERC165 --out ./contracts/erc165.go
 
-func (ftm *FtmBridge) Erc165SupportsInterface(address *common.Address, interfaceID [4]byte) (bool, error) {
+func (ftm *FtmBridge) Erc165SupportsInterface(ctx context.Context, address *common.Address, interfaceID [4]byte) (bool, error) {
 	// connect the contract
 	contract, err := contracts.NewERC165(*address, ftm.eth)
 	if err != nil {
@@ -28,11 +30,11 @@ func (ftm *FtmBridge) Erc165SupportsInterface(address *common.Address, interface
 		return false, err
 	}
 
-	supports, err := contract.SupportsInterface(nil, interfaceID)
+	supports, err := contract.SupportsInterface(&bind.CallOpts{Context: ctx}, interfaceID)
 	if err != nil {
 		ftm.log.Noticef("interface support by ERC165 for contract %s cannot be detected; %s", address.String(), err.Error())
 		return false, err
 	}
 
 	return supports, nil
-}
\ No newline at end of file
+}
@@ -14,7 +14,9 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
@@ -24,7 +26,7 @@ import (
 //go:generate tools/abigen.sh --abi ./contracts/abi/wftm.abi --pkg contracts --type ErcWrappedFtm --out ./contracts/erc20wftm_token.go
 
 // Erc20Name provides information about the name of the ERC20 token.
-func (ftm *FtmBridge) Erc20Name(token *common.Address) (string, error) {
+func (ftm *FtmBridge) Erc20Name(ctx context.Context, token *common.Address) (string, error) {
 	// connect the contract
 	contract, err := contracts.NewERCTwenty(*token, ftm.eth)
 	if err != nil {
@@ -33,7 +35,7 @@ func (ftm *FtmBridge) Erc20Name(token *common.Address) (string, error) {
 	}
 
 	// get the token name
-	name, err := contract.Name(nil)
+	name, err := contract.Name(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("ERC20 token %s name not available; %s", token.String(), err.Error())
 		return "", err
@@ -43,7 +45,7 @@ func (ftm *FtmBridge) Erc20Name(token *common.Address) (string, error) {
 }
 
 // Erc20Symbol provides information about the symbol of the ERC20 token.
-func (ftm *FtmBridge) Erc20Symbol(token *common.Address) (string, error) {
+func (ftm *FtmBridge) Erc20Symbol(ctx context.Context, token *common.Address) (string, error) {
 	// connect the contract
 	contract, err := contracts.NewERCTwenty(*token, ftm.eth)
 	if err != nil {
@@ -52,7 +54,7 @@ func (ftm *FtmBridge) Erc20Symbol(token *common.Address) (string, error) {
 	}
 
 	// get the token name
-	symbol, err := contract.Symbol(nil)
+	symbol, err := contract.Symbol(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("ERC20 token %s symbol not available; %s", token.String(), err.Error())
 		return "", err
@@ -62,7 +64,7 @@ func (ftm *FtmBridge) Erc20Symbol(token *common.Address) (string, error) {
 }
 
 // Erc20Decimals provides information about the decimals of the ERC20 token.
-func (ftm *FtmBridge) Erc20Decimals(token *common.Address) (int32, error) {
+func (ftm *FtmBridge) Erc20Decimals(ctx context.Context, token *common.Address) (int32, error) {
 	// connect the contract
 	contract, err := contracts.NewERCTwenty(*token, ftm.eth)
 	if err != nil {
@@ -71,7 +73,7 @@ func (ftm *FtmBridge) Erc20Decimals(token *common.Address) (int32, error) {
 	}
 
 	// get the token name
-	deci, err := contract.Decimals(nil)
+	deci, err := contract.Decimals(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("ERC20 token %s decimals not available; %s", token.String(), err.Error())
 		return 0, nil
@@ -82,7 +84,7 @@ func (ftm *FtmBridge) Erc20Decimals(token *common.Address) (int32, error) {
 
 // Erc20BalanceOf loads the current available balance of and ERC20 token identified by the token
 // contract address for an identified owner address.
-func (ftm *FtmBridge) Erc20BalanceOf(token *common.Address, owner *common.Address) (hexutil.Big, error) {
+func (ftm *FtmBridge) Erc20BalanceOf(ctx context.Context, token *common.Address, owner *common.Address) (hexutil.Big, error) {
 	// connect the contract
 	contract, err := contracts.NewERCTwenty(*token, ftm.eth)
 	if err != nil {
@@ -91,7 +93,7 @@ func (ftm *FtmBridge) Erc20BalanceOf(token *common.Address, owner *common.Addres
 	}
 
 	// get the balance
-	val, err := contract.BalanceOf(nil, *owner)
+	val, err := contract.BalanceOf(&bind.CallOpts{Context: ctx}, *owner)
 	if err != nil {
 		ftm.log.Errorf("can not ERC20 %s balance for %s; %s", token.String(), owner.String(), err.Error())
 		return hexutil.Big{}, err
@@ -111,7 +113,7 @@ func (ftm *FtmBridge) Erc20BalanceOf(token *common.Address, owner *common.Addres
 
 // Erc20Allowance loads the current amount of ERC20 tokens unlocked for DeFi
 // contract by the token owner.
-func (ftm *FtmBridge) Erc20Allowance(token *common.Address, owner *common.Address, spender *common.Address) (hexutil.Big, error) {
+func (ftm *FtmBridge) Erc20Allowance(ctx context.Context, token *common.Address, owner *common.Address, spender *common.Address) (hexutil.Big, error) {
 	// connect the contract
 	contract, err := contracts.NewERCTwenty(*token, ftm.eth)
 	if err != nil {
@@ -126,7 +128,7 @@ func (ftm *FtmBridge) Erc20Allowance(token *common.Address, owner *common.Addres
 	}
 
 	// get the amount of tokens allowed for DeFi
-	val, err := contract.Allowance(nil, *owner, *spender)
+	val, err := contract.Allowance(&bind.CallOpts{Context: ctx}, *owner, *spender)
 	if err != nil {
 		ftm.log.Errorf("can not get defi ERC20 %s allowance for %s; %s", token.String(), owner.String(), err.Error())
 		return hexutil.Big{}, err
@@ -145,7 +147,7 @@ func (ftm *FtmBridge) Erc20Allowance(token *common.Address, owner *common.Addres
 }
 
 // Erc20TotalSupply provides information about all available tokens
-func (ftm *FtmBridge) Erc20TotalSupply(token *common.Address) (hexutil.Big, error) {
+func (ftm *FtmBridge) Erc20TotalSupply(ctx context.Context, token *common.Address) (hexutil.Big, error) {
 	// connect the contract
 	contract, err := contracts.NewERCTwenty(*token, ftm.eth)
 	if err != nil {
@@ -154,7 +156,7 @@ func (ftm *FtmBridge) Erc20TotalSupply(token *common.Address) (hexutil.Big, erro
 	}
 
 	// get the amount of tokens allowed for DeFi
-	val, err := contract.TotalSupply(nil)
+	val, err := contract.TotalSupply(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("can not get ERC20 %s total supply; %s", token.String(), err.Error())
 		return hexutil.Big{}, err
@@ -14,7 +14,9 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
@@ -23,7 +25,7 @@ import (
 //go:generate tools/abigen.sh --abi ./contracts/abi/erc721.abi --pkg contracts --type ERC721 --out ./contracts/erc721_token.go
 
 // Erc721Name provides information about the name of the ERC721 token.
-func (ftm *FtmBridge) Erc721Name(token *common.Address) (string, error) {
+func (ftm *FtmBridge) Erc721Name(ctx context.Context, token *common.Address) (string, error) {
 	// connect the contract
 	contract, err := contracts.NewERC721(*token, ftm.eth)
 	if err != nil {
@@ -32,7 +34,7 @@ func (ftm *FtmBridge) Erc721Name(token *common.Address) (string, error) {
 	}
 
 	// get the token name
-	name, err := contract.Name(nil)
+	name, err := contract.Name(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("ERC721 token %s name not available; %s", token.String(), err.Error())
 		return "", err
@@ -42,7 +44,7 @@ func (ftm *FtmBridge) Erc721Name(token *common.Address) (string, error) {
 }
 
 // Erc721Symbol provides information about the symbol of the ERC721 token.
-func (ftm *FtmBridge) Erc721Symbol(token *common.Address) (string, error) {
+func (ftm *FtmBridge) Erc721Symbol(ctx context.Context, token *common.Address) (string, error) {
 	// connect the contract
 	contract, err := contracts.NewERC721(*token, ftm.eth)
 	if err != nil {
@@ -51,7 +53,7 @@ func (ftm *FtmBridge) Erc721Symbol(token *common.Address) (string, error) {
 	}
 
 	// get the token name
-	symbol, err := contract.Symbol(nil)
+	symbol, err := contract.Symbol(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("ERC721 token %s symbol not available; %s", token.String(), err.Error())
 		return "", err
@@ -61,7 +63,7 @@ func (ftm *FtmBridge) Erc721Symbol(token *common.Address) (string, error) {
 }
 
 // Erc721BalanceOf provides amount of NFT tokens owned by given owner in given ERC721 contract.
-func (ftm *FtmBridge) Erc721BalanceOf(token *common.Address, owner *common.Address) (hexutil.Big, error) {
+func (ftm *FtmBridge) Erc721BalanceOf(ctx context.Context, token *common.Address, owner *common.Address) (hexutil.Big, error) {
 	// connect the contract
 	contract, err := contracts.NewERC721(*token, ftm.eth)
 	if err != nil {
@@ -70,7 +72,7 @@ func (ftm *FtmBridge) Erc721BalanceOf(token *common.Address, owner *common.Addre
 	}
 
 	// get the balance
-	val, err := contract.BalanceOf(nil, *owner)
+	val, err := contract.BalanceOf(&bind.CallOpts{Context: ctx}, *owner)
 	if err != nil {
 		ftm.log.Errorf("can not ERC721 %s balance for %s; %s", token.String(), owner.String(), err.Error())
 		return hexutil.Big{}, err
@@ -89,7 +91,7 @@ func (ftm *FtmBridge) Erc721BalanceOf(token *common.Address, owner *common.Addre
 }
 
 // Erc721TotalSupply provides information about all available tokens
-func (ftm *FtmBridge) Erc721TotalSupply(token *common.Address) (hexutil.Big, error) {
+func (ftm *FtmBridge) Erc721TotalSupply(ctx context.Context, token *common.Address) (hexutil.Big, error) {
 	// connect the contract
 	contract, err := contracts.NewERC721(*token, ftm.eth)
 	if err != nil {
@@ -98,7 +100,7 @@ func (ftm *FtmBridge) Erc721TotalSupply(token *common.Address) (hexutil.Big, err
 	}
 
 	// get the amount of tokens allowed for DeFi
-	val, err := contract.TotalSupply(nil)
+	val, err := contract.TotalSupply(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("can not get ERC721 %s total supply; %s", token.String(), err.Error())
 		return hexutil.Big{}, err
@@ -115,7 +117,7 @@ func (ftm *FtmBridge) Erc721TotalSupply(token *common.Address) (hexutil.Big, err
 }
 
 // Erc721TokenURI provides URI of Metadata JSON Schema of the ERC721 token.
-func (ftm *FtmBridge) Erc721TokenURI(token *common.Address, tokenId *big.Int) (string, error) {
+func (ftm *FtmBridge) Erc721TokenURI(ctx context.Context, token *common.Address, tokenId *big.Int) (string, error) {
 	// connect the contract
 	contract, err := contracts.NewERC721(*token, ftm.eth)
 	if err != nil {
@@ -124,7 +126,7 @@ func (ftm *FtmBridge) Erc721TokenURI(token *common.Address, tokenId *big.Int) (s
 	}
 
 	// get the token name
-	uri, err := contract.TokenURI(nil, tokenId)
+	uri, err := contract.TokenURI(&bind.CallOpts{Context: ctx}, tokenId)
 	if err != nil {
 		ftm.log.Errorf("ERC721 token %s/%s URI not available; %s", token.String(), tokenId.String(), err.Error())
 		return "", err
@@ -134,7 +136,7 @@ func (ftm *FtmBridge) Erc721TokenURI(token *common.Address, tokenId *big.Int) (s
 }
 
 // Erc721OwnerOf provides information about NFT token ownership
-func (ftm *FtmBridge) Erc721OwnerOf(token *common.Address, tokenId *big.Int) (common.Address, error) {
+func (ftm *FtmBridge) Erc721OwnerOf(ctx context.Context, token *common.Address, tokenId *big.Int) (common.Address, error) {
 	// connect the contract
 	contract, err := contracts.NewERC721(*token, ftm.eth)
 	if err != nil {
@@ -142,7 +144,7 @@ func (ftm *FtmBridge) Erc721OwnerOf(token *common.Address, tokenId *big.Int) (co
 		return common.Address{}, err
 	}
 
-	owner, err := contract.OwnerOf(nil, tokenId)
+	owner, err := contract.OwnerOf(&bind.CallOpts{Context: ctx}, tokenId)
 	if err != nil {
 		ftm.log.Errorf("can not get ERC721 %s owner of %s; %s", token.String(), tokenId.String(), err.Error())
 		return common.Address{}, err
@@ -152,7 +154,7 @@ func (ftm *FtmBridge) Erc721OwnerOf(token *common.Address, tokenId *big.Int) (co
 }
 
 // Erc721GetApproved provides information about operator approved to manipulate with the NFT token.
-func (ftm *FtmBridge) Erc721GetApproved(token *common.Address, tokenId *big.Int) (common.Address, error) {
+func (ftm *FtmBridge) Erc721GetApproved(ctx context.Context, token *common.Address, tokenId *big.Int) (common.Address, error) {
 	// connect the contract
 	contract, err := contracts.NewERC721(*token, ftm.eth)
 	if err != nil {
@@ -160,7 +162,7 @@ func (ftm *FtmBridge) Erc721GetApproved(token *common.Address, tokenId *big.Int)
 		return common.Address{}, err
 	}
 
-	owner, err := contract.GetApproved(nil, tokenId)
+	owner, err := contract.GetApproved(&bind.CallOpts{Context: ctx}, tokenId)
 	if err != nil {
 		ftm.log.Errorf("can not get ERC721 %s approved operator of %s; %s", token.String(), tokenId.String(), err.Error())
 		return common.Address{}, err
@@ -170,7 +172,7 @@ func (ftm *FtmBridge) Erc721GetApproved(token *common.Address, tokenId *big.Int)
 }
 
 // Erc721IsApprovedForAll provides information about operator approved to manipulate with NFT tokens of given owner.
-func (ftm *FtmBridge) Erc721IsApprovedForAll(token *common.Address, owner *common.Address, operator *common.Address) (bool, error) {
+func (ftm *FtmBridge) Erc721IsApprovedForAll(ctx context.Context, token *common.Address, owner *common.Address, operator *common.Address) (bool, error) {
 	// connect the contract
 	contract, err := contracts.NewERC721(*token, ftm.eth)
 	if err != nil {
@@ -178,11 +180,11 @@ func (ftm *FtmBridge) Erc721IsApprovedForAll(token *common.Address, owner *commo
 		return false, err
 	}
 
-	isApproved, err := contract.IsApprovedForAll(nil, *owner, *operator)
+	isApproved, err := contract.IsApprovedForAll(&bind.CallOpts{Context: ctx}, *owner, *operator)
 	if err != nil {
 		ftm.log.Errorf("can not get ERC721 %s approved-for-all status for owner %s and operator %s; %s", token.String(), owner.String(), operator.String(), err.Error())
 		return false, err
 	}
 
 	return isApproved, nil
-}
\ No newline at end of file
+}
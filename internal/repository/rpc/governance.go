@@ -14,10 +14,13 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
 	"math/big"
 	"strings"
 )
@@ -35,7 +38,7 @@ type govProposalExtended struct {
 
 // GovernanceProposalsCount provides the total number of proposals
 // in a given Governance contract.
-func (ftm *FtmBridge) GovernanceProposalsCount(gov *common.Address) (hexutil.Big, error) {
+func (ftm *FtmBridge) GovernanceProposalsCount(ctx context.Context, gov *common.Address) (hexutil.Big, error) {
 	// get the contract
 	gc, err := contracts.NewGovernance(*gov, ftm.eth)
 	if err != nil {
@@ -44,7 +47,7 @@ func (ftm *FtmBridge) GovernanceProposalsCount(gov *common.Address) (hexutil.Big
 	}
 
 	// get the last proposal id
-	id, err := gc.LastProposalID(nil)
+	id, err := gc.LastProposalID(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("can not count governance %s proposals; %s", gov.String(), err.Error())
 		return hexutil.Big{}, err
@@ -55,7 +58,7 @@ func (ftm *FtmBridge) GovernanceProposalsCount(gov *common.Address) (hexutil.Big
 
 // GovernanceProposal provides a detail of Proposal of a governance contract
 // specified by its id.
-func (ftm *FtmBridge) GovernanceProposal(gov *common.Address, id *hexutil.Big) (*types.GovernanceProposal, error) {
+func (ftm *FtmBridge) GovernanceProposal(ctx context.Context, gov *common.Address, id *hexutil.Big) (*types.GovernanceProposal, error) {
 	// get the contract
 	gc, err := contracts.NewGovernance(*gov, ftm.eth)
 	if err != nil {
@@ -64,7 +67,7 @@ func (ftm *FtmBridge) GovernanceProposal(gov *common.Address, id *hexutil.Big) (
 	}
 
 	// get the detail
-	gp, err := ftm.governanceProposalDetail(gc, gov, id.ToInt())
+	gp, err := ftm.governanceProposalDetail(ctx, gc, gov, id.ToInt())
 	if err != nil {
 		ftm.log.Errorf("proposal %d not available in %s; %s", id.ToInt().Uint64(), gov.String(), err.Error())
 		return nil, err
@@ -75,15 +78,15 @@ func (ftm *FtmBridge) GovernanceProposal(gov *common.Address, id *hexutil.Big) (
 
 // GovernanceProposal provides a detail of Proposal of a governance contract
 // specified by its id.
-func (ftm *FtmBridge) governanceProposalDetail(gc *contracts.Governance, govId *common.Address, id *big.Int) (*types.GovernanceProposal, error) {
+func (ftm *FtmBridge) governanceProposalDetail(ctx context.Context, gc *contracts.Governance, govId *common.Address, id *big.Int) (*types.GovernanceProposal, error) {
 	// try to get proposal params
-	data, err := gc.ProposalParams(nil, id)
+	data, err := gc.ProposalParams(&bind.CallOpts{Context: ctx}, id)
 	if err != nil {
 		return nil, err
 	}
 
 	// get some details about the proposal from its contract
-	ext, err := ftm.GovernanceProposalDetails(&data.ProposalContract)
+	ext, err := ftm.GovernanceProposalDetails(ctx, &data.ProposalContract)
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +112,7 @@ func (ftm *FtmBridge) governanceProposalDetail(gc *contracts.Governance, govId *
 
 // GovernanceProposalState provides a state of Proposal of a governance contract
 // specified by its id.
-func (ftm *FtmBridge) GovernanceProposalState(gov *common.Address, id *hexutil.Big) (*types.GovernanceProposalState, error) {
+func (ftm *FtmBridge) GovernanceProposalState(ctx context.Context, gov *common.Address, id *hexutil.Big) (*types.GovernanceProposalState, error) {
 	// get the contract
 	gc, err := contracts.NewGovernance(*gov, ftm.eth)
 	if err != nil {
@@ -118,7 +121,7 @@ func (ftm *FtmBridge) GovernanceProposalState(gov *common.Address, id *hexutil.B
 	}
 
 	// get the state
-	st, err := gc.ProposalState(nil, id.ToInt())
+	st, err := gc.ProposalState(&bind.CallOpts{Context: ctx}, id.ToInt())
 	if err != nil {
 		ftm.log.Errorf("can not access governance %s proposal %d state; %s", gov.String(), id.ToInt().Int64(), err.Error())
 		return nil, err
@@ -167,7 +170,7 @@ func govConvertScales(sc []*big.Int) []hexutil.Uint64 {
 
 // GovernanceProposalDetails provides a detail of Proposal of a governance contract
 // specified by its id.
-func (ftm *FtmBridge) GovernanceProposalDetails(prop *common.Address) (*govProposalExtended, error) {
+func (ftm *FtmBridge) GovernanceProposalDetails(ctx context.Context, prop *common.Address) (*govProposalExtended, error) {
 	// get the proposal contract
 	pp, err := contracts.NewGovernanceProposal(*prop, ftm.eth)
 	if err != nil {
@@ -179,14 +182,14 @@ func (ftm *FtmBridge) GovernanceProposalDetails(prop *common.Address) (*govPropo
 	ge := govProposalExtended{}
 
 	// load the name
-	ge.Name, err = pp.Name(nil)
+	ge.Name, err = pp.Name(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("governance proposal %s name not available; %s", prop.String(), err.Error())
 		return nil, err
 	}
 
 	// load the description
-	ge.Desc, err = pp.Description(nil)
+	ge.Desc, err = pp.Description(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("governance proposal %s description not available; %s", prop.String(), err.Error())
 		return nil, err
@@ -196,7 +199,7 @@ func (ftm *FtmBridge) GovernanceProposalDetails(prop *common.Address) (*govPropo
 }
 
 // GovernanceOptionState returns a state of the given option of a proposal.
-func (ftm *FtmBridge) GovernanceOptionState(gov *common.Address, propId *hexutil.Big, optId *hexutil.Big) (*types.GovernanceOptionState, error) {
+func (ftm *FtmBridge) GovernanceOptionState(ctx context.Context, gov *common.Address, propId *hexutil.Big, optId *hexutil.Big) (*types.GovernanceOptionState, error) {
 	// get the contract
 	gc, err := contracts.NewGovernance(*gov, ftm.eth)
 	if err != nil {
@@ -205,7 +208,7 @@ func (ftm *FtmBridge) GovernanceOptionState(gov *common.Address, propId *hexutil
 	}
 
 	// et the state from the contract
-	gs, err := ftm.GovernanceOptionStateById(gc, propId, optId)
+	gs, err := ftm.GovernanceOptionStateById(ctx, gc, propId, optId)
 	if err != nil {
 		ftm.log.Errorf("governance %s proposal #%d state #%d not available; %s",
 			gov.String(), propId.ToInt().Uint64(), optId.ToInt().Uint64(), err.Error())
@@ -216,7 +219,7 @@ func (ftm *FtmBridge) GovernanceOptionState(gov *common.Address, propId *hexutil
 }
 
 // GovernanceOptionStates returns a list of states of options of a proposal.
-func (ftm *FtmBridge) GovernanceOptionStates(gov *common.Address, propId *hexutil.Big, optRange int) ([]*types.GovernanceOptionState, error) {
+func (ftm *FtmBridge) GovernanceOptionStates(ctx context.Context, gov *common.Address, propId *hexutil.Big, optRange int) ([]*types.GovernanceOptionState, error) {
 	// get the contract
 	gc, err := contracts.NewGovernance(*gov, ftm.eth)
 	if err != nil {
@@ -230,7 +233,7 @@ func (ftm *FtmBridge) GovernanceOptionStates(gov *common.Address, propId *hexuti
 	// loop over all possible states and check them one by one
 	for i := 0; i < optRange; i++ {
 		// get the state of this option
-		res[i], err = ftm.GovernanceOptionStateById(gc, propId, (*hexutil.Big)(big.NewInt(int64(i))))
+		res[i], err = ftm.GovernanceOptionStateById(ctx, gc, propId, (*hexutil.Big)(big.NewInt(int64(i))))
 		if err != nil {
 			ftm.log.Errorf("unknown option #%d on governance %s; %s", i, gov.String(), err.Error())
 			break
@@ -240,9 +243,9 @@ func (ftm *FtmBridge) GovernanceOptionStates(gov *common.Address, propId *hexuti
 }
 
 // GovernanceOptionStateById returns a state of the given option of a proposal.
-func (ftm *FtmBridge) GovernanceOptionStateById(gc *contracts.Governance, propId *hexutil.Big, optId *hexutil.Big) (*types.GovernanceOptionState, error) {
+func (ftm *FtmBridge) GovernanceOptionStateById(ctx context.Context, gc *contracts.Governance, propId *hexutil.Big, optId *hexutil.Big) (*types.GovernanceOptionState, error) {
 	// get the state
-	data, err := gc.ProposalOptionState(nil, propId.ToInt(), optId.ToInt())
+	data, err := gc.ProposalOptionState(&bind.CallOpts{Context: ctx}, propId.ToInt(), optId.ToInt())
 	if err != nil {
 		return nil, err
 	}
@@ -257,7 +260,7 @@ func (ftm *FtmBridge) GovernanceOptionStateById(gc *contracts.Governance, propId
 }
 
 // GovernanceVote provides a single vote in the Governance Proposal context.
-func (ftm *FtmBridge) GovernanceVote(
+func (ftm *FtmBridge) GovernanceVote(ctx context.Context,
 	gov *common.Address,
 	propId *hexutil.Big,
 	from *common.Address,
@@ -275,7 +278,7 @@ func (ftm *FtmBridge) GovernanceVote(
 	}
 
 	// get the vote details
-	vote, err := gc.GetVote(nil, *from, *delegatedTo, propId.ToInt())
+	vote, err := gc.GetVote(&bind.CallOpts{Context: ctx}, *from, *delegatedTo, propId.ToInt())
 	if err != nil {
 		ftm.log.Errorf("can not access vote of %s on governance %s; %s", from.String(), gov.String(), err.Error())
 		return nil, err
@@ -291,8 +294,32 @@ func (ftm *FtmBridge) GovernanceVote(
 	}, nil
 }
 
+// GovernanceParseVote decodes the governance vote from the given Voted event log.
+// event Voted(address voter, address delegatedTo, uint256 proposalID, uint256[] choices, uint256 weight)
+func GovernanceParseVote(lg *retypes.Log) (*types.GovernanceVote, error) {
+	gf, err := contracts.NewGovernanceFilterer(lg.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// unpack the event data
+	ev, err := gf.ParseVoted(*lg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GovernanceVote{
+		GovernanceId: lg.Address,
+		ProposalId:   hexutil.Big(*ev.ProposalID),
+		From:         ev.Voter,
+		DelegatedTo:  &ev.DelegatedTo,
+		Weight:       hexutil.Big(*ev.Weight),
+		Choices:      govConvertScales(ev.Choices),
+	}, nil
+}
+
 // GovernanceProposalsBy loads list of proposals of the given Governance contract.
-func (ftm *FtmBridge) GovernanceProposalsBy(gov *common.Address) ([]*types.GovernanceProposal, error) {
+func (ftm *FtmBridge) GovernanceProposalsBy(ctx context.Context, gov *common.Address) ([]*types.GovernanceProposal, error) {
 	// get the contract
 	gc, err := contracts.NewGovernance(*gov, ftm.eth)
 	if err != nil {
@@ -301,7 +328,7 @@ func (ftm *FtmBridge) GovernanceProposalsBy(gov *common.Address) ([]*types.Gover
 	}
 
 	// get the max number of proposals
-	maxProposalId, err := gc.LastProposalID(nil)
+	maxProposalId, err := gc.LastProposalID(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("can not count governance %s proposals; %s", gov.String(), err.Error())
 		return nil, err
@@ -316,7 +343,7 @@ func (ftm *FtmBridge) GovernanceProposalsBy(gov *common.Address) ([]*types.Gover
 	// loop the sys to load proposals
 	for i := maxProposalId.Int64(); i > 0; i-- {
 		// pull the proposal details
-		gp, err := ftm.governanceProposalDetail(gc, gov, big.NewInt(i))
+		gp, err := ftm.governanceProposalDetail(ctx, gc, gov, big.NewInt(i))
 		if err != nil {
 			ftm.log.Errorf("can not access governance %s proposal %d; %s", gov.String(), i, err.Error())
 			return nil, err
@@ -334,7 +361,7 @@ func (ftm *FtmBridge) GovernanceProposalsBy(gov *common.Address) ([]*types.Gover
 
 // GovernanceProposalFee returns the fee payable for a new proposal
 // in given Governance contract context.
-func (ftm *FtmBridge) GovernanceProposalFee(gov *common.Address) (hexutil.Big, error) {
+func (ftm *FtmBridge) GovernanceProposalFee(ctx context.Context, gov *common.Address) (hexutil.Big, error) {
 	// get the contract
 	gc, err := contracts.NewGovernance(*gov, ftm.eth)
 	if err != nil {
@@ -343,7 +370,7 @@ func (ftm *FtmBridge) GovernanceProposalFee(gov *common.Address) (hexutil.Big, e
 	}
 
 	// get the fee
-	fee, err := gc.ProposalFee(nil)
+	fee, err := gc.ProposalFee(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("governance %s fee not available; %s", gov.String(), err.Error())
 		return hexutil.Big{}, err
@@ -355,7 +382,7 @@ func (ftm *FtmBridge) GovernanceProposalFee(gov *common.Address) (hexutil.Big, e
 // GovernanceTotalWeight returns the total available voting weight for all proposals
 // of a governance contract. The address given must be the Governable contract linked
 // to the core Governance.
-func (ftm *FtmBridge) GovernanceTotalWeight(ge *common.Address) (*hexutil.Big, error) {
+func (ftm *FtmBridge) GovernanceTotalWeight(ctx context.Context, ge *common.Address) (*hexutil.Big, error) {
 	// get the contract
 	goe, err := contracts.NewGovernable(*ge, ftm.eth)
 	if err != nil {
@@ -364,7 +391,7 @@ func (ftm *FtmBridge) GovernanceTotalWeight(ge *common.Address) (*hexutil.Big, e
 	}
 
 	// get the total available voting weight
-	w, err := goe.GetTotalWeight(ftm.DefaultCallOpts())
+	w, err := goe.GetTotalWeight(ftm.DefaultCallOpts(ctx))
 	if err != nil {
 		ftm.log.Errorf("total weight not available on adapter %s; %s", ge.String(), err.Error())
 		return nil, err
@@ -0,0 +1,176 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository/rpc/contracts"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"strings"
+)
+
+// multicallAbiJSON represents the ABI of the tryAggregate call of the Multicall2 contract;
+// the Multicall3 contract supports the call as well.
+const multicallAbiJSON = `[{"inputs":[{"internalType":"bool","name":"requireSuccess","type":"bool"},{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall2.Call[]","name":"calls","type":"tuple[]"}],"name":"tryAggregate","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall2.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"nonpayable","type":"function"}]`
+
+// multicallMaxCalls represents the max number of calls aggregated into a single contract call,
+// so the aggregated call does not hit the gas limit of the node.
+const multicallMaxCalls = 100
+
+var (
+	// multicallAbi represents the parsed ABI of the Multicall contract.
+	multicallAbi = mustParseAbi(multicallAbiJSON)
+
+	// erc20Abi represents the parsed ABI of the ERC20 token contract.
+	erc20Abi = mustParseAbi(contracts.ERCTwentyABI)
+)
+
+// multicallCall represents a single contract call aggregated by the Multicall contract.
+type multicallCall struct {
+	Target   common.Address
+	CallData []byte
+}
+
+// multicallResult represents the result of a single aggregated contract call.
+type multicallResult struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// mustParseAbi parses the given contract ABI definition.
+func mustParseAbi(def string) *abi.ABI {
+	ab, err := abi.JSON(strings.NewReader(def))
+	if err != nil {
+		panic(fmt.Errorf("invalid contract ABI; %s", err.Error()))
+	}
+	return &ab
+}
+
+// hasMulticall checks if the Multicall contract is configured.
+func (ftm *FtmBridge) hasMulticall() bool {
+	return ftm.multicall != (common.Address{})
+}
+
+// aggregate executes the given contract calls in batches through the Multicall contract
+// collapsing the calls into a single node round trip per batch. Failed calls
+// are reported by the results, they do not fail the whole batch.
+func (ftm *FtmBridge) aggregate(ctx context.Context, calls []multicallCall) ([]multicallResult, error) {
+	res := make([]multicallResult, 0, len(calls))
+	for len(calls) > 0 {
+		n := len(calls)
+		if n > multicallMaxCalls {
+			n = multicallMaxCalls
+		}
+
+		data, err := multicallAbi.Pack("tryAggregate", false, calls[:n])
+		if err != nil {
+			return nil, err
+		}
+
+		out, err := ftm.eth.CallContract(ctx, ethereum.CallMsg{To: &ftm.multicall, Data: data}, nil)
+		if err != nil {
+			ftm.log.Errorf("multicall of %d calls failed; %s", n, err.Error())
+			return nil, err
+		}
+
+		val, err := multicallAbi.Unpack("tryAggregate", out)
+		if err != nil {
+			return nil, err
+		}
+
+		part := *abi.ConvertType(val[0], new([]multicallResult)).(*[]multicallResult)
+		if len(part) != n {
+			return nil, fmt.Errorf("multicall returned %d results for %d calls", len(part), n)
+		}
+
+		res = append(res, part...)
+		calls = calls[n:]
+	}
+	return res, nil
+}
+
+// aggregateBig executes the given calls of the contract methods returning a single integer value
+// through the Multicall contract. A failed call fails the whole aggregation.
+func (ftm *FtmBridge) aggregateBig(ctx context.Context, ab *abi.ABI, method string, calls []multicallCall) ([]*big.Int, error) {
+	res, err := ftm.aggregate(ctx, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*big.Int, len(res))
+	for i, r := range res {
+		if !r.Success {
+			return nil, fmt.Errorf("call %s of %s failed", method, calls[i].Target.String())
+		}
+
+		val, err := ab.Unpack(method, r.ReturnData)
+		if err != nil {
+			return nil, err
+		}
+		list[i] = *abi.ConvertType(val[0], new(*big.Int)).(**big.Int)
+	}
+	return list, nil
+}
+
+// Erc20BalancesOf loads the current available balances of the given ERC20 tokens
+// of the given owner. The reads are aggregated by the Multicall contract, if available.
+// Nil balance is provided for tokens the balance can not be loaded for.
+func (ftm *FtmBridge) Erc20BalancesOf(ctx context.Context, owner *common.Address, tokens []common.Address) ([]*hexutil.Big, error) {
+	list := make([]*hexutil.Big, len(tokens))
+
+	// no multicall, read the balances one by one
+	if !ftm.hasMulticall() {
+		for i := range tokens {
+			if val, err := ftm.Erc20BalanceOf(ctx, &tokens[i], owner); err == nil {
+				list[i] = &val
+			}
+		}
+		return list, nil
+	}
+
+	data, err := erc20Abi.Pack("balanceOf", *owner)
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]multicallCall, len(tokens))
+	for i, t := range tokens {
+		calls[i] = multicallCall{Target: t, CallData: data}
+	}
+
+	res, err := ftm.aggregate(ctx, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, r := range res {
+		if !r.Success {
+			ftm.log.Debugf("ERC20 %s balance of %s not available", tokens[i].String(), owner.String())
+			continue
+		}
+
+		val, err := erc20Abi.Unpack("balanceOf", r.ReturnData)
+		if err != nil {
+			ftm.log.Debugf("invalid ERC20 %s balance of %s; %s", tokens[i].String(), owner.String(), err.Error())
+			continue
+		}
+		list[i] = (*hexutil.Big)(*abi.ConvertType(val[0], new(*big.Int)).(**big.Int))
+	}
+	return list, nil
+}
@@ -0,0 +1,429 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/metrics"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	eth "github.com/ethereum/go-ethereum/ethclient"
+	ftm "github.com/ethereum/go-ethereum/rpc"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// nodeHealthCheckTick represents the period of the nodes health check.
+const nodeHealthCheckTick = 10 * time.Second
+
+// nodeHealthCheckTimeout represents the time limit of a single node health check.
+const nodeHealthCheckTimeout = 5 * time.Second
+
+// nodeCallTimeout represents the time limit of a single RPC call
+// after which the node is considered failed.
+const nodeCallTimeout = 30 * time.Second
+
+// nodeMaxLag represents the number of blocks a node can lag behind
+// the most advanced node of the pool and still be considered healthy.
+const nodeMaxLag = 30
+
+// nodeBalanceMaxLag represents the number of blocks a node can lag behind
+// the active node and still serve balanced read calls.
+const nodeBalanceMaxLag = 2
+
+// errNodeNotConnected represents an error of a call to a node without open connection.
+var errNodeNotConnected = errors.New("node not connected")
+
+// activeNodeKey represents the context key of calls pinned to the active node.
+type activeNodeKey struct{}
+
+// node represents a single blockchain node connection of the pool.
+// The head is the block height of the node at the last health check.
+type node struct {
+	head    uint64
+	url     string
+	mu      sync.RWMutex
+	rpc     *ftm.Client
+	eth     *eth.Client
+	healthy int32
+}
+
+// nodePool represents a pool of blockchain nodes serving the bridge calls.
+// Calls are served by the active node and fail over to the next healthy node
+// on connection errors and timeouts. Read calls can be balanced across the healthy nodes
+// following the active node closely, unless they are pinned to the active node.
+// The list of nodes can be updated on a configuration reload.
+type nodePool struct {
+	log     logger.Logger
+	mu      sync.RWMutex
+	nodes   []*node
+	active  int32
+	balance bool
+	turn    uint32
+}
+
+// newNodePool connects the configured blockchain nodes and builds the pool.
+// At least one node must be available.
+func newNodePool(cfg *config.Config, log logger.Logger) (*nodePool, error) {
+	p := &nodePool{
+		log:     log,
+		nodes:   connectNodes(cfg, nil, log),
+		balance: cfg.Lachesis.LoadBalance,
+	}
+
+	// make sure we have at least one working node; the heads of the nodes are known
+	// before the read calls are balanced
+	p.check()
+	if !p.elect() {
+		return nil, fmt.Errorf("no blockchain node available")
+	}
+	return p, nil
+}
+
+// connectNodes builds the list of the configured blockchain nodes.
+// The primary node goes first, backup nodes follow. Nodes of the given
+// current list are re-used, new nodes are connected.
+func connectNodes(cfg *config.Config, current []*node, log logger.Logger) []*node {
+	known := make(map[string]*node, len(current))
+	for _, n := range current {
+		known[n.url] = n
+	}
+
+	list := make([]*node, 0, len(cfg.Lachesis.Backup)+1)
+	for _, url := range append([]string{cfg.Lachesis.Url}, cfg.Lachesis.Backup...) {
+		if url == "" || contains(list, url) {
+			continue
+		}
+
+		// known node keeps its connection and health
+		if n, ok := known[url]; ok {
+			list = append(list, n)
+			continue
+		}
+
+		n := &node{url: url}
+		if err := n.dial(log); err != nil {
+			log.Errorf("can not connect blockchain node at %s; %s", url, err.Error())
+		} else {
+			n.healthy = 1
+		}
+		list = append(list, n)
+	}
+	return list
+}
+
+// contains checks if the list of nodes contains a node of the given URL.
+func contains(list []*node, url string) bool {
+	for _, n := range list {
+		if n.url == url {
+			return true
+		}
+	}
+	return false
+}
+
+// update applies the configured list of blockchain nodes and the load balancing to the pool.
+// Nodes removed from the configuration are disconnected.
+func (p *nodePool) update(cfg *config.Config) {
+	list := connectNodes(cfg, p.list(), p.log)
+	if len(list) == 0 {
+		p.log.Errorf("no blockchain node configured, keeping the current nodes")
+		return
+	}
+
+	p.mu.Lock()
+	old := p.nodes
+	p.nodes = list
+	p.balance = cfg.Lachesis.LoadBalance
+	atomic.StoreInt32(&p.active, 0)
+	p.mu.Unlock()
+
+	// close connections of the removed nodes
+	for _, n := range old {
+		if contains(list, n.url) {
+			continue
+		}
+		if rc, _ := n.clients(); rc != nil {
+			rc.Close()
+		}
+		p.log.Noticef("blockchain node %s removed", n.url)
+	}
+	p.elect()
+}
+
+// list provides the current list of the pool nodes.
+func (p *nodePool) list() []*node {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.nodes
+}
+
+// dial opens the node connection.
+func (n *node) dial(log logger.Logger) error {
+	// log what we do
+	log.Debugf("connecting blockchain node at %s", n.url)
+
+	// try to establish a connection
+	client, err := ftm.Dial(n.url)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	n.rpc = client
+	n.eth = eth.NewClient(client)
+	n.mu.Unlock()
+
+	log.Noticef("node connection open to %s", n.url)
+	return nil
+}
+
+// clients provides the node RPC client and the contracts interaction client.
+func (n *node) clients() (*ftm.Client, *eth.Client) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.rpc, n.eth
+}
+
+// isHealthy checks if the node is considered healthy.
+func (n *node) isHealthy() bool {
+	return atomic.LoadInt32(&n.healthy) == 1
+}
+
+// isNodeFailure checks if the error of a call means the node failed
+// to serve it, e.g. on a broken connection or a timeout. Errors returned
+// by the node itself as a response to the call are not node failures.
+func isNodeFailure(err error) bool {
+	if err == nil || err == ftm.ErrNoResult || err == ftm.ErrNotificationsUnsupported || err == ethereum.NotFound {
+		return false
+	}
+
+	var re ftm.Error
+	return !errors.As(err, &re)
+}
+
+// current provides the active node of the pool.
+func (p *nodePool) current() *node {
+	nodes := p.list()
+	if i := int(atomic.LoadInt32(&p.active)); i < len(nodes) {
+		return nodes[i]
+	}
+	return nodes[0]
+}
+
+// WithActiveNode provides a context of calls served by the active node even if read calls
+// are balanced across the pool. Lagging nodes do not report missing blocks and transactions
+// as a failure, so calls which need a consistent view of the chain, e.g. the calls of the blockchain
+// scanner, must not be balanced.
+func WithActiveNode(ctx context.Context) context.Context {
+	return context.WithValue(ctx, activeNodeKey{}, true)
+}
+
+// isActiveNodeOnly checks if the calls of the given context are pinned to the active node.
+func isActiveNodeOnly(ctx context.Context) bool {
+	v, ok := ctx.Value(activeNodeKey{}).(bool)
+	return ok && v
+}
+
+// candidates provides the list of nodes to serve a call in the order of preference.
+func (p *nodePool) candidates(read bool) []*node {
+	p.mu.RLock()
+	nodes, balance := p.nodes, p.balance
+	p.mu.RUnlock()
+
+	start := int(atomic.LoadInt32(&p.active))
+	if start >= len(nodes) {
+		start = 0
+	}
+	if read && balance {
+		start = p.balancedStart(nodes, start)
+	}
+
+	// healthy nodes first
+	list := make([]*node, 0, len(nodes))
+	for i := 0; i < len(nodes); i++ {
+		if n := nodes[(start+i)%len(nodes)]; n.isHealthy() {
+			list = append(list, n)
+		}
+	}
+
+	// no healthy node? try them all anyway
+	if len(list) == 0 {
+		for i := 0; i < len(nodes); i++ {
+			list = append(list, nodes[(start+i)%len(nodes)])
+		}
+	}
+	return list
+}
+
+// balancedStart picks the node to start a balanced read call with. Only healthy nodes
+// with the head close to the head of the active node take turns.
+func (p *nodePool) balancedStart(nodes []*node, active int) int {
+	top := atomic.LoadUint64(&nodes[active].head)
+	synced := make([]int, 0, len(nodes))
+	for i, n := range nodes {
+		if n.isHealthy() && atomic.LoadUint64(&n.head)+nodeBalanceMaxLag >= top {
+			synced = append(synced, i)
+		}
+	}
+
+	if len(synced) == 0 {
+		return active
+	}
+	return synced[atomic.AddUint32(&p.turn, 1)%uint32(len(synced))]
+}
+
+// do executes the given call on the pool nodes failing over
+// to the next candidate node if the node fails to serve it.
+// The method identifies the call in the RPC metrics. Calls cancelled
+// by the caller context are not considered a node failure. Read calls of contexts pinned
+// to the active node are not balanced, see WithActiveNode. If none of the nodes
+// can serve the call, the last failure is reported as the node being unavailable.
+func (p *nodePool) do(ctx context.Context, method string, read bool, call func(*ftm.Client, *eth.Client) error) (err error) {
+	start := time.Now()
+	defer func() {
+		metrics.ObserveRpcCall(method, time.Since(start))
+	}()
+
+	for _, n := range p.candidates(read && !isActiveNodeOnly(ctx)) {
+		rc, ec := n.clients()
+		if rc == nil {
+			err = errNodeNotConnected
+			continue
+		}
+
+		err = call(rc, ec)
+		if !isNodeFailure(err) {
+			return err
+		}
+
+		// the caller gave up; the node is fine, no need to try the others
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		p.failed(n, err)
+	}
+
+	if err != nil {
+		return types.Errorf(types.ErrCodeRpcUnavailable, "blockchain node not available; %s", err.Error())
+	}
+	return err
+}
+
+// failed marks the node as failed and elects a new active node, if needed.
+func (p *nodePool) failed(n *node, err error) {
+	if atomic.CompareAndSwapInt32(&n.healthy, 1, 0) {
+		p.log.Warningf("blockchain node %s failed; %s", n.url, err.Error())
+	}
+	p.elect()
+}
+
+// elect makes the first healthy node of the pool active.
+// The primary node is preferred as soon as it's healthy again.
+// It returns FALSE if no healthy node is available.
+func (p *nodePool) elect() bool {
+	for i, n := range p.list() {
+		if n.isHealthy() {
+			if old := atomic.SwapInt32(&p.active, int32(i)); old != int32(i) {
+				p.log.Noticef("blockchain node %s is active", n.url)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// check verifies the health of all the nodes of the pool.
+// A node is healthy if it responds and follows the most advanced node closely.
+func (p *nodePool) check() {
+	nodes := p.list()
+	heads := make([]uint64, len(nodes))
+	var top uint64
+	for i, n := range nodes {
+		heads[i] = p.probe(n)
+		if heads[i] > top {
+			top = heads[i]
+		}
+	}
+
+	for i, n := range nodes {
+		atomic.StoreUint64(&n.head, heads[i])
+
+		ok := heads[i] > 0 && heads[i]+nodeMaxLag >= top
+		if ok && atomic.CompareAndSwapInt32(&n.healthy, 0, 1) {
+			p.log.Noticef("blockchain node %s is healthy at #%d", n.url, heads[i])
+		}
+		if !ok && atomic.CompareAndSwapInt32(&n.healthy, 1, 0) {
+			p.log.Warningf("blockchain node %s is not healthy at #%d, top is #%d", n.url, heads[i], top)
+		}
+	}
+	p.elect()
+}
+
+// probe provides the current head of the node; it re-connects the node if needed.
+// Zero is returned if the node is not available.
+func (p *nodePool) probe(n *node) uint64 {
+	rc, _ := n.clients()
+	if rc == nil {
+		if err := n.dial(p.log); err != nil {
+			p.log.Debugf("blockchain node %s not available; %s", n.url, err.Error())
+			return 0
+		}
+		rc, _ = n.clients()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), nodeHealthCheckTimeout)
+	defer cancel()
+
+	var head hexutil.Uint64
+	if err := rc.CallContext(ctx, &head, "eth_blockNumber"); err != nil {
+		p.log.Debugf("blockchain node %s health check failed; %s", n.url, err.Error())
+		return 0
+	}
+	return uint64(head)
+}
+
+// close terminates all the nodes connections.
+func (p *nodePool) close() {
+	for _, n := range p.list() {
+		if rc, _ := n.clients(); rc != nil {
+			rc.Close()
+		}
+	}
+}
+
+// observeNodes runs the periodic health check of the blockchain nodes.
+func (ftm *FtmBridge) observeNodes() {
+	ticker := time.NewTicker(nodeHealthCheckTick)
+	defer func() {
+		ticker.Stop()
+		ftm.wg.Done()
+		ftm.log.Noticef("nodes health check terminated")
+	}()
+
+	for {
+		select {
+		case <-ftm.sigClose:
+			return
+		case <-ticker.C:
+			ftm.nodes.check()
+		}
+	}
+}
@@ -0,0 +1,177 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"fantom-api-graphql/internal/tracing"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	etc "github.com/ethereum/go-ethereum/core/types"
+	eth "github.com/ethereum/go-ethereum/ethclient"
+	ftm "github.com/ethereum/go-ethereum/rpc"
+	"go.opentelemetry.io/otel/attribute"
+	"math/big"
+)
+
+// rpcProxy implements the RPC client calls served by the blockchain nodes pool.
+type rpcProxy struct {
+	pool *nodePool
+}
+
+// ethProxy implements the contracts interaction backend served by the blockchain nodes pool.
+type ethProxy struct {
+	pool *nodePool
+}
+
+// make sure the proxy can be used to bind contracts
+var _ bind.ContractBackend = (*ethProxy)(nil)
+
+// Call performs a JSON-RPC call with the given arguments and unmarshals into
+// result if no error occurred.
+func (rp *rpcProxy) Call(result interface{}, method string, args ...interface{}) error {
+	return rp.CallContext(context.Background(), result, method, args...)
+}
+
+// CallContext performs a JSON-RPC call with the given arguments and unmarshals into
+// result if no error occurred. The call is cancelled with the given context.
+func (rp *rpcProxy) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return rp.pool.do(ctx, method, method != "eth_sendRawTransaction", func(rc *ftm.Client, _ *eth.Client) error {
+		ctx, cancel := context.WithTimeout(ctx, nodeCallTimeout)
+		defer cancel()
+		return rc.CallContext(ctx, result, method, args...)
+	})
+}
+
+// BatchCall sends all the given requests as a single batch and waits for the responses.
+// The call is cancelled with the given context.
+func (rp *rpcProxy) BatchCall(ctx context.Context, b []ftm.BatchElem) (err error) {
+	_, span := tracing.Start(ctx, "rpc.BatchCall", attribute.Int("size", len(b)))
+	defer func() {
+		tracing.Finish(span, err)
+	}()
+
+	return rp.pool.do(ctx, "batch", true, func(rc *ftm.Client, _ *eth.Client) error {
+		ctx, cancel := context.WithTimeout(ctx, nodeCallTimeout)
+		defer cancel()
+		return rc.BatchCallContext(ctx, b)
+	})
+}
+
+// EthSubscribe registers a subscription under the "eth" namespace on the active node.
+func (rp *rpcProxy) EthSubscribe(ctx context.Context, channel interface{}, args ...interface{}) (sub *ftm.ClientSubscription, err error) {
+	err = rp.pool.do(ctx, "eth_subscribe", false, func(rc *ftm.Client, _ *eth.Client) error {
+		sub, err = rc.EthSubscribe(ctx, channel, args...)
+		return err
+	})
+	return sub, err
+}
+
+// CodeAt returns the code of the given account.
+func (ep *ethProxy) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) (code []byte, err error) {
+	err = ep.pool.do(ctx, "eth_getCode", true, func(_ *ftm.Client, ec *eth.Client) error {
+		code, err = ec.CodeAt(ctx, contract, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+// CallContract executes a message call transaction.
+func (ep *ethProxy) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) (res []byte, err error) {
+	err = ep.pool.do(ctx, "eth_call", true, func(_ *ftm.Client, ec *eth.Client) error {
+		res, err = ec.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return res, err
+}
+
+// HeaderByNumber returns a block header from the current canonical chain.
+func (ep *ethProxy) HeaderByNumber(ctx context.Context, number *big.Int) (hdr *etc.Header, err error) {
+	err = ep.pool.do(ctx, "eth_getBlockByNumber", true, func(_ *ftm.Client, ec *eth.Client) error {
+		hdr, err = ec.HeaderByNumber(ctx, number)
+		return err
+	})
+	return hdr, err
+}
+
+// PendingCodeAt returns the code of the given account in the pending state.
+func (ep *ethProxy) PendingCodeAt(ctx context.Context, account common.Address) (code []byte, err error) {
+	err = ep.pool.do(ctx, "eth_getCode", false, func(_ *ftm.Client, ec *eth.Client) error {
+		code, err = ec.PendingCodeAt(ctx, account)
+		return err
+	})
+	return code, err
+}
+
+// PendingNonceAt returns the account nonce of the given account in the pending state.
+func (ep *ethProxy) PendingNonceAt(ctx context.Context, account common.Address) (nonce uint64, err error) {
+	err = ep.pool.do(ctx, "eth_getTransactionCount", false, func(_ *ftm.Client, ec *eth.Client) error {
+		nonce, err = ec.PendingNonceAt(ctx, account)
+		return err
+	})
+	return nonce, err
+}
+
+// SuggestGasPrice retrieves the currently suggested gas price.
+func (ep *ethProxy) SuggestGasPrice(ctx context.Context) (price *big.Int, err error) {
+	err = ep.pool.do(ctx, "eth_gasPrice", true, func(_ *ftm.Client, ec *eth.Client) error {
+		price, err = ec.SuggestGasPrice(ctx)
+		return err
+	})
+	return price, err
+}
+
+// SuggestGasTipCap retrieves the currently suggested gas tip cap.
+func (ep *ethProxy) SuggestGasTipCap(ctx context.Context) (tip *big.Int, err error) {
+	err = ep.pool.do(ctx, "eth_maxPriorityFeePerGas", true, func(_ *ftm.Client, ec *eth.Client) error {
+		tip, err = ec.SuggestGasTipCap(ctx)
+		return err
+	})
+	return tip, err
+}
+
+// EstimateGas tries to estimate the gas needed to execute a specific transaction.
+func (ep *ethProxy) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
+	err = ep.pool.do(ctx, "eth_estimateGas", true, func(_ *ftm.Client, ec *eth.Client) error {
+		gas, err = ec.EstimateGas(ctx, call)
+		return err
+	})
+	return gas, err
+}
+
+// SendTransaction injects the transaction into the pending pool for execution.
+func (ep *ethProxy) SendTransaction(ctx context.Context, tx *etc.Transaction) error {
+	return ep.pool.do(ctx, "eth_sendRawTransaction", false, func(_ *ftm.Client, ec *eth.Client) error {
+		return ec.SendTransaction(ctx, tx)
+	})
+}
+
+// FilterLogs executes a log filter operation.
+func (ep *ethProxy) FilterLogs(ctx context.Context, query ethereum.FilterQuery) (logs []etc.Log, err error) {
+	err = ep.pool.do(ctx, "eth_getLogs", true, func(_ *ftm.Client, ec *eth.Client) error {
+		logs, err = ec.FilterLogs(ctx, query)
+		return err
+	})
+	return logs, err
+}
+
+// SubscribeFilterLogs creates a background log filtering operation on the active node.
+func (ep *ethProxy) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- etc.Log) (sub ethereum.Subscription, err error) {
+	err = ep.pool.do(ctx, "eth_subscribe", false, func(_ *ftm.Client, ec *eth.Client) error {
+		sub, err = ec.SubscribeFilterLogs(ctx, query, ch)
+		return err
+	})
+	return sub, err
+}
@@ -0,0 +1,142 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"github.com/ethereum/go-ethereum"
+	ftm "github.com/ethereum/go-ethereum/rpc"
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+// testRpcError represents an error returned by the node as a response to a call.
+type testRpcError struct{}
+
+// Error provides the message of the error.
+func (testRpcError) Error() string { return "execution reverted" }
+
+// ErrorCode provides the JSON-RPC code of the error.
+func (testRpcError) ErrorCode() int { return 3 }
+
+func TestIsNodeFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		failure bool
+	}{
+		{name: "no error", err: nil, failure: false},
+		{name: "no result", err: ftm.ErrNoResult, failure: false},
+		{name: "not found", err: ethereum.NotFound, failure: false},
+		{name: "node error", err: testRpcError{}, failure: false},
+		{name: "connection error", err: errors.New("connection refused"), failure: true},
+		{name: "timeout", err: context.DeadlineExceeded, failure: true},
+		{name: "not connected", err: errNodeNotConnected, failure: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			g.Expect(isNodeFailure(tt.err)).To(gomega.Equal(tt.failure))
+		})
+	}
+}
+
+func TestNodePoolCandidates(t *testing.T) {
+	type testNode struct {
+		healthy bool
+		head    uint64
+	}
+
+	tests := []struct {
+		name    string
+		nodes   []testNode
+		active  int32
+		balance bool
+		read    bool
+		first   []string // the nodes allowed to serve the call first
+		order   []string // the full order of the candidates; checked if set
+	}{
+		{
+			name:   "active node first",
+			nodes:  []testNode{{true, 100}, {true, 100}, {true, 100}},
+			active: 1, read: true,
+			first: []string{"n1"}, order: []string{"n1", "n2", "n0"},
+		},
+		{
+			name:   "failed nodes skipped",
+			nodes:  []testNode{{false, 0}, {true, 100}, {false, 100}},
+			active: 1, read: true,
+			first: []string{"n1"}, order: []string{"n1"},
+		},
+		{
+			name:   "all nodes failed",
+			nodes:  []testNode{{false, 0}, {false, 0}},
+			active: 0, read: true,
+			first: []string{"n0"}, order: []string{"n0", "n1"},
+		},
+		{
+			name:   "write calls not balanced",
+			nodes:  []testNode{{true, 100}, {true, 100}, {true, 100}},
+			active: 0, balance: true, read: false,
+			first: []string{"n0"}, order: []string{"n0", "n1", "n2"},
+		},
+		{
+			name:   "reads balanced across synced nodes",
+			nodes:  []testNode{{true, 100}, {true, 99}, {true, 102}},
+			active: 0, balance: true, read: true,
+			first: []string{"n0", "n1", "n2"},
+		},
+		{
+			name:   "lagging node not balanced",
+			nodes:  []testNode{{true, 100}, {true, 97}, {true, 100}},
+			active: 0, balance: true, read: true,
+			first: []string{"n0", "n2"},
+		},
+		{
+			name:   "failed node not balanced",
+			nodes:  []testNode{{true, 100}, {false, 100}, {true, 100}},
+			active: 0, balance: true, read: true,
+			first: []string{"n0", "n2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			p := &nodePool{active: tt.active, balance: tt.balance}
+			for i, tn := range tt.nodes {
+				n := &node{url: "n" + string(rune('0'+i)), head: tn.head}
+				if tn.healthy {
+					n.healthy = 1
+				}
+				p.nodes = append(p.nodes, n)
+			}
+
+			// balanced calls take turns, check a few of them
+			seen := make(map[string]bool)
+			for i := 0; i < 3*len(tt.nodes); i++ {
+				list := p.candidates(tt.read)
+				g.Expect(list).NotTo(gomega.BeEmpty())
+				g.Expect(tt.first).To(gomega.ContainElement(list[0].url))
+				seen[list[0].url] = true
+
+				if tt.order != nil {
+					urls := make([]string, len(list))
+					for j, n := range list {
+						urls[j] = n.url
+					}
+					g.Expect(urls).To(gomega.Equal(tt.order))
+				}
+			}
+			g.Expect(seen).To(gomega.HaveLen(len(tt.first)))
+		})
+	}
+}
+
+func TestWithActiveNode(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	g.Expect(isActiveNodeOnly(context.Background())).To(gomega.BeFalse())
+	g.Expect(isActiveNodeOnly(WithActiveNode(context.Background()))).To(gomega.BeTrue())
+}
@@ -0,0 +1,66 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+var (
+	// oracleDecimalsCall represents the call data of the aggregator decimals() function.
+	oracleDecimalsCall = hexutil.Bytes{0x31, 0x3c, 0xe5, 0x67}
+
+	// oracleLatestRoundCall represents the call data of the aggregator latestRoundData() function.
+	oracleLatestRoundCall = hexutil.Bytes{0xfe, 0xaf, 0x96, 0x8c}
+)
+
+// OraclePrice pulls the latest price from a Chainlink style price aggregator contract.
+// It returns the price adjusted by the aggregator decimals and the time stamp
+// of the latest price update.
+func (ftm *FtmBridge) OraclePrice(ctx context.Context, oracle *common.Address) (float64, hexutil.Uint64, error) {
+	// get the number of decimals of the answer
+	dec, err := ftm.ContractCall(ctx, oracle, oracleDecimalsCall, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(dec) != 32 {
+		return 0, 0, fmt.Errorf("invalid decimals response of oracle %s", oracle.String())
+	}
+
+	// latestRoundData() returns (uint80 roundId, int256 answer, uint256 startedAt, uint256 updatedAt, uint80 answeredInRound)
+	round, err := ftm.ContractCall(ctx, oracle, oracleLatestRoundCall, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(round) != 160 {
+		return 0, 0, fmt.Errorf("invalid round data response of oracle %s", oracle.String())
+	}
+
+	// negative answers are not a valid price
+	answer := new(big.Int).SetBytes(round[32:64])
+	if round[32]&0x80 != 0 || answer.Sign() == 0 {
+		return 0, 0, fmt.Errorf("invalid price received from oracle %s", oracle.String())
+	}
+
+	// adjust the answer by the decimals
+	val, _ := new(big.Float).Quo(
+		new(big.Float).SetInt(answer),
+		new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), new(big.Int).SetBytes(dec), nil)),
+	).Float64()
+	return val, hexutil.Uint64(new(big.Int).SetBytes(round[96:128]).Uint64()), nil
+}
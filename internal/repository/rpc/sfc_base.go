@@ -19,7 +19,9 @@ package rpc
 //go:generate tools/abigen.sh --abi ./contracts/abi/sfc-tokenizer.abi --pkg contracts --type SfcTokenizer --out ./contracts/sfc_tokenizer.go
 
 import (
+	"context"
 	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
 )
@@ -28,11 +30,11 @@ import (
 const sfcFirstLockEpoch uint64 = 1600
 
 // SfcVersion returns current version of the SFC contract as a single number.
-func (ftm *FtmBridge) SfcVersion() (hexutil.Uint64, error) {
+func (ftm *FtmBridge) SfcVersion(ctx context.Context) (hexutil.Uint64, error) {
 	// get the version information from the contract
 	var ver [3]byte
 	var err error
-	ver, err = ftm.SfcContract().Version(nil)
+	ver, err = ftm.SfcContract().Version(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Criticalf("failed to get the SFC version; %s", err.Error())
 		return 0, err
@@ -41,9 +43,9 @@ func (ftm *FtmBridge) SfcVersion() (hexutil.Uint64, error) {
 }
 
 // CurrentEpoch extract the current epoch id from SFC smart contract.
-func (ftm *FtmBridge) CurrentEpoch() (hexutil.Uint64, error) {
+func (ftm *FtmBridge) CurrentEpoch(ctx context.Context) (hexutil.Uint64, error) {
 	// get the value from the contract
-	epoch, err := ftm.SfcContract().CurrentEpoch(ftm.DefaultCallOpts())
+	epoch, err := ftm.SfcContract().CurrentEpoch(ftm.DefaultCallOpts(ctx))
 	if err != nil {
 		ftm.log.Errorf("failed to get the current sealed epoch: %s", err.Error())
 		return 0, err
@@ -52,9 +54,9 @@ func (ftm *FtmBridge) CurrentEpoch() (hexutil.Uint64, error) {
 }
 
 // CurrentSealedEpoch extract the current sealed epoch id from SFC smart contract.
-func (ftm *FtmBridge) CurrentSealedEpoch() (hexutil.Uint64, error) {
+func (ftm *FtmBridge) CurrentSealedEpoch(ctx context.Context) (hexutil.Uint64, error) {
 	// get the value from the contract
-	epoch, err := ftm.SfcContract().CurrentSealedEpoch(ftm.DefaultCallOpts())
+	epoch, err := ftm.SfcContract().CurrentSealedEpoch(ftm.DefaultCallOpts(ctx))
 	if err != nil {
 		ftm.log.Errorf("failed to get the current sealed epoch: %s", err.Error())
 		return 0, err
@@ -63,9 +65,9 @@ func (ftm *FtmBridge) CurrentSealedEpoch() (hexutil.Uint64, error) {
 }
 
 // Epoch extract information about an epoch from SFC smart contract.
-func (ftm *FtmBridge) Epoch(id hexutil.Uint64) (*types.Epoch, error) {
+func (ftm *FtmBridge) Epoch(ctx context.Context, id hexutil.Uint64) (*types.Epoch, error) {
 	// extract epoch snapshot
-	epo, err := ftm.SfcContract().GetEpochSnapshot(nil, big.NewInt(int64(id)))
+	epo, err := ftm.SfcContract().GetEpochSnapshot(&bind.CallOpts{Context: ctx}, big.NewInt(int64(id)))
 	if err != nil {
 		ftm.log.Errorf("failed to extract epoch information: %s", err.Error())
 		return nil, err
@@ -83,16 +85,158 @@ func (ftm *FtmBridge) Epoch(id hexutil.Uint64) (*types.Epoch, error) {
 	}, nil
 }
 
+// EpochValidators extracts the list of validators of the given sealed epoch
+// with their received stake and rewards from SFC smart contract.
+func (ftm *FtmBridge) EpochValidators(ctx context.Context, id hexutil.Uint64) ([]types.EpochValidator, error) {
+	epoch := new(big.Int).SetUint64(uint64(id))
+	ids, err := ftm.SfcContract().GetEpochValidatorIDs(&bind.CallOpts{Context: ctx}, epoch)
+	if err != nil {
+		ftm.log.Errorf("failed to get validators of epoch #%d: %s", uint64(id), err.Error())
+		return nil, err
+	}
+
+	// aggregate the reads, if possible
+	if ftm.hasMulticall() {
+		return ftm.epochValidatorsAggregated(ctx, epoch, ids)
+	}
+
+	// collect the validators details
+	list := make([]types.EpochValidator, len(ids))
+	for i, vid := range ids {
+		ev, err := ftm.epochValidator(ctx, epoch, vid)
+		if err != nil {
+			return nil, err
+		}
+		list[i] = *ev
+	}
+	return list, nil
+}
+
+// epochValidator extracts the snapshot of the given validator at the given epoch.
+func (ftm *FtmBridge) epochValidator(ctx context.Context, epoch *big.Int, vid *big.Int) (*types.EpochValidator, error) {
+	contract := ftm.SfcContract()
+	stake, err := contract.GetEpochReceivedStake(&bind.CallOpts{Context: ctx}, epoch, vid)
+	if err != nil {
+		ftm.log.Errorf("failed to get validator #%d epoch stake: %s", vid.Uint64(), err.Error())
+		return nil, err
+	}
+
+	// the reward per token is accumulated over epochs,
+	// the epoch reward is the difference to the previous epoch
+	arpt, err := contract.GetEpochAccumulatedRewardPerToken(&bind.CallOpts{Context: ctx}, epoch, vid)
+	if err != nil {
+		ftm.log.Errorf("failed to get validator #%d epoch reward: %s", vid.Uint64(), err.Error())
+		return nil, err
+	}
+	rpt := new(big.Int).Set(arpt)
+	if epoch.Sign() > 0 {
+		prev, err := contract.GetEpochAccumulatedRewardPerToken(&bind.CallOpts{Context: ctx}, new(big.Int).Sub(epoch, big.NewInt(1)), vid)
+		if err != nil {
+			ftm.log.Errorf("failed to get validator #%d previous epoch reward: %s", vid.Uint64(), err.Error())
+			return nil, err
+		}
+		rpt.Sub(rpt, prev)
+	}
+
+	fee, err := contract.GetEpochAccumulatedOriginatedTxsFee(&bind.CallOpts{Context: ctx}, epoch, vid)
+	if err != nil {
+		ftm.log.Errorf("failed to get validator #%d epoch fee: %s", vid.Uint64(), err.Error())
+		return nil, err
+	}
+
+	uptime, err := contract.GetEpochAccumulatedUptime(&bind.CallOpts{Context: ctx}, epoch, vid)
+	if err != nil {
+		ftm.log.Errorf("failed to get validator #%d epoch uptime: %s", vid.Uint64(), err.Error())
+		return nil, err
+	}
+
+	return &types.EpochValidator{
+		ValidatorId:               (hexutil.Big)(*vid),
+		ReceivedStake:             (hexutil.Big)(*stake),
+		RewardPerToken:            (hexutil.Big)(*rpt),
+		AccumulatedRewardPerToken: (hexutil.Big)(*arpt),
+		OriginatedTxsFee:          (hexutil.Big)(*fee),
+		Uptime:                    (hexutil.Big)(*uptime),
+	}, nil
+}
+
+// epochValidatorsAggregated extracts the snapshots of the given validators at the given epoch
+// with the SFC reads of all the validators aggregated by the Multicall contract.
+func (ftm *FtmBridge) epochValidatorsAggregated(ctx context.Context, epoch *big.Int, ids []*big.Int) ([]types.EpochValidator, error) {
+	sfc := ftm.SfcAddress()
+	read := func(method string, ep *big.Int) ([]*big.Int, error) {
+		calls := make([]multicallCall, len(ids))
+		for i, vid := range ids {
+			data, err := ftm.SfcAbi().Pack(method, ep, vid)
+			if err != nil {
+				return nil, err
+			}
+			calls[i] = multicallCall{Target: sfc, CallData: data}
+		}
+
+		res, err := ftm.aggregateBig(ctx, ftm.SfcAbi(), method, calls)
+		if err != nil {
+			ftm.log.Errorf("failed to get validators %s of epoch #%d: %s", method, ep.Uint64(), err.Error())
+		}
+		return res, err
+	}
+
+	stake, err := read("getEpochReceivedStake", epoch)
+	if err != nil {
+		return nil, err
+	}
+	arpt, err := read("getEpochAccumulatedRewardPerToken", epoch)
+	if err != nil {
+		return nil, err
+	}
+	fee, err := read("getEpochAccumulatedOriginatedTxsFee", epoch)
+	if err != nil {
+		return nil, err
+	}
+	uptime, err := read("getEpochAccumulatedUptime", epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	// the reward per token is accumulated over epochs,
+	// the epoch reward is the difference to the previous epoch
+	var prev []*big.Int
+	if epoch.Sign() > 0 {
+		prev, err = read("getEpochAccumulatedRewardPerToken", new(big.Int).Sub(epoch, big.NewInt(1)))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	list := make([]types.EpochValidator, len(ids))
+	for i, vid := range ids {
+		rpt := new(big.Int).Set(arpt[i])
+		if prev != nil {
+			rpt.Sub(rpt, prev[i])
+		}
+
+		list[i] = types.EpochValidator{
+			ValidatorId:               (hexutil.Big)(*vid),
+			ReceivedStake:             (hexutil.Big)(*stake[i]),
+			RewardPerToken:            (hexutil.Big)(*rpt),
+			AccumulatedRewardPerToken: (hexutil.Big)(*arpt[i]),
+			OriginatedTxsFee:          (hexutil.Big)(*fee[i]),
+			Uptime:                    (hexutil.Big)(*uptime[i]),
+		}
+	}
+	return list, nil
+}
+
 // RewardsAllowed returns if the rewards can be manipulated with.
-func (ftm *FtmBridge) RewardsAllowed() (bool, error) {
+func (ftm *FtmBridge) RewardsAllowed(ctx context.Context) (bool, error) {
 	ftm.log.Debug("rewards lock always open")
 	return true, nil
 }
 
 // LockingAllowed indicates if the stake locking has been enabled in SFC.
-func (ftm *FtmBridge) LockingAllowed() (bool, error) {
+func (ftm *FtmBridge) LockingAllowed(ctx context.Context) (bool, error) {
 	// get the current sealed epoch value from the contract
-	epoch, err := ftm.SfcContract().CurrentSealedEpoch(nil)
+	epoch, err := ftm.SfcContract().CurrentSealedEpoch(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("failed to get the current sealed epoch: %s", err.Error())
 		return false, err
@@ -102,36 +246,46 @@ func (ftm *FtmBridge) LockingAllowed() (bool, error) {
 }
 
 // TotalStaked returns the total amount of staked tokens.
-func (ftm *FtmBridge) TotalStaked() (*big.Int, error) {
-	return ftm.SfcContract().TotalStake(ftm.DefaultCallOpts())
+func (ftm *FtmBridge) TotalStaked(ctx context.Context) (*big.Int, error) {
+	return ftm.SfcContract().TotalStake(ftm.DefaultCallOpts(ctx))
 }
 
 // SfcMinValidatorStake extracts a value of minimal validator self stake.
-func (ftm *FtmBridge) SfcMinValidatorStake() (*big.Int, error) {
-	return ftm.SfcContract().MinSelfStake(ftm.DefaultCallOpts())
+func (ftm *FtmBridge) SfcMinValidatorStake(ctx context.Context) (*big.Int, error) {
+	return ftm.SfcContract().MinSelfStake(ftm.DefaultCallOpts(ctx))
 }
 
 // SfcMaxDelegatedRatio extracts a ratio between self delegation and received stake.
-func (ftm *FtmBridge) SfcMaxDelegatedRatio() (*big.Int, error) {
-	return ftm.SfcContract().MaxDelegatedRatio(ftm.DefaultCallOpts())
+func (ftm *FtmBridge) SfcMaxDelegatedRatio(ctx context.Context) (*big.Int, error) {
+	return ftm.SfcContract().MaxDelegatedRatio(ftm.DefaultCallOpts(ctx))
 }
 
 // SfcMinLockupDuration extracts a minimal lockup duration.
-func (ftm *FtmBridge) SfcMinLockupDuration() (*big.Int, error) {
-	return ftm.SfcContract().MinLockupDuration(ftm.DefaultCallOpts())
+func (ftm *FtmBridge) SfcMinLockupDuration(ctx context.Context) (*big.Int, error) {
+	return ftm.SfcContract().MinLockupDuration(ftm.DefaultCallOpts(ctx))
 }
 
 // SfcMaxLockupDuration extracts a maximal lockup duration.
-func (ftm *FtmBridge) SfcMaxLockupDuration() (*big.Int, error) {
-	return ftm.SfcContract().MaxLockupDuration(ftm.DefaultCallOpts())
+func (ftm *FtmBridge) SfcMaxLockupDuration(ctx context.Context) (*big.Int, error) {
+	return ftm.SfcContract().MaxLockupDuration(ftm.DefaultCallOpts(ctx))
+}
+
+// SfcUnlockedRewardRatio extracts the share of full rewards paid to unlocked stake.
+func (ftm *FtmBridge) SfcUnlockedRewardRatio(ctx context.Context) (*big.Int, error) {
+	return ftm.SfcContract().UnlockedRewardRatio(ftm.DefaultCallOpts(ctx))
+}
+
+// SfcValidatorCommission extracts the share of delegators rewards paid to the validator.
+func (ftm *FtmBridge) SfcValidatorCommission(ctx context.Context) (*big.Int, error) {
+	return ftm.SfcContract().ValidatorCommission(ftm.DefaultCallOpts(ctx))
 }
 
 // SfcWithdrawalPeriodEpochs extracts a minimal number of epochs between un-delegate and withdraw.
-func (ftm *FtmBridge) SfcWithdrawalPeriodEpochs() (*big.Int, error) {
-	return ftm.SfcContract().WithdrawalPeriodEpochs(ftm.DefaultCallOpts())
+func (ftm *FtmBridge) SfcWithdrawalPeriodEpochs(ctx context.Context) (*big.Int, error) {
+	return ftm.SfcContract().WithdrawalPeriodEpochs(ftm.DefaultCallOpts(ctx))
 }
 
 // SfcWithdrawalPeriodTime extracts a minimal number of seconds between un-delegate and withdraw.
-func (ftm *FtmBridge) SfcWithdrawalPeriodTime() (*big.Int, error) {
-	return ftm.SfcContract().WithdrawalPeriodTime(ftm.DefaultCallOpts())
+func (ftm *FtmBridge) SfcWithdrawalPeriodTime(ctx context.Context) (*big.Int, error) {
+	return ftm.SfcContract().WithdrawalPeriodTime(ftm.DefaultCallOpts(ctx))
 }
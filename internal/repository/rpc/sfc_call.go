@@ -0,0 +1,34 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// SfcCallData packs the call data of the given SFC contract method and arguments.
+func (ftm *FtmBridge) SfcCallData(method string, args ...interface{}) (hexutil.Bytes, error) {
+	cd, err := ftm.SfcAbi().Pack(method, args...)
+	if err != nil {
+		ftm.log.Errorf("can not pack SFC call %s; %s", method, err.Error())
+		return nil, err
+	}
+	return cd, nil
+}
+
+// SfcAddress returns the address of the SFC contract.
+func (ftm *FtmBridge) SfcAddress() common.Address {
+	return ftm.sfcConfig.SFCContract
+}
@@ -25,24 +25,24 @@ import (
 )
 
 // AmountStaked returns the current amount at stake for the given staker address and target validator
-func (ftm *FtmBridge) AmountStaked(addr *common.Address, valID *big.Int) (*big.Int, error) {
+func (ftm *FtmBridge) AmountStaked(ctx context.Context, addr *common.Address, valID *big.Int) (*big.Int, error) {
 	// keep track of the operation
 	ftm.log.Debugf("verifying amount staked by %s to %d", addr.String(), valID.Uint64())
-	return ftm.SfcContract().GetStake(ftm.DefaultCallOpts(), *addr, valID)
+	return ftm.SfcContract().GetStake(ftm.DefaultCallOpts(ctx), *addr, valID)
 }
 
 // AmountStakeLocked returns the current locked amount at stake for the given staker address and target validator.
-func (ftm *FtmBridge) AmountStakeLocked(addr *common.Address, valID *big.Int) (*big.Int, error) {
-	return ftm.SfcContract().GetLockedStake(ftm.DefaultCallOpts(), *addr, valID)
+func (ftm *FtmBridge) AmountStakeLocked(ctx context.Context, addr *common.Address, valID *big.Int) (*big.Int, error) {
+	return ftm.SfcContract().GetLockedStake(ftm.DefaultCallOpts(ctx), *addr, valID)
 }
 
 // AmountStakeUnlocked returns the current unlocked amount at stake for the given staker address and target validator.
-func (ftm *FtmBridge) AmountStakeUnlocked(addr *common.Address, valID *big.Int) (*big.Int, error) {
-	return ftm.SfcContract().GetUnlockedStake(ftm.DefaultCallOpts(), *addr, valID)
+func (ftm *FtmBridge) AmountStakeUnlocked(ctx context.Context, addr *common.Address, valID *big.Int) (*big.Int, error) {
+	return ftm.SfcContract().GetUnlockedStake(ftm.DefaultCallOpts(ctx), *addr, valID)
 }
 
 // StakeUnlockPenalty returns the expected penalty of a premature stake unlock.
-func (ftm *FtmBridge) StakeUnlockPenalty(addr *common.Address, valID *big.Int, amount *big.Int) (*big.Int, error) {
+func (ftm *FtmBridge) StakeUnlockPenalty(ctx context.Context, addr *common.Address, valID *big.Int, amount *big.Int) (*big.Int, error) {
 	// pack call data
 	cd, err := ftm.SfcAbi().Pack("unlockStake", valID, amount)
 	if err != nil {
@@ -51,7 +51,7 @@ func (ftm *FtmBridge) StakeUnlockPenalty(addr *common.Address, valID *big.Int, a
 	}
 
 	// make the UnlockStake call as a view call to get the penalty value
-	data, err := ftm.eth.CallContract(context.Background(), ethereum.CallMsg{
+	data, err := ftm.eth.CallContract(ctx, ethereum.CallMsg{
 		From: *addr,
 		To:   &ftm.sfcConfig.SFCContract,
 		Data: cd,
@@ -72,7 +72,7 @@ func (ftm *FtmBridge) StakeUnlockPenalty(addr *common.Address, valID *big.Int, a
 }
 
 // PendingRewards returns a detail of delegation rewards waiting to be claimed for the given delegation.
-func (ftm *FtmBridge) PendingRewards(addr *common.Address, valID *big.Int) (*types.PendingRewards, error) {
+func (ftm *FtmBridge) PendingRewards(ctx context.Context, addr *common.Address, valID *big.Int) (*types.PendingRewards, error) {
 	// prep the empty value
 	pr := types.PendingRewards{
 		Address: *addr,
@@ -81,7 +81,7 @@ func (ftm *FtmBridge) PendingRewards(addr *common.Address, valID *big.Int) (*typ
 	}
 
 	// get the pending rewards amount
-	amo, err := ftm.SfcContract().PendingRewards(ftm.DefaultCallOpts(), *addr, valID)
+	amo, err := ftm.SfcContract().PendingRewards(ftm.DefaultCallOpts(ctx), *addr, valID)
 	if err != nil {
 		ftm.log.Criticalf("can not calculate pending rewards of %s to %d; %s", addr.String(), valID.Uint64(), err.Error())
 		return &pr, nil
@@ -93,7 +93,7 @@ func (ftm *FtmBridge) PendingRewards(addr *common.Address, valID *big.Int) (*typ
 }
 
 // DelegationLock returns delegation lock information using SFC contract binding.
-func (ftm *FtmBridge) DelegationLock(addr *common.Address, valID *hexutil.Big) (dll *types.DelegationLock, err error) {
+func (ftm *FtmBridge) DelegationLock(ctx context.Context, addr *common.Address, valID *hexutil.Big) (dll *types.DelegationLock, err error) {
 	// recover from panic here
 	defer func() {
 		if r := recover(); r != nil {
@@ -103,7 +103,7 @@ func (ftm *FtmBridge) DelegationLock(addr *common.Address, valID *hexutil.Big) (
 	}()
 
 	// get staker locking detail
-	lock, err := ftm.SfcContract().GetLockupInfo(ftm.DefaultCallOpts(), *addr, valID.ToInt())
+	lock, err := ftm.SfcContract().GetLockupInfo(ftm.DefaultCallOpts(ctx), *addr, valID.ToInt())
 	if err != nil {
 		ftm.log.Errorf("delegation lock query failed; %v", err)
 		return nil, err
@@ -126,7 +126,7 @@ func (ftm *FtmBridge) DelegationLock(addr *common.Address, valID *hexutil.Big) (
 
 // DelegationOutstandingSFTM returns the amount of sFTM tokens for the delegation
 // identified by the delegator address and the stakerId.
-func (ftm *FtmBridge) DelegationOutstandingSFTM(addr *common.Address, valID *big.Int) (*big.Int, error) {
+func (ftm *FtmBridge) DelegationOutstandingSFTM(ctx context.Context, addr *common.Address, valID *big.Int) (*big.Int, error) {
 	// log action
 	ftm.log.Debugf("checking outstanding sFTM of %s to %d", addr.String(), valID.Uint64())
 
@@ -138,12 +138,12 @@ func (ftm *FtmBridge) DelegationOutstandingSFTM(addr *common.Address, valID *big
 	}
 
 	// get the amount of outstanding sFTM
-	return contract.OutstandingSFTM(ftm.DefaultCallOpts(), *addr, valID)
+	return contract.OutstandingSFTM(ftm.DefaultCallOpts(ctx), *addr, valID)
 }
 
 // DelegationTokenizerUnlocked returns the status of SFC Tokenizer lock
 // for a delegation identified by the address and staker id.
-func (ftm *FtmBridge) DelegationTokenizerUnlocked(addr *common.Address, valID *big.Int) (bool, error) {
+func (ftm *FtmBridge) DelegationTokenizerUnlocked(ctx context.Context, addr *common.Address, valID *big.Int) (bool, error) {
 	// log action
 	ftm.log.Debugf("checking SFC tokenizer lock of %s to %d", addr.String(), valID.Uint64())
 
@@ -155,7 +155,7 @@ func (ftm *FtmBridge) DelegationTokenizerUnlocked(addr *common.Address, valID *b
 	}
 
 	// get the lock status
-	lock, err := contract.AllowedToWithdrawStake(ftm.DefaultCallOpts(), *addr, valID)
+	lock, err := contract.AllowedToWithdrawStake(ftm.DefaultCallOpts(ctx), *addr, valID)
 	if err != nil {
 		ftm.log.Criticalf("failed to get SFC Tokenizer lock status of %s to %d; %s", addr.String(), valID.Uint64(), err.Error())
 		return false, err
@@ -14,21 +14,23 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/types"
 	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
 )
 
 // ValidatorDowntime pulls information about validator downtime from the RPC interface.
-func (ftm *FtmBridge) ValidatorDowntime(valID *hexutil.Big) (uint64, uint64, error) {
+func (ftm *FtmBridge) ValidatorDowntime(ctx context.Context, valID *hexutil.Big) (uint64, uint64, error) {
 	// use rather the public API, it should be faster since it does not involve contract call
 	var dt struct {
 		Blocks hexutil.Uint64 `json:"offlineBlocks"`
 		Time   hexutil.Uint64 `json:"offlineTime"`
 	}
-	if err := ftm.rpc.Call(&dt, "abft_getDowntime", valID); err != nil {
+	if err := ftm.rpc.CallContext(ctx, &dt, "abft_getDowntime", valID); err != nil {
 		ftm.log.Errorf("failed to get downtime of validator #%d; %s", valID.ToInt().Uint64(), err.Error())
 		return 0, 0, err
 	}
@@ -37,10 +39,10 @@ func (ftm *FtmBridge) ValidatorDowntime(valID *hexutil.Big) (uint64, uint64, err
 }
 
 // ValidatorEpochUptime pulls information about validator uptime on the given epoch.
-func (ftm *FtmBridge) ValidatorEpochUptime(valID *hexutil.Big) (uint64, error) {
+func (ftm *FtmBridge) ValidatorEpochUptime(ctx context.Context, valID *hexutil.Big) (uint64, error) {
 	// use rather the public API, it should be faster since it does not involve contract call
 	var ut hexutil.Uint64
-	if err := ftm.rpc.Call(&ut, "abft_getEpochUptime", valID); err != nil {
+	if err := ftm.rpc.CallContext(ctx, &ut, "abft_getEpochUptime", valID); err != nil {
 		ftm.log.Errorf("failed to get epoch uptime of validator #%d; %s", valID.ToInt().Uint64(), err.Error())
 		return 0, err
 	}
@@ -49,9 +51,9 @@ func (ftm *FtmBridge) ValidatorEpochUptime(valID *hexutil.Big) (uint64, error) {
 }
 
 // LastValidatorId returns the last staker id in Opera blockchain.
-func (ftm *FtmBridge) LastValidatorId() (uint64, error) {
+func (ftm *FtmBridge) LastValidatorId(ctx context.Context) (uint64, error) {
 	// get the value from the contract
-	sl, err := ftm.SfcContract().LastValidatorID(nil)
+	sl, err := ftm.SfcContract().LastValidatorID(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("failed to get the last staker ID: %s", err.Error())
 		return 0, err
@@ -60,16 +62,16 @@ func (ftm *FtmBridge) LastValidatorId() (uint64, error) {
 }
 
 // ValidatorsCount returns the number of validators in Opera blockchain.
-func (ftm *FtmBridge) ValidatorsCount() (uint64, error) {
+func (ftm *FtmBridge) ValidatorsCount(ctx context.Context) (uint64, error) {
 	// get the value from the contract
-	epoch, err := ftm.SfcContract().CurrentEpoch(ftm.DefaultCallOpts())
+	epoch, err := ftm.SfcContract().CurrentEpoch(ftm.DefaultCallOpts(ctx))
 	if err != nil {
 		ftm.log.Errorf("failed to get the current sealed epoch: %s", err.Error())
 		return 0, err
 	}
 
 	// get the value from the contract
-	val, err := ftm.SfcContract().GetEpochValidatorIDs(nil, epoch)
+	val, err := ftm.SfcContract().GetEpochValidatorIDs(&bind.CallOpts{Context: ctx}, epoch)
 	if err != nil {
 		ftm.log.Errorf("failed to get the list of validators; %s", err.Error())
 		return 0, err
@@ -80,7 +82,7 @@ func (ftm *FtmBridge) ValidatorsCount() (uint64, error) {
 }
 
 // Validator extract a staker information by numeric id.
-func (ftm *FtmBridge) Validator(valID *big.Int) (*types.Validator, error) {
+func (ftm *FtmBridge) Validator(ctx context.Context, valID *big.Int) (*types.Validator, error) {
 	// no validator id?
 	if valID == nil {
 		return nil, fmt.Errorf("validator ID not provided")
@@ -88,13 +90,13 @@ func (ftm *FtmBridge) Validator(valID *big.Int) (*types.Validator, error) {
 
 	// keep track of the operation
 	ftm.log.Debugf("loading validator #%d", valID.Uint64())
-	return ftm.validatorById(valID)
+	return ftm.validatorById(ctx, valID)
 }
 
 // validatorById loads details of a validator with the specified ID.
-func (ftm *FtmBridge) validatorById(valID *big.Int) (*types.Validator, error) {
+func (ftm *FtmBridge) validatorById(ctx context.Context, valID *big.Int) (*types.Validator, error) {
 	// call for data
-	val, err := ftm.SfcContract().GetValidator(nil, valID)
+	val, err := ftm.SfcContract().GetValidator(&bind.CallOpts{Context: ctx}, valID)
 	if err != nil {
 		ftm.log.Criticalf("failed to load validator #%d from SFC; %s", valID.Uint64(), err.Error())
 		return nil, err
@@ -133,9 +135,9 @@ func (ftm *FtmBridge) validatorById(valID *big.Int) (*types.Validator, error) {
 }
 
 // ValidatorAddress extract a staker address for the given staker ID.
-func (ftm *FtmBridge) ValidatorAddress(valID *big.Int) (*common.Address, error) {
+func (ftm *FtmBridge) ValidatorAddress(ctx context.Context, valID *big.Int) (*common.Address, error) {
 	// do we have an address call?
-	val, err := ftm.SfcContract().GetValidator(nil, valID)
+	val, err := ftm.SfcContract().GetValidator(&bind.CallOpts{Context: ctx}, valID)
 	if err != nil {
 		ftm.log.Error("validator information could not be extracted")
 		return nil, err
@@ -152,12 +154,12 @@ func (ftm *FtmBridge) ValidatorAddress(valID *big.Int) (*common.Address, error)
 }
 
 // IsValidator returns if the given address is an SFC validator.
-func (ftm *FtmBridge) IsValidator(addr *common.Address) (bool, error) {
+func (ftm *FtmBridge) IsValidator(ctx context.Context, addr *common.Address) (bool, error) {
 	// keep track of the operation
 	ftm.log.Debugf("verifying validator address %s", addr.String())
 
 	// try to get the id
-	id, err := ftm.SfcContract().GetValidatorID(nil, *addr)
+	id, err := ftm.SfcContract().GetValidatorID(&bind.CallOpts{Context: ctx}, *addr)
 	if err != nil {
 		ftm.log.Criticalf("can not check validator at %s; %s", addr.String(), err.Error())
 		return false, err
@@ -167,7 +169,7 @@ func (ftm *FtmBridge) IsValidator(addr *common.Address) (bool, error) {
 }
 
 // ValidatorByAddress extracts a validator information by address.
-func (ftm *FtmBridge) ValidatorByAddress(addr *common.Address) (*types.Validator, error) {
+func (ftm *FtmBridge) ValidatorByAddress(ctx context.Context, addr *common.Address) (*types.Validator, error) {
 	// no validator id?
 	if addr == nil {
 		return nil, fmt.Errorf("validator address not provided")
@@ -177,7 +179,7 @@ func (ftm *FtmBridge) ValidatorByAddress(addr *common.Address) (*types.Validator
 	ftm.log.Debugf("loading validator with address %s", addr.String())
 
 	// try to get the staker id
-	id, err := ftm.SfcContract().GetValidatorID(ftm.DefaultCallOpts(), *addr)
+	id, err := ftm.SfcContract().GetValidatorID(ftm.DefaultCallOpts(ctx), *addr)
 	if err != nil {
 		ftm.log.Criticalf("can not check validator at %s; %s", addr.String(), err.Error())
 		return nil, err
@@ -188,5 +190,100 @@ func (ftm *FtmBridge) ValidatorByAddress(addr *common.Address) (*types.Validator
 		ftm.log.Debugf("validator not found for address %s", addr.String())
 		return nil, nil
 	}
-	return ftm.validatorById(id)
+	return ftm.validatorById(ctx, id)
+}
+
+// EpochValidatorsPerformance extracts the performance of validators within the given sealed epoch
+// from the SFC epoch snapshots. The uptime and originated fee are accumulated by the SFC
+// over epochs, so the epoch values are the difference to the previous epoch snapshot.
+func (ftm *FtmBridge) EpochValidatorsPerformance(ctx context.Context, id hexutil.Uint64) ([]*types.ValidatorEpochPerformance, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("epoch #0 has no performance data")
+	}
+
+	// get the snapshots of the epoch and the previous one
+	contract := ftm.SfcContract()
+	epoch := new(big.Int).SetUint64(uint64(id))
+	prev := new(big.Int).Sub(epoch, big.NewInt(1))
+
+	snap, err := contract.GetEpochSnapshot(&bind.CallOpts{Context: ctx}, epoch)
+	if err != nil {
+		ftm.log.Errorf("failed to get epoch #%d snapshot: %s", uint64(id), err.Error())
+		return nil, err
+	}
+	prevSnap, err := contract.GetEpochSnapshot(&bind.CallOpts{Context: ctx}, prev)
+	if err != nil {
+		ftm.log.Errorf("failed to get epoch #%d snapshot: %s", prev.Uint64(), err.Error())
+		return nil, err
+	}
+
+	// the duration of the epoch
+	var dur uint64
+	if snap.EndTime.Cmp(prevSnap.EndTime) > 0 {
+		dur = new(big.Int).Sub(snap.EndTime, prevSnap.EndTime).Uint64()
+	}
+
+	ids, err := contract.GetEpochValidatorIDs(&bind.CallOpts{Context: ctx}, epoch)
+	if err != nil {
+		ftm.log.Errorf("failed to get validators of epoch #%d: %s", uint64(id), err.Error())
+		return nil, err
+	}
+
+	// collect the performance of each validator
+	list := make([]*types.ValidatorEpochPerformance, len(ids))
+	for i, vid := range ids {
+		up, err := ftm.sfcEpochDelta(ctx, contract.GetEpochAccumulatedUptime, epoch, prev, vid)
+		if err != nil {
+			ftm.log.Errorf("failed to get validator #%d epoch uptime: %s", vid.Uint64(), err.Error())
+			return nil, err
+		}
+
+		fee, err := ftm.sfcEpochDelta(ctx, contract.GetEpochAccumulatedOriginatedTxsFee, epoch, prev, vid)
+		if err != nil {
+			ftm.log.Errorf("failed to get validator #%d epoch fee: %s", vid.Uint64(), err.Error())
+			return nil, err
+		}
+
+		missed, err := contract.GetEpochOfflineBlocks(&bind.CallOpts{Context: ctx}, epoch, vid)
+		if err != nil {
+			ftm.log.Errorf("failed to get validator #%d epoch offline blocks: %s", vid.Uint64(), err.Error())
+			return nil, err
+		}
+
+		list[i] = &types.ValidatorEpochPerformance{
+			ValidatorId:   (hexutil.Big)(*vid),
+			Epoch:         id,
+			EpochDuration: dur,
+			Uptime:        up.Uint64(),
+			MissedBlocks:  missed.Uint64(),
+			OriginatedFee: (hexutil.Big)(*fee),
+			EpochFee:      (hexutil.Big)(*snap.EpochFee),
+		}
+	}
+	return list, nil
+}
+
+// sfcEpochDelta calculates the difference of an accumulated SFC validator value
+// between the given epoch and the previous one.
+func (ftm *FtmBridge) sfcEpochDelta(ctx context.Context,
+	get func(*bind.CallOpts, *big.Int, *big.Int) (*big.Int, error),
+	epoch *big.Int,
+	prev *big.Int,
+	vid *big.Int,
+) (*big.Int, error) {
+	val, err := get(&bind.CallOpts{Context: ctx}, epoch, vid)
+	if err != nil {
+		return nil, err
+	}
+
+	pv, err := get(&bind.CallOpts{Context: ctx}, prev, vid)
+	if err != nil {
+		return nil, err
+	}
+
+	// the accumulated value should never decrease
+	if val.Cmp(pv) < 0 {
+		return new(big.Int), nil
+	}
+	return val.Sub(val, pv), nil
 }
@@ -16,10 +16,12 @@ package rpc
 //go:generate tools/abigen.sh --abi ./contracts/abi/st_info.abi --pkg contracts --type StakerInfoContract --out ./contracts/staker_info.go
 
 import (
+	"context"
 	"encoding/json"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"fantom-api-graphql/internal/types"
 	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"io/ioutil"
 	"math/big"
@@ -36,7 +38,7 @@ const stiRequestTimeout = 2 * time.Second
 var stiNameCheckRegex = regexp.MustCompile(`^[\w\d\s.\-_'$()]+$`)
 
 // StakerInfo extracts an extended staker information from smart contact by their id.
-func (ftm *FtmBridge) StakerInfo(id *hexutil.Big) (*types.StakerInfo, error) {
+func (ftm *FtmBridge) StakerInfo(ctx context.Context, id *hexutil.Big) (*types.StakerInfo, error) {
 	if id == nil {
 		return nil, fmt.Errorf("validator ID not given")
 	}
@@ -52,7 +54,7 @@ func (ftm *FtmBridge) StakerInfo(id *hexutil.Big) (*types.StakerInfo, error) {
 	}
 
 	// call for data
-	stUrl, err := contract.GetInfo(nil, (*big.Int)(id))
+	stUrl, err := contract.GetInfo(&bind.CallOpts{Context: ctx}, (*big.Int)(id))
 	if err != nil {
 		ftm.log.Errorf("failed to get the staker information: %v", err)
 		return nil, err
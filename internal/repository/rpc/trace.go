@@ -0,0 +1,102 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for a remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"strconv"
+	"strings"
+)
+
+// traceRecord represents a single record of the transaction trace
+// as provided by the node trace API.
+type traceRecord struct {
+	Action struct {
+		CallType *string         `json:"callType"`
+		From     common.Address  `json:"from"`
+		To       *common.Address `json:"to"`
+		Gas      hexutil.Uint64  `json:"gas"`
+		Value    hexutil.Big     `json:"value"`
+	} `json:"action"`
+	Result *struct {
+		GasUsed hexutil.Uint64  `json:"gasUsed"`
+		Address *common.Address `json:"address"`
+	} `json:"result"`
+	BlockNumber  uint64  `json:"blockNumber"`
+	TraceAddress []int   `json:"traceAddress"`
+	Type         string  `json:"type"`
+	Error        *string `json:"error"`
+}
+
+// InternalTransactions loads the list of calls executed inside the given transaction
+// using the node trace API. The top level call is the transaction itself and is not included.
+func (ftm *FtmBridge) InternalTransactions(ctx context.Context, hash *common.Hash) ([]*types.InternalTransaction, error) {
+	// keep track of the operation
+	ftm.log.Debugf("tracing transaction %s", hash.String())
+
+	// call for data
+	var traces []traceRecord
+	err := ftm.rpc.CallContext(ctx, &traces, "trace_transaction", hash)
+	if err != nil {
+		ftm.log.Errorf("can not trace transaction %s; %s", hash.String(), err.Error())
+		return nil, err
+	}
+
+	// convert traces to internal transactions;
+	// the node lists the calls in the order of their execution
+	list := make([]*types.InternalTransaction, 0, len(traces))
+	for _, tr := range traces {
+		// skip the top level call
+		if len(tr.TraceAddress) == 0 {
+			continue
+		}
+
+		itx := types.InternalTransaction{
+			TrxHash:      *hash,
+			BlockNumber:  hexutil.Uint64(tr.BlockNumber),
+			TraceAddress: traceAddress(tr.TraceAddress),
+			Ordinal:      uint32(len(list)),
+			Type:         tr.Type,
+			CallType:     tr.Action.CallType,
+			From:         tr.Action.From,
+			To:           tr.Action.To,
+			Value:        tr.Action.Value,
+			Gas:          tr.Action.Gas,
+			Error:        tr.Error,
+		}
+
+		// the result is not available on failed calls;
+		// contract creation provides the new address in the result
+		if tr.Result != nil {
+			itx.GasUsed = tr.Result.GasUsed
+			if itx.To == nil {
+				itx.To = tr.Result.Address
+			}
+		}
+		list = append(list, &itx)
+	}
+	return list, nil
+}
+
+// traceAddress encodes the position of a call in the call tree into a string.
+func traceAddress(ta []int) string {
+	parts := make([]string, len(ta))
+	for i, v := range ta {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, "_")
+}
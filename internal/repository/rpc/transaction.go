@@ -14,20 +14,50 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
+	"context"
+	"errors"
 	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	retypes "github.com/ethereum/go-ethereum/core/types"
+	ftm "github.com/ethereum/go-ethereum/rpc"
 )
 
+// trxOutOfGasReason represents the failure reason of a transaction which consumed all its gas.
+const trxOutOfGasReason = "out of gas"
+
+// trxUnknownReason represents the failure reason of a transaction which could not be replayed.
+const trxUnknownReason = "execution failed"
+
+// trxReceipt represents the part of a transaction receipt we use to complete the transaction.
+type trxReceipt struct {
+	Index             hexutil.Uint64  `json:"transactionIndex"`
+	CumulativeGasUsed hexutil.Uint64  `json:"cumulativeGasUsed"`
+	GasUsed           hexutil.Uint64  `json:"gasUsed"`
+	ContractAddress   *common.Address `json:"contractAddress,omitempty"`
+	Status            hexutil.Uint64  `json:"status"`
+	Logs              []retypes.Log   `json:"logs"`
+}
+
+// apply copies the receipt data into the given transaction.
+func (rec *trxReceipt) apply(trx *types.Transaction) {
+	trx.Index = &rec.Index
+	trx.CumulativeGasUsed = &rec.CumulativeGasUsed
+	trx.GasUsed = &rec.GasUsed
+	trx.ContractAddress = rec.ContractAddress
+	trx.Status = &rec.Status
+	trx.Logs = rec.Logs
+}
+
 // Transaction returns information about a blockchain transaction by hash.
-func (ftm *FtmBridge) Transaction(hash *common.Hash) (*types.Transaction, error) {
+func (ftm *FtmBridge) Transaction(ctx context.Context, hash *common.Hash) (*types.Transaction, error) {
 	// keep track of the operation
 	ftm.log.Debugf("loading transaction %s", hash.String())
 
 	// call for data
 	var trx types.Transaction
-	err := ftm.rpc.Call(&trx, "ftm_getTransactionByHash", hash)
+	err := ftm.rpc.CallContext(ctx, &trx, "ftm_getTransactionByHash", hash)
 	if err != nil {
 		ftm.log.Error("transaction could not be extracted")
 		return nil, err
@@ -35,30 +65,14 @@ func (ftm *FtmBridge) Transaction(hash *common.Hash) (*types.Transaction, error)
 
 	// is there a block reference already?
 	if trx.BlockNumber != nil {
-		// get transaction receipt
-		var rec struct {
-			Index             hexutil.Uint64  `json:"transactionIndex"`
-			CumulativeGasUsed hexutil.Uint64  `json:"cumulativeGasUsed"`
-			GasUsed           hexutil.Uint64  `json:"gasUsed"`
-			ContractAddress   *common.Address `json:"contractAddress,omitempty"`
-			Status            hexutil.Uint64  `json:"status"`
-			Logs              []retypes.Log   `json:"logs"`
-		}
-
 		// call for the transaction receipt data
-		err := ftm.rpc.Call(&rec, "ftm_getTransactionReceipt", hash)
+		var rec trxReceipt
+		err := ftm.rpc.CallContext(ctx, &rec, "ftm_getTransactionReceipt", hash)
 		if err != nil {
 			ftm.log.Errorf("can not get receipt for transaction %s", hash)
 			return nil, err
 		}
-
-		// copy some data
-		trx.Index = &rec.Index
-		trx.CumulativeGasUsed = &rec.CumulativeGasUsed
-		trx.GasUsed = &rec.GasUsed
-		trx.ContractAddress = rec.ContractAddress
-		trx.Status = &rec.Status
-		trx.Logs = rec.Logs
+		rec.apply(&trx)
 	}
 
 	// keep track of the operation
@@ -66,13 +80,59 @@ func (ftm *FtmBridge) Transaction(hash *common.Hash) (*types.Transaction, error)
 	return &trx, nil
 }
 
+// TransactionRevertReason replays the given failed transaction on the state of the parent block
+// and provides the reason of its failure. The replay does not include transactions preceding
+// the given one in its block, so the reason may differ in rare cases.
+func (ftm *FtmBridge) TransactionRevertReason(ctx context.Context, trx *types.Transaction) (string, error) {
+	if trx.BlockNumber == nil || *trx.BlockNumber == 0 {
+		return "", errors.New("transaction not mined")
+	}
+
+	// the transaction consumed all the gas provided
+	if trx.GasUsed != nil && *trx.GasUsed == trx.Gas {
+		return trxOutOfGasReason, nil
+	}
+
+	// replay the call
+	var res hexutil.Bytes
+	err := ftm.rpc.CallContext(ctx, &res, "eth_call", map[string]interface{}{
+		"from":     trx.From,
+		"to":       trx.To,
+		"gas":      trx.Gas,
+		"gasPrice": trx.GasPrice,
+		"value":    trx.Value,
+		"data":     trx.InputData,
+	}, hexutil.Uint64(*trx.BlockNumber-1))
+
+	// the call passed on the replay; we don't know why it failed
+	if err == nil {
+		return trxUnknownReason, nil
+	}
+	return revertReason(err), nil
+}
+
+// revertReason extracts the revert reason from the error of a failed call.
+func revertReason(err error) string {
+	var de ftm.DataError
+	if errors.As(err, &de) {
+		if data, ok := de.ErrorData().(string); ok {
+			if raw, e := hexutil.Decode(data); e == nil {
+				if reason, e := abi.UnpackRevert(raw); e == nil {
+					return reason
+				}
+			}
+		}
+	}
+	return err.Error()
+}
+
 // SendTransaction sends raw signed and RLP encoded transaction to the block chain.
-func (ftm *FtmBridge) SendTransaction(tx hexutil.Bytes) (*common.Hash, error) {
+func (ftm *FtmBridge) SendTransaction(ctx context.Context, tx hexutil.Bytes) (*common.Hash, error) {
 	// keep track of the operation
 	ftm.log.Debug("sending new transaction to block chain")
 
 	var hash common.Hash
-	err := ftm.rpc.Call(&hash, "eth_sendRawTransaction", tx)
+	err := ftm.rpc.CallContext(ctx, &hash, "eth_sendRawTransaction", tx)
 	if err != nil {
 		ftm.log.Error("transaction could not be sent")
 		return nil, err
@@ -82,3 +142,37 @@ func (ftm *FtmBridge) SendTransaction(tx hexutil.Bytes) (*common.Hash, error) {
 	ftm.log.Debugf("transaction has been accepted with hash %s", hash.String())
 	return &hash, nil
 }
+
+// TxPoolStatus provides the number of pending and queued transactions in the node transaction pool.
+func (ftm *FtmBridge) TxPoolStatus(ctx context.Context) (hexutil.Uint64, hexutil.Uint64, error) {
+	var status struct {
+		Pending hexutil.Uint64 `json:"pending"`
+		Queued  hexutil.Uint64 `json:"queued"`
+	}
+	if err := ftm.rpc.CallContext(ctx, &status, "txpool_status"); err != nil {
+		ftm.log.Errorf("can not get the transaction pool status; %s", err.Error())
+		return 0, 0, err
+	}
+	return status.Pending, status.Queued, nil
+}
+
+// TxPoolPending provides the list of transactions pending in the node transaction pool.
+func (ftm *FtmBridge) TxPoolPending(ctx context.Context) ([]*types.Transaction, error) {
+	// the pool content is organized by sender and nonce
+	var content struct {
+		Pending map[common.Address]map[string]*types.Transaction `json:"pending"`
+	}
+	if err := ftm.rpc.CallContext(ctx, &content, "txpool_content"); err != nil {
+		ftm.log.Errorf("can not get the transaction pool content; %s", err.Error())
+		return nil, err
+	}
+
+	// flatten the pool
+	list := make([]*types.Transaction, 0)
+	for _, txs := range content.Pending {
+		for _, trx := range txs {
+			list = append(list, trx)
+		}
+	}
+	return list, nil
+}
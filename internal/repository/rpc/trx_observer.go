@@ -0,0 +1,71 @@
+/*
+Package rpc implements bridge to Lachesis full node API interface.
+
+We recommend using local IPC for fast and the most efficient inter-process communication between the API server
+and an Opera/Lachesis node. Any remote RPC connection will work, but the performance may be significantly degraded
+by extra networking overhead of remote RPC calls.
+
+You should also consider security implications of opening Lachesis RPC interface for remote access.
+If you considering it as your deployment strategy, you should establish encrypted channel between the API server
+and Lachesis RPC interface with connection limited to specified endpoints.
+
+We strongly discourage opening Lachesis RPC interface for unrestricted Internet access.
+*/
+package rpc
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum"
+	"time"
+)
+
+// ftmPendingObserverSubscribeTick represents the time between subscription attempts.
+const ftmPendingObserverSubscribeTick = 30 * time.Second
+
+// observePending collects hashes of new pending transactions from the blockchain node
+// and posts them into the proxy channel for processing.
+func (ftm *FtmBridge) observePending() {
+	var sub ethereum.Subscription
+	defer func() {
+		if sub != nil {
+			sub.Unsubscribe()
+		}
+		ftm.log.Noticef("pending transactions observer done")
+		ftm.wg.Done()
+	}()
+
+	sub = ftm.pendingSubscription()
+	for {
+		// re-subscribe if the subscription ref is not valid
+		if sub == nil {
+			tm := time.NewTimer(ftmPendingObserverSubscribeTick)
+			select {
+			case <-ftm.sigClose:
+				return
+			case <-tm.C:
+				sub = ftm.pendingSubscription()
+				continue
+			}
+		}
+
+		// use the subscriptions
+		select {
+		case <-ftm.sigClose:
+			return
+		case err := <-sub.Err():
+			ftm.log.Errorf("pending transactions subscription failed; %s", err.Error())
+			sub = nil
+		}
+	}
+}
+
+// pendingSubscription provides a subscription for new pending transactions
+// received by the connected blockchain node.
+func (ftm *FtmBridge) pendingSubscription() ethereum.Subscription {
+	sub, err := ftm.rpc.EthSubscribe(context.Background(), ftm.pending, "newPendingTransactions")
+	if err != nil {
+		ftm.log.Errorf("can not observe pending transactions; %s", err.Error())
+		return nil
+	}
+	return sub
+}
@@ -14,6 +14,7 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"math/big"
 	"strings"
@@ -28,7 +29,7 @@ import (
 //go:generate tools/abigen.sh --abi ./contracts/abi/uniswap-router.abi --pkg contracts --type UniswapRouter --out ./contracts/uniswap_router.go
 
 // NativeTokenAddress returns an address of native token.
-func (ftm *FtmBridge) NativeTokenAddress() (*common.Address, error) {
+func (ftm *FtmBridge) NativeTokenAddress(ctx context.Context) (*common.Address, error) {
 	// get the router contract if possible
 	contract, err := contracts.NewUniswapRouter(ftm.uniswapConfig.Router, ftm.eth)
 	if err != nil {
@@ -37,7 +38,7 @@ func (ftm *FtmBridge) NativeTokenAddress() (*common.Address, error) {
 	}
 
 	// get the native token address
-	adr, err := contract.WETH(nil)
+	adr, err := contract.WETH(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("Native token address not available; %s", err.Error())
 		return nil, err
@@ -47,7 +48,7 @@ func (ftm *FtmBridge) NativeTokenAddress() (*common.Address, error) {
 }
 
 // UniswapPair returns an address of an Uniswap pair for the given tokens.
-func (ftm *FtmBridge) UniswapPair(tokenA *common.Address, tokenB *common.Address) (*common.Address, error) {
+func (ftm *FtmBridge) UniswapPair(ctx context.Context, tokenA *common.Address, tokenB *common.Address) (*common.Address, error) {
 	// get the router contract if possible
 	contract, err := contracts.NewUniswapFactory(ftm.uniswapConfig.Core, ftm.eth)
 	if err != nil {
@@ -56,7 +57,7 @@ func (ftm *FtmBridge) UniswapPair(tokenA *common.Address, tokenB *common.Address
 	}
 
 	// try to get the pair
-	pair, err := contract.GetPair(nil, *tokenA, *tokenB)
+	pair, err := contract.GetPair(&bind.CallOpts{Context: ctx}, *tokenA, *tokenB)
 	if err != nil {
 		ftm.log.Errorf("Uniswap pair not found for tokens %s and %s; %s", tokenA.String(), tokenB.String(), err.Error())
 		return nil, err
@@ -66,7 +67,7 @@ func (ftm *FtmBridge) UniswapPair(tokenA *common.Address, tokenB *common.Address
 }
 
 // UniswapPairs returns list of all token pairs managed by Uniswap core.
-func (ftm *FtmBridge) UniswapPairs(whiteListedOnly bool) ([]common.Address, error) {
+func (ftm *FtmBridge) UniswapPairs(ctx context.Context, whiteListedOnly bool) ([]common.Address, error) {
 	// get the router contract if possible
 	contract, err := contracts.NewUniswapFactory(ftm.uniswapConfig.Core, ftm.eth)
 	if err != nil {
@@ -75,7 +76,7 @@ func (ftm *FtmBridge) UniswapPairs(whiteListedOnly bool) ([]common.Address, erro
 	}
 
 	// get the number of pairs
-	length, err := contract.AllPairsLength(nil)
+	length, err := contract.AllPairsLength(&bind.CallOpts{Context: ctx})
 	if err != nil || length == nil {
 		ftm.log.Error("Uniswap pairs array length not available")
 		return nil, err
@@ -88,7 +89,7 @@ func (ftm *FtmBridge) UniswapPairs(whiteListedOnly bool) ([]common.Address, erro
 	index := new(big.Int)
 	for i := uint64(0); i < length.Uint64(); i++ {
 		// get the pair address
-		adr, err := contract.AllPairs(nil, index.SetUint64(i))
+		adr, err := contract.AllPairs(&bind.CallOpts{Context: ctx}, index.SetUint64(i))
 		if err != nil {
 			ftm.log.Errorf("error loading Uniswap pair; %s", err.Error())
 			continue
@@ -123,7 +124,7 @@ func (ftm *FtmBridge) isUniswapPairWhitelisted(pair *common.Address) bool {
 
 // UniswapQuoteInput calculates optimal input on sibling token based on input amount and
 // self reserves of the analyzed token.
-func (ftm *FtmBridge) UniswapQuoteInput(
+func (ftm *FtmBridge) UniswapQuoteInput(ctx context.Context,
 	amountA hexutil.Big,
 	reserveA hexutil.Big,
 	reserveB hexutil.Big,
@@ -136,7 +137,7 @@ func (ftm *FtmBridge) UniswapQuoteInput(
 	}
 
 	// get the quote amount
-	amount, err := contract.Quote(nil, amountA.ToInt(), reserveA.ToInt(), reserveB.ToInt())
+	amount, err := contract.Quote(&bind.CallOpts{Context: ctx}, amountA.ToInt(), reserveA.ToInt(), reserveB.ToInt())
 	if err != nil {
 		ftm.log.Errorf("can not calculate Uniswap quote; %s", err.Error())
 		return hexutil.Big{}, err
@@ -147,7 +148,7 @@ func (ftm *FtmBridge) UniswapQuoteInput(
 
 // UniswapAmountsOut resolves a list of output amounts for the given
 // input amount and a list of tokens to be used to make the swap operation.
-func (ftm *FtmBridge) UniswapAmountsOut(amountIn hexutil.Big, tokens []common.Address) ([]hexutil.Big, error) {
+func (ftm *FtmBridge) UniswapAmountsOut(ctx context.Context, amountIn hexutil.Big, tokens []common.Address) ([]hexutil.Big, error) {
 	// get the router contract if possible
 	contract, err := contracts.NewUniswapRouter(ftm.uniswapConfig.Router, ftm.eth)
 	if err != nil {
@@ -155,12 +156,12 @@ func (ftm *FtmBridge) UniswapAmountsOut(amountIn hexutil.Big, tokens []common.Ad
 		return nil, err
 	}
 
-	return ftm.uniswapLoadAmounts(contract.GetAmountsOut, (*big.Int)(&amountIn), tokens)
+	return ftm.uniswapLoadAmounts(ctx, contract.GetAmountsOut, (*big.Int)(&amountIn), tokens)
 }
 
 // UniswapAmountsIn resolves a list of input amounts for the given
 // output amount and a list of tokens to be used to make the swap operation.
-func (ftm *FtmBridge) UniswapAmountsIn(amountOut hexutil.Big, tokens []common.Address) ([]hexutil.Big, error) {
+func (ftm *FtmBridge) UniswapAmountsIn(ctx context.Context, amountOut hexutil.Big, tokens []common.Address) ([]hexutil.Big, error) {
 	// get the router contract if possible
 	contract, err := contracts.NewUniswapRouter(ftm.uniswapConfig.Router, ftm.eth)
 	if err != nil {
@@ -168,18 +169,18 @@ func (ftm *FtmBridge) UniswapAmountsIn(amountOut hexutil.Big, tokens []common.Ad
 		return nil, err
 	}
 
-	return ftm.uniswapLoadAmounts(contract.GetAmountsIn, (*big.Int)(&amountOut), tokens)
+	return ftm.uniswapLoadAmounts(ctx, contract.GetAmountsIn, (*big.Int)(&amountOut), tokens)
 }
 
 // uniswapLoadAmounts loads a list of swap amounts using the given loader function
 // of the Uniswap router contract.
-func (ftm *FtmBridge) uniswapLoadAmounts(
+func (ftm *FtmBridge) uniswapLoadAmounts(ctx context.Context,
 	loader func(*bind.CallOpts, *big.Int, []common.Address) ([]*big.Int, error),
 	amount *big.Int,
 	tokens []common.Address,
 ) ([]hexutil.Big, error) {
 	// get the amounts list
-	out, err := loader(nil, amount, tokens)
+	out, err := loader(&bind.CallOpts{Context: ctx}, amount, tokens)
 	if err != nil {
 		ftm.log.Errorf("can not load swap amounts; %s", err.Error())
 		return nil, err
@@ -195,7 +196,7 @@ func (ftm *FtmBridge) uniswapLoadAmounts(
 }
 
 // UniswapTokens returns list of addresses of tokens involved in a Uniswap pair.
-func (ftm *FtmBridge) UniswapTokens(pair *common.Address) ([]common.Address, error) {
+func (ftm *FtmBridge) UniswapTokens(ctx context.Context, pair *common.Address) ([]common.Address, error) {
 	// get the pair contract if possible
 	contract, err := contracts.NewUniswapPair(*pair, ftm.eth)
 	if err != nil {
@@ -207,14 +208,14 @@ func (ftm *FtmBridge) UniswapTokens(pair *common.Address) ([]common.Address, err
 	tokens := make([]common.Address, 2)
 
 	// get the first token
-	tokens[0], err = contract.Token0(nil)
+	tokens[0], err = contract.Token0(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("Uniswap pair %s token A not found; %s", pair.String(), err.Error())
 		return nil, err
 	}
 
 	// get the second token
-	tokens[1], err = contract.Token1(nil)
+	tokens[1], err = contract.Token1(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("Uniswap pair %s token B not found; %s", pair.String(), err.Error())
 		return nil, err
@@ -224,9 +225,9 @@ func (ftm *FtmBridge) UniswapTokens(pair *common.Address) ([]common.Address, err
 }
 
 // UniswapReserves returns list of token reserve amounts in a Uniswap pair.
-func (ftm *FtmBridge) UniswapReserves(pair *common.Address) ([]hexutil.Big, error) {
+func (ftm *FtmBridge) UniswapReserves(ctx context.Context, pair *common.Address) ([]hexutil.Big, error) {
 	// get the reserves record from the contract
-	rs, err := ftm.uniswapReservesRecord(pair)
+	rs, err := ftm.uniswapReservesRecord(ctx, pair)
 	if err != nil {
 		ftm.log.Errorf("Uniswap pair %s reserves not available; %s", pair.String(), err.Error())
 		return nil, err
@@ -241,9 +242,9 @@ func (ftm *FtmBridge) UniswapReserves(pair *common.Address) ([]hexutil.Big, erro
 }
 
 // UniswapReservesTimeStamp returns the timestamp of the reserves of a Uniswap pair.
-func (ftm *FtmBridge) UniswapReservesTimeStamp(pair *common.Address) (hexutil.Uint64, error) {
+func (ftm *FtmBridge) UniswapReservesTimeStamp(ctx context.Context, pair *common.Address) (hexutil.Uint64, error) {
 	// get the reserves record from the contract
-	rs, err := ftm.uniswapReservesRecord(pair)
+	rs, err := ftm.uniswapReservesRecord(ctx, pair)
 	if err != nil {
 		ftm.log.Errorf("Uniswap pair %s reserves not available; %s", pair.String(), err.Error())
 		return 0, err
@@ -253,7 +254,7 @@ func (ftm *FtmBridge) UniswapReservesTimeStamp(pair *common.Address) (hexutil.Ui
 }
 
 // UniswapCumulativePrices returns list of token cumulative prices of a Uniswap pair.
-func (ftm *FtmBridge) UniswapCumulativePrices(pair *common.Address) ([]hexutil.Big, error) {
+func (ftm *FtmBridge) UniswapCumulativePrices(ctx context.Context, pair *common.Address) ([]hexutil.Big, error) {
 	// get the pair contract if possible
 	contract, err := contracts.NewUniswapPair(*pair, ftm.eth)
 	if err != nil {
@@ -266,7 +267,7 @@ func (ftm *FtmBridge) UniswapCumulativePrices(pair *common.Address) ([]hexutil.B
 	var price *big.Int
 
 	// get the first token price
-	price, err = contract.Price0CumulativeLast(nil)
+	price, err = contract.Price0CumulativeLast(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("Uniswap pair %s cumulative price A not available; %s", pair.String(), err.Error())
 		return nil, err
@@ -276,7 +277,7 @@ func (ftm *FtmBridge) UniswapCumulativePrices(pair *common.Address) ([]hexutil.B
 	prices[0] = hexutil.Big(*price)
 
 	// get the second token price
-	price, err = contract.Price1CumulativeLast(nil)
+	price, err = contract.Price1CumulativeLast(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("Uniswap pair %s cumulative price B not available; %s", pair.String(), err.Error())
 		return nil, err
@@ -288,7 +289,7 @@ func (ftm *FtmBridge) UniswapCumulativePrices(pair *common.Address) ([]hexutil.B
 }
 
 // uniswapReservesRecord loads the reserves record for the given Uniswap pair.
-func (ftm *FtmBridge) uniswapReservesRecord(pair *common.Address) (*struct {
+func (ftm *FtmBridge) uniswapReservesRecord(ctx context.Context, pair *common.Address) (*struct {
 	Reserve0           *big.Int
 	Reserve1           *big.Int
 	BlockTimestampLast uint32
@@ -301,12 +302,12 @@ func (ftm *FtmBridge) uniswapReservesRecord(pair *common.Address) (*struct {
 	}
 
 	// try to get the reserves
-	rs, err := contract.GetReserves(nil)
+	rs, err := contract.GetReserves(&bind.CallOpts{Context: ctx})
 	return &rs, err
 }
 
 // UniswapLastKValue returns the last value of the pool control coefficient.
-func (ftm *FtmBridge) UniswapLastKValue(pair *common.Address) (hexutil.Big, error) {
+func (ftm *FtmBridge) UniswapLastKValue(ctx context.Context, pair *common.Address) (hexutil.Big, error) {
 	// get the pair contract if possible
 	contract, err := contracts.NewUniswapPair(*pair, ftm.eth)
 	if err != nil {
@@ -315,7 +316,7 @@ func (ftm *FtmBridge) UniswapLastKValue(pair *common.Address) (hexutil.Big, erro
 	}
 
 	// try to get the reserves
-	k, err := contract.KLast(nil)
+	k, err := contract.KLast(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		ftm.log.Errorf("Uniswap pair %s coefficient K not available; %s", pair.String(), err.Error())
 		return hexutil.Big{}, err
@@ -14,19 +14,20 @@ We strongly discourage opening Lachesis RPC interface for unrestricted Internet
 package rpc
 
 import (
+	"context"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"strings"
 )
 
 // GasPrice pulls the current amount of WEI for single Gas.
-func (ftm *FtmBridge) GasPrice() (hexutil.Big, error) {
+func (ftm *FtmBridge) GasPrice(ctx context.Context) (hexutil.Big, error) {
 	// keep track of the operation
 	ftm.log.Debugf("checking current gas price")
 
 	// call for data
 	var price hexutil.Big
-	err := ftm.rpc.Call(&price, "ftm_gasPrice")
+	err := ftm.rpc.CallContext(ctx, &price, "ftm_gasPrice")
 	if err != nil {
 		ftm.log.Error("current gas price could not be obtained")
 		return price, err
@@ -37,7 +38,7 @@ func (ftm *FtmBridge) GasPrice() (hexutil.Big, error) {
 
 // GasEstimate calculates the estimated amount of Gas required to perform
 // transaction described by the input params.
-func (ftm *FtmBridge) GasEstimate(trx *struct {
+func (ftm *FtmBridge) GasEstimate(ctx context.Context, trx *struct {
 	From  *common.Address
 	To    *common.Address
 	Value *hexutil.Big
@@ -47,11 +48,11 @@ func (ftm *FtmBridge) GasEstimate(trx *struct {
 	ftm.log.Debugf("calling for gas amount estimation")
 
 	var val hexutil.Uint64
-	err := ftm.rpc.Call(&val, "ftm_estimateGas", trx)
+	err := ftm.rpc.CallContext(ctx, &val, "ftm_estimateGas", trx)
 	if err != nil {
 		// missing required argument? incompatibility between old and new RPC API
 		if strings.Contains(err.Error(), "missing value") {
-			return ftm.GasEstimateWithBlock(trx)
+			return ftm.GasEstimateWithBlock(ctx, trx)
 		}
 
 		// return error
@@ -66,7 +67,7 @@ func (ftm *FtmBridge) GasEstimate(trx *struct {
 // transaction described by the input params with specifying the block on which the calculation
 // should happen (new RPC API compatibility).
 // @TODO Replace the old gas estimate call once the API gets upgraded on all nodes.
-func (ftm *FtmBridge) GasEstimateWithBlock(trx *struct {
+func (ftm *FtmBridge) GasEstimateWithBlock(ctx context.Context, trx *struct {
 	From  *common.Address
 	To    *common.Address
 	Value *hexutil.Big
@@ -76,7 +77,7 @@ func (ftm *FtmBridge) GasEstimateWithBlock(trx *struct {
 	ftm.log.Debugf("calling for gas amount estimation with block details")
 
 	var val hexutil.Uint64
-	err := ftm.rpc.Call(&val, "ftm_estimateGas", trx, BlockTypeLatest)
+	err := ftm.rpc.CallContext(ctx, &val, "ftm_estimateGas", trx, BlockTypeLatest)
 	if err != nil {
 		// return error
 		ftm.log.Errorf("can not estimate gas; %s", err.Error())
@@ -85,3 +86,27 @@ func (ftm *FtmBridge) GasEstimateWithBlock(trx *struct {
 
 	return &val, nil
 }
+
+// ContractCall executes a read-only message call to the given contract
+// on top of the state of the given block, or the latest block if not specified.
+func (ftm *FtmBridge) ContractCall(ctx context.Context, to *common.Address, data hexutil.Bytes, block *hexutil.Uint64) (hexutil.Bytes, error) {
+	// keep track of the operation
+	ftm.log.Debugf("calling contract %s", to.String())
+
+	// which block state to use?
+	var blk interface{} = BlockTypeLatest
+	if block != nil {
+		blk = block
+	}
+
+	var res hexutil.Bytes
+	err := ftm.rpc.CallContext(ctx, &res, "ftm_call", map[string]interface{}{
+		"to":   to,
+		"data": data,
+	}, blk)
+	if err != nil {
+		ftm.log.Errorf("can not call contract %s; %s", to.String(), err.Error())
+		return nil, err
+	}
+	return res, nil
+}
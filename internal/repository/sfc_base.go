@@ -10,10 +10,12 @@ package repository
 
 import (
 	"bytes"
+	"context"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
+	"time"
 )
 
 // sfcDecimalUnit represents decimal units adjustment used by SFC contract
@@ -26,23 +28,23 @@ func (p *proxy) SfcDecimalUnit() *big.Int {
 }
 
 // SfcVersion returns current version of the SFC contract.
-func (p *proxy) SfcVersion() (hexutil.Uint64, error) {
-	return p.rpc.SfcVersion()
+func (p *proxy) SfcVersion(ctx context.Context) (hexutil.Uint64, error) {
+	return p.rpc.SfcVersion(ctx)
 }
 
 // SfcConfiguration provides SFC contract configuration.
-func (p *proxy) SfcConfiguration() (*types.SfcConfig, error) {
+func (p *proxy) SfcConfiguration(ctx context.Context) (*types.SfcConfig, error) {
 	// try cache first
 	c := p.cache.PullSfcConfig()
 	if c == nil {
 		// load the config with all the values filled
 		c = &types.SfcConfig{
-			MinValidatorStake:      p.pullSfcConfigValue(p.rpc.SfcMinValidatorStake),
-			MaxDelegatedRatio:      p.pullSfcConfigValue(p.rpc.SfcMaxDelegatedRatio),
-			MinLockupDuration:      p.pullSfcConfigValue(p.rpc.SfcMinLockupDuration),
-			MaxLockupDuration:      p.pullSfcConfigValue(p.rpc.SfcMaxLockupDuration),
-			WithdrawalPeriodEpochs: p.pullSfcConfigValue(p.rpc.SfcWithdrawalPeriodEpochs),
-			WithdrawalPeriodTime:   p.pullSfcConfigValue(p.rpc.SfcWithdrawalPeriodTime),
+			MinValidatorStake:      p.pullSfcConfigValue(ctx, p.rpc.SfcMinValidatorStake),
+			MaxDelegatedRatio:      p.pullSfcConfigValue(ctx, p.rpc.SfcMaxDelegatedRatio),
+			MinLockupDuration:      p.pullSfcConfigValue(ctx, p.rpc.SfcMinLockupDuration),
+			MaxLockupDuration:      p.pullSfcConfigValue(ctx, p.rpc.SfcMaxLockupDuration),
+			WithdrawalPeriodEpochs: p.pullSfcConfigValue(ctx, p.rpc.SfcWithdrawalPeriodEpochs),
+			WithdrawalPeriodTime:   p.pullSfcConfigValue(ctx, p.rpc.SfcWithdrawalPeriodTime),
 		}
 		// cache for future use
 		p.cache.PushSfcConfig(c)
@@ -50,9 +52,15 @@ func (p *proxy) SfcConfiguration() (*types.SfcConfig, error) {
 	return c, nil
 }
 
+// SfcValidatorCommission provides the share of delegators rewards paid to the validator;
+// the share is provided with 18 decimals.
+func (p *proxy) SfcValidatorCommission(ctx context.Context) (*big.Int, error) {
+	return p.rpc.SfcValidatorCommission(ctx)
+}
+
 // pullSfcConfigValue pulls SFC config value for the given value loader function.
-func (p *proxy) pullSfcConfigValue(f func() (*big.Int, error)) hexutil.Big {
-	val, err := f()
+func (p *proxy) pullSfcConfigValue(ctx context.Context, f func(context.Context) (*big.Int, error)) hexutil.Big {
+	val, err := f(ctx)
 	if err != nil {
 		p.log.Errorf("can not load SFC config value; %s", err.Error())
 		return hexutil.Big{}
@@ -61,16 +69,16 @@ func (p *proxy) pullSfcConfigValue(f func() (*big.Int, error)) hexutil.Big {
 }
 
 // CurrentEpoch returns the id of the current epoch.
-func (p *proxy) CurrentEpoch() (hexutil.Uint64, error) {
-	return p.rpc.CurrentEpoch()
+func (p *proxy) CurrentEpoch(ctx context.Context) (hexutil.Uint64, error) {
+	return p.rpc.CurrentEpoch(ctx)
 }
 
 // Epoch returns the structure of the current epoch.
-func (p *proxy) Epoch(id *hexutil.Uint64) (*types.Epoch, error) {
+func (p *proxy) Epoch(ctx context.Context, id *hexutil.Uint64) (*types.Epoch, error) {
 	// get the current epoch if the id has not been provided
 	if id == nil || *id == 0 {
 		// ask for the sealed epoch id
-		val, err := p.rpc.CurrentSealedEpoch()
+		val, err := p.rpc.CurrentSealedEpoch(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -84,7 +92,7 @@ func (p *proxy) Epoch(id *hexutil.Uint64) (*types.Epoch, error) {
 	}
 
 	// pull from remote
-	ep, err := p.rpc.Epoch(*id)
+	ep, err := p.rpc.Epoch(ctx, *id)
 	if err != nil {
 		return nil, err
 	}
@@ -100,21 +108,21 @@ func (p *proxy) Epoch(id *hexutil.Uint64) (*types.Epoch, error) {
 // So, we use cache for handling the response.
 // It will not be updated in sync with the SFC contract.
 // If you need real time response, please use the Epoch(id) function instead.
-func (p *proxy) CurrentSealedEpoch() (*types.Epoch, error) {
+func (p *proxy) CurrentSealedEpoch(ctx context.Context) (*types.Epoch, error) {
 	// inform what we do
 	p.log.Debug("latest sealed epoch requested")
 
 	// we need to go the slow path
-	id, err := p.rpc.CurrentSealedEpoch()
+	id, err := p.rpc.CurrentSealedEpoch(ctx)
 	if err != nil {
 		p.log.Errorf("can not get the id of the last sealed epoch; %s", err.Error())
 		return nil, err
 	}
-	return p.Epoch(&id)
+	return p.Epoch(ctx, &id)
 }
 
 // TotalStaked calculates current total staked amount for all stakers.
-func (p *proxy) TotalStaked() (*hexutil.Big, error) {
+func (p *proxy) TotalStaked(ctx context.Context) (*hexutil.Big, error) {
 	// try cache first
 	value := p.cache.PullTotalStaked()
 	if value != nil {
@@ -123,7 +131,7 @@ func (p *proxy) TotalStaked() (*hexutil.Big, error) {
 	}
 
 	// get the actual live value
-	total, err := p.rpc.TotalStaked()
+	total, err := p.rpc.TotalStaked(ctx)
 	if err != nil {
 		p.log.Errorf("can not get the total staked amount; %s", err.Error())
 		return nil, err
@@ -140,31 +148,76 @@ func (p *proxy) TotalStaked() (*hexutil.Big, error) {
 }
 
 // RewardsAllowed returns the reward lock status from SFC.
-func (p *proxy) RewardsAllowed() (bool, error) {
-	return p.rpc.RewardsAllowed()
+func (p *proxy) RewardsAllowed(ctx context.Context) (bool, error) {
+	return p.rpc.RewardsAllowed(ctx)
 }
 
 // LockingAllowed indicates if the stake locking has been enabled in SFC.
-func (p *proxy) LockingAllowed() (bool, error) {
-	return p.rpc.LockingAllowed()
+func (p *proxy) LockingAllowed(ctx context.Context) (bool, error) {
+	return p.rpc.LockingAllowed(ctx)
 }
 
 // IsSfcContract returns true if the given address points to the SFC contract.
-func (p *proxy) IsSfcContract(addr *common.Address) bool {
+func (p *proxy) IsSfcContract(ctx context.Context, addr *common.Address) bool {
 	return bytes.Equal(addr.Bytes(), p.cfg.Staking.SFCContract.Bytes())
 }
 
+// EpochValidators returns the list of validators of the given sealed epoch
+// with their received stake and rewards. Epochs sealed before the snapshots
+// have been kept get the snapshot collected from the SFC contract on the first request.
+func (p *proxy) EpochValidators(ctx context.Context, id hexutil.Uint64) ([]types.EpochValidator, error) {
+	list, err := p.db.EpochValidators(ctx, id)
+	if err != nil || len(list) > 0 {
+		return list, err
+	}
+
+	// the snapshot of a sealed epoch does not change, keep it
+	list, err = p.rpc.EpochValidators(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sealed, err := p.rpc.CurrentSealedEpoch(ctx); err == nil && id <= sealed {
+		if err := p.db.AddEpochValidators(ctx, id, list); err != nil {
+			p.log.Errorf("can not keep validators of epoch #%d; %s", uint64(id), err.Error())
+		}
+	}
+	return list, nil
+}
+
+// StoreEpochValidators collects the snapshot of validators at the end
+// of the given sealed epoch and stores it in the persistent storage.
+func (p *proxy) StoreEpochValidators(ctx context.Context, id hexutil.Uint64) error {
+	list, err := p.rpc.EpochValidators(ctx, id)
+	if err != nil {
+		return err
+	}
+	return p.db.AddEpochValidators(ctx, id, list)
+}
+
 // LastKnownEpoch returns the id of the last known and scanned epoch.
-func (p *proxy) LastKnownEpoch() (uint64, error) {
-	return p.db.LastKnownEpoch()
+func (p *proxy) LastKnownEpoch(ctx context.Context) (uint64, error) {
+	return p.db.LastKnownEpoch(ctx)
+}
+
+// EpochIdAt returns the id of the epoch active at the given time stamp.
+// The current epoch is used if no sealed epoch ended after the time stamp yet.
+func (p *proxy) EpochIdAt(ctx context.Context, ts hexutil.Uint64) (hexutil.Uint64, error) {
+	id, err := p.db.EpochIdAt(ctx, time.Unix(int64(ts), 0))
+	if err != nil {
+		return 0, err
+	}
+	if id == 0 {
+		return p.CurrentEpoch(ctx)
+	}
+	return hexutil.Uint64(id), nil
 }
 
 // AddEpoch stores an epoch reference in connected persistent storage.
-func (p *proxy) AddEpoch(e *types.Epoch) error {
-	return p.db.AddEpoch(e)
+func (p *proxy) AddEpoch(ctx context.Context, e *types.Epoch) error {
+	return p.db.AddEpoch(ctx, e)
 }
 
 // Epochs pulls list of epochs starting at the specified cursor.
-func (p *proxy) Epochs(cursor *string, count int32) (*types.EpochList, error) {
-	return p.db.Epochs(cursor, count)
+func (p *proxy) Epochs(ctx context.Context, cursor *string, count int32) (*types.EpochList, error) {
+	return p.db.Epochs(ctx, cursor, count)
 }
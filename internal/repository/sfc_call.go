@@ -0,0 +1,166 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// sfcValidatorStatusActive represents the SFC status of an active validator.
+const sfcValidatorStatusActive = 0
+
+// sfcRatioUnit represents the unit of the SFC ratios provided with 18 decimals.
+var sfcRatioUnit = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+// SfcDelegateCall prepares an unsigned SFC call delegating the given amount
+// of native tokens to the validator. The amount is validated against the sender balance
+// and the current delegation limit of the validator.
+func (p *proxy) SfcDelegateCall(ctx context.Context, from *common.Address, valID *hexutil.Big, amount *hexutil.Big) (*types.UnsignedTransaction, error) {
+	if amount.ToInt().Sign() <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	// the validator must be active to receive delegations
+	val, err := p.Validator(ctx, valID)
+	if err != nil {
+		return nil, err
+	}
+	if val.Status != sfcValidatorStatusActive {
+		return nil, fmt.Errorf("validator #%d is not active", valID.ToInt().Uint64())
+	}
+
+	// the sender must be able to pay the amount
+	bal, err := p.AccountBalance(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	if bal.ToInt().Cmp(amount.ToInt()) < 0 {
+		return nil, fmt.Errorf("insufficient balance")
+	}
+
+	// the validator can not receive more than its self stake multiplied by the max delegated ratio
+	limit, err := p.validatorDelegationLimit(ctx, val)
+	if err != nil {
+		return nil, err
+	}
+	if new(big.Int).Add(val.TotalStake.ToInt(), amount.ToInt()).Cmp(limit) > 0 {
+		return nil, fmt.Errorf("validator #%d can receive at most %s", valID.ToInt().Uint64(),
+			(*hexutil.Big)(new(big.Int).Sub(limit, val.TotalStake.ToInt())).String())
+	}
+
+	data, err := p.rpc.SfcCallData("delegate", valID.ToInt())
+	if err != nil {
+		return nil, err
+	}
+	return p.sfcUnsignedCall(ctx, from, amount, data)
+}
+
+// SfcClaimRewardsCall prepares an unsigned SFC call claiming pending rewards of the delegation
+// to the validator. The rewards are re-staked into the delegation, if requested.
+func (p *proxy) SfcClaimRewardsCall(ctx context.Context, from *common.Address, valID *hexutil.Big, restake bool) (*types.UnsignedTransaction, error) {
+	pr, err := p.PendingRewards(ctx, from, valID)
+	if err != nil {
+		return nil, err
+	}
+	if pr.Amount.ToInt().Sign() <= 0 {
+		return nil, fmt.Errorf("no rewards to claim")
+	}
+
+	method := "claimRewards"
+	if restake {
+		method = "restakeRewards"
+	}
+
+	data, err := p.rpc.SfcCallData(method, valID.ToInt())
+	if err != nil {
+		return nil, err
+	}
+	return p.sfcUnsignedCall(ctx, from, new(hexutil.Big), data)
+}
+
+// SfcUndelegateCall prepares an unsigned SFC call un-delegating the given amount
+// from the validator under the given withdraw request ID. The amount is validated
+// against the unlocked amount of the delegation.
+func (p *proxy) SfcUndelegateCall(ctx context.Context, from *common.Address, valID *hexutil.Big, wrID *hexutil.Big, amount *hexutil.Big) (*types.UnsignedTransaction, error) {
+	if amount.ToInt().Sign() <= 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	unlocked, err := p.DelegationAmountUnlocked(ctx, from, valID.ToInt())
+	if err != nil {
+		return nil, err
+	}
+	if unlocked.ToInt().Cmp(amount.ToInt()) < 0 {
+		return nil, fmt.Errorf("at most %s can be undelegated", unlocked.String())
+	}
+
+	data, err := p.rpc.SfcCallData("undelegate", valID.ToInt(), wrID.ToInt(), amount.ToInt())
+	if err != nil {
+		return nil, err
+	}
+	return p.sfcUnsignedCall(ctx, from, new(hexutil.Big), data)
+}
+
+// validatorDelegationLimit calculates the max amount of stake the validator can receive.
+func (p *proxy) validatorDelegationLimit(ctx context.Context, val *types.Validator) (*big.Int, error) {
+	self, err := p.DelegationAmountStaked(ctx, &val.StakerAddress, &val.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	sc, err := p.SfcConfiguration(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := new(big.Int).Mul(self, sc.MaxDelegatedRatio.ToInt())
+	return limit.Div(limit, sfcRatioUnit), nil
+}
+
+// sfcUnsignedCall builds the unsigned transaction calling the SFC contract
+// with the given value and call data; the call is verified by the gas estimation.
+func (p *proxy) sfcUnsignedCall(ctx context.Context, from *common.Address, value *hexutil.Big, data hexutil.Bytes) (*types.UnsignedTransaction, error) {
+	to := p.rpc.SfcAddress()
+	cd := data.String()
+
+	gas, err := p.GasEstimate(ctx, &struct {
+		From  *common.Address
+		To    *common.Address
+		Value *hexutil.Big
+		Data  *string
+	}{From: from, To: &to, Value: value, Data: &cd})
+	if err != nil {
+		return nil, fmt.Errorf("transaction would fail; %s", err.Error())
+	}
+
+	nonce, err := p.AccountNonce(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := p.GasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.UnsignedTransaction{
+		From:     *from,
+		To:       to,
+		Nonce:    *nonce,
+		Value:    *value,
+		Gas:      *gas,
+		GasPrice: price,
+		Data:     data,
+	}, nil
+}
@@ -9,21 +9,18 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 package repository
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository/db"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	"go.mongodb.org/mongo-driver/bson"
 	"math/big"
 )
 
 // IsDelegating returns if the given address is an SFC delegator.
-func (p *proxy) IsDelegating(addr *common.Address) (bool, error) {
+func (p *proxy) IsDelegating(ctx context.Context, addr *common.Address) (bool, error) {
 	// count only active delegations (with non-zero value)
-	count, err := p.db.DelegationsCountFiltered(&bson.D{
-		{Key: types.FiDelegationAddress, Value: addr.String()},
-		{Key: types.FiDelegationValue, Value: bson.D{{Key: "$gt", Value: 0}}},
-	})
+	count, err := p.db.DelegationsCountFiltered(ctx, &types.DelegationFilter{Address: addr, ActiveOnly: true})
 	if err != nil {
 		p.log.Errorf("can not check delegation by address; %s", addr.String())
 		return false, err
@@ -32,21 +29,21 @@ func (p *proxy) IsDelegating(addr *common.Address) (bool, error) {
 }
 
 // StoreDelegation stores the delegation in persistent database.
-func (p *proxy) StoreDelegation(dl *types.Delegation) error {
-	return p.db.AddDelegation(dl)
+func (p *proxy) StoreDelegation(ctx context.Context, dl *types.Delegation) error {
+	return p.db.AddDelegation(ctx, dl)
 }
 
 // UpdateDelegationBalance updates active balance of the given delegation.
-func (p *proxy) UpdateDelegationBalance(addr *common.Address, valID *hexutil.Big, unknownDelegation func(*big.Int) error) error {
+func (p *proxy) UpdateDelegationBalance(ctx context.Context, addr *common.Address, valID *hexutil.Big, unknownDelegation func(*big.Int) error) error {
 	// pull the current value
-	val, err := p.DelegationAmountStaked(addr, valID)
+	val, err := p.DelegationAmountStaked(ctx, addr, valID)
 	if err != nil {
 		p.log.Errorf("delegation balance not available for %s to %d; %s", addr.String(), valID.ToInt().Uint64(), err.Error())
 		return err
 	}
 
 	// do the update
-	err = p.updateDelegationBalance(addr, valID, val)
+	err = p.updateDelegationBalance(ctx, addr, valID, val)
 	if err == nil {
 		return nil
 	}
@@ -63,9 +60,9 @@ func (p *proxy) UpdateDelegationBalance(addr *common.Address, valID *hexutil.Big
 }
 
 // updateDelegationBalance performs delegation balance update if needed.
-func (p *proxy) updateDelegationBalance(addr *common.Address, valID *hexutil.Big, amo *big.Int) error {
+func (p *proxy) updateDelegationBalance(ctx context.Context, addr *common.Address, valID *hexutil.Big, amo *big.Int) error {
 	// get the delegation detail
-	dlg, err := p.Delegation(addr, valID)
+	dlg, err := p.Delegation(ctx, addr, valID)
 	if err != nil {
 		return err
 	}
@@ -77,7 +74,7 @@ func (p *proxy) updateDelegationBalance(addr *common.Address, valID *hexutil.Big
 
 	// update the delegation in DB and memory
 	dlg.AmountDelegated = (*hexutil.Big)(amo)
-	err = p.db.UpdateDelegationBalance(addr, valID, dlg.AmountDelegated)
+	err = p.db.UpdateDelegationBalance(ctx, addr, valID, dlg.AmountDelegated)
 	if nil == err {
 		p.cache.PushDelegation(dlg)
 	}
@@ -85,7 +82,7 @@ func (p *proxy) updateDelegationBalance(addr *common.Address, valID *hexutil.Big
 }
 
 // Delegation returns a detail of delegation for the given address.
-func (p *proxy) Delegation(adr *common.Address, valID *hexutil.Big) (*types.Delegation, error) {
+func (p *proxy) Delegation(ctx context.Context, adr *common.Address, valID *hexutil.Big) (*types.Delegation, error) {
 	// log what we do
 	p.log.Debugf("accessing delegation of %s to #%d", adr.String(), valID.ToInt().Uint64())
 
@@ -96,7 +93,7 @@ func (p *proxy) Delegation(adr *common.Address, valID *hexutil.Big) (*types.Dele
 	}
 
 	// pull from DB instead; do we actually have it?
-	dlg, err := p.db.Delegation(adr, valID)
+	dlg, err := p.db.Delegation(ctx, adr, valID)
 	if err != nil {
 		return nil, err
 	}
@@ -107,8 +104,8 @@ func (p *proxy) Delegation(adr *common.Address, valID *hexutil.Big) (*types.Dele
 }
 
 // DelegationAmountStaked returns the current amount of staked tokens for the given delegation.
-func (p *proxy) DelegationAmountStaked(addr *common.Address, valID *hexutil.Big) (*big.Int, error) {
-	val, err := p.rpc.AmountStaked(addr, (*big.Int)(valID))
+func (p *proxy) DelegationAmountStaked(ctx context.Context, addr *common.Address, valID *hexutil.Big) (*big.Int, error) {
+	val, err := p.rpc.AmountStaked(ctx, addr, (*big.Int)(valID))
 	if err != nil {
 		p.log.Errorf("can not get amount delegated by %s to %d; %s", addr.String(), valID.ToInt().Uint64(), err.Error())
 		return nil, err
@@ -119,35 +116,72 @@ func (p *proxy) DelegationAmountStaked(addr *common.Address, valID *hexutil.Big)
 }
 
 // DelegationsByAddress returns a list of all delegations of a given delegator address.
-func (p *proxy) DelegationsByAddress(addr *common.Address, cursor *string, count int32) (*types.DelegationList, error) {
+func (p *proxy) DelegationsByAddress(ctx context.Context, addr *common.Address, cursor *string, count int32) (*types.DelegationList, error) {
 	p.log.Debugf("loading delegations of %s", addr.String())
-	return p.db.Delegations(cursor, count, &bson.D{{Key: types.FiDelegationAddress, Value: addr.String()}})
+	return p.db.Delegations(ctx, cursor, count, &types.DelegationFilter{Address: addr})
 }
 
 // DelegationsByAddressAll returns a list of all delegations of the given address un-paged.
-func (p *proxy) DelegationsByAddressAll(addr *common.Address) ([]*types.Delegation, error) {
+func (p *proxy) DelegationsByAddressAll(ctx context.Context, addr *common.Address) ([]*types.Delegation, error) {
 	p.log.Debugf("loading all delegations of %s", addr.String())
-	return p.db.DelegationsAll(&bson.D{{Key: types.FiDelegationAddress, Value: addr.String()}})
+	return p.db.DelegationsAll(ctx, &types.DelegationFilter{Address: addr})
 }
 
 // DelegationsOfValidator extract a list of delegations for a given validator.
-func (p *proxy) DelegationsOfValidator(valID *hexutil.Big, cursor *string, count int32) (*types.DelegationList, error) {
+func (p *proxy) DelegationsOfValidator(ctx context.Context, valID *hexutil.Big, cursor *string, count int32) (*types.DelegationList, error) {
 	p.log.Debugf("loading delegations of #%d", valID.ToInt().Uint64())
-	return p.db.Delegations(cursor, count, &bson.D{{Key: types.FiDelegationToValidator, Value: valID.String()}})
+	return p.db.Delegations(ctx, cursor, count, &types.DelegationFilter{ToValidator: valID})
+}
+
+// StoreDelegationLockEvent stores a delegation lock event in the persistent storage.
+func (p *proxy) StoreDelegationLockEvent(ctx context.Context, dle *types.DelegationLockEvent) error {
+	return p.db.AddDelegationLockEvent(ctx, dle)
+}
+
+// DelegationLockHistory provides the given number of the most recent lock events of the delegation.
+func (p *proxy) DelegationLockHistory(ctx context.Context, addr *common.Address, valID *hexutil.Big, count int32) ([]*types.DelegationLockEvent, error) {
+	return p.db.DelegationLockEvents(ctx, addr, valID, count)
+}
+
+// DelegationLockRewardRatio calculates the share of full rewards paid to stake locked
+// for the given duration in seconds; the ratio is provided with 18 decimals.
+// Unlocked stake receives the base share, the rest is added in proportion
+// to the lock duration relative to the longest possible lock.
+func (p *proxy) DelegationLockRewardRatio(ctx context.Context, duration hexutil.Uint64) (*big.Int, error) {
+	base, err := p.rpc.SfcUnlockedRewardRatio(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if duration == 0 {
+		return base, nil
+	}
+
+	sc, err := p.SfcConfiguration(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if sc.MaxLockupDuration.ToInt().Sign() == 0 {
+		return base, nil
+	}
+
+	extra := new(big.Int).Sub(sfcRatioUnit, base)
+	extra.Mul(extra, new(big.Int).SetUint64(uint64(duration)))
+	extra.Div(extra, sc.MaxLockupDuration.ToInt())
+	return extra.Add(extra, base), nil
 }
 
 // DelegationLock returns delegation lock information using SFC contract binding.
-func (p *proxy) DelegationLock(addr *common.Address, valID *hexutil.Big) (*types.DelegationLock, error) {
+func (p *proxy) DelegationLock(ctx context.Context, addr *common.Address, valID *hexutil.Big) (*types.DelegationLock, error) {
 	p.log.Debugf("loading lock information for %s to #%d", addr.String(), valID.ToInt().Uint64())
-	return p.rpc.DelegationLock(addr, valID)
+	return p.rpc.DelegationLock(ctx, addr, valID)
 }
 
 // DelegationAmountUnlocked returns delegation lock information using SFC contract binding.
-func (p *proxy) DelegationAmountUnlocked(addr *common.Address, valID *big.Int) (hexutil.Big, error) {
+func (p *proxy) DelegationAmountUnlocked(ctx context.Context, addr *common.Address, valID *big.Int) (hexutil.Big, error) {
 	p.log.Debugf("loading unlocked amount for %s to #%d", addr.String(), valID.Uint64())
 
 	// get the amount
-	val, err := p.rpc.AmountStakeUnlocked(addr, valID)
+	val, err := p.rpc.AmountStakeUnlocked(ctx, addr, valID)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -155,11 +189,11 @@ func (p *proxy) DelegationAmountUnlocked(addr *common.Address, valID *big.Int) (
 }
 
 // DelegationUnlockPenalty returns the amount of penalty applied on given stake unlock.
-func (p *proxy) DelegationUnlockPenalty(addr *common.Address, valID *big.Int, amount *big.Int) (hexutil.Big, error) {
+func (p *proxy) DelegationUnlockPenalty(ctx context.Context, addr *common.Address, valID *big.Int, amount *big.Int) (hexutil.Big, error) {
 	p.log.Debugf("checking unlock of %d penalty for %s to #%d", amount.Uint64(), addr.String(), valID.Uint64())
 
 	// get the amount
-	val, err := p.rpc.StakeUnlockPenalty(addr, valID, amount)
+	val, err := p.rpc.StakeUnlockPenalty(ctx, addr, valID, amount)
 	if err != nil {
 		return hexutil.Big{}, err
 	}
@@ -167,15 +201,15 @@ func (p *proxy) DelegationUnlockPenalty(addr *common.Address, valID *big.Int, am
 }
 
 // PendingRewards returns a detail of pending rewards for the given delegation address and validator ID.
-func (p *proxy) PendingRewards(addr *common.Address, valID *hexutil.Big) (*types.PendingRewards, error) {
+func (p *proxy) PendingRewards(ctx context.Context, addr *common.Address, valID *hexutil.Big) (*types.PendingRewards, error) {
 	p.log.Debugf("loading pending rewards of %s to #%d", addr.String(), valID.ToInt().Uint64())
-	return p.rpc.PendingRewards(addr, valID.ToInt())
+	return p.rpc.PendingRewards(ctx, addr, valID.ToInt())
 }
 
 // DelegationOutstandingSFTM returns the amount of sFTM tokens for the delegation
 // identified by the delegator address and the stakerId.
-func (p *proxy) DelegationOutstandingSFTM(addr *common.Address, toStaker *hexutil.Big) (*hexutil.Big, error) {
-	val, err := p.rpc.DelegationOutstandingSFTM(addr, toStaker.ToInt())
+func (p *proxy) DelegationOutstandingSFTM(ctx context.Context, addr *common.Address, toStaker *hexutil.Big) (*hexutil.Big, error) {
+	val, err := p.rpc.DelegationOutstandingSFTM(ctx, addr, toStaker.ToInt())
 	if err != nil {
 		return nil, err
 	}
@@ -184,11 +218,11 @@ func (p *proxy) DelegationOutstandingSFTM(addr *common.Address, toStaker *hexuti
 
 // DelegationTokenizerUnlocked returns the status of SFC Tokenizer lock
 // for a delegation identified by the address and staker id.
-func (p *proxy) DelegationTokenizerUnlocked(addr *common.Address, toStaker *hexutil.Big) (bool, error) {
-	return p.rpc.DelegationTokenizerUnlocked(addr, toStaker.ToInt())
+func (p *proxy) DelegationTokenizerUnlocked(ctx context.Context, addr *common.Address, toStaker *hexutil.Big) (bool, error) {
+	return p.rpc.DelegationTokenizerUnlocked(ctx, addr, toStaker.ToInt())
 }
 
 // DelegationFluidStakingActive signals if the delegation is upgraded to Fluid Staking model.
-func (p *proxy) DelegationFluidStakingActive(_ *common.Address, _ *hexutil.Big) (bool, error) {
+func (p *proxy) DelegationFluidStakingActive(ctx context.Context, _ *common.Address, _ *hexutil.Big) (bool, error) {
 	return true, nil
 }
@@ -9,77 +9,42 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 package repository
 
 import (
+	"context"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	"go.mongodb.org/mongo-driver/bson"
 	"math/big"
 	"time"
 )
 
 // StoreRewardClaim stores reward claim record in the persistent repository.
-func (p *proxy) StoreRewardClaim(rc *types.RewardClaim) error {
-	return p.db.AddRewardClaim(rc)
+func (p *proxy) StoreRewardClaim(ctx context.Context, rc *types.RewardClaim) error {
+	return p.db.AddRewardClaim(ctx, rc)
 }
 
 // RewardClaims provides a list of reward claims for the given delegation and/or filter.
-func (p *proxy) RewardClaims(adr *common.Address, valID *big.Int, cursor *string, count int32) (*types.RewardClaimsList, error) {
-	// prep the filter
-	fi := bson.D{}
-
-	// add delegator address to the filter
-	if adr != nil {
-		fi = append(fi, bson.E{
-			Key:   types.FiRewardClaimAddress,
-			Value: adr.String(),
-		})
-	}
-
-	// add validator ID to the filter
-	if valID != nil {
-		fi = append(fi, bson.E{
-			Key:   types.FiRewardClaimToValidator,
-			Value: (*hexutil.Big)(valID).String(),
-		})
-	}
-	return p.db.RewardClaims(cursor, count, &fi)
+func (p *proxy) RewardClaims(ctx context.Context, adr *common.Address, valID *big.Int, cursor *string, count int32) (*types.RewardClaimsList, error) {
+	return p.db.RewardClaims(ctx, cursor, count, &types.RewardClaimFilter{
+		Address:     adr,
+		ToValidator: (*hexutil.Big)(valID),
+	})
 }
 
 // RewardsClaimed returns sum of all claimed rewards for the given delegator address and validator ID.
-func (p *proxy) RewardsClaimed(adr *common.Address, valId *big.Int, since *int64, until *int64) (*big.Int, error) {
-	// prep the filter
-	fi := bson.D{}
-
-	// filter by delegator address
-	if adr != nil {
-		fi = append(fi, bson.E{
-			Key:   types.FiRewardClaimAddress,
-			Value: adr.String(),
-		})
-	}
-
-	// filter by validator ID
-	if valId != nil {
-		fi = append(fi, bson.E{
-			Key:   types.FiRewardClaimToValidator,
-			Value: (*hexutil.Big)(valId).String(),
-		})
+func (p *proxy) RewardsClaimed(ctx context.Context, adr *common.Address, valId *big.Int, since *int64, until *int64) (*big.Int, error) {
+	fi := types.RewardClaimFilter{
+		Address:     adr,
+		ToValidator: (*hexutil.Big)(valId),
 	}
 
-	// starting time stamp provided
+	// time range of the claims
 	if since != nil {
-		fi = append(fi, bson.E{
-			Key:   types.FiRewardClaimedTimeStamp,
-			Value: bson.D{{Key: "$gte", Value: time.Unix(*since, 0)}},
-		})
+		ts := time.Unix(*since, 0)
+		fi.Since = &ts
 	}
-
-	// ending time stamp provided
 	if until != nil {
-		fi = append(fi, bson.E{
-			Key:   types.FiRewardClaimedTimeStamp,
-			Value: bson.D{{Key: "$lte", Value: time.Unix(*until, 0)}},
-		})
+		ts := time.Unix(*until, 0)
+		fi.Until = &ts
 	}
-	return p.db.RewardsSumValue(&fi)
+	return p.db.RewardsSumValue(ctx, &fi)
 }
@@ -9,29 +9,31 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 package repository
 
 import (
+	"context"
 	"fantom-api-graphql/internal/types"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
 )
 
 // LastValidatorId returns the last staker id in Opera blockchain.
-func (p *proxy) LastValidatorId() (uint64, error) {
-	return p.rpc.LastValidatorId()
+func (p *proxy) LastValidatorId(ctx context.Context) (uint64, error) {
+	return p.rpc.LastValidatorId(ctx)
 }
 
 // ValidatorsCount returns the number of stakers in Opera blockchain.
-func (p *proxy) ValidatorsCount() (uint64, error) {
-	return p.rpc.ValidatorsCount()
+func (p *proxy) ValidatorsCount(ctx context.Context) (uint64, error) {
+	return p.rpc.ValidatorsCount(ctx)
 }
 
 // IsValidator returns if the given address is an SFC staker.
-func (p *proxy) IsValidator(addr *common.Address) (bool, error) {
-	return p.rpc.IsValidator(addr)
+func (p *proxy) IsValidator(ctx context.Context, addr *common.Address) (bool, error) {
+	return p.rpc.IsValidator(ctx, addr)
 }
 
 // ValidatorAddress extract a staker address for the given staker ID.
-func (p *proxy) ValidatorAddress(id *hexutil.Big) (*common.Address, error) {
+func (p *proxy) ValidatorAddress(ctx context.Context, id *hexutil.Big) (*common.Address, error) {
 	// try to use cache
 	adr := p.cache.PullValidatorAddress(id)
 	if nil != adr {
@@ -39,7 +41,7 @@ func (p *proxy) ValidatorAddress(id *hexutil.Big) (*common.Address, error) {
 	}
 
 	// pull from SFC
-	adr, err := p.rpc.ValidatorAddress((*big.Int)(id))
+	adr, err := p.rpc.ValidatorAddress(ctx, (*big.Int)(id))
 	if err != nil {
 		return nil, err
 	}
@@ -50,17 +52,35 @@ func (p *proxy) ValidatorAddress(id *hexutil.Big) (*common.Address, error) {
 }
 
 // Validator extract a staker information from SFC smart contract.
-func (p *proxy) Validator(id *hexutil.Big) (*types.Validator, error) {
-	return p.rpc.Validator((*big.Int)(id))
+// Parallel requests for the same validator are merged into a single SFC call.
+func (p *proxy) Validator(ctx context.Context, id *hexutil.Big) (*types.Validator, error) {
+	val, err, _ := p.apiRequestGroup.Do(fmt.Sprintf("validator_%s", id.String()), func() (interface{}, error) {
+		// the result is shared with all the callers waiting for it,
+		// so the call must not be cancelled by any single one of them
+		return p.rpc.Validator(context.Background(), (*big.Int)(id))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return val.(*types.Validator), nil
 }
 
 // ValidatorByAddress extract a staker information by address.
-func (p *proxy) ValidatorByAddress(addr *common.Address) (*types.Validator, error) {
-	return p.rpc.ValidatorByAddress(addr)
+// Parallel requests for the same validator are merged into a single SFC call.
+func (p *proxy) ValidatorByAddress(ctx context.Context, addr *common.Address) (*types.Validator, error) {
+	val, err, _ := p.apiRequestGroup.Do(fmt.Sprintf("validator_adr_%s", addr.String()), func() (interface{}, error) {
+		// the result is shared with all the callers waiting for it,
+		// so the call must not be cancelled by any single one of them
+		return p.rpc.ValidatorByAddress(context.Background(), addr)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return val.(*types.Validator), nil
 }
 
 // SfcMaxDelegatedRatio extracts a ratio between self delegation and received stake.
-func (p *proxy) SfcMaxDelegatedRatio() (*big.Int, error) {
+func (p *proxy) SfcMaxDelegatedRatio(ctx context.Context) (*big.Int, error) {
 	// try cache first
 	val := p.cache.PullSfcMaxDelegatedRatio()
 	if val != nil {
@@ -68,7 +88,7 @@ func (p *proxy) SfcMaxDelegatedRatio() (*big.Int, error) {
 	}
 
 	// pull from the SFC contract
-	val, err := p.rpc.SfcMaxDelegatedRatio()
+	val, err := p.rpc.SfcMaxDelegatedRatio(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -79,6 +99,75 @@ func (p *proxy) SfcMaxDelegatedRatio() (*big.Int, error) {
 }
 
 // ValidatorDowntime pulls information about validator downtime from the RPC interface.
-func (p *proxy) ValidatorDowntime(valID *hexutil.Big) (uint64, uint64, error) {
-	return p.rpc.ValidatorDowntime(valID)
+func (p *proxy) ValidatorDowntime(ctx context.Context, valID *hexutil.Big) (uint64, uint64, error) {
+	return p.rpc.ValidatorDowntime(ctx, valID)
+}
+
+// StoreEpochValidatorsPerformance collects the performance of validators
+// within the given sealed epoch and stores it in the persistent storage.
+func (p *proxy) StoreEpochValidatorsPerformance(ctx context.Context, id hexutil.Uint64) error {
+	list, err := p.rpc.EpochValidatorsPerformance(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	for _, vep := range list {
+		if err := p.db.AddValidatorEpochPerformance(ctx, vep); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidatorPerformance provides the performance of the given validator
+// aggregated over the given number of the most recent sealed epochs.
+func (p *proxy) ValidatorPerformance(ctx context.Context, valID *hexutil.Big, epochs int32) (*types.ValidatorPerformance, error) {
+	from, err := p.validatorPerformanceFrom(ctx, epochs)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := p.db.ValidatorPerformance(ctx, valID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	// no performance recorded for the validator in the range
+	if len(list) == 0 {
+		return &types.ValidatorPerformance{
+			ValidatorId:   *valID,
+			FromEpoch:     hexutil.Uint64(from),
+			OriginatedFee: new(big.Int),
+			EpochFee:      new(big.Int),
+		}, nil
+	}
+	return list[0], nil
+}
+
+// ValidatorPerformanceList provides the performance of all the validators
+// aggregated over the given number of the most recent sealed epochs.
+func (p *proxy) ValidatorPerformanceList(ctx context.Context, epochs int32) ([]*types.ValidatorPerformance, error) {
+	from, err := p.validatorPerformanceFrom(ctx, epochs)
+	if err != nil {
+		return nil, err
+	}
+	return p.db.ValidatorPerformance(ctx, nil, from)
+}
+
+// validatorPerformanceFrom calculates the first epoch of the performance range
+// covering the given number of the most recent sealed epochs.
+func (p *proxy) validatorPerformanceFrom(ctx context.Context, epochs int32) (uint64, error) {
+	if epochs <= 0 {
+		return 0, fmt.Errorf("invalid number of epochs %d", epochs)
+	}
+
+	top, err := p.rpc.CurrentSealedEpoch(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if uint64(top) < uint64(epochs) {
+		return 0, nil
+	}
+	return uint64(top) - uint64(epochs) + 1, nil
 }
@@ -9,31 +9,31 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 package repository
 
 import (
+	"context"
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	"go.mongodb.org/mongo-driver/bson"
 	"math/big"
 )
 
 // StoreWithdrawRequest stores the given withdraw request in persistent storage.
-func (p *proxy) StoreWithdrawRequest(wr *types.WithdrawRequest) error {
-	return p.db.AddWithdrawal(wr)
+func (p *proxy) StoreWithdrawRequest(ctx context.Context, wr *types.WithdrawRequest) error {
+	return p.db.AddWithdrawal(ctx, wr)
 }
 
 // UpdateWithdrawRequest stores the given updated withdraw request in persistent storage.
-func (p *proxy) UpdateWithdrawRequest(wr *types.WithdrawRequest) error {
-	return p.db.UpdateWithdrawal(wr)
+func (p *proxy) UpdateWithdrawRequest(ctx context.Context, wr *types.WithdrawRequest) error {
+	return p.db.UpdateWithdrawal(ctx, wr)
 }
 
 // WithdrawRequest extracts details of a withdraw request specified by the delegator, validator and request ID.
-func (p *proxy) WithdrawRequest(addr *common.Address, valID *hexutil.Big, reqID *hexutil.Big) (*types.WithdrawRequest, error) {
-	return p.db.Withdrawal(addr, valID, reqID)
+func (p *proxy) WithdrawRequest(ctx context.Context, addr *common.Address, valID *hexutil.Big, reqID *hexutil.Big) (*types.WithdrawRequest, error) {
+	return p.db.Withdrawal(ctx, addr, valID, reqID)
 }
 
 // WithdrawRequests extracts a list of partial withdraw requests for the given address.
-func (p *proxy) WithdrawRequests(addr *common.Address, stakerID *hexutil.Big, cursor *string, count int32) (*types.WithdrawRequestList, error) {
+func (p *proxy) WithdrawRequests(ctx context.Context, addr *common.Address, stakerID *hexutil.Big, cursor *string, count int32) (*types.WithdrawRequestList, error) {
 	if addr == nil {
 		return nil, fmt.Errorf("address not given")
 	}
@@ -42,36 +42,26 @@ func (p *proxy) WithdrawRequests(addr *common.Address, stakerID *hexutil.Big, cu
 	if stakerID == nil {
 		// log the action and pull the list for all vals
 		p.log.Debugf("loading withdraw requests of %s to any validator", addr.String())
-		return p.db.Withdrawals(cursor, count, &bson.D{{Key: types.FiWithdrawalAddress, Value: addr.String()}})
+		return p.db.Withdrawals(ctx, cursor, count, &types.WithdrawalFilter{Address: addr})
 	}
 
 	// log the action and pull the list for specific address and val
 	p.log.Debugf("loading withdraw requests of %s to #%d", addr.String(), stakerID.ToInt().Uint64())
-	return p.db.Withdrawals(cursor, count, &bson.D{
-		{Key: types.FiWithdrawalAddress, Value: addr.String()},
-		{Key: types.FiWithdrawalToValidator, Value: stakerID.String()},
-	})
+	return p.db.Withdrawals(ctx, cursor, count, &types.WithdrawalFilter{Address: addr, ToValidator: stakerID})
 }
 
 // WithdrawRequestsPendingTotal is the total value of all pending withdrawal requests
 // for the given delegator and target staker ID.
-func (p *proxy) WithdrawRequestsPendingTotal(addr *common.Address, stakerID *hexutil.Big) (*big.Int, error) {
+func (p *proxy) WithdrawRequestsPendingTotal(ctx context.Context, addr *common.Address, stakerID *hexutil.Big) (*big.Int, error) {
 	if addr == nil {
 		return nil, fmt.Errorf("address not given")
 	}
 
 	// all withdrawals for the address regardless of the target staker
 	if stakerID == nil {
-		return p.db.WithdrawalsSumValue(&bson.D{
-			{Key: types.FiWithdrawalAddress, Value: addr.String()},
-			{Key: types.FiWithdrawalFinTrx, Value: bson.D{{Key: "$type", Value: 10}}},
-		})
+		return p.db.WithdrawalsSumValue(ctx, &types.WithdrawalFilter{Address: addr, PendingOnly: true})
 	}
 
 	// specific delegation withdrawal
-	return p.db.WithdrawalsSumValue(&bson.D{
-		{Key: types.FiWithdrawalAddress, Value: addr.String()},
-		{Key: types.FiWithdrawalToValidator, Value: stakerID.String()},
-		{Key: types.FiWithdrawalFinTrx, Value: bson.D{{Key: "$type", Value: 10}}},
-	})
+	return p.db.WithdrawalsSumValue(ctx, &types.WithdrawalFilter{Address: addr, ToValidator: stakerID, PendingOnly: true})
 }
@@ -0,0 +1,289 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// eip712DomainType represents the name of the EIP-712 domain separator type.
+const eip712DomainType = "EIP712Domain"
+
+// typedDataField represents a member of an EIP-712 struct type.
+type typedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// typedData represents the EIP-712 typed structured data signed by a wallet.
+type typedData struct {
+	Types       map[string][]typedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      map[string]interface{}      `json:"domain"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+// VerifySignature checks if the given signature of the message has been made by the given address.
+// The message is either a text signed by EIP-191 personal_sign, or a JSON of the EIP-712 typed data.
+// Signatures of contract wallets are verified by the ERC1271 interface of the wallet.
+func (p *proxy) VerifySignature(ctx context.Context, addr *common.Address, msg string, sig []byte, typed bool) (bool, error) {
+	if len(sig) != crypto.SignatureLength {
+		return false, types.Errorf(types.ErrCodeInvalidInput, "signature must be %d bytes long", crypto.SignatureLength)
+	}
+
+	var hash common.Hash
+	if typed {
+		var err error
+		if hash, err = typedDataHash(msg); err != nil {
+			return false, types.Errorf(types.ErrCodeInvalidInput, "invalid typed data; %s", err.Error())
+		}
+	} else {
+		hash = common.BytesToHash(accounts.TextHash([]byte(msg)))
+	}
+
+	// contract wallets validate signatures on their own
+	code, err := p.rpc.AccountCode(ctx, addr)
+	if err != nil {
+		return false, err
+	}
+	if len(code) > 0 {
+		valid, err := p.rpc.Erc1271IsValidSignature(ctx, addr, hash, sig)
+		if err != nil {
+			p.log.Debugf("contract %s does not validate signatures; %s", addr.String(), err.Error())
+			return false, nil
+		}
+		return valid, nil
+	}
+
+	// wallets use 27/28 as the recovery id, the recovery expects 0/1
+	rs := make([]byte, crypto.SignatureLength)
+	copy(rs, sig)
+	if rs[crypto.RecoveryIDOffset] >= 27 {
+		rs[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pub, err := crypto.SigToPub(hash.Bytes(), rs)
+	if err != nil {
+		return false, nil
+	}
+	return crypto.PubkeyToAddress(*pub) == *addr, nil
+}
+
+// typedDataHash calculates the EIP-712 hash of the typed data in the given JSON.
+func typedDataHash(src string) (common.Hash, error) {
+	var td typedData
+	dec := json.NewDecoder(strings.NewReader(src))
+	dec.UseNumber()
+	if err := dec.Decode(&td); err != nil {
+		return common.Hash{}, err
+	}
+	if _, ok := td.Types[eip712DomainType]; !ok {
+		return common.Hash{}, fmt.Errorf("%s type not defined", eip712DomainType)
+	}
+
+	domain, err := td.hashStruct(eip712DomainType, td.Domain)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("domain; %s", err.Error())
+	}
+
+	// the domain itself may be signed, there is no message then
+	raw := []byte{0x19, 0x01}
+	raw = append(raw, domain...)
+	if td.PrimaryType != eip712DomainType {
+		msg, err := td.hashStruct(td.PrimaryType, td.Message)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("message; %s", err.Error())
+		}
+		raw = append(raw, msg...)
+	}
+	return crypto.Keccak256Hash(raw), nil
+}
+
+// hashStruct calculates the hash of the given value of a struct type.
+func (td *typedData) hashStruct(name string, data map[string]interface{}) ([]byte, error) {
+	fields, ok := td.Types[name]
+	if !ok {
+		return nil, fmt.Errorf("type %s not defined", name)
+	}
+
+	buf := bytes.NewBuffer(crypto.Keccak256([]byte(td.encodeType(name))))
+	for _, f := range fields {
+		val, ok := data[f.Name]
+		if !ok {
+			return nil, fmt.Errorf("%s.%s missing", name, f.Name)
+		}
+
+		enc, err := td.encodeValue(f.Type, val)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s; %s", name, f.Name, err.Error())
+		}
+		buf.Write(enc)
+	}
+	return crypto.Keccak256(buf.Bytes()), nil
+}
+
+// encodeType provides the EIP-712 encoding of the struct type with the referenced
+// struct types appended in alphabetical order.
+func (td *typedData) encodeType(name string) string {
+	deps := td.dependencies(name, map[string]bool{})
+	delete(deps, name)
+
+	list := make([]string, 0, len(deps))
+	for dep := range deps {
+		list = append(list, dep)
+	}
+	sort.Strings(list)
+
+	var sb strings.Builder
+	for _, t := range append([]string{name}, list...) {
+		members := make([]string, len(td.Types[t]))
+		for i, f := range td.Types[t] {
+			members[i] = f.Type + " " + f.Name
+		}
+		sb.WriteString(t + "(" + strings.Join(members, ",") + ")")
+	}
+	return sb.String()
+}
+
+// dependencies collects the struct types referenced by the given struct type.
+func (td *typedData) dependencies(name string, found map[string]bool) map[string]bool {
+	if _, ok := td.Types[name]; !ok || found[name] {
+		return found
+	}
+
+	found[name] = true
+	for _, f := range td.Types[name] {
+		td.dependencies(strings.Split(f.Type, "[")[0], found)
+	}
+	return found
+}
+
+// encodeValue provides the 32 bytes EIP-712 encoding of the given value of the given type.
+func (td *typedData) encodeValue(t string, val interface{}) ([]byte, error) {
+	// arrays are encoded as the hash of the concatenated encoding of their items
+	if strings.HasSuffix(t, "]") {
+		items, ok := val.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("array expected")
+		}
+
+		item := t[:strings.LastIndex(t, "[")]
+		if size := t[len(item)+1 : len(t)-1]; size != "" {
+			if n, err := strconv.Atoi(size); err != nil || n != len(items) {
+				return nil, fmt.Errorf("array of %s items expected", size)
+			}
+		}
+
+		buf := new(bytes.Buffer)
+		for _, v := range items {
+			enc, err := td.encodeValue(item, v)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(enc)
+		}
+		return crypto.Keccak256(buf.Bytes()), nil
+	}
+
+	// nested struct
+	if _, ok := td.Types[t]; ok {
+		data, ok := val.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("object expected")
+		}
+		return td.hashStruct(t, data)
+	}
+
+	switch {
+	case t == "string":
+		s, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("string expected")
+		}
+		return crypto.Keccak256([]byte(s)), nil
+	case t == "bool":
+		b, ok := val.(bool)
+		if !ok {
+			return nil, fmt.Errorf("boolean expected")
+		}
+		if b {
+			return math.U256Bytes(big.NewInt(1)), nil
+		}
+		return math.U256Bytes(big.NewInt(0)), nil
+	case t == "address":
+		s, ok := val.(string)
+		if !ok || !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("address expected")
+		}
+		return common.LeftPadBytes(common.HexToAddress(s).Bytes(), 32), nil
+	case strings.HasPrefix(t, "bytes"):
+		s, ok := val.(string)
+		if !ok {
+			return nil, fmt.Errorf("hex encoded bytes expected")
+		}
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return nil, err
+		}
+		if t == "bytes" {
+			return crypto.Keccak256(b), nil
+		}
+		size, err := strconv.Atoi(t[len("bytes"):])
+		if err != nil || size < 1 || size > 32 || len(b) > size {
+			return nil, fmt.Errorf("invalid %s value", t)
+		}
+		return common.RightPadBytes(b, 32), nil
+	case strings.HasPrefix(t, "uint") || strings.HasPrefix(t, "int"):
+		num, err := typedDataNumber(val)
+		if err != nil {
+			return nil, err
+		}
+		if num.Sign() < 0 && strings.HasPrefix(t, "uint") {
+			return nil, fmt.Errorf("unsigned number expected")
+		}
+		if num.BitLen() > 256 {
+			return nil, fmt.Errorf("number too large")
+		}
+		return math.U256Bytes(num), nil
+	}
+	return nil, fmt.Errorf("type %s not supported", t)
+}
+
+// typedDataNumber decodes a number of the typed data provided as a JSON number,
+// or as a decimal or hex encoded string.
+func typedDataNumber(val interface{}) (*big.Int, error) {
+	var s string
+	switch v := val.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = v
+	default:
+		return nil, fmt.Errorf("number expected")
+	}
+
+	num, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid number %s", s)
+	}
+	return num, nil
+}
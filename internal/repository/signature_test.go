@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"github.com/onsi/gomega"
+	"strings"
+	"testing"
+)
+
+// typedDataMail represents the reference typed data of the EIP-712 specification.
+const typedDataMail = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+// typedDataList represents typed data with a fixed size array member.
+const typedDataList = `{
+	"types": {
+		"EIP712Domain": [{"name": "name", "type": "string"}],
+		"List": [{"name": "items", "type": "uint8[3]"}]
+	},
+	"primaryType": "List",
+	"domain": {"name": "Lists"},
+	"message": {"items": %s}
+}`
+
+func TestTypedDataHash(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		hash string // empty for invalid data
+	}{
+		{name: "EIP-712 reference mail", data: typedDataMail, hash: "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"},
+		{name: "fixed size array", data: strings.Replace(typedDataList, "%s", `[1, 2, 3]`, 1), hash: "0x52e0e33646ad75c2b8ad5788319f869f079fa2af6b6dac70979908b02dadf0f6"},
+		{name: "fixed size array too short", data: strings.Replace(typedDataList, "%s", `[1, 2]`, 1)},
+		{name: "fixed size array too long", data: strings.Replace(typedDataList, "%s", `[1, 2, 3, 4]`, 1)},
+		{name: "array expected", data: strings.Replace(typedDataList, "%s", `1`, 1)},
+		{name: "negative unsigned", data: strings.Replace(typedDataList, "%s", `[1, -2, 3]`, 1)},
+		{name: "missing member", data: strings.Replace(typedDataMail, `"contents": "Hello, Bob!"`, `"title": "Hello, Bob!"`, 1)},
+		{name: "invalid address", data: strings.Replace(typedDataMail, "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB", "Bob", 1)},
+		{name: "undefined primary type", data: strings.Replace(typedDataMail, `"primaryType": "Mail"`, `"primaryType": "Letter"`, 1)},
+		{name: "undefined domain type", data: strings.Replace(typedDataMail, `"EIP712Domain": [`, `"Domain": [`, 1)},
+		{name: "invalid JSON", data: "{"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			hash, err := typedDataHash(tt.data)
+			if tt.hash == "" {
+				g.Expect(err).NotTo(gomega.BeNil())
+				return
+			}
+
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(hash.Hex()).To(gomega.Equal(tt.hash))
+		})
+	}
+}
+
+func TestTypedDataEncodeType(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	td := typedData{Types: map[string][]typedDataField{
+		"Mail":   {{Name: "from", Type: "Person"}, {Name: "to", Type: "Person[]"}, {Name: "contents", Type: "string"}},
+		"Person": {{Name: "name", Type: "string"}, {Name: "wallet", Type: "address"}},
+	}}
+	g.Expect(td.encodeType("Mail")).To(gomega.Equal("Mail(Person from,Person[] to,string contents)Person(string name,address wallet)"))
+}
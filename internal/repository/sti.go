@@ -10,18 +10,19 @@ package repository
 
 import (
 	"bytes"
+	"context"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // PullStakerInfo extracts an extended staker information from smart contact.
-func (p *proxy) PullStakerInfo(id *hexutil.Big) (*types.StakerInfo, error) {
-	return p.rpc.StakerInfo(id)
+func (p *proxy) PullStakerInfo(ctx context.Context, id *hexutil.Big) (*types.StakerInfo, error) {
+	return p.rpc.StakerInfo(ctx, id)
 }
 
 // StoreStakerInfo stores staker information to in-memory cache for future use.
-func (p *proxy) StoreStakerInfo(id *hexutil.Big, sti *types.StakerInfo) error {
+func (p *proxy) StoreStakerInfo(ctx context.Context, id *hexutil.Big, sti *types.StakerInfo) error {
 	// push to in-memory cache
 	err := p.cache.PushStakerInfo(id, sti)
 	if err != nil {
@@ -32,11 +33,11 @@ func (p *proxy) StoreStakerInfo(id *hexutil.Big, sti *types.StakerInfo) error {
 }
 
 // RetrieveStakerInfo gets staker information from in-memory if available.
-func (p *proxy) RetrieveStakerInfo(id *hexutil.Big) *types.StakerInfo {
+func (p *proxy) RetrieveStakerInfo(ctx context.Context, id *hexutil.Big) *types.StakerInfo {
 	return p.cache.PullStakerInfo(id)
 }
 
 // IsStiContract returns true if the given address points to the STI contract.
-func (p *proxy) IsStiContract(addr *common.Address) bool {
+func (p *proxy) IsStiContract(ctx context.Context, addr *common.Address) bool {
 	return bytes.Equal(addr.Bytes(), p.cfg.Staking.StiContract.Bytes())
 }
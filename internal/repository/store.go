@@ -0,0 +1,433 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"time"
+)
+
+// persistentStore represents the off-chain persistent storage of the indexed blockchain data
+// used by the repository. The interface does not expose any database specific types,
+// so the storage can be implemented on top of other databases. The MongoDB bridge
+// is the only implementation available. All the calls take the context of the caller.
+type persistentStore interface {
+	// Account tries to load an account identified by the address given from
+	// the off-chain database.
+	Account(ctx context.Context, addr *common.Address) (*types.Account, error)
+
+	// Accounts loads the accounts identified by the given addresses from the off-chain database
+	// in a single query. Accounts not found in the database are not included in the result.
+	Accounts(ctx context.Context, adr []*common.Address) (map[common.Address]*types.Account, error)
+
+	// AccountCount calculates total number of accounts in the database.
+	AccountCount(context.Context) (uint64, error)
+
+	// AccountMarkActivity marks the latest account activity in the repository.
+	AccountMarkActivity(ctx context.Context, addr *common.Address, ts uint64) error
+
+	// AccountFirstAppearance derives the block number and the time stamp
+	// of the first appearance of the account from the indexed transactions.
+	AccountFirstAppearance(ctx context.Context, addr *common.Address) (uint64, uint64, error)
+
+	// AccountGasStats aggregates the gas used and the fees paid by the transactions
+	// sent from the account after the given time.
+	AccountGasStats(ctx context.Context, addr *common.Address, from time.Time) (*types.AccountGasStats, error)
+
+	// AccountTransactions loads list of transaction hashes of an account.
+	AccountTransactions(ctx context.Context, addr *common.Address, rec *common.Address, cursor *string, count int32, filter *types.TransactionFilter) (*types.TransactionList, error)
+
+	// AccountTransactionsExport passes all the transactions of the given account made in the given
+	// time range to the given callback in the chronological order.
+	AccountTransactionsExport(ctx context.Context, addr *common.Address, from time.Time, to time.Time, fn func(*types.Transaction) error) error
+
+	// AccountUpdateBalance sets the known balance of the account read at the given block.
+	AccountUpdateBalance(ctx context.Context, addr *common.Address, bal *hexutil.Big, blk uint64) error
+
+	// AccountsAddBalance adds the balance changes of the given block to the known balances of the accounts.
+	AccountsAddBalance(ctx context.Context, blk uint64, diff map[common.Address]*big.Int) error
+
+	// AccountsWithoutBalance filters the given accounts to those stored without known balance.
+	AccountsWithoutBalance(ctx context.Context, adr []*common.Address) ([]*common.Address, error)
+
+	// AccountsByBalance loads a list of accounts sorted by their balance
+	// from the highest to the lowest, skipping the given number of accounts.
+	AccountsByBalance(ctx context.Context, skip uint64, count int32) ([]*types.AccountBalance, error)
+
+	// AddAccount stores an account in the blockchain if not exists.
+	AddAccount(ctx context.Context, acc *types.Account) error
+
+	// AddApiKey stores a new API key in the database.
+	AddApiKey(ctx context.Context, key *types.ApiKey) error
+
+	// ApiKey loads the API key of the given hash; nil is returned for unknown keys.
+	ApiKey(ctx context.Context, hash string) (*types.ApiKey, error)
+
+	// RevokeApiKey marks the API key of the given hash as revoked.
+	RevokeApiKey(ctx context.Context, hash string) (bool, error)
+
+	// AddApiKeyUsage adds the given number of queries and their cost to the daily usage of the API key.
+	AddApiKeyUsage(ctx context.Context, u *types.ApiKeyUsage) error
+
+	// ApiKeyUsage loads the daily usage of the API key of the given hash since the given day.
+	ApiKeyUsage(ctx context.Context, key string, from time.Time) ([]*types.ApiKeyUsage, error)
+
+	// AddAuditRecord stores a record of an executed operation in the audit log.
+	AddAuditRecord(ctx context.Context, rec *types.AuditRecord) error
+
+	// AuditLog loads the given number of the most recent audit records
+	// optionally filtered by the client and the operation name.
+	AuditLog(ctx context.Context, client *string, operation *string, count int32) ([]*types.AuditRecord, error)
+
+	// AccountLabel loads the label of the given address; nil is returned for unlabeled addresses.
+	AccountLabel(ctx context.Context, addr *common.Address) (*types.AccountLabel, error)
+
+	// AccountLabels loads the list of account labels, optionally of the given category only.
+	AccountLabels(ctx context.Context, category *string) ([]*types.AccountLabel, error)
+
+	// RemoveAccountLabel removes the label of the given address.
+	RemoveAccountLabel(ctx context.Context, addr *common.Address) (bool, error)
+
+	// SetAccountLabel stores the given account label, replacing the previous label of the address.
+	SetAccountLabel(ctx context.Context, al *types.AccountLabel) error
+
+	// AddWatch stores a new address watch in the database.
+	AddWatch(ctx context.Context, w *types.Watch) error
+
+	// RemoveWatch removes the address watch of the given id, optionally owned by the given client only.
+	RemoveWatch(ctx context.Context, id string, owner *string) (bool, error)
+
+	// Watches loads the list of address watches, optionally owned by the given client only.
+	Watches(ctx context.Context, owner *string) ([]*types.Watch, error)
+
+	// AddBlockTime stores the timing of a processed block; the previous record of the block is replaced.
+	AddBlockTime(ctx context.Context, bt *types.BlockTime) error
+
+	// BlockTimeHash loads the hash of the processed block of the given number;
+	// nil if the block is not known, or its hash was not recorded.
+	BlockTimeHash(ctx context.Context, number uint64) (*common.Hash, error)
+
+	// BlockTimeDailyList loads a range of daily block time aggregations from the database.
+	BlockTimeDailyList(ctx context.Context, from *time.Time, to *time.Time) ([]*types.DailyBlockTime, error)
+
+	// BlockTimeDailyUpdate aggregates the block times created after the given time
+	// into the daily block times collection.
+	BlockTimeDailyUpdate(ctx context.Context, from time.Time) error
+
+	// ContractUsage loads a range of daily usage aggregations of the given contract from the database.
+	ContractUsage(ctx context.Context, adr *common.Address, from *time.Time, to *time.Time) ([]*types.ContractDailyUsage, error)
+
+	// ContractUsageDailyUpdate aggregates the calls of smart contracts made after the given time
+	// into the daily contract usage collection.
+	ContractUsageDailyUpdate(ctx context.Context, from time.Time) error
+
+	// ContractTopGasUpdate ranks the contracts by the gas used by their calls made after the given time.
+	ContractTopGasUpdate(ctx context.Context, from time.Time, count int64) error
+
+	// TopContractsByGas loads the given number of the contracts with the highest gas used.
+	TopContractsByGas(ctx context.Context, count int32) ([]*types.ContractGasRank, error)
+
+	// BlockTimes loads the timing of the given number of the most recent blocks, newest first.
+	BlockTimes(ctx context.Context, count int32) ([]*types.BlockTime, error)
+
+	// AddDelegationLockEvent stores a delegation lock event in the database.
+	AddDelegationLockEvent(ctx context.Context, dle *types.DelegationLockEvent) error
+
+	// DelegationLockEvents loads the given number of the most recent lock events of the delegation.
+	DelegationLockEvents(ctx context.Context, addr *common.Address, valID *hexutil.Big, count int32) ([]*types.DelegationLockEvent, error)
+
+	// AddValidatorEpochPerformance stores the performance of a validator within an epoch.
+	AddValidatorEpochPerformance(ctx context.Context, vep *types.ValidatorEpochPerformance) error
+
+	// AddEpochValidators stores the snapshot of the validators at the end of the given epoch.
+	AddEpochValidators(ctx context.Context, id hexutil.Uint64, list []types.EpochValidator) error
+
+	// EpochValidators loads the snapshot of the validators at the end of the given epoch.
+	EpochValidators(ctx context.Context, id hexutil.Uint64) ([]types.EpochValidator, error)
+
+	// ValidatorPerformance aggregates the performance of validators over the epochs starting with the given epoch.
+	ValidatorPerformance(ctx context.Context, valID *hexutil.Big, fromEpoch uint64) ([]*types.ValidatorPerformance, error)
+
+	// BurnByBlock loads the FTM burn record of the given block, if any.
+	BurnByBlock(ctx context.Context, block uint64) (*types.FtmBurn, error)
+
+	// BurnCount calculates total number of FTM burn records in the database.
+	BurnCount(context.Context) (uint64, error)
+
+	// BurnList loads a list of FTM burn records from the most recent block,
+	// starting below the given block number, if any.
+	BurnList(ctx context.Context, below *uint64, count int32) ([]*types.FtmBurn, error)
+
+	// BurnTotal calculates the total amount of FTM burned.
+	BurnTotal(context.Context) (*big.Int, error)
+
+	// StoreBurn stores the FTM burn record of a block; the previous record of the block is replaced.
+	StoreBurn(ctx context.Context, burn *types.FtmBurn) error
+
+	// Erc1155ContractsList returns a list of known ERC1155 contracts ordered by their activity.
+	Erc1155ContractsList(ctx context.Context, count int32) ([]common.Address, error)
+
+	// Erc20TokensList returns a list of known ERC20 tokens ordered by their activity.
+	Erc20TokensList(ctx context.Context, count int32) ([]common.Address, error)
+
+	// Erc721ContractsList returns a list of known ERC20 tokens ordered by their activity.
+	Erc721ContractsList(ctx context.Context, count int32) ([]common.Address, error)
+
+	// IsAccountKnown checks if an account document already exists in the database.
+	IsAccountKnown(ctx context.Context, addr *common.Address) (bool, error)
+
+	// Close will terminate or finish all operations and close the connection to Mongo database.
+	Close()
+
+	// LastKnownBlock returns the last known block from the database.
+	LastKnownBlock(context.Context) (uint64, error)
+
+	// UpdateLastKnownBlock stores the last known block into the config collection.
+	UpdateLastKnownBlock(ctx context.Context, blockNo *hexutil.Uint64) error
+
+	// AddContract stores a smart contract reference in connected persistent storage.
+	AddContract(ctx context.Context, sc *types.Contract) error
+
+	// Contract returns details of a smart contract stored in the Mongo database
+	// if available, or nil if contract does not exist.
+	Contract(ctx context.Context, addr *common.Address) (*types.Contract, error)
+
+	// ContractTransaction returns contract creation transaction hash if available.
+	ContractTransaction(ctx context.Context, addr *common.Address) (*common.Hash, error)
+
+	// Contracts provides list of smart contracts stored in the persistent storage.
+	Contracts(ctx context.Context, validatedOnly bool, cursor *string, count int32) (*types.ContractList, error)
+
+	// ContractsBySymbol provides the addresses of the contracts of the given type
+	// issuing a token with the given symbol; the symbol is matched case-insensitive.
+	ContractsBySymbol(ctx context.Context, cType string, sym string, count int32) ([]common.Address, error)
+
+	// ContractsWithoutSymbol provides the addresses of the contracts of the given type
+	// with the symbol of the token not known yet.
+	ContractsWithoutSymbol(ctx context.Context, cType string) ([]common.Address, error)
+
+	// ContractUpdateSymbol sets the symbol of the token issued by the given contract.
+	ContractUpdateSymbol(ctx context.Context, addr *common.Address, sym string) error
+
+	// IsContractKnown checks if a smart contract document already exists in the database.
+	IsContractKnown(ctx context.Context, addr *common.Address) bool
+
+	// UpdateContract updates smart contract information in database to reflect
+	// new validation or similar changes passed from repository.
+	UpdateContract(ctx context.Context, sc *types.Contract) error
+
+	// AddDelegation stores a delegation in the database if it doesn't exist.
+	AddDelegation(ctx context.Context, dl *types.Delegation) error
+
+	// Delegation returns details of a delegation from an address to a validator ID.
+	Delegation(ctx context.Context, addr *common.Address, valID *hexutil.Big) (*types.Delegation, error)
+
+	// Delegations pulls list of delegations starting at the specified cursor.
+	Delegations(ctx context.Context, cursor *string, count int32, filter *types.DelegationFilter) (*types.DelegationList, error)
+
+	// DelegationsAll pulls list of delegations for the given filter un-paged.
+	DelegationsAll(ctx context.Context, filter *types.DelegationFilter) ([]*types.Delegation, error)
+
+	// DelegationsCountFiltered calculates total number of delegations in the database for the given filter.
+	DelegationsCountFiltered(ctx context.Context, filter *types.DelegationFilter) (uint64, error)
+
+	// UpdateDelegationBalance updates the given delegation active balance in database to the given amount.
+	UpdateDelegationBalance(ctx context.Context, addr *common.Address, valID *hexutil.Big, amo *hexutil.Big) error
+
+	// AddEpoch stores an epoch reference in connected persistent storage.
+	AddEpoch(ctx context.Context, e *types.Epoch) error
+
+	// EpochIdAt provides the id of the epoch which was active at the given time stamp.
+	// The epoch is identified as the first sealed epoch ending after the time stamp,
+	// zero is returned if no such epoch has been sealed yet.
+	EpochIdAt(ctx context.Context, ts time.Time) (uint64, error)
+
+	// Epochs pulls list of epochs starting at the specified cursor.
+	Epochs(ctx context.Context, cursor *string, count int32) (*types.EpochList, error)
+
+	// LastKnownEpoch provides the number of the newest epoch stored in the database.
+	LastKnownEpoch(context.Context) (uint64, error)
+
+	// Erc20Holders loads a list of holders of the given ERC20 token sorted by their balance
+	// from the highest to the lowest, skipping the given number of holders.
+	Erc20Holders(ctx context.Context, token *common.Address, skip uint64, count int32) ([]*types.AccountBalance, error)
+
+	// Erc20TokenHoldersCount returns the number of known holders of the given ERC20 token.
+	Erc20TokenHoldersCount(ctx context.Context, token *common.Address) (uint64, error)
+
+	// Erc20UpdateHolders applies the given ERC20 transfer to the recorded balances
+	// of the sender and the recipient.
+	Erc20UpdateHolders(ctx context.Context, trx *types.TokenTransaction) error
+
+	// Erc1155UpdateBalances applies the given ERC1155 transfer to the recorded balances
+	// of the sender and the recipient.
+	Erc1155UpdateBalances(ctx context.Context, trx *types.TokenTransaction) error
+
+	// Erc1155Balances loads the list of ERC1155 token types held by the given account
+	// along with the current balance. Token types with zero balance are not included.
+	Erc1155Balances(ctx context.Context, owner common.Address, count int32) ([]*types.Erc1155Balance, error)
+
+	// Erc721UpdateOwner records the recipient of the given ERC721 transfer as the owner of the token.
+	Erc721UpdateOwner(ctx context.Context, trx *types.TokenTransaction) error
+
+	// Erc721TokensOfOwner loads the list of ERC721 NFT tokens currently owned by the given account.
+	Erc721TokensOfOwner(ctx context.Context, owner common.Address, count int32) ([]*types.Erc721Token, error)
+
+	// StoreNftMetadata stores the downloaded NFT metadata; the previous record of the token is replaced.
+	StoreNftMetadata(ctx context.Context, md *types.NftMetadata) error
+
+	// NftMetadata loads the stored metadata of the NFT of the given identifier, nil if not known.
+	NftMetadata(ctx context.Context, id string) (*types.NftMetadata, error)
+
+	// AddERC20Transaction stores an ERC20 transaction in the database if it doesn't exist.
+	AddERC20Transaction(ctx context.Context, trx *types.TokenTransaction) error
+
+	// Erc20Assets provides list of unique token addresses linked by transactions to the given owner address.
+	Erc20Assets(ctx context.Context, owner common.Address, count int32) ([]common.Address, error)
+
+	// Erc20Transactions pulls list of ERC20 transactions starting at the specified cursor.
+	Erc20Transactions(ctx context.Context, cursor *string, count int32, filter *types.TokenTransactionFilter) (*types.TokenTransactionList, error)
+
+	// TokenTransactionsByCall provides list of token transactions for the given blockchain transaction call.
+	TokenTransactionsByCall(ctx context.Context, trxHash *common.Hash) ([]*types.TokenTransaction, error)
+
+	// AddFMintTransaction stores an fMint transaction in the database if it doesn't exist.
+	AddFMintTransaction(ctx context.Context, trx *types.FMintTransaction) error
+
+	// FMintUsers loads the list of fMint users and their associated tokens
+	// used for a specified transaction type  from the collected database using aggregation pipeline.
+	FMintUsers(ctx context.Context, tt int32) ([]*types.FMintUserTokens, error)
+
+	// AddGasPricePeriod stores a new record for the gas price evaluation
+	// into the persistent collection.
+	AddGasPricePeriod(ctx context.Context, gp *types.GasPricePeriod) error
+
+	// AddGovernanceVote stores a governance vote in the database;
+	// a known vote of the same voter and delegation on the proposal is replaced.
+	AddGovernanceVote(ctx context.Context, gv *types.GovernanceVote) error
+
+	// GovernanceVotesOf loads the list of the most recent governance votes
+	// placed by the given account.
+	GovernanceVotesOf(ctx context.Context, adr *common.Address, count int32) ([]*types.GovernanceVote, error)
+
+	// RemoveGovernanceVote removes a canceled governance vote from the database.
+	RemoveGovernanceVote(ctx context.Context, gov *common.Address, prop *hexutil.Big, from *common.Address, delegatedTo *common.Address) error
+
+	// AddInternalTransaction stores an internal transaction in the database;
+	// a known internal transaction is replaced.
+	AddInternalTransaction(ctx context.Context, itx *types.InternalTransaction) error
+
+	// InternalTransactions loads the list of internal transactions of the given parent transaction.
+	InternalTransactions(ctx context.Context, hash *common.Hash) ([]*types.InternalTransaction, error)
+
+	// InternalTransactionsOfAccount loads the list of the most recent internal transactions
+	// the given account is involved with, either as the caller, or the callee.
+	InternalTransactionsOfAccount(ctx context.Context, adr *common.Address, count int32) ([]*types.InternalTransaction, error)
+
+	// RemoveBlocksFrom removes transactions of all the blocks starting with the given block number
+	// and the indexed data derived from them so the blocks can be re-ingested
+	// after a chain reorganization. The state which can not be rebuilt from the remaining
+	// records is returned so it can be refreshed from the node.
+	RemoveBlocksFrom(ctx context.Context, from uint64) (*types.BlockRollback, error)
+
+	// AddRewardClaim stores a reward claim in the database if it doesn't exist.
+	AddRewardClaim(ctx context.Context, rc *types.RewardClaim) error
+
+	// RewardClaims pulls list of reward claims starting at the specified cursor.
+	RewardClaims(ctx context.Context, cursor *string, count int32, filter *types.RewardClaimFilter) (*types.RewardClaimsList, error)
+
+	// RewardsSumValue calculates sum of values for all the reward claims by a filter.
+	RewardsSumValue(ctx context.Context, filter *types.RewardClaimFilter) (*big.Int, error)
+
+	// AddTransaction stores a transaction reference in connected persistent storage.
+	AddTransaction(ctx context.Context, block *types.Block, trx *types.Transaction) error
+
+	// Transactions pulls list of transaction hashes starting on the specified cursor.
+	// The optional account and filter limit the list to transactions matching the conditions.
+	Transactions(ctx context.Context, cursor *string, count int32, acc *common.Address, filter *types.TransactionFilter) (*types.TransactionList, error)
+
+	// TransactionsCount returns the number of transactions stored in the database.
+	TransactionsCount(context.Context) (uint64, error)
+
+	// TransactionsTotal provides the exact number of the indexed transactions.
+	TransactionsTotal(context.Context) (uint64, error)
+
+	// TransactionsCountByBlock provides the number of stored transactions of blocks in the given range.
+	// Blocks without any stored transaction are not included.
+	TransactionsCountByBlock(ctx context.Context, from uint64, to uint64) (map[uint64]int, error)
+
+	// TrxDailyFlowList loads a range of daily trx volumes from the database.
+	TrxDailyFlowList(ctx context.Context, from *time.Time, to *time.Time) ([]*types.DailyTrxVolume, error)
+
+	// TrxDailyFlowUpdate performs an update on the daily trx flow data
+	// for the given date range directly.
+	TrxDailyFlowUpdate(ctx context.Context, from time.Time) error
+
+	// TrxGasSpeed provides amount of gas consumed by transaction per second
+	// in the given time range.
+	TrxGasSpeed(ctx context.Context, from *time.Time, to *time.Time) (float64, error)
+
+	// TrxRecentTrxSpeed provides the number of transaction per second on the defined range in seconds.
+	TrxRecentTrxSpeed(ctx context.Context, sec int32) (float64, error)
+
+	// LastKnownSwapBlock returns number of the last known block stored in the database.
+	LastKnownSwapBlock(context.Context) (uint64, error)
+
+	// UniswapActions provides list of uniswap actions stored in the persistent storage.
+	UniswapActions(ctx context.Context, pairAddress *common.Address, cursor *string, count int32, actionType int32) (*types.UniswapActionList, error)
+
+	// UniswapReservesAt loads the reserves of the given pair recorded by the most recent
+	// sync event before the given time.
+	UniswapReservesAt(ctx context.Context, pair *common.Address, at time.Time) ([]*big.Int, error)
+
+	// UniswapTokenVolume resolves the amount of the token of the given side of the pair
+	// traded by swaps since the given time.
+	UniswapTokenVolume(ctx context.Context, pair *common.Address, side int, from time.Time) (*big.Int, error)
+
+	// UniswapAdd stores a swap reference in connected persistent storage.
+	UniswapAdd(ctx context.Context, swap *types.Swap) error
+
+	// UniswapTimePrices resolves price of swap trades for specified pair grouped by date interval.
+	// If toTime is 0, then it calculates prices till now
+	UniswapTimePrices(ctx context.Context, pairAddress *common.Address, resolution string, fromTime int64, toTime int64, direction int32) ([]types.DefiTimePrice, error)
+
+	// UniswapTimeReserves resolves reserves of uniswap trades for specified pair grouped by date interval.
+	// If toTime is 0, then it calculates prices till now
+	UniswapTimeReserves(ctx context.Context, pairAddress *common.Address, resolution string, fromTime int64, toTime int64) ([]types.DefiTimeReserve, error)
+
+	// UniswapTimeVolumes resolves volumes of swap trades for specified pair grouped by date interval.
+	// If toTime is 0, then it calculates volumes till now
+	UniswapTimeVolumes(ctx context.Context, pairAddress *common.Address, resolution string, fromTime int64, toTime int64) ([]types.DefiSwapVolume, error)
+
+	// UniswapUpdateLastKnownSwapBlock stores a last correctly saved swap block number into persistent storage.
+	UniswapUpdateLastKnownSwapBlock(ctx context.Context, blkNumber uint64) error
+
+	// UniswapVolume resolves volume of swap trades for specified pair and date interval.
+	// If toTime is 0, then it calculates volumes till now
+	UniswapVolume(ctx context.Context, pairAddress *common.Address, fromTime int64, toTime int64) (types.DefiSwapVolume, error)
+
+	// AddWithdrawal stores a withdrawal request in the database if it doesn't exist.
+	AddWithdrawal(ctx context.Context, wr *types.WithdrawRequest) error
+
+	// UpdateWithdrawal updates the given withdraw request in database.
+	UpdateWithdrawal(ctx context.Context, wr *types.WithdrawRequest) error
+
+	// Withdrawal returns details of a withdrawal request specified by the request ID.
+	Withdrawal(ctx context.Context, addr *common.Address, valID *hexutil.Big, reqID *hexutil.Big) (*types.WithdrawRequest, error)
+
+	// Withdrawals pulls list of withdraw requests starting at the specified cursor.
+	Withdrawals(ctx context.Context, cursor *string, count int32, filter *types.WithdrawalFilter) (*types.WithdrawRequestList, error)
+
+	// WithdrawalsSumValue calculates sum of values for all the withdrawals by a filter.
+	WithdrawalsSumValue(ctx context.Context, filter *types.WithdrawalFilter) (*big.Int, error)
+}
@@ -10,21 +10,24 @@ package repository
 
 import (
 	"bytes"
-	"errors"
+	"context"
 	"fantom-api-graphql/internal/repository/cache"
+	"fantom-api-graphql/internal/tracing"
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	retypes "github.com/ethereum/go-ethereum/core/types"
 	eth "github.com/ethereum/go-ethereum/rpc"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // ErrTransactionNotFound represents an error returned if a transaction can not be found.
-var ErrTransactionNotFound = errors.New("requested transaction can not be found in Opera blockchain")
+var ErrTransactionNotFound = types.NewError(types.ErrCodeNotFound, "requested transaction can not be found in Opera blockchain")
 
 // StoreTransaction notifies a new incoming transaction from blockchain to the repository.
-func (p *proxy) StoreTransaction(block *types.Block, trx *types.Transaction) error {
-	return p.db.AddTransaction(block, trx)
+func (p *proxy) StoreTransaction(ctx context.Context, block *types.Block, trx *types.Transaction) error {
+	return p.db.AddTransaction(ctx, block, trx)
 }
 
 // CacheTransaction puts a transaction to the internal ring cache.
@@ -34,7 +37,7 @@ func (p *proxy) CacheTransaction(trx *types.Transaction) {
 
 // Transaction returns a transaction at Opera blockchain by a hash, nil if not found.
 // If the transaction is not found, ErrTransactionNotFound error is returned.
-func (p *proxy) Transaction(hash *common.Hash) (*types.Transaction, error) {
+func (p *proxy) Transaction(ctx context.Context, hash *common.Hash) (*types.Transaction, error) {
 	// log
 	p.log.Debugf("requested transaction %s", hash.String())
 
@@ -45,12 +48,27 @@ func (p *proxy) Transaction(hash *common.Hash) (*types.Transaction, error) {
 		return trx, nil
 	}
 
+	// recently looked up and not found?
+	if p.cache.IsNotFound(cache.NotFoundTransaction, hash.String()) {
+		return nil, ErrTransactionNotFound
+	}
+
 	// return the value
-	trx, err := p.LoadTransaction(hash)
+	trx, err := p.LoadTransaction(ctx, hash)
 	if err != nil {
+		if err == eth.ErrNoResult {
+			p.cache.PushNotFound(cache.NotFoundTransaction, hash.String())
+			return nil, ErrTransactionNotFound
+		}
 		return nil, err
 	}
 
+	// the node responds with an empty structure for unknown transactions
+	if trx.Hash == (common.Hash{}) {
+		p.cache.PushNotFound(cache.NotFoundTransaction, hash.String())
+		return nil, ErrTransactionNotFound
+	}
+
 	// push the transaction to the cache to speed things up next time
 	// we don't cache pending transactions since it would cause issues
 	// when re-loading data of such transactions on the client side
@@ -64,18 +82,78 @@ func (p *proxy) Transaction(hash *common.Hash) (*types.Transaction, error) {
 	return trx, nil
 }
 
+// TransactionsByHash returns the given transactions at Opera blockchain. Transactions not in cache
+// are loaded in a single batch. Transactions not available are returned as nil.
+func (p *proxy) TransactionsByHash(ctx context.Context, hashes []*common.Hash) ([]*types.Transaction, error) {
+	ctx, span := tracing.Start(ctx, "repository.TransactionsByHash", attribute.Int("count", len(hashes)))
+	defer span.End()
+
+	list := make([]*types.Transaction, len(hashes))
+
+	// try to use the in-memory cache first
+	missing := make([]*common.Hash, 0, len(hashes))
+	index := make([]int, 0, len(hashes))
+	for i, h := range hashes {
+		if list[i] = p.cache.PullTransaction(h); list[i] == nil {
+			missing = append(missing, h)
+			index = append(index, i)
+		}
+	}
+	if len(missing) == 0 {
+		return list, nil
+	}
+
+	// pull the rest from the chain
+	pulled, err := p.rpc.Transactions(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, trx := range pulled {
+		if trx == nil {
+			continue
+		}
+
+		list[index[i]] = trx
+		if trx.BlockHash != nil {
+			p.cache.PushTransaction(trx)
+		}
+	}
+	return list, nil
+}
+
 // LoadTransaction returns a transaction at Opera blockchain
 // by a hash loaded directly from the node.
-func (p *proxy) LoadTransaction(hash *common.Hash) (*types.Transaction, error) {
-	return p.rpc.Transaction(hash)
+func (p *proxy) LoadTransaction(ctx context.Context, hash *common.Hash) (*types.Transaction, error) {
+	return p.rpc.Transaction(ctx, hash)
+}
+
+// TransactionRevertReason provides the reason of failure of the given failed transaction.
+// The transaction is replayed on the node if the reason is not known yet.
+func (p *proxy) TransactionRevertReason(ctx context.Context, trx *types.Transaction) (string, error) {
+	if trx.RevertReason != nil {
+		return *trx.RevertReason, nil
+	}
+	return p.rpc.TransactionRevertReason(ctx, trx)
+}
+
+// TransactionLogs provides the list of event log records emitted
+// by the transaction. The logs are pulled from the transaction receipt
+// and cached along with the transaction.
+func (p *proxy) TransactionLogs(ctx context.Context, hash *common.Hash) ([]retypes.Log, error) {
+	trx, err := p.Transaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return trx.Logs, nil
 }
 
 // SendTransaction sends raw signed and RLP encoded transaction to the block chain.
-func (p *proxy) SendTransaction(tx hexutil.Bytes) (*types.Transaction, error) {
+func (p *proxy) SendTransaction(ctx context.Context, tx hexutil.Bytes) (*types.Transaction, error) {
 	p.log.Debugf("announcing trx %s", tx.String())
 
 	// try to send it and get the tx hash
-	hash, err := p.rpc.SendTransaction(tx)
+	hash, err := p.rpc.SendTransaction(ctx, tx)
 	if err != nil {
 		p.log.Errorf("can not send transaction to block chain; %s", err.Error())
 		return nil, err
@@ -89,12 +167,13 @@ func (p *proxy) SendTransaction(tx hexutil.Bytes) (*types.Transaction, error) {
 
 	// we do have the hash, so we can use it to get the transaction details
 	// we always need to go to RPC, and we will not try to store the transaction in cache yet
-	trx, err := p.rpc.Transaction(hash)
+	trx, err := p.rpc.Transaction(ctx, hash)
 	if err != nil {
-		// transaction simply not found?
+		// transaction not found yet? the node may still be processing it,
+		// so we build a pending stub from the raw transaction itself
 		if err == eth.ErrNoResult {
-			p.log.Warning("transaction not found in the blockchain")
-			return nil, ErrTransactionNotFound
+			p.log.Warningf("transaction %s not found in the blockchain yet", hash.String())
+			return pendingTransactionStub(tx)
 		}
 
 		// something went wrong
@@ -112,13 +191,50 @@ func (p *proxy) SendTransaction(tx hexutil.Bytes) (*types.Transaction, error) {
 	return trx, nil
 }
 
+// pendingTransactionStub decodes the raw signed transaction into a pending transaction stub.
+func pendingTransactionStub(raw hexutil.Bytes) (*types.Transaction, error) {
+	// decode the transaction
+	var tx retypes.Transaction
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return nil, ErrTransactionNotFound
+	}
+
+	// recover the sender; legacy transactions may not be replay protected
+	var signer retypes.Signer = retypes.HomesteadSigner{}
+	if tx.ChainId().Sign() > 0 {
+		signer = retypes.LatestSignerForChainID(tx.ChainId())
+	}
+	from, err := retypes.Sender(signer, &tx)
+	if err != nil {
+		return nil, ErrTransactionNotFound
+	}
+
+	return &types.Transaction{
+		From:      from,
+		Gas:       hexutil.Uint64(tx.Gas()),
+		GasPrice:  hexutil.Big(*tx.GasPrice()),
+		Hash:      tx.Hash(),
+		Nonce:     hexutil.Uint64(tx.Nonce()),
+		To:        tx.To(),
+		Value:     hexutil.Big(*tx.Value()),
+		InputData: tx.Data(),
+	}, nil
+}
+
 // Transactions pulls list of transaction hashes starting on the specified cursor.
 // If the initial transaction cursor is not provided, we start on top, or bottom based on count value.
 //
 // No-number boundaries are handled as follows:
-// 	- For positive count we start from the most recent transaction and scan to older transactions.
-// 	- For negative count we start from the first transaction and scan to newer transactions.
-func (p *proxy) Transactions(cursor *string, count int32) (*types.TransactionList, error) {
+//   - For positive count we start from the most recent transaction and scan to older transactions.
+//   - For negative count we start from the first transaction and scan to newer transactions.
+//
+// The optional filter limits the list to transactions matching its conditions.
+func (p *proxy) Transactions(ctx context.Context, cursor *string, count int32, filter *types.TransactionFilter) (*types.TransactionList, error) {
+	// filtered list is always loaded from the db
+	if filter != nil {
+		return p.db.Transactions(ctx, cursor, count, nil, filter)
+	}
+
 	// we may be able to pull the list faster than from the db
 	if cursor == nil && count > 0 && count < cache.TransactionRingCacheSize {
 		// pull the quick list
@@ -139,10 +255,10 @@ func (p *proxy) Transactions(cursor *string, count int32) (*types.TransactionLis
 	}
 
 	// use slow trx list pulling
-	return p.db.Transactions(cursor, count, nil)
+	return p.db.Transactions(ctx, cursor, count, nil, nil)
 }
 
 // StoreGasPricePeriod stores the given gas price period data in the persistent storage
-func (p *proxy) StoreGasPricePeriod(gp *types.GasPricePeriod) error {
-	return p.db.AddGasPricePeriod(gp)
+func (p *proxy) StoreGasPricePeriod(ctx context.Context, gp *types.GasPricePeriod) error {
+	return p.db.AddGasPricePeriod(ctx, gp)
 }
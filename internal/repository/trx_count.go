@@ -9,6 +9,7 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 package repository
 
 import (
+	"context"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"sync/atomic"
 )
@@ -46,6 +47,12 @@ func (p *proxy) UpdateTrxCountEstimate(val uint64) {
 }
 
 // TransactionsCount returns total number of transactions in the block chain.
-func (p *proxy) TransactionsCount() (uint64, error) {
-	return p.db.TransactionsCount()
+func (p *proxy) TransactionsCount(ctx context.Context) (uint64, error) {
+	return p.db.TransactionsCount(ctx)
+}
+
+// TransactionsTotal provides the exact number of the indexed transactions
+// maintained as a counter by the scanner.
+func (p *proxy) TransactionsTotal(ctx context.Context) (uint64, error) {
+	return p.db.TransactionsTotal(ctx)
 }
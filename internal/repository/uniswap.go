@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"fantom-api-graphql/internal/types"
 
@@ -9,13 +10,13 @@ import (
 )
 
 // NativeTokenAddress returns address of the native token wrapper, if available.
-func (p *proxy) NativeTokenAddress() (*common.Address, error) {
-	return p.rpc.NativeTokenAddress()
+func (p *proxy) NativeTokenAddress(ctx context.Context) (*common.Address, error) {
+	return p.rpc.NativeTokenAddress(ctx)
 }
 
 // UniswapPairs returns list of all token pairs managed by Uniswap core.
 // We use cache to store the list temporarily, the list is refreshed from RCP when the cache record expires.
-func (p *proxy) UniswapPairs() ([]common.Address, error) {
+func (p *proxy) UniswapPairs(ctx context.Context) ([]common.Address, error) {
 	// try the cache first
 	l := p.cache.PullAllPairsList()
 	if l != nil {
@@ -23,7 +24,7 @@ func (p *proxy) UniswapPairs() ([]common.Address, error) {
 	}
 
 	// use RPC to get the fresh list and cache it for future use
-	l, err := p.rpc.UniswapPairs(false)
+	l, err := p.rpc.UniswapPairs(ctx, false)
 	if err != nil {
 		log.Errorf("uniswap pairs not available; %s", err.Error())
 		return nil, err
@@ -32,46 +33,53 @@ func (p *proxy) UniswapPairs() ([]common.Address, error) {
 	return l, nil
 }
 
+// UniswapPairCreated registers a new pair created by the Uniswap core,
+// the cached list of all pairs is dropped, so the new pair is picked up on the next access.
+func (p *proxy) UniswapPairCreated(ctx context.Context, pair *common.Address) {
+	p.log.Noticef("new uniswap pair %s created", pair.String())
+	p.cache.EvictAllPairsList()
+}
+
 // UniswapKnownPairs returns list of all known and whitelisted token pairs managed by Uniswap core.
-func (p *proxy) UniswapKnownPairs() ([]common.Address, error) {
-	return p.rpc.UniswapPairs(true)
+func (p *proxy) UniswapKnownPairs(ctx context.Context) ([]common.Address, error) {
+	return p.rpc.UniswapPairs(ctx, true)
 }
 
 // UniswapPair returns an address of an Uniswap pair for the given tokens.
-func (p *proxy) UniswapPair(tokenA *common.Address, tokenB *common.Address) (*common.Address, error) {
-	return p.rpc.UniswapPair(tokenA, tokenB)
+func (p *proxy) UniswapPair(ctx context.Context, tokenA *common.Address, tokenB *common.Address) (*common.Address, error) {
+	return p.rpc.UniswapPair(ctx, tokenA, tokenB)
 }
 
 // UniswapAmountsOut resolves a list of output amounts for the given
 // input amount and a list of tokens to be used to make the swap operation.
-func (p *proxy) UniswapAmountsOut(amountIn hexutil.Big, tokens []common.Address) ([]hexutil.Big, error) {
-	return p.rpc.UniswapAmountsOut(amountIn, tokens)
+func (p *proxy) UniswapAmountsOut(ctx context.Context, amountIn hexutil.Big, tokens []common.Address) ([]hexutil.Big, error) {
+	return p.rpc.UniswapAmountsOut(ctx, amountIn, tokens)
 }
 
 // UniswapAmountsIn resolves a list of input amounts for the given
 // output amount and a list of tokens to be used to make the swap operation.
-func (p *proxy) UniswapAmountsIn(amountOut hexutil.Big, tokens []common.Address) ([]hexutil.Big, error) {
-	return p.rpc.UniswapAmountsIn(amountOut, tokens)
+func (p *proxy) UniswapAmountsIn(ctx context.Context, amountOut hexutil.Big, tokens []common.Address) ([]hexutil.Big, error) {
+	return p.rpc.UniswapAmountsIn(ctx, amountOut, tokens)
 }
 
 // UniswapQuoteInput calculates optimal input on sibling token based on input amount and
 // self reserves of the analyzed token.
-func (p *proxy) UniswapQuoteInput(
+func (p *proxy) UniswapQuoteInput(ctx context.Context,
 	amountIn hexutil.Big,
 	reserveMy hexutil.Big,
 	reserveSibling hexutil.Big,
 ) (hexutil.Big, error) {
-	return p.rpc.UniswapQuoteInput(amountIn, reserveMy, reserveSibling)
+	return p.rpc.UniswapQuoteInput(ctx, amountIn, reserveMy, reserveSibling)
 }
 
 // UniswapTokens returns list of addresses of tokens involved in a Uniswap pair.
-func (p *proxy) UniswapTokens(pair *common.Address) ([]common.Address, error) {
+func (p *proxy) UniswapTokens(ctx context.Context, pair *common.Address) ([]common.Address, error) {
 	var err error
 	// try cache first
 	tl := p.cache.PullUniswapPairTokens(pair)
 	if nil == tl {
 		// load the hard way
-		tl, err = p.rpc.UniswapTokens(pair)
+		tl, err = p.rpc.UniswapTokens(ctx, pair)
 		if err == nil {
 			// push to cache for future use
 			p.cache.PushUniswapPairTokens(pair, tl)
@@ -81,75 +89,75 @@ func (p *proxy) UniswapTokens(pair *common.Address) ([]common.Address, error) {
 }
 
 // UniswapReserves returns list of token reserve amounts in a Uniswap pair.
-func (p *proxy) UniswapReserves(pair *common.Address) ([]hexutil.Big, error) {
-	return p.rpc.UniswapReserves(pair)
+func (p *proxy) UniswapReserves(ctx context.Context, pair *common.Address) ([]hexutil.Big, error) {
+	return p.rpc.UniswapReserves(ctx, pair)
 }
 
 // UniswapReservesTimeStamp returns the timestamp of the reserves of a Uniswap pair.
-func (p *proxy) UniswapReservesTimeStamp(pair *common.Address) (hexutil.Uint64, error) {
-	return p.rpc.UniswapReservesTimeStamp(pair)
+func (p *proxy) UniswapReservesTimeStamp(ctx context.Context, pair *common.Address) (hexutil.Uint64, error) {
+	return p.rpc.UniswapReservesTimeStamp(ctx, pair)
 }
 
 // UniswapCumulativePrices returns list of token cumulative prices of a Uniswap pair.
-func (p *proxy) UniswapCumulativePrices(pair *common.Address) ([]hexutil.Big, error) {
-	return p.rpc.UniswapCumulativePrices(pair)
+func (p *proxy) UniswapCumulativePrices(ctx context.Context, pair *common.Address) ([]hexutil.Big, error) {
+	return p.rpc.UniswapCumulativePrices(ctx, pair)
 }
 
 // UniswapLastKValue returns the last value of the pool control coefficient.
-func (p *proxy) UniswapLastKValue(pair *common.Address) (hexutil.Big, error) {
-	return p.rpc.UniswapLastKValue(pair)
+func (p *proxy) UniswapLastKValue(ctx context.Context, pair *common.Address) (hexutil.Big, error) {
+	return p.rpc.UniswapLastKValue(ctx, pair)
 }
 
 // UniswapPairContract returns instance of this contract according to given pair address
-func (p *proxy) UniswapPairContract(pairAddres *common.Address) (*contracts.UniswapPair, error) {
+func (p *proxy) UniswapPairContract(ctx context.Context, pairAddres *common.Address) (*contracts.UniswapPair, error) {
 	return p.rpc.UniswapPairContract(pairAddres)
 }
 
 // UniswapAdd notifies a new incoming swap from blockchain to the repository.
-func (p *proxy) UniswapAdd(swap *types.Swap) error {
-	return p.db.UniswapAdd(swap)
+func (p *proxy) UniswapAdd(ctx context.Context, swap *types.Swap) error {
+	return p.db.UniswapAdd(ctx, swap)
 }
 
 // LastKnownSwapBlock returns number of the last block known to the repository with the swap event.
-func (p *proxy) LastKnownSwapBlock() (uint64, error) {
-	return p.db.LastKnownSwapBlock()
+func (p *proxy) LastKnownSwapBlock(ctx context.Context) (uint64, error) {
+	return p.db.LastKnownSwapBlock(ctx)
 }
 
 // UniswapUpdateLastKnownSwapBlock stores a last correctly saved swap block number into persistent storage.
-func (p *proxy) UniswapUpdateLastKnownSwapBlock(blkNumber uint64) error {
-	return p.db.UniswapUpdateLastKnownSwapBlock(blkNumber)
+func (p *proxy) UniswapUpdateLastKnownSwapBlock(ctx context.Context, blkNumber uint64) error {
+	return p.db.UniswapUpdateLastKnownSwapBlock(ctx, blkNumber)
 }
 
 // UniswapFactoryContract returns an instance of an Uniswap factory
-func (p *proxy) UniswapFactoryContract() (*contracts.UniswapFactory, error) {
+func (p *proxy) UniswapFactoryContract(ctx context.Context) (*contracts.UniswapFactory, error) {
 	return p.rpc.UniswapFactoryContract()
 }
 
 // UniswapVolume returns swap volume for specified uniswap pair
 // If toTime = 0, then it resolves volumes till now
-func (p *proxy) UniswapVolume(pairAddress *common.Address, fromTime int64, toTime int64) (types.DefiSwapVolume, error) {
-	return p.db.UniswapVolume(pairAddress, fromTime, toTime)
+func (p *proxy) UniswapVolume(ctx context.Context, pairAddress *common.Address, fromTime int64, toTime int64) (types.DefiSwapVolume, error) {
+	return p.db.UniswapVolume(ctx, pairAddress, fromTime, toTime)
 }
 
 // UniswapTimeVolumes returns daily swap volume for specified uniswap pair and period of time
 // If toTime = 0, then it resolves volumes till now
-func (p *proxy) UniswapTimeVolumes(pairAddress *common.Address, resolution string, fromTime int64, toTime int64) ([]types.DefiSwapVolume, error) {
-	return p.db.UniswapTimeVolumes(pairAddress, resolution, fromTime, toTime)
+func (p *proxy) UniswapTimeVolumes(ctx context.Context, pairAddress *common.Address, resolution string, fromTime int64, toTime int64) ([]types.DefiSwapVolume, error) {
+	return p.db.UniswapTimeVolumes(ctx, pairAddress, resolution, fromTime, toTime)
 }
 
 // UniswapTimePrices resolves price of swap trades for specified pair grouped by date interval.
 // If toTime is 0, then it calculates prices till now
-func (p *proxy) UniswapTimePrices(pairAddress *common.Address, resolution string, fromTime int64, toTime int64, direction int32) ([]types.DefiTimePrice, error) {
-	return p.db.UniswapTimePrices(pairAddress, resolution, fromTime, toTime, direction)
+func (p *proxy) UniswapTimePrices(ctx context.Context, pairAddress *common.Address, resolution string, fromTime int64, toTime int64, direction int32) ([]types.DefiTimePrice, error) {
+	return p.db.UniswapTimePrices(ctx, pairAddress, resolution, fromTime, toTime, direction)
 }
 
 // UniswapTimeReserves resolves reserves of uniswap trades for specified pair grouped by date interval.
 // If toTime is 0, then it calculates prices till now
-func (p *proxy) UniswapTimeReserves(pairAddress *common.Address, resolution string, fromTime int64, toTime int64) ([]types.DefiTimeReserve, error) {
-	return p.db.UniswapTimeReserves(pairAddress, resolution, fromTime, toTime)
+func (p *proxy) UniswapTimeReserves(ctx context.Context, pairAddress *common.Address, resolution string, fromTime int64, toTime int64) ([]types.DefiTimeReserve, error) {
+	return p.db.UniswapTimeReserves(ctx, pairAddress, resolution, fromTime, toTime)
 }
 
 // UniswapActions provides list of uniswap actions stored in the persistent storage.
-func (p *proxy) UniswapActions(pairAddress *common.Address, cursor *string, count int32, actionType int32) (*types.UniswapActionList, error) {
-	return p.db.UniswapActions(pairAddress, cursor, count, actionType)
+func (p *proxy) UniswapActions(ctx context.Context, pairAddress *common.Address, cursor *string, count int32, actionType int32) (*types.UniswapActionList, error) {
+	return p.db.UniswapActions(ctx, pairAddress, cursor, count, actionType)
 }
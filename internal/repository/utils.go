@@ -9,6 +9,7 @@ results. BigCache for in-memory object storage to speed up loading of frequently
 package repository
 
 import (
+	"context"
 	"encoding/json"
 	"fantom-api-graphql/internal/types"
 	"fmt"
@@ -32,37 +33,67 @@ const (
 )
 
 // GasPrice pulls the current amount of WEI for single Gas.
-func (p *proxy) GasPrice() (hexutil.Big, error) {
-	return p.rpc.GasPrice()
+func (p *proxy) GasPrice(ctx context.Context) (hexutil.Big, error) {
+	return p.rpc.GasPrice(ctx)
 }
 
 // GasPriceExtended provides extended gas price information.
-func (p *proxy) GasPriceExtended() (*types.GasPrice, error) {
-	// get the current gas price
-	gp, err := p.rpc.GasPrice()
+func (p *proxy) GasPriceExtended(ctx context.Context) (*types.GasPrice, error) {
+	// get the current gas price suggestion
+	gps, err := p.GasPriceSuggestion(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// calculate the gas price in Gwei units
-	gWei := math.Round(float64(gp.ToInt().Int64())/float64(10000000)) / 10.0
 	return &types.GasPrice{
-		Fast:    gWei,
-		Fastest: gWei,
-		SafeLow: gWei,
-		Average: gWei,
+		Fast:    gWei(&gps.Fast),
+		Fastest: gWei(&gps.Fast),
+		SafeLow: gWei(&gps.Slow),
+		Average: gWei(&gps.Standard),
 	}, nil
 }
 
+// gWei converts the given gas price in WEI units to GWei units rounded to single decimal place.
+func gWei(val *hexutil.Big) float64 {
+	return math.Round(float64(val.ToInt().Int64())/float64(10000000)) / 10.0
+}
+
+// GasPriceSuggestion provides the gas price suggestion calculated from recent transactions.
+// If the suggestion is not available, the raw suggested value is used for all the levels.
+func (p *proxy) GasPriceSuggestion(ctx context.Context) (*types.GasPriceSuggestion, error) {
+	// try the calculated suggestion first
+	if gps := p.cache.PullGasPriceSuggestion(); gps != nil {
+		return gps, nil
+	}
+
+	// get the current gas price
+	gp, err := p.rpc.GasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GasPriceSuggestion{
+		Slow:     gp,
+		Standard: gp,
+		Fast:     gp,
+		Updated:  hexutil.Uint64(time.Now().Unix()),
+	}, nil
+}
+
+// StoreGasPriceSuggestion keeps the calculated gas price suggestion for future use.
+func (p *proxy) StoreGasPriceSuggestion(ctx context.Context, gps *types.GasPriceSuggestion) error {
+	return p.cache.PushGasPriceSuggestion(gps)
+}
+
 // GasEstimate calculates the estimated amount of Gas required to perform
 // transaction described by the input params.
-func (p *proxy) GasEstimate(trx *struct {
+func (p *proxy) GasEstimate(ctx context.Context, trx *struct {
 	From  *common.Address
 	To    *common.Address
 	Value *hexutil.Big
 	Data  *string
 }) (*hexutil.Uint64, error) {
-	return p.rpc.GasEstimate(trx)
+	return p.rpc.GasEstimate(ctx, trx)
 }
 
 // isValidPriceSymbol checks if the requested symbol is a valid price symbol we support
@@ -77,7 +108,7 @@ func (p *proxy) isValidPriceSymbol(sym string) bool {
 }
 
 // Price returns a price information for the given target symbol.
-func (p *proxy) Price(sym string) (types.Price, error) {
+func (p *proxy) Price(ctx context.Context, sym string) (types.Price, error) {
 	// check the symbol validity
 	if !p.isValidPriceSymbol(sym) {
 		return types.Price{}, fmt.Errorf("unknown price symbol requested")
@@ -90,6 +121,7 @@ func (p *proxy) Price(sym string) (types.Price, error) {
 	if pri := p.cache.PullPrice(sym); pri != nil {
 		// inform what we do
 		p.log.Infof("price [%s] loaded from cache", sym)
+		pri.IsStale = p.isStalePrice(pri)
 		return *pri, nil
 	}
 
@@ -103,9 +135,32 @@ func (p *proxy) Price(sym string) (types.Price, error) {
 
 	// inform what we do
 	p.log.Infof("price [%s] obtained from an API request", sym)
+	pri.IsStale = p.isStalePrice(&pri)
 	return pri, nil
 }
 
+// isStalePrice checks if the given price has not been updated
+// for longer than the configured max price age.
+func (p *proxy) isStalePrice(pri *types.Price) bool {
+	maxAge := p.cfg.Tunable().PriceMaxAge
+	if maxAge <= 0 {
+		return false
+	}
+	return time.Since(time.Unix(int64(pri.LastUpdate), 0)) > maxAge
+}
+
+// priceOracle returns the address of the price oracle configured
+// for the given target symbol, if any.
+func (p *proxy) priceOracle(sym string) *common.Address {
+	for ps, adr := range p.cfg.DeFi.PriceOracles {
+		if strings.EqualFold(ps, sym) {
+			oracle := adr
+			return &oracle
+		}
+	}
+	return nil
+}
+
 // requestPrice requests the price from an external 3rd party API
 // inside a request group.
 func (p *proxy) requestPrice(sym string) (types.Price, error) {
@@ -149,6 +204,15 @@ func getPriceApiUrl(sym string) string {
 // requestRemotePrice pulls the price for given symbol from an external API
 // and ensures the result, if valid, is stored in cache for future use
 func (p *proxy) requestRemotePrice(sym string) (types.Price, error) {
+	// prefer the on-chain price oracle, if configured
+	if oracle := p.priceOracle(sym); oracle != nil {
+		pri, err := p.makeOraclePrice(context.Background(), oracle, sym)
+		if err == nil {
+			return pri, p.cache.PushPrice(sym, &pri)
+		}
+		p.log.Errorf("price oracle [%s] not available; %s", sym, err.Error())
+	}
+
 	// make the request tpo remote API
 	pri, err := p.makePriceRequest(sym)
 	if err != nil {
@@ -166,6 +230,23 @@ func (p *proxy) requestRemotePrice(sym string) (types.Price, error) {
 	return pri, nil
 }
 
+// makeOraclePrice pulls the price for given symbol from an on-chain price oracle.
+func (p *proxy) makeOraclePrice(ctx context.Context, oracle *common.Address, sym string) (types.Price, error) {
+	val, updated, err := p.rpc.OraclePrice(ctx, oracle)
+	if err != nil {
+		return types.Price{}, err
+	}
+
+	// inform what we got here
+	p.log.Infof("oracle price loaded: %s -> %s = %f", ownPriceSymbol, sym, val)
+	return types.Price{
+		FromSymbol: ownPriceSymbol,
+		ToSymbol:   strings.ToUpper(sym),
+		Price:      val,
+		LastUpdate: updated,
+	}, nil
+}
+
 // makePriceRequest executes a request to remote API to pull the price
 // and return the result from the pull.
 func (p *proxy) makePriceRequest(sym string) (types.Price, error) {
@@ -0,0 +1,132 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"time"
+)
+
+// WarmUp preloads the frequently accessed entities into the cache, so the API server
+// does not hit the node with a burst of calls right after it starts to serve requests.
+// Failures are logged and skipped, the cache is simply filled on demand later.
+func (p *proxy) WarmUp(cfg *config.CacheWarmUp) {
+	start := time.Now()
+	p.log.Notice("warming up the cache")
+
+	if cfg.Blocks > 0 {
+		p.warmUpBlocks(context.Background(), cfg.Blocks)
+	}
+	if cfg.Accounts > 0 {
+		p.warmUpAccounts(context.Background(), cfg.Accounts)
+	}
+	if cfg.Tokens > 0 {
+		p.warmUpTokens(context.Background(), cfg.Tokens)
+	}
+	if cfg.Stakers {
+		p.warmUpStakers(context.Background())
+	}
+
+	p.log.Noticef("cache warmed up in %s", time.Since(start))
+}
+
+// warmUpBlocks preloads the given number of the latest blocks
+// into the block cache and the recent blocks list.
+func (p *proxy) warmUpBlocks(ctx context.Context, count int) {
+	top, err := p.BlockHeight(ctx)
+	if err != nil {
+		p.log.Errorf("can not warm up blocks; %s", err.Error())
+		return
+	}
+
+	head, from := top.ToInt().Uint64(), uint64(0)
+	if head >= uint64(count) {
+		from = head - uint64(count) + 1
+	}
+
+	list, err := p.BlocksByNumber(ctx, from, int(head-from+1))
+	if err != nil {
+		p.log.Errorf("can not warm up blocks; %s", err.Error())
+		return
+	}
+
+	// the recent blocks list expects the blocks in the chain order
+	for _, blk := range list {
+		if blk != nil {
+			p.cache.AddBlock(blk)
+		}
+	}
+	p.log.Debugf("%d blocks preloaded", len(list))
+}
+
+// warmUpAccounts preloads the given number of the accounts with the highest balance.
+func (p *proxy) warmUpAccounts(ctx context.Context, count int32) {
+	bl, err := p.db.AccountsByBalance(ctx, 0, count)
+	if err != nil {
+		p.log.Errorf("can not warm up accounts; %s", err.Error())
+		return
+	}
+
+	adr := make([]*common.Address, len(bl))
+	for i, b := range bl {
+		adr[i] = &b.Address
+	}
+
+	if _, err := p.Accounts(ctx, adr); err != nil {
+		p.log.Errorf("can not warm up accounts; %s", err.Error())
+		return
+	}
+	p.log.Debugf("%d accounts preloaded", len(adr))
+}
+
+// warmUpTokens preloads the given number of the most active ERC20 tokens.
+func (p *proxy) warmUpTokens(ctx context.Context, count int32) {
+	list, err := p.db.Erc20TokensList(ctx, count)
+	if err != nil {
+		p.log.Errorf("can not warm up tokens; %s", err.Error())
+		return
+	}
+
+	for i := range list {
+		if _, err := p.Erc20Token(ctx, &list[i]); err != nil {
+			p.log.Warningf("can not warm up token %s; %s", list[i].String(), err.Error())
+		}
+	}
+	p.log.Debugf("%d tokens preloaded", len(list))
+}
+
+// warmUpStakers preloads the addresses and the extended information of the validators.
+func (p *proxy) warmUpStakers(ctx context.Context) {
+	last, err := p.LastValidatorId(ctx)
+	if err != nil {
+		p.log.Errorf("can not warm up stakers; %s", err.Error())
+		return
+	}
+
+	for id := uint64(1); id <= last; id++ {
+		valID := (*hexutil.Big)(new(big.Int).SetUint64(id))
+		if _, err := p.ValidatorAddress(ctx, valID); err != nil {
+			p.log.Warningf("can not warm up validator #%d; %s", id, err.Error())
+			continue
+		}
+
+		// the extended information is optional
+		if p.RetrieveStakerInfo(ctx, valID) != nil {
+			continue
+		}
+		if sti, err := p.PullStakerInfo(ctx, valID); err == nil && sti != nil {
+			_ = p.StoreStakerInfo(ctx, valID, sti)
+		}
+	}
+	p.log.Debugf("%d stakers preloaded", last)
+}
@@ -0,0 +1,75 @@
+/*
+Package repository implements repository for handling fast and efficient access to data required
+by the resolvers of the API server.
+
+Internally it utilizes RPC to access Opera/Lachesis full node for blockchain interaction. Mongo database
+for fast, robust and scalable off-chain data storage, especially for aggregated and pre-calculated data mining
+results. BigCache for in-memory object storage to speed up loading of frequently accessed entities.
+*/
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"time"
+)
+
+const (
+	// watchIdSize represents the number of random bytes of a new watch identifier.
+	watchIdSize = 12
+
+	// watchSecretSize represents the number of random bytes of a new watch signature secret.
+	watchSecretSize = 32
+)
+
+// CreateWatch registers a new webhook notified about the given events of the address.
+// The watch is owned by the client of the given API key hash.
+func (p *proxy) CreateWatch(ctx context.Context, owner string, addr *common.Address, url string, events []string) (*types.Watch, error) {
+	id, err := randomHex(watchIdSize)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := randomHex(watchSecretSize)
+	if err != nil {
+		return nil, err
+	}
+
+	w := types.Watch{
+		Id:      id,
+		Owner:   owner,
+		Address: addr.String(),
+		Url:     url,
+		Secret:  secret,
+		Events:  events,
+		Created: time.Now().UTC(),
+	}
+	if err := p.db.AddWatch(ctx, &w); err != nil {
+		return nil, err
+	}
+
+	p.log.Noticef("watch %s of %s registered", id, w.Address)
+	return &w, nil
+}
+
+// RemoveWatch removes the watch of the given id, optionally owned by the given client only.
+// It returns FALSE if no such watch is known.
+func (p *proxy) RemoveWatch(ctx context.Context, id string, owner *string) (bool, error) {
+	return p.db.RemoveWatch(ctx, id, owner)
+}
+
+// Watches provides the list of address watches, optionally owned by the given client only.
+func (p *proxy) Watches(ctx context.Context, owner *string) ([]*types.Watch, error) {
+	return p.db.Watches(ctx, owner)
+}
+
+// randomHex provides a random hex encoded string of the given number of bytes.
+func randomHex(size int) (string, error) {
+	raw := make([]byte, size)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
@@ -0,0 +1,50 @@
+// Package stream implements publishers of the processed blockchain data
+// to the external message brokers, e.g. Kafka, or NATS.
+package stream
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/segmentio/kafka-go"
+	"time"
+)
+
+// kafkaBatchTimeout represents the max time messages wait for a batch to be filled.
+const kafkaBatchTimeout = 10 * time.Millisecond
+
+// kafkaPublisher implements the publisher of messages to a Kafka cluster.
+type kafkaPublisher struct {
+	log    logger.Logger
+	writer *kafka.Writer
+}
+
+// newKafkaPublisher creates a new publisher writing to the configured Kafka brokers.
+// Messages are balanced across topic partitions by their keys.
+func newKafkaPublisher(cfg *config.Stream, log logger.Logger) *kafkaPublisher {
+	log.Noticef("streaming to Kafka at %v", cfg.Brokers)
+	return &kafkaPublisher{
+		log: log,
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(cfg.Brokers...),
+			Balancer:               &kafka.Hash{},
+			BatchTimeout:           kafkaBatchTimeout,
+			RequiredAcks:           kafka.RequireOne,
+			AllowAutoTopicCreation: true,
+		},
+	}
+}
+
+// Publish sends the message to the given Kafka topic.
+func (kp *kafkaPublisher) Publish(topic string, key string, data []byte) error {
+	return kp.writer.WriteMessages(context.Background(), kafka.Message{
+		Topic: topic,
+		Key:   []byte(key),
+		Value: data,
+	})
+}
+
+// Close flushes pending messages and closes the Kafka writer.
+func (kp *kafkaPublisher) Close() error {
+	return kp.writer.Close()
+}
@@ -0,0 +1,49 @@
+// Package stream implements publishers of the processed blockchain data
+// to the external message brokers, e.g. Kafka, or NATS.
+package stream
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"github.com/nats-io/nats.go"
+	"strings"
+)
+
+// natsPublisher implements the publisher of messages to a NATS server.
+type natsPublisher struct {
+	log  logger.Logger
+	conn *nats.Conn
+}
+
+// newNatsPublisher connects the configured NATS servers.
+func newNatsPublisher(cfg *config.Stream, log logger.Logger) (*natsPublisher, error) {
+	conn, err := nats.Connect(strings.Join(cfg.Brokers, ","),
+		nats.Name(cfg.Prefix+"publisher"),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if err != nil {
+				log.Warningf("NATS connection lost; %s", err.Error())
+			}
+		}),
+		nats.ReconnectHandler(func(c *nats.Conn) {
+			log.Noticef("NATS connection restored to %s", c.ConnectedUrl())
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Noticef("streaming to NATS at %s", conn.ConnectedUrl())
+	return &natsPublisher{log: log, conn: conn}, nil
+}
+
+// Publish sends the message to the given NATS subject.
+// NATS subjects keep the order of publishing, the key is not used.
+func (np *natsPublisher) Publish(topic string, _ string, data []byte) error {
+	return np.conn.Publish(topic, data)
+}
+
+// Close flushes pending messages and closes the NATS connection.
+func (np *natsPublisher) Close() error {
+	return np.conn.Drain()
+}
@@ -0,0 +1,42 @@
+// Package stream implements publishers of the processed blockchain data
+// to the external message brokers, e.g. Kafka, or NATS.
+package stream
+
+import (
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fmt"
+)
+
+const (
+	// TypeKafka represents the Kafka message broker.
+	TypeKafka = "kafka"
+
+	// TypeNats represents the NATS message broker.
+	TypeNats = "nats"
+)
+
+// Publisher represents a publisher of messages to a message broker.
+type Publisher interface {
+	// Publish sends the message to the given topic. Messages with the same key
+	// are delivered in the order of publishing, if the broker supports it.
+	Publish(topic string, key string, data []byte) error
+
+	// Close flushes pending messages and closes the broker connection.
+	Close() error
+}
+
+// New creates a publisher of the configured message broker type.
+func New(cfg *config.Stream, log logger.Logger) (Publisher, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, fmt.Errorf("no stream broker configured")
+	}
+
+	switch cfg.Type {
+	case TypeKafka:
+		return newKafkaPublisher(cfg, log), nil
+	case TypeNats:
+		return newNatsPublisher(cfg, log)
+	}
+	return nil, fmt.Errorf("unknown stream type %s", cfg.Type)
+}
@@ -0,0 +1,88 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"sync"
+)
+
+// checkpointMaxPending represents the max number of completed blocks waiting
+// for a missing block before the missing block is considered skipped.
+const checkpointMaxPending = 10000
+
+// checkpoint tracks the last block fully processed by the dispatchers;
+// a block is fully processed if all the blocks below it are processed, too.
+type checkpoint struct {
+	sync.Mutex
+	top     uint64
+	pending map[uint64]bool
+}
+
+// newCheckpoint creates a new checkpoint tracker starting at the given block.
+func newCheckpoint(top uint64) *checkpoint {
+	return &checkpoint{
+		top:     top,
+		pending: make(map[uint64]bool),
+	}
+}
+
+// last provides the number of the last fully processed block.
+func (cp *checkpoint) last() uint64 {
+	cp.Lock()
+	defer cp.Unlock()
+	return cp.top
+}
+
+// complete marks the given block as processed and advances the checkpoint, if possible.
+func (cp *checkpoint) complete(bn uint64) {
+	cp.Lock()
+	defer cp.Unlock()
+
+	// already behind us?
+	if bn <= cp.top {
+		return
+	}
+	cp.pending[bn] = true
+
+	// a block we wait for has never been dispatched? skip to the lowest completed one
+	if len(cp.pending) > checkpointMaxPending {
+		low := bn
+		for n := range cp.pending {
+			if n < low {
+				low = n
+			}
+		}
+		log.Warningf("blocks #%d to #%d not processed, checkpoint skips to #%d", cp.top+1, low-1, low)
+		cp.top = low - 1
+	}
+
+	// advance through the contiguous range of completed blocks
+	for cp.pending[cp.top+1] {
+		delete(cp.pending, cp.top+1)
+		cp.top++
+	}
+}
+
+// rewind moves the checkpoint back to the given block so the blocks above it
+// have to be processed again.
+func (cp *checkpoint) rewind(bn uint64) {
+	cp.Lock()
+	defer cp.Unlock()
+
+	if bn < cp.top {
+		cp.top = bn
+	}
+	for n := range cp.pending {
+		if n > bn {
+			delete(cp.pending, n)
+		}
+	}
+}
+
+// reset sets the checkpoint to the given block dropping any pending state.
+func (cp *checkpoint) reset(bn uint64) {
+	cp.Lock()
+	defer cp.Unlock()
+
+	cp.top = bn
+	cp.pending = make(map[uint64]bool)
+}
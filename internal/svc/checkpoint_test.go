@@ -0,0 +1,154 @@
+package svc
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/onsi/gomega"
+	"testing"
+)
+
+// testLogger provides a logger of the tests reporting only critical failures.
+func testLogger() logger.Logger {
+	return logger.New(&config.Config{AppName: "test", Log: config.Log{Level: "CRITICAL", Format: "%{message}"}})
+}
+
+// testChainRepo represents a repository of a chain with the given number of transactions
+// in each block and the given number of transactions stored by blocks.
+// Blocks out of the chain are not available.
+type testChainRepo struct {
+	repository.Repository
+	chain  map[uint64]int
+	stored map[uint64]int
+	err    error
+}
+
+// TransactionsCountByBlock provides the number of stored transactions of blocks in the given range.
+func (r *testChainRepo) TransactionsCountByBlock(_ context.Context, from uint64, to uint64) (map[uint64]int, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	res := make(map[uint64]int)
+	for bn, cnt := range r.stored {
+		if bn >= from && bn <= to {
+			res[bn] = cnt
+		}
+	}
+	return res, nil
+}
+
+// BlockByNumber provides a block of the chain with the configured number of transactions.
+func (r *testChainRepo) BlockByNumber(_ context.Context, num *hexutil.Uint64) (*types.Block, error) {
+	cnt, ok := r.chain[uint64(*num)]
+	if !ok {
+		return nil, repository.ErrBlockNotFound
+	}
+
+	txs := make([]*common.Hash, cnt)
+	for i := range txs {
+		txs[i] = &common.Hash{}
+	}
+	return &types.Block{Number: *num, Txs: txs}, nil
+}
+
+// completeRange marks the given range of blocks as processed.
+func completeRange(from uint64, to uint64) func(*checkpoint) {
+	return func(cp *checkpoint) {
+		for n := from; n <= to; n++ {
+			cp.complete(n)
+		}
+	}
+}
+
+func TestCheckpoint(t *testing.T) {
+	log = testLogger()
+
+	complete := func(bn ...uint64) func(*checkpoint) {
+		return func(cp *checkpoint) {
+			for _, n := range bn {
+				cp.complete(n)
+			}
+		}
+	}
+	rewind := func(bn uint64) func(*checkpoint) {
+		return func(cp *checkpoint) { cp.rewind(bn) }
+	}
+	reset := func(bn uint64) func(*checkpoint) {
+		return func(cp *checkpoint) { cp.reset(bn) }
+	}
+
+	tests := []struct {
+		name string
+		top  uint64
+		ops  []func(*checkpoint)
+		want uint64
+	}{
+		{name: "nothing processed", top: 100, want: 100},
+		{name: "in order", top: 100, ops: []func(*checkpoint){complete(101, 102, 103)}, want: 103},
+		{name: "out of order", top: 100, ops: []func(*checkpoint){complete(103, 101, 102)}, want: 103},
+		{name: "gap waits", top: 100, ops: []func(*checkpoint){complete(101, 103, 104)}, want: 101},
+		{name: "gap filled", top: 100, ops: []func(*checkpoint){complete(101, 103, 104), complete(102)}, want: 104},
+		{name: "repeated blocks", top: 100, ops: []func(*checkpoint){complete(101, 101, 102, 102)}, want: 102},
+		{name: "blocks below the top", top: 100, ops: []func(*checkpoint){complete(50, 100, 101)}, want: 101},
+		{name: "rewind", top: 100, ops: []func(*checkpoint){complete(101, 102, 103), rewind(101)}, want: 101},
+		{name: "rewind drops pending above", top: 100, ops: []func(*checkpoint){complete(102, 103), rewind(101), complete(101)}, want: 101},
+		{name: "rewind keeps pending below", top: 100, ops: []func(*checkpoint){complete(102, 105), rewind(103), complete(101)}, want: 102},
+		{name: "rewind above the top", top: 100, ops: []func(*checkpoint){rewind(200)}, want: 100},
+		{name: "reset", top: 100, ops: []func(*checkpoint){complete(102, 103), reset(50), complete(51)}, want: 51},
+		{name: "missing block skipped", top: 100, ops: []func(*checkpoint){completeRange(102, 102+checkpointMaxPending)}, want: 102 + checkpointMaxPending},
+		{name: "missing block waited for", top: 100, ops: []func(*checkpoint){completeRange(102, 101+checkpointMaxPending)}, want: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			cp := newCheckpoint(tt.top)
+			for _, op := range tt.ops {
+				op(cp)
+			}
+			g.Expect(cp.last()).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestBlkScannerVerify(t *testing.T) {
+	log = testLogger()
+	defer func() {
+		repo = nil
+	}()
+
+	chain := map[uint64]int{10: 2, 11: 0, 12: 1, 13: 3}
+	tests := []struct {
+		name   string
+		stored map[uint64]int
+		chain  map[uint64]int
+		err    error
+		want   uint64
+	}{
+		{name: "all stored", stored: map[uint64]int{10: 2, 12: 1, 13: 3}, chain: chain, want: 14},
+		{name: "incomplete block", stored: map[uint64]int{10: 2, 12: 0, 13: 3}, chain: chain, want: 12},
+		{name: "missing block", stored: map[uint64]int{10: 2, 13: 3}, chain: chain, want: 12},
+		{name: "last block incomplete", stored: map[uint64]int{10: 2, 12: 1, 13: 2}, chain: chain, want: 13},
+		{name: "more stored than on chain", stored: map[uint64]int{10: 3, 12: 1, 13: 3}, chain: chain, want: 10},
+		{name: "block not on chain", stored: map[uint64]int{10: 2}, chain: map[uint64]int{10: 2, 11: 0}, want: 12},
+		{name: "counts not available", chain: chain, err: fmt.Errorf("database down"), want: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			repo = &testChainRepo{chain: tt.chain, stored: tt.stored, err: tt.err}
+			bn, err := (&blkScanner{}).verify(10, 13)
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(bn).To(gomega.Equal(tt.want))
+		})
+	}
+}
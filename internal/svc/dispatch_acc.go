@@ -2,10 +2,12 @@
 package svc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/repository/rpc/contracts"
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
+	"sync"
 )
 
 const (
@@ -13,13 +15,17 @@ const (
 	// SFC contract, above this block the contract should already be known, and we can
 	// skip the check
 	sfcCheckBelowBlock = 100000
+
+	// accBalanceBatchSize represents the max number of accounts
+	// with balances read in a single batch
+	accBalanceBatchSize = 50
 )
 
 // testAddress represents an address used to test an account reference
 var testAddress = common.HexToAddress("0xabc00FA001230012300aBc0012300Fa00FACE000")
 
-var erc721InterfaceId  = [4]byte { 0x80, 0xac, 0x58, 0xcd } // ERC-721: 0x80ac58cd
-var erc1155InterfaceId = [4]byte { 0xd9, 0xb6, 0x7a, 0x26 } // ERC-1155: 0xd9b67a26
+var erc721InterfaceId = [4]byte{0x80, 0xac, 0x58, 0xcd}  // ERC-721: 0x80ac58cd
+var erc1155InterfaceId = [4]byte{0xd9, 0xb6, 0x7a, 0x26} // ERC-1155: 0xd9b67a26
 
 // accDispatcher implements account dispatcher queue
 type accDispatcher struct {
@@ -42,13 +48,30 @@ func (acd *accDispatcher) run() {
 	// signal orchestrator we started and go
 	acd.mgr.started(acd)
 	go acd.execute()
+	go acd.indexTokenSymbols()
+}
+
+// indexTokenSymbols stores the symbols of the ERC20 tokens registered
+// before the symbols have been kept with the token contracts.
+func (acd *accDispatcher) indexTokenSymbols() {
+	if err := repo.Erc20IndexSymbols(context.Background()); err != nil {
+		log.Errorf("can not index ERC20 token symbols; %s", err.Error())
+	}
 }
 
 // execute runs the main account requests monitor and dispatcher
 // loop in a separate thread.
 func (acd *accDispatcher) execute() {
+	// start the account processing workers
+	lanes, wg := acd.spawn()
+
 	// don't forget to sign off after we are done
 	defer func() {
+		for _, ln := range lanes {
+			close(ln)
+		}
+		wg.Wait()
+
 		close(acd.sigStop)
 		acd.mgr.finished(acd)
 	}()
@@ -65,16 +88,90 @@ func (acd *accDispatcher) execute() {
 				return
 			}
 
+			// route the account to its worker; the same account is never processed in parallel
+			select {
+			case lanes[laneOf(acc.addr, len(lanes))] <- acc:
+			case <-acd.sigStop:
+				return
+			}
+		}
+	}
+}
+
+// spawn starts the account processing workers and provides their input lanes.
+func (acd *accDispatcher) spawn() ([]chan *eventAcc, *sync.WaitGroup) {
+	lanes := make([]chan *eventAcc, scanWorkers())
+	wg := new(sync.WaitGroup)
+
+	for i := range lanes {
+		lanes[i] = make(chan *eventAcc, workerLaneCapacity)
+		wg.Add(1)
+		go acd.worker(lanes[i], wg)
+	}
+	return lanes, wg
+}
+
+// worker processes accounts of the given lane until the lane is closed.
+// Accounts waiting in the lane are processed together, so the balances
+// of new accounts can be read in a single batch.
+func (acd *accDispatcher) worker(lane chan *eventAcc, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for acc := range lane {
+		batch := acd.collect(acc, lane)
+		for _, ac := range batch {
 			// do the stuff
-			err := acd.process(acc)
+			err := acd.process(ac)
 			if err != nil {
-				log.Errorf("failed account %s processing; %s", acc.addr.String(), err.Error())
+				log.Errorf("failed account %s processing; %s", ac.addr.String(), err.Error())
+			}
+		}
+
+		// read the balance of new accounts for the rich list
+		acd.seedBalances(batch)
+
+		// signal the accounts have been processed
+		for _, ac := range batch {
+			ac.watchDog.Done()
+		}
+	}
+}
+
+// collect pulls accounts already waiting in the lane to be processed
+// together with the given account.
+func (acd *accDispatcher) collect(acc *eventAcc, lane chan *eventAcc) []*eventAcc {
+	batch := make([]*eventAcc, 1, accBalanceBatchSize)
+	batch[0] = acc
+
+	for len(batch) < accBalanceBatchSize {
+		select {
+		case ac, ok := <-lane:
+			if !ok {
+				return batch
 			}
+			batch = append(batch, ac)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
 
-			// signal this account has been processed
-			acc.watchDog.Done()
+// seedBalances reads the balances of the given accounts not known yet; the known balances
+// are kept up-to-date by the balance changes of the processed transactions.
+func (acd *accDispatcher) seedBalances(batch []*eventAcc) {
+	adr := make([]*common.Address, 0, len(batch))
+	known := make(map[common.Address]bool, len(batch))
+	for _, ac := range batch {
+		if !known[*ac.addr] {
+			known[*ac.addr] = true
+			adr = append(adr, ac.addr)
 		}
 	}
+
+	if err := repo.AccountsSeedBalance(context.Background(), adr); err != nil {
+		log.Errorf("can not read balance of %d accounts; %s", len(adr), err.Error())
+	}
 }
 
 // processAccount processes account into the database
@@ -84,8 +181,8 @@ func (acd *accDispatcher) process(acc *eventAcc) error {
 	log.Debugf("account %s received for processing", acc.addr.String())
 
 	// check if the account is new; if we already know it, we are done
-	if repo.AccountIsKnown(acc.addr) {
-		return repo.AccountMarkActivity(acc.addr, uint64(acc.blk.TimeStamp))
+	if repo.AccountIsKnown(context.Background(), acc.addr) {
+		return repo.AccountMarkActivity(context.Background(), acc.addr, uint64(acc.blk.TimeStamp))
 	}
 
 	// is this a simple wallet/account?
@@ -108,12 +205,14 @@ func (acd *accDispatcher) wallet(acc *eventAcc) error {
 	acd.checkSfc(acc)
 
 	// add the account into the database
-	err := repo.StoreAccount(&types.Account{
+	err := repo.StoreAccount(context.Background(), &types.Account{
 		Address:      *acc.addr,
 		ContractTx:   acc.deploy,
 		Type:         acc.act,
 		LastActivity: acc.blk.TimeStamp,
 		TrxCounter:   1,
+		FirstBlock:   acc.blk.Number,
+		FirstSeen:    acc.blk.TimeStamp,
 	})
 	if err != nil {
 		log.Errorf("can not add account %s; %s", acc.addr.String(), err.Error())
@@ -125,18 +224,18 @@ func (acd *accDispatcher) wallet(acc *eventAcc) error {
 // and if so, it adds the SFC target with a different type.
 func (acd *accDispatcher) checkSfc(acc *eventAcc) {
 	// act on SFC detection
-	if uint64(acc.blk.Number) < sfcCheckBelowBlock && repo.IsSfcContract(acc.addr) {
+	if uint64(acc.blk.Number) < sfcCheckBelowBlock && repo.IsSfcContract(context.Background(), acc.addr) {
 		// change the type to SFC contract
 		acc.act = types.AccountTypeSFC
 
 		// get the SFC version
-		ver, err := repo.SfcVersion()
+		ver, err := repo.SfcVersion(context.Background())
 		if err == nil {
 			// log what we found
 			log.Debugf("detected SFC contract %d.%d.%d", byte((ver>>16)&255), byte((ver>>8)&255), byte(ver&255))
 
 			// add the contract
-			err = repo.StoreContract(types.NewSfcContract(acc.addr, uint64(ver), acc.blk, acc.trx))
+			err = repo.StoreContract(context.Background(), types.NewSfcContract(acc.addr, uint64(ver), acc.blk, acc.trx))
 			if err != nil {
 				log.Errorf("can not add the SFC contract at %s; %s", acc.addr.String(), err.Error())
 			}
@@ -159,7 +258,7 @@ func (acd *accDispatcher) processContract(acc *eventAcc) error {
 
 	// insert the contract record if possible
 	if contract != nil {
-		err = repo.StoreContract(contract)
+		err = repo.StoreContract(context.Background(), contract)
 		if err != nil {
 			log.Errorf("can not add contract at %s; %s", acc.addr.String(), err.Error())
 			return err
@@ -171,7 +270,7 @@ func (acd *accDispatcher) processContract(acc *eventAcc) error {
 // detectContract tries to identify the contract type.
 func (acd *accDispatcher) detectContract(addr *common.Address, block *types.Block, trx *types.Transaction) (*types.Contract, string, error) {
 
-	isErc1155, err := repo.Erc165SupportsInterface(addr, erc1155InterfaceId)
+	isErc1155, err := repo.Erc165SupportsInterface(context.Background(), addr, erc1155InterfaceId)
 	if err == nil && isErc1155 {
 		log.Noticef("ERC1155 multi-token detected at %s", addr.String())
 		contract := types.NewErcTokenContract(addr, "", block, trx, types.AccountTypeERC1155Contract, contracts.ERC1155MetaData.ABI)
@@ -185,10 +284,11 @@ func (acd *accDispatcher) detectContract(addr *common.Address, block *types.Bloc
 		return contract, types.AccountTypeERC721Contract, nil
 	}
 
-	isErc20, name := acd.detectErc20Token(addr)
+	isErc20, name, symbol := acd.detectErc20Token(addr)
 	if isErc20 {
 		log.Noticef("ERC20 token %s detected at %s", name, addr.String())
 		contract := types.NewErcTokenContract(addr, name, block, trx, types.AccountTypeERC20Token, contracts.ERCTwentyMetaData.ABI)
+		contract.Symbol = symbol
 		return contract, types.AccountTypeERC20Token, nil
 	}
 
@@ -200,39 +300,40 @@ func (acd *accDispatcher) detectContract(addr *common.Address, block *types.Bloc
 }
 
 // detectErc20Token identifies ERC20 token contracts by trying to call specific contract methods.
-func (acd *accDispatcher) detectErc20Token(addr *common.Address) (isErc20 bool, name string) {
+func (acd *accDispatcher) detectErc20Token(addr *common.Address) (isErc20 bool, name string, symbol string) {
 	// try to get the token name
-	name, err := repo.Erc20Name(addr)
+	name, err := repo.Erc20Name(context.Background(), addr)
 	if err != nil {
-		return false, ""
+		return false, "", ""
 	}
 
 	// try to detect symbol
-	if _, err := repo.Erc20Symbol(addr); err != nil {
-		return false, ""
+	symbol, err = repo.Erc20Symbol(context.Background(), addr)
+	if err != nil {
+		return false, "", ""
 	}
 
 	// try to detect balance of
-	if _, err := repo.Erc20BalanceOf(addr, &testAddress); err != nil {
-		return false, ""
+	if _, err := repo.Erc20BalanceOf(context.Background(), addr, &testAddress); err != nil {
+		return false, "", ""
 	}
 
 	// try to detect total supply
-	if _, err := repo.Erc20TotalSupply(addr); err != nil {
-		return false, ""
+	if _, err := repo.Erc20TotalSupply(context.Background(), addr); err != nil {
+		return false, "", ""
 	}
 
-	return true, name
+	return true, name, symbol
 }
 
 func (acd *accDispatcher) detectErc721Token(addr *common.Address) (isErc721 bool, name string) {
-	isErc721, err := repo.Erc165SupportsInterface(addr, erc721InterfaceId)
+	isErc721, err := repo.Erc165SupportsInterface(context.Background(), addr, erc721InterfaceId)
 	if err != nil || !isErc721 {
 		return false, ""
 	}
 
 	// try to detect name - but it is optional for ERC721
-	name, err = repo.Erc20Name(addr)
+	name, err = repo.Erc20Name(context.Background(), addr)
 	if err != nil {
 		return true, ""
 	}
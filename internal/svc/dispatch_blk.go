@@ -2,20 +2,43 @@
 package svc
 
 import (
+	"context"
+	"fantom-api-graphql/internal/metrics"
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"sync"
 	"time"
 )
 
 // trxBufferCapacity is the number of new packed transactions kept in the trx channel.
 const trxBufferCapacity = 50000
 
+// trxBatchSize represents the number of transactions loaded by a single RPC batch.
+const trxBatchSize = 20
+
+// blkReorgMaxDepth is the number of recently dispatched blocks kept
+// to detect and resolve a chain reorganization.
+const blkReorgMaxDepth = 64
+
+// blkCheckpointTick represents the period of the processed blocks checkpoint persistence.
+const blkCheckpointTick = 15 * time.Second
+
+// blkTtfMaxAge represents the max age of a block arriving to be considered received live;
+// older blocks are being scanned and their time to finality is not observed.
+const blkTtfMaxAge = 30 * time.Second
+
+// blkBurnShareBase represents the precision base of the burned share of transaction fees.
+const blkBurnShareBase = 1000000
+
 // eventTrx represents a packed transaction event
 // sent between block dispatcher and transaction dispatcher
 type eventTrx struct {
-	blk *types.Block
-	trx *types.Transaction
+	blk     *types.Block
+	trx     *types.Transaction
+	blkDone *sync.WaitGroup
 }
 
 // blockDispatcher implements a service responsible for processing new blocks on the blockchain.
@@ -23,8 +46,16 @@ type blockDispatcher struct {
 	service
 	onBlock        chan *types.Block
 	inBlock        chan *types.Block
+	inReindex      chan *types.Block
+	inReindexStart chan chan *types.Block
 	outTransaction chan *eventTrx
 	outDispatched  chan uint64
+	outStream      chan *types.Block
+	topBroadcast   uint64
+	recent         map[uint64]common.Hash
+	stamps         map[uint64]time.Time
+	checkpoint     *checkpoint
+	cpTick         *time.Ticker
 }
 
 // name returns the name of the service used by orchestrator.
@@ -35,8 +66,17 @@ func (bld *blockDispatcher) name() string {
 // init prepares the block dispatcher to perform its function.
 func (bld *blockDispatcher) init() {
 	bld.sigStop = make(chan bool, 1)
+	bld.inReindexStart = make(chan chan *types.Block, 1)
 	bld.outTransaction = make(chan *eventTrx, trxBufferCapacity)
 	bld.outDispatched = make(chan uint64, blsBlockBufferCapacity)
+	bld.recent = make(map[uint64]common.Hash, blkReorgMaxDepth)
+	bld.stamps = make(map[uint64]time.Time, blkReorgMaxDepth)
+	bld.checkpoint = newCheckpoint(0)
+
+	// the stream publisher is optional
+	if bld.mgr.pub != nil {
+		bld.outStream = make(chan *types.Block, pubQueueCapacity)
+	}
 }
 
 // run starts the block dispatcher
@@ -46,11 +86,24 @@ func (bld *blockDispatcher) run() {
 		panic(fmt.Errorf("no svc manager set on %s", bld.name()))
 	}
 
+	// start the checkpoint ticker
+	bld.cpTick = time.NewTicker(blkCheckpointTick)
+
 	// signal orchestrator we started and go
 	bld.mgr.started(bld)
 	go bld.execute()
 }
 
+// close terminates the block dispatcher.
+func (bld *blockDispatcher) close() {
+	if bld.cpTick != nil {
+		bld.cpTick.Stop()
+	}
+	if bld.sigStop != nil {
+		bld.sigStop <- true
+	}
+}
+
 // execute collects blocks from an input channel
 // and processes them.
 func (bld *blockDispatcher) execute() {
@@ -60,6 +113,9 @@ func (bld *blockDispatcher) execute() {
 		close(bld.sigStop)
 		close(bld.outTransaction)
 		close(bld.outDispatched)
+		if bld.outStream != nil {
+			close(bld.outStream)
+		}
 
 		// signal we are done
 		bld.mgr.finished(bld)
@@ -71,6 +127,9 @@ func (bld *blockDispatcher) execute() {
 		case <-bld.sigStop:
 			return
 
+		case <-bld.cpTick.C:
+			bld.persistCheckpoint()
+
 		case blk, ok := <-bld.inBlock:
 			// do we have a working channel?
 			if !ok {
@@ -80,60 +139,308 @@ func (bld *blockDispatcher) execute() {
 
 			// process the new block
 			log.Debugf("block #%d arrived", uint64(blk.Number))
-			if !bld.process(blk) {
+			if !bld.dispatched(blk) || !bld.process(blk, true) {
 				continue
 			}
 
-			// broadcast the block event
-			select {
-			case bld.onBlock <- blk:
-			case <-time.After(200 * time.Millisecond):
+			// broadcast the block event to subscribers
+			bld.broadcast(blk)
+
+		case ch := <-bld.inReindexStart:
+			// a new chain reindex has been started
+			bld.inReindex = ch
+
+		case blk, ok := <-bld.inReindex:
+			// reindex is done? stop listening to the channel
+			if !ok {
+				bld.inReindex = nil
+				continue
 			}
 
-			// add the block to the ring
-			repo.CacheBlock(blk)
+			// replayed blocks are processed only; they are not new to anybody
+			bld.process(blk, false)
 		}
 	}
 }
 
-// process the given block by loading its content and sending block transactions
-// into the trx dispatcher. Observe terminate signal.
-func (bld *blockDispatcher) process(blk *types.Block) bool {
-	// dispatched block number is used by the block scanner
-	// to keep track of the work done vs. work pending
+// broadcast pushes the processed block to the new block subscribers and the recent blocks ring.
+// Blocks re-sent by the orchestrator on the scanner idle transition are skipped,
+// so the subscribers receive each block only once and in the ascending order.
+func (bld *blockDispatcher) broadcast(blk *types.Block) {
+	// the block has already been announced
+	if uint64(blk.Number) <= bld.topBroadcast {
+		log.Debugf("block #%d already broadcast, top is #%d", uint64(blk.Number), bld.topBroadcast)
+		return
+	}
+	bld.topBroadcast = uint64(blk.Number)
+
+	// broadcast the block event; if it can not be broadcast quickly, skip
+	select {
+	case bld.onBlock <- blk:
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// add the block to the ring
+	repo.CacheBlock(blk)
+}
+
+// dispatched signals the block scanner the block is being dispatched;
+// the dispatched block number is used by the block scanner
+// to keep track of the work done vs. work pending. Observe terminate signal.
+func (bld *blockDispatcher) dispatched(blk *types.Block) bool {
 	select {
 	case bld.outDispatched <- uint64(blk.Number):
+		return true
 	case <-bld.sigStop:
 		bld.sigStop <- true
 		return false
 	}
+}
+
+// process the given block by loading its content and sending block transactions
+// into the trx dispatcher. Observe terminate signal.
+// If tracked, the block is marked on the checkpoint once all its transactions are processed.
+func (bld *blockDispatcher) process(blk *types.Block, track bool) bool {
+	// make sure the block extends the chain we dispatched so far
+	if !bld.checkParent(blk) {
+		return false
+	}
 
 	if blk.Txs == nil || len(blk.Txs) == 0 {
 		log.Debugf("empty block #%d processed", blk.Number)
-		return true
+		metrics.BlockProcessed()
+		bld.storeTime(blk, track)
+		if track {
+			bld.checkpoint.complete(uint64(blk.Number))
+		}
+		return bld.publish(blk)
+	}
+
+	// prep the block completion tracking
+	var done *sync.WaitGroup
+	if track {
+		done = new(sync.WaitGroup)
 	}
 
-	log.Debugf("%d transaction found in block #%d", len(blk.Txs), blk.Number)
-	if !bld.processTxs(blk) {
+	log.With("block", uint64(blk.Number)).Debugf("%d transaction found", len(blk.Txs))
+	if !bld.processTxs(blk, done) {
 		return false
 	}
 
+	// wait for the transactions to be processed to advance the checkpoint
+	if track {
+		go func() {
+			done.Wait()
+			bld.checkpoint.complete(uint64(blk.Number))
+		}()
+	}
+
 	log.Debugf("block #%d processed", blk.Number)
+	metrics.BlockProcessed()
+	bld.storeTime(blk, track)
+	return bld.publish(blk)
+}
+
+// storeTime records the time between the parent block and the given block,
+// and the time to finality of blocks received live.
+func (bld *blockDispatcher) storeTime(blk *types.Block, live bool) {
+	bn := uint64(blk.Number)
+	bt := types.BlockTime{Number: bn, Hash: blk.Hash.String(), Stamp: blk.Created()}
+
+	if prev, ok := bld.stamps[bn-1]; ok {
+		dlt := bt.Stamp.Sub(prev).Milliseconds()
+		bt.Delta = &dlt
+	}
+	if age := time.Since(bt.Stamp); live && age < blkTtfMaxAge {
+		ttf := age.Milliseconds()
+		bt.Ttf = &ttf
+	}
+
+	// remember the block time; forget blocks too deep to be reorganized
+	bld.stamps[bn] = bt.Stamp
+	if bn >= blkReorgMaxDepth {
+		delete(bld.stamps, bn-blkReorgMaxDepth)
+	}
+
+	if err := repo.StoreBlockTime(context.Background(), &bt); err != nil {
+		log.Errorf("can not store time of block #%d; %s", bn, err.Error())
+	}
+}
+
+// storeBurn records the share of the transaction fees of the block burned by the network.
+// Blocks before the fee burning started are skipped.
+func (bld *blockDispatcher) storeBurn(blk *types.Block, txs []*types.Transaction) {
+	if uint64(blk.Number) < cfg.Staking.FeeBurnFrom || cfg.Staking.FeeBurnShare <= 0 {
+		return
+	}
+
+	// sum the fees paid by the transactions
+	fee := new(big.Int)
+	var count uint32
+	for _, trx := range txs {
+		if trx == nil || trx.GasUsed == nil {
+			continue
+		}
+		fee.Add(fee, new(big.Int).Mul(new(big.Int).SetUint64(uint64(*trx.GasUsed)), trx.GasPrice.ToInt()))
+		count++
+	}
+	if fee.Sign() == 0 {
+		return
+	}
+
+	// apply the burned share; the share is kept in parts per million
+	share := new(big.Int).SetUint64(uint64(cfg.Staking.FeeBurnShare * blkBurnShareBase))
+	fee.Mul(fee, share).Div(fee, big.NewInt(blkBurnShareBase))
+
+	burn := types.FtmBurn{
+		BlockNumber:    blk.Number,
+		BlockTimeStamp: blk.TimeStamp,
+		Amount:         hexutil.Big(*fee),
+		TxCount:        count,
+	}
+	if err := repo.StoreFtmBurn(context.Background(), &burn); err != nil {
+		log.Errorf("can not store burn of block #%d; %s", uint64(blk.Number), err.Error())
+	}
+}
+
+// publish pushes the processed block to the stream publisher, if enabled.
+// Observe terminate signal.
+func (bld *blockDispatcher) publish(blk *types.Block) bool {
+	if bld.outStream == nil {
+		return true
+	}
+
+	select {
+	case bld.outStream <- blk:
+		return true
+	case <-bld.sigStop:
+		bld.sigStop <- true
+		return false
+	}
+}
+
+// persistCheckpoint stores the last fully processed block in the persistent database,
+// so the processing can be resumed from it on restart.
+func (bld *blockDispatcher) persistCheckpoint() {
+	lpb := bld.checkpoint.last()
+	if lpb == 0 {
+		return
+	}
+
+	log.Noticef("last processed block is #%d", lpb)
+	if err := repo.UpdateLastKnownBlock(context.Background(), (*hexutil.Uint64)(&lpb)); err != nil {
+		log.Errorf("could not update last processed block; %s", err.Error())
+	}
+}
+
+// checkParent verifies the block follows the previously dispatched block.
+// If the parent hash does not match, the chain has been reorganized and the blocks
+// of the abandoned branch are replaced with the canonical ones.
+func (bld *blockDispatcher) checkParent(blk *types.Block) bool {
+	bn := uint64(blk.Number)
+	if prev, ok := bld.processedHash(bn - 1); ok && prev != blk.ParentHash {
+		if !bld.reorg(blk) {
+			return false
+		}
+	}
+
+	// remember the block; forget blocks too deep to be reorganized
+	bld.recent[bn] = blk.Hash
+	if bn >= blkReorgMaxDepth {
+		delete(bld.recent, bn-blkReorgMaxDepth)
+	}
 	return true
 }
 
-// processTxs loops all the transactions in the block and pushes them
+// processedHash provides the hash of the processed block of the given number.
+// Blocks too deep for the recent blocks ring are looked up in the repository,
+// so deeper reorganizations and reorganizations across restarts are resolved, too.
+func (bld *blockDispatcher) processedHash(bn uint64) (common.Hash, bool) {
+	if hash, ok := bld.recent[bn]; ok {
+		return hash, true
+	}
+
+	hash, err := repo.ProcessedBlockHash(context.Background(), bn)
+	if err != nil {
+		log.Errorf("can not check processed block #%d; %s", bn, err.Error())
+		return common.Hash{}, false
+	}
+	if hash == nil {
+		return common.Hash{}, false
+	}
+	return *hash, true
+}
+
+// reorg finds the fork point of the abandoned branch by walking the canonical chain
+// back from the given block, removes the abandoned blocks from the repository
+// and re-ingests the canonical blocks up to the given one.
+func (bld *blockDispatcher) reorg(blk *types.Block) bool {
+	log.Warningf("chain reorganization detected at block #%d", uint64(blk.Number))
+
+	// collect canonical blocks until we get to a block we dispatched
+	canon := make([]*types.Block, 0)
+	parent := blk.ParentHash
+	for {
+		cb, err := repo.BlockByHash(chainContext(), &parent)
+		if err != nil {
+			log.Errorf("canonical block %s not available; %s", parent.String(), err.Error())
+			return false
+		}
+
+		known, ok := bld.processedHash(uint64(cb.Number))
+		if !ok || known == cb.Hash {
+			break
+		}
+
+		canon = append(canon, cb)
+		parent = cb.ParentHash
+	}
+
+	// nothing to replace?
+	if len(canon) == 0 {
+		return true
+	}
+
+	// remove the abandoned blocks
+	from, to := uint64(canon[len(canon)-1].Number), uint64(canon[0].Number)
+	log.Noticef("replacing blocks #%d to #%d with the canonical chain", from, to)
+	if err := repo.RemoveBlocks(context.Background(), from, to); err != nil {
+		log.Errorf("can not remove blocks #%d to #%d; %s", from, to, err.Error())
+		return false
+	}
+	for n := from; n <= to; n++ {
+		delete(bld.recent, n)
+		delete(bld.stamps, n)
+	}
+	bld.checkpoint.rewind(from - 1)
+
+	// re-ingest the canonical blocks from the oldest one
+	for i := len(canon) - 1; i >= 0; i-- {
+		if !bld.process(canon[i], true) {
+			return false
+		}
+	}
+	return true
+}
+
+// processTxs loads all the transactions in the block and pushes them
 // into the transaction dispatcher queue observing the term signal.
-func (bld *blockDispatcher) processTxs(blk *types.Block) bool {
-	for i, th := range blk.Txs {
-		log.Debugf("loading trx #%d from block #%d", i, blk.Number)
-		trx := bld.load(blk, th)
+func (bld *blockDispatcher) processTxs(blk *types.Block, done *sync.WaitGroup) bool {
+	txs := bld.loadTxs(blk)
+	bld.storeBurn(blk, txs)
+
+	for _, trx := range txs {
 		if trx != nil {
+			if done != nil {
+				done.Add(1)
+			}
+
 			// queue and broadcast the transaction
 			select {
 			case bld.outTransaction <- &eventTrx{
-				blk: blk,
-				trx: trx,
+				blk:     blk,
+				trx:     trx,
+				blkDone: done,
 			}:
 			case <-bld.sigStop:
 				bld.sigStop <- true
@@ -144,16 +451,60 @@ func (bld *blockDispatcher) processTxs(blk *types.Block) bool {
 	return true
 }
 
-// load a transaction detail from repository, if possible.
-func (bld *blockDispatcher) load(blk *types.Block, th *common.Hash) *types.Transaction {
-	// get transaction
-	trx, err := repo.Transaction(th)
+// loadTxs loads the transactions of the block using parallel workers,
+// each of them loading a chunk of transactions in a single RPC batch.
+// Transactions not available are left empty, the order of transactions is kept.
+func (bld *blockDispatcher) loadTxs(blk *types.Block) []*types.Transaction {
+	txs := make([]*types.Transaction, len(blk.Txs))
+	queue := make(chan int, len(blk.Txs)/trxBatchSize+1)
+	for i := 0; i < len(blk.Txs); i += trxBatchSize {
+		queue <- i
+	}
+	close(queue)
+
+	// spawn the workers; no more than we have chunks to load
+	wc := scanWorkers()
+	if wc > len(queue) {
+		wc = len(queue)
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < wc; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				end := i + trxBatchSize
+				if end > len(blk.Txs) {
+					end = len(blk.Txs)
+				}
+
+				log.Debugf("loading trx #%d to #%d from block #%d", i, end-1, blk.Number)
+				bld.loadChunk(blk, blk.Txs[i:end], txs[i:end])
+			}
+		}()
+	}
+
+	wg.Wait()
+	return txs
+}
+
+// loadChunk loads a chunk of block transactions into the given target slice.
+func (bld *blockDispatcher) loadChunk(blk *types.Block, hashes []*common.Hash, target []*types.Transaction) {
+	list, err := repo.TransactionsByHash(chainContext(), hashes)
 	if err != nil {
-		log.Errorf("transaction %s detail not available; %s", th.String(), err.Error())
-		return nil
+		log.Errorf("transactions of block #%d not available; %s", uint64(blk.Number), err.Error())
+		return
 	}
 
-	// update time stamp using the block data
-	trx.TimeStamp = time.Unix(int64(blk.TimeStamp), 0)
-	return trx
+	for i, trx := range list {
+		if trx == nil {
+			log.Errorf("transaction %s detail not available", hashes[i].String())
+			continue
+		}
+
+		// update time stamp using the block data
+		trx.TimeStamp = time.Unix(int64(blk.TimeStamp), 0)
+		target[i] = trx
+	}
 }
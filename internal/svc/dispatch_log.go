@@ -5,6 +5,7 @@ import (
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
+	"sync"
 )
 
 // logDispatcher implements dispatcher of new log events in the blockchain.
@@ -89,6 +90,12 @@ func (lgd *logDispatcher) init() {
 		/* SFC3::RestakedRewards(address indexed delegator, uint256 indexed toValidatorID, uint256 lockupExtraReward, uint256 lockupBaseReward, uint256 unlockedReward) */
 		common.HexToHash("0x4119153d17a36f9597d40e3ab4148d03261a439dddbec4e91799ab7159608e26"): handleSfcRestakeRewards,
 
+		/* SFC3::LockedUpStake(address indexed delegator, uint256 indexed validatorID, uint256 duration, uint256 amount) */
+		common.HexToHash("0x138940e95abffcd789b497bf6188bba3afa5fbd22fb5c42c2f6018d1bf0f4e78"): handleSfcLockedUpStake,
+
+		/* SFC3::UnlockedStake(address indexed delegator, uint256 indexed validatorID, uint256 amount, uint256 penalty) */
+		common.HexToHash("0xef6c0c14fe9aa51af36acd791464dec3badbde668b63189b47bfa4e25be9b2b9"): handleSfcUnlockedStake,
+
 		/* ---------------- ERC20 and ERC721 contracts related event hooks below this line ---------------- */
 
 		/* ERC20::Approval(address indexed owner, address indexed spender, uint256 value) */
@@ -105,6 +112,9 @@ func (lgd *logDispatcher) init() {
 
 		/* --------------------- Uniswap contract related event hooks below this line --------------------- */
 
+		/* UniswapFactory::PairCreated(address indexed token0, address indexed token1, address pair, uint) */
+		common.HexToHash("0x0d3648bd0f6ba80134a33ba9275ac585d9d315f0ad8355cddefde31afa28d0e9"): handleUniswapPairCreated,
+
 		/* UniswapPair::Swap(address indexed sender, uint256 amount0In, uint256 amount1In, uint256 amount0Out, uint256 amount1Out, address indexed to) */
 		common.HexToHash("0xd78ad95fa46c994b6551d0da85fc275fe613ce37657fb8d5e3d130840159d822"): handleUniswapSwap,
 
@@ -133,6 +143,12 @@ func (lgd *logDispatcher) init() {
 
 		/* FantomMintRewardManager::RewardPaid(address indexed user, uint256 reward) */
 		common.HexToHash("0xe2403640ba68fed3a2f88b7557551d1993f84b99bb10ff833f0cf8db0c5e0486"): handleFMintReward,
+
+		/* Governance::Voted(address voter, address delegatedTo, uint256 proposalID, uint256[] choices, uint256 weight) */
+		common.HexToHash("0x6e5f0f6e0ce2bdcdb0a82952fc6eb90c4c22f0b6228e4619b5dc2118e1166a12"): handleGovernanceVoted,
+
+		/* Governance::VoteCanceled(address voter, address delegatedTo, uint256 proposalID) */
+		common.HexToHash("0x666685d133047310e2a2e8c4f6794b6dccb4e9ad9c6903ac753fb10d8918b649"): handleGovernanceVoteCanceled,
 	}
 }
 
@@ -150,8 +166,16 @@ func (lgd *logDispatcher) run() {
 
 // execute implements the dispatcher reader and router routine.
 func (lgd *logDispatcher) execute() {
+	// start the log processing workers
+	lanes, wg := lgd.spawn()
+
 	// don't forget to sign off after we are done
 	defer func() {
+		for _, ln := range lanes {
+			close(ln)
+		}
+		wg.Wait()
+
 		close(lgd.sigStop)
 		lgd.mgr.finished(lgd)
 	}()
@@ -169,17 +193,45 @@ func (lgd *logDispatcher) execute() {
 				return
 			}
 
-			// try to find the topic handler
-			if nil != lr && nil != lr.Topics && 0 < len(lr.Topics) {
-				handler, ok := lgd.knownTopics[lr.Topics[0]]
-				if ok && lr.Block != nil && lr.Trx != nil {
-					log.Debugf("known topic %s found, processing", lr.Topics[0].String())
-					handler(lr)
-				}
+			// route the log to its worker by the emitting contract;
+			// logs of the same contract are processed in order
+			select {
+			case lanes[laneOf(&lr.Address, len(lanes))] <- lr:
+			case <-lgd.sigStop:
+				return
 			}
+		}
+	}
+}
 
-			// mark the processing of this log record as finished
-			lr.WatchDog.Done()
+// spawn starts the log processing workers and provides their input lanes.
+func (lgd *logDispatcher) spawn() ([]chan *types.LogRecord, *sync.WaitGroup) {
+	lanes := make([]chan *types.LogRecord, scanWorkers())
+	wg := new(sync.WaitGroup)
+
+	for i := range lanes {
+		lanes[i] = make(chan *types.LogRecord, workerLaneCapacity)
+		wg.Add(1)
+		go lgd.worker(lanes[i], wg)
+	}
+	return lanes, wg
+}
+
+// worker processes log records of the given lane until the lane is closed.
+func (lgd *logDispatcher) worker(lane chan *types.LogRecord, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for lr := range lane {
+		// try to find the topic handler
+		if nil != lr.Topics && 0 < len(lr.Topics) {
+			handler, ok := lgd.knownTopics[lr.Topics[0]]
+			if ok && lr.Block != nil && lr.Trx != nil {
+				log.Debugf("known topic %s found, processing", lr.Topics[0].String())
+				handler(lr)
+			}
 		}
+
+		// mark the processing of this log record as finished
+		lr.WatchDog.Done()
 	}
 }
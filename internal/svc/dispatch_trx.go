@@ -2,12 +2,13 @@
 package svc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	retypes "github.com/ethereum/go-ethereum/core/types"
-	"go.uber.org/atomic"
+	"math/big"
+	"strings"
 	"sync"
 	"time"
 )
@@ -18,9 +19,6 @@ const trxAddressQueueCapacity = 1000
 // trxLogQueueCapacity is the number of transaction logs kept in the dispatch buffer.
 const trxLogQueueCapacity = 5000
 
-// trxDispatchBlockUpdateTicker represents the period of block registry updater.
-const trxDispatchBlockUpdateTicker = 15 * time.Second
-
 // eventAcc represents a structure of a mentioned account.
 type eventAcc struct {
 	watchDog *sync.WaitGroup
@@ -35,11 +33,12 @@ type eventAcc struct {
 type trxDispatcher struct {
 	service
 	onTransaction chan *types.Transaction
-	bot           *time.Ticker
-	blkObserver   *atomic.Uint64
 	inTransaction chan *eventTrx
 	outAccount    chan *eventAcc
 	outLog        chan *types.LogRecord
+	outWatch      chan *eventTrx
+	outStream     chan *eventTrx
+	traceInternal bool
 }
 
 // name returns the name of the service used by orchestrator.
@@ -50,9 +49,18 @@ func (trd *trxDispatcher) name() string {
 // init prepares the transaction dispatcher to perform its function.
 func (trd *trxDispatcher) init() {
 	trd.sigStop = make(chan bool, 1)
-	trd.blkObserver = atomic.NewUint64(1)
 	trd.outAccount = make(chan *eventAcc, trxAddressQueueCapacity)
 	trd.outLog = make(chan *types.LogRecord, trxLogQueueCapacity)
+
+	// the address watcher is optional
+	if cfg.Watch.Enabled {
+		trd.outWatch = make(chan *eventTrx, watchTrxQueueCapacity)
+	}
+
+	// the stream publisher is optional
+	if trd.mgr.pub != nil {
+		trd.outStream = make(chan *eventTrx, pubQueueCapacity)
+	}
 }
 
 // run starts the transaction dispatcher job
@@ -62,24 +70,11 @@ func (trd *trxDispatcher) run() {
 		panic(fmt.Errorf("no svc manager set on %s", trd.name()))
 	}
 
-	// start the block observer ticker
-	trd.bot = time.NewTicker(trxDispatchBlockUpdateTicker)
-
 	// signal orchestrator we started and go
 	trd.mgr.started(trd)
 	go trd.execute()
 }
 
-// close terminates the block dispatcher.
-func (trd *trxDispatcher) close() {
-	if trd.bot != nil {
-		trd.bot.Stop()
-	}
-	if trd.sigStop != nil {
-		trd.sigStop <- true
-	}
-}
-
 // execute implements the dispatcher reader and router routine.
 func (trd *trxDispatcher) execute() {
 	// don't forget to sign off after we are done
@@ -87,6 +82,12 @@ func (trd *trxDispatcher) execute() {
 		close(trd.sigStop)
 		close(trd.outAccount)
 		close(trd.outLog)
+		if trd.outWatch != nil {
+			close(trd.outWatch)
+		}
+		if trd.outStream != nil {
+			close(trd.outStream)
+		}
 
 		trd.mgr.finished(trd)
 	}()
@@ -97,8 +98,6 @@ func (trd *trxDispatcher) execute() {
 		select {
 		case <-trd.sigStop:
 			return
-		case <-trd.bot.C:
-			trd.updateLastSeenBlock()
 		case evt, ok := <-trd.inTransaction:
 			// is the channel even available for reading
 			if !ok {
@@ -115,20 +114,6 @@ func (trd *trxDispatcher) execute() {
 	}
 }
 
-// updateLastSeenBlock updates the information about last known block
-// in the persistent database.
-func (trd *trxDispatcher) updateLastSeenBlock() {
-	// get the current value
-	lsb := trd.blkObserver.Load()
-	log.Noticef("last seen block is #%d", lsb)
-
-	// make the change in the database so the progress persists
-	err := repo.UpdateLastKnownBlock((*hexutil.Uint64)(&lsb))
-	if err != nil {
-		log.Errorf("could not update last seen block; %s", err.Error())
-	}
-}
-
 // process the given transaction event into the required targets.
 func (trd *trxDispatcher) process(evt *eventTrx) {
 	// process transaction accounts; exit if terminated
@@ -144,6 +129,26 @@ func (trd *trxDispatcher) process(evt *eventTrx) {
 		}
 	}
 
+	// check the transaction against the watch list, if enabled; exit if terminated
+	if trd.outWatch != nil {
+		select {
+		case trd.outWatch <- evt:
+		case <-trd.sigStop:
+			trd.sigStop <- true
+			return
+		}
+	}
+
+	// publish the transaction to the event stream, if enabled; exit if terminated
+	if trd.outStream != nil {
+		select {
+		case trd.outStream <- evt:
+		case <-trd.sigStop:
+			trd.sigStop <- true
+			return
+		}
+	}
+
 	// store the transaction into the database once the processing is done
 	// we spawn a lot of go-routines here, so we should test the optimal queue length above
 	go trd.waitAndStore(evt, &wg)
@@ -159,13 +164,45 @@ func (trd *trxDispatcher) process(evt *eventTrx) {
 func (trd *trxDispatcher) waitAndStore(evt *eventTrx, wg *sync.WaitGroup) {
 	// wait until all the sub-processors finish their job
 	wg.Wait()
-	if err := repo.StoreTransaction(evt.blk, evt.trx); err != nil {
+
+	// find out why the transaction failed so the reason is stored with it
+	if evt.trx.Status != nil && *evt.trx.Status == types.TransactionStatusFailed && evt.trx.RevertReason == nil {
+		reason, err := repo.TransactionRevertReason(context.Background(), evt.trx)
+		if err != nil {
+			log.Errorf("can not get revert reason of trx %s; %s", evt.trx.Hash.String(), err.Error())
+		} else {
+			evt.trx.RevertReason = &reason
+		}
+	}
+
+	if err := repo.StoreTransaction(context.Background(), evt.blk, evt.trx); err != nil {
 		log.Errorf("can not store trx %s from block #%d", evt.trx.Hash.String(), evt.blk.Number)
 	}
 
+	// trace internal calls of contract interactions, if enabled
+	var itx []*types.InternalTransaction
+	if trd.traceInternal && evt.trx.To != nil && len(evt.trx.InputData) > 0 {
+		var err error
+		if itx, err = repo.TraceInternalTransactions(context.Background(), &evt.trx.Hash); err != nil {
+			log.Errorf("can not trace trx %s; %s", evt.trx.Hash.String(), err.Error())
+		}
+	}
+
+	// apply the balance changes to the known balances of the accounts;
+	// replayed blocks are not tracked, their changes are already included in the balances
+	if evt.blkDone != nil {
+		if err := repo.AccountsAddBalance(context.Background(), uint64(evt.blk.Number), balanceChanges(evt.trx, itx)); err != nil {
+			log.Errorf("can not update balances of trx %s; %s", evt.trx.Hash.String(), err.Error())
+		}
+	}
+
 	repo.IncTrxCountEstimate(1)
 	repo.CacheTransaction(evt.trx)
-	trd.blkObserver.Store(uint64(evt.blk.Number))
+
+	// signal the transaction of the block is done
+	if evt.blkDone != nil {
+		evt.blkDone.Done()
+	}
 }
 
 // pushAccounts pushes given transaction accounts on both sides observing terminate signal on process.
@@ -225,3 +262,73 @@ func (trd *trxDispatcher) pushLog(lg retypes.Log, blk *types.Block, trx *types.T
 	}
 	return true
 }
+
+// balanceChanges collects the changes of the native token balances made by the given transaction
+// and its internal calls. The sender pays the fee even if the transaction failed,
+// the value is moved only by successful calls.
+func balanceChanges(trx *types.Transaction, itx []*types.InternalTransaction) map[common.Address]*big.Int {
+	diff := make(map[common.Address]*big.Int)
+	add := func(adr *common.Address, val *big.Int) {
+		if adr == nil || val.Sign() == 0 {
+			return
+		}
+		if d, ok := diff[*adr]; ok {
+			d.Add(d, val)
+			return
+		}
+		diff[*adr] = new(big.Int).Set(val)
+	}
+
+	if trx.GasUsed != nil {
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(uint64(*trx.GasUsed)), trx.GasPrice.ToInt())
+		add(&trx.From, fee.Neg(fee))
+	}
+	if trx.Status == nil || *trx.Status != types.TransactionStatusSuccess {
+		return diff
+	}
+
+	// the value of a contract creation goes to the new contract
+	to := trx.To
+	if to == nil {
+		to = trx.ContractAddress
+	}
+	add(&trx.From, new(big.Int).Neg(trx.Value.ToInt()))
+	add(to, trx.Value.ToInt())
+
+	// calls reverted with a failed parent call do not move the value either
+	reverted := make([]string, 0)
+	for _, it := range itx {
+		if it.Error != nil {
+			reverted = append(reverted, it.TraceAddress)
+			continue
+		}
+		if !movesValue(it) || isReverted(it.TraceAddress, reverted) {
+			continue
+		}
+		add(&it.From, new(big.Int).Neg(it.Value.ToInt()))
+		add(it.To, it.Value.ToInt())
+	}
+	return diff
+}
+
+// movesValue checks if the internal call transfers its value between the caller and the callee;
+// delegated and static calls run in the context of the caller.
+func movesValue(itx *types.InternalTransaction) bool {
+	switch itx.Type {
+	case "create":
+		return true
+	case "call":
+		return itx.CallType != nil && *itx.CallType == "call"
+	}
+	return false
+}
+
+// isReverted checks if the call of the given trace address is nested in any of the failed calls.
+func isReverted(ta string, failed []string) bool {
+	for _, f := range failed {
+		if strings.HasPrefix(ta, f+"_") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package svc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/onsi/gomega"
+	"math/big"
+	"testing"
+)
+
+func TestBalanceChanges(t *testing.T) {
+	var (
+		alice    = common.HexToAddress("0xA11CE00000000000000000000000000000000001")
+		bob      = common.HexToAddress("0xB0B0000000000000000000000000000000000002")
+		contract = common.HexToAddress("0xC0C0000000000000000000000000000000000003")
+		carol    = common.HexToAddress("0xCA40100000000000000000000000000000000004")
+	)
+
+	status := func(s uint64) *hexutil.Uint64 {
+		v := hexutil.Uint64(s)
+		return &v
+	}
+	gas := hexutil.Uint64(21000)
+	call, delegate := "call", "delegatecall"
+	failure := "Reverted"
+
+	trx := func(to *common.Address, value int64, st uint64) *types.Transaction {
+		return &types.Transaction{
+			From:     alice,
+			To:       to,
+			Value:    hexutil.Big(*big.NewInt(value)),
+			GasUsed:  &gas,
+			GasPrice: hexutil.Big(*big.NewInt(10)),
+			Status:   status(st),
+		}
+	}
+	itx := func(ta string, ct *string, from common.Address, to common.Address, value int64, err *string) *types.InternalTransaction {
+		return &types.InternalTransaction{
+			TraceAddress: ta,
+			Type:         "call",
+			CallType:     ct,
+			From:         from,
+			To:           &to,
+			Value:        hexutil.Big(*big.NewInt(value)),
+			Error:        err,
+		}
+	}
+
+	tests := []struct {
+		name string
+		trx  *types.Transaction
+		itx  []*types.InternalTransaction
+		want map[common.Address]int64
+	}{
+		{
+			name: "value transfer",
+			trx:  trx(&bob, 1000, types.TransactionStatusSuccess),
+			want: map[common.Address]int64{alice: -211000, bob: 1000},
+		},
+		{
+			name: "failed transaction pays the fee only",
+			trx:  trx(&bob, 1000, types.TransactionStatusFailed),
+			want: map[common.Address]int64{alice: -210000},
+		},
+		{
+			name: "contract creation",
+			trx: func() *types.Transaction {
+				t := trx(nil, 500, types.TransactionStatusSuccess)
+				t.ContractAddress = &contract
+				return t
+			}(),
+			want: map[common.Address]int64{alice: -210500, contract: 500},
+		},
+		{
+			name: "internal calls",
+			trx:  trx(&contract, 1000, types.TransactionStatusSuccess),
+			itx: []*types.InternalTransaction{
+				itx("0", &call, contract, bob, 300, nil),
+				itx("1", &delegate, contract, carol, 200, nil),
+				itx("2", &call, contract, carol, 100, &failure),
+				itx("2_0", &call, carol, bob, 50, nil),
+				itx("20", &call, contract, carol, 10, nil),
+			},
+			want: map[common.Address]int64{alice: -211000, contract: 690, bob: 300, carol: 10},
+		},
+		{
+			name: "balanced internal calls",
+			trx:  trx(&contract, 0, types.TransactionStatusSuccess),
+			itx: []*types.InternalTransaction{
+				itx("0", &call, contract, bob, 300, nil),
+				itx("1", &call, bob, contract, 300, nil),
+			},
+			want: map[common.Address]int64{alice: -210000, contract: 0, bob: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			got := make(map[common.Address]int64)
+			for adr, val := range balanceChanges(tt.trx, tt.itx) {
+				got[adr] = val.Int64()
+			}
+			g.Expect(got).To(gomega.Equal(tt.want))
+		})
+	}
+}
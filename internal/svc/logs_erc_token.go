@@ -2,6 +2,7 @@
 package svc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/repository/rpc"
 	"fantom-api-graphql/internal/types"
@@ -35,7 +36,12 @@ func handleErcTransaction(lr *types.LogRecord, trxType int32) {
 		to := common.BytesToAddress(lr.Topics[2].Bytes())
 		amount := new(big.Int).SetBytes(lr.Data[:])
 		tokenId := big.NewInt(0)
-		storeTokenTransaction(lr, types.AccountTypeERC20Token, tokenTrxType(trxType, from, to), from, to, *amount, *tokenId, 0)
+		trx := storeTokenTransaction(lr, types.AccountTypeERC20Token, tokenTrxType(trxType, from, to), from, to, *amount, *tokenId, 0)
+
+		// transfers change balances of the token holders
+		if trx != nil && trxType == types.TokenTrxTypeTransfer {
+			updateErc20Holders(trx)
+		}
 		return
 	}
 
@@ -45,7 +51,12 @@ func handleErcTransaction(lr *types.LogRecord, trxType int32) {
 		to := common.BytesToAddress(lr.Topics[2].Bytes())
 		amount := big.NewInt(1)
 		tokenId := new(big.Int).SetBytes(lr.Topics[3].Bytes())
-		storeTokenTransaction(lr, types.AccountTypeERC721Contract, tokenTrxType(trxType, from, to), from, to, *amount, *tokenId, 0)
+		trx := storeTokenTransaction(lr, types.AccountTypeERC721Contract, tokenTrxType(trxType, from, to), from, to, *amount, *tokenId, 0)
+
+		// transfers change the owner of the token
+		if trx != nil && trxType == types.TokenTrxTypeTransfer {
+			updateErc721Owner(trx)
+		}
 		return
 	}
 
@@ -60,7 +71,9 @@ func handleErc1155TransferSingle(lr *types.LogRecord) {
 		to := common.BytesToAddress(lr.Topics[3].Bytes())
 		tokenId := new(big.Int).SetBytes(lr.Data[0:32])
 		amount := new(big.Int).SetBytes(lr.Data[32:64])
-		storeTokenTransaction(lr, types.AccountTypeERC1155Contract, tokenTrxType(types.TokenTrxTypeTransfer, from, to), from, to, *amount, *tokenId, 0)
+		if trx := storeTokenTransaction(lr, types.AccountTypeERC1155Contract, tokenTrxType(types.TokenTrxTypeTransfer, from, to), from, to, *amount, *tokenId, 0); trx != nil {
+			updateErc1155Balances(trx)
+		}
 		return
 	}
 	log.Debugf("Unrecognized ERC1155 TransferSingle from tx %s (%d data bytes, %d topics)", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
@@ -75,19 +88,45 @@ func handleErc1155TransferBatch(lr *types.LogRecord) {
 		ids, values, err := rpc.Erc1155ParseTransferBatchData(lr.Data)
 		if err != nil {
 			log.Errorf("failed to parse ERC1155 TransferBatch data - trx %s; %s", lr.TxHash.String(), err.Error())
+			return
 		}
 		if len(ids) != len(values) {
 			log.Errorf("ERC1155 TransferBatch ids and values length differs - trx %s", lr.TxHash.String())
+			return
 		}
+
+		log.Infof("ERC1155 storing TransferBatch - trx %s - len %d", lr.TxHash.String(), len(ids))
 		for i := range ids {
-			log.Infof("ERC1155 storing TransferBatch - trx %s - len %d", lr.TxHash.String(), len(ids))
-			storeTokenTransaction(lr, types.AccountTypeERC1155Contract, types.TokenTrxTypeTransfer, from, to, *values[i], *ids[i], uint16(i))
+			if trx := storeTokenTransaction(lr, types.AccountTypeERC1155Contract, tokenTrxType(types.TokenTrxTypeTransfer, from, to), from, to, *values[i], *ids[i], uint16(i)); trx != nil {
+				updateErc1155Balances(trx)
+			}
 		}
 		return
 	}
 	log.Debugf("Unrecognized ERC-1155 TransferBatch from tx %s (%d data bytes, %d topics)", lr.TxHash.String(), len(lr.Data), len(lr.Topics))
 }
 
+// updateErc20Holders moves the amount of the ERC20 transfer between the known balances of the holders.
+func updateErc20Holders(trx *types.TokenTransaction) {
+	if err := repo.Erc20UpdateHolders(context.Background(), trx); err != nil {
+		log.Errorf("can not update ERC20 %s holders; %s", trx.TokenAddress.String(), err.Error())
+	}
+}
+
+// updateErc721Owner records the recipient of the ERC721 transfer as the new owner of the token.
+func updateErc721Owner(trx *types.TokenTransaction) {
+	if err := repo.Erc721UpdateOwner(context.Background(), trx); err != nil {
+		log.Errorf("can not update ERC721 %s token #%s owner; %s", trx.TokenAddress.String(), trx.TokenId.String(), err.Error())
+	}
+}
+
+// updateErc1155Balances moves the amount of the ERC1155 transfer between the known balances.
+func updateErc1155Balances(trx *types.TokenTransaction) {
+	if err := repo.Erc1155UpdateBalances(context.Background(), trx); err != nil {
+		log.Errorf("can not update ERC1155 %s token #%s balances; %s", trx.TokenAddress.String(), trx.TokenId.String(), err.Error())
+	}
+}
+
 func tokenTrxType(trxType int32, from common.Address, to common.Address) int32 {
 	if trxType == types.TokenTrxTypeTransfer && config.EmptyAddress == from.String() {
 		return types.TokenTrxTypeMint
@@ -99,8 +138,9 @@ func tokenTrxType(trxType int32, from common.Address, to common.Address) int32 {
 }
 
 // storeTokenTransaction handles general token (ERC20/ERC721/ERC1155) transaction.
-func storeTokenTransaction(lr *types.LogRecord, tokenType string, eventType int32, from common.Address, to common.Address, amount big.Int, tokenId big.Int, seq uint16) {
-	if err := repo.StoreTokenTransaction(&types.TokenTransaction{
+// The stored transaction is returned, nil is returned if it could not be stored.
+func storeTokenTransaction(lr *types.LogRecord, tokenType string, eventType int32, from common.Address, to common.Address, amount big.Int, tokenId big.Int, seq uint16) *types.TokenTransaction {
+	trx := types.TokenTransaction{
 		Transaction:  lr.TxHash,
 		TrxIndex:     hexutil.Uint64(uint64(lr.TxIndex)),
 		TokenAddress: lr.Address,
@@ -114,7 +154,10 @@ func storeTokenTransaction(lr *types.LogRecord, tokenType string, eventType int3
 		LogIndex:     lr.Index,
 		BlockNumber:  lr.BlockNumber,
 		Seq:          seq, // sequence of erc transactions emitted by one log event - non-zero only for batch transfer events
-	}); err != nil {
+	}
+	if err := repo.StoreTokenTransaction(context.Background(), &trx); err != nil {
 		log.Errorf("can not store token %s trx for call %s; %s", tokenType, lr.TxHash.String(), err.Error())
+		return nil
 	}
+	return &trx
 }
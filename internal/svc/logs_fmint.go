@@ -2,6 +2,7 @@
 package svc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -87,7 +88,7 @@ func handleFMintRepay(lr *types.LogRecord) {
 // handleNewFMintRecord creates an fMint record with the given data
 // and pushes it into the persistent storage for future reference.
 func handleNewFMintRecord(lr *types.LogRecord, tp int32, user common.Address, token common.Address, amount *big.Int, fee *big.Int) {
-	err := repo.AddFMintTransaction(&types.FMintTransaction{
+	err := repo.AddFMintTransaction(context.Background(), &types.FMintTransaction{
 		UserAddress:  user,
 		TokenAddress: token,
 		Type:         tp,
@@ -0,0 +1,58 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository/rpc"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// handleGovernanceVoted handles a new vote on a governance proposal.
+// event Voted(address voter, address delegatedTo, uint256 proposalID, uint256[] choices, uint256 weight)
+func handleGovernanceVoted(lr *types.LogRecord) {
+	// the event signature may be shared with other contracts, we care about known governance only
+	if _, err := repo.GovernanceContractBy(context.Background(), &lr.Address); err != nil {
+		return
+	}
+
+	gv, err := rpc.GovernanceParseVote(&lr.Log)
+	if err != nil {
+		log.Errorf("can not decode governance vote in trx %s; %s", lr.TxHash.String(), err.Error())
+		return
+	}
+
+	// add the vote context
+	gv.Voted = lr.Block.TimeStamp
+	gv.TrxHash = &lr.TxHash
+
+	if err := repo.StoreGovernanceVote(context.Background(), gv); err != nil {
+		log.Errorf("can not store governance vote; %s", err.Error())
+	}
+}
+
+// handleGovernanceVoteCanceled handles a vote cancellation on a governance proposal.
+// event VoteCanceled(address voter, address delegatedTo, uint256 proposalID)
+func handleGovernanceVoteCanceled(lr *types.LogRecord) {
+	// sanity check for data (3x 32 bytes = 96 bytes)
+	if len(lr.Data) != 96 {
+		log.Criticalf("%s lr invalid data length; expected 96 bytes, given %d bytes", lr.TxHash.String(), len(lr.Data))
+		return
+	}
+
+	// the event signature may be shared with other contracts, we care about known governance only
+	if _, err := repo.GovernanceContractBy(context.Background(), &lr.Address); err != nil {
+		return
+	}
+
+	// extract the vote identification
+	from := common.BytesToAddress(lr.Data[:32])
+	delegatedTo := common.BytesToAddress(lr.Data[32:64])
+	prop := (*hexutil.Big)(new(big.Int).SetBytes(lr.Data[64:]))
+
+	if err := repo.RemoveGovernanceVote(context.Background(), &lr.Address, prop, &from, &delegatedTo); err != nil {
+		log.Errorf("can not remove canceled governance vote; %s", err.Error())
+	}
+}
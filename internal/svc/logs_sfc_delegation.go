@@ -2,6 +2,7 @@
 package svc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -11,14 +12,14 @@ import (
 // handleDelegationLog handles a new delegation event from logs.
 func handleNewDelegation(lr *types.LogRecord, stakerID *big.Int, addr common.Address, amo *big.Int) {
 	// get the validator address
-	val, err := repo.ValidatorAddress((*hexutil.Big)(stakerID))
+	val, err := repo.ValidatorAddress(context.Background(), (*hexutil.Big)(stakerID))
 	if err != nil {
 		log.Errorf("unknown validator #%d; %s", stakerID.Uint64(), err.Error())
 		return
 	}
 
 	// pull the current value of the stake
-	staked, err := repo.DelegationAmountStaked(&addr, (*hexutil.Big)(stakerID))
+	staked, err := repo.DelegationAmountStaked(context.Background(), &addr, (*hexutil.Big)(stakerID))
 	if err != nil {
 		log.Errorf("delegation balance not available for %s to %d; %s", addr.String(), stakerID.Uint64(), err.Error())
 		return
@@ -36,7 +37,7 @@ func handleNewDelegation(lr *types.LogRecord, stakerID *big.Int, addr common.Add
 	}
 
 	// store the delegation
-	if err := repo.StoreDelegation(&dl); err != nil {
+	if err := repo.StoreDelegation(context.Background(), &dl); err != nil {
 		log.Errorf("failed to store delegation; %s", err.Error())
 	}
 }
@@ -63,7 +64,7 @@ func handleSfc1IncreasedDelegation(lr *types.LogRecord) {
 	valID := new(big.Int).SetBytes(lr.Topics[2].Bytes())
 
 	// update the balance
-	if err := repo.UpdateDelegationBalance(&addr, (*hexutil.Big)(valID), func(amo *big.Int) error {
+	if err := repo.UpdateDelegationBalance(context.Background(), &addr, (*hexutil.Big)(valID), func(amo *big.Int) error {
 		return makeAdHocDelegation(lr, &addr, (*hexutil.Big)(valID), amo)
 	}); err != nil {
 		log.Errorf("failed to update delegation; %s", err.Error())
@@ -115,12 +116,12 @@ func handleNewWithdrawRequest(wrt string, adr common.Address, valID *big.Int, re
 	)
 
 	// store the request
-	if err := repo.StoreWithdrawRequest(&wr); err != nil {
+	if err := repo.StoreWithdrawRequest(context.Background(), &wr); err != nil {
 		log.Errorf("failed to store new withdraw request; %s", err.Error())
 	}
 
 	// check active amount on the delegation
-	if err := repo.UpdateDelegationBalance(&wr.Address, wr.StakerID, func(amo *big.Int) error {
+	if err := repo.UpdateDelegationBalance(context.Background(), &wr.Address, wr.StakerID, func(amo *big.Int) error {
 		return makeAdHocDelegation(lr, &wr.Address, wr.StakerID, amo)
 	}); err != nil {
 		log.Errorf("failed to update delegation; %s", err.Error())
@@ -132,7 +133,7 @@ func handleFinishedWithdrawRequest(adr common.Address, valID *big.Int, reqID *bi
 	// make sure the delegation balance will be updated
 	defer func() {
 		// check active amount on the delegation
-		if err := repo.UpdateDelegationBalance(&adr, (*hexutil.Big)(valID), func(amo *big.Int) error {
+		if err := repo.UpdateDelegationBalance(context.Background(), &adr, (*hexutil.Big)(valID), func(amo *big.Int) error {
 			return makeAdHocDelegation(lr, &adr, (*hexutil.Big)(valID), amo)
 		}); err != nil {
 			log.Errorf("failed to update delegation; %s", err.Error())
@@ -148,7 +149,7 @@ func handleFinishedWithdrawRequest(adr common.Address, valID *big.Int, reqID *bi
 	)
 
 	// try to get the request from database
-	req, err := repo.WithdrawRequest(&adr, (*hexutil.Big)(valID), (*hexutil.Big)(reqID))
+	req, err := repo.WithdrawRequest(context.Background(), &adr, (*hexutil.Big)(valID), (*hexutil.Big)(reqID))
 	if err != nil {
 		log.Errorf("can not load withdraw requests to finalise; %s", err.Error())
 		return
@@ -160,7 +161,7 @@ func handleFinishedWithdrawRequest(adr common.Address, valID *big.Int, reqID *bi
 	req.Penalty = (*hexutil.Big)(penalty)
 
 	// store the updated request
-	if err := repo.UpdateWithdrawRequest(req); err != nil {
+	if err := repo.UpdateWithdrawRequest(context.Background(), req); err != nil {
 		log.Errorf("failed to store finalized withdraw request; %s", err.Error())
 	}
 }
@@ -238,7 +239,7 @@ func handleSfc1UpdatedDelegation(lr *types.LogRecord) {
 	// check active amount on the delegation
 	addr := common.BytesToAddress(lr.Topics[1].Bytes())
 	valID := (*hexutil.Big)(new(big.Int).SetBytes(lr.Topics[2].Bytes()))
-	if err := repo.UpdateDelegationBalance(&addr, valID, func(amo *big.Int) error {
+	if err := repo.UpdateDelegationBalance(context.Background(), &addr, valID, func(amo *big.Int) error {
 		return makeAdHocDelegation(lr, &addr, valID, amo)
 	}); err != nil {
 		log.Errorf("failed to update delegation; %s", err.Error())
@@ -282,14 +283,14 @@ func handleSfc1WithdrawnDelegation(lr *types.LogRecord) {
 // could not be found on a new lr event processing.
 func makeAdHocDelegation(lr *types.LogRecord, addr *common.Address, stakerID *hexutil.Big, amo *big.Int) error {
 	// get staker address
-	val, err := repo.ValidatorAddress(stakerID)
+	val, err := repo.ValidatorAddress(context.Background(), stakerID)
 	if err != nil {
 		return err
 	}
 
 	// do the insert
 	log.Noticef("creating ad-hoc delegation of %s to #%d", addr.String(), stakerID.ToInt().Uint64())
-	return repo.StoreDelegation(&types.Delegation{
+	return repo.StoreDelegation(context.Background(), &types.Delegation{
 		Transaction:     lr.TxHash,
 		Address:         *addr,
 		ToStakerId:      stakerID,
@@ -0,0 +1,85 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+)
+
+// handleSfcLockedUpStake handles a delegation stake lockup event.
+// event LockedUpStake(address indexed delegator, uint256 indexed validatorID, uint256 duration, uint256 amount)
+func handleSfcLockedUpStake(lr *types.LogRecord) {
+	// sanity check for data (2x uint256 = 2x32 bytes = 64 bytes)
+	if len(lr.Data) != 64 {
+		log.Criticalf("%s lr invalid data length; expected 64 bytes, given %d bytes", lr.TxHash.String(), len(lr.Data))
+		return
+	}
+
+	// extract the basic info about the lock
+	addr := common.BytesToAddress(lr.Topics[1].Bytes())
+	valID := (*hexutil.Big)(new(big.Int).SetBytes(lr.Topics[2].Bytes()))
+	dur := new(big.Int).SetBytes(lr.Data[:32])
+	amo := new(big.Int).SetBytes(lr.Data[32:])
+
+	// debug the event
+	log.Debugf("%s locked %d in stake to #%d for %ds", addr.String(), amo.Uint64(), valID.ToInt().Uint64(), dur.Uint64())
+
+	handleSfcLockEvent(lr, &types.DelegationLockEvent{
+		Delegator:     addr,
+		ToValidatorId: *valID,
+		Type:          types.DelegationLockEventLock,
+		Amount:        (hexutil.Big)(*amo),
+		Duration:      hexutil.Uint64(dur.Uint64()),
+	})
+}
+
+// handleSfcUnlockedStake handles a delegation stake unlock event.
+// event UnlockedStake(address indexed delegator, uint256 indexed validatorID, uint256 amount, uint256 penalty)
+func handleSfcUnlockedStake(lr *types.LogRecord) {
+	// sanity check for data (2x uint256 = 2x32 bytes = 64 bytes)
+	if len(lr.Data) != 64 {
+		log.Criticalf("%s lr invalid data length; expected 64 bytes, given %d bytes", lr.TxHash.String(), len(lr.Data))
+		return
+	}
+
+	// extract the basic info about the unlock
+	addr := common.BytesToAddress(lr.Topics[1].Bytes())
+	valID := (*hexutil.Big)(new(big.Int).SetBytes(lr.Topics[2].Bytes()))
+	amo := new(big.Int).SetBytes(lr.Data[:32])
+	penalty := new(big.Int).SetBytes(lr.Data[32:])
+
+	// debug the event
+	log.Debugf("%s unlocked %d in stake to #%d, penalty %d", addr.String(), amo.Uint64(), valID.ToInt().Uint64(), penalty.Uint64())
+
+	handleSfcLockEvent(lr, &types.DelegationLockEvent{
+		Delegator:     addr,
+		ToValidatorId: *valID,
+		Type:          types.DelegationLockEventUnlock,
+		Amount:        (hexutil.Big)(*amo),
+		Penalty:       (hexutil.Big)(*penalty),
+	})
+
+	// the penalty is slashed from the delegated stake
+	if penalty.Sign() > 0 {
+		if err := repo.UpdateDelegationBalance(context.Background(), &addr, valID, func(amo *big.Int) error {
+			return makeAdHocDelegation(lr, &addr, valID, amo)
+		}); err != nil {
+			log.Errorf("failed to update delegation; %s", err.Error())
+		}
+	}
+}
+
+// handleSfcLockEvent stores the given delegation lock event
+// completed with the details of the log record.
+func handleSfcLockEvent(lr *types.LogRecord, dle *types.DelegationLockEvent) {
+	dle.TimeStamp = lr.Block.TimeStamp
+	dle.Trx = lr.TxHash
+	dle.LogIndex = lr.Index
+
+	if err := repo.StoreDelegationLockEvent(context.Background(), dle); err != nil {
+		log.Criticalf("can not store delegation lock event; %s", err.Error())
+	}
+}
@@ -2,6 +2,7 @@
 package svc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -14,7 +15,7 @@ func handleSfcRewardClaim(lr *types.LogRecord, addr common.Address, valID *hexut
 	log.Debugf("%s claimed %d in stake to #%d", addr.String(), amo.Uint64(), valID.ToInt().Uint64())
 
 	// add the rewards claim into the repository
-	if err := repo.StoreRewardClaim(&types.RewardClaim{
+	if err := repo.StoreRewardClaim(context.Background(), &types.RewardClaim{
 		Delegator:     addr,
 		ToValidatorId: *valID,
 		Claimed:       lr.Block.TimeStamp,
@@ -27,7 +28,7 @@ func handleSfcRewardClaim(lr *types.LogRecord, addr common.Address, valID *hexut
 	}
 
 	// check active amount on the delegation
-	if err := repo.UpdateDelegationBalance(&addr, valID, func(amo *big.Int) error {
+	if err := repo.UpdateDelegationBalance(context.Background(), &addr, valID, func(amo *big.Int) error {
 		return makeAdHocDelegation(lr, &addr, valID, amo)
 	}); err != nil {
 		log.Errorf("failed to update delegation; %s", err.Error())
@@ -121,7 +122,7 @@ func handleSfc1ClaimedValidatorReward(lr *types.LogRecord) {
 	amo := new(big.Int).SetBytes(lr.Data[:32])
 
 	// get the validator address since this a self stake
-	addr, err := repo.ValidatorAddress(valID)
+	addr, err := repo.ValidatorAddress(context.Background(), valID)
 	if err != nil {
 		log.Errorf("validator #%d not found; %s", valID.ToInt().Uint64(), err.Error())
 		return
@@ -2,6 +2,7 @@
 package svc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -24,14 +25,14 @@ func handleSfcCreatedStake(lr *types.LogRecord) {
 func handleSfc1IncreasedStake(lr *types.LogRecord) {
 	// get the validator address
 	valID := (*hexutil.Big)(new(big.Int).SetBytes(lr.Topics[1].Bytes()))
-	addr, err := repo.ValidatorAddress(valID)
+	addr, err := repo.ValidatorAddress(context.Background(), valID)
 	if err != nil {
 		log.Errorf("unknown validator #%d; %s", valID.ToInt().Uint64(), err.Error())
 		return
 	}
 
 	// update the balance
-	if err := repo.UpdateDelegationBalance(addr, valID, func(amo *big.Int) error {
+	if err := repo.UpdateDelegationBalance(context.Background(), addr, valID, func(amo *big.Int) error {
 		return makeAdHocDelegation(lr, addr, valID, amo)
 	}); err != nil {
 		log.Errorf("failed to update delegation; %s", err.Error())
@@ -49,7 +50,7 @@ func handleSfc1WithdrawnStake(lr *types.LogRecord) {
 
 	// get the validator address (we handle the stake as self-delegation in SFC3 context)
 	valID := new(big.Int).SetBytes(lr.Topics[1].Bytes())
-	addr, err := repo.ValidatorAddress((*hexutil.Big)(valID))
+	addr, err := repo.ValidatorAddress(context.Background(), (*hexutil.Big)(valID))
 	if err != nil {
 		log.Errorf("unknown validator #%d; %s", valID.Uint64(), err.Error())
 		return
@@ -72,7 +73,7 @@ func handleSfc1DeactivatedStake(lr *types.LogRecord) {
 
 	// get the validator address (we handle the stake as self-delegation in SFC3 context)
 	valID := new(big.Int).SetBytes(lr.Topics[1].Bytes())
-	addr, err := repo.ValidatorAddress((*hexutil.Big)(valID))
+	addr, err := repo.ValidatorAddress(context.Background(), (*hexutil.Big)(valID))
 	if err != nil {
 		log.Errorf("unknown validator #%d; %s", valID.Uint64(), err.Error())
 		return
@@ -96,14 +97,14 @@ func handleSfc1DeactivatedStake(lr *types.LogRecord) {
 func handleSfc1UpdatedStake(lr *types.LogRecord) {
 	// get the validator address (we handle the stake as self-delegation in SFC3 context)
 	valID := (*hexutil.Big)(new(big.Int).SetBytes(lr.Topics[1].Bytes()))
-	addr, err := repo.ValidatorAddress(valID)
+	addr, err := repo.ValidatorAddress(context.Background(), valID)
 	if err != nil {
 		log.Errorf("unknown validator #%d; %s", valID.ToInt().Uint64(), err.Error())
 		return
 	}
 
 	// update the balance
-	if err := repo.UpdateDelegationBalance(addr, valID, func(amo *big.Int) error {
+	if err := repo.UpdateDelegationBalance(context.Background(), addr, valID, func(amo *big.Int) error {
 		log.Criticalf("expected validator %d stake not found at %s", valID.ToInt().Uint64(), lr.TxHash.String())
 		return makeAdHocDelegation(lr, addr, valID, amo)
 	}); err != nil {
@@ -2,6 +2,7 @@
 package svc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -25,7 +26,7 @@ func isKnownUniswapPair(pair *common.Address) bool {
 		apr = false
 
 		// get the full list of all known pairs and use it to check this one
-		l, err := repo.UniswapPairs()
+		l, err := repo.UniswapPairs(context.Background())
 		if err != nil {
 			log.Errorf("unknown uniswap pairs list; %s", err.Error())
 			return false
@@ -43,6 +44,35 @@ func isKnownUniswapPair(pair *common.Address) bool {
 	return apr
 }
 
+// handleUniswapPairCreated processes Uniswap PairCreated event lr emitted by the Uniswap core
+// when a new pair of tokens is created; the pair is known from now on.
+// UniswapFactory::PairCreated(address indexed token0, address indexed token1, address pair, uint)
+func handleUniswapPairCreated(lr *types.LogRecord) {
+	if lr.Address != cfg.DeFi.Uniswap.Core {
+		return
+	}
+
+	// sanity check for data (address + uint256 = 64 bytes), (1 x subject topic + 2 x address = 3 topics)
+	if len(lr.Data) != 64 || len(lr.Topics) != 3 {
+		log.Errorf("%s invalid data length; expected 64 bytes, %d bytes given; expected 3 topics, %d given",
+			lr.TxHash.String(),
+			len(lr.Data),
+			len(lr.Topics),
+		)
+		return
+	}
+
+	pair := common.BytesToAddress(lr.Data[:32])
+	log.Debugf("uniswap pair %s created for tokens %s and %s",
+		pair.String(),
+		common.BytesToAddress(lr.Topics[1].Bytes()).String(),
+		common.BytesToAddress(lr.Topics[2].Bytes()).String(),
+	)
+
+	uniswapKnownPairs[pair] = true
+	repo.UniswapPairCreated(context.Background(), &pair)
+}
+
 // handleUniswapSwap processes Uniswap Swap event lr emitted when a sender trades
 // input tokens to gain output tokens, this is the basic type of trade on an Uniswap pair.
 // UniswapPair::Swap(address indexed sender, uint256 amount0In, uint256 amount1In, uint256 amount0Out, uint256 amount1Out, address indexed to)
@@ -82,10 +112,10 @@ func handleUniswapSwap(lr *types.LogRecord) {
 	)
 
 	// store the swap to repository
-	err := repo.UniswapAdd(&types.Swap{
+	err := repo.UniswapAdd(context.Background(), &types.Swap{
 		OrdIndex:    uniswapOrdinalIndex(lr),
 		BlockNumber: &lr.Block.Number,
-		Type:        types.SwapMint,
+		Type:        types.SwapNormal,
 		TimeStamp:   &lr.Block.TimeStamp,
 		Pair:        lr.Address,
 		Sender:      lr.Trx.From,
@@ -137,7 +167,7 @@ func handleUniswapMint(lr *types.LogRecord) {
 	)
 
 	// store the swap to repository
-	err := repo.UniswapAdd(&types.Swap{
+	err := repo.UniswapAdd(context.Background(), &types.Swap{
 		OrdIndex:    uniswapOrdinalIndex(lr),
 		BlockNumber: &lr.Block.Number,
 		Type:        types.SwapMint,
@@ -192,7 +222,7 @@ func handleUniswapBurn(lr *types.LogRecord) {
 	)
 
 	// store the swap to repository
-	err := repo.UniswapAdd(&types.Swap{
+	err := repo.UniswapAdd(context.Background(), &types.Swap{
 		OrdIndex:    uniswapOrdinalIndex(lr),
 		BlockNumber: &lr.Block.Number,
 		Type:        types.SwapBurn,
@@ -244,7 +274,7 @@ func handleUniswapSync(lr *types.LogRecord) {
 	)
 
 	// store the swap to repository
-	err := repo.UniswapAdd(&types.Swap{
+	err := repo.UniswapAdd(context.Background(), &types.Swap{
 		OrdIndex:    uniswapOrdinalIndex(lr),
 		BlockNumber: &lr.Block.Number,
 		Type:        types.SwapSync,
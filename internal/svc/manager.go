@@ -2,16 +2,21 @@
 package svc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/config"
 	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/stream"
 	"fantom-api-graphql/internal/types"
 	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
 	"sync"
 )
 
 // ServiceManager implements service manager.
 type ServiceManager struct {
 	wg *sync.WaitGroup
+	mu sync.Mutex
 
 	// special services with external dependency
 	ora *orchestrator
@@ -20,6 +25,10 @@ type ServiceManager struct {
 	acd *accDispatcher
 	lgd *logDispatcher
 	bls *blkScanner
+	rix *reindexer
+	wtc *watcher
+	pub *publisher
+	nmf *nftMetadataFetcher
 
 	// collection of all the managed services
 	svc []Svc
@@ -51,6 +60,11 @@ func (mgr *ServiceManager) Run() {
 	// get local copy of the repository
 	repo = repository.R()
 
+	// the chain reindexer, if requested, must be ready before the orchestrator connects it
+	if mgr.rix != nil {
+		mgr.rix.init()
+	}
+
 	// init all the services to the starting state
 	for _, s := range mgr.svc {
 		s.init()
@@ -60,12 +74,26 @@ func (mgr *ServiceManager) Run() {
 	for _, s := range mgr.svc {
 		s.run()
 	}
+
+	// start the requested chain reindex
+	if mgr.rix != nil {
+		mgr.rix.run()
+	}
 }
 
 // Close signals orchestrator to terminate all orchestrated services.
 func (mgr *ServiceManager) Close() {
 	log.Noticef("svc manager received a close signal")
 
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	// stop the chain reindex first, so it does not push blocks to the closing dispatcher
+	if mgr.rix != nil {
+		log.Noticef("closing %s", mgr.rix.name())
+		mgr.rix.close()
+	}
+
 	// pass the signal to all the services
 	for _, s := range mgr.svc {
 		log.Noticef("closing %s", s.name())
@@ -76,10 +104,64 @@ func (mgr *ServiceManager) Close() {
 	log.Notice("waiting for services to finish")
 	mgr.wg.Wait()
 
+	// flush the processed blocks checkpoint so the scanner resumes where it stopped
+	if mgr.bld != nil {
+		mgr.bld.persistCheckpoint()
+	}
+
 	// we are done
 	log.Notice("svc manager closed")
 }
 
+// Reindex starts the chain replay from the given block to the current head
+// to rebuild the indexed data. Only one chain replay can run at a time.
+func (mgr *ServiceManager) Reindex(from uint64) error {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	if mgr.rix != nil && mgr.rix.isRunning() {
+		return fmt.Errorf("chain reindex already running")
+	}
+
+	// prep the reindexer and connect it to the block dispatcher
+	rix := &reindexer{service: service{mgr: mgr}, from: from}
+	rix.init()
+
+	select {
+	case mgr.bld.inReindexStart <- rix.outBlock:
+	default:
+		return fmt.Errorf("block dispatcher not ready for chain reindex")
+	}
+
+	mgr.rix = rix
+	rix.run()
+	return nil
+}
+
+// ScannerStatus provides the current state of the blockchain data scanner.
+func (mgr *ServiceManager) ScannerStatus() *types.ScannerStatus {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+
+	st := types.ScannerStatus{LastProcessedBlock: mgr.bld.checkpoint.last()}
+	st.ChainHead, st.IsIdle, st.BlocksPerSecond = mgr.ora.state()
+	if mgr.rix != nil {
+		st.IsReindexing = mgr.rix.isRunning()
+		st.ReindexFrom, st.ReindexTo, st.ReindexNext = mgr.rix.status()
+	}
+	return &st
+}
+
+// NftMetadata provides the metadata of the given NFT token, downloading them from the token URI
+// if they are not known yet, or they are outdated. If the fetcher is disabled,
+// only the metadata downloaded before are provided.
+func (mgr *ServiceManager) NftMetadata(ctx context.Context, contract *common.Address, tokenId *big.Int) (*types.NftMetadata, error) {
+	if mgr.nmf == nil {
+		return repository.R().NftMetadata(ctx, contract, tokenId)
+	}
+	return mgr.nmf.metadata(ctx, contract, tokenId)
+}
+
 // SetBlockChannel registers a channel for notifying new block events.
 func (mgr *ServiceManager) SetBlockChannel(ch chan *types.Block) {
 	mgr.bld.onBlock = ch
@@ -97,7 +179,7 @@ func (mgr *ServiceManager) init() {
 	mgr.svc = append(mgr.svc, mgr.bld)
 
 	// make the transaction dispatcher
-	mgr.trd = &trxDispatcher{service: service{mgr: mgr}}
+	mgr.trd = &trxDispatcher{service: service{mgr: mgr}, traceInternal: cfg.Repository.TraceInternalCalls}
 	mgr.svc = append(mgr.svc, mgr.trd)
 
 	// make account dispatcher
@@ -112,6 +194,35 @@ func (mgr *ServiceManager) init() {
 	mgr.bls = &blkScanner{service: service{mgr: mgr}, cfg: cfg.RepoCommand}
 	mgr.svc = append(mgr.svc, mgr.bls)
 
+	// make chain reindexer only if requested; the reindexer is not a permanent service,
+	// it's kept aside so a later reindex replaces it instead of piling up
+	if cfg.RepoCommand.ReindexFrom > 0 {
+		mgr.rix = &reindexer{service: service{mgr: mgr}, from: cfg.RepoCommand.ReindexFrom, to: cfg.RepoCommand.ReindexTo}
+	}
+
+	// make address watcher only if enabled
+	if cfg.Watch.Enabled {
+		mgr.wtc = &watcher{service: service{mgr: mgr}}
+		mgr.svc = append(mgr.svc, mgr.wtc)
+	}
+
+	// make NFT metadata fetcher only if enabled
+	if cfg.NftMetadata.Enabled {
+		mgr.nmf = &nftMetadataFetcher{service: service{mgr: mgr}}
+		mgr.svc = append(mgr.svc, mgr.nmf)
+	}
+
+	// make stream publisher only if enabled and the broker is available
+	if cfg.Stream.Enabled {
+		sp, err := stream.New(&cfg.Stream, log)
+		if err != nil {
+			log.Criticalf("stream publisher not available; %s", err.Error())
+		} else {
+			mgr.pub = &publisher{service: service{mgr: mgr}, pub: sp}
+			mgr.svc = append(mgr.svc, mgr.pub)
+		}
+	}
+
 	// make epoch scanner
 	mgr.svc = append(mgr.svc, &epochScanner{service: service{mgr: mgr}})
 
@@ -123,9 +234,15 @@ func (mgr *ServiceManager) init() {
 	// make gas price suggestion monitor
 	mgr.svc = append(mgr.svc, &gpsMonitor{service: service{mgr: mgr}})
 
+	// make gas price sampler of recent transactions
+	mgr.svc = append(mgr.svc, &gpsSampler{service: service{mgr: mgr}})
+
 	// make transaction flow monitor
 	mgr.svc = append(mgr.svc, &trxFlowMonitor{service: service{mgr: mgr}})
 
+	// make pending transactions monitor
+	mgr.svc = append(mgr.svc, &pendingMonitor{service: service{mgr: mgr}})
+
 	// add orchestrator as the last service, so it can safely operate on all the other
 	mgr.ora = &orchestrator{service: service{mgr: mgr}}
 	mgr.svc = append(mgr.svc, mgr.ora)
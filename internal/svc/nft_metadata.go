@@ -0,0 +1,144 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"context"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/sync/singleflight"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// nftMetadataQueueCapacity represents the number of NFT metadata downloads waiting for a worker.
+const nftMetadataQueueCapacity = 1000
+
+// nftMetadataFetcher implements a service downloading the metadata JSON of NFT tokens
+// from their token URIs. The downloaded metadata are sanitized and stored in the database,
+// so each token metadata are downloaded only once per the configured refresh period.
+type nftMetadataFetcher struct {
+	service
+	queue   chan *nftMetadataRequest
+	client  *http.Client
+	gateway *http.Client
+	cg      singleflight.Group
+	workers sync.WaitGroup
+	done    chan struct{}
+}
+
+// nftMetadataRequest represents a request to download the metadata of an NFT token.
+type nftMetadataRequest struct {
+	contract common.Address
+	tokenId  *big.Int
+	prev     *types.NftMetadata
+	result   chan *types.NftMetadata
+}
+
+// name returns the name of the service used by orchestrator.
+func (nmf *nftMetadataFetcher) name() string {
+	return "nft metadata fetcher"
+}
+
+// init prepares the NFT metadata fetcher to perform its function.
+func (nmf *nftMetadataFetcher) init() {
+	nmf.sigStop = make(chan bool, 1)
+	nmf.queue = make(chan *nftMetadataRequest, nftMetadataQueueCapacity)
+	nmf.done = make(chan struct{})
+
+	// the configured gateway may live on the private network, the token URIs may not
+	nmf.gateway = &http.Client{Timeout: cfg.NftMetadata.Timeout}
+	nmf.client = newPublicClient(cfg.NftMetadata.Timeout)
+}
+
+// run starts the NFT metadata fetcher.
+func (nmf *nftMetadataFetcher) run() {
+	// make sure we are orchestrated
+	if nmf.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", nmf.name()))
+	}
+
+	for i := 0; i < cfg.NftMetadata.Workers; i++ {
+		nmf.workers.Add(1)
+		go nmf.download()
+	}
+
+	nmf.mgr.started(nmf)
+	go nmf.execute()
+}
+
+// execute waits for the termination signal and stops the download workers.
+func (nmf *nftMetadataFetcher) execute() {
+	defer func() {
+		close(nmf.done)
+		nmf.workers.Wait()
+
+		close(nmf.sigStop)
+		nmf.mgr.finished(nmf)
+	}()
+	<-nmf.sigStop
+}
+
+// download processes the queued metadata download requests.
+func (nmf *nftMetadataFetcher) download() {
+	defer nmf.workers.Done()
+
+	for {
+		select {
+		case <-nmf.done:
+			return
+		case req := <-nmf.queue:
+			md := nmf.fetch(req)
+			if err := repo.StoreNftMetadata(context.Background(), md); err != nil {
+				log.Errorf("can not store metadata of NFT %s; %s", md.Id, err.Error())
+			}
+			req.result <- md
+		}
+	}
+}
+
+// metadata provides the metadata of the given NFT token. Stored metadata are used
+// until the refresh period passes, otherwise the metadata are downloaded again.
+// Concurrent requests of the same token share a single download.
+func (nmf *nftMetadataFetcher) metadata(ctx context.Context, contract *common.Address, tokenId *big.Int) (*types.NftMetadata, error) {
+	prev, err := repository.R().NftMetadata(ctx, contract, tokenId)
+	if err != nil {
+		return nil, err
+	}
+	if prev != nil && time.Since(prev.Fetched) < cfg.NftMetadata.RefreshAfter {
+		return prev, nil
+	}
+
+	// the download is shared by concurrent callers, so it does not end with the context of any of them
+	ch := nmf.cg.DoChan(repository.NftMetadataId(contract, tokenId), func() (interface{}, error) {
+		req := nftMetadataRequest{contract: *contract, tokenId: tokenId, prev: prev, result: make(chan *types.NftMetadata, 1)}
+
+		// the queue is full, serve what we have
+		select {
+		case nmf.queue <- &req:
+		default:
+			log.Warningf("metadata download queue full, NFT %s skipped", repository.NftMetadataId(contract, tokenId))
+			return prev, nil
+		}
+
+		select {
+		case md := <-req.result:
+			return md, nil
+		case <-nmf.done:
+			return prev, nil
+		}
+	})
+
+	select {
+	case res := <-ch:
+		if res.Val == nil {
+			return nil, res.Err
+		}
+		return res.Val.(*types.NftMetadata), res.Err
+	case <-ctx.Done():
+		return prev, ctx.Err()
+	}
+}
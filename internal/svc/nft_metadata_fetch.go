@@ -0,0 +1,241 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"context"
+	"encoding/json"
+	"fantom-api-graphql/internal/repository"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// nftMaxNameLength represents the max length of the NFT name in characters.
+	nftMaxNameLength = 256
+
+	// nftMaxDescriptionLength represents the max length of the NFT description in characters.
+	nftMaxDescriptionLength = 4096
+
+	// nftMaxUrlLength represents the max length of a URL in the NFT metadata.
+	nftMaxUrlLength = 2048
+
+	// nftMaxAttributes represents the max number of the NFT attributes kept.
+	nftMaxAttributes = 100
+
+	// nftMaxAttributeLength represents the max length of the NFT attribute type and value in characters.
+	nftMaxAttributeLength = 256
+)
+
+// nftRawMetadata represents the metadata JSON of an NFT token as published by the issuer.
+// Issuers do not follow the types strictly, so the values are decoded loosely.
+type nftRawMetadata struct {
+	Name        interface{}     `json:"name"`
+	Description interface{}     `json:"description"`
+	Image       interface{}     `json:"image"`
+	ImageUrl    interface{}     `json:"image_url"`
+	ExternalUrl interface{}     `json:"external_url"`
+	Attributes  json.RawMessage `json:"attributes"`
+}
+
+// nftRawAttribute represents a single trait of the NFT token metadata JSON.
+type nftRawAttribute struct {
+	TraitType   interface{} `json:"trait_type"`
+	Value       interface{} `json:"value"`
+	DisplayType interface{} `json:"display_type"`
+}
+
+// fetch downloads and sanitizes the metadata of the requested NFT token. If the download fails,
+// previously downloaded metadata are kept, otherwise the failure is recorded.
+func (nmf *nftMetadataFetcher) fetch(req *nftMetadataRequest) *types.NftMetadata {
+	id := repository.NftMetadataId(&req.contract, req.tokenId)
+
+	md, err := nmf.load(req)
+	if err != nil {
+		log.Warningf("can not download metadata of NFT %s; %s", id, err.Error())
+		if req.prev != nil && req.prev.Error == "" {
+			req.prev.Fetched = time.Now().UTC()
+			return req.prev
+		}
+		md = &types.NftMetadata{Error: err.Error()}
+	}
+
+	md.Id = id
+	md.Fetched = time.Now().UTC()
+	return md
+}
+
+// load downloads and sanitizes the metadata of the requested NFT token.
+func (nmf *nftMetadataFetcher) load(req *nftMetadataRequest) (*types.NftMetadata, error) {
+	uri, err := repo.Erc721TokenURI(context.Background(), &req.contract, req.tokenId)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := nmf.get(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw nftRawMetadata
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid metadata JSON; %s", err.Error())
+	}
+
+	md := types.NftMetadata{
+		Uri:         uri,
+		Name:        sanitizeText(raw.Name, nftMaxNameLength),
+		Description: sanitizeText(raw.Description, nftMaxDescriptionLength),
+		Image:       nmf.sanitizeUrl(raw.Image),
+		ExternalUrl: nmf.sanitizeUrl(raw.ExternalUrl),
+		Attributes:  sanitizeAttributes(raw.Attributes),
+	}
+	if md.Image == "" {
+		md.Image = nmf.sanitizeUrl(raw.ImageUrl)
+	}
+	return &md, nil
+}
+
+// get downloads the document of the given ipfs:// or HTTP URI respecting the size limit.
+func (nmf *nftMetadataFetcher) get(uri string) ([]byte, error) {
+	target, viaGateway, err := nmf.resolve(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	client := nmf.client
+	if viaGateway {
+		client = nmf.gateway
+	}
+
+	res, err := client.Get(target)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+	if res.ContentLength > cfg.NftMetadata.MaxSize {
+		return nil, fmt.Errorf("metadata too large, %d bytes", res.ContentLength)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(res.Body, cfg.NftMetadata.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > cfg.NftMetadata.MaxSize {
+		return nil, fmt.Errorf("metadata larger than %d bytes", cfg.NftMetadata.MaxSize)
+	}
+	return data, nil
+}
+
+// resolve translates the given token URI to the HTTP URL to be downloaded;
+// ipfs:// URIs are resolved by the configured IPFS gateway.
+func (nmf *nftMetadataFetcher) resolve(uri string) (string, bool, error) {
+	uri = strings.TrimSpace(uri)
+	if len(uri) > nftMaxUrlLength {
+		return "", false, fmt.Errorf("token URI too long")
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid token URI; %s", err.Error())
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "ipfs":
+		// both ipfs://<cid>/<path> and ipfs://ipfs/<cid>/<path> are used in the wild
+		path := strings.TrimPrefix(strings.TrimPrefix(uri[len(u.Scheme)+3:], "ipfs/"), "/")
+		if path == "" {
+			return "", false, fmt.Errorf("empty IPFS path")
+		}
+		return strings.TrimSuffix(cfg.NftMetadata.Gateway, "/") + "/" + path, true, nil
+	case "http", "https":
+		if u.Host == "" {
+			return "", false, fmt.Errorf("token URI without host")
+		}
+		return u.String(), false, nil
+	}
+	return "", false, fmt.Errorf("token URI scheme %q not supported", u.Scheme)
+}
+
+// sanitizeUrl provides the given metadata URL if it's a valid ipfs:// or HTTP URL,
+// or an empty string. The ipfs:// URLs are translated to the IPFS gateway.
+func (nmf *nftMetadataFetcher) sanitizeUrl(val interface{}) string {
+	s, ok := val.(string)
+	if !ok {
+		return ""
+	}
+
+	target, _, err := nmf.resolve(s)
+	if err != nil {
+		return ""
+	}
+	return target
+}
+
+// sanitizeAttributes decodes and sanitizes the list of the NFT attributes.
+func sanitizeAttributes(data json.RawMessage) []types.NftAttribute {
+	var raw []nftRawAttribute
+	if len(data) == 0 || json.Unmarshal(data, &raw) != nil {
+		return []types.NftAttribute{}
+	}
+
+	if len(raw) > nftMaxAttributes {
+		raw = raw[:nftMaxAttributes]
+	}
+
+	list := make([]types.NftAttribute, 0, len(raw))
+	for _, a := range raw {
+		attr := types.NftAttribute{
+			TraitType:   sanitizeText(a.TraitType, nftMaxAttributeLength),
+			Value:       sanitizeText(a.Value, nftMaxAttributeLength),
+			DisplayType: sanitizeText(a.DisplayType, nftMaxAttributeLength),
+		}
+		if attr.Value == "" {
+			continue
+		}
+		list = append(list, attr)
+	}
+	return list
+}
+
+// sanitizeText provides the text of the given metadata value stripped of control characters
+// and cut to the given max number of characters. Numbers and booleans are formatted as text.
+func sanitizeText(val interface{}, max int) string {
+	var s string
+	switch v := val.(type) {
+	case string:
+		s = v
+	case float64:
+		s = fmt.Sprintf("%g", v)
+	case bool:
+		s = fmt.Sprintf("%t", v)
+	default:
+		return ""
+	}
+
+	s = strings.Map(func(r rune) rune {
+		if r == utf8.RuneError || (unicode.IsControl(r) && r != '\n' && r != '\t') {
+			return -1
+		}
+		return r
+	}, s)
+	s = strings.TrimSpace(s)
+
+	if utf8.RuneCountInString(s) > max {
+		s = string([]rune(s)[:max])
+	}
+	return s
+}
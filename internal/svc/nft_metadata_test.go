@@ -0,0 +1,112 @@
+package svc
+
+import (
+	"fantom-api-graphql/internal/config"
+	"github.com/onsi/gomega"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeText(t *testing.T) {
+	tests := []struct {
+		name string
+		val  interface{}
+		max  int
+		want string
+	}{
+		{name: "plain text", val: "Fantom Punk #1", max: 256, want: "Fantom Punk #1"},
+		{name: "surrounding spaces", val: "  Punk \n", max: 256, want: "Punk"},
+		{name: "control characters", val: "Pu\x00nk\x1b[31m", max: 256, want: "Punk[31m"},
+		{name: "new lines and tabs kept", val: "line\none\tand two", max: 256, want: "line\none\tand two"},
+		{name: "invalid UTF-8", val: "Pu\xffnk", max: 256, want: "Punk"},
+		{name: "cut by characters", val: "žluťoučký kůň", max: 5, want: "žluťo"},
+		{name: "integer number", val: float64(42), max: 256, want: "42"},
+		{name: "decimal number", val: 1.5, max: 256, want: "1.5"},
+		{name: "boolean", val: true, max: 256, want: "true"},
+		{name: "object", val: map[string]interface{}{"a": "b"}, max: 256, want: ""},
+		{name: "list", val: []interface{}{"a"}, max: 256, want: ""},
+		{name: "missing", val: nil, max: 256, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			g.Expect(sanitizeText(tt.val, tt.max)).To(gomega.Equal(tt.want))
+		})
+	}
+}
+
+func TestNftMetadataResolve(t *testing.T) {
+	cfg = &config.Config{NftMetadata: config.NftMetadata{Gateway: "https://gateway.test/ipfs/"}}
+	defer func() {
+		cfg = nil
+	}()
+
+	tests := []struct {
+		name    string
+		uri     string
+		target  string // empty for rejected URIs
+		gateway bool
+	}{
+		{name: "https", uri: "https://example.com/meta/1.json", target: "https://example.com/meta/1.json"},
+		{name: "http with spaces", uri: " http://example.com/1 ", target: "http://example.com/1"},
+		{name: "ipfs", uri: "ipfs://QmHash/1.json", target: "https://gateway.test/ipfs/QmHash/1.json", gateway: true},
+		{name: "ipfs with ipfs prefix", uri: "ipfs://ipfs/QmHash/1.json", target: "https://gateway.test/ipfs/QmHash/1.json", gateway: true},
+		{name: "ipfs upper case scheme", uri: "IPFS://QmHash", target: "https://gateway.test/ipfs/QmHash", gateway: true},
+		{name: "empty ipfs path", uri: "ipfs://"},
+		{name: "no host", uri: "https:///meta.json"},
+		{name: "file scheme", uri: "file:///etc/passwd"},
+		{name: "data scheme", uri: "data:application/json;base64,e30="},
+		{name: "relative", uri: "/meta/1.json"},
+		{name: "empty", uri: ""},
+		{name: "too long", uri: "https://example.com/" + strings.Repeat("a", nftMaxUrlLength)},
+	}
+
+	nmf := nftMetadataFetcher{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+
+			target, gateway, err := nmf.resolve(tt.uri)
+			if tt.target == "" {
+				g.Expect(err).NotTo(gomega.BeNil())
+				return
+			}
+
+			g.Expect(err).To(gomega.BeNil())
+			g.Expect(target).To(gomega.Equal(tt.target))
+			g.Expect(gateway).To(gomega.Equal(tt.gateway))
+		})
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip     string
+		public bool
+	}{
+		{ip: "8.8.8.8", public: true},
+		{ip: "2001:4860:4860::8888", public: true},
+		{ip: "127.0.0.1"},
+		{ip: "::1"},
+		{ip: "0.0.0.0"},
+		{ip: "10.1.2.3"},
+		{ip: "172.16.0.1"},
+		{ip: "192.168.1.1"},
+		{ip: "100.64.0.1"},
+		{ip: "169.254.169.254"},
+		{ip: "fd00::1"},
+		{ip: "fe80::1"},
+		{ip: "::ffff:127.0.0.1"},
+		{ip: "224.0.0.1"},
+		{ip: "not an address"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			g.Expect(isPublicIP(net.ParseIP(tt.ip))).To(gomega.Equal(tt.public))
+		})
+	}
+}
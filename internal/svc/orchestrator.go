@@ -7,18 +7,34 @@ import (
 	"fmt"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	etc "github.com/ethereum/go-ethereum/core/types"
+	"math"
+	"sync/atomic"
+	"time"
 	"unsafe"
 )
 
 // orBlockCacheCapacity represents the capacity of the local block cache.
 const orBlockCacheCapacity = 50
 
+// orRateSampleTick represents the period of the processing rate sampling.
+const orRateSampleTick = 15 * time.Second
+
+// orRateSmoothing represents the weight of the most recent sample of the processing rate.
+const orRateSmoothing = 0.3
+
 // orchestrator implements service responsible for moderating connections between other services.
 type orchestrator struct {
 	service
 	blkCache          *ring.Ring
 	pushHeads         bool
 	inScanStateSwitch chan bool
+
+	// counters of the scanner state; accessed atomically
+	head     uint64
+	idle     int32
+	rate     uint64
+	lastDone uint64
+	lastTime time.Time
 }
 
 // name returns the name of the service used by manager.
@@ -39,9 +55,26 @@ func (or *orchestrator) init() {
 	or.mgr.bls.inDispatched = or.mgr.bld.outDispatched
 	or.inScanStateSwitch = or.mgr.bls.outStateSwitch
 
+	// connect the chain reindexer, if any
+	if or.mgr.rix != nil {
+		or.mgr.bld.inReindex = or.mgr.rix.outBlock
+	}
+
+	// connect the address watcher, if any
+	if or.mgr.wtc != nil {
+		or.mgr.wtc.inTransaction = or.mgr.trd.outWatch
+	}
+
+	// connect the stream publisher, if any
+	if or.mgr.pub != nil {
+		or.mgr.pub.inBlock = or.mgr.bld.outStream
+		or.mgr.pub.inTransaction = or.mgr.trd.outStream
+	}
+
 	// read initial block scanner state
 	// no need to worry about race condition, init() is called sequentially and this is the last one
 	or.pushHeads = or.mgr.bls.onIdle
+	or.setIdle(or.pushHeads)
 }
 
 // run starts the orchestrator service business
@@ -69,10 +102,17 @@ func (or *orchestrator) execute() {
 	// access the new heads queue
 	// it's filled with new heads as the connected node processes blocks from the network
 	heads := repo.ObservedHeaders()
+
+	// sample the processing rate periodically
+	rateTick := time.NewTicker(orRateSampleTick)
+	defer rateTick.Stop()
+
 	for {
 		select {
 		case <-or.sigStop:
 			return
+		case <-rateTick.C:
+			or.sampleRate()
 		case h, ok := <-heads:
 			if ok {
 				or.handleNewHead(h)
@@ -80,6 +120,7 @@ func (or *orchestrator) execute() {
 		case idle, ok := <-or.inScanStateSwitch:
 			if ok {
 				or.pushHeads = idle
+				or.setIdle(idle)
 				if idle {
 					or.unloadCache()
 				}
@@ -94,7 +135,9 @@ func (or *orchestrator) execute() {
 func (or *orchestrator) handleNewHead(h *etc.Header) {
 	// get the block
 	bn := h.Number.Uint64()
-	blk, err := repo.BlockByNumber((*hexutil.Uint64)(&bn))
+	atomic.StoreUint64(&or.head, bn)
+
+	blk, err := repo.BlockByNumber(chainContext(), (*hexutil.Uint64)(&bn))
 	if err != nil {
 		log.Errorf("block #%d not available; %s", bn, err.Error())
 		return
@@ -112,6 +155,44 @@ func (or *orchestrator) handleNewHead(h *etc.Header) {
 	or.blkCache.Add(unsafe.Pointer(blk))
 }
 
+// setIdle records the state of the block scanner.
+func (or *orchestrator) setIdle(idle bool) {
+	var v int32
+	if idle {
+		v = 1
+	}
+	atomic.StoreInt32(&or.idle, v)
+}
+
+// sampleRate updates the processing rate of the blocks by the progress
+// of the processed blocks checkpoint since the previous sample.
+func (or *orchestrator) sampleRate() {
+	done := or.mgr.bld.checkpoint.last()
+	now := time.Now()
+
+	// the first sample has nothing to compare with
+	if or.lastTime.IsZero() || done < or.lastDone {
+		or.lastDone, or.lastTime = done, now
+		return
+	}
+
+	rate := float64(done-or.lastDone) / now.Sub(or.lastTime).Seconds()
+	or.lastDone, or.lastTime = done, now
+
+	// smooth the rate so a single slow sample does not distort the estimate
+	prev := math.Float64frombits(atomic.LoadUint64(&or.rate))
+	if prev > 0 {
+		rate = orRateSmoothing*rate + (1-orRateSmoothing)*prev
+	}
+	atomic.StoreUint64(&or.rate, math.Float64bits(rate))
+}
+
+// state provides the most recent chain head observed, the scanner idle state,
+// and the processing rate in blocks per second.
+func (or *orchestrator) state() (head uint64, idle bool, rate float64) {
+	return atomic.LoadUint64(&or.head), atomic.LoadInt32(&or.idle) == 1, math.Float64frombits(atomic.LoadUint64(&or.rate))
+}
+
 // unloadCache pushes all the blocks currently stored in cache (e.g. blocks of the most recent heads)
 // into the block processing queue to make sure they get all processed, and we don't miss any
 // on block scanner full speed to idle transition (consistency feature, may not be needed).
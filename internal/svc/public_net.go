@@ -0,0 +1,103 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// privateNetworks represents the address ranges outgoing calls to user provided URLs never reach,
+// so a token URI, or a webhook URL can not reach services of the server private network.
+var privateNetworks = parseNetworks(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"100.64.0.0/10",
+	"169.254.0.0/16",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+// newPublicClient creates an HTTP client connecting only to public addresses.
+// The addresses are checked on the connection, after the host name is resolved, so a host name
+// resolving to a private address is rejected as well. Proxies are never used, since the proxy
+// address would be checked instead of the target.
+func newPublicClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy: nil,
+			DialContext: (&net.Dialer{
+				Timeout: timeout,
+				Control: publicAddressOnly,
+			}).DialContext,
+			MaxIdleConns:        100,
+			IdleConnTimeout:     90 * time.Second,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+	}
+}
+
+// IsPublicUrl checks if the given HTTP(S) URL points to a public address.
+// The host name is resolved and all its addresses must be public.
+func IsPublicUrl(ctx context.Context, u string) error {
+	pu, err := url.Parse(u)
+	if err != nil || pu.Hostname() == "" {
+		return fmt.Errorf("invalid URL")
+	}
+
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, pu.Hostname())
+	if err != nil {
+		return fmt.Errorf("can not resolve host %s", pu.Hostname())
+	}
+	for _, ip := range ips {
+		if !isPublicIP(ip.IP) {
+			return fmt.Errorf("host %s is not public", pu.Hostname())
+		}
+	}
+	return nil
+}
+
+// publicAddressOnly rejects connections to loopback, private and link-local addresses.
+func publicAddressOnly(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if !isPublicIP(net.ParseIP(host)) {
+		return fmt.Errorf("address %s not allowed", host)
+	}
+	return nil
+}
+
+// isPublicIP checks if the given IP address is a public unicast address.
+func isPublicIP(ip net.IP) bool {
+	if ip == nil || ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() || ip.IsMulticast() {
+		return false
+	}
+	for _, n := range privateNetworks {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseNetworks parses the given list of CIDR network ranges.
+func parseNetworks(cidr ...string) []*net.IPNet {
+	list := make([]*net.IPNet, len(cidr))
+	for i, c := range cidr {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			panic(err)
+		}
+		list[i] = n
+	}
+	return list
+}
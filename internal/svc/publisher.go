@@ -0,0 +1,159 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"context"
+	"encoding/json"
+	"fantom-api-graphql/internal/stream"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"strconv"
+)
+
+const (
+	// pubQueueCapacity represents the number of blocks and transactions waiting to be published.
+	pubQueueCapacity = 5000
+
+	// pubTopicBlocks represents the name of the topic of processed blocks.
+	pubTopicBlocks = "blocks"
+
+	// pubTopicTransactions represents the name of the topic of processed transactions.
+	pubTopicTransactions = "transactions"
+
+	// pubTopicLogs represents the name of the topic of processed event logs.
+	pubTopicLogs = "logs"
+)
+
+// publisher implements a service streaming processed blocks, transactions
+// and decoded event logs to the configured message broker.
+// All the messages are keyed by the block number, so a block and its content
+// share the same partition. Blocks replaced by a chain reorganization are published again.
+type publisher struct {
+	service
+	pub           stream.Publisher
+	inBlock       chan *types.Block
+	inTransaction chan *eventTrx
+}
+
+// pubLog represents a streamed event log record.
+type pubLog struct {
+	Block       hexutil.Uint64      `json:"blockNumber"`
+	Transaction common.Hash         `json:"transactionHash"`
+	Index       uint                `json:"logIndex"`
+	Address     common.Address      `json:"address"`
+	Topics      []common.Hash       `json:"topics"`
+	Data        hexutil.Bytes       `json:"data"`
+	Event       *types.DecodedEvent `json:"event,omitempty"`
+}
+
+// name returns the name of the service used by orchestrator.
+func (pub *publisher) name() string {
+	return "stream publisher"
+}
+
+// init prepares the stream publisher to perform its function.
+func (pub *publisher) init() {
+	pub.sigStop = make(chan bool, 1)
+}
+
+// run starts the stream publisher.
+func (pub *publisher) run() {
+	// make sure we are orchestrated
+	if pub.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", pub.name()))
+	}
+
+	pub.mgr.started(pub)
+	go pub.execute()
+}
+
+// close terminates the stream publisher.
+func (pub *publisher) close() {
+	if pub.sigStop != nil {
+		pub.sigStop <- true
+	}
+}
+
+// execute publishes incoming blocks and transactions.
+func (pub *publisher) execute() {
+	defer func() {
+		if err := pub.pub.Close(); err != nil {
+			log.Errorf("can not close stream publisher; %s", err.Error())
+		}
+
+		close(pub.sigStop)
+		pub.mgr.finished(pub)
+	}()
+
+	for {
+		select {
+		case <-pub.sigStop:
+			return
+		case blk, ok := <-pub.inBlock:
+			if !ok {
+				log.Noticef("block channel closed, terminating %s", pub.name())
+				return
+			}
+			pub.block(blk)
+		case evt, ok := <-pub.inTransaction:
+			if !ok {
+				log.Noticef("trx channel closed, terminating %s", pub.name())
+				return
+			}
+			pub.transaction(evt.blk, evt.trx)
+		}
+	}
+}
+
+// block publishes the processed block.
+func (pub *publisher) block(blk *types.Block) {
+	data, err := json.Marshal(blk)
+	if err != nil {
+		log.Errorf("can not encode block #%d; %s", uint64(blk.Number), err.Error())
+		return
+	}
+	pub.publish(pubTopicBlocks, uint64(blk.Number), data)
+}
+
+// transaction publishes the processed transaction and its event logs.
+func (pub *publisher) transaction(blk *types.Block, trx *types.Transaction) {
+	data, err := trx.Marshal()
+	if err != nil {
+		log.Errorf("can not encode trx %s; %s", trx.Hash.String(), err.Error())
+		return
+	}
+	pub.publish(pubTopicTransactions, uint64(blk.Number), data)
+
+	for i := range trx.Logs {
+		lg := &trx.Logs[i]
+		rec := pubLog{
+			Block:       blk.Number,
+			Transaction: trx.Hash,
+			Index:       lg.Index,
+			Address:     lg.Address,
+			Topics:      lg.Topics,
+			Data:        lg.Data,
+		}
+
+		// decode the event, if the contract ABI is known
+		if ev, err := repo.DecodeLog(context.Background(), lg); err == nil {
+			rec.Event = ev
+		}
+
+		data, err := json.Marshal(&rec)
+		if err != nil {
+			log.Errorf("can not encode log #%d of trx %s; %s", lg.Index, trx.Hash.String(), err.Error())
+			continue
+		}
+		pub.publish(pubTopicLogs, uint64(blk.Number), data)
+	}
+}
+
+// publish sends the message to the prefixed topic keyed by the block number.
+func (pub *publisher) publish(topic string, blk uint64, data []byte) {
+	if err := pub.pub.Publish(cfg.Stream.Prefix+topic, strconv.FormatUint(blk, 10), data); err != nil {
+		log.Errorf("can not publish to %s; %s", topic, err.Error())
+	}
+}
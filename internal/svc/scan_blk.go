@@ -2,10 +2,14 @@
 package svc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/metrics"
+	"fantom-api-graphql/internal/repository/rpc"
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"sync"
 	"time"
 )
 
@@ -26,6 +30,9 @@ const blsScanTickIdleDuration = 5 * time.Minute
 // will be slowed down naturally.
 const blsBlockBufferCapacity = 1000
 
+// blsBlockBatchSize represents the number of blocks loaded by a single RPC batch.
+const blsBlockBatchSize = 10
+
 // blsReScanHysteresis is the number of blocks we wait from dispatcher until a re-scan kicks in.
 const blsReScanHysteresis = 100
 
@@ -45,6 +52,13 @@ type blkScanner struct {
 	done           uint64
 }
 
+// chainContext provides the context of the calls loading the scanned blocks and transactions.
+// The calls are served by the active node, so the scanner sees a consistent chain
+// even if the read calls are balanced across the blockchain nodes.
+func chainContext() context.Context {
+	return rpc.WithActiveNode(context.Background())
+}
+
 // name returns the name of the service used by orchestrator.
 func (bls *blkScanner) name() string {
 	return "block scanner"
@@ -78,6 +92,9 @@ func (bls *blkScanner) run() {
 	log.Noticef("block scan starts at #%d", start)
 	bls.from = start
 	bls.next = start
+	if start > 0 {
+		bls.mgr.bld.checkpoint.reset(start - 1)
+	}
 
 	bls.mgr.started(bls)
 	go bls.execute()
@@ -95,20 +112,49 @@ func (bls *blkScanner) close() {
 }
 
 // boundaries provides the block scanner initial range.
+// The scan resumes after the last processed block, or at the first block
+// of the verified range with its transactions not stored completely.
 func (bls *blkScanner) boundaries() (uint64, error) {
-	// get the newest known transaction
-	lnb, err := repo.LastKnownBlock()
+	// get the last processed block
+	lnb, err := repo.LastKnownBlock(context.Background())
 	if err != nil {
-		log.Critical("can not scan blockchain; %s", err.Error())
+		log.Criticalf("can not scan blockchain; %s", err.Error())
 		return 0, err
 	}
+	if lnb == 0 {
+		return 0, nil
+	}
 
-	// apply re-scan
+	// get the range to be verified
+	from := uint64(0)
 	if lnb > bls.cfg.BlockScanReScan {
-		log.Debugf("last known block is #%d, re-scanning %d blocks", lnb, bls.cfg.BlockScanReScan)
-		lnb = lnb - bls.cfg.BlockScanReScan
+		from = lnb - bls.cfg.BlockScanReScan
+	}
+	log.Debugf("last processed block is #%d, verifying blocks since #%d", lnb, from)
+	return bls.verify(from, lnb)
+}
+
+// verify checks the stored transactions of the given range of blocks against the chain
+// and provides the first block to be scanned.
+func (bls *blkScanner) verify(from uint64, to uint64) (uint64, error) {
+	counts, err := repo.TransactionsCountByBlock(context.Background(), from, to)
+	if err != nil {
+		return from, nil
 	}
-	return lnb, nil
+
+	for bn := from; bn <= to; bn++ {
+		blk, err := repo.BlockByNumber(chainContext(), (*hexutil.Uint64)(&bn))
+		if err != nil {
+			log.Errorf("block #%d not available for verification; %s", bn, err.Error())
+			return bn, nil
+		}
+
+		if counts[bn] != len(blk.Txs) {
+			log.Noticef("block #%d has %d of %d transactions stored", bn, counts[bn], len(blk.Txs))
+			return bn, nil
+		}
+	}
+	return to + 1, nil
 }
 
 // execute scans blockchain blocks in the given range and push found blocks
@@ -148,7 +194,7 @@ func (bls *blkScanner) execute() {
 // It returns expected idle state to be used to transition if needed.
 func (bls *blkScanner) observe() bool {
 	// try to get the block height
-	bh, err := repo.BlockHeight()
+	bh, err := repo.BlockHeight(chainContext())
 	if err != nil {
 		log.Errorf("can not get current block height; %s", err.Error())
 		return false
@@ -158,6 +204,12 @@ func (bls *blkScanner) observe() bool {
 	// we use a hysteresis to delay state flip back to active scan
 	// we compare current block height with the latest known dispatched block number
 	target := bh.ToInt().Uint64()
+	if target > bls.done {
+		metrics.SetScannerLag(target - bls.done)
+	} else {
+		metrics.SetScannerLag(0)
+	}
+
 	if bls.onIdle && target < bls.done+blsReScanHysteresis {
 		bls.next = bls.done
 		bls.from = bls.done
@@ -215,19 +267,56 @@ func (bls *blkScanner) shift() {
 		return
 	}
 
-	// pull the current block
-	block, err := repo.BlockByNumber((*hexutil.Uint64)(&bls.next))
-	if err != nil {
-		log.Errorf("block #%d not available; %s", bls.next, err.Error())
-		return
+	// pull the next batch of blocks in parallel
+	for _, block := range bls.pull() {
+		// a block of the batch is missing; we will try again from it on the next tick
+		if block == nil {
+			return
+		}
+
+		// push the block for processing and advance to the next expected block
+		// observe possible stop signal during a wait for the block queue slot
+		select {
+		case bls.outBlock <- block:
+			bls.next++
+		case <-bls.sigStop:
+			bls.sigStop <- true
+			return
+		}
 	}
+}
 
-	// push the block for processing and advance to the next expected block
-	// observe possible stop signal during a wait for the block queue slot
-	select {
-	case bls.outBlock <- block:
-		bls.next++
-	case <-bls.sigStop:
-		bls.sigStop <- true
+// pull loads the next batch of blocks using parallel workers,
+// each of them loading a range of blocks in a single RPC batch.
+// Blocks not available are left empty in the batch, the order of blocks is kept.
+func (bls *blkScanner) pull() []*types.Block {
+	// how many blocks do we pull?
+	count := uint64(scanWorkers() * blsBlockBatchSize)
+	if bls.next+count > bls.to+1 {
+		count = bls.to + 1 - bls.next
 	}
+
+	batch := make([]*types.Block, count)
+	var wg sync.WaitGroup
+	for i := uint64(0); i < count; i += blsBlockBatchSize {
+		size := uint64(blsBlockBatchSize)
+		if i+size > count {
+			size = count - i
+		}
+
+		wg.Add(1)
+		go func(i uint64, size uint64) {
+			defer wg.Done()
+
+			blocks, err := repo.BlocksByNumber(chainContext(), bls.next+i, int(size))
+			if err != nil {
+				log.Errorf("blocks #%d to #%d not available; %s", bls.next+i, bls.next+i+size-1, err.Error())
+				return
+			}
+			copy(batch[i:], blocks)
+		}(i, size)
+	}
+
+	wg.Wait()
+	return batch
 }
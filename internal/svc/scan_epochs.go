@@ -2,6 +2,7 @@
 package svc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -48,7 +49,7 @@ func (eps *epochScanner) run() {
 
 	// try to find the last known epoch, we want to start where we left off
 	var err error
-	eps.current, err = repo.LastKnownEpoch()
+	eps.current, err = repo.LastKnownEpoch(context.Background())
 	if err != nil {
 		log.Criticalf("can not get the last known epoch; %s", err.Error())
 		eps.current = 1
@@ -105,7 +106,7 @@ func (eps *epochScanner) execute() {
 // updateState updates observed
 func (eps *epochScanner) observe() {
 	// what is the top epoch number
-	ep, err := repo.CurrentSealedEpoch()
+	ep, err := repo.CurrentSealedEpoch(context.Background())
 	if err != nil {
 		log.Criticalf("can not get sealed epoch; %s", err.Error())
 		return
@@ -129,7 +130,7 @@ func (eps *epochScanner) next() {
 	}
 
 	// try to get the epoch data
-	ep, err := repo.Epoch((*hexutil.Uint64)(&eps.current))
+	ep, err := repo.Epoch(context.Background(), (*hexutil.Uint64)(&eps.current))
 	if err != nil {
 		log.Errorf("can not get epoch #%d; %s", eps.current, err.Error())
 		return
@@ -170,8 +171,18 @@ func (eps *epochScanner) store(ep *types.Epoch) {
 	log.Debugf("processing epoch #%d", ep.Id)
 
 	// add the epoch to the database
-	err := repo.AddEpoch(ep)
+	err := repo.AddEpoch(context.Background(), ep)
 	if err != nil {
 		log.Errorf("can not store epoch #%d; %s", ep.Id, err.Error())
 	}
+
+	// keep the snapshot of validators at the end of the epoch
+	if err := repo.StoreEpochValidators(context.Background(), ep.Id); err != nil {
+		log.Errorf("can not store validators of epoch #%d; %s", ep.Id, err.Error())
+	}
+
+	// collect the performance of validators within the epoch
+	if err := repo.StoreEpochValidatorsPerformance(context.Background(), ep.Id); err != nil {
+		log.Errorf("can not store validators performance of epoch #%d; %s", ep.Id, err.Error())
+	}
 }
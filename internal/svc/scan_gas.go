@@ -2,6 +2,7 @@
 package svc
 
 import (
+	"context"
 	"fantom-api-graphql/internal/types"
 	"fmt"
 	"math"
@@ -126,7 +127,7 @@ func (gps *gpsMonitor) execute() {
 // read makes the reading on the current gas price suggestion from connected the Opera node.
 func (gps *gpsMonitor) read() {
 	// get the current suggested gas price value
-	gs, err := repo.GasPrice()
+	gs, err := repo.GasPrice(context.Background())
 	if err != nil {
 		log.Errorf("can not read the suggested gas price; %s", err.Error())
 		return
@@ -171,7 +172,7 @@ func (gps *gpsMonitor) flip() {
 // store this period worth of data into the persistent storage.
 func (gps *gpsMonitor) store(now time.Time) {
 	// prep and store the period data
-	err := repo.StoreGasPricePeriod(&types.GasPricePeriod{
+	err := repo.StoreGasPricePeriod(context.Background(), &types.GasPricePeriod{
 		Type:  types.GasPricePeriodTypeSuggestion,
 		Open:  gps.ticks[0],
 		Close: gps.ticks[gps.count-1],
@@ -0,0 +1,127 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"sort"
+	"time"
+)
+
+const (
+	// gasPriceSamplerInterval represents the interval in which we sample
+	// gas prices of recent transactions.
+	gasPriceSamplerInterval = 10 * time.Second
+
+	// gasPriceSamplerSize represents the number of recent transactions sampled.
+	gasPriceSamplerSize = 200
+
+	// gasPriceSlowPercentile represents the percentile of sampled gas prices used for slow suggestion.
+	gasPriceSlowPercentile = 30
+
+	// gasPriceStandardPercentile represents the percentile of sampled gas prices used for standard suggestion.
+	gasPriceStandardPercentile = 60
+
+	// gasPriceFastPercentile represents the percentile of sampled gas prices used for fast suggestion.
+	gasPriceFastPercentile = 90
+)
+
+// gpsSampler represents a service calculating gas price suggestions
+// from gas price percentiles of recent transactions.
+type gpsSampler struct {
+	service
+	ticker *time.Ticker
+}
+
+// name returns a human-readable name of the service used by the manager.
+func (gss *gpsSampler) name() string {
+	return "gas price sampler"
+}
+
+// run starts the gas price sampling.
+func (gss *gpsSampler) run() {
+	// make sure we are orchestrated
+	if gss.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", gss.name()))
+	}
+
+	// start go routine for processing
+	gss.mgr.started(gss)
+	go gss.execute()
+}
+
+// close terminates the gas price sampler.
+func (gss *gpsSampler) close() {
+	if gss.ticker != nil {
+		gss.ticker.Stop()
+	}
+	if gss.sigStop != nil {
+		gss.sigStop <- true
+	}
+}
+
+// execute performs regular sampling of recent transactions gas prices.
+func (gss *gpsSampler) execute() {
+	defer func() {
+		close(gss.sigStop)
+		gss.mgr.finished(gss)
+	}()
+
+	// start the sampling ticker
+	gss.ticker = time.NewTicker(gasPriceSamplerInterval)
+
+	// loop here
+	for {
+		select {
+		case <-gss.sigStop:
+			return
+		case <-gss.ticker.C:
+			gss.sample()
+		}
+	}
+}
+
+// sample collects gas prices of recent transactions and updates the suggestion.
+func (gss *gpsSampler) sample() {
+	// get the list of recent transactions
+	tl, err := repo.Transactions(context.Background(), nil, gasPriceSamplerSize, nil)
+	if err != nil {
+		log.Errorf("can not sample recent transactions; %s", err.Error())
+		return
+	}
+
+	// nothing to sample?
+	if len(tl.Collection) == 0 {
+		return
+	}
+
+	// collect and sort the gas prices
+	prices := make([]*big.Int, len(tl.Collection))
+	for i, trx := range tl.Collection {
+		prices[i] = trx.GasPrice.ToInt()
+	}
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Cmp(prices[j]) < 0
+	})
+
+	// store the suggestion
+	err = repo.StoreGasPriceSuggestion(context.Background(), &types.GasPriceSuggestion{
+		Slow:     percentile(prices, gasPriceSlowPercentile),
+		Standard: percentile(prices, gasPriceStandardPercentile),
+		Fast:     percentile(prices, gasPriceFastPercentile),
+		Samples:  int32(len(prices)),
+		Updated:  hexutil.Uint64(time.Now().Unix()),
+	})
+	if err != nil {
+		log.Errorf("can not store gas price suggestion; %s", err.Error())
+	}
+}
+
+// percentile picks the value at the given percentile of the sorted list of values.
+func percentile(sorted []*big.Int, pct int) hexutil.Big {
+	idx := (len(sorted) - 1) * pct / 100
+	return hexutil.Big(*sorted[idx])
+}
@@ -0,0 +1,50 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fmt"
+)
+
+// pendingMonitor represents a monitor of the node transaction pool
+// observing new pending transactions announced by the node.
+type pendingMonitor struct {
+	service
+}
+
+// name returns a human-readable name of the service used by the manager.
+func (pm *pendingMonitor) name() string {
+	return "pending transactions monitor"
+}
+
+// run starts the pending transactions monitor.
+func (pm *pendingMonitor) run() {
+	// make sure we are orchestrated
+	if pm.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", pm.name()))
+	}
+
+	// start go routine for processing
+	pm.mgr.started(pm)
+	go pm.execute()
+}
+
+// execute observes new pending transactions and signals the repository
+// the content of the node transaction pool changed.
+func (pm *pendingMonitor) execute() {
+	defer func() {
+		close(pm.sigStop)
+		pm.mgr.finished(pm)
+	}()
+
+	pending := repo.ObservedPendingTransactions()
+	for {
+		select {
+		case <-pm.sigStop:
+			return
+		case _, ok := <-pending:
+			if ok {
+				repo.PendingTransactionsChanged()
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"sync/atomic"
+	"time"
+)
+
+// reindexProgressTick represents the frequency of the chain replay progress report.
+const reindexProgressTick = 30 * time.Second
+
+// reindexer implements a service replaying the blockchain from a given block
+// to rebuild the indexed data. The replayed blocks are processed by the block dispatcher
+// alongside the live blocks, so the API keeps serving while the reindex runs.
+type reindexer struct {
+	service
+	outBlock chan *types.Block
+	from     uint64
+	to       uint64
+	next     uint64
+	start    time.Time
+	running  int32
+	done     chan struct{}
+}
+
+// name returns the name of the service used by orchestrator.
+func (rix *reindexer) name() string {
+	return "chain reindexer"
+}
+
+// init prepares the chain reindexer.
+func (rix *reindexer) init() {
+	rix.sigStop = make(chan bool, 1)
+	rix.done = make(chan struct{})
+	rix.outBlock = make(chan *types.Block, blsBlockBufferCapacity)
+}
+
+// run starts the chain reindexer.
+func (rix *reindexer) run() {
+	if rix.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", rix.name()))
+	}
+
+	// replay up to the current head, if the last block is not set
+	if rix.to == 0 {
+		bh, err := repo.BlockHeight(chainContext())
+		if err != nil {
+			log.Errorf("chain reindex can not proceed; %s", err.Error())
+			close(rix.outBlock)
+			close(rix.done)
+			return
+		}
+		rix.to = bh.ToInt().Uint64()
+	}
+
+	log.Noticef("chain reindex of blocks #%d to #%d starts", rix.from, rix.to)
+	rix.next = rix.from
+	rix.start = time.Now()
+	atomic.StoreInt32(&rix.running, 1)
+
+	rix.mgr.started(rix)
+	go rix.execute()
+}
+
+// close signals the chain reindexer to terminate, if it's still running.
+func (rix *reindexer) close() {
+	select {
+	case rix.sigStop <- true:
+	case <-rix.done:
+	}
+}
+
+// isRunning checks if the chain reindex is in progress.
+func (rix *reindexer) isRunning() bool {
+	return atomic.LoadInt32(&rix.running) == 1
+}
+
+// status provides the range of the chain reindex and the next block to be replayed.
+func (rix *reindexer) status() (from uint64, to uint64, next uint64) {
+	return rix.from, rix.to, atomic.LoadUint64(&rix.next)
+}
+
+// execute replays the blocks of the range and pushes them to the output channel for processing.
+func (rix *reindexer) execute() {
+	defer func() {
+		atomic.StoreInt32(&rix.running, 0)
+		close(rix.done)
+		close(rix.outBlock)
+		rix.mgr.finished(rix)
+	}()
+
+	tick := time.NewTicker(reindexProgressTick)
+	defer tick.Stop()
+
+	for rix.next <= rix.to {
+		// pull the current block
+		num := hexutil.Uint64(rix.next)
+		block, err := repo.BlockByNumber(chainContext(), &num)
+		if err != nil {
+			log.Errorf("block #%d not available for reindex; %s", rix.next, err.Error())
+			return
+		}
+
+		// report the progress, if it's time to do so
+		select {
+		case <-tick.C:
+			rix.report()
+		default:
+		}
+
+		// push the block for processing; observe the stop signal
+		select {
+		case <-rix.sigStop:
+			log.Noticef("chain reindex stopped at #%d", rix.next)
+			return
+		case rix.outBlock <- block:
+			atomic.AddUint64(&rix.next, 1)
+		}
+	}
+
+	log.Noticef("chain reindex of blocks #%d to #%d done in %s", rix.from, rix.to, time.Since(rix.start).String())
+}
+
+// report logs the progress of the chain reindex.
+func (rix *reindexer) report() {
+	done := atomic.LoadUint64(&rix.next) - rix.from
+	total := rix.to - rix.from + 1
+	rate := float64(done) / time.Since(rix.start).Seconds()
+
+	// estimate the remaining time
+	var eta time.Duration
+	if rate > 0 {
+		eta = time.Duration(float64(total-done)/rate) * time.Second
+	}
+	log.Noticef("chain reindex at #%d of <#%d, #%d>, %.1f%% done, %.1f blocks/s, ETA %s",
+		rix.next, rix.from, rix.to, 100*float64(done)/float64(total), rate, eta.String())
+}
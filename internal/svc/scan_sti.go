@@ -2,6 +2,7 @@
 package svc
 
 import (
+	"context"
 	"fmt"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"math/big"
@@ -76,7 +77,7 @@ func (sti *stiScanner) syncTop() error {
 	// the top is updated on the scan loop reset only
 	if sti.current == 0 {
 		// the last staker id will be used as the new top
-		ls, err := repo.LastValidatorId()
+		ls, err := repo.LastValidatorId(context.Background())
 		if err != nil {
 			log.Errorf("last staker id not available; %s", err.Error())
 			return err
@@ -102,9 +103,9 @@ func (sti *stiScanner) next() {
 	// request the staker information
 	log.Debugf("loading staker #%d information", sti.current)
 	stakerID := new(big.Int).SetUint64(sti.current)
-	info, err := repo.PullStakerInfo((*hexutil.Big)(stakerID))
+	info, err := repo.PullStakerInfo(context.Background(), (*hexutil.Big)(stakerID))
 	if err == nil && info != nil {
-		err = repo.StoreStakerInfo((*hexutil.Big)(stakerID), info)
+		err = repo.StoreStakerInfo(context.Background(), (*hexutil.Big)(stakerID), info)
 	}
 
 	// anything failed?
@@ -2,6 +2,7 @@
 package svc
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
@@ -15,7 +16,7 @@ const (
 	trxCountUpdaterPeriod = 30 * time.Minute
 )
 
-// trxFlowMonitor represents a service for transaction flow monitoring.
+// trxFlowMonitor represents a service for transaction flow and block time monitoring.
 type trxFlowMonitor struct {
 	service
 	flowTicker  *time.Ticker
@@ -71,7 +72,9 @@ func (tfm *trxFlowMonitor) execute() {
 		case <-tfm.sigStop:
 			return
 		case <-tfm.flowTicker.C:
-			repo.TrxFlowUpdate()
+			repo.TrxFlowUpdate(context.Background())
+			repo.BlockTimeDailyUpdate(context.Background())
+			repo.ContractUsageUpdate(context.Background())
 		case <-tfm.countTicker.C:
 			go tfm.updateCount()
 		}
@@ -81,7 +84,7 @@ func (tfm *trxFlowMonitor) execute() {
 // updateCount updates trx counter estimation.
 func (tfm *trxFlowMonitor) updateCount() {
 	// pull the value from DB
-	val, err := repo.TransactionsCount()
+	val, err := repo.TransactionsCount(context.Background())
 	if err != nil {
 		log.Errorf("can not update trx count estimation; %s", err.Error())
 		return
@@ -0,0 +1,188 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"context"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// watchRefreshTick represents the period of the watch list refresh.
+	watchRefreshTick = 30 * time.Second
+
+	// watchQueueCapacity represents the number of notifications waiting for delivery.
+	watchQueueCapacity = 5000
+
+	// watchTrxQueueCapacity represents the number of transactions waiting for the watch list check.
+	watchTrxQueueCapacity = 5000
+)
+
+var (
+	// watchTopicErcTransfer represents the topic of ERC20/ERC721 Transfer event.
+	watchTopicErcTransfer = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+	// watchTopicErc1155TransferSingle represents the topic of ERC1155 TransferSingle event.
+	watchTopicErc1155TransferSingle = common.HexToHash("0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62")
+
+	// watchTopicErc1155TransferBatch represents the topic of ERC1155 TransferBatch event.
+	watchTopicErc1155TransferBatch = common.HexToHash("0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb")
+)
+
+// watcher implements a service comparing processed transactions against the list of watched addresses
+// and notifying the registered webhooks about the events found.
+type watcher struct {
+	service
+	inTransaction chan *eventTrx
+	queue         chan *watchDelivery
+	client        *http.Client
+	watches       map[common.Address][]*types.Watch
+	refreshTick   *time.Ticker
+	retries       watchRetryQueue
+	retriesMu     sync.Mutex
+	wakeRetry     chan struct{}
+	workers       sync.WaitGroup
+	scheduler     sync.WaitGroup
+	done          chan struct{}
+}
+
+// watchDelivery represents a notification to be delivered to a webhook.
+type watchDelivery struct {
+	watch   *types.Watch
+	note    *types.WatchNotification
+	attempt int
+	due     time.Time
+}
+
+// name returns the name of the service used by orchestrator.
+func (wtc *watcher) name() string {
+	return "address watcher"
+}
+
+// init prepares the address watcher to perform its function.
+func (wtc *watcher) init() {
+	wtc.sigStop = make(chan bool, 1)
+	wtc.queue = make(chan *watchDelivery, watchQueueCapacity)
+	wtc.done = make(chan struct{})
+	wtc.client = newPublicClient(cfg.Watch.Timeout)
+	wtc.wakeRetry = make(chan struct{}, 1)
+	wtc.watches = make(map[common.Address][]*types.Watch)
+}
+
+// run starts the address watcher.
+func (wtc *watcher) run() {
+	// make sure we are orchestrated
+	if wtc.mgr == nil {
+		panic(fmt.Errorf("no svc manager set on %s", wtc.name()))
+	}
+
+	// load the watch list and start the delivery workers and the retry scheduler
+	wtc.refresh()
+	for i := 0; i < cfg.Watch.Workers; i++ {
+		wtc.workers.Add(1)
+		go wtc.deliver()
+	}
+	wtc.scheduler.Add(1)
+	go wtc.schedule()
+
+	wtc.refreshTick = time.NewTicker(watchRefreshTick)
+	wtc.mgr.started(wtc)
+	go wtc.execute()
+}
+
+// close terminates the address watcher.
+func (wtc *watcher) close() {
+	if wtc.refreshTick != nil {
+		wtc.refreshTick.Stop()
+	}
+	if wtc.sigStop != nil {
+		wtc.sigStop <- true
+	}
+}
+
+// execute checks incoming transactions against the watch list.
+func (wtc *watcher) execute() {
+	defer func() {
+		// stop the retry scheduler first, it feeds the queue; wait for the delivery workers to finish
+		close(wtc.done)
+		wtc.scheduler.Wait()
+		close(wtc.queue)
+		wtc.workers.Wait()
+
+		close(wtc.sigStop)
+		wtc.mgr.finished(wtc)
+	}()
+
+	for {
+		select {
+		case <-wtc.sigStop:
+			return
+		case <-wtc.refreshTick.C:
+			wtc.refresh()
+		case evt, ok := <-wtc.inTransaction:
+			if !ok {
+				log.Noticef("trx channel closed, terminating %s", wtc.name())
+				return
+			}
+			wtc.check(evt.blk, evt.trx)
+		}
+	}
+}
+
+// refresh re-loads the list of watched addresses.
+func (wtc *watcher) refresh() {
+	list, err := repo.Watches(context.Background(), nil)
+	if err != nil {
+		log.Errorf("can not load watch list; %s", err.Error())
+		return
+	}
+
+	watches := make(map[common.Address][]*types.Watch)
+	for _, w := range list {
+		adr := common.HexToAddress(w.Address)
+		watches[adr] = append(watches[adr], w)
+	}
+	wtc.watches = watches
+}
+
+// check compares the transaction and its logs against the watch list and queues notifications
+// for all the matching watches.
+func (wtc *watcher) check(blk *types.Block, trx *types.Transaction) {
+	if len(wtc.watches) == 0 {
+		return
+	}
+
+	// the transaction itself
+	wtc.notify(trx.From, types.WatchEventOutgoingTrx, blk, trx, nil)
+	if trx.To != nil {
+		wtc.notify(*trx.To, types.WatchEventIncomingTrx, blk, trx, nil)
+	}
+
+	// logs of token transfers and delegation changes
+	for i := range trx.Logs {
+		lg := &trx.Logs[i]
+		if len(lg.Topics) < 2 {
+			continue
+		}
+
+		var adr []common.Hash
+		event := types.WatchEventTokenTransfer
+		switch {
+		case lg.Topics[0] == watchTopicErcTransfer && len(lg.Topics) >= 3:
+			adr = lg.Topics[1:3]
+		case (lg.Topics[0] == watchTopicErc1155TransferSingle || lg.Topics[0] == watchTopicErc1155TransferBatch) && len(lg.Topics) >= 4:
+			adr = lg.Topics[2:4]
+		case repo.IsSfcContract(context.Background(), &lg.Address):
+			adr = lg.Topics[1:2]
+			event = types.WatchEventDelegation
+		}
+
+		for _, a := range adr {
+			wtc.notify(common.BytesToAddress(a.Bytes()), event, blk, trx, lg)
+		}
+	}
+}
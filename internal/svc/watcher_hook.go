@@ -0,0 +1,143 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fantom-api-graphql/internal/types"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	retypes "github.com/ethereum/go-ethereum/core/types"
+	"net/http"
+	"time"
+)
+
+const (
+	// watchRetryBaseDelay represents the delay before the first retry of a failed delivery;
+	// the delay doubles with each next attempt.
+	watchRetryBaseDelay = time.Second
+
+	// watchRetryMaxDelay represents the max delay between delivery attempts.
+	watchRetryMaxDelay = time.Minute
+
+	// watchSignatureHeader represents the HTTP header carrying the payload signature.
+	watchSignatureHeader = "X-Signature"
+
+	// watchIdHeader represents the HTTP header carrying the watch identifier.
+	watchIdHeader = "X-Watch-Id"
+
+	// watchEventIdHeader represents the HTTP header carrying the event identifier.
+	watchEventIdHeader = "X-Event-Id"
+)
+
+// notify queues notifications of the event of the address for all the watches interested.
+// Watches registered after the transaction are skipped, so re-scanned blocks
+// do not notify new watches about historical events. The notifications are dropped
+// if the delivery queue is full, slow webhooks never hold the transactions processing.
+func (wtc *watcher) notify(adr common.Address, event string, blk *types.Block, trx *types.Transaction, lg *retypes.Log) {
+	for _, w := range wtc.watches[adr] {
+		if !w.Wants(event) || trx.TimeStamp.Before(w.Created) {
+			continue
+		}
+
+		note := types.WatchNotification{
+			Id:          fmt.Sprintf("%s:%s:%s", trx.Hash.String(), event, adr.String()),
+			Watch:       w.Id,
+			Event:       event,
+			Address:     w.Address,
+			Transaction: trx.Hash.String(),
+			Block:       uint64(blk.Number),
+			TimeStamp:   int64(blk.TimeStamp),
+		}
+		if lg != nil {
+			con := lg.Address.String()
+			note.Contract = &con
+			note.LogIndex = &lg.Index
+			note.Id = fmt.Sprintf("%s:%d", note.Id, lg.Index)
+		}
+
+		select {
+		case wtc.queue <- &watchDelivery{watch: w, note: &note}:
+		default:
+			log.Warningf("watch delivery queue full, notification %s dropped", note.Id)
+		}
+	}
+}
+
+// deliver sends queued notifications to the webhooks.
+func (wtc *watcher) deliver() {
+	defer wtc.workers.Done()
+	for wd := range wtc.queue {
+		wtc.send(wd)
+	}
+}
+
+// send makes a delivery attempt of the notification to the webhook. Failed attempts are scheduled
+// for a retry with an exponential back-off up to the configured number of attempts,
+// so the worker is free to deliver other notifications in the meantime.
+func (wtc *watcher) send(wd *watchDelivery) {
+	body, err := json.Marshal(wd.note)
+	if err != nil {
+		log.Errorf("can not encode watch notification %s; %s", wd.note.Id, err.Error())
+		return
+	}
+
+	wd.attempt++
+	err = wtc.post(wd, body)
+	if err == nil {
+		log.Debugf("watch notification %s delivered to %s", wd.note.Id, wd.watch.Url)
+		return
+	}
+
+	if wd.attempt >= cfg.Watch.Retries {
+		log.Warningf("watch notification %s not delivered to %s; %s", wd.note.Id, wd.watch.Url, err.Error())
+		return
+	}
+	log.Debugf("watch notification %s attempt #%d failed; %s", wd.note.Id, wd.attempt, err.Error())
+
+	// the delay doubles with each attempt
+	delay := watchRetryBaseDelay << uint(wd.attempt-1)
+	if delay > watchRetryMaxDelay || delay <= 0 {
+		delay = watchRetryMaxDelay
+	}
+	wd.due = time.Now().Add(delay)
+	wtc.retry(wd)
+}
+
+// post makes a single delivery attempt of the signed notification.
+func (wtc *watcher) post(wd *watchDelivery, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, wd.watch.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(watchIdHeader, wd.watch.Id)
+	req.Header.Set(watchEventIdHeader, wd.note.Id)
+	req.Header.Set(watchSignatureHeader, watchSignature(wd.watch.Secret, body))
+
+	res, err := wtc.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			log.Errorf("can not close webhook response body; %s", err.Error())
+		}
+	}()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+	return nil
+}
+
+// watchSignature provides the HMAC-SHA256 signature of the payload with the watch secret.
+func watchSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
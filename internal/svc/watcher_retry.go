@@ -0,0 +1,103 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"container/heap"
+	"time"
+)
+
+// watchRetryCapacity represents the max number of failed notifications waiting for the next attempt.
+const watchRetryCapacity = 5000
+
+// watchRetryQueue implements a heap of failed notifications ordered by the time of the next attempt.
+type watchRetryQueue []*watchDelivery
+
+// Len returns the number of notifications waiting for the next attempt.
+func (q watchRetryQueue) Len() int { return len(q) }
+
+// Less compares the time of the next attempt of the notifications.
+func (q watchRetryQueue) Less(i, j int) bool { return q[i].due.Before(q[j].due) }
+
+// Swap swaps the notifications on the given positions.
+func (q watchRetryQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+// Push adds a notification to the queue.
+func (q *watchRetryQueue) Push(x interface{}) { *q = append(*q, x.(*watchDelivery)) }
+
+// Pop removes the last notification of the queue.
+func (q *watchRetryQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	wd := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return wd
+}
+
+// retry schedules the failed notification for the next delivery attempt.
+// The notification is dropped if too many notifications already wait for a retry.
+func (wtc *watcher) retry(wd *watchDelivery) {
+	wtc.retriesMu.Lock()
+	if wtc.retries.Len() >= watchRetryCapacity {
+		wtc.retriesMu.Unlock()
+		log.Warningf("watch retry queue full, notification %s dropped", wd.note.Id)
+		return
+	}
+	heap.Push(&wtc.retries, wd)
+	wtc.retriesMu.Unlock()
+
+	// wake up the scheduler, the new notification may be due sooner than the others
+	select {
+	case wtc.wakeRetry <- struct{}{}:
+	default:
+	}
+}
+
+// schedule moves failed notifications back to the delivery queue once their next attempt is due.
+func (wtc *watcher) schedule() {
+	defer wtc.scheduler.Done()
+
+	timer := time.NewTimer(watchRetryMaxDelay)
+	defer timer.Stop()
+
+	for {
+		wait := wtc.requeue()
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+
+		select {
+		case <-wtc.done:
+			return
+		case <-wtc.wakeRetry:
+		case <-timer.C:
+		}
+	}
+}
+
+// requeue passes due notifications to the delivery queue and provides the time
+// to wait for the next notification to become due.
+func (wtc *watcher) requeue() time.Duration {
+	wtc.retriesMu.Lock()
+	defer wtc.retriesMu.Unlock()
+
+	for wtc.retries.Len() > 0 {
+		wait := time.Until(wtc.retries[0].due)
+		if wait > 0 {
+			return wait
+		}
+
+		// the delivery queue is full; try again later
+		select {
+		case wtc.queue <- wtc.retries[0]:
+			heap.Pop(&wtc.retries)
+		default:
+			return watchRetryBaseDelay
+		}
+	}
+	return watchRetryMaxDelay
+}
@@ -0,0 +1,25 @@
+// Package svc implements blockchain data processing services.
+package svc
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// workerLaneCapacity represents the capacity of a single worker lane queue.
+const workerLaneCapacity = 100
+
+// scanWorkers provides the configured number of parallel workers
+// of each block processing stage.
+func scanWorkers() int {
+	if cfg == nil || cfg.RepoCommand.ScanWorkers < 1 {
+		return 1
+	}
+	return cfg.RepoCommand.ScanWorkers
+}
+
+// laneOf provides the index of the worker lane responsible for the given address.
+// All the work related to the same address goes through the same lane,
+// so it's processed in the order it arrived.
+func laneOf(adr *common.Address, lanes int) int {
+	return (int(adr[common.AddressLength-2])<<8 | int(adr[common.AddressLength-1])) % lanes
+}
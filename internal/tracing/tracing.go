@@ -0,0 +1,96 @@
+// Package tracing implements distributed tracing of the API requests
+// across the GraphQL resolvers, the repository and the backend bridges.
+package tracing
+
+import (
+	"context"
+	"fantom-api-graphql/internal/config"
+	"fantom-api-graphql/internal/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/jaeger"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.7.0"
+	"go.opentelemetry.io/otel/trace"
+	"time"
+)
+
+// tracerName represents the name of the tracer creating the API server spans.
+const tracerName = "fantom-api-graphql"
+
+// shutdownTimeout represents the time limit for flushing pending spans on termination.
+const shutdownTimeout = 5 * time.Second
+
+// provider represents the configured trace provider, if any.
+var provider *sdktrace.TracerProvider
+
+// Setup configures the trace provider exporting spans to the configured Jaeger collector.
+// The tracing stays disabled if not enabled by the configuration.
+func Setup(cfg *config.Config, log logger.Logger) {
+	if !cfg.Tracing.Enabled {
+		log.Debugf("distributed tracing disabled")
+		return
+	}
+
+	exp, err := jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(cfg.Tracing.Endpoint)))
+	if err != nil {
+		log.Errorf("can not create trace exporter; %s", err.Error())
+		return
+	}
+
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.Tracing.Sample))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(cfg.AppName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	log.Noticef("distributed tracing enabled, exporting to %s", cfg.Tracing.Endpoint)
+}
+
+// Close flushes pending spans and terminates the trace provider.
+func Close(log logger.Logger) {
+	if provider == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := provider.Shutdown(ctx); err != nil {
+		log.Errorf("can not flush trace spans; %s", err.Error())
+	}
+}
+
+// Root starts a new span of an incoming request; the span continues the trace of the caller
+// if the trace context has been extracted into the given context.
+func Root(ctx context.Context, name string, attr ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attr...))
+}
+
+// Start starts a new child span of the span in the given context.
+// No span is started if the context does not carry a recording span, so the background
+// processing calls do not create orphan traces.
+func Start(ctx context.Context, name string, attr ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attr...))
+}
+
+// Finish ends the given span recording the error, if any.
+func Finish(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,39 @@
+// Package types implements different core types of the API.
+package types
+
+// DecodedArgument represents a single argument of a contract function call,
+// or an event, decoded using the contract ABI.
+type DecodedArgument struct {
+	// Name represents the name of the argument as defined in the ABI.
+	Name string `json:"name"`
+
+	// Type represents the Solidity type of the argument, i.e. "uint256".
+	Type string `json:"type"`
+
+	// Value represents the textual representation of the argument value.
+	Value string `json:"value"`
+}
+
+// DecodedCall represents a contract function call decoded using the contract ABI.
+type DecodedCall struct {
+	// Name represents the name of the function called.
+	Name string `json:"name"`
+
+	// Signature represents the canonical signature of the function, i.e. "transfer(address,uint256)".
+	Signature string `json:"signature"`
+
+	// Arguments represents the list of decoded call arguments.
+	Arguments []DecodedArgument `json:"args"`
+}
+
+// DecodedEvent represents a contract event log record decoded using the contract ABI.
+type DecodedEvent struct {
+	// Name represents the name of the event.
+	Name string `json:"name"`
+
+	// Signature represents the canonical signature of the event, i.e. "Transfer(address,address,uint256)".
+	Signature string `json:"signature"`
+
+	// Arguments represents the list of decoded event arguments, both indexed and not indexed.
+	Arguments []DecodedArgument `json:"args"`
+}
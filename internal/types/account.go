@@ -34,6 +34,8 @@ type Account struct {
 	Type         string         `json:"type"`
 	LastActivity hexutil.Uint64 `json:"ats"`
 	TrxCounter   hexutil.Uint64 `json:"trc"`
+	FirstBlock   hexutil.Uint64 `json:"fab"`
+	FirstSeen    hexutil.Uint64 `json:"fat"`
 }
 
 // UnmarshalAccount parses the JSON-encoded account data.
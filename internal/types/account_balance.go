@@ -0,0 +1,35 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// AccountBalance represents a known balance of an account
+// as recorded by the blockchain scanner.
+type AccountBalance struct {
+	// Address represents the address of the account.
+	Address common.Address
+
+	// Balance represents the balance of the account in WEI.
+	Balance hexutil.Big
+
+	// Rank represents the position of the account sorted by balance, starting at 1.
+	Rank uint64
+}
+
+// AccountBalanceList represents a list of accounts sorted by their balance.
+type AccountBalanceList struct {
+	// Collection keeps the actual list of balances.
+	Collection []*AccountBalance
+
+	// Total indicates total number of accounts in the whole collection.
+	Total uint64
+
+	// IsStart indicates there are no accounts with higher balance.
+	IsStart bool
+
+	// IsEnd indicates there are no more accounts with lower balance.
+	IsEnd bool
+}
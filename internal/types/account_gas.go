@@ -0,0 +1,19 @@
+// Package types implements different core types of the API.
+package types
+
+// AccountGasStats represents a summary of the gas used and the fees paid
+// by the transactions sent from an account.
+type AccountGasStats struct {
+	// Transactions represents the number of transactions sent from the account.
+	Transactions int64 `bson:"trx"`
+
+	// GasUsed represents the total amount of gas used by the transactions.
+	GasUsed int64 `bson:"gas"`
+
+	// Fee represents the total fee paid with the precision reduced by TransactionGasCorrection.
+	Fee int64 `bson:"fee"`
+
+	// AvgGasPrice represents the average gas price of the transactions
+	// with the precision reduced by TransactionGasCorrection.
+	AvgGasPrice float64 `bson:"price"`
+}
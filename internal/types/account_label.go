@@ -0,0 +1,41 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	FiAccountLabelPk       = "_id"
+	FiAccountLabelCategory = "cat"
+	FiAccountLabelName     = "name"
+)
+
+// AccountLabel represents a name and a category of a known address,
+// e.g. an exchange hot wallet, a bridge, or a team wallet.
+type AccountLabel struct {
+	// Address represents the labeled address.
+	Address string `bson:"_id" json:"address"`
+
+	// Name represents the name of the address owner.
+	Name string `bson:"name" json:"name"`
+
+	// Category represents the category of the address.
+	Category string `bson:"cat" json:"cat"`
+
+	// Updated represents the time of the last label update.
+	Updated time.Time `bson:"upd" json:"upd"`
+}
+
+// UnmarshalAccountLabel parses the JSON-encoded account label data.
+func UnmarshalAccountLabel(data []byte) (*AccountLabel, error) {
+	var al AccountLabel
+	err := json.Unmarshal(data, &al)
+	return &al, err
+}
+
+// Marshal returns the JSON encoding of account label.
+func (al *AccountLabel) Marshal() ([]byte, error) {
+	return json.Marshal(al)
+}
@@ -0,0 +1,26 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// AccountStateProof represents the Merkle proof of an account state
+// and of the selected storage slots of the account.
+type AccountStateProof struct {
+	Address      common.Address  `json:"address"`
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	Balance      hexutil.Big     `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+	StorageProof []StorageProof  `json:"storageProof"`
+}
+
+// StorageProof represents the Merkle proof of a single storage slot of an account.
+type StorageProof struct {
+	Key   string          `json:"key"`
+	Value hexutil.Big     `json:"value"`
+	Proof []hexutil.Bytes `json:"proof"`
+}
@@ -0,0 +1,46 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+const (
+	FiApiKeyPk      = "_id"
+	FiApiKeyRevoked = "revoked"
+)
+
+// ApiKey represents an API access key of a client with its usage limits.
+// Only the hash of the key is stored, the key itself is known to the client only.
+type ApiKey struct {
+	// Hash represents the SHA-256 hash of the key.
+	Hash string `bson:"_id"`
+
+	// Name represents the name of the client the key was issued to.
+	Name string `bson:"name"`
+
+	// RateLimit represents the max number of requests per minute.
+	RateLimit int32 `bson:"rate"`
+
+	// DailyQuota represents the max number of requests per day; zero for unlimited.
+	DailyQuota int64 `bson:"quota"`
+
+	// Created represents the time the key was issued.
+	Created time.Time `bson:"created"`
+
+	// Revoked represents the time the key was revoked; nil for active keys.
+	Revoked *time.Time `bson:"revoked"`
+}
+
+// ApiKeyHash provides the hash identifying the given API key.
+func ApiKeyHash(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(h[:])
+}
+
+// IsActive checks if the API key can be used.
+func (k *ApiKey) IsActive() bool {
+	return k.Revoked == nil
+}
@@ -0,0 +1,31 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"time"
+)
+
+const (
+	FiApiKeyUsageKey = "key"
+	FiApiKeyUsageDay = "day"
+)
+
+// ApiKeyUsage represents the usage of an API key over a single day.
+type ApiKeyUsage struct {
+	// Key represents the hash of the API key.
+	Key string `bson:"key"`
+
+	// Day represents the UTC day of the usage.
+	Day time.Time `bson:"day"`
+
+	// Queries represents the number of queries executed by the key owner.
+	Queries int64 `bson:"queries"`
+
+	// Cost represents the cumulative estimated cost of the executed queries.
+	Cost int64 `bson:"cost"`
+}
+
+// ApiKeyUsageId provides the identifier of the usage record of the given API key hash and day.
+func ApiKeyUsageId(key string, day time.Time) string {
+	return key + ":" + day.Format("2006-01-02")
+}
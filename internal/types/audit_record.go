@@ -0,0 +1,33 @@
+// Package types implements different core types of the API.
+package types
+
+import "time"
+
+const (
+	FiAuditRecordClient    = "client"
+	FiAuditRecordOperation = "op"
+)
+
+// AuditRecord represents a single executed GraphQL operation recorded in the audit log.
+type AuditRecord struct {
+	// Operation represents the name of the executed operation; empty for anonymous operations.
+	Operation string `bson:"op"`
+
+	// VariablesHash represents the SHA-256 hash of the operation variables.
+	VariablesHash string `bson:"vars"`
+
+	// Client represents the hash of the API key of the client; empty for anonymous clients.
+	Client string `bson:"client"`
+
+	// IsAdmin signals the operation was executed by the API server administrator.
+	IsAdmin bool `bson:"admin"`
+
+	// Duration represents the duration of the operation execution in milliseconds.
+	Duration int64 `bson:"dur"`
+
+	// Errors represents the errors of the operation execution, if any.
+	Errors []string `bson:"errors"`
+
+	// Executed represents the time the operation execution finished.
+	Executed time.Time `bson:"ts"`
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
 )
 
 // Block represents basic information provided by the API about block inside Opera blockchain.
@@ -39,6 +40,9 @@ type Block struct {
 	// TimeStamp represents the unix timestamp for when the block was collated.
 	TimeStamp hexutil.Uint64 `json:"timestamp"`
 
+	// TimeStampNano represents the unix timestamp for when the block was collated in nanoseconds.
+	TimeStampNano hexutil.Uint64 `json:"timestampNano"`
+
 	// Txs represents array of 32 bytes hashes of transactions included in the block.
 	Txs []*common.Hash `json:"transactions"`
 }
@@ -50,6 +54,14 @@ func UnmarshalBlock(data []byte) (*Block, error) {
 	return &blk, err
 }
 
+// Created returns the time the block was collated; the nanosecond time stamp is used, if available.
+func (b *Block) Created() time.Time {
+	if b.TimeStampNano > 0 {
+		return time.Unix(0, int64(b.TimeStampNano))
+	}
+	return time.Unix(int64(b.TimeStamp), 0)
+}
+
 // Marshal returns the JSON encoding of block.
 func (b *Block) Marshal() ([]byte, error) {
 	return json.Marshal(b)
@@ -0,0 +1,11 @@
+// Package types implements different core types of the API.
+package types
+
+import "github.com/ethereum/go-ethereum/common"
+
+// BlockRollback represents the state affected by blocks removed after a chain reorganization,
+// which can not be rebuilt from the remaining records and has to be refreshed from the node.
+type BlockRollback struct {
+	// Accounts represents the senders and the recipients of the removed transactions.
+	Accounts []common.Address
+}
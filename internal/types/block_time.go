@@ -0,0 +1,74 @@
+// Package types implements different core types of the API.
+package types
+
+import "time"
+
+const (
+	// FiBlockTimePk is the name of the primary key field of the block time record, the block number.
+	FiBlockTimePk = "_id"
+
+	// FiBlockTimeStamp is the name of the block time stamp field of the block time record.
+	FiBlockTimeStamp = "stamp"
+)
+
+// BlockTime represents the timing of a processed block.
+type BlockTime struct {
+	// Number represents the number of the block.
+	Number uint64 `bson:"_id"`
+
+	// Hash represents the hash of the block; records stored
+	// before the hash had been recorded do not have it.
+	Hash string `bson:"hash,omitempty"`
+
+	// Stamp represents the time the block was created.
+	Stamp time.Time `bson:"stamp"`
+
+	// Delta represents the time between the parent block and the block in milliseconds,
+	// if the parent block is known.
+	Delta *int64 `bson:"dlt,omitempty"`
+
+	// Ttf represents the observed time to finality of the block in milliseconds,
+	// the time between the block creation and its arrival to the API server.
+	// Only blocks received live are observed.
+	Ttf *int64 `bson:"ttf,omitempty"`
+}
+
+// BlockTimeStats represents statistics of the block time and the time to finality
+// over a window of recent blocks. The times are in seconds.
+type BlockTimeStats struct {
+	// Blocks represents the number of blocks the stats are calculated on.
+	Blocks int32
+
+	// AvgBlockTime represents the average time between blocks.
+	AvgBlockTime float64
+
+	// MedianBlockTime represents the median time between blocks.
+	MedianBlockTime float64
+
+	// P95BlockTime represents the 95th percentile of the time between blocks.
+	P95BlockTime float64
+
+	// TtfBlocks represents the number of blocks with the time to finality observed.
+	TtfBlocks int32
+
+	// AvgTtf represents the average observed time to finality.
+	AvgTtf float64
+
+	// MedianTtf represents the median observed time to finality.
+	MedianTtf float64
+
+	// P95Ttf represents the 95th percentile of the observed time to finality.
+	P95Ttf float64
+}
+
+// DailyBlockTime represents a daily aggregation of the block time and the time to finality.
+// The times are in milliseconds.
+type DailyBlockTime struct {
+	Day          string    `bson:"_id"`
+	Stamp        time.Time `bson:"stamp"`
+	Blocks       int64     `bson:"blocks"`
+	AvgBlockTime float64   `bson:"avg_dlt"`
+	MaxBlockTime int64     `bson:"max_dlt"`
+	AvgTtf       *float64  `bson:"avg_ttf"`
+	MaxTtf       *int64    `bson:"max_ttf"`
+}
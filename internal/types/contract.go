@@ -22,12 +22,19 @@ type Contract struct {
 	// TransactionHash represents the hash of the contract deployment transaction.
 	TransactionHash common.Hash `json:"tx"`
 
+	// Deployer represents the address of the account which deployed the contract,
+	// nil if not known.
+	Deployer *common.Address `json:"from,omitempty"`
+
 	// TimeStamp represents the unix timestamp of the contract deployment.
 	TimeStamp hexutil.Uint64 `json:"timestamp"`
 
 	// Name of the smart contract, if available.
 	Name string `json:"name"`
 
+	// Symbol of the token issued by the contract, if available.
+	Symbol string `json:"symbol,omitempty"`
+
 	// Smart contract version identifier, if available.
 	Version string `json:"ver,omitempty"`
 
@@ -62,26 +69,33 @@ type Contract struct {
 	// Validated represents the unix timestamp
 	//of the contract source validation against deployed byte code.
 	Validated *hexutil.Uint64 `json:"ok,omitempty" bson:"is_ok,omitempty"`
+
+	// StorageLayout represents the layout of the contract state variables
+	// in the contract storage, if known from the validated source code.
+	StorageLayout []ContractStorageVariable `json:"layout,omitempty"`
 }
 
 // BsonContract represents the contract data structure for BSON formatting.
 type BsonContract struct {
-	Address   string  `bson:"_id"`
-	Type      string  `bson:"type"`
-	Name      string  `bson:"name"`
-	Ordinal   uint64  `bson:"orx"`
-	Trx       string  `bson:"trx"`
-	Created   uint64  `bson:"ts"`
-	Version   string  `bson:"ver"`
-	Support   string  `bson:"sup"`
-	License   string  `bson:"lic"`
-	Compiler  string  `bson:"sol"`
-	IsOpt     bool    `bson:"is_opt"`
-	OptRuns   int32   `bson:"opt"`
-	Src       string  `bson:"src"`
-	Abi       string  `bson:"abi"`
-	SrcHash   *string `bson:"src_h"`
-	Validated *uint64 `bson:"val"`
+	Address   string                    `bson:"_id"`
+	Type      string                    `bson:"type"`
+	Name      string                    `bson:"name"`
+	Symbol    string                    `bson:"sym,omitempty"`
+	Ordinal   uint64                    `bson:"orx"`
+	Trx       string                    `bson:"trx"`
+	Deployer  *string                   `bson:"from"`
+	Created   uint64                    `bson:"ts"`
+	Version   string                    `bson:"ver"`
+	Support   string                    `bson:"sup"`
+	License   string                    `bson:"lic"`
+	Compiler  string                    `bson:"sol"`
+	IsOpt     bool                      `bson:"is_opt"`
+	OptRuns   int32                     `bson:"opt"`
+	Src       string                    `bson:"src"`
+	Abi       string                    `bson:"abi"`
+	SrcHash   *string                   `bson:"src_h"`
+	Validated *uint64                   `bson:"val"`
+	Layout    []ContractStorageVariable `bson:"layout,omitempty"`
 }
 
 // UnmarshalContract parses the JSON-encoded smart contract data.
@@ -108,6 +122,7 @@ func NewGenericContract(addr *common.Address, block *Block, trx *Transaction) *C
 		Type:            AccountTypeContract,
 		Address:         *addr,
 		TransactionHash: trx.Hash,
+		Deployer:        &trx.From,
 		TimeStamp:       block.TimeStamp,
 		Name:            "",
 		Version:         "",
@@ -179,6 +194,7 @@ func (sc *Contract) MarshalBSON() ([]byte, error) {
 		Address:  sc.Address.String(),
 		Type:     sc.Type,
 		Name:     sc.Name,
+		Symbol:   sc.Symbol,
 		Ordinal:  sc.Uid(),
 		Trx:      sc.TransactionHash.String(),
 		Created:  uint64(sc.TimeStamp),
@@ -190,11 +206,17 @@ func (sc *Contract) MarshalBSON() ([]byte, error) {
 		OptRuns:  sc.OptimizeRuns,
 		Src:      sc.SourceCode,
 		Abi:      sc.Abi,
+		Layout:   sc.StorageLayout,
 	}
 	// is validated?
 	if sc.Validated != nil {
 		row.Validated = (*uint64)(sc.Validated)
 	}
+	// do we know the deployer?
+	if sc.Deployer != nil {
+		val := sc.Deployer.String()
+		row.Deployer = &val
+	}
 	// do we have source code hash?
 	if sc.SourceCodeHash != nil {
 		val := sc.SourceCodeHash.String()
@@ -221,6 +243,7 @@ func (sc *Contract) UnmarshalBSON(data []byte) (err error) {
 	sc.Address = common.HexToAddress(row.Address)
 	sc.Type = row.Type
 	sc.Name = row.Name
+	sc.Symbol = row.Symbol
 	sc.TransactionHash = common.HexToHash(row.Trx)
 	sc.TimeStamp = hexutil.Uint64(row.Created)
 	sc.Version = row.Version
@@ -231,9 +254,14 @@ func (sc *Contract) UnmarshalBSON(data []byte) (err error) {
 	sc.OptimizeRuns = row.OptRuns
 	sc.SourceCode = row.Src
 	sc.Abi = row.Abi
+	sc.StorageLayout = row.Layout
 	if row.Validated != nil {
 		sc.Validated = (*hexutil.Uint64)(row.Validated)
 	}
+	if row.Deployer != nil {
+		val := common.HexToAddress(*row.Deployer)
+		sc.Deployer = &val
+	}
 	if row.SrcHash != nil {
 		val := common.HexToHash(*row.SrcHash)
 		sc.SourceCodeHash = &val
@@ -0,0 +1,35 @@
+// Package types implements different core types of the API.
+package types
+
+// ContractStorageVariable represents a state variable of a smart contract
+// as placed in the contract storage by the Solidity compiler.
+type ContractStorageVariable struct {
+	// Label represents the name of the state variable.
+	Label string `json:"label" bson:"label"`
+
+	// Type represents the Solidity type of the variable, i.e. "uint256" or "mapping(address => uint256)".
+	Type string `json:"type" bson:"type"`
+
+	// Slot represents the decimal index of the storage slot the variable starts at.
+	Slot string `json:"slot" bson:"slot"`
+
+	// Offset represents the byte offset of the variable inside the storage slot.
+	Offset int32 `json:"offset" bson:"offset"`
+
+	// Size represents the number of bytes used by the variable.
+	Size int32 `json:"size" bson:"size"`
+
+	// Encoding represents the way the variable is encoded in the storage;
+	// "inplace", "mapping", "dynamic_array", or "bytes".
+	Encoding string `json:"enc" bson:"enc"`
+}
+
+// ContractStorageValue represents a state variable of a smart contract
+// with its value decoded from the contract storage.
+type ContractStorageValue struct {
+	ContractStorageVariable
+
+	// Value represents the decoded value of the variable;
+	// nil if the value can not be decoded from its slot.
+	Value *string `json:"value"`
+}
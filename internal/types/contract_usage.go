@@ -0,0 +1,21 @@
+// Package types implements different core types of the API.
+package types
+
+import "time"
+
+// ContractDailyUsage represents a daily aggregation of the calls of a smart contract.
+type ContractDailyUsage struct {
+	Contract string    `bson:"contract"`
+	Day      string    `bson:"day"`
+	Stamp    time.Time `bson:"stamp"`
+	Calls    int64     `bson:"calls"`
+	Callers  int64     `bson:"callers"`
+	Gas      int64     `bson:"gas"`
+}
+
+// ContractGasRank represents a smart contract ranked by the gas consumed by its calls.
+type ContractGasRank struct {
+	Contract string `bson:"_id"`
+	Calls    int64  `bson:"calls"`
+	Gas      int64  `bson:"gas"`
+}
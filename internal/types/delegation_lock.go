@@ -1,7 +1,13 @@
 // Package types implements different core types of the API.
 package types
 
-import "github.com/ethereum/go-ethereum/common/hexutil"
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"time"
+)
 
 // DelegationLock represents a lock related to a delegation
 type DelegationLock struct {
@@ -10,3 +16,95 @@ type DelegationLock struct {
 	LockedUntil     hexutil.Uint64 `json:"endTime"`
 	Duration        hexutil.Uint64 `json:"duration"`
 }
+
+const (
+	FiDelegationLockEventPk        = "_id"
+	FiDelegationLockEventAddress   = "addr"
+	FiDelegationLockEventValidator = "to"
+	FiDelegationLockEventTimeStamp = "stamp"
+)
+
+const (
+	// DelegationLockEventLock represents the type of a delegation lock event locking the stake.
+	DelegationLockEventLock = "LOCK"
+
+	// DelegationLockEventUnlock represents the type of a delegation lock event unlocking the stake.
+	DelegationLockEventUnlock = "UNLOCK"
+)
+
+// DelegationLockEvent represents a lockup or an unlock of a delegation stake
+// recorded from the SFC contract events.
+type DelegationLockEvent struct {
+	Delegator     common.Address
+	ToValidatorId hexutil.Big
+	Type          string
+	Amount        hexutil.Big
+	Duration      hexutil.Uint64
+	Penalty       hexutil.Big
+	TimeStamp     hexutil.Uint64
+	Trx           common.Hash
+	LogIndex      uint
+}
+
+// BsonDelegationLockEvent represents BSON row structure of the delegation lock event.
+type BsonDelegationLockEvent struct {
+	ID        string    `bson:"_id"`
+	Addr      string    `bson:"addr"`
+	To        string    `bson:"to"`
+	Type      string    `bson:"type"`
+	Amount    string    `bson:"amount"`
+	Duration  uint64    `bson:"dur"`
+	Penalty   string    `bson:"penalty"`
+	TimeStamp time.Time `bson:"stamp"`
+	Trx       string    `bson:"trx"`
+	LogIndex  uint      `bson:"lix"`
+}
+
+// Pk returns a unique primary key of the delegation lock event.
+func (dle *DelegationLockEvent) Pk() string {
+	return fmt.Sprintf("%s.%d", dle.Trx.String(), dle.LogIndex)
+}
+
+// MarshalBSON creates a BSON representation of the delegation lock event.
+func (dle *DelegationLockEvent) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonDelegationLockEvent{
+		ID:        dle.Pk(),
+		Addr:      dle.Delegator.String(),
+		To:        dle.ToValidatorId.String(),
+		Type:      dle.Type,
+		Amount:    dle.Amount.String(),
+		Duration:  uint64(dle.Duration),
+		Penalty:   dle.Penalty.String(),
+		TimeStamp: time.Unix(int64(dle.TimeStamp), 0),
+		Trx:       dle.Trx.String(),
+		LogIndex:  dle.LogIndex,
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (dle *DelegationLockEvent) UnmarshalBSON(data []byte) (err error) {
+	// capture unmarshal issue
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode and unmarshal")
+		}
+	}()
+
+	// try to decode the BSON data
+	var row BsonDelegationLockEvent
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer values
+	dle.Delegator = common.HexToAddress(row.Addr)
+	dle.ToValidatorId = (hexutil.Big)(*hexutil.MustDecodeBig(row.To))
+	dle.Type = row.Type
+	dle.Amount = (hexutil.Big)(*hexutil.MustDecodeBig(row.Amount))
+	dle.Duration = hexutil.Uint64(row.Duration)
+	dle.Penalty = (hexutil.Big)(*hexutil.MustDecodeBig(row.Penalty))
+	dle.TimeStamp = hexutil.Uint64(row.TimeStamp.Unix())
+	dle.Trx = common.HexToHash(row.Trx)
+	dle.LogIndex = row.LogIndex
+	return nil
+}
@@ -0,0 +1,15 @@
+// Package types implements different core types of the API.
+package types
+
+import "github.com/ethereum/go-ethereum/common/hexutil"
+
+// EpochValidator represents the snapshot of a validator state
+// and rewards at the end of an epoch.
+type EpochValidator struct {
+	ValidatorId               hexutil.Big `json:"id"`
+	ReceivedStake             hexutil.Big `json:"stake"`
+	RewardPerToken            hexutil.Big `json:"rpt"`
+	AccumulatedRewardPerToken hexutil.Big `json:"arpt"`
+	OriginatedTxsFee          hexutil.Big `json:"fee"`
+	Uptime                    hexutil.Big `json:"uptime"`
+}
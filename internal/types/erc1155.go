@@ -3,6 +3,7 @@ package types
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // Erc1155Contract represents an ERC1155 token contract
@@ -11,3 +12,19 @@ type Erc1155Contract struct {
 	// Address represents the address of the ERC20 contract on chain.
 	Address common.Address `json:"address"`
 }
+
+// Erc1155Balance represents the amount of a token type
+// of an ERC1155 multi-token contract held by an account.
+type Erc1155Balance struct {
+	// Contract represents the address of the ERC1155 contract managing the token.
+	Contract common.Address `json:"contract"`
+
+	// TokenId represents the identifier of the token type inside the contract.
+	TokenId hexutil.Big `json:"tokenId"`
+
+	// Owner represents the account holding the tokens.
+	Owner common.Address `json:"owner"`
+
+	// Balance represents the amount of the tokens held by the account.
+	Balance hexutil.Big `json:"balance"`
+}
@@ -3,6 +3,7 @@ package types
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // Erc721Contract represents an ERC721 token contract
@@ -17,3 +18,16 @@ type Erc721Contract struct {
 	// Symbol represents an abbreviation for the token.
 	Symbol string `json:"symbol"`
 }
+
+// Erc721Token represents a single non-fungible token
+// managed by an ERC721 contract.
+type Erc721Token struct {
+	// Contract represents the address of the ERC721 contract managing the token.
+	Contract common.Address `json:"contract"`
+
+	// TokenId represents the identifier of the token inside the contract.
+	TokenId hexutil.Big `json:"tokenId"`
+
+	// Owner represents the current owner of the token.
+	Owner common.Address `json:"owner"`
+}
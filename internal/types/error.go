@@ -0,0 +1,69 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Codes of the API errors; clients get them in the GraphQL error extensions
+// and can branch on the failure reason instead of parsing the error message.
+const (
+	// ErrCodeNotFound represents an error of a requested entity which does not exist.
+	ErrCodeNotFound = "NOT_FOUND"
+
+	// ErrCodeRpcUnavailable represents an error of a blockchain node not being able to serve the request.
+	ErrCodeRpcUnavailable = "RPC_UNAVAILABLE"
+
+	// ErrCodeRateLimited represents an error of a client exceeding its request rate, or quota.
+	ErrCodeRateLimited = "RATE_LIMITED"
+
+	// ErrCodeInvalidCursor represents an error of a list cursor which can not be decoded.
+	ErrCodeInvalidCursor = "INVALID_CURSOR"
+
+	// ErrCodeInvalidInput represents an error of a malformed input value, e.g. an address, or a hash.
+	ErrCodeInvalidInput = "INVALID_INPUT"
+
+	// ErrCodeUnauthorized represents an error of a missing, or invalid authentication token.
+	ErrCodeUnauthorized = "UNAUTHORIZED"
+
+	// ErrCodeForbidden represents an error of an authenticated client without the role required.
+	ErrCodeForbidden = "FORBIDDEN"
+)
+
+// Error represents an API error with a machine-readable code.
+type Error struct {
+	Code    string
+	Message string
+}
+
+// NewError creates a new API error of the given code and message.
+func NewError(code string, msg string) *Error {
+	return &Error{Code: code, Message: msg}
+}
+
+// Errorf creates a new API error of the given code with the message formatted
+// according to the format specifier.
+func Errorf(code string, format string, args ...interface{}) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Error provides the message of the error.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Extensions provides the GraphQL error extensions containing the error code.
+func (e *Error) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.Code}
+}
+
+// ErrorCode provides the code of the given error, or an empty string
+// if the error does not carry any code.
+func ErrorCode(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+	return ""
+}
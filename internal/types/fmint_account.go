@@ -25,4 +25,8 @@ type FMintAccount struct {
 	// DebtValue represents the current debt value
 	// in ref. denomination (fUSD).
 	DebtValue hexutil.Big
+
+	// CollateralRatio4 represents the current ratio between the collateral
+	// and the debt value in 4 digits; nil if the account does not have any debt.
+	CollateralRatio4 *hexutil.Big
 }
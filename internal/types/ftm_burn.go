@@ -0,0 +1,91 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"math/big"
+	"time"
+)
+
+const (
+	// FiFtmBurnPk is the name of the primary key field of the burn record, the block number.
+	FiFtmBurnPk = "_id"
+
+	// FiFtmBurnValue is the name of the field of the burned value with reduced precision.
+	FiFtmBurnValue = "value"
+)
+
+// FtmBurnDecimalsCorrection is used to manipulate precision of a burned value,
+// so it can be stored in database as UINT64 without loosing too much data
+var FtmBurnDecimalsCorrection = new(big.Int).SetUint64(1000000000)
+
+// FtmBurn represents the native FTM tokens burned from the transaction fees of a block.
+type FtmBurn struct {
+	BlockNumber    hexutil.Uint64
+	BlockTimeStamp hexutil.Uint64
+	Amount         hexutil.Big
+	TxCount        uint32
+}
+
+// FtmBurnList represents a list of burn records sorted from the most recent block.
+type FtmBurnList struct {
+	// Collection keeps the actual list of burns.
+	Collection []*FtmBurn
+
+	// Total indicates total number of burn records in the whole collection.
+	Total uint64
+
+	// IsStart indicates there are no more recent burns.
+	IsStart bool
+
+	// IsEnd indicates there are no more older burns.
+	IsEnd bool
+}
+
+// BsonFtmBurn represents BSON row structure of the burn record.
+type BsonFtmBurn struct {
+	Block     uint64    `bson:"_id"`
+	TimeStamp time.Time `bson:"stamp"`
+	Amount    string    `bson:"amount"`
+	Value     uint64    `bson:"value"`
+	TxCount   uint32    `bson:"txs"`
+}
+
+// MarshalBSON creates a BSON representation of the burn record.
+func (burn *FtmBurn) MarshalBSON() ([]byte, error) {
+	// calculate the value to 9 digits (and 18 billions remain available)
+	val := new(big.Int).Div(burn.Amount.ToInt(), FtmBurnDecimalsCorrection)
+
+	return bson.Marshal(BsonFtmBurn{
+		Block:     uint64(burn.BlockNumber),
+		TimeStamp: time.Unix(int64(burn.BlockTimeStamp), 0),
+		Amount:    burn.Amount.String(),
+		Value:     val.Uint64(),
+		TxCount:   burn.TxCount,
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (burn *FtmBurn) UnmarshalBSON(data []byte) (err error) {
+	// capture unmarshal issue
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode and unmarshal")
+		}
+	}()
+
+	// try to decode the BSON data
+	var row BsonFtmBurn
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer values
+	burn.BlockNumber = hexutil.Uint64(row.Block)
+	burn.BlockTimeStamp = hexutil.Uint64(row.TimeStamp.Unix())
+	burn.Amount = (hexutil.Big)(*hexutil.MustDecodeBig(row.Amount))
+	burn.TxCount = row.TxCount
+	return nil
+}
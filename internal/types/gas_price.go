@@ -2,6 +2,8 @@
 package types
 
 import (
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"go.mongodb.org/mongo-driver/bson"
 	"time"
 )
@@ -47,6 +49,28 @@ type GasPrice struct {
 	Average float64 `json:"average"`
 }
 
+// GasPriceSuggestion represents a set of gas price suggestions
+// calculated from gas prices of recent transactions.
+type GasPriceSuggestion struct {
+	Slow     hexutil.Big    `json:"slow"`
+	Standard hexutil.Big    `json:"standard"`
+	Fast     hexutil.Big    `json:"fast"`
+	Samples  int32          `json:"samples"`
+	Updated  hexutil.Uint64 `json:"updated"`
+}
+
+// UnmarshalGasPriceSuggestion parses the JSON-encoded gas price suggestion data.
+func UnmarshalGasPriceSuggestion(data []byte) (*GasPriceSuggestion, error) {
+	var gps GasPriceSuggestion
+	err := json.Unmarshal(data, &gps)
+	return &gps, err
+}
+
+// Marshal returns the JSON encoding of gas price suggestion.
+func (gps *GasPriceSuggestion) Marshal() ([]byte, error) {
+	return json.Marshal(gps)
+}
+
 // GasPricePeriod represents an data set of interval of gas price
 // estimation provided by the Opera node.
 type GasPricePeriod struct {
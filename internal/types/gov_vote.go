@@ -2,8 +2,18 @@
 package types
 
 import (
+	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	FiGovVotePk         = "_id"
+	FiGovVoteGovernance = "gov"
+	FiGovVoteProposal   = "prop"
+	FiGovVoteFrom       = "from"
+	FiGovVoteTimeStamp  = "ts"
 )
 
 // GovernanceVote represents a vote in the Governance Proposal.
@@ -26,4 +36,98 @@ type GovernanceVote struct {
 	// Choices represents the list of opinions on the Proposal options the vote
 	// presented.
 	Choices []hexutil.Uint64
+
+	// Voted represents the time stamp of the vote; zero if not known.
+	Voted hexutil.Uint64
+
+	// TrxHash represents the hash of the transaction placing the vote; nil if not known.
+	TrxHash *common.Hash
+}
+
+// BsonGovernanceVote represents the BSON i/o struct for a governance vote.
+type BsonGovernanceVote struct {
+	ID          string   `bson:"_id"`
+	Governance  string   `bson:"gov"`
+	Proposal    string   `bson:"prop"`
+	From        string   `bson:"from"`
+	DelegatedTo *string  `bson:"to"`
+	Weight      string   `bson:"weight"`
+	Choices     []uint64 `bson:"choices"`
+	TimeStamp   uint64   `bson:"ts"`
+	Trx         *string  `bson:"trx"`
+}
+
+// GovernanceVotePk generates unique identifier of a governance vote
+// of the given voter and delegation on the given proposal.
+func GovernanceVotePk(gov *common.Address, prop *hexutil.Big, from *common.Address, delegatedTo *common.Address) string {
+	var to string
+	if delegatedTo != nil {
+		to = delegatedTo.String()
+	}
+	return fmt.Sprintf("%s_%s_%s_%s", gov.String(), prop.String(), from.String(), to)
+}
+
+// Pk generates unique identifier of the governance vote.
+func (gv *GovernanceVote) Pk() string {
+	return GovernanceVotePk(&gv.GovernanceId, &gv.ProposalId, &gv.From, gv.DelegatedTo)
+}
+
+// MarshalBSON creates a BSON representation of the governance vote record.
+func (gv *GovernanceVote) MarshalBSON() ([]byte, error) {
+	row := BsonGovernanceVote{
+		ID:         gv.Pk(),
+		Governance: gv.GovernanceId.String(),
+		Proposal:   gv.ProposalId.String(),
+		From:       gv.From.String(),
+		Weight:     gv.Weight.String(),
+		Choices:    make([]uint64, len(gv.Choices)),
+		TimeStamp:  uint64(gv.Voted),
+	}
+	for i, ch := range gv.Choices {
+		row.Choices[i] = uint64(ch)
+	}
+	if gv.DelegatedTo != nil {
+		to := gv.DelegatedTo.String()
+		row.DelegatedTo = &to
+	}
+	if gv.TrxHash != nil {
+		trx := gv.TrxHash.String()
+		row.Trx = &trx
+	}
+	return bson.Marshal(row)
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (gv *GovernanceVote) UnmarshalBSON(data []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode governance vote")
+		}
+	}()
+
+	// try to decode the BSON data
+	var row BsonGovernanceVote
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// copy the data
+	gv.GovernanceId = common.HexToAddress(row.Governance)
+	gv.ProposalId = (hexutil.Big)(*hexutil.MustDecodeBig(row.Proposal))
+	gv.From = common.HexToAddress(row.From)
+	gv.Weight = (hexutil.Big)(*hexutil.MustDecodeBig(row.Weight))
+	gv.Voted = hexutil.Uint64(row.TimeStamp)
+	gv.Choices = make([]hexutil.Uint64, len(row.Choices))
+	for i, ch := range row.Choices {
+		gv.Choices[i] = hexutil.Uint64(ch)
+	}
+	if row.DelegatedTo != nil {
+		to := common.HexToAddress(*row.DelegatedTo)
+		gv.DelegatedTo = &to
+	}
+	if row.Trx != nil {
+		trx := common.HexToHash(*row.Trx)
+		gv.TrxHash = &trx
+	}
+	return nil
 }
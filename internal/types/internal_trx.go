@@ -0,0 +1,139 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	FiInternalTrxPk           = "_id"
+	FiInternalTrxHash         = "trx"
+	FiInternalTrxBlock        = "blk"
+	FiInternalTrxSender       = "from"
+	FiInternalTrxRecipient    = "to"
+	FiInternalTrxTraceAddress = "tad"
+	FiInternalTrxOrdinal      = "ord"
+)
+
+// InternalTransaction represents a call executed inside a transaction
+// by a contract, as reported by the transaction trace.
+type InternalTransaction struct {
+	// TrxHash represents the hash of the parent transaction.
+	TrxHash common.Hash `json:"trx"`
+
+	// BlockNumber represents the number of the block of the parent transaction.
+	BlockNumber hexutil.Uint64 `json:"blk"`
+
+	// TraceAddress represents the position of the call in the call tree, i.e. "0_1".
+	TraceAddress string `json:"tad"`
+
+	// Ordinal represents the position of the call in the trace of the parent transaction,
+	// the calls are ordered the way they were executed.
+	Ordinal uint32 `json:"ord"`
+
+	// Type represents the type of the trace, i.e. "call", "create", "suicide".
+	Type string `json:"type"`
+
+	// CallType represents the type of call, i.e. "call", "delegatecall", "staticcall".
+	CallType *string `json:"ct,omitempty"`
+
+	// From represents the address of the caller.
+	From common.Address `json:"from"`
+
+	// To represents the address of the callee; nil on failed contract creation.
+	To *common.Address `json:"to,omitempty"`
+
+	// Value represents the amount of native tokens transferred in the call.
+	Value hexutil.Big `json:"value"`
+
+	// Gas represents the gas provided to the call.
+	Gas hexutil.Uint64 `json:"gas"`
+
+	// GasUsed represents the gas consumed by the call.
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+
+	// Error represents the error of the call, if it failed.
+	Error *string `json:"error,omitempty"`
+}
+
+// BsonInternalTransaction represents the BSON i/o struct for an internal transaction.
+type BsonInternalTransaction struct {
+	ID       string  `bson:"_id"`
+	Trx      string  `bson:"trx"`
+	Block    uint64  `bson:"blk"`
+	TraceAdr string  `bson:"tad"`
+	Ordinal  uint32  `bson:"ord"`
+	Type     string  `bson:"type"`
+	CallType *string `bson:"ct"`
+	From     string  `bson:"from"`
+	To       *string `bson:"to"`
+	Amo      string  `bson:"amo"`
+	Gas      uint64  `bson:"gas"`
+	GasUsed  uint64  `bson:"gas_use"`
+	Error    *string `bson:"err"`
+}
+
+// Pk generates unique identifier of the internal transaction
+// from the parent transaction hash and the trace address.
+func (itx *InternalTransaction) Pk() string {
+	return fmt.Sprintf("%s_%s", itx.TrxHash.String(), itx.TraceAddress)
+}
+
+// MarshalBSON creates a BSON representation of the internal transaction record.
+func (itx *InternalTransaction) MarshalBSON() ([]byte, error) {
+	row := BsonInternalTransaction{
+		ID:       itx.Pk(),
+		Trx:      itx.TrxHash.String(),
+		Block:    uint64(itx.BlockNumber),
+		TraceAdr: itx.TraceAddress,
+		Ordinal:  itx.Ordinal,
+		Type:     itx.Type,
+		CallType: itx.CallType,
+		From:     itx.From.String(),
+		Amo:      itx.Value.String(),
+		Gas:      uint64(itx.Gas),
+		GasUsed:  uint64(itx.GasUsed),
+		Error:    itx.Error,
+	}
+	if itx.To != nil {
+		to := itx.To.String()
+		row.To = &to
+	}
+	return bson.Marshal(row)
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (itx *InternalTransaction) UnmarshalBSON(data []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode internal transaction")
+		}
+	}()
+
+	// try to decode the BSON data
+	var row BsonInternalTransaction
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// copy the data
+	itx.TrxHash = common.HexToHash(row.Trx)
+	itx.BlockNumber = hexutil.Uint64(row.Block)
+	itx.TraceAddress = row.TraceAdr
+	itx.Ordinal = row.Ordinal
+	itx.Type = row.Type
+	itx.CallType = row.CallType
+	itx.From = common.HexToAddress(row.From)
+	itx.Value = (hexutil.Big)(*hexutil.MustDecodeBig(row.Amo))
+	itx.Gas = hexutil.Uint64(row.Gas)
+	itx.GasUsed = hexutil.Uint64(row.GasUsed)
+	itx.Error = row.Error
+	if row.To != nil {
+		to := common.HexToAddress(*row.To)
+		itx.To = &to
+	}
+	return nil
+}
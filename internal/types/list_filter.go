@@ -0,0 +1,69 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"time"
+)
+
+// DelegationFilter represents a set of conditions of a delegation list.
+// Empty conditions are not applied.
+type DelegationFilter struct {
+	// Address is the delegator address.
+	Address *common.Address
+
+	// ToValidator is the ID of the validator receiving the delegation.
+	ToValidator *hexutil.Big
+
+	// ActiveOnly limits the list to delegations of a non-zero value.
+	ActiveOnly bool
+}
+
+// TokenTransactionFilter represents a set of conditions of a token transaction list.
+// Empty conditions are not applied.
+type TokenTransactionFilter struct {
+	// TokenType is the type of the token (ERC20/ERC721/ERC1155...).
+	TokenType string
+
+	// Token is the address of the token contract.
+	Token *common.Address
+
+	// TokenId is the ID of the token of a multi-token contract.
+	TokenId *hexutil.Big
+
+	// Account is the sender, or the recipient of the transactions.
+	Account *common.Address
+
+	// Type is the type of the token transaction.
+	Type *int32
+}
+
+// RewardClaimFilter represents a set of conditions of a reward claim list.
+// Empty conditions are not applied.
+type RewardClaimFilter struct {
+	// Address is the delegator address.
+	Address *common.Address
+
+	// ToValidator is the ID of the validator the rewards were claimed from.
+	ToValidator *hexutil.Big
+
+	// Since is the earliest time of the claims.
+	Since *time.Time
+
+	// Until is the latest time of the claims.
+	Until *time.Time
+}
+
+// WithdrawalFilter represents a set of conditions of a withdraw request list.
+// Empty conditions are not applied.
+type WithdrawalFilter struct {
+	// Address is the delegator address.
+	Address *common.Address
+
+	// ToValidator is the ID of the validator the stake is withdrawn from.
+	ToValidator *hexutil.Big
+
+	// PendingOnly limits the list to requests not finalized yet.
+	PendingOnly bool
+}
@@ -0,0 +1,30 @@
+// Package types implements different core types of the API.
+package types
+
+import "github.com/ethereum/go-ethereum/common/hexutil"
+
+// NetworkLoad represents the recent load of the network
+// calculated over a sliding window of the most recent blocks.
+type NetworkLoad struct {
+	// Blocks represents the number of blocks in the window.
+	Blocks int32
+
+	// CurrentTps represents the transactions per second rate on the most recent blocks.
+	CurrentTps float64
+
+	// AverageTps represents the transactions per second rate across the whole window.
+	AverageTps float64
+
+	// GasUtilization represents the ratio of the gas used to the gas limit
+	// of the most recent block.
+	GasUtilization float64
+
+	// AverageGasUtilization represents the average gas utilization of blocks in the window.
+	AverageGasUtilization float64
+
+	// PendingTransactions represents the number of transactions pending in the node pool.
+	PendingTransactions hexutil.Uint64
+
+	// QueuedTransactions represents the number of transactions queued in the node pool.
+	QueuedTransactions hexutil.Uint64
+}
@@ -0,0 +1,41 @@
+// Package types implements different core types of the API.
+package types
+
+import "time"
+
+// NftMetadata represents the sanitized metadata JSON of an NFT token.
+type NftMetadata struct {
+	// Id represents the identifier of the token in the form contract/tokenId.
+	Id string `bson:"_id"`
+
+	// Uri represents the token URI the metadata were downloaded from.
+	Uri string `bson:"uri"`
+
+	// Name represents the name of the token.
+	Name string `bson:"name"`
+
+	// Description represents the description of the token.
+	Description string `bson:"desc"`
+
+	// Image represents the URL of the token image.
+	Image string `bson:"image"`
+
+	// ExternalUrl represents the URL of the token page on the issuer site.
+	ExternalUrl string `bson:"ext"`
+
+	// Attributes represents the list of the token traits.
+	Attributes []NftAttribute `bson:"attr"`
+
+	// Error represents the reason of a failed download, if any.
+	Error string `bson:"err,omitempty"`
+
+	// Fetched represents the time of the last download attempt.
+	Fetched time.Time `bson:"fetched"`
+}
+
+// NftAttribute represents a single trait of an NFT token.
+type NftAttribute struct {
+	TraitType   string `bson:"type"`
+	Value       string `bson:"value"`
+	DisplayType string `bson:"display,omitempty"`
+}
@@ -21,6 +21,7 @@ type Price struct {
 	Supply        float64        `json:"SUPPLY"`
 	MarketCap     float64        `json:"MKTCAP"`
 	LastUpdate    hexutil.Uint64 `json:"LASTUPDATE"`
+	IsStale       bool           `json:"-"`
 }
 
 // UnmarshalPrice parses the JSON-encoded price data.
@@ -0,0 +1,48 @@
+// Package types implements different core types of the API.
+package types
+
+import "time"
+
+// ScannerStatus represents the state of the blockchain data scanner.
+type ScannerStatus struct {
+	// LastProcessedBlock is the number of the last block fully processed by the scanner.
+	LastProcessedBlock uint64
+
+	// ChainHead is the number of the most recent block observed on the chain.
+	ChainHead uint64
+
+	// IsIdle indicates the scanner caught up with the chain and follows new blocks.
+	IsIdle bool
+
+	// BlocksPerSecond is the recent processing rate of the scanner.
+	BlocksPerSecond float64
+
+	// IsReindexing indicates a chain reindex is in progress.
+	IsReindexing bool
+
+	// ReindexFrom is the first block of the recent chain reindex, if any.
+	ReindexFrom uint64
+
+	// ReindexTo is the last block of the recent chain reindex, if any.
+	ReindexTo uint64
+
+	// ReindexNext is the next block to be replayed by the recent chain reindex, if any.
+	ReindexNext uint64
+}
+
+// BlocksBehind provides the number of blocks the processing is behind the chain head.
+func (st *ScannerStatus) BlocksBehind() uint64 {
+	if st.ChainHead <= st.LastProcessedBlock {
+		return 0
+	}
+	return st.ChainHead - st.LastProcessedBlock
+}
+
+// CatchUpTime estimates the time the scanner needs to process the blocks behind the chain head.
+// Zero is returned if the processing rate is not known yet.
+func (st *ScannerStatus) CatchUpTime() time.Duration {
+	if st.BlocksPerSecond <= 0 {
+		return 0
+	}
+	return time.Duration(float64(st.BlocksBehind()) / st.BlocksPerSecond * float64(time.Second))
+}
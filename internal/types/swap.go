@@ -99,11 +99,12 @@ type DefiTimePrice struct {
 	Average float64 `json:"average" bson:"avg"`
 }
 
-// Swap types
+// Swap types; trades stored before the SwapNormal type was introduced are typed as SwapMint.
 const (
 	SwapMint = iota
 	SwapBurn
 	SwapSync
+	SwapNormal
 )
 
 // DefiTimeReserve represents a reserve for uniswap pair in history
@@ -82,6 +82,9 @@ type Transaction struct {
 
 	// Logs represents a list of log records created along with the transaction
 	Logs []retypes.Log `json:"logs"`
+
+	// RevertReason represents the reason of a failed transaction, if known.
+	RevertReason *string `json:"revertReason,omitempty"`
 }
 
 // BsonLog represents the transaction log record data structure for BSON formatting.
@@ -114,6 +117,7 @@ type BsonTransaction struct {
 	Nonce      int64     `bson:"nonce"`
 	Contract   *string   `bson:"contr"`
 	Status     uint64    `bson:"stat"`
+	Reason     *string   `bson:"err,omitempty"`
 	Stamp      time.Time `bson:"stamp"`
 	Logs       []BsonLog `bson:"logs"`
 }
@@ -188,8 +192,9 @@ func (trx *Transaction) MarshalBSON() ([]byte, error) {
 		}
 		pom.UsedGas = &gu
 
-		// status
+		// status and the reason of failure
 		pom.Status = uint64(*trx.Status)
+		pom.Reason = trx.RevertReason
 	}
 
 	// recipient
@@ -245,7 +250,6 @@ func (trx *Transaction) UnmarshalBSON(data []byte) (err error) {
 	trx.Gas = hexutil.Uint64(row.Gas)
 	trx.GasPrice = (hexutil.Big)(*hexutil.MustDecodeBig(row.GasPrice))
 	trx.Nonce = hexutil.Uint64(row.Nonce)
-	trx.Status = (*hexutil.Uint64)(&row.Status)
 	trx.InputData = row.Input
 	trx.LargeInput = row.LargeInput
 	trx.TimeStamp = row.Stamp
@@ -279,6 +283,10 @@ func (trx *Transaction) UnmarshalBSON(data []byte) (err error) {
 		// cumulative gas
 		gc := hexutil.Uint64(*row.CumGas)
 		trx.CumulativeGasUsed = &gc
+
+		// status is known for mined transactions only
+		trx.Status = (*hexutil.Uint64)(&row.Status)
+		trx.RevertReason = row.Reason
 	}
 
 	// recipient
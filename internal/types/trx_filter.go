@@ -0,0 +1,45 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+)
+
+const (
+	// TransactionDirectionAny represents transactions of both directions.
+	TransactionDirectionAny = iota
+
+	// TransactionDirectionIn represents transactions received by an account.
+	TransactionDirectionIn
+
+	// TransactionDirectionOut represents transactions sent by an account.
+	TransactionDirectionOut
+)
+
+const (
+	// TransactionStatusFailed represents the receipt status of a failed transaction.
+	TransactionStatusFailed = 0
+
+	// TransactionStatusSuccess represents the receipt status of a successful transaction.
+	TransactionStatusSuccess = 1
+)
+
+// TransactionFilter represents a set of conditions of a transaction list.
+// Empty conditions are not applied.
+type TransactionFilter struct {
+	// Direction of the transactions relative to the listed account.
+	Direction int
+
+	// MinValue is the lowest value of the listed transactions.
+	MinValue *big.Int
+
+	// MaxValue is the highest value of the listed transactions.
+	MaxValue *big.Int
+
+	// Status is the receipt status of the listed transactions.
+	Status *uint64
+
+	// Counterpart is the address on the other side of the listed transactions.
+	Counterpart *common.Address
+}
@@ -11,4 +11,6 @@ type DailyTrxVolume struct {
 	Counter        int64     `bson:"value"`
 	AmountAdjusted int64     `bson:"volume"`
 	Gas            int64     `bson:"gas"`
+	Fee            int64     `bson:"fee"`
+	ActiveAccounts int64     `bson:"active"`
 }
@@ -0,0 +1,32 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// UnsignedTransaction represents a transaction prepared by the API server
+// for the client to sign and send to the blockchain.
+type UnsignedTransaction struct {
+	// From represents the address of the sender expected to sign the transaction.
+	From common.Address
+
+	// To represents the address of the called contract.
+	To common.Address
+
+	// Nonce represents the current nonce of the sender.
+	Nonce hexutil.Uint64
+
+	// Value represents the amount of native tokens sent with the transaction in WEI.
+	Value hexutil.Big
+
+	// Gas represents the estimated amount of gas the transaction consumes.
+	Gas hexutil.Uint64
+
+	// GasPrice represents the suggested gas price in WEI.
+	GasPrice hexutil.Big
+
+	// Data represents the encoded contract call data.
+	Data hexutil.Bytes
+}
@@ -0,0 +1,51 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"encoding/hex"
+	"github.com/ethereum/go-ethereum/common"
+	"strings"
+)
+
+// ParseAddress validates and decodes the given hex encoded address.
+// The address must be 0x prefixed and 20 bytes long; if it's given in mixed case,
+// the EIP-55 checksum must match. All lower, or all upper case addresses are accepted as they are.
+func ParseAddress(s string) (common.Address, error) {
+	b, err := parseHex(s, common.AddressLength, "address")
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	adr := common.BytesToAddress(b)
+	digits := s[2:]
+	if digits != strings.ToLower(digits) && digits != strings.ToUpper(digits) && adr.Hex() != "0x"+digits {
+		return common.Address{}, Errorf(ErrCodeInvalidInput, "invalid address %s; checksum mismatch", s)
+	}
+	return adr, nil
+}
+
+// ParseHash validates and decodes the given hex encoded 0x prefixed 32 bytes hash.
+func ParseHash(s string) (common.Hash, error) {
+	b, err := parseHex(s, common.HashLength, "hash")
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(b), nil
+}
+
+// parseHex decodes the given 0x prefixed hex string of the expected length in bytes.
+// The name identifies the kind of the value in the error message.
+func parseHex(s string, length int, name string) ([]byte, error) {
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return nil, Errorf(ErrCodeInvalidInput, "invalid %s %q; 0x prefix expected", name, s)
+	}
+	if len(s)-2 != 2*length {
+		return nil, Errorf(ErrCodeInvalidInput, "invalid %s %q; %d hex digits expected, %d given", name, s, 2*length, len(s)-2)
+	}
+
+	b, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return nil, Errorf(ErrCodeInvalidInput, "invalid %s %q; not a hex number", name, s)
+	}
+	return b, nil
+}
@@ -0,0 +1,124 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"go.mongodb.org/mongo-driver/bson"
+	"math/big"
+)
+
+const (
+	// FiValidatorPerfPk is the name of the primary key field of the validator epoch performance.
+	FiValidatorPerfPk = "_id"
+
+	// FiValidatorPerfValidator is the name of the field of the validator id.
+	FiValidatorPerfValidator = "val"
+
+	// FiValidatorPerfEpoch is the name of the field of the epoch id.
+	FiValidatorPerfEpoch = "epoch"
+)
+
+// ValidatorPerfFeeDecimalsCorrection is used to manipulate precision of the fee values,
+// so they can be stored in database as INT64 and aggregated without loosing too much data.
+var ValidatorPerfFeeDecimalsCorrection = new(big.Int).SetUint64(1000000000)
+
+// ValidatorEpochPerformance represents the performance of a validator
+// within a sealed epoch as recorded by the SFC epoch snapshot.
+type ValidatorEpochPerformance struct {
+	ValidatorId   hexutil.Big
+	Epoch         hexutil.Uint64
+	EpochDuration uint64
+	Uptime        uint64
+	MissedBlocks  uint64
+	OriginatedFee hexutil.Big
+	EpochFee      hexutil.Big
+}
+
+// ValidatorPerformance represents the performance of a validator
+// aggregated over a range of sealed epochs.
+type ValidatorPerformance struct {
+	ValidatorId hexutil.Big
+
+	// FromEpoch is the first epoch of the range the validator participated in.
+	FromEpoch hexutil.Uint64
+
+	// Epochs is the number of epochs the validator participated in.
+	Epochs uint64
+
+	// Duration is the total duration of the epochs in seconds.
+	Duration uint64
+
+	// Uptime is the total time the validator was online in seconds.
+	Uptime uint64
+
+	// MissedBlocks is the highest number of blocks missed in a row.
+	MissedBlocks uint64
+
+	// OriginatedFee is the amount of fees originated by the validator.
+	OriginatedFee *big.Int
+
+	// EpochFee is the amount of fees collected by the network in the epochs.
+	EpochFee *big.Int
+}
+
+// BsonValidatorEpochPerformance represents BSON row structure of the validator epoch performance.
+type BsonValidatorEpochPerformance struct {
+	ID            string `bson:"_id"`
+	Validator     int64  `bson:"val"`
+	Epoch         int64  `bson:"epoch"`
+	Duration      int64  `bson:"dur"`
+	Uptime        int64  `bson:"up"`
+	MissedBlocks  int64  `bson:"missed"`
+	OriginatedFee string `bson:"fee"`
+	FeeValue      int64  `bson:"fee_val"`
+	EpochFee      string `bson:"ep_fee"`
+	EpochFeeValue int64  `bson:"ep_fee_val"`
+}
+
+// Pk returns a unique primary key of the validator epoch performance.
+func (vep *ValidatorEpochPerformance) Pk() string {
+	return fmt.Sprintf("%d.%d", uint64(vep.Epoch), vep.ValidatorId.ToInt().Uint64())
+}
+
+// MarshalBSON creates a BSON representation of the validator epoch performance.
+func (vep *ValidatorEpochPerformance) MarshalBSON() ([]byte, error) {
+	return bson.Marshal(BsonValidatorEpochPerformance{
+		ID:            vep.Pk(),
+		Validator:     vep.ValidatorId.ToInt().Int64(),
+		Epoch:         int64(vep.Epoch),
+		Duration:      int64(vep.EpochDuration),
+		Uptime:        int64(vep.Uptime),
+		MissedBlocks:  int64(vep.MissedBlocks),
+		OriginatedFee: vep.OriginatedFee.String(),
+		FeeValue:      new(big.Int).Div(vep.OriginatedFee.ToInt(), ValidatorPerfFeeDecimalsCorrection).Int64(),
+		EpochFee:      vep.EpochFee.String(),
+		EpochFeeValue: new(big.Int).Div(vep.EpochFee.ToInt(), ValidatorPerfFeeDecimalsCorrection).Int64(),
+	})
+}
+
+// UnmarshalBSON updates the value from BSON source.
+func (vep *ValidatorEpochPerformance) UnmarshalBSON(data []byte) (err error) {
+	// capture unmarshal issue
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("can not decode and unmarshal")
+		}
+	}()
+
+	// try to decode the BSON data
+	var row BsonValidatorEpochPerformance
+	if err = bson.Unmarshal(data, &row); err != nil {
+		return err
+	}
+
+	// transfer values
+	vep.ValidatorId = (hexutil.Big)(*big.NewInt(row.Validator))
+	vep.Epoch = hexutil.Uint64(row.Epoch)
+	vep.EpochDuration = uint64(row.Duration)
+	vep.Uptime = uint64(row.Uptime)
+	vep.MissedBlocks = uint64(row.MissedBlocks)
+	vep.OriginatedFee = (hexutil.Big)(*hexutil.MustDecodeBig(row.OriginatedFee))
+	vep.EpochFee = (hexutil.Big)(*hexutil.MustDecodeBig(row.EpochFee))
+	return nil
+}
@@ -0,0 +1,90 @@
+// Package types implements different core types of the API.
+package types
+
+import (
+	"time"
+)
+
+const (
+	FiWatchPk      = "_id"
+	FiWatchOwner   = "owner"
+	FiWatchCreated = "created"
+)
+
+const (
+	// WatchEventIncomingTrx represents a transaction received by the watched address.
+	WatchEventIncomingTrx = "incoming_trx"
+
+	// WatchEventOutgoingTrx represents a transaction sent by the watched address.
+	WatchEventOutgoingTrx = "outgoing_trx"
+
+	// WatchEventTokenTransfer represents a token transfer from, or to the watched address.
+	WatchEventTokenTransfer = "token_transfer"
+
+	// WatchEventDelegation represents a change of a delegation of the watched address.
+	WatchEventDelegation = "delegation"
+)
+
+// Watch represents a registration of a webhook notified about events of a watched address.
+type Watch struct {
+	// Id represents the unique identifier of the watch.
+	Id string `bson:"_id"`
+
+	// Owner represents the API key hash of the client owning the watch.
+	Owner string `bson:"owner"`
+
+	// Address represents the watched address.
+	Address string `bson:"addr"`
+
+	// Url represents the webhook URL receiving the events.
+	Url string `bson:"url"`
+
+	// Secret represents the key of the HMAC signature of the webhook payload.
+	Secret string `bson:"secret"`
+
+	// Events represents the list of event types the webhook is notified about.
+	Events []string `bson:"events"`
+
+	// Created represents the time the watch was registered.
+	Created time.Time `bson:"created"`
+}
+
+// WatchNotification represents a single event of a watched address sent to the webhook.
+type WatchNotification struct {
+	// Id represents the unique identifier of the event.
+	Id string `json:"id"`
+
+	// Watch represents the identifier of the watch.
+	Watch string `json:"watch"`
+
+	// Event represents the type of the event.
+	Event string `json:"event"`
+
+	// Address represents the watched address.
+	Address string `json:"address"`
+
+	// Transaction represents the hash of the transaction the event comes from.
+	Transaction string `json:"transaction"`
+
+	// Block represents the number of the block of the transaction.
+	Block uint64 `json:"block"`
+
+	// TimeStamp represents the UNIX time of the block.
+	TimeStamp int64 `json:"timestamp"`
+
+	// Contract represents the address of the contract emitting the event log, if any.
+	Contract *string `json:"contract,omitempty"`
+
+	// LogIndex represents the index of the event log in the block, if any.
+	LogIndex *uint `json:"logIndex,omitempty"`
+}
+
+// Wants checks if the watch should be notified about the given type of event.
+func (w *Watch) Wants(event string) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}